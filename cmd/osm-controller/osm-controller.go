@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
@@ -66,6 +68,21 @@ var (
 
 	injectorConfig injector.Config
 
+	// Path to a file holding the Envoy bootstrap config template, e.g. mounted from a ConfigMap
+	envoyBootstrapTemplateFile string
+
+	// Path to a file holding the JSON list of additional sidecars injected together with the Envoy sidecar
+	extraSidecarsFile string
+
+	// Path to a file holding the JSON topology spread constraints and affinity merged into the spec of the injected pods
+	podSchedulingFile string
+
+	// Directory the xDS config computed for each proxy is persisted to, the period following a restart during which
+	// the persisted config is served to connecting proxies, and the maximum number of proxies with persisted config
+	xdsSnapshotDir            string
+	xdsSnapshotRecoveryPeriod time.Duration
+	xdsSnapshotMaxProxies     int
+
 	// feature flag options
 	optionalFeatures featureflags.OptionalFeatures
 
@@ -106,6 +123,31 @@ func init() {
 	flags.IntVar(&injectorConfig.ListenPort, "webhook-port", constants.InjectorWebhookPort, "Webhook port for sidecar-injector")
 	flags.StringVar(&injectorConfig.InitContainerImage, "init-container-image", "", "InitContainer image")
 	flags.StringVar(&injectorConfig.SidecarImage, "sidecar-image", "", "Sidecar proxy Container image")
+	flags.StringVar(&injectorConfig.InitContainerName, "init-container-name", constants.InitContainerName, "Name of the injected InitContainer")
+	flags.StringVar(&injectorConfig.SidecarContainerName, "sidecar-container-name", constants.EnvoyContainerName, "Name of the injected sidecar proxy Container")
+	flags.StringSliceVar(&injectorConfig.ServiceAccountAllowList, "injection-service-account-allowlist", nil, "Comma separated list of service account names whose pods are allowed sidecar injection; all service accounts are allowed when not set")
+	flags.StringSliceVar(&injectorConfig.ServiceAccountDenyList, "injection-service-account-denylist", nil, "Comma separated list of service account names whose pods are never injected with a sidecar")
+	flags.StringSliceVar(&injectorConfig.OwnerKindAllowList, "injection-owner-kind-allowlist", nil, "Comma separated list of controller kinds, such as ReplicaSet,StatefulSet, whose pods are allowed sidecar injection; pods of all controllers and standalone pods are allowed when not set")
+	flags.StringVar(&injectorConfig.InjectionLabelSelector, "injection-label-selector", "", "Label selector, e.g. 'app in (bookstore,bookbuyer)', the pods must match to be injected with a sidecar, overridden by the openservicemesh.io/sidecar-injection-selector annotation of their namespace; all pods match when not set")
+	flags.BoolVar(&injectorConfig.NativeSidecar, "enable-native-sidecar", false, "Inject the sidecar proxy as a native sidecar, an init container restarted always, on clusters supporting it, so that it starts before and stops after the app containers")
+	flags.StringVar(&injectorConfig.SidecarTerminationMessagePath, "sidecar-termination-message-path", "", "Path of the file the sidecar proxy's termination message is read from; the Kubernetes default is used when not set")
+	flags.StringVar((*string)(&injectorConfig.SidecarTerminationMessagePolicy), "sidecar-termination-message-policy", "", "Termination message policy of the sidecar proxy, File or FallbackToLogsOnError; the Kubernetes default is used when not set")
+	flags.DurationVar(&injectorConfig.BootstrapCertIssuanceTimeout, "bootstrap-cert-issuance-timeout", 5*time.Second, "Time an admission request waits for the bootstrap certificate of the sidecar proxy to be issued when no pre-issued certificate is available")
+	flags.StringVar(&injectorConfig.SidecarCABundleConfigMap, "sidecar-ca-bundle-configmap", "", "Name of a ConfigMap in the OSM namespace holding, under the 'ca-bundle.pem' key, additional CA certificates the sidecar proxy trusts for TLS connections to destinations outside the mesh")
+	flags.BoolVar(&injectorConfig.SidecarDrainOnTermination, "sidecar-drain-on-termination", false, "Enable graceful draining of the sidecar proxy on pod termination: the proxy fails its readiness, stops accepting new inbound connections, then drains in-flight ones")
+	flags.DurationVar(&injectorConfig.SidecarDrainReadinessDelay, "sidecar-drain-readiness-delay", 5*time.Second, "Time a terminating sidecar proxy keeps accepting new inbound connections after failing its readiness")
+	flags.DurationVar(&injectorConfig.SidecarDrainDuration, "sidecar-drain-duration", 20*time.Second, "Time a terminating sidecar proxy waits for in-flight inbound connections to complete once it stops accepting new ones")
+	flags.StringVar(&injectorConfig.SidecarPostStartCommand, "sidecar-post-start-command", "", "Shell command the postStart hook of the sidecar proxy runs, for example to warm it up before the app containers start; no postStart hook is added when not set")
+	flags.Uint64Var(&injectorConfig.SidecarMaxHeapSizeBytes, "sidecar-max-heap-size-bytes", 0, "Maximum heap size of the sidecar proxy, enabling its overload manager to shed load as the heap grows towards it instead of getting OOM-killed; the overload manager is disabled when 0")
+	flags.Float64Var(&injectorConfig.SidecarShrinkHeapThreshold, "sidecar-shrink-heap-threshold", 0.95, "Ratio of the maximum heap size at which the sidecar proxy returns unused memory to the system")
+	flags.Float64Var(&injectorConfig.SidecarStopAcceptingRequestsThreshold, "sidecar-stop-accepting-requests-threshold", 0.98, "Ratio of the maximum heap size at which the sidecar proxy stops accepting new requests")
+	flags.StringVar(&envoyBootstrapTemplateFile, "envoy-bootstrap-template-file", "", "Path to a Go template used to render the Envoy sidecar bootstrap config; the default bootstrap config is used when not set")
+	flags.StringVar(&extraSidecarsFile, "extra-sidecars-file", "", "Path to a JSON file listing additional sidecar containers, such as telemetry agents, injected together with the Envoy sidecar")
+	flags.StringVar(&podSchedulingFile, "pod-scheduling-file", "", "Path to a JSON file holding the topologySpreadConstraints and affinity merged into the spec of the injected pods, without overriding the scheduling the pods declare")
+	flags.StringToStringVar(&injectorConfig.SpotNodeLabels, "critical-pod-spot-node-labels", nil, "Comma separated list of key=value labels of the spot nodes the injected pods annotated with openservicemesh.io/critical are kept off of; an empty value matches any node with the label key")
+	flags.StringVar(&xdsSnapshotDir, "xds-snapshot-dir", "", "Path to a directory the xDS config of each proxy is persisted to, and served from while the config is recomputed after a restart; persistence is disabled when not set")
+	flags.DurationVar(&xdsSnapshotRecoveryPeriod, "xds-snapshot-recovery-period", 30*time.Second, "Period following a restart during which connecting proxies are served their persisted xDS config")
+	flags.IntVar(&xdsSnapshotMaxProxies, "xds-snapshot-max-proxies", 10000, "Maximum number of proxies whose xDS config is persisted, the config of the least recently updated proxy being evicted beyond it")
 
 	// feature flags
 	flags.BoolVar(&optionalFeatures.Backpressure, "enable-backpressure-experimental", false, "Enable experimental backpressure feature")
@@ -159,6 +201,34 @@ func main() {
 		log.Fatal().Msg("Error initializing generic event recorder")
 	}
 
+	if envoyBootstrapTemplateFile != "" {
+		bootstrapTemplate, err := ioutil.ReadFile(envoyBootstrapTemplateFile)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error reading Envoy bootstrap config template")
+		}
+		injectorConfig.BootstrapTemplate = string(bootstrapTemplate)
+	}
+
+	if extraSidecarsFile != "" {
+		extraSidecars, err := ioutil.ReadFile(extraSidecarsFile)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error reading additional sidecars")
+		}
+		if err := json.Unmarshal(extraSidecars, &injectorConfig.ExtraSidecars); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error parsing additional sidecars")
+		}
+	}
+
+	if podSchedulingFile != "" {
+		podScheduling, err := ioutil.ReadFile(podSchedulingFile)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error reading pod scheduling config")
+		}
+		if err := json.Unmarshal(podScheduling, &injectorConfig.PodScheduling); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error parsing pod scheduling config")
+		}
+	}
+
 	// This ensures CLI parameters (and dependent values) are correct.
 	if err := validateCLIParams(); err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error validating CLI parameters")
@@ -242,7 +312,14 @@ func main() {
 	}
 
 	// Create and start the ADS gRPC service
-	xdsServer := ads.NewADSServer(meshCatalog, cfg.IsDebugServerEnabled(), osmNamespace, cfg, certManager)
+	xdsServer := ads.NewADSServer(meshCatalog, cfg.IsDebugServerEnabled(), osmNamespace, cfg, certManager, kubeClient, kubernetesClient)
+	if xdsSnapshotDir != "" {
+		snapshotStore, err := ads.NewFileSnapshotStore(xdsSnapshotDir)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating xDS snapshot store")
+		}
+		xdsServer.EnableSnapshotPersistence(snapshotStore, xdsSnapshotRecoveryPeriod, xdsSnapshotMaxProxies)
+	}
 	if err := xdsServer.Start(ctx, cancel, *port, adsCert); err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error initializing ADS server")
 	}
@@ -57,3 +57,16 @@ func TestJoinURL(t *testing.T) {
 		assert.Equal(result, ju.expectedOutput)
 	}
 }
+
+func TestGetCertificateManagerUnsupportedKind(t *testing.T) {
+	assert := tassert.New(t)
+
+	originalKind := *osmCertificateManagerKind
+	defer func() { *osmCertificateManagerKind = originalKind }()
+	*osmCertificateManagerKind = "unsupported"
+
+	certManager, certDebugger, err := getCertificateManager(testclient.NewSimpleClientset(), nil, nil)
+	assert.NotNil(err)
+	assert.Nil(certManager)
+	assert.Nil(certDebugger)
+}
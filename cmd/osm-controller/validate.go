@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/injector"
 )
 
 // validateCLIParams contains all checks necessary that various permutations of the CLI flags are consistent
@@ -28,6 +30,38 @@ func validateCLIParams() error {
 		return errors.Errorf("Please specify the sidecar image using --sidecar-image")
 	}
 
+	if err := injector.ValidateBootstrapTemplate(injectorConfig.BootstrapTemplate); err != nil {
+		return errors.Errorf("Invalid --envoy-bootstrap-template-file: %s", err)
+	}
+
+	if err := injector.ValidateExtraSidecars(injectorConfig); err != nil {
+		return errors.Errorf("Invalid --extra-sidecars-file: %s", err)
+	}
+
+	if err := injector.ValidatePodScheduling(injectorConfig); err != nil {
+		return errors.Errorf("Invalid --pod-scheduling-file: %s", err)
+	}
+
+	if err := injector.ValidateSpotNodeLabels(injectorConfig); err != nil {
+		return errors.Errorf("Invalid --critical-pod-spot-node-labels: %s", err)
+	}
+
+	if err := injector.ValidateInjectionLabelSelector(injectorConfig); err != nil {
+		return errors.Errorf("Invalid --injection-label-selector: %s", err)
+	}
+
+	if err := injector.ValidateTerminationMessageConfig(injectorConfig); err != nil {
+		return errors.Errorf("Invalid --sidecar-termination-message-policy: %s", err)
+	}
+
+	if err := injector.ValidateDrainConfig(injectorConfig); err != nil {
+		return errors.Errorf("Invalid sidecar drain config: %s", err)
+	}
+
+	if err := injector.ValidateOverloadManagerConfig(injectorConfig); err != nil {
+		return errors.Errorf("Invalid sidecar overload manager config: %s", err)
+	}
+
 	if webhookConfigName == "" {
 		return errors.Errorf("Invalid --webhook-config-name value: '%s'", webhookConfigName)
 	}
@@ -198,6 +198,23 @@ var _ = Describe("Test validateCLIParams", func() {
 
 		err := validateCLIParams()
 
+		It("should error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("envoy bootstrap template is missing required placeholders", func() {
+		*osmCertificateManagerKind = tresorKind
+		meshName = testMeshName
+		osmNamespace = testOsmNamespace
+		injectorConfig = injector.Config{
+			InitContainerImage: testInitContainerImage,
+			SidecarImage:       testSidecarImage,
+			BootstrapTemplate:  "node: {{.EnvoyNodeID}}",
+		}
+		webhookConfigName = testwebhookConfigName
+
+		err := validateCLIParams()
+
 		It("should error", func() {
 			Expect(err).To(HaveOccurred())
 		})
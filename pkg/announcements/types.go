@@ -11,7 +11,8 @@ const (
 	// ScheduleProxyBroadcast is used by other modules to request the dispatcher to schedule a global proxy broadcast
 	ScheduleProxyBroadcast AnnouncementType = "schedule-proxy-broadcast"
 
-	// ProxyBroadcast is used to notify all Proxy streams that they need to trigger an update
+	// ProxyBroadcast is used to notify all Proxy streams that they need to trigger an update.
+	// The message's NewObj optionally holds the list of xDS types affected by the update.
 	ProxyBroadcast AnnouncementType = "proxy-broadcast"
 
 	// PodAdded is the type of announcement emitted when we observe an addition of a Kubernetes Pod
@@ -132,6 +133,12 @@ const (
 
 	// IngressUpdated is the type of announcement emitted when we observe an update to a Kubernetes Ingress
 	IngressUpdated AnnouncementType = "ingress-updated"
+
+	// ---
+
+	// CertificateRevoked is the type of announcement emitted when a certificate manager revokes a certificate, which
+	// changes the certificate revocation list of the proxies' validation contexts
+	CertificateRevoked AnnouncementType = "certificate-revoked"
 )
 
 // Announcement is a struct for messages between various components of OSM signaling a need for a change in Envoy proxy configuration
@@ -1,14 +1,19 @@
 package catalog
 
 import (
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
-// releaseCertificateHandler releases certificates based on podDelete events
+// releaseCertificateHandler releases certificates based on podDelete events. The service certificate shared by the pods
+// of a service account is revoked once the last of these pods is deleted, when the certificate manager supports
+// revocation, so that it is rejected by the proxies if it is ever presented again.
 // returns a stop channel which can be used to stop the inner handler
 func (mc *MeshCatalog) releaseCertificateHandler() chan struct{} {
 	podDeleteSubscription := events.GetPubSubInstance().Subscribe(announcements.PodDeleted)
@@ -38,6 +43,7 @@ func (mc *MeshCatalog) releaseCertificateHandler() chan struct{} {
 					endpointCN := podIface.(certificate.CommonName)
 					log.Warn().Msgf("Pod with UID %s found in Mesh Catalog; Releasing certificate %s", podUID, endpointCN)
 					mc.certManager.ReleaseCertificate(endpointCN)
+					mc.revokeServiceCertificateOfLastPod(deletedPodObj)
 
 					// Request a broadcast update, just for security.
 					// Dispatcher code also handles PodDelete, so probably the two will get coalesced.
@@ -55,3 +61,69 @@ func (mc *MeshCatalog) releaseCertificateHandler() chan struct{} {
 
 	return stop
 }
+
+// revokeServiceCertificateOfLastPod revokes the service certificate of the service account of the given deleted pod when
+// no other pod in the mesh runs with that service account. The pods of a service account present the same service
+// certificate to their peers, so it must stay valid while any of them remains.
+func (mc *MeshCatalog) revokeServiceCertificateOfLastPod(deletedPod *v1.Pod) {
+	revoker, ok := mc.certManager.(certificate.RevocationListPublisher)
+	if !ok {
+		return
+	}
+
+	for _, pod := range mc.kubeController.ListPods() {
+		if pod.UID != deletedPod.UID && pod.Namespace == deletedPod.Namespace && pod.Spec.ServiceAccountName == deletedPod.Spec.ServiceAccountName {
+			return
+		}
+	}
+
+	svcAccount := service.K8sServiceAccount{
+		Name:      deletedPod.Spec.ServiceAccountName,
+		Namespace: deletedPod.Namespace,
+	}
+	serviceCN := certificate.CommonName(identity.GetKubernetesServiceIdentity(svcAccount, identity.ClusterLocalTrustDomain))
+	if err := revoker.RevokeCertificate(serviceCN); err != nil {
+		log.Debug().Err(err).Msgf("Service certificate %s of service account %s not revoked after its last Pod was deleted", serviceCN, svcAccount)
+		return
+	}
+	log.Info().Msgf("Revoked service certificate %s of service account %s after its last Pod was deleted", serviceCN, svcAccount)
+}
+
+// trafficTargetValidationHandler validates the TrafficTargets being added or updated and records the result as events
+// on the TrafficTargets. It returns a stop channel which can be used to stop the inner handler.
+func (mc *MeshCatalog) trafficTargetValidationHandler() chan struct{} {
+	trafficTargetSubscription := events.GetPubSubInstance().Subscribe(announcements.TrafficTargetAdded, announcements.TrafficTargetUpdated)
+	stop := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case trafficTargetMsg := <-trafficTargetSubscription:
+				psubMessage, castOk := trafficTargetMsg.(events.PubSubMessage)
+				if !castOk {
+					log.Error().Msgf("Error casting PubSubMessage: %v", psubMessage)
+					continue
+				}
+
+				// Only the name of the TrafficTarget is taken from the message: the current version of the
+				// TrafficTarget is validated, in case it changed since the message was published
+				trafficTarget, castOk := psubMessage.NewObj.(*smiAccess.TrafficTarget)
+				if !castOk {
+					log.Error().Msgf("Failed to cast to *TrafficTarget: %v", psubMessage.NewObj)
+					continue
+				}
+
+				for _, t := range mc.meshSpec.ListTrafficTargets() {
+					if t.Namespace == trafficTarget.Namespace && t.Name == trafficTarget.Name {
+						mc.validateTrafficTarget(t)
+						break
+					}
+				}
+			}
+		}
+	}()
+
+	return stop
+}
@@ -1,6 +1,8 @@
 package catalog
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,21 +18,45 @@ import (
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var _ = Describe("Test Announcement Handlers", func() {
 	var mc *MeshCatalog
+	var kubeClient *testclient.Clientset
 	var podUID string
 	var proxy *envoy.Proxy
 	var envoyCN certificate.CommonName
+	var serviceCN certificate.CommonName
+
+	newPod := func(uid string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uid,
+				Namespace: tests.BookbuyerServiceAccount.Namespace,
+				UID:       types.UID(uid),
+			},
+			Spec: v1.PodSpec{
+				ServiceAccountName: tests.BookbuyerServiceAccount.Name,
+			},
+		}
+	}
 
 	BeforeEach(func() {
-		mc = NewFakeMeshCatalog(testclient.NewSimpleClientset())
+		kubeClient = testclient.NewSimpleClientset()
+		mc = NewFakeMeshCatalog(kubeClient)
 		podUID = uuid.New().String()
+		envoyCN = certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), tests.BookbuyerServiceAccount.Name, tests.BookbuyerServiceAccount.Namespace))
 		_, err := mc.certManager.IssueCertificate(envoyCN, 5*time.Second)
 		Expect(err).ToNot(HaveOccurred())
 
+		// The service certificate shared by the pods of the service account
+		serviceCN = certificate.CommonName(identity.GetKubernetesServiceIdentity(tests.BookbuyerServiceAccount, identity.ClusterLocalTrustDomain))
+		_, err = mc.certManager.IssueCertificate(serviceCN, 5*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+
 		proxy = envoy.NewProxy(envoyCN, "-cert-serial-number-", nil)
 		proxy.PodMetadata = &envoy.PodMetadata{
 			UID: podUID,
@@ -49,30 +75,27 @@ var _ = Describe("Test Announcement Handlers", func() {
 			stopChannel <- struct{}{}
 		})
 
-		It("deletes certificate when Pod is terminated", func() {
+		It("releases the xDS certificate and revokes the service certificate when the last Pod of its service account is terminated", func() {
 			// Ensure setup is correct
 			{
 				certs, err := mc.certManager.ListCertificates()
 				Expect(err).ToNot(HaveOccurred())
-				Expect(len(certs)).To(Equal(1))
+				Expect(len(certs)).To(Equal(2))
 			}
 
 			// Register to Update proxies event. We should see a schedule broadcast update
 			// requested by the handler when the certificate is released.
 			rcvBroadcastChannel := events.GetPubSubInstance().Subscribe(announcements.ScheduleProxyBroadcast)
+			rcvRevokedChannel := events.GetPubSubInstance().Subscribe(announcements.CertificateRevoked)
 
 			// Publish a podDeleted event
 			events.GetPubSubInstance().Publish(events.PubSubMessage{
 				AnnouncementType: announcements.PodDeleted,
 				NewObj:           nil,
-				OldObj: &v1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						UID: types.UID(podUID),
-					},
-				},
+				OldObj:           newPod(podUID),
 			})
 
-			// Expect the certificate to eventually be gone for the deleted Pod
+			// Expect the certificates to eventually be gone for the deleted Pod
 			Eventually(func() int {
 				certs, err := mc.certManager.ListCertificates()
 				Expect(err).ToNot(HaveOccurred())
@@ -85,6 +108,45 @@ var _ = Describe("Test Announcement Handlers", func() {
 			case <-time.After(1 * time.Second):
 				Fail("Did not see a broadcast request in time")
 			}
+
+			// Only the service certificate presented to the peers is revoked
+			select {
+			case revokedMsg := <-rcvRevokedChannel:
+				Expect(revokedMsg.(events.PubSubMessage).OldObj).To(Equal(serviceCN))
+			case <-time.After(1 * time.Second):
+				Fail("Did not see the certificate revoked in time")
+			}
+			Consistently(rcvRevokedChannel, 500*time.Millisecond).ShouldNot(Receive())
+			crl, err := mc.certManager.(certificate.RevocationListPublisher).GetCertificateRevocationList()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(crl).ToNot(BeNil())
+		})
+
+		It("keeps the service certificate valid while another Pod of its service account remains", func() {
+			_, err := kubeClient.CoreV1().Pods(tests.BookbuyerServiceAccount.Namespace).Create(context.TODO(), newPod(uuid.New().String()), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			rcvRevokedChannel := events.GetPubSubInstance().Subscribe(announcements.CertificateRevoked)
+
+			events.GetPubSubInstance().Publish(events.PubSubMessage{
+				AnnouncementType: announcements.PodDeleted,
+				NewObj:           nil,
+				OldObj:           newPod(podUID),
+			})
+
+			// Only the xDS certificate of the deleted Pod is released
+			Eventually(func() int {
+				certs, err := mc.certManager.ListCertificates()
+				Expect(err).ToNot(HaveOccurred())
+				return len(certs)
+			}).Should(Equal(1))
+			certs, err := mc.certManager.ListCertificates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certs[0].GetCommonName()).To(Equal(serviceCN))
+
+			Consistently(rcvRevokedChannel, 500*time.Millisecond).ShouldNot(Receive())
+			crl, err := mc.certManager.(certificate.RevocationListPublisher).GetCertificateRevocationList()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(crl).To(BeNil())
 		})
 
 		It("ignores events other than pod-deleted", func() {
@@ -102,11 +164,7 @@ var _ = Describe("Test Announcement Handlers", func() {
 			events.GetPubSubInstance().Publish(events.PubSubMessage{
 				AnnouncementType: announcements.IngressAdded,
 				NewObj:           nil,
-				OldObj: &v1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						UID: types.UID(proxy.PodMetadata.UID),
-					},
-				},
+				OldObj:           newPod(proxy.PodMetadata.UID),
 			})
 
 			// Give some grace period for event to propagate
@@ -115,7 +173,7 @@ var _ = Describe("Test Announcement Handlers", func() {
 			// Ensure it was not deleted due to an unrelated event
 			certs, err := mc.certManager.ListCertificates()
 			Expect(err).ToNot(HaveOccurred())
-			Expect(len(certs)).To(Equal(1))
+			Expect(len(certs)).To(Equal(2))
 		})
 	})
 })
@@ -8,6 +8,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 	"github.com/openservicemesh/osm/pkg/smi"
 )
 
@@ -26,11 +27,16 @@ func NewMeshCatalog(kubeController k8s.Controller, kubeClient kubernetes.Interfa
 		// The certificate itself would contain the cluster ID making it easy to lookup the client in this map.
 		kubeClient:     kubeClient,
 		kubeController: kubeController,
+
+		eventRecorder: events.NewObjectEventRecorder(kubeClient),
 	}
 
 	// Run release certificate handler, which listens to podDelete events
 	mc.releaseCertificateHandler()
 
+	// Run the TrafficTarget validation handler, which reports invalid references of TrafficTargets as events
+	mc.trafficTargetValidationHandler()
+
 	go mc.dispatcher()
 	return &mc
 }
@@ -39,3 +45,11 @@ func NewMeshCatalog(kubeController k8s.Controller, kubeClient kubernetes.Interfa
 func (mc *MeshCatalog) GetSMISpec() smi.MeshSpec {
 	return mc.meshSpec
 }
+
+// IsConfigReadyForProxy returns whether the mesh config of the proxy with the given certificate common name can be
+// computed, which requires the pod of the proxy and its services to be resolvable. A proxy may connect before its pod
+// is observed, until which its config cannot be computed.
+func (mc *MeshCatalog) IsConfigReadyForProxy(cn certificate.CommonName) bool {
+	_, err := mc.GetServicesFromEnvoyCertificate(cn)
+	return err == nil
+}
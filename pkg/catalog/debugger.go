@@ -10,6 +10,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
 )
 
 // ListExpectedProxies lists the Envoy proxies yet to connect and the time their XDS certificate was issued.
@@ -80,3 +81,18 @@ func (mc *MeshCatalog) ListMonitoredNamespaces() []string {
 
 	return namespaces
 }
+
+// NewCandidateCatalog returns a mesh catalog computing the mesh config with the given candidate SMI policies overlaid
+// on the live SMI policies. The candidate catalog shares the providers of the live catalog, and is not tracking proxies.
+func (mc *MeshCatalog) NewCandidateCatalog(candidate smi.CandidatePolicies) MeshCataloger {
+	return &MeshCatalog{
+		endpointsProviders: mc.endpointsProviders,
+		meshSpec:           smi.NewCandidateMeshSpec(mc.meshSpec, candidate),
+		certManager:        mc.certManager,
+		ingressMonitor:     mc.ingressMonitor,
+		configurator:       mc.configurator,
+		kubeClient:         mc.kubeClient,
+		kubeController:     mc.kubeController,
+		eventRecorder:      mc.eventRecorder,
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"strings"
 	"time"
 
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+
 	a "github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 )
 
@@ -18,6 +21,67 @@ const (
 	maxGraceDeadlineTime = 3 * time.Second
 )
 
+// affectedTypeURIs maps announcement types to the xDS types whose configuration they may change.
+// Announcement types not listed here are assumed to affect every xDS type.
+var affectedTypeURIs = map[a.AnnouncementType][]envoy.TypeURI{
+	a.BackpressureAdded:   {envoy.TypeCDS},
+	a.BackpressureDeleted: {envoy.TypeCDS},
+	a.BackpressureUpdated: {envoy.TypeCDS},
+	a.IngressAdded:        {envoy.TypeLDS, envoy.TypeRDS},
+	a.IngressDeleted:      {envoy.TypeLDS, envoy.TypeRDS},
+	a.IngressUpdated:      {envoy.TypeLDS, envoy.TypeRDS},
+	a.CertificateRevoked:  {envoy.TypeSDS},
+}
+
+// getAffectedTypeURIs returns the xDS types whose configuration may be changed by the given announcement type
+func getAffectedTypeURIs(announcementType a.AnnouncementType) []envoy.TypeURI {
+	if typeURIs, ok := affectedTypeURIs[announcementType]; ok {
+		return typeURIs
+	}
+	return envoy.XDSResponseOrder
+}
+
+// getMessageTypeURIs returns the xDS types whose configuration may be changed by the given pubsub message. An update of
+// a TrafficSplit changing only the weights of its backends changes only the weighted clusters of the routes, so that
+// only RDS is pushed to the proxies, leaving their listeners and clusters untouched.
+func getMessageTypeURIs(psubMessage events.PubSubMessage) []envoy.TypeURI {
+	if psubMessage.AnnouncementType == a.TrafficSplitUpdated && isTrafficSplitWeightChange(psubMessage.OldObj, psubMessage.NewObj) {
+		return []envoy.TypeURI{envoy.TypeRDS}
+	}
+	return getAffectedTypeURIs(psubMessage.AnnouncementType)
+}
+
+// isTrafficSplitWeightChange returns true if the given TrafficSplits split the same service between the same backends,
+// only with different weights
+func isTrafficSplitWeightChange(oldObj, newObj interface{}) bool {
+	oldSplit, oldOk := oldObj.(*split.TrafficSplit)
+	newSplit, newOk := newObj.(*split.TrafficSplit)
+	if !oldOk || !newOk {
+		return false
+	}
+
+	if oldSplit.Spec.Service != newSplit.Spec.Service || len(oldSplit.Spec.Backends) != len(newSplit.Spec.Backends) {
+		return false
+	}
+	for i := range oldSplit.Spec.Backends {
+		if oldSplit.Spec.Backends[i].Service != newSplit.Spec.Backends[i].Service {
+			return false
+		}
+	}
+	return true
+}
+
+// orderedTypeURIs returns the given set of xDS types in the order in which xDS responses must be sent
+func orderedTypeURIs(typeURIs map[envoy.TypeURI]struct{}) []envoy.TypeURI {
+	var ordered []envoy.TypeURI
+	for _, typeURI := range envoy.XDSResponseOrder {
+		if _, ok := typeURIs[typeURI]; ok {
+			ordered = append(ordered, typeURI)
+		}
+	}
+	return ordered
+}
+
 // isDeltaUpdate assesses and returns if a pubsub message contains an actual delta in config
 func isDeltaUpdate(psubMsg events.PubSubMessage) bool {
 	return !(strings.HasSuffix(psubMsg.AnnouncementType.String(), "updated") &&
@@ -40,10 +104,13 @@ func (mc *MeshCatalog) dispatcher() {
 		a.BackpressureAdded, a.BackpressureDeleted, a.BackpressureUpdated, // backpressure
 		a.IngressAdded, a.IngressDeleted, a.IngressUpdated, // Ingress
 		a.TCPRouteAdded, a.TCPRouteDeleted, a.TCPRouteUpdated, // TCProute
+		a.CertificateRevoked, // certificate revocation list
 	)
 
 	// State and channels for event-coalescing
 	broadcastScheduled := false
+	// The xDS types affected by the events coalesced into the scheduled broadcast
+	pendingTypeURIs := make(map[envoy.TypeURI]struct{})
 	chanMovingDeadline := make(<-chan time.Time)
 	chanMaxDeadline := make(<-chan time.Time)
 
@@ -80,6 +147,10 @@ func (mc *MeshCatalog) dispatcher() {
 			// - detected a config delta
 			// - another module requested a broadcast through ScheduleProxyBroadcast
 			if delta || psubMessage.AnnouncementType == a.ScheduleProxyBroadcast {
+				for _, typeURI := range getMessageTypeURIs(psubMessage) {
+					pendingTypeURIs[typeURI] = struct{}{}
+				}
+
 				if !broadcastScheduled {
 					broadcastScheduled = true
 					chanMaxDeadline = time.After(maxBroadcastDeadlineTime)
@@ -98,10 +169,12 @@ func (mc *MeshCatalog) dispatcher() {
 			log.Debug().Msgf("[Moving deadline trigger] Broadcast envoy update")
 			events.GetPubSubInstance().Publish(events.PubSubMessage{
 				AnnouncementType: a.ProxyBroadcast,
+				NewObj:           orderedTypeURIs(pendingTypeURIs),
 			})
 
-			// broadcast done, reset timer channels
+			// broadcast done, reset timer channels and affected xDS types
 			broadcastScheduled = false
+			pendingTypeURIs = make(map[envoy.TypeURI]struct{})
 			chanMovingDeadline = make(<-chan time.Time)
 			chanMaxDeadline = make(<-chan time.Time)
 
@@ -109,10 +182,12 @@ func (mc *MeshCatalog) dispatcher() {
 			log.Debug().Msgf("[Max deadline trigger] Broadcast envoy update")
 			events.GetPubSubInstance().Publish(events.PubSubMessage{
 				AnnouncementType: a.ProxyBroadcast,
+				NewObj:           orderedTypeURIs(pendingTypeURIs),
 			})
 
-			// broadcast done, reset timer channels
+			// broadcast done, reset timer channels and affected xDS types
 			broadcastScheduled = false
+			pendingTypeURIs = make(map[envoy.TypeURI]struct{})
 			chanMovingDeadline = make(<-chan time.Time)
 			chanMaxDeadline = make(<-chan time.Time)
 		}
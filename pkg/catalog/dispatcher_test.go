@@ -0,0 +1,84 @@
+package catalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	a "github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+)
+
+var _ = Describe("Test dispatcher helpers", func() {
+	Context("Testing getAffectedTypeURIs()", func() {
+		It("returns only the xDS types affected by a backpressure change", func() {
+			Expect(getAffectedTypeURIs(a.BackpressureUpdated)).To(Equal([]envoy.TypeURI{envoy.TypeCDS}))
+		})
+
+		It("returns only SDS for a certificate revocation", func() {
+			Expect(getAffectedTypeURIs(a.CertificateRevoked)).To(Equal([]envoy.TypeURI{envoy.TypeSDS}))
+		})
+
+		It("returns all xDS types for announcements without a known scope", func() {
+			Expect(getAffectedTypeURIs(a.TrafficTargetUpdated)).To(Equal(envoy.XDSResponseOrder))
+			Expect(getAffectedTypeURIs(a.ScheduleProxyBroadcast)).To(Equal(envoy.XDSResponseOrder))
+		})
+	})
+
+	Context("Testing getMessageTypeURIs()", func() {
+		trafficSplit := &split.TrafficSplit{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bookstore", Name: "bookstore-split"},
+			Spec: split.TrafficSplitSpec{
+				Service: "bookstore",
+				Backends: []split.TrafficSplitBackend{
+					{Service: "bookstore-v1", Weight: 90},
+					{Service: "bookstore-v2", Weight: 10},
+				},
+			},
+		}
+
+		It("returns only RDS when only the weights of a TrafficSplit change", func() {
+			reweighted := trafficSplit.DeepCopy()
+			reweighted.Spec.Backends[0].Weight = 50
+			reweighted.Spec.Backends[1].Weight = 50
+
+			Expect(getMessageTypeURIs(events.PubSubMessage{
+				AnnouncementType: a.TrafficSplitUpdated,
+				OldObj:           trafficSplit,
+				NewObj:           reweighted,
+			})).To(Equal([]envoy.TypeURI{envoy.TypeRDS}))
+		})
+
+		It("returns all xDS types when the backends of a TrafficSplit change", func() {
+			rebackended := trafficSplit.DeepCopy()
+			rebackended.Spec.Backends[1].Service = "bookstore-v3"
+
+			Expect(getMessageTypeURIs(events.PubSubMessage{
+				AnnouncementType: a.TrafficSplitUpdated,
+				OldObj:           trafficSplit,
+				NewObj:           rebackended,
+			})).To(Equal(envoy.XDSResponseOrder))
+		})
+
+		It("returns all xDS types when a TrafficSplit is added", func() {
+			Expect(getMessageTypeURIs(events.PubSubMessage{
+				AnnouncementType: a.TrafficSplitAdded,
+				NewObj:           trafficSplit,
+			})).To(Equal(envoy.XDSResponseOrder))
+		})
+	})
+
+	Context("Testing orderedTypeURIs()", func() {
+		It("returns the coalesced xDS types in response order", func() {
+			pending := make(map[envoy.TypeURI]struct{})
+			for _, announcementType := range []a.AnnouncementType{a.IngressUpdated, a.BackpressureAdded} {
+				for _, typeURI := range getAffectedTypeURIs(announcementType) {
+					pending[typeURI] = struct{}{}
+				}
+			}
+			Expect(orderedTypeURIs(pending)).To(Equal([]envoy.TypeURI{envoy.TypeCDS, envoy.TypeLDS, envoy.TypeRDS}))
+		})
+	})
+})
@@ -19,6 +19,21 @@ func (mc *MeshCatalog) ListEndpointsForService(svc service.MeshService) ([]endpo
 	return endpoints, nil
 }
 
+// ListAllEndpointsForService returns the list of provider endpoints corresponding to a service, including the endpoints
+// not ready to serve traffic. Only consumers sending the readiness of the endpoints to the proxies must use it.
+func (mc *MeshCatalog) ListAllEndpointsForService(svc service.MeshService) ([]endpoint.Endpoint, error) {
+	var endpoints []endpoint.Endpoint
+	for _, provider := range mc.endpointsProviders {
+		ep := provider.ListAllEndpointsForService(svc)
+		if len(ep) == 0 {
+			log.Trace().Msgf("[%s] No endpoints found for service=%s", provider.GetID(), svc)
+			continue
+		}
+		endpoints = append(endpoints, ep...)
+	}
+	return endpoints, nil
+}
+
 // GetResolvableServiceEndpoints returns the resolvable set of endpoint over which a service is accessible using its FQDN
 func (mc *MeshCatalog) GetResolvableServiceEndpoints(svc service.MeshService) ([]endpoint.Endpoint, error) {
 	var endpoints []endpoint.Endpoint
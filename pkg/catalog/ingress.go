@@ -1,6 +1,8 @@
 package catalog
 
 import (
+	extensionsV1beta "k8s.io/api/extensions/v1beta1"
+
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
@@ -24,8 +26,12 @@ func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map
 	}
 
 	for _, ingress := range ingresses {
+		prefixRewrite, regexRewrite := getIngressPathRewrite(ingress)
+
 		if ingress.Spec.Backend != nil && ingress.Spec.Backend.ServiceName == service.Name {
-			domainRoutesMap[constants.WildcardHTTPMethod] = []trafficpolicy.HTTPRouteMatch{defaultRoute}
+			backendRoute := defaultRoute
+			backendRoute.RegexRewrite = regexRewrite
+			domainRoutesMap[constants.WildcardHTTPMethod] = []trafficpolicy.HTTPRouteMatch{backendRoute}
 		}
 
 		for _, rule := range ingress.Spec.Rules {
@@ -41,6 +47,8 @@ func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map
 				if routePolicy.PathRegex != "" {
 					routePolicy.PathRegex = ingressPath.Path
 				}
+				routePolicy.PrefixRewrite = prefixRewrite
+				routePolicy.RegexRewrite = regexRewrite
 				domainRoutesMap[domain] = append(domainRoutesMap[domain], routePolicy)
 			}
 		}
@@ -50,3 +58,23 @@ func (mc *MeshCatalog) GetIngressRoutesPerHost(service service.MeshService) (map
 
 	return domainRoutesMap, nil
 }
+
+// getIngressPathRewrite returns the prefix rewrite and regex rewrite annotated on the given ingress.
+// Only one of them is returned if both are annotated, with the prefix rewrite taking precedence.
+func getIngressPathRewrite(ingress *extensionsV1beta.Ingress) (string, *trafficpolicy.RegexRewrite) {
+	prefixRewrite := ingress.Annotations[constants.IngressPrefixRewriteAnnotation]
+
+	pattern, hasPattern := ingress.Annotations[constants.IngressRegexRewritePatternAnnotation]
+	if !hasPattern || pattern == "" {
+		return prefixRewrite, nil
+	}
+	if prefixRewrite != "" {
+		log.Error().Msgf("Ignoring regex rewrite of ingress %s/%s, which also specifies a prefix rewrite", ingress.Namespace, ingress.Name)
+		return prefixRewrite, nil
+	}
+
+	return "", &trafficpolicy.RegexRewrite{
+		Pattern:      pattern,
+		Substitution: ingress.Annotations[constants.IngressRegexRewriteSubstitutionAnnotation],
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var (
@@ -274,4 +275,48 @@ var _ = Describe("Test ingress route policies", func() {
 		})
 
 	})
+
+	Context("Testing getIngressPathRewrite", func() {
+		newIngress := func(annotations map[string]string) *extensionsV1beta.Ingress {
+			return &extensionsV1beta.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ingress",
+					Namespace:   fakeIngressNamespace,
+					Annotations: annotations,
+				},
+			}
+		}
+
+		It("Returns no rewrite when none is annotated", func() {
+			prefixRewrite, regexRewrite := getIngressPathRewrite(newIngress(nil))
+			Expect(prefixRewrite).To(BeEmpty())
+			Expect(regexRewrite).To(BeNil())
+		})
+
+		It("Returns the annotated prefix rewrite", func() {
+			prefixRewrite, regexRewrite := getIngressPathRewrite(newIngress(map[string]string{
+				constants.IngressPrefixRewriteAnnotation: "/",
+			}))
+			Expect(prefixRewrite).To(Equal("/"))
+			Expect(regexRewrite).To(BeNil())
+		})
+
+		It("Returns the annotated regex rewrite", func() {
+			prefixRewrite, regexRewrite := getIngressPathRewrite(newIngress(map[string]string{
+				constants.IngressRegexRewritePatternAnnotation:      "^/api/v1/(.*)$",
+				constants.IngressRegexRewriteSubstitutionAnnotation: "/\\1",
+			}))
+			Expect(prefixRewrite).To(BeEmpty())
+			Expect(regexRewrite).To(Equal(&trafficpolicy.RegexRewrite{Pattern: "^/api/v1/(.*)$", Substitution: "/\\1"}))
+		})
+
+		It("Prefers the prefix rewrite when both are annotated", func() {
+			prefixRewrite, regexRewrite := getIngressPathRewrite(newIngress(map[string]string{
+				constants.IngressPrefixRewriteAnnotation:       "/",
+				constants.IngressRegexRewritePatternAnnotation: "^/api/v1/(.*)$",
+			}))
+			Expect(prefixRewrite).To(Equal("/"))
+			Expect(regexRewrite).To(BeNil())
+		})
+	})
 })
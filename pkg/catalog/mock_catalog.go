@@ -6,6 +6,7 @@ package catalog
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	certificate "github.com/openservicemesh/osm/pkg/certificate"
@@ -54,6 +55,203 @@ func (mr *MockMeshCatalogerMockRecorder) ExpectProxy(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpectProxy", reflect.TypeOf((*MockMeshCataloger)(nil).ExpectProxy), arg0)
 }
 
+// GetAccessLogSamplingPercentageForService mocks base method
+func (m *MockMeshCataloger) GetAccessLogSamplingPercentageForService(arg0 service.MeshService) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessLogSamplingPercentageForService", arg0)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetAccessLogSamplingPercentageForService indicates an expected call of GetAccessLogSamplingPercentageForService
+func (mr *MockMeshCatalogerMockRecorder) GetAccessLogSamplingPercentageForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessLogSamplingPercentageForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetAccessLogSamplingPercentageForService), arg0)
+}
+
+// GetAllowedSourceIPRangesForService mocks base method
+func (m *MockMeshCataloger) GetAllowedSourceIPRangesForService(arg0 service.MeshService) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllowedSourceIPRangesForService", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetAllowedSourceIPRangesForService indicates an expected call of GetAllowedSourceIPRangesForService
+func (mr *MockMeshCatalogerMockRecorder) GetAllowedSourceIPRangesForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllowedSourceIPRangesForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetAllowedSourceIPRangesForService), arg0)
+}
+
+// GetCircuitBreakingForService mocks base method
+func (m *MockMeshCataloger) GetCircuitBreakingForService(arg0 service.MeshService) *trafficpolicy.CircuitBreaking {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCircuitBreakingForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.CircuitBreaking)
+	return ret0
+}
+
+// GetCircuitBreakingForService indicates an expected call of GetCircuitBreakingForService
+func (mr *MockMeshCatalogerMockRecorder) GetCircuitBreakingForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCircuitBreakingForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetCircuitBreakingForService), arg0)
+}
+
+// GetClientAddressDetectionForService mocks base method
+func (m *MockMeshCataloger) GetClientAddressDetectionForService(arg0 service.MeshService) *trafficpolicy.ClientAddressDetection {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientAddressDetectionForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.ClientAddressDetection)
+	return ret0
+}
+
+// GetClientAddressDetectionForService indicates an expected call of GetClientAddressDetectionForService
+func (mr *MockMeshCatalogerMockRecorder) GetClientAddressDetectionForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientAddressDetectionForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetClientAddressDetectionForService), arg0)
+}
+
+// GetClusterTypeForService mocks base method
+func (m *MockMeshCataloger) GetClusterTypeForService(arg0 service.MeshService) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterTypeForService", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetClusterTypeForService indicates an expected call of GetClusterTypeForService
+func (mr *MockMeshCatalogerMockRecorder) GetClusterTypeForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterTypeForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetClusterTypeForService), arg0)
+}
+
+// GetDNSRefreshRateForService mocks base method
+func (m *MockMeshCataloger) GetDNSRefreshRateForService(arg0 service.MeshService) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSRefreshRateForService", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDNSRefreshRateForService indicates an expected call of GetDNSRefreshRateForService
+func (mr *MockMeshCatalogerMockRecorder) GetDNSRefreshRateForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSRefreshRateForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetDNSRefreshRateForService), arg0)
+}
+
+// GetDirectResponseForService mocks base method
+func (m *MockMeshCataloger) GetDirectResponseForService(arg0 service.MeshService) *trafficpolicy.DirectResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectResponseForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.DirectResponse)
+	return ret0
+}
+
+// GetDirectResponseForService indicates an expected call of GetDirectResponseForService
+func (mr *MockMeshCatalogerMockRecorder) GetDirectResponseForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectResponseForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetDirectResponseForService), arg0)
+}
+
+// GetFailoverServicesForService mocks base method
+func (m *MockMeshCataloger) GetFailoverServicesForService(arg0 service.MeshService) []service.MeshService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailoverServicesForService", arg0)
+	ret0, _ := ret[0].([]service.MeshService)
+	return ret0
+}
+
+// GetFailoverServicesForService indicates an expected call of GetFailoverServicesForService
+func (mr *MockMeshCatalogerMockRecorder) GetFailoverServicesForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailoverServicesForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetFailoverServicesForService), arg0)
+}
+
+// GetHTTP1ProtocolOptionsForService mocks base method
+func (m *MockMeshCataloger) GetHTTP1ProtocolOptionsForService(arg0 service.MeshService) *trafficpolicy.HTTP1ProtocolOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTP1ProtocolOptionsForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.HTTP1ProtocolOptions)
+	return ret0
+}
+
+// GetHTTP1ProtocolOptionsForService indicates an expected call of GetHTTP1ProtocolOptionsForService
+func (mr *MockMeshCatalogerMockRecorder) GetHTTP1ProtocolOptionsForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTP1ProtocolOptionsForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetHTTP1ProtocolOptionsForService), arg0)
+}
+
+// GetHTTP2MaxConcurrentStreamsForService mocks base method
+func (m *MockMeshCataloger) GetHTTP2MaxConcurrentStreamsForService(arg0 service.MeshService) uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTP2MaxConcurrentStreamsForService", arg0)
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetHTTP2MaxConcurrentStreamsForService indicates an expected call of GetHTTP2MaxConcurrentStreamsForService
+func (mr *MockMeshCatalogerMockRecorder) GetHTTP2MaxConcurrentStreamsForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTP2MaxConcurrentStreamsForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetHTTP2MaxConcurrentStreamsForService), arg0)
+}
+
+// GetHashPolicyForService mocks base method
+func (m *MockMeshCataloger) GetHashPolicyForService(arg0 service.MeshService) *trafficpolicy.HashPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHashPolicyForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.HashPolicy)
+	return ret0
+}
+
+// GetHashPolicyForService indicates an expected call of GetHashPolicyForService
+func (mr *MockMeshCatalogerMockRecorder) GetHashPolicyForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashPolicyForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetHashPolicyForService), arg0)
+}
+
+// GetHealthCheckForService mocks base method
+func (m *MockMeshCataloger) GetHealthCheckForService(arg0 service.MeshService) *trafficpolicy.HealthCheck {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.HealthCheck)
+	return ret0
+}
+
+// GetHealthCheckForService indicates an expected call of GetHealthCheckForService
+func (mr *MockMeshCatalogerMockRecorder) GetHealthCheckForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetHealthCheckForService), arg0)
+}
+
+// GetInboundPlaintextProbePortsForProxy mocks base method
+func (m *MockMeshCataloger) GetInboundPlaintextProbePortsForProxy(arg0 certificate.CommonName) ([]uint32, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundPlaintextProbePortsForProxy", arg0)
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// GetInboundPlaintextProbePortsForProxy indicates an expected call of GetInboundPlaintextProbePortsForProxy
+func (mr *MockMeshCatalogerMockRecorder) GetInboundPlaintextProbePortsForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundPlaintextProbePortsForProxy", reflect.TypeOf((*MockMeshCataloger)(nil).GetInboundPlaintextProbePortsForProxy), arg0)
+}
+
+// GetInboundPortExclusionListForProxy mocks base method
+func (m *MockMeshCataloger) GetInboundPortExclusionListForProxy(arg0 certificate.CommonName) []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundPortExclusionListForProxy", arg0)
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// GetInboundPortExclusionListForProxy indicates an expected call of GetInboundPortExclusionListForProxy
+func (mr *MockMeshCatalogerMockRecorder) GetInboundPortExclusionListForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundPortExclusionListForProxy", reflect.TypeOf((*MockMeshCataloger)(nil).GetInboundPortExclusionListForProxy), arg0)
+}
+
 // GetIngressRoutesPerHost mocks base method
 func (m *MockMeshCataloger) GetIngressRoutesPerHost(arg0 service.MeshService) (map[string][]trafficpolicy.HTTPRouteMatch, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +267,62 @@ func (mr *MockMeshCatalogerMockRecorder) GetIngressRoutesPerHost(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressRoutesPerHost", reflect.TypeOf((*MockMeshCataloger)(nil).GetIngressRoutesPerHost), arg0)
 }
 
+// GetMeshTopology mocks base method
+func (m *MockMeshCataloger) GetMeshTopology() trafficpolicy.MeshTopology {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshTopology")
+	ret0, _ := ret[0].(trafficpolicy.MeshTopology)
+	return ret0
+}
+
+// GetMeshTopology indicates an expected call of GetMeshTopology
+func (mr *MockMeshCatalogerMockRecorder) GetMeshTopology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshTopology", reflect.TypeOf((*MockMeshCataloger)(nil).GetMeshTopology))
+}
+
+// GetMirrorPoliciesForService mocks base method
+func (m *MockMeshCataloger) GetMirrorPoliciesForService(arg0 service.MeshService) []trafficpolicy.MirrorPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMirrorPoliciesForService", arg0)
+	ret0, _ := ret[0].([]trafficpolicy.MirrorPolicy)
+	return ret0
+}
+
+// GetMirrorPoliciesForService indicates an expected call of GetMirrorPoliciesForService
+func (mr *MockMeshCatalogerMockRecorder) GetMirrorPoliciesForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMirrorPoliciesForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetMirrorPoliciesForService), arg0)
+}
+
+// GetOutlierDetectionForService mocks base method
+func (m *MockMeshCataloger) GetOutlierDetectionForService(arg0 service.MeshService) *trafficpolicy.OutlierDetection {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutlierDetectionForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.OutlierDetection)
+	return ret0
+}
+
+// GetOutlierDetectionForService indicates an expected call of GetOutlierDetectionForService
+func (mr *MockMeshCatalogerMockRecorder) GetOutlierDetectionForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutlierDetectionForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetOutlierDetectionForService), arg0)
+}
+
+// GetPerConnectionBufferLimitBytesForService mocks base method
+func (m *MockMeshCataloger) GetPerConnectionBufferLimitBytesForService(arg0 service.MeshService) uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerConnectionBufferLimitBytesForService", arg0)
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetPerConnectionBufferLimitBytesForService indicates an expected call of GetPerConnectionBufferLimitBytesForService
+func (mr *MockMeshCatalogerMockRecorder) GetPerConnectionBufferLimitBytesForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerConnectionBufferLimitBytesForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetPerConnectionBufferLimitBytesForService), arg0)
+}
+
 // GetPortToProtocolMappingForService mocks base method
 func (m *MockMeshCataloger) GetPortToProtocolMappingForService(arg0 service.MeshService) (map[uint32]string, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +338,20 @@ func (mr *MockMeshCatalogerMockRecorder) GetPortToProtocolMappingForService(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortToProtocolMappingForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetPortToProtocolMappingForService), arg0)
 }
 
+// GetRequestTimeoutForService mocks base method
+func (m *MockMeshCataloger) GetRequestTimeoutForService(arg0 service.MeshService) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequestTimeoutForService", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetRequestTimeoutForService indicates an expected call of GetRequestTimeoutForService
+func (mr *MockMeshCatalogerMockRecorder) GetRequestTimeoutForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequestTimeoutForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetRequestTimeoutForService), arg0)
+}
+
 // GetResolvableHostnamesForUpstreamService mocks base method
 func (m *MockMeshCataloger) GetResolvableHostnamesForUpstreamService(arg0, arg1 service.MeshService) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +382,20 @@ func (mr *MockMeshCatalogerMockRecorder) GetResolvableServiceEndpoints(arg0 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResolvableServiceEndpoints", reflect.TypeOf((*MockMeshCataloger)(nil).GetResolvableServiceEndpoints), arg0)
 }
 
+// GetRetryPolicyForService mocks base method
+func (m *MockMeshCataloger) GetRetryPolicyForService(arg0 service.MeshService) *trafficpolicy.RetryPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetryPolicyForService", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.RetryPolicy)
+	return ret0
+}
+
+// GetRetryPolicyForService indicates an expected call of GetRetryPolicyForService
+func (mr *MockMeshCatalogerMockRecorder) GetRetryPolicyForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetryPolicyForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetRetryPolicyForService), arg0)
+}
+
 // GetSMISpec mocks base method
 func (m *MockMeshCataloger) GetSMISpec() smi.MeshSpec {
 	m.ctrl.T.Helper()
@@ -173,6 +455,48 @@ func (mr *MockMeshCatalogerMockRecorder) GetTargetPortToProtocolMappingForServic
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetPortToProtocolMappingForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetTargetPortToProtocolMappingForService), arg0)
 }
 
+// GetUpstreamBindSourceAddressForService mocks base method
+func (m *MockMeshCataloger) GetUpstreamBindSourceAddressForService(arg0 service.MeshService) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamBindSourceAddressForService", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetUpstreamBindSourceAddressForService indicates an expected call of GetUpstreamBindSourceAddressForService
+func (mr *MockMeshCatalogerMockRecorder) GetUpstreamBindSourceAddressForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamBindSourceAddressForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetUpstreamBindSourceAddressForService), arg0)
+}
+
+// GetUpstreamIdleTimeoutForService mocks base method
+func (m *MockMeshCataloger) GetUpstreamIdleTimeoutForService(arg0 service.MeshService) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamIdleTimeoutForService", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetUpstreamIdleTimeoutForService indicates an expected call of GetUpstreamIdleTimeoutForService
+func (mr *MockMeshCatalogerMockRecorder) GetUpstreamIdleTimeoutForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamIdleTimeoutForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetUpstreamIdleTimeoutForService), arg0)
+}
+
+// GetUpstreamSNIForService mocks base method
+func (m *MockMeshCataloger) GetUpstreamSNIForService(arg0 service.MeshService) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamSNIForService", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetUpstreamSNIForService indicates an expected call of GetUpstreamSNIForService
+func (mr *MockMeshCatalogerMockRecorder) GetUpstreamSNIForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamSNIForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetUpstreamSNIForService), arg0)
+}
+
 // GetWeightedClusterForService mocks base method
 func (m *MockMeshCataloger) GetWeightedClusterForService(arg0 service.MeshService) (service.WeightedCluster, error) {
 	m.ctrl.T.Helper()
@@ -188,6 +512,120 @@ func (mr *MockMeshCatalogerMockRecorder) GetWeightedClusterForService(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeightedClusterForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetWeightedClusterForService), arg0)
 }
 
+// HasMaxRequestBytesOverrides mocks base method
+func (m *MockMeshCataloger) HasMaxRequestBytesOverrides() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasMaxRequestBytesOverrides")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasMaxRequestBytesOverrides indicates an expected call of HasMaxRequestBytesOverrides
+func (mr *MockMeshCatalogerMockRecorder) HasMaxRequestBytesOverrides() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasMaxRequestBytesOverrides", reflect.TypeOf((*MockMeshCataloger)(nil).HasMaxRequestBytesOverrides))
+}
+
+// IsConfigReadyForProxy mocks base method
+func (m *MockMeshCataloger) IsConfigReadyForProxy(arg0 certificate.CommonName) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConfigReadyForProxy", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsConfigReadyForProxy indicates an expected call of IsConfigReadyForProxy
+func (mr *MockMeshCatalogerMockRecorder) IsConfigReadyForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConfigReadyForProxy", reflect.TypeOf((*MockMeshCataloger)(nil).IsConfigReadyForProxy), arg0)
+}
+
+// IsDNSTTLRespectedForService mocks base method
+func (m *MockMeshCataloger) IsDNSTTLRespectedForService(arg0 service.MeshService) (bool, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDNSTTLRespectedForService", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// IsDNSTTLRespectedForService indicates an expected call of IsDNSTTLRespectedForService
+func (mr *MockMeshCatalogerMockRecorder) IsDNSTTLRespectedForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDNSTTLRespectedForService", reflect.TypeOf((*MockMeshCataloger)(nil).IsDNSTTLRespectedForService), arg0)
+}
+
+// IsExternalTLSOriginationEnabledForService mocks base method
+func (m *MockMeshCataloger) IsExternalTLSOriginationEnabledForService(arg0 service.MeshService) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExternalTLSOriginationEnabledForService", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsExternalTLSOriginationEnabledForService indicates an expected call of IsExternalTLSOriginationEnabledForService
+func (mr *MockMeshCatalogerMockRecorder) IsExternalTLSOriginationEnabledForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExternalTLSOriginationEnabledForService", reflect.TypeOf((*MockMeshCataloger)(nil).IsExternalTLSOriginationEnabledForService), arg0)
+}
+
+// IsOutboundDisabledForService mocks base method
+func (m *MockMeshCataloger) IsOutboundDisabledForService(arg0 service.MeshService) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsOutboundDisabledForService", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsOutboundDisabledForService indicates an expected call of IsOutboundDisabledForService
+func (mr *MockMeshCatalogerMockRecorder) IsOutboundDisabledForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOutboundDisabledForService", reflect.TypeOf((*MockMeshCataloger)(nil).IsOutboundDisabledForService), arg0)
+}
+
+// IsTLSSessionTicketsDisabledForService mocks base method
+func (m *MockMeshCataloger) IsTLSSessionTicketsDisabledForService(arg0 service.MeshService) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTLSSessionTicketsDisabledForService", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTLSSessionTicketsDisabledForService indicates an expected call of IsTLSSessionTicketsDisabledForService
+func (mr *MockMeshCatalogerMockRecorder) IsTLSSessionTicketsDisabledForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTLSSessionTicketsDisabledForService", reflect.TypeOf((*MockMeshCataloger)(nil).IsTLSSessionTicketsDisabledForService), arg0)
+}
+
+// ListAllEndpointsForService mocks base method
+func (m *MockMeshCataloger) ListAllEndpointsForService(arg0 service.MeshService) ([]endpoint.Endpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllEndpointsForService", arg0)
+	ret0, _ := ret[0].([]endpoint.Endpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllEndpointsForService indicates an expected call of ListAllEndpointsForService
+func (mr *MockMeshCatalogerMockRecorder) ListAllEndpointsForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllEndpointsForService", reflect.TypeOf((*MockMeshCataloger)(nil).ListAllEndpointsForService), arg0)
+}
+
+// ListAllowedFailoverServicesForService mocks base method
+func (m *MockMeshCataloger) ListAllowedFailoverServicesForService(arg0 service.K8sServiceAccount, arg1 service.MeshService) []service.MeshService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllowedFailoverServicesForService", arg0, arg1)
+	ret0, _ := ret[0].([]service.MeshService)
+	return ret0
+}
+
+// ListAllowedFailoverServicesForService indicates an expected call of ListAllowedFailoverServicesForService
+func (mr *MockMeshCatalogerMockRecorder) ListAllowedFailoverServicesForService(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllowedFailoverServicesForService", reflect.TypeOf((*MockMeshCataloger)(nil).ListAllowedFailoverServicesForService), arg0, arg1)
+}
+
 // ListAllowedInboundServiceAccounts mocks base method
 func (m *MockMeshCataloger) ListAllowedInboundServiceAccounts(arg0 service.K8sServiceAccount) ([]service.K8sServiceAccount, error) {
 	m.ctrl.T.Helper()
@@ -218,6 +656,20 @@ func (mr *MockMeshCatalogerMockRecorder) ListAllowedInboundServices(arg0 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllowedInboundServices", reflect.TypeOf((*MockMeshCataloger)(nil).ListAllowedInboundServices), arg0)
 }
 
+// ListAllowedMirrorPoliciesForService mocks base method
+func (m *MockMeshCataloger) ListAllowedMirrorPoliciesForService(arg0 service.K8sServiceAccount, arg1 service.MeshService) []trafficpolicy.MirrorPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllowedMirrorPoliciesForService", arg0, arg1)
+	ret0, _ := ret[0].([]trafficpolicy.MirrorPolicy)
+	return ret0
+}
+
+// ListAllowedMirrorPoliciesForService indicates an expected call of ListAllowedMirrorPoliciesForService
+func (mr *MockMeshCatalogerMockRecorder) ListAllowedMirrorPoliciesForService(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllowedMirrorPoliciesForService", reflect.TypeOf((*MockMeshCataloger)(nil).ListAllowedMirrorPoliciesForService), arg0, arg1)
+}
+
 // ListAllowedOutboundServiceAccounts mocks base method
 func (m *MockMeshCataloger) ListAllowedOutboundServiceAccounts(arg0 service.K8sServiceAccount) ([]service.K8sServiceAccount, error) {
 	m.ctrl.T.Helper()
@@ -3,6 +3,8 @@ package catalog
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
@@ -244,11 +246,13 @@ func (mc *MeshCatalog) getHTTPPathsPerRoute() (map[trafficpolicy.TrafficSpecName
 		// since this method gets only specs related to HTTPRouteGroups added HTTPTraffic to the specKey by default
 		specKey := mc.getTrafficSpecName(HTTPTraffic, trafficSpecs.Namespace, trafficSpecs.Name)
 		routePolicies[specKey] = make(map[trafficpolicy.TrafficSpecMatchName]trafficpolicy.HTTPRouteMatch)
+		maxRequestBytes := getMaxRequestBytesOverride(trafficSpecs.Annotations)
 		for _, trafficSpecsMatches := range trafficSpecs.Spec.Matches {
 			serviceRoute := trafficpolicy.HTTPRouteMatch{}
 			serviceRoute.PathRegex = trafficSpecsMatches.PathRegex
 			serviceRoute.Methods = trafficSpecsMatches.Methods
 			serviceRoute.Headers = trafficSpecsMatches.Headers
+			serviceRoute.MaxRequestBytes = maxRequestBytes
 			if len(serviceRoute.Headers) != 0 {
 				// When pathRegex and methods are not defined, the header filters are applied to any path and all HTTP methods
 				if serviceRoute.PathRegex == "" {
@@ -265,6 +269,35 @@ func (mc *MeshCatalog) getHTTPPathsPerRoute() (map[trafficpolicy.TrafficSpecName
 	return routePolicies, nil
 }
 
+// HasMaxRequestBytesOverrides returns true if the routes of any HTTPRouteGroup override the mesh wide limit on the size
+// of requests, in which case the buffer filter enforcing the limits must be programmed even without a mesh wide limit.
+func (mc *MeshCatalog) HasMaxRequestBytesOverrides() bool {
+	for _, trafficSpecs := range mc.meshSpec.ListHTTPTrafficSpecs() {
+		if getMaxRequestBytesOverride(trafficSpecs.Annotations) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getMaxRequestBytesOverride returns the request size limit overriding the mesh wide limit for the routes of an HTTPRouteGroup
+// with the given annotations, or nil if the limit is not overridden
+func getMaxRequestBytesOverride(annotations map[string]string) *uint32 {
+	maxRequestBytesStr, ok := annotations[constants.MaxRequestBytesAnnotation]
+	if !ok {
+		return nil
+	}
+
+	maxRequestBytes, err := strconv.ParseUint(maxRequestBytesStr, 10, 32)
+	if err != nil {
+		log.Error().Err(err).Msgf("Ignoring invalid value %q for annotation %s", maxRequestBytesStr, constants.MaxRequestBytesAnnotation)
+		return nil
+	}
+
+	override := uint32(maxRequestBytes)
+	return &override
+}
+
 func (mc *MeshCatalog) getTrafficSpecName(trafficSpecKind string, trafficSpecNamespace string, trafficSpecName string) trafficpolicy.TrafficSpecName {
 	specKey := fmt.Sprintf("%s/%s/%s", trafficSpecKind, trafficSpecNamespace, trafficSpecName)
 	return trafficpolicy.TrafficSpecName(specKey)
@@ -445,7 +478,9 @@ func (mc *MeshCatalog) listTrafficTargetPermutations(trafficTarget access.Traffi
 }
 
 // routesFromRules takes a set of traffic target rules and the namespace of the traffic target and returns a list of
-//	http route matches (trafficpolicy.HTTPRouteMatch)
+//	http route matches (trafficpolicy.HTTPRouteMatch). The routes of all the HTTPRouteGroups referenced by the rules are
+//	unioned, so that a source is allowed the routes of every group it is granted. A rule without matches grants all
+//	the routes of its group.
 func (mc *MeshCatalog) routesFromRules(rules []access.TrafficTargetRule, trafficTargetNamespace string) ([]trafficpolicy.HTTPRouteMatch, error) {
 	routes := []trafficpolicy.HTTPRouteMatch{}
 
@@ -460,13 +495,28 @@ func (mc *MeshCatalog) routesFromRules(rules []access.TrafficTargetRule, traffic
 	}
 
 	for _, rule := range rules {
-		trafficSpecName := mc.getTrafficSpecName("HTTPRouteGroup", trafficTargetNamespace, rule.Name)
-		for _, match := range rule.Matches {
+		if rule.Kind != HTTPTraffic {
+			continue
+		}
+
+		trafficSpecName := mc.getTrafficSpecName(HTTPTraffic, trafficTargetNamespace, rule.Name)
+		matchNames := rule.Matches
+		if len(matchNames) == 0 {
+			// The rule does not restrict the routes of the group, so consider all of them
+			for matchName := range specMatchRoute[trafficSpecName] {
+				matchNames = append(matchNames, string(matchName))
+			}
+			sort.Strings(matchNames)
+		}
+
+		for _, match := range matchNames {
 			matchedRoute, found := specMatchRoute[trafficSpecName][trafficpolicy.TrafficSpecMatchName(match)]
-			if found {
-				routes = append(routes, matchedRoute)
-			} else {
+			if !found {
 				log.Debug().Msgf("No matching trafficpolicy.HTTPRoute found for match name %s in Traffic Spec %s (in namespace %s)", match, trafficSpecName, trafficTargetNamespace)
+				continue
+			}
+			if !containsHTTPRouteMatch(routes, matchedRoute) {
+				routes = append(routes, matchedRoute)
 			}
 		}
 	}
@@ -474,6 +524,16 @@ func (mc *MeshCatalog) routesFromRules(rules []access.TrafficTargetRule, traffic
 	return routes, nil
 }
 
+// containsHTTPRouteMatch returns true if the given route match is in the given list of route matches
+func containsHTTPRouteMatch(routes []trafficpolicy.HTTPRouteMatch, route trafficpolicy.HTTPRouteMatch) bool {
+	for _, existing := range routes {
+		if reflect.DeepEqual(existing, route) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetServicesForServiceAccounts returns a list of services corresponding to a list service accounts
 //	TODO: Consider merging this function and mc.GetServicesForServiceAccount in future (#2038)
 func (mc *MeshCatalog) GetServicesForServiceAccounts(saList []service.K8sServiceAccount) []service.MeshService {
@@ -228,6 +228,48 @@ func TestRoutesFromRules(t *testing.T) {
 			namespace:      tests.Namespace,
 			expectedRoutes: []trafficpolicy.HTTPRouteMatch{},
 		},
+		{
+			name: "http route group without match names",
+			rules: []access.TrafficTargetRule{
+				{
+					Kind: "HTTPRouteGroup",
+					Name: tests.RouteGroupName,
+				},
+			},
+			namespace: tests.Namespace,
+			expectedRoutes: []trafficpolicy.HTTPRouteMatch{
+				{
+					PathRegex: constants.RegexMatchAll,
+					Methods:   []string{constants.WildcardHTTPMethod},
+					Headers: map[string]string{
+						"user-agent": tests.HTTPUserAgent,
+					},
+				},
+				tests.BookstoreBuyHTTPRoute,
+				tests.BookstoreSellHTTPRoute,
+			},
+		},
+		{
+			name: "rules referencing the same routes and a TCP route",
+			rules: []access.TrafficTargetRule{
+				{
+					Kind:    "HTTPRouteGroup",
+					Name:    tests.RouteGroupName,
+					Matches: []string{tests.BuyBooksMatchName},
+				},
+				{
+					Kind:    "HTTPRouteGroup",
+					Name:    tests.RouteGroupName,
+					Matches: []string{tests.BuyBooksMatchName, tests.SellBooksMatchName},
+				},
+				{
+					Kind: "TCPRoute",
+					Name: tests.RouteGroupName,
+				},
+			},
+			namespace:      tests.Namespace,
+			expectedRoutes: []trafficpolicy.HTTPRouteMatch{tests.BookstoreBuyHTTPRoute, tests.BookstoreSellHTTPRoute},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1047,6 +1089,119 @@ func TestBuildInboundPoliciesSameNamespace(t *testing.T) {
 	assert.ElementsMatch(expectedPolicies, actual)
 }
 
+func TestBuildInboundPoliciesMultipleRouteGroups(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
+
+	mc := MeshCatalog{
+		kubeController:     mockKubeController,
+		meshSpec:           mockMeshSpec,
+		endpointsProviders: []endpoint.Provider{mockEndpointProvider},
+	}
+
+	sourceSA := service.K8sServiceAccount{
+		Name:      "bookbuyer",
+		Namespace: "default",
+	}
+	destSA := service.K8sServiceAccount{
+		Name:      "bookstore",
+		Namespace: "default",
+	}
+
+	destMeshService := service.MeshService{
+		Name:      "bookstore",
+		Namespace: "default",
+	}
+
+	destK8sService := tests.NewServiceFixture(destMeshService.Name, destMeshService.Namespace, map[string]string{})
+
+	buyGroup := spec.HTTPRouteGroup{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "default",
+			Name:      "buy-routes",
+		},
+		Spec: spec.HTTPRouteGroupSpec{
+			Matches: []spec.HTTPMatch{
+				{
+					Name:      tests.BuyBooksMatchName,
+					PathRegex: tests.BookstoreBuyPath,
+					Methods:   []string{"GET"},
+					Headers: map[string]string{
+						"user-agent": tests.HTTPUserAgent,
+					},
+				},
+			},
+		},
+	}
+	sellGroup := spec.HTTPRouteGroup{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "default",
+			Name:      "sell-routes",
+		},
+		Spec: spec.HTTPRouteGroupSpec{
+			Matches: []spec.HTTPMatch{
+				{
+					Name:      tests.SellBooksMatchName,
+					PathRegex: tests.BookstoreSellPath,
+					Methods:   []string{"GET"},
+					Headers: map[string]string{
+						"user-agent": tests.HTTPUserAgent,
+					},
+				},
+			},
+		},
+	}
+
+	mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return([]*specs.HTTPRouteGroup{&buyGroup, &sellGroup}).AnyTimes()
+	mockEndpointProvider.EXPECT().GetServicesForServiceAccount(destSA).Return([]service.MeshService{destMeshService}, nil).AnyTimes()
+	mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
+	mockKubeController.EXPECT().GetService(destMeshService).Return(destK8sService).AnyTimes()
+
+	// The traffic target grants the routes of both groups, the second one without restricting its matches
+	trafficTarget := tests.NewSMITrafficTarget(sourceSA.Name, sourceSA.Namespace, destSA.Name, destSA.Namespace)
+	trafficTarget.Spec.Rules = []access.TrafficTargetRule{
+		{
+			Kind:    "HTTPRouteGroup",
+			Name:    buyGroup.Name,
+			Matches: []string{tests.BuyBooksMatchName},
+		},
+		{
+			Kind: "HTTPRouteGroup",
+			Name: sellGroup.Name,
+		},
+	}
+
+	bookstoreWeightedCluster := service.WeightedCluster{
+		ClusterName: "default/bookstore",
+		Weight:      100,
+	}
+	expectedRules := []*trafficpolicy.Rule{
+		{
+			Route: trafficpolicy.RouteWeightedClusters{
+				HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+				WeightedClusters: mapset.NewSet(bookstoreWeightedCluster),
+			},
+			AllowedServiceAccounts: mapset.NewSet(sourceSA),
+		},
+		{
+			Route: trafficpolicy.RouteWeightedClusters{
+				HTTPRouteMatch:   tests.BookstoreSellHTTPRoute,
+				WeightedClusters: mapset.NewSet(bookstoreWeightedCluster),
+			},
+			AllowedServiceAccounts: mapset.NewSet(sourceSA),
+		},
+	}
+
+	actual := mc.buildInboundPolicies(&trafficTarget)
+	assert.Len(actual, 1)
+	assert.ElementsMatch(expectedRules, actual[0].Rules)
+}
+
 func TestListPoliciesFromTrafficTargets(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -1276,3 +1431,60 @@ func TestBuildPolicyName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMaxRequestBytesOverride(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Nil(getMaxRequestBytesOverride(nil))
+	assert.Nil(getMaxRequestBytesOverride(map[string]string{constants.MaxRequestBytesAnnotation: "-1"}))
+	assert.Nil(getMaxRequestBytesOverride(map[string]string{constants.MaxRequestBytesAnnotation: "10MB"}))
+
+	override := getMaxRequestBytesOverride(map[string]string{constants.MaxRequestBytesAnnotation: "10485760"})
+	assert.NotNil(override)
+	assert.Equal(uint32(10485760), *override)
+
+	override = getMaxRequestBytesOverride(map[string]string{constants.MaxRequestBytesAnnotation: "0"})
+	assert.NotNil(override)
+	assert.Equal(uint32(0), *override)
+}
+
+func TestHasMaxRequestBytesOverrides(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:        "no HTTPRouteGroup overrides the limit",
+			annotations: nil,
+			expected:    false,
+		},
+		{
+			name:        "an invalid override is ignored",
+			annotations: map[string]string{constants.MaxRequestBytesAnnotation: "10MB"},
+			expected:    false,
+		},
+		{
+			name:        "an HTTPRouteGroup overrides the limit",
+			annotations: map[string]string{constants.MaxRequestBytesAnnotation: "10485760"},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+			mc := MeshCatalog{meshSpec: mockMeshSpec}
+
+			routeGroup := tests.HTTPRouteGroup
+			routeGroup.Annotations = tc.annotations
+			mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return([]*specs.HTTPRouteGroup{&routeGroup}).Times(1)
+
+			assert.Equal(tc.expected, mc.HasMaxRequestBytesOverrides())
+		})
+	}
+}
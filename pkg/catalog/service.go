@@ -1,15 +1,69 @@
 package catalog
 
 import (
+	"encoding/hex"
+	"math"
+	"net"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	mapset "github.com/deckarep/golang-set"
 	"github.com/pkg/errors"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
+const (
+	// defaultMirrorPercentage is the percentage of requests mirrored to the shadow service of a service when not specified
+	defaultMirrorPercentage = 100.0
+
+	// maxPercentage is the maximum value of annotations specifying a percentage as an integer
+	maxPercentage = 100
+
+	// minHTTPStatusCode and maxHTTPStatusCode are the bounds of the HTTP status codes of direct responses
+	minHTTPStatusCode = 200
+	maxHTTPStatusCode = 599
+
+	// maxDirectResponseBodyBytes is the maximum size of the body of direct responses accepted by Envoy
+	maxDirectResponseBodyBytes = 4096
+
+	// maxAccessLogSamplingPercentage is the percentage of requests logged when all requests are logged
+	maxAccessLogSamplingPercentage = 100
+
+	// hashPolicyHeader, hashPolicyCookie and hashPolicySourceIP are the request attributes a hash policy can hash
+	hashPolicyHeader   = "header"
+	hashPolicyCookie   = "cookie"
+	hashPolicySourceIP = "source-ip"
+
+	// retryOnRetriableStatusCodes is the retry condition retrying requests answered with one of the retriable status codes
+	retryOnRetriableStatusCodes = "retriable-status-codes"
+)
+
+// validRetryOnConditions are the Envoy HTTP and gRPC retry conditions a retry policy can retry requests on
+var validRetryOnConditions = map[string]bool{
+	"5xx":                       true,
+	"gateway-error":             true,
+	"reset":                     true,
+	"connect-failure":           true,
+	"envoy-ratelimited":         true,
+	"retriable-4xx":             true,
+	"refused-stream":            true,
+	retryOnRetriableStatusCodes: true,
+	"retriable-headers":         true,
+	"cancelled":                 true,
+	"deadline-exceeded":         true,
+	"internal":                  true,
+	"resource-exhausted":        true,
+	"unavailable":               true,
+}
+
 // GetServicesForServiceAccount returns a list of services corresponding to a service account
 func (mc *MeshCatalog) GetServicesForServiceAccount(sa service.K8sServiceAccount) ([]service.MeshService, error) {
 	var services []service.MeshService
@@ -92,3 +146,744 @@ func (mc *MeshCatalog) GetPortToProtocolMappingForService(svc service.MeshServic
 
 	return portToProtocolMap, nil
 }
+
+// GetUpstreamSNIForService returns the SNI override for connections to the given upstream service, or an empty string if none is set.
+// The override is specified using an annotation on the Kubernetes service.
+func (mc *MeshCatalog) GetUpstreamSNIForService(svc service.MeshService) string {
+	return mc.getServiceAnnotations(svc)[constants.UpstreamSNIAnnotation]
+}
+
+// IsExternalTLSOriginationEnabledForService returns whether the downstream proxies of the given service, which
+// represents a destination outside the mesh, originate TLS to it validated with the CA bundle of the sidecar instead of
+// mesh mTLS, as set by the service's annotation
+func (mc *MeshCatalog) IsExternalTLSOriginationEnabledForService(svc service.MeshService) bool {
+	value, _ := getBoolAnnotation(mc.getServiceAnnotations(svc), constants.ExternalTLSOriginationAnnotation, svc)
+	return value
+}
+
+// GetFailoverServicesForService returns the ordered list of services that traffic to the given upstream service fails over to
+// when it is unhealthy, or nil if failover is not configured for the service. The failover services are specified using an
+// annotation on the Kubernetes service, as a comma separated list of '<namespace>/<name>' or '<name>' entries, where the
+// namespace defaults to the namespace of the given service.
+func (mc *MeshCatalog) GetFailoverServicesForService(svc service.MeshService) []service.MeshService {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+	failoverStr, ok := k8sSvc.Annotations[constants.FailoverServicesAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var failoverServices []service.MeshService
+	seen := map[service.MeshService]bool{svc: true}
+	for _, entry := range strings.Split(failoverStr, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		failoverSvc := service.MeshService{Namespace: svc.Namespace, Name: entry}
+		if strings.Contains(entry, "/") {
+			meshSvc, err := service.UnmarshalMeshService(entry)
+			if err != nil {
+				log.Error().Err(err).Msgf("Ignoring invalid failover service %q for service %s", entry, svc)
+				continue
+			}
+			failoverSvc = *meshSvc
+		}
+
+		if seen[failoverSvc] {
+			continue
+		}
+		seen[failoverSvc] = true
+		failoverServices = append(failoverServices, failoverSvc)
+	}
+
+	return failoverServices
+}
+
+// ListAllowedFailoverServicesForService returns the ordered list of services that traffic to the given upstream service
+// fails over to and the given downstream identity is allowed to access, so that the downstream proxies only fail over to
+// services granted to them by traffic targets
+func (mc *MeshCatalog) ListAllowedFailoverServicesForService(downstreamIdentity service.K8sServiceAccount, svc service.MeshService) []service.MeshService {
+	failoverServices := mc.GetFailoverServicesForService(svc)
+	if len(failoverServices) == 0 {
+		return nil
+	}
+
+	allowedServices := mapset.NewSet()
+	for _, allowedSvc := range mc.ListAllowedOutboundServicesForIdentity(downstreamIdentity) {
+		allowedServices.Add(allowedSvc)
+	}
+
+	var allowedFailoverServices []service.MeshService
+	for _, failoverSvc := range failoverServices {
+		if !allowedServices.Contains(failoverSvc) {
+			log.Debug().Msgf("Not failing over from service %s to service %s, identity %s is not allowed to access it", svc, failoverSvc, downstreamIdentity)
+			continue
+		}
+		allowedFailoverServices = append(allowedFailoverServices, failoverSvc)
+	}
+	return allowedFailoverServices
+}
+
+// GetMirrorPoliciesForService returns the policies mirroring requests to the given upstream service to shadow services,
+// or nil if mirroring is not configured for the service. The shadow services are specified using an annotation on the
+// Kubernetes service as a comma separated list of '<namespace>/<name>' or '<name>' entries, where the namespace defaults
+// to the namespace of the given service. Each entry can be followed by ':<percentage>' to set the percentage of requests
+// mirrored to its shadow service, which otherwise defaults to 100 unless specified using an annotation.
+func (mc *MeshCatalog) GetMirrorPoliciesForService(svc service.MeshService) []trafficpolicy.MirrorPolicy {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+	mirrorsStr := strings.TrimSpace(k8sSvc.Annotations[constants.MirrorServiceAnnotation])
+	if mirrorsStr == "" {
+		return nil
+	}
+
+	defaultPercentage := defaultMirrorPercentage
+	if percentageStr, ok := k8sSvc.Annotations[constants.MirrorPercentageAnnotation]; ok {
+		parsed, err := parseMirrorPercentage(percentageStr)
+		if err != nil {
+			log.Error().Err(err).Msgf("Ignoring invalid mirror percentage %q for service %s, mirroring %v%% of requests", percentageStr, svc, defaultMirrorPercentage)
+		} else {
+			defaultPercentage = parsed
+		}
+	}
+
+	var mirrorPolicies []trafficpolicy.MirrorPolicy
+	for _, mirrorEntry := range strings.Split(mirrorsStr, ",") {
+		mirrorStr := strings.TrimSpace(mirrorEntry)
+		if mirrorStr == "" {
+			continue
+		}
+
+		percentage := defaultPercentage
+		if i := strings.LastIndex(mirrorStr, ":"); i >= 0 {
+			percentageStr := mirrorStr[i+1:]
+			mirrorStr = strings.TrimSpace(mirrorStr[:i])
+			parsed, err := parseMirrorPercentage(percentageStr)
+			if err != nil {
+				log.Error().Err(err).Msgf("Ignoring invalid mirror percentage %q of mirror service %q for service %s, mirroring %v%% of requests", percentageStr, mirrorStr, svc, defaultPercentage)
+			} else {
+				percentage = parsed
+			}
+		}
+
+		mirrorSvc := service.MeshService{Namespace: svc.Namespace, Name: mirrorStr}
+		if strings.Contains(mirrorStr, "/") {
+			meshSvc, err := service.UnmarshalMeshService(mirrorStr)
+			if err != nil {
+				log.Error().Err(err).Msgf("Ignoring invalid mirror service %q for service %s", mirrorStr, svc)
+				continue
+			}
+			mirrorSvc = *meshSvc
+		}
+		if mirrorSvc == svc {
+			log.Error().Msgf("Ignoring mirror service for service %s, a service cannot be mirrored to itself", svc)
+			continue
+		}
+		if containsMirrorService(mirrorPolicies, mirrorSvc) {
+			log.Error().Msgf("Ignoring duplicate mirror service %s for service %s", mirrorSvc, svc)
+			continue
+		}
+
+		mirrorPolicies = append(mirrorPolicies, trafficpolicy.MirrorPolicy{
+			Service:    mirrorSvc,
+			Percentage: percentage,
+		})
+	}
+
+	return mirrorPolicies
+}
+
+// ListAllowedMirrorPoliciesForService returns the policies mirroring requests to the given upstream service to the shadow
+// services the given downstream identity is allowed to access, so that requests are only mirrored by the downstream
+// proxies to shadow services granted to them by traffic targets
+func (mc *MeshCatalog) ListAllowedMirrorPoliciesForService(downstreamIdentity service.K8sServiceAccount, svc service.MeshService) []trafficpolicy.MirrorPolicy {
+	mirrorPolicies := mc.GetMirrorPoliciesForService(svc)
+	if len(mirrorPolicies) == 0 {
+		return nil
+	}
+
+	allowedServices := mapset.NewSet()
+	for _, allowedSvc := range mc.ListAllowedOutboundServicesForIdentity(downstreamIdentity) {
+		allowedServices.Add(allowedSvc)
+	}
+
+	var allowedMirrorPolicies []trafficpolicy.MirrorPolicy
+	for _, mirrorPolicy := range mirrorPolicies {
+		if !allowedServices.Contains(mirrorPolicy.Service) {
+			log.Debug().Msgf("Not mirroring requests to service %s to mirror service %s, identity %s is not allowed to access it", svc, mirrorPolicy.Service, downstreamIdentity)
+			continue
+		}
+		allowedMirrorPolicies = append(allowedMirrorPolicies, mirrorPolicy)
+	}
+	return allowedMirrorPolicies
+}
+
+// parseMirrorPercentage parses the given percentage of requests to mirror, between 0 and 100
+func parseMirrorPercentage(percentageStr string) (float64, error) {
+	percentage, err := strconv.ParseFloat(strings.TrimSpace(percentageStr), 64)
+	if err != nil {
+		return 0, err
+	}
+	if percentage < 0 || percentage > 100 {
+		return 0, errors.Errorf("mirror percentage %v out of range [0, 100]", percentage)
+	}
+	return percentage, nil
+}
+
+// containsMirrorService returns true if one of the given mirror policies mirrors requests to the given service
+func containsMirrorService(mirrorPolicies []trafficpolicy.MirrorPolicy, svc service.MeshService) bool {
+	for _, mirrorPolicy := range mirrorPolicies {
+		if mirrorPolicy.Service == svc {
+			return true
+		}
+	}
+	return false
+}
+
+// GetOutlierDetectionForService returns the overrides of the outlier detection of the endpoints of the given upstream
+// service, or nil if none are set. The overrides are specified using annotations on the Kubernetes service, letting
+// services with few replicas limit the percentage of their endpoints that can be ejected, and services eject their
+// endpoints for connection failures separately from their 5xx responses.
+func (mc *MeshCatalog) GetOutlierDetectionForService(svc service.MeshService) *trafficpolicy.OutlierDetection {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+
+	outlierDetection := &trafficpolicy.OutlierDetection{
+		Consecutive5xx:                         getUint32Annotation(k8sSvc.Annotations, constants.OutlierDetectionConsecutive5xxAnnotation, 0, math.MaxUint32, svc),
+		MaxEjectionPercent:                     getUint32Annotation(k8sSvc.Annotations, constants.OutlierDetectionMaxEjectionPercentAnnotation, 0, maxPercentage, svc),
+		EnforcingConsecutive5xx:                getUint32Annotation(k8sSvc.Annotations, constants.OutlierDetectionEnforcingConsecutive5xxAnnotation, 0, maxPercentage, svc),
+		SplitExternalLocalOriginErrors:         isLocalOriginErrorSplit(k8sSvc.Annotations, svc),
+		ConsecutiveLocalOriginFailure:          getUint32Annotation(k8sSvc.Annotations, constants.OutlierDetectionConsecutiveLocalOriginFailureAnnotation, 0, math.MaxUint32, svc),
+		EnforcingConsecutiveLocalOriginFailure: getUint32Annotation(k8sSvc.Annotations, constants.OutlierDetectionEnforcingConsecutiveLocalOriginFailureAnnotation, 0, maxPercentage, svc),
+	}
+	if outlierDetection.Consecutive5xx == nil && outlierDetection.MaxEjectionPercent == nil && outlierDetection.EnforcingConsecutive5xx == nil &&
+		!outlierDetection.SplitExternalLocalOriginErrors && outlierDetection.ConsecutiveLocalOriginFailure == nil && outlierDetection.EnforcingConsecutiveLocalOriginFailure == nil {
+		return nil
+	}
+
+	return outlierDetection
+}
+
+// isLocalOriginErrorSplit returns whether the outlier detection of the given service counts local-origin failures
+// separately from 5xx responses, as set by the service's annotation. An invalid value is ignored.
+func isLocalOriginErrorSplit(annotations map[string]string, svc service.MeshService) bool {
+	split, _ := getBoolAnnotation(annotations, constants.OutlierDetectionSplitExternalLocalOriginErrorsAnnotation, svc)
+	return split
+}
+
+// GetCircuitBreakingForService returns the circuit breaker thresholds of the given upstream service for the default
+// and high routing priorities, which are specified using annotations on the Kubernetes service. It returns nil if no
+// threshold is set, and leaves a priority nil if none of its thresholds is set.
+func (mc *MeshCatalog) GetCircuitBreakingForService(svc service.MeshService) *trafficpolicy.CircuitBreaking {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+
+	circuitBreaking := &trafficpolicy.CircuitBreaking{
+		Default: getCircuitBreakerThresholds(k8sSvc.Annotations, svc,
+			constants.CircuitBreakerMaxConnectionsAnnotation,
+			constants.CircuitBreakerMaxPendingRequestsAnnotation,
+			constants.CircuitBreakerMaxRequestsAnnotation,
+			constants.CircuitBreakerMaxRetriesAnnotation),
+		High: getCircuitBreakerThresholds(k8sSvc.Annotations, svc,
+			constants.CircuitBreakerHighPriorityMaxConnectionsAnnotation,
+			constants.CircuitBreakerHighPriorityMaxPendingRequestsAnnotation,
+			constants.CircuitBreakerHighPriorityMaxRequestsAnnotation,
+			constants.CircuitBreakerHighPriorityMaxRetriesAnnotation),
+	}
+	if circuitBreaking.Default == nil && circuitBreaking.High == nil {
+		return nil
+	}
+
+	return circuitBreaking
+}
+
+// getCircuitBreakerThresholds returns the circuit breaker thresholds set by the given annotations, or nil if none is set
+func getCircuitBreakerThresholds(annotations map[string]string, svc service.MeshService, maxConnectionsAnnotation, maxPendingRequestsAnnotation, maxRequestsAnnotation, maxRetriesAnnotation string) *trafficpolicy.CircuitBreakerThresholds {
+	thresholds := &trafficpolicy.CircuitBreakerThresholds{
+		MaxConnections:     getUint32Annotation(annotations, maxConnectionsAnnotation, 0, math.MaxUint32, svc),
+		MaxPendingRequests: getUint32Annotation(annotations, maxPendingRequestsAnnotation, 0, math.MaxUint32, svc),
+		MaxRequests:        getUint32Annotation(annotations, maxRequestsAnnotation, 0, math.MaxUint32, svc),
+		MaxRetries:         getUint32Annotation(annotations, maxRetriesAnnotation, 0, math.MaxUint32, svc),
+	}
+	if thresholds.MaxConnections == nil && thresholds.MaxPendingRequests == nil && thresholds.MaxRequests == nil && thresholds.MaxRetries == nil {
+		return nil
+	}
+	return thresholds
+}
+
+// GetHealthCheckForService returns the active health checking of the endpoints of the given upstream service, which is
+// enabled by setting the health check interval using an annotation on the Kubernetes service. The endpoints of a service
+// whose ports are all TCP ports are checked with TCP health checks, and the endpoints of other services with HTTP
+// health checks. It returns nil if health checking is not enabled.
+func (mc *MeshCatalog) GetHealthCheckForService(svc service.MeshService) *trafficpolicy.HealthCheck {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+	interval := getDurationAnnotation(k8sSvc.Annotations, constants.HealthCheckIntervalAnnotation, svc)
+	if interval == 0 {
+		return nil
+	}
+
+	healthCheck := &trafficpolicy.HealthCheck{
+		Interval: interval,
+		TCP:      len(k8sSvc.Spec.Ports) > 0,
+	}
+	for _, portSpec := range k8sSvc.Spec.Ports {
+		appProtocol := kubernetes.GetAppProtocolFromPortName(portSpec.Name)
+		if portSpec.AppProtocol != nil {
+			appProtocol = *portSpec.AppProtocol
+		}
+		if appProtocol != "tcp" {
+			healthCheck.TCP = false
+		}
+	}
+
+	if !healthCheck.TCP {
+		healthCheck.Path = k8sSvc.Annotations[constants.HealthCheckPathAnnotation]
+		if expectedStatusesStr, ok := k8sSvc.Annotations[constants.HealthCheckExpectedStatusesAnnotation]; ok {
+			expectedStatuses, err := parseStatusCodeRanges(expectedStatusesStr)
+			if err != nil {
+				log.Error().Err(err).Msgf("Ignoring invalid value %q of annotation %s for service %s, must be a comma separated list of HTTP status codes or ranges of status codes between %d and %d", expectedStatusesStr, constants.HealthCheckExpectedStatusesAnnotation, svc, minHTTPStatusCode, maxHTTPStatusCode)
+			}
+			healthCheck.ExpectedStatuses = expectedStatuses
+		}
+		return healthCheck
+	}
+	healthCheck.Send = getHexPayloadAnnotation(k8sSvc.Annotations, constants.HealthCheckSendAnnotation, svc)
+	healthCheck.Receive = getHexPayloadAnnotation(k8sSvc.Annotations, constants.HealthCheckReceiveAnnotation, svc)
+	return healthCheck
+}
+
+// parseStatusCodeRanges parses the given comma separated list of HTTP status codes and inclusive ranges of status codes
+// of the form <start>-<end>
+func parseStatusCodeRanges(rangesStr string) ([]trafficpolicy.StatusCodeRange, error) {
+	var statusCodeRanges []trafficpolicy.StatusCodeRange
+	for _, rangeStr := range strings.Split(rangesStr, ",") {
+		startStr, endStr := strings.TrimSpace(rangeStr), strings.TrimSpace(rangeStr)
+		if i := strings.Index(rangeStr, "-"); i >= 0 {
+			startStr, endStr = strings.TrimSpace(rangeStr[:i]), strings.TrimSpace(rangeStr[i+1:])
+		}
+
+		start, err := strconv.ParseUint(startStr, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		end, err := strconv.ParseUint(endStr, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if start < minHTTPStatusCode || end > maxHTTPStatusCode || start > end {
+			return nil, errors.Errorf("invalid status code range %q", rangeStr)
+		}
+		statusCodeRanges = append(statusCodeRanges, trafficpolicy.StatusCodeRange{Start: uint32(start), End: uint32(end)})
+	}
+	return statusCodeRanges, nil
+}
+
+// getHexPayloadAnnotation returns the hex encoded payload set by the given annotation, or an empty string if the
+// annotation is not set or is not hex encoded
+func getHexPayloadAnnotation(annotations map[string]string, annotation string, svc service.MeshService) string {
+	payload := strings.TrimSpace(annotations[annotation])
+	if _, err := hex.DecodeString(payload); err != nil {
+		log.Error().Err(err).Msgf("Ignoring invalid payload %q of annotation %s for service %s, must be hex encoded", payload, annotation, svc)
+		return ""
+	}
+	return payload
+}
+
+// IsOutboundDisabledForService returns true if the proxies of the given service must not be programmed with the
+// outbound filter chains and clusters of their upstreams, which is specified using an annotation on the Kubernetes service. This removes the
+// overhead of outbound config from ingress-only workloads, such as API gateways, that never originate mesh traffic.
+// The default filter chain of the outbound listener is kept, as outbound traffic keeps being redirected to the proxy.
+func (mc *MeshCatalog) IsOutboundDisabledForService(svc service.MeshService) bool {
+	value, _ := getBoolAnnotation(mc.getServiceAnnotations(svc), constants.OutboundDisabledAnnotation, svc)
+	return value
+}
+
+// GetAllowedSourceIPRangesForService returns the source IP CIDR ranges allowed to connect to the given service, as set
+// by the service's annotation. The sources must connect from the ranges in addition to being allowed by their identity,
+// unless connections from the ranges bypass mTLS. Invalid CIDR ranges are ignored.
+func (mc *MeshCatalog) GetAllowedSourceIPRangesForService(svc service.MeshService) []string {
+	rangesStr, ok := mc.getServiceAnnotations(svc)[constants.AllowedSourceIPRangesAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var ranges []string
+	for _, cidr := range strings.Split(rangesStr, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			log.Error().Err(err).Msgf("Ignoring invalid CIDR range %q of annotation %s for service %s", cidr, constants.AllowedSourceIPRangesAnnotation, svc)
+			continue
+		}
+		ranges = append(ranges, cidr)
+	}
+	return ranges
+}
+
+// GetClusterTypeForService returns the type of the clusters of the given service on its downstream proxies, as set by
+// the service's annotation, or an empty string if the annotation is not set or invalid
+func (mc *MeshCatalog) GetClusterTypeForService(svc service.MeshService) string {
+	clusterTypeStr, ok := mc.getServiceAnnotations(svc)[constants.ClusterTypeAnnotation]
+	if !ok {
+		return ""
+	}
+
+	clusterType := strings.ToUpper(strings.TrimSpace(clusterTypeStr))
+	if !configurator.IsValidClusterType(clusterType) {
+		log.Error().Msgf("Ignoring invalid value %q of annotation %s for service %s, must be one of %v", clusterTypeStr, constants.ClusterTypeAnnotation, svc, configurator.ValidClusterTypes)
+		return ""
+	}
+	return clusterType
+}
+
+// GetUpstreamBindSourceAddressForService returns the source address the downstream proxies of the given service bind
+// their connections to the service to, as set by the service's annotation, or an empty string if the annotation is not
+// set or invalid
+func (mc *MeshCatalog) GetUpstreamBindSourceAddressForService(svc service.MeshService) string {
+	address, ok := mc.getServiceAnnotations(svc)[constants.UpstreamBindSourceAddressAnnotation]
+	if !ok {
+		return ""
+	}
+
+	address = strings.TrimSpace(address)
+	if net.ParseIP(address) == nil {
+		log.Error().Msgf("Ignoring invalid IP address %q of annotation %s for service %s", address, constants.UpstreamBindSourceAddressAnnotation, svc)
+		return ""
+	}
+	return address
+}
+
+// GetDNSRefreshRateForService returns the rate at which the downstream proxies of the given service resolve the DNS name
+// of the service's DNS clusters, as set by the service's annotation, or 0 if not set
+func (mc *MeshCatalog) GetDNSRefreshRateForService(svc service.MeshService) time.Duration {
+	return getDurationAnnotation(mc.getServiceAnnotations(svc), constants.DNSRefreshRateAnnotation, svc)
+}
+
+// GetUpstreamIdleTimeoutForService returns the time after which the connections of the downstream proxies of the given
+// service to the service are closed when they have no active requests, as set by the service's annotation, or 0 if not set
+func (mc *MeshCatalog) GetUpstreamIdleTimeoutForService(svc service.MeshService) time.Duration {
+	return getDurationAnnotation(mc.getServiceAnnotations(svc), constants.UpstreamIdleTimeoutAnnotation, svc)
+}
+
+// GetRequestTimeoutForService returns the time the downstream proxies of the given service wait for the response to a
+// request to the service, as set by the service's annotation, or 0 if not set
+func (mc *MeshCatalog) GetRequestTimeoutForService(svc service.MeshService) time.Duration {
+	return getDurationAnnotation(mc.getServiceAnnotations(svc), constants.RequestTimeoutAnnotation, svc)
+}
+
+// IsDNSTTLRespectedForService returns whether the downstream proxies of the given service resolve the DNS name of the
+// service's DNS clusters again once their DNS records expire, as set by the service's annotation. The second return
+// value is false if the service does not set it.
+func (mc *MeshCatalog) IsDNSTTLRespectedForService(svc service.MeshService) (bool, bool) {
+	return getBoolAnnotation(mc.getServiceAnnotations(svc), constants.RespectDNSTTLAnnotation, svc)
+}
+
+// GetHTTP2MaxConcurrentStreamsForService returns the maximum number of concurrent streams the proxies of the given
+// service accept on a single inbound HTTP/2 connection, as set by the service's annotation, or 0 if not set, in which
+// case Envoy's default applies
+func (mc *MeshCatalog) GetHTTP2MaxConcurrentStreamsForService(svc service.MeshService) uint32 {
+	// Envoy accepts between 1 and 2^31 - 1 concurrent streams
+	if maxStreams := getUint32Annotation(mc.getServiceAnnotations(svc), constants.HTTP2MaxConcurrentStreamsAnnotation, 1, math.MaxInt32, svc); maxStreams != nil {
+		return *maxStreams
+	}
+	return 0
+}
+
+// GetAccessLogSamplingPercentageForService returns the percentage of the requests to the given service logged by its
+// proxies, as set by the service's annotation to either a percentage of the requests, e.g. 10%, or 1 in N requests,
+// e.g. 1/1000. All requests are logged if the annotation is not set.
+func (mc *MeshCatalog) GetAccessLogSamplingPercentageForService(svc service.MeshService) float64 {
+	value, ok := mc.getServiceAnnotations(svc)[constants.AccessLogSamplingAnnotation]
+	if !ok {
+		return maxAccessLogSamplingPercentage
+	}
+
+	percentage, err := parseAccessLogSampling(strings.TrimSpace(value))
+	if err != nil || percentage <= 0 || percentage > maxAccessLogSamplingPercentage {
+		log.Error().Err(err).Msgf("Ignoring invalid access log sampling %q of annotation %s for service %s, must be a percentage such as 10%% or a fraction of requests such as 1/1000", value, constants.AccessLogSamplingAnnotation, svc)
+		return maxAccessLogSamplingPercentage
+	}
+	return percentage
+}
+
+// parseAccessLogSampling parses the given percentage of the form <percentage>% or fraction of the form <m>/<n> of the
+// requests logged, returning it as a percentage
+func parseAccessLogSampling(value string) (float64, error) {
+	if strings.HasSuffix(value, "%") {
+		return strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+	}
+
+	i := strings.Index(value, "/")
+	if i < 0 {
+		return 0, errors.Errorf("missing %% or / in %q", value)
+	}
+	numerator, err := strconv.ParseUint(strings.TrimSpace(value[:i]), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	denominator, err := strconv.ParseUint(strings.TrimSpace(value[i+1:]), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if denominator == 0 {
+		return 0, errors.Errorf("zero denominator in %q", value)
+	}
+	return float64(numerator) * maxAccessLogSamplingPercentage / float64(denominator), nil
+}
+
+// GetPerConnectionBufferLimitBytesForService returns the soft limit in bytes on the read and write buffers of the
+// connections of the inbound and outbound listeners of the proxies of the given service, as set by the service's
+// annotation, or 0 if not set
+func (mc *MeshCatalog) GetPerConnectionBufferLimitBytesForService(svc service.MeshService) uint32 {
+	if limit := getUint32Annotation(mc.getServiceAnnotations(svc), constants.PerConnectionBufferLimitBytesAnnotation, 1, math.MaxUint32, svc); limit != nil {
+		return *limit
+	}
+	return 0
+}
+
+// GetClientAddressDetectionForService returns how the proxies of the given service determine the client address of
+// inbound HTTP requests, as set by the service's annotations, or nil if the service sets neither annotation, in which
+// case Envoy's defaults apply. Invalid annotation values are ignored.
+func (mc *MeshCatalog) GetClientAddressDetectionForService(svc service.MeshService) *trafficpolicy.ClientAddressDetection {
+	annotations := mc.getServiceAnnotations(svc)
+	_, hasUseRemoteAddress := annotations[constants.UseRemoteAddressAnnotation]
+	_, hasXFFNumTrustedHops := annotations[constants.XFFNumTrustedHopsAnnotation]
+	if !hasUseRemoteAddress && !hasXFFNumTrustedHops {
+		return nil
+	}
+
+	detection := &trafficpolicy.ClientAddressDetection{}
+	detection.UseRemoteAddress, _ = getBoolAnnotation(annotations, constants.UseRemoteAddressAnnotation, svc)
+	if xffNumTrustedHops := getUint32Annotation(annotations, constants.XFFNumTrustedHopsAnnotation, 0, math.MaxUint32, svc); xffNumTrustedHops != nil {
+		detection.XFFNumTrustedHops = *xffNumTrustedHops
+	}
+	return detection
+}
+
+// GetHTTP1ProtocolOptionsForService returns the HTTP/1.1 options of the connections of the proxies of the given service
+// to the local application, as set by the service's annotations, or nil if not set
+func (mc *MeshCatalog) GetHTTP1ProtocolOptionsForService(svc service.MeshService) *trafficpolicy.HTTP1ProtocolOptions {
+	annotations := mc.getServiceAnnotations(svc)
+	_, hasProperCaseHeaders := annotations[constants.HTTP1ProperCaseHeadersAnnotation]
+	_, hasKeepaliveTime := annotations[constants.HTTP1KeepaliveTimeAnnotation]
+	if !hasProperCaseHeaders && !hasKeepaliveTime {
+		return nil
+	}
+
+	options := &trafficpolicy.HTTP1ProtocolOptions{}
+	options.ProperCaseHeaders, _ = getBoolAnnotation(annotations, constants.HTTP1ProperCaseHeadersAnnotation, svc)
+	if keepaliveTime := getDurationAnnotation(annotations, constants.HTTP1KeepaliveTimeAnnotation, svc); keepaliveTime >= time.Second {
+		options.KeepaliveTime = keepaliveTime
+	} else if keepaliveTime > 0 {
+		log.Error().Msgf("Ignoring keepalive time %v of annotation %s for service %s, must be at least 1s", keepaliveTime, constants.HTTP1KeepaliveTimeAnnotation, svc)
+	}
+	return options
+}
+
+// IsTLSSessionTicketsDisabledForService returns true if the proxies of the given service must not resume the inbound
+// TLS sessions of their clients using session tickets, which is specified using an annotation on the Kubernetes
+// service. Envoy's default of resuming TLS sessions using session tickets applies otherwise.
+func (mc *MeshCatalog) IsTLSSessionTicketsDisabledForService(svc service.MeshService) bool {
+	value, _ := getBoolAnnotation(mc.getServiceAnnotations(svc), constants.TLSSessionTicketsDisabledAnnotation, svc)
+	return value
+}
+
+// GetDirectResponseForService returns the direct response the downstream proxies of the given service send instead of
+// routing requests to the service, as set by the service's annotations, or nil if requests to the service are routed.
+// Requests to all paths are responded to unless restricted by a path regex.
+func (mc *MeshCatalog) GetDirectResponseForService(svc service.MeshService) *trafficpolicy.DirectResponse {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+	status := getUint32Annotation(k8sSvc.Annotations, constants.DirectResponseStatusAnnotation, minHTTPStatusCode, maxHTTPStatusCode, svc)
+	if status == nil {
+		return nil
+	}
+
+	pathRegex := constants.RegexMatchAll
+	if pathRegexStr := strings.TrimSpace(k8sSvc.Annotations[constants.DirectResponsePathRegexAnnotation]); pathRegexStr != "" {
+		if _, err := regexp.Compile(pathRegexStr); err != nil {
+			log.Error().Err(err).Msgf("Ignoring invalid path regex %q of annotation %s for service %s, routing requests to the service", pathRegexStr, constants.DirectResponsePathRegexAnnotation, svc)
+			return nil
+		}
+		pathRegex = pathRegexStr
+	}
+
+	body := k8sSvc.Annotations[constants.DirectResponseBodyAnnotation]
+	if len(body) > maxDirectResponseBodyBytes {
+		log.Error().Msgf("Ignoring body of %d bytes of annotation %s for service %s exceeding %d bytes, routing requests to the service", len(body), constants.DirectResponseBodyAnnotation, svc, maxDirectResponseBodyBytes)
+		return nil
+	}
+
+	return &trafficpolicy.DirectResponse{
+		StatusCode: *status,
+		Body:       body,
+		PathRegex:  pathRegex,
+	}
+}
+
+// GetHashPolicyForService returns the hash policy the downstream proxies of the given service use to load balance
+// requests to the service by consistent hashing, as set by the service's annotation, or nil if requests are load
+// balanced round robin
+func (mc *MeshCatalog) GetHashPolicyForService(svc service.MeshService) *trafficpolicy.HashPolicy {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+	hashPolicyStr, ok := k8sSvc.Annotations[constants.HashPolicyAnnotation]
+	if !ok {
+		return nil
+	}
+
+	hashPolicyStr = strings.TrimSpace(hashPolicyStr)
+	if hashPolicyStr == hashPolicySourceIP {
+		return &trafficpolicy.HashPolicy{}
+	}
+	attribute := strings.SplitN(hashPolicyStr, ":", 2)
+	if len(attribute) == 2 && strings.TrimSpace(attribute[1]) != "" {
+		switch strings.TrimSpace(attribute[0]) {
+		case hashPolicyHeader:
+			return &trafficpolicy.HashPolicy{Header: strings.TrimSpace(attribute[1])}
+		case hashPolicyCookie:
+			return &trafficpolicy.HashPolicy{Cookie: strings.TrimSpace(attribute[1])}
+		}
+	}
+
+	log.Error().Msgf("Ignoring invalid value %q of annotation %s for service %s, must be one of 'header:<name>', 'cookie:<name>' or '%s'", hashPolicyStr, constants.HashPolicyAnnotation, svc, hashPolicySourceIP)
+	return nil
+}
+
+// GetRetryPolicyForService returns the policy the downstream proxies of the given service use to retry requests to the
+// service, as set by the service's annotations, or nil if requests are not retried. Requests are retried on the retry
+// conditions and the retriable status codes set by the service, each attempt being bound by the per-try timeout if set.
+func (mc *MeshCatalog) GetRetryPolicyForService(svc service.MeshService) *trafficpolicy.RetryPolicy {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+
+	var conditions []string
+	if retryOnStr, ok := k8sSvc.Annotations[constants.RetryOnAnnotation]; ok {
+		for _, condition := range strings.Split(retryOnStr, ",") {
+			condition = strings.TrimSpace(condition)
+			if !validRetryOnConditions[condition] {
+				log.Error().Msgf("Ignoring invalid value %q of annotation %s for service %s, unknown retry condition %q", retryOnStr, constants.RetryOnAnnotation, svc, condition)
+				return nil
+			}
+			conditions = append(conditions, condition)
+		}
+	}
+
+	var statusCodes []uint32
+	if statusCodesStr, ok := k8sSvc.Annotations[constants.RetriableStatusCodesAnnotation]; ok {
+		for _, statusCodeStr := range strings.Split(statusCodesStr, ",") {
+			statusCode, err := strconv.ParseUint(strings.TrimSpace(statusCodeStr), 10, 32)
+			if err != nil || statusCode < minHTTPStatusCode || statusCode > maxHTTPStatusCode {
+				log.Error().Err(err).Msgf("Ignoring invalid value %q of annotation %s for service %s, must be a comma separated list of HTTP status codes between %d and %d", statusCodesStr, constants.RetriableStatusCodesAnnotation, svc, minHTTPStatusCode, maxHTTPStatusCode)
+				return nil
+			}
+			statusCodes = append(statusCodes, uint32(statusCode))
+		}
+
+		// The retriable status codes are only retried on with the corresponding retry condition
+		hasRetriableStatusCodesCondition := false
+		for _, condition := range conditions {
+			if condition == retryOnRetriableStatusCodes {
+				hasRetriableStatusCodesCondition = true
+			}
+		}
+		if !hasRetriableStatusCodesCondition {
+			conditions = append(conditions, retryOnRetriableStatusCodes)
+		}
+	}
+
+	perTryTimeout := getDurationAnnotation(k8sSvc.Annotations, constants.RetryPerTryTimeoutAnnotation, svc)
+
+	if len(conditions) == 0 {
+		if perTryTimeout > 0 {
+			log.Error().Msgf("Ignoring annotation %s for service %s without a retry condition set by annotation %s or %s", constants.RetryPerTryTimeoutAnnotation, svc, constants.RetryOnAnnotation, constants.RetriableStatusCodesAnnotation)
+		}
+		return nil
+	}
+
+	return &trafficpolicy.RetryPolicy{
+		RetryOn:              strings.Join(conditions, ","),
+		PerTryTimeout:        perTryTimeout,
+		RetriableStatusCodes: statusCodes,
+	}
+}
+
+// getServiceAnnotations returns the annotations of the given service, or nil if the service does not exist
+func (mc *MeshCatalog) getServiceAnnotations(svc service.MeshService) map[string]string {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+	return k8sSvc.Annotations
+}
+
+// getBoolAnnotation returns the value of the given annotation on the given service. The second return value is false
+// if the annotation is not set or is not a boolean.
+func getBoolAnnotation(annotations map[string]string, annotation string, svc service.MeshService) (bool, bool) {
+	valueStr, ok := annotations[annotation]
+	if !ok {
+		return false, false
+	}
+
+	value, err := strconv.ParseBool(strings.TrimSpace(valueStr))
+	if err != nil {
+		log.Error().Err(err).Msgf("Ignoring invalid value %q of annotation %s for service %s, must be a boolean", valueStr, annotation, svc)
+		return false, false
+	}
+	return value, true
+}
+
+// getDurationAnnotation returns the value of the given annotation on the given service, or 0 if it is not set or is
+// not a positive duration
+func getDurationAnnotation(annotations map[string]string, annotation string, svc service.MeshService) time.Duration {
+	valueStr, ok := annotations[annotation]
+	if !ok {
+		return 0
+	}
+
+	value, err := time.ParseDuration(strings.TrimSpace(valueStr))
+	if err != nil || value <= 0 {
+		log.Error().Err(err).Msgf("Ignoring invalid value %q of annotation %s for service %s, must be a positive duration", valueStr, annotation, svc)
+		return 0
+	}
+	return value
+}
+
+// getUint32Annotation returns the value of the given annotation on the given service, or nil if it is not set or is
+// not an integer between the given minimum and maximum
+func getUint32Annotation(annotations map[string]string, annotation string, minValue, maxValue uint64, svc service.MeshService) *uint32 {
+	valueStr, ok := annotations[annotation]
+	if !ok {
+		return nil
+	}
+
+	value, err := strconv.ParseUint(strings.TrimSpace(valueStr), 10, 32)
+	if err != nil || value < minValue || value > maxValue {
+		log.Error().Err(err).Msgf("Ignoring invalid value %q of annotation %s for service %s, must be an integer between %d and %d", valueStr, annotation, svc, minValue, maxValue)
+		return nil
+	}
+
+	v := uint32(value)
+	return &v
+}
@@ -1,8 +1,11 @@
 package catalog
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -11,9 +14,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 func TestListServiceAccountsForService(t *testing.T) {
@@ -295,3 +301,1608 @@ func TestGetPortToProtocolMappingForResolvableService(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFailoverServicesForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                     string
+		k8sSvc                   *corev1.Service
+		expectedFailoverServices []service.MeshService
+	}{
+		{
+			name:                     "service does not exist",
+			k8sSvc:                   nil,
+			expectedFailoverServices: nil,
+		},
+		{
+			name:                     "service without failover services",
+			k8sSvc:                   &corev1.Service{},
+			expectedFailoverServices: nil,
+		},
+		{
+			name: "service with failover services",
+			k8sSvc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						constants.FailoverServicesAnnotation: "bookstore-green, ns-2/bookstore,bookstore,ns-2/bookstore,invalid/",
+					},
+				},
+			},
+			expectedFailoverServices: []service.MeshService{
+				{Name: "bookstore-green", Namespace: "ns-1"},
+				{Name: "bookstore", Namespace: "ns-2"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockKubeController.EXPECT().GetService(svc).Return(tc.k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedFailoverServices, mc.GetFailoverServicesForService(svc))
+		})
+	}
+}
+
+func TestGetMirrorPoliciesForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                   string
+		annotations            map[string]string
+		expectedMirrorPolicies []trafficpolicy.MirrorPolicy
+	}{
+		{
+			name:                   "service without a mirror service",
+			annotations:            nil,
+			expectedMirrorPolicies: nil,
+		},
+		{
+			name: "service mirrored to a service in the same namespace",
+			annotations: map[string]string{
+				constants.MirrorServiceAnnotation: "bookstore-shadow",
+			},
+			expectedMirrorPolicies: []trafficpolicy.MirrorPolicy{{
+				Service:    service.MeshService{Name: "bookstore-shadow", Namespace: "ns-1"},
+				Percentage: 100,
+			}},
+		},
+		{
+			name: "service mirrored to a service in another namespace with a percentage",
+			annotations: map[string]string{
+				constants.MirrorServiceAnnotation:    "ns-2/bookstore",
+				constants.MirrorPercentageAnnotation: "12.5",
+			},
+			expectedMirrorPolicies: []trafficpolicy.MirrorPolicy{{
+				Service:    service.MeshService{Name: "bookstore", Namespace: "ns-2"},
+				Percentage: 12.5,
+			}},
+		},
+		{
+			name: "service with an invalid mirror percentage",
+			annotations: map[string]string{
+				constants.MirrorServiceAnnotation:    "bookstore-shadow",
+				constants.MirrorPercentageAnnotation: "150",
+			},
+			expectedMirrorPolicies: []trafficpolicy.MirrorPolicy{{
+				Service:    service.MeshService{Name: "bookstore-shadow", Namespace: "ns-1"},
+				Percentage: 100,
+			}},
+		},
+		{
+			name: "service mirrored to several services at different percentages",
+			annotations: map[string]string{
+				constants.MirrorServiceAnnotation:    "bookstore-staging, ns-2/bookstore-analysis:1, bookstore-canary:invalid",
+				constants.MirrorPercentageAnnotation: "50",
+			},
+			expectedMirrorPolicies: []trafficpolicy.MirrorPolicy{
+				{Service: service.MeshService{Name: "bookstore-staging", Namespace: "ns-1"}, Percentage: 50},
+				{Service: service.MeshService{Name: "bookstore-analysis", Namespace: "ns-2"}, Percentage: 1},
+				{Service: service.MeshService{Name: "bookstore-canary", Namespace: "ns-1"}, Percentage: 50},
+			},
+		},
+		{
+			name: "service mirrored to the same service twice",
+			annotations: map[string]string{
+				constants.MirrorServiceAnnotation: "bookstore-shadow:10,ns-1/bookstore-shadow:20",
+			},
+			expectedMirrorPolicies: []trafficpolicy.MirrorPolicy{{
+				Service:    service.MeshService{Name: "bookstore-shadow", Namespace: "ns-1"},
+				Percentage: 10,
+			}},
+		},
+		{
+			name: "service mirrored to itself",
+			annotations: map[string]string{
+				constants.MirrorServiceAnnotation: "ns-1/bookstore",
+			},
+			expectedMirrorPolicies: nil,
+		},
+		{
+			name: "service with an invalid mirror service",
+			annotations: map[string]string{
+				constants.MirrorServiceAnnotation: "invalid/",
+			},
+			expectedMirrorPolicies: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedMirrorPolicies, mc.GetMirrorPoliciesForService(svc))
+		})
+	}
+}
+
+func TestListAllowedMirrorPoliciesForService(t *testing.T) {
+	assert := tassert.New(t)
+
+	testCases := []struct {
+		name                   string
+		permissiveMode         bool
+		expectedMirrorServices []service.MeshService
+	}{
+		{
+			name:                   "only the shadow services allowed by traffic targets are mirrored to",
+			permissiveMode:         false,
+			expectedMirrorServices: []service.MeshService{tests.BookstoreV2Service},
+		},
+		{
+			name:                   "all the shadow services are mirrored to in permissive mode",
+			permissiveMode:         true,
+			expectedMirrorServices: []service.MeshService{tests.BookstoreV2Service, tests.BookbuyerService},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := newFakeMeshCatalogForRoutes(t, testParams{
+				permissiveMode: tc.permissiveMode,
+			})
+
+			// The bookbuyer identity is allowed to access bookstore-v2 but not bookbuyer unless in permissive mode
+			k8sSvc, err := mc.kubeClient.CoreV1().Services(tests.BookstoreV1Service.Namespace).Get(context.TODO(), tests.BookstoreV1Service.Name, metav1.GetOptions{})
+			assert.Nil(err)
+			k8sSvc.Annotations = map[string]string{
+				constants.MirrorServiceAnnotation: fmt.Sprintf("%s,%s", tests.BookstoreV2Service.Name, tests.BookbuyerService.Name),
+			}
+			_, err = mc.kubeClient.CoreV1().Services(tests.BookstoreV1Service.Namespace).Update(context.TODO(), k8sSvc, metav1.UpdateOptions{})
+			assert.Nil(err)
+
+			var mirrorServices []service.MeshService
+			for _, mirrorPolicy := range mc.ListAllowedMirrorPoliciesForService(tests.BookbuyerServiceAccount, tests.BookstoreV1Service) {
+				mirrorServices = append(mirrorServices, mirrorPolicy.Service)
+			}
+			assert.ElementsMatch(tc.expectedMirrorServices, mirrorServices)
+		})
+	}
+}
+
+func TestListAllowedFailoverServicesForService(t *testing.T) {
+	assert := tassert.New(t)
+
+	testCases := []struct {
+		name                     string
+		permissiveMode           bool
+		expectedFailoverServices []service.MeshService
+	}{
+		{
+			name:                     "only the failover services allowed by traffic targets are failed over to",
+			permissiveMode:           false,
+			expectedFailoverServices: []service.MeshService{tests.BookstoreV2Service},
+		},
+		{
+			name:                     "all the failover services are failed over to in permissive mode",
+			permissiveMode:           true,
+			expectedFailoverServices: []service.MeshService{tests.BookbuyerService, tests.BookstoreV2Service},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := newFakeMeshCatalogForRoutes(t, testParams{
+				permissiveMode: tc.permissiveMode,
+			})
+
+			// The bookbuyer identity is allowed to access bookstore-v2 but not bookbuyer unless in permissive mode
+			k8sSvc, err := mc.kubeClient.CoreV1().Services(tests.BookstoreV1Service.Namespace).Get(context.TODO(), tests.BookstoreV1Service.Name, metav1.GetOptions{})
+			assert.Nil(err)
+			k8sSvc.Annotations = map[string]string{
+				constants.FailoverServicesAnnotation: fmt.Sprintf("%s,%s", tests.BookbuyerService, tests.BookstoreV2Service.Name),
+			}
+			_, err = mc.kubeClient.CoreV1().Services(tests.BookstoreV1Service.Namespace).Update(context.TODO(), k8sSvc, metav1.UpdateOptions{})
+			assert.Nil(err)
+
+			// The order of the failover services is kept
+			assert.Equal(tc.expectedFailoverServices, mc.ListAllowedFailoverServicesForService(tests.BookbuyerServiceAccount, tests.BookstoreV1Service))
+		})
+	}
+}
+
+func TestGetOutlierDetectionForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+	fifty := uint32(50)
+	five := uint32(5)
+
+	testCases := []struct {
+		name                     string
+		annotations              map[string]string
+		expectedOutlierDetection *trafficpolicy.OutlierDetection
+	}{
+		{
+			name:                     "service without outlier detection overrides",
+			annotations:              nil,
+			expectedOutlierDetection: nil,
+		},
+		{
+			name: "two-endpoint service capping the ejection to one endpoint",
+			annotations: map[string]string{
+				constants.OutlierDetectionMaxEjectionPercentAnnotation: "50",
+				constants.OutlierDetectionConsecutive5xxAnnotation:     "5",
+			},
+			expectedOutlierDetection: &trafficpolicy.OutlierDetection{
+				Consecutive5xx:     &five,
+				MaxEjectionPercent: &fifty,
+			},
+		},
+		{
+			name: "service with invalid outlier detection overrides",
+			annotations: map[string]string{
+				constants.OutlierDetectionMaxEjectionPercentAnnotation:      "150",
+				constants.OutlierDetectionEnforcingConsecutive5xxAnnotation: "-1",
+			},
+			expectedOutlierDetection: nil,
+		},
+		{
+			name: "service ejecting endpoints for local-origin failures separately from 5xx responses",
+			annotations: map[string]string{
+				constants.OutlierDetectionSplitExternalLocalOriginErrorsAnnotation:         "true",
+				constants.OutlierDetectionConsecutiveLocalOriginFailureAnnotation:          "5",
+				constants.OutlierDetectionEnforcingConsecutiveLocalOriginFailureAnnotation: "50",
+			},
+			expectedOutlierDetection: &trafficpolicy.OutlierDetection{
+				SplitExternalLocalOriginErrors:         true,
+				ConsecutiveLocalOriginFailure:          &five,
+				EnforcingConsecutiveLocalOriginFailure: &fifty,
+			},
+		},
+		{
+			name: "service with an invalid local-origin error split",
+			annotations: map[string]string{
+				constants.OutlierDetectionSplitExternalLocalOriginErrorsAnnotation: "yes",
+			},
+			expectedOutlierDetection: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedOutlierDetection, mc.GetOutlierDetectionForService(svc))
+		})
+	}
+}
+
+func TestGetHealthCheckForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+	tcpAppProtocol := "tcp"
+	tcpPorts := []corev1.ServicePort{{Name: "tcp-db", Port: 5432}, {Name: "replication", Port: 5433, AppProtocol: &tcpAppProtocol}}
+	httpPorts := []corev1.ServicePort{{Name: "http-api", Port: 80}, {Name: "tcp-db", Port: 5432}}
+
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		ports               []corev1.ServicePort
+		expectedHealthCheck *trafficpolicy.HealthCheck
+	}{
+		{
+			name:                "service without health checking",
+			annotations:         nil,
+			ports:               tcpPorts,
+			expectedHealthCheck: nil,
+		},
+		{
+			name: "TCP service with health check payloads",
+			annotations: map[string]string{
+				constants.HealthCheckIntervalAnnotation: "10s",
+				constants.HealthCheckSendAnnotation:     "50494e47",
+				constants.HealthCheckReceiveAnnotation:  "504f4e47",
+				constants.HealthCheckPathAnnotation:     "/healthz",
+			},
+			ports: tcpPorts,
+			expectedHealthCheck: &trafficpolicy.HealthCheck{
+				Interval: 10 * time.Second,
+				TCP:      true,
+				Send:     "50494e47",
+				Receive:  "504f4e47",
+			},
+		},
+		{
+			name: "TCP service with an invalid health check payload",
+			annotations: map[string]string{
+				constants.HealthCheckIntervalAnnotation: "10s",
+				constants.HealthCheckSendAnnotation:     "PING",
+			},
+			ports: tcpPorts,
+			expectedHealthCheck: &trafficpolicy.HealthCheck{
+				Interval: 10 * time.Second,
+				TCP:      true,
+			},
+		},
+		{
+			name: "service with an HTTP port",
+			annotations: map[string]string{
+				constants.HealthCheckIntervalAnnotation: "5s",
+				constants.HealthCheckPathAnnotation:     "/healthz",
+				constants.HealthCheckSendAnnotation:     "50494e47",
+			},
+			ports: httpPorts,
+			expectedHealthCheck: &trafficpolicy.HealthCheck{
+				Interval: 5 * time.Second,
+				Path:     "/healthz",
+			},
+		},
+		{
+			name: "service with an HTTP port and expected health check statuses",
+			annotations: map[string]string{
+				constants.HealthCheckIntervalAnnotation:         "5s",
+				constants.HealthCheckExpectedStatusesAnnotation: "204, 300-399",
+			},
+			ports: httpPorts,
+			expectedHealthCheck: &trafficpolicy.HealthCheck{
+				Interval:         5 * time.Second,
+				ExpectedStatuses: []trafficpolicy.StatusCodeRange{{Start: 204, End: 204}, {Start: 300, End: 399}},
+			},
+		},
+		{
+			name: "service with an HTTP port and invalid expected health check statuses",
+			annotations: map[string]string{
+				constants.HealthCheckIntervalAnnotation:         "5s",
+				constants.HealthCheckExpectedStatusesAnnotation: "204,399-300",
+			},
+			ports: httpPorts,
+			expectedHealthCheck: &trafficpolicy.HealthCheck{
+				Interval: 5 * time.Second,
+			},
+		},
+		{
+			name: "service with an invalid health check interval",
+			annotations: map[string]string{
+				constants.HealthCheckIntervalAnnotation: "-5s",
+			},
+			ports:               tcpPorts,
+			expectedHealthCheck: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: tc.ports,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedHealthCheck, mc.GetHealthCheckForService(svc))
+		})
+	}
+}
+
+func TestGetCircuitBreakingForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+	ten := uint32(10)
+	hundred := uint32(100)
+
+	testCases := []struct {
+		name                    string
+		annotations             map[string]string
+		expectedCircuitBreaking *trafficpolicy.CircuitBreaking
+	}{
+		{
+			name:                    "service without circuit breaker thresholds",
+			annotations:             nil,
+			expectedCircuitBreaking: nil,
+		},
+		{
+			name: "service with default priority thresholds only",
+			annotations: map[string]string{
+				constants.CircuitBreakerMaxConnectionsAnnotation: "100",
+				constants.CircuitBreakerMaxRetriesAnnotation:     "10",
+			},
+			expectedCircuitBreaking: &trafficpolicy.CircuitBreaking{
+				Default: &trafficpolicy.CircuitBreakerThresholds{
+					MaxConnections: &hundred,
+					MaxRetries:     &ten,
+				},
+			},
+		},
+		{
+			name: "service with default and high priority thresholds",
+			annotations: map[string]string{
+				constants.CircuitBreakerMaxRequestsAnnotation:                    "100",
+				constants.CircuitBreakerHighPriorityMaxPendingRequestsAnnotation: "10",
+			},
+			expectedCircuitBreaking: &trafficpolicy.CircuitBreaking{
+				Default: &trafficpolicy.CircuitBreakerThresholds{
+					MaxRequests: &hundred,
+				},
+				High: &trafficpolicy.CircuitBreakerThresholds{
+					MaxPendingRequests: &ten,
+				},
+			},
+		},
+		{
+			name: "service with invalid circuit breaker thresholds",
+			annotations: map[string]string{
+				constants.CircuitBreakerMaxConnectionsAnnotation:             "-1",
+				constants.CircuitBreakerHighPriorityMaxConnectionsAnnotation: "many",
+			},
+			expectedCircuitBreaking: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedCircuitBreaking, mc.GetCircuitBreakingForService(svc))
+		})
+	}
+}
+
+func TestIsOutboundDisabledForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "api-gateway", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedDisabled bool
+	}{
+		{
+			name:             "service without annotation",
+			annotations:      nil,
+			expectedDisabled: false,
+		},
+		{
+			name:             "service with outbound disabled",
+			annotations:      map[string]string{constants.OutboundDisabledAnnotation: "true"},
+			expectedDisabled: true,
+		},
+		{
+			name:             "service with outbound explicitly enabled",
+			annotations:      map[string]string{constants.OutboundDisabledAnnotation: "false"},
+			expectedDisabled: false,
+		},
+		{
+			name:             "service with invalid annotation",
+			annotations:      map[string]string{constants.OutboundDisabledAnnotation: "maybe"},
+			expectedDisabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedDisabled, mc.IsOutboundDisabledForService(svc))
+		})
+	}
+}
+
+func TestIsExternalTLSOriginationEnabledForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "payments-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name            string
+		annotations     map[string]string
+		expectedEnabled bool
+	}{
+		{
+			name:            "service without annotation",
+			annotations:     nil,
+			expectedEnabled: false,
+		},
+		{
+			name:            "service with external TLS origination enabled",
+			annotations:     map[string]string{constants.ExternalTLSOriginationAnnotation: "true"},
+			expectedEnabled: true,
+		},
+		{
+			name:            "service with external TLS origination disabled",
+			annotations:     map[string]string{constants.ExternalTLSOriginationAnnotation: "false"},
+			expectedEnabled: false,
+		},
+		{
+			name:            "service with invalid annotation",
+			annotations:     map[string]string{constants.ExternalTLSOriginationAnnotation: "maybe"},
+			expectedEnabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedEnabled, mc.IsExternalTLSOriginationEnabledForService(svc))
+		})
+	}
+}
+
+func TestGetAllowedSourceIPRangesForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "legacy-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		expectedRanges []string
+	}{
+		{
+			name:           "service without annotation",
+			annotations:    nil,
+			expectedRanges: nil,
+		},
+		{
+			name:           "service with allowed source IP ranges",
+			annotations:    map[string]string{constants.AllowedSourceIPRangesAnnotation: "10.0.0.0/8, 192.168.1.10/32"},
+			expectedRanges: []string{"10.0.0.0/8", "192.168.1.10/32"},
+		},
+		{
+			name:           "service with invalid source IP ranges",
+			annotations:    map[string]string{constants.AllowedSourceIPRangesAnnotation: "10.0.0.0/8,192.168.1.10,,not-a-cidr"},
+			expectedRanges: []string{"10.0.0.0/8"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedRanges, mc.GetAllowedSourceIPRangesForService(svc))
+		})
+	}
+}
+
+func TestGetClusterTypeForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "external-db", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		expectedClusterType string
+	}{
+		{
+			name:                "service without annotation",
+			annotations:         nil,
+			expectedClusterType: "",
+		},
+		{
+			name:                "service with STRICT_DNS cluster type",
+			annotations:         map[string]string{constants.ClusterTypeAnnotation: "strict_dns"},
+			expectedClusterType: constants.ClusterTypeStrictDNS,
+		},
+		{
+			name:                "service with EDS cluster type",
+			annotations:         map[string]string{constants.ClusterTypeAnnotation: "EDS"},
+			expectedClusterType: constants.ClusterTypeEDS,
+		},
+		{
+			name:                "service with invalid cluster type",
+			annotations:         map[string]string{constants.ClusterTypeAnnotation: "STATIC"},
+			expectedClusterType: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedClusterType, mc.GetClusterTypeForService(svc))
+		})
+	}
+}
+
+func TestGetUpstreamBindSourceAddressForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "partner-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name            string
+		annotations     map[string]string
+		expectedAddress string
+	}{
+		{
+			name:            "service without annotation",
+			annotations:     nil,
+			expectedAddress: "",
+		},
+		{
+			name:            "service with upstream bind source address",
+			annotations:     map[string]string{constants.UpstreamBindSourceAddressAnnotation: " 10.0.0.5 "},
+			expectedAddress: "10.0.0.5",
+		},
+		{
+			name:            "service with invalid upstream bind source address",
+			annotations:     map[string]string{constants.UpstreamBindSourceAddressAnnotation: "10.0.0.0/24"},
+			expectedAddress: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedAddress, mc.GetUpstreamBindSourceAddressForService(svc))
+		})
+	}
+}
+
+func TestGetDNSRefreshRateForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "partner-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		expectedRefreshRate time.Duration
+	}{
+		{
+			name:                "service without annotation",
+			annotations:         nil,
+			expectedRefreshRate: 0,
+		},
+		{
+			name:                "service with DNS refresh rate",
+			annotations:         map[string]string{constants.DNSRefreshRateAnnotation: " 500ms "},
+			expectedRefreshRate: 500 * time.Millisecond,
+		},
+		{
+			name:                "service with negative DNS refresh rate",
+			annotations:         map[string]string{constants.DNSRefreshRateAnnotation: "-1s"},
+			expectedRefreshRate: 0,
+		},
+		{
+			name:                "service with invalid DNS refresh rate",
+			annotations:         map[string]string{constants.DNSRefreshRateAnnotation: "often"},
+			expectedRefreshRate: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedRefreshRate, mc.GetDNSRefreshRateForService(svc))
+		})
+	}
+}
+
+func TestGetUpstreamIdleTimeoutForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "partner-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		expectedIdleTimeout time.Duration
+	}{
+		{
+			name:                "service without annotation",
+			annotations:         nil,
+			expectedIdleTimeout: 0,
+		},
+		{
+			name:                "service with idle timeout",
+			annotations:         map[string]string{constants.UpstreamIdleTimeoutAnnotation: " 5m "},
+			expectedIdleTimeout: 5 * time.Minute,
+		},
+		{
+			name:                "service with invalid idle timeout",
+			annotations:         map[string]string{constants.UpstreamIdleTimeoutAnnotation: "0s"},
+			expectedIdleTimeout: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedIdleTimeout, mc.GetUpstreamIdleTimeoutForService(svc))
+		})
+	}
+}
+
+func TestGetRequestTimeoutForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "partner-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                   string
+		annotations            map[string]string
+		expectedRequestTimeout time.Duration
+	}{
+		{
+			name:                   "service without annotation",
+			annotations:            nil,
+			expectedRequestTimeout: 0,
+		},
+		{
+			name:                   "service with request timeout",
+			annotations:            map[string]string{constants.RequestTimeoutAnnotation: " 2m "},
+			expectedRequestTimeout: 2 * time.Minute,
+		},
+		{
+			name:                   "service with invalid request timeout",
+			annotations:            map[string]string{constants.RequestTimeoutAnnotation: "forever"},
+			expectedRequestTimeout: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedRequestTimeout, mc.GetRequestTimeoutForService(svc))
+		})
+	}
+}
+
+func TestGetPerConnectionBufferLimitBytesForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "uploads", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		expectedBufferLimit uint32
+	}{
+		{
+			name:                "service without annotation",
+			annotations:         nil,
+			expectedBufferLimit: 0,
+		},
+		{
+			name:                "service with buffer limit",
+			annotations:         map[string]string{constants.PerConnectionBufferLimitBytesAnnotation: "16777216"},
+			expectedBufferLimit: 16777216,
+		},
+		{
+			name:                "service with invalid buffer limit",
+			annotations:         map[string]string{constants.PerConnectionBufferLimitBytesAnnotation: "16Mi"},
+			expectedBufferLimit: 0,
+		},
+		{
+			name:                "service with zero buffer limit",
+			annotations:         map[string]string{constants.PerConnectionBufferLimitBytesAnnotation: "0"},
+			expectedBufferLimit: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedBufferLimit, mc.GetPerConnectionBufferLimitBytesForService(svc))
+		})
+	}
+}
+
+func TestIsDNSTTLRespectedForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "partner-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name              string
+		annotations       map[string]string
+		expectedRespected bool
+		expectedOk        bool
+	}{
+		{
+			name:              "service without annotation",
+			annotations:       nil,
+			expectedRespected: false,
+			expectedOk:        false,
+		},
+		{
+			name:              "service respecting DNS TTLs",
+			annotations:       map[string]string{constants.RespectDNSTTLAnnotation: "true"},
+			expectedRespected: true,
+			expectedOk:        true,
+		},
+		{
+			name:              "service not respecting DNS TTLs",
+			annotations:       map[string]string{constants.RespectDNSTTLAnnotation: "false"},
+			expectedRespected: false,
+			expectedOk:        true,
+		},
+		{
+			name:              "service with invalid annotation",
+			annotations:       map[string]string{constants.RespectDNSTTLAnnotation: "sometimes"},
+			expectedRespected: false,
+			expectedOk:        false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			respected, ok := mc.IsDNSTTLRespectedForService(svc)
+			assert.Equal(tc.expectedRespected, respected)
+			assert.Equal(tc.expectedOk, ok)
+		})
+	}
+}
+
+func TestGetDirectResponseForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "checkout", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                   string
+		annotations            map[string]string
+		expectedDirectResponse *trafficpolicy.DirectResponse
+	}{
+		{
+			name:                   "service without annotation",
+			annotations:            nil,
+			expectedDirectResponse: nil,
+		},
+		{
+			name: "service with a direct response to all paths",
+			annotations: map[string]string{
+				constants.DirectResponseStatusAnnotation: "503",
+				constants.DirectResponseBodyAnnotation:   "down for maintenance",
+			},
+			expectedDirectResponse: &trafficpolicy.DirectResponse{StatusCode: 503, Body: "down for maintenance", PathRegex: constants.RegexMatchAll},
+		},
+		{
+			name: "service with a direct response without a body to some paths",
+			annotations: map[string]string{
+				constants.DirectResponseStatusAnnotation:    "503",
+				constants.DirectResponsePathRegexAnnotation: "/cart.*",
+			},
+			expectedDirectResponse: &trafficpolicy.DirectResponse{StatusCode: 503, PathRegex: "/cart.*"},
+		},
+		{
+			name: "service with a direct response body exceeding the maximum size",
+			annotations: map[string]string{
+				constants.DirectResponseStatusAnnotation: "503",
+				constants.DirectResponseBodyAnnotation:   strings.Repeat("x", maxDirectResponseBodyBytes+1),
+			},
+			expectedDirectResponse: nil,
+		},
+		{
+			name:                   "service with an invalid status code",
+			annotations:            map[string]string{constants.DirectResponseStatusAnnotation: "999"},
+			expectedDirectResponse: nil,
+		},
+		{
+			name: "service with an invalid path regex",
+			annotations: map[string]string{
+				constants.DirectResponseStatusAnnotation:    "503",
+				constants.DirectResponsePathRegexAnnotation: "/cart(",
+			},
+			expectedDirectResponse: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedDirectResponse, mc.GetDirectResponseForService(svc))
+		})
+	}
+}
+
+func TestGetHashPolicyForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "cache", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name               string
+		annotations        map[string]string
+		expectedHashPolicy *trafficpolicy.HashPolicy
+	}{
+		{
+			name:               "service without annotation",
+			annotations:        nil,
+			expectedHashPolicy: nil,
+		},
+		{
+			name:               "service hashing a header",
+			annotations:        map[string]string{constants.HashPolicyAnnotation: "header: x-user-id"},
+			expectedHashPolicy: &trafficpolicy.HashPolicy{Header: "x-user-id"},
+		},
+		{
+			name:               "service hashing a cookie",
+			annotations:        map[string]string{constants.HashPolicyAnnotation: "cookie:session"},
+			expectedHashPolicy: &trafficpolicy.HashPolicy{Cookie: "session"},
+		},
+		{
+			name:               "service hashing the source IP",
+			annotations:        map[string]string{constants.HashPolicyAnnotation: "source-ip"},
+			expectedHashPolicy: &trafficpolicy.HashPolicy{},
+		},
+		{
+			name:               "service with a header hash policy without a header name",
+			annotations:        map[string]string{constants.HashPolicyAnnotation: "header:"},
+			expectedHashPolicy: nil,
+		},
+		{
+			name:               "service with an unknown hash policy",
+			annotations:        map[string]string{constants.HashPolicyAnnotation: "query:user"},
+			expectedHashPolicy: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedHashPolicy, mc.GetHashPolicyForService(svc))
+		})
+	}
+}
+
+func TestGetRetryPolicyForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		expectedRetryPolicy *trafficpolicy.RetryPolicy
+	}{
+		{
+			name:                "service without annotations",
+			annotations:         nil,
+			expectedRetryPolicy: nil,
+		},
+		{
+			name:                "service retrying on retry conditions",
+			annotations:         map[string]string{constants.RetryOnAnnotation: "5xx, reset"},
+			expectedRetryPolicy: &trafficpolicy.RetryPolicy{RetryOn: "5xx,reset"},
+		},
+		{
+			name: "service retrying on retriable status codes with a per-try timeout",
+			annotations: map[string]string{
+				constants.RetriableStatusCodesAnnotation: "502, 503",
+				constants.RetryPerTryTimeoutAnnotation:   "250ms",
+			},
+			expectedRetryPolicy: &trafficpolicy.RetryPolicy{RetryOn: "retriable-status-codes", PerTryTimeout: 250 * time.Millisecond, RetriableStatusCodes: []uint32{502, 503}},
+		},
+		{
+			name: "service retrying on retry conditions and retriable status codes",
+			annotations: map[string]string{
+				constants.RetryOnAnnotation:              "connect-failure,retriable-status-codes",
+				constants.RetriableStatusCodesAnnotation: "502",
+			},
+			expectedRetryPolicy: &trafficpolicy.RetryPolicy{RetryOn: "connect-failure,retriable-status-codes", RetriableStatusCodes: []uint32{502}},
+		},
+		{
+			name: "service with an invalid per-try timeout",
+			annotations: map[string]string{
+				constants.RetryOnAnnotation:            "reset",
+				constants.RetryPerTryTimeoutAnnotation: "-1s",
+			},
+			expectedRetryPolicy: &trafficpolicy.RetryPolicy{RetryOn: "reset"},
+		},
+		{
+			name:                "service with a per-try timeout without a retry condition",
+			annotations:         map[string]string{constants.RetryPerTryTimeoutAnnotation: "1s"},
+			expectedRetryPolicy: nil,
+		},
+		{
+			name:                "service with an unknown retry condition",
+			annotations:         map[string]string{constants.RetryOnAnnotation: "5xx,timeout"},
+			expectedRetryPolicy: nil,
+		},
+		{
+			name:                "service with an invalid retriable status code",
+			annotations:         map[string]string{constants.RetriableStatusCodesAnnotation: "502,abc"},
+			expectedRetryPolicy: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedRetryPolicy, mc.GetRetryPolicyForService(svc))
+		})
+	}
+}
+
+func TestGetHTTP2MaxConcurrentStreamsForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "grpc-api", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name               string
+		annotations        map[string]string
+		expectedMaxStreams uint32
+	}{
+		{
+			name:               "service without annotation",
+			annotations:        nil,
+			expectedMaxStreams: 0,
+		},
+		{
+			name:               "service with max concurrent streams",
+			annotations:        map[string]string{constants.HTTP2MaxConcurrentStreamsAnnotation: " 100 "},
+			expectedMaxStreams: 100,
+		},
+		{
+			name:               "service with zero max concurrent streams",
+			annotations:        map[string]string{constants.HTTP2MaxConcurrentStreamsAnnotation: "0"},
+			expectedMaxStreams: 0,
+		},
+		{
+			name:               "service with max concurrent streams out of range",
+			annotations:        map[string]string{constants.HTTP2MaxConcurrentStreamsAnnotation: "2147483648"},
+			expectedMaxStreams: 0,
+		},
+		{
+			name:               "service with invalid max concurrent streams",
+			annotations:        map[string]string{constants.HTTP2MaxConcurrentStreamsAnnotation: "many"},
+			expectedMaxStreams: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedMaxStreams, mc.GetHTTP2MaxConcurrentStreamsForService(svc))
+		})
+	}
+}
+
+func TestGetAccessLogSamplingPercentageForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name               string
+		annotations        map[string]string
+		expectedPercentage float64
+	}{
+		{
+			name:               "service without annotation",
+			annotations:        nil,
+			expectedPercentage: 100,
+		},
+		{
+			name:               "service with a sampling percentage",
+			annotations:        map[string]string{constants.AccessLogSamplingAnnotation: " 12.5% "},
+			expectedPercentage: 12.5,
+		},
+		{
+			name:               "service logging 1 in N requests",
+			annotations:        map[string]string{constants.AccessLogSamplingAnnotation: "1/1000"},
+			expectedPercentage: 0.1,
+		},
+		{
+			name:               "service with a sampling percentage above 100%",
+			annotations:        map[string]string{constants.AccessLogSamplingAnnotation: "150%"},
+			expectedPercentage: 100,
+		},
+		{
+			name:               "service with a zero denominator",
+			annotations:        map[string]string{constants.AccessLogSamplingAnnotation: "1/0"},
+			expectedPercentage: 100,
+		},
+		{
+			name:               "service with invalid sampling",
+			annotations:        map[string]string{constants.AccessLogSamplingAnnotation: "10"},
+			expectedPercentage: 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedPercentage, mc.GetAccessLogSamplingPercentageForService(svc))
+		})
+	}
+}
+
+func TestIsTLSSessionTicketsDisabledForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "payments", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedDisabled bool
+	}{
+		{
+			name:             "service without annotation",
+			annotations:      nil,
+			expectedDisabled: false,
+		},
+		{
+			name:             "service with session tickets disabled",
+			annotations:      map[string]string{constants.TLSSessionTicketsDisabledAnnotation: " true "},
+			expectedDisabled: true,
+		},
+		{
+			name:             "service with session tickets explicitly enabled",
+			annotations:      map[string]string{constants.TLSSessionTicketsDisabledAnnotation: "false"},
+			expectedDisabled: false,
+		},
+		{
+			name:             "service with invalid annotation",
+			annotations:      map[string]string{constants.TLSSessionTicketsDisabledAnnotation: "sometimes"},
+			expectedDisabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedDisabled, mc.IsTLSSessionTicketsDisabledForService(svc))
+		})
+	}
+}
+
+func TestGetHTTP1ProtocolOptionsForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "legacy", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name            string
+		annotations     map[string]string
+		expectedOptions *trafficpolicy.HTTP1ProtocolOptions
+	}{
+		{
+			name:            "service without annotations",
+			annotations:     nil,
+			expectedOptions: nil,
+		},
+		{
+			name: "legacy service requiring proper case headers and keepalive",
+			annotations: map[string]string{
+				constants.HTTP1ProperCaseHeadersAnnotation: "true",
+				constants.HTTP1KeepaliveTimeAnnotation:     " 30s ",
+			},
+			expectedOptions: &trafficpolicy.HTTP1ProtocolOptions{ProperCaseHeaders: true, KeepaliveTime: 30 * time.Second},
+		},
+		{
+			name:            "service setting the keepalive time only",
+			annotations:     map[string]string{constants.HTTP1KeepaliveTimeAnnotation: "2m"},
+			expectedOptions: &trafficpolicy.HTTP1ProtocolOptions{KeepaliveTime: 2 * time.Minute},
+		},
+		{
+			name: "service with invalid values",
+			annotations: map[string]string{
+				constants.HTTP1ProperCaseHeadersAnnotation: "proper",
+				constants.HTTP1KeepaliveTimeAnnotation:     "500ms",
+			},
+			expectedOptions: &trafficpolicy.HTTP1ProtocolOptions{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedOptions, mc.GetHTTP1ProtocolOptionsForService(svc))
+		})
+	}
+}
+
+func TestGetClientAddressDetectionForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	svc := service.MeshService{Name: "frontend", Namespace: "ns-1"}
+
+	testCases := []struct {
+		name              string
+		annotations       map[string]string
+		expectedDetection *trafficpolicy.ClientAddressDetection
+	}{
+		{
+			name:              "service without annotations",
+			annotations:       nil,
+			expectedDetection: nil,
+		},
+		{
+			name: "service using the remote address behind trusted hops",
+			annotations: map[string]string{
+				constants.UseRemoteAddressAnnotation:  "true",
+				constants.XFFNumTrustedHopsAnnotation: " 1 ",
+			},
+			expectedDetection: &trafficpolicy.ClientAddressDetection{UseRemoteAddress: true, XFFNumTrustedHops: 1},
+		},
+		{
+			name:              "service setting trusted hops only",
+			annotations:       map[string]string{constants.XFFNumTrustedHopsAnnotation: "2"},
+			expectedDetection: &trafficpolicy.ClientAddressDetection{XFFNumTrustedHops: 2},
+		},
+		{
+			name: "service with invalid values",
+			annotations: map[string]string{
+				constants.UseRemoteAddressAnnotation:  "yes please",
+				constants.XFFNumTrustedHopsAnnotation: "-1",
+			},
+			expectedDetection: &trafficpolicy.ClientAddressDetection{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			mockKubeController.EXPECT().GetService(svc).Return(k8sSvc).Times(1)
+
+			assert.Equal(tc.expectedDetection, mc.GetClientAddressDetectionForService(svc))
+		})
+	}
+}
+
+func TestGetUint32Annotation(t *testing.T) {
+	assert := tassert.New(t)
+
+	svc := service.MeshService{Name: "bookstore", Namespace: "ns-1"}
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
+	testCases := []struct {
+		name          string
+		value         string
+		minValue      uint64
+		maxValue      uint64
+		expectedValue *uint32
+	}{
+		{
+			name:          "value within bounds",
+			value:         " 10 ",
+			minValue:      1,
+			maxValue:      100,
+			expectedValue: uint32Ptr(10),
+		},
+		{
+			name:          "value at the bounds",
+			value:         "100",
+			minValue:      100,
+			maxValue:      100,
+			expectedValue: uint32Ptr(100),
+		},
+		{
+			name:          "value below the minimum",
+			value:         "0",
+			minValue:      1,
+			maxValue:      100,
+			expectedValue: nil,
+		},
+		{
+			name:          "value above the maximum",
+			value:         "101",
+			minValue:      1,
+			maxValue:      100,
+			expectedValue: nil,
+		},
+		{
+			name:          "value that is not an integer",
+			value:         "ten",
+			minValue:      0,
+			maxValue:      100,
+			expectedValue: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations := map[string]string{"test-annotation": tc.value}
+			assert.Equal(tc.expectedValue, getUint32Annotation(annotations, "test-annotation", tc.minValue, tc.maxValue, svc))
+		})
+	}
+
+	assert.Nil(getUint32Annotation(nil, "test-annotation", 0, 100, svc))
+}
@@ -2,11 +2,15 @@ package catalog
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	mapset "github.com/deckarep/golang-set"
 	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
@@ -14,6 +18,9 @@ import (
 const (
 	serviceAccountKind = "ServiceAccount"
 	tcpRouteKind       = "TCPRoute"
+
+	trafficTargetKind       = "TrafficTarget"
+	trafficTargetAPIVersion = "access.smi-spec.io/v1alpha3"
 )
 
 // ListAllowedInboundServiceAccounts lists the downstream service accounts that can connect to the given upstream service account
@@ -73,6 +80,80 @@ func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream service.K8sS
 	return trafficTargets, nil
 }
 
+// GetMeshTopology returns the graph of the communications allowed by the SMI TrafficTarget policies, each edge with the
+// TrafficTarget allowing it. The services of each source service account of a TrafficTarget are allowed to communicate
+// with the services of its destination service account. A source service account without services, such as the one of
+// a client workload, is a node of its own. In permissive traffic policy mode, where any workload is allowed to
+// communicate with any service, the topology is marked permissive and no edges are listed.
+func (mc *MeshCatalog) GetMeshTopology() trafficpolicy.MeshTopology {
+	if mc.configurator.IsPermissiveTrafficPolicyMode() {
+		return trafficpolicy.MeshTopology{Permissive: true}
+	}
+
+	var edges []trafficpolicy.MeshTopologyEdge
+	for _, t := range mc.meshSpec.ListTrafficTargets() {
+		if !isValidTrafficTarget(t) || t.Spec.Destination.Kind != serviceAccountKind {
+			continue
+		}
+
+		destinationSvcAccount := trafficTargetIdentityToSvcAccount(t.Spec.Destination)
+		destinationServices, err := mc.GetServicesForServiceAccount(destinationSvcAccount)
+		if err != nil {
+			log.Debug().Err(err).Msgf("No service found for destination service account %s of TrafficTarget %s/%s", destinationSvcAccount, t.Namespace, t.Name)
+			continue
+		}
+
+		trafficTargetName := fmt.Sprintf("%s/%s", t.Namespace, t.Name)
+		for _, sourceSvcAccount := range trafficTargetIdentitiesToSvcAccounts(t.Spec.Sources) {
+			var sourceNodes []trafficpolicy.MeshTopologyNode
+			sourceServices, err := mc.GetServicesForServiceAccount(sourceSvcAccount)
+			if err != nil || len(sourceServices) == 0 {
+				log.Trace().Msgf("No service found for source service account %s of TrafficTarget %s, using the service account as the source", sourceSvcAccount, trafficTargetName)
+				sourceNodes = append(sourceNodes, trafficpolicy.MeshTopologyNode{
+					Kind:      trafficpolicy.MeshTopologyServiceAccountNode,
+					Namespace: sourceSvcAccount.Namespace,
+					Name:      sourceSvcAccount.Name,
+				})
+			}
+			for _, sourceService := range sourceServices {
+				sourceNodes = append(sourceNodes, getMeshTopologyServiceNode(sourceService))
+			}
+			for _, sourceNode := range sourceNodes {
+				for _, destinationService := range destinationServices {
+					edges = append(edges, trafficpolicy.MeshTopologyEdge{
+						Source:        sourceNode,
+						Destination:   getMeshTopologyServiceNode(destinationService),
+						TrafficTarget: trafficTargetName,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			if edges[i].Source.String() != edges[j].Source.String() {
+				return edges[i].Source.String() < edges[j].Source.String()
+			}
+			return edges[i].Source.Kind < edges[j].Source.Kind
+		}
+		if edges[i].Destination != edges[j].Destination {
+			return edges[i].Destination.String() < edges[j].Destination.String()
+		}
+		return edges[i].TrafficTarget < edges[j].TrafficTarget
+	})
+	return trafficpolicy.MeshTopology{Edges: edges}
+}
+
+// getMeshTopologyServiceNode returns the node of the mesh topology for the given service
+func getMeshTopologyServiceNode(svc service.MeshService) trafficpolicy.MeshTopologyNode {
+	return trafficpolicy.MeshTopologyNode{
+		Kind:      trafficpolicy.MeshTopologyServiceNode,
+		Namespace: svc.Namespace,
+		Name:      svc.Name,
+	}
+}
+
 func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcAccount service.K8sServiceAccount, direction trafficDirection) ([]service.K8sServiceAccount, error) {
 	var allowedSvcAccounts []service.K8sServiceAccount
 	allowed := mapset.NewSet()
@@ -184,3 +265,79 @@ func (mc *MeshCatalog) getTCPRouteMatchesFromTrafficTarget(trafficTarget smiAcce
 
 	return matches, nil
 }
+
+// getTrafficTargetReferenceErrors returns the references of the given TrafficTarget to services and routes that do not exist
+func (mc *MeshCatalog) getTrafficTargetReferenceErrors(trafficTarget *smiAccess.TrafficTarget) []string {
+	var referenceErrors []string
+
+	if trafficTarget.Spec.Destination.Kind != serviceAccountKind {
+		referenceErrors = append(referenceErrors, fmt.Sprintf("destination has unsupported kind %s", trafficTarget.Spec.Destination.Kind))
+	} else {
+		destinationSvcAccount := trafficTargetIdentityToSvcAccount(trafficTarget.Spec.Destination)
+		if _, err := mc.GetServicesForServiceAccount(destinationSvcAccount); err != nil {
+			referenceErrors = append(referenceErrors, fmt.Sprintf("no service found for destination service account %s", destinationSvcAccount))
+		}
+	}
+
+	// Routes referenced in a traffic target must belong to the same namespace as the traffic target
+	httpRouteGroups := make(map[string]map[string]bool)
+	for _, routeGroup := range mc.meshSpec.ListHTTPTrafficSpecs() {
+		if routeGroup.Namespace != trafficTarget.Namespace {
+			continue
+		}
+		matchNames := make(map[string]bool)
+		for _, match := range routeGroup.Spec.Matches {
+			matchNames[match.Name] = true
+		}
+		httpRouteGroups[routeGroup.Name] = matchNames
+	}
+
+	for _, rule := range trafficTarget.Spec.Rules {
+		switch rule.Kind {
+		case HTTPTraffic:
+			matchNames, found := httpRouteGroups[rule.Name]
+			if !found {
+				referenceErrors = append(referenceErrors, fmt.Sprintf("%s %s/%s not found", HTTPTraffic, trafficTarget.Namespace, rule.Name))
+				continue
+			}
+			for _, match := range rule.Matches {
+				if !matchNames[match] {
+					referenceErrors = append(referenceErrors, fmt.Sprintf("match %s not found in %s %s/%s", match, HTTPTraffic, trafficTarget.Namespace, rule.Name))
+				}
+			}
+
+		case tcpRouteKind:
+			if mc.meshSpec.GetTCPRoute(fmt.Sprintf("%s/%s", trafficTarget.Namespace, rule.Name)) == nil {
+				referenceErrors = append(referenceErrors, fmt.Sprintf("%s %s/%s not found", tcpRouteKind, trafficTarget.Namespace, rule.Name))
+			}
+
+		default:
+			referenceErrors = append(referenceErrors, fmt.Sprintf("rule %s has unsupported kind %s", rule.Name, rule.Kind))
+		}
+	}
+
+	return referenceErrors
+}
+
+// validateTrafficTarget validates the references of the given TrafficTarget, and records a Normal event on the
+// TrafficTarget if it is accepted, or a Warning event listing the dangling references otherwise
+func (mc *MeshCatalog) validateTrafficTarget(trafficTarget *smiAccess.TrafficTarget) {
+	// The TrafficTarget is referenced explicitly, since the SMI types are not registered with the Kubernetes scheme
+	ref := &corev1.ObjectReference{
+		Kind:            trafficTargetKind,
+		APIVersion:      trafficTargetAPIVersion,
+		Namespace:       trafficTarget.Namespace,
+		Name:            trafficTarget.Name,
+		UID:             trafficTarget.UID,
+		ResourceVersion: trafficTarget.ResourceVersion,
+	}
+
+	referenceErrors := mc.getTrafficTargetReferenceErrors(trafficTarget)
+	if len(referenceErrors) == 0 {
+		mc.eventRecorder.Event(ref, corev1.EventTypeNormal, events.PolicyAccepted, "TrafficTarget is valid and accepted")
+		return
+	}
+
+	log.Warn().Msgf("TrafficTarget %s/%s has invalid references: %s", trafficTarget.Namespace, trafficTarget.Name, strings.Join(referenceErrors, "; "))
+	mc.eventRecorder.Eventf(ref, corev1.EventTypeWarning, events.PolicyInvalidReference, "TrafficTarget has invalid references: %s", strings.Join(referenceErrors, "; "))
+}
@@ -9,8 +9,10 @@ import (
 	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	tassert "github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -886,3 +888,272 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTrafficTarget(t *testing.T) {
+	destSA := service.K8sServiceAccount{
+		Name:      "bookstore",
+		Namespace: "bookstore-ns",
+	}
+	destMeshService := service.MeshService{
+		Name:      "bookstore",
+		Namespace: "bookstore-ns",
+	}
+	routeGroups := []*smiSpecs.HTTPRouteGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookstore-routes",
+				Namespace: "bookstore-ns",
+			},
+			Spec: smiSpecs.HTTPRouteGroupSpec{
+				Matches: []smiSpecs.HTTPMatch{{
+					Name:      "buy-books",
+					PathRegex: "/buy",
+					Methods:   []string{"GET"},
+				}},
+			},
+		},
+	}
+
+	newTrafficTarget := func(rules ...smiAccess.TrafficTargetRule) *smiAccess.TrafficTarget {
+		return &smiAccess.TrafficTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookbuyer-access-bookstore",
+				Namespace: "bookstore-ns",
+			},
+			Spec: smiAccess.TrafficTargetSpec{
+				Destination: smiAccess.IdentityBindingSubject{
+					Kind:      "ServiceAccount",
+					Name:      destSA.Name,
+					Namespace: destSA.Namespace,
+				},
+				Sources: []smiAccess.IdentityBindingSubject{{
+					Kind:      "ServiceAccount",
+					Name:      "bookbuyer",
+					Namespace: "bookbuyer-ns",
+				}},
+				Rules: rules,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		trafficTarget *smiAccess.TrafficTarget
+		destServices  []service.MeshService
+		expectedEvent string
+	}{
+		{
+			name: "valid traffic target is accepted",
+			trafficTarget: newTrafficTarget(smiAccess.TrafficTargetRule{
+				Kind:    "HTTPRouteGroup",
+				Name:    "bookstore-routes",
+				Matches: []string{"buy-books"},
+			}),
+			destServices:  []service.MeshService{destMeshService},
+			expectedEvent: "Normal Accepted TrafficTarget is valid and accepted",
+		},
+		{
+			name: "traffic target referencing a missing route group",
+			trafficTarget: newTrafficTarget(smiAccess.TrafficTargetRule{
+				Kind: "HTTPRouteGroup",
+				Name: "missing-routes",
+			}),
+			destServices:  []service.MeshService{destMeshService},
+			expectedEvent: "Warning InvalidReference TrafficTarget has invalid references: HTTPRouteGroup bookstore-ns/missing-routes not found",
+		},
+		{
+			name: "traffic target referencing a missing match and TCP route",
+			trafficTarget: newTrafficTarget(smiAccess.TrafficTargetRule{
+				Kind:    "HTTPRouteGroup",
+				Name:    "bookstore-routes",
+				Matches: []string{"sell-books"},
+			}, smiAccess.TrafficTargetRule{
+				Kind: "TCPRoute",
+				Name: "missing-tcp-route",
+			}),
+			destServices:  []service.MeshService{destMeshService},
+			expectedEvent: "Warning InvalidReference TrafficTarget has invalid references: match sell-books not found in HTTPRouteGroup bookstore-ns/bookstore-routes; TCPRoute bookstore-ns/missing-tcp-route not found",
+		},
+		{
+			name: "traffic target whose destination has no service",
+			trafficTarget: newTrafficTarget(smiAccess.TrafficTargetRule{
+				Kind: "HTTPRouteGroup",
+				Name: "bookstore-routes",
+			}),
+			destServices:  nil,
+			expectedEvent: "Warning InvalidReference TrafficTarget has invalid references: no service found for destination service account bookstore-ns/bookstore",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
+			recorder := record.NewFakeRecorder(1)
+
+			mc := MeshCatalog{
+				meshSpec:           mockMeshSpec,
+				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
+				eventRecorder:      recorder,
+			}
+
+			mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return(routeGroups).AnyTimes()
+			mockMeshSpec.EXPECT().GetTCPRoute(gomock.Any()).Return(nil).AnyTimes()
+			mockEndpointProvider.EXPECT().GetServicesForServiceAccount(destSA).Return(tc.destServices, nil).AnyTimes()
+			mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
+
+			mc.validateTrafficTarget(tc.trafficTarget)
+
+			assert.Len(recorder.Events, 1)
+			assert.Equal(tc.expectedEvent, <-recorder.Events)
+		})
+	}
+}
+
+func TestGetMeshTopology(t *testing.T) {
+	bookbuyerSA := service.K8sServiceAccount{Name: "bookbuyer", Namespace: "bookbuyer-ns"}
+	bookthiefSA := service.K8sServiceAccount{Name: "bookthief", Namespace: "bookthief-ns"}
+	bookstoreSA := service.K8sServiceAccount{Name: "bookstore", Namespace: "bookstore-ns"}
+	bookwarehouseSA := service.K8sServiceAccount{Name: "bookwarehouse", Namespace: "bookwarehouse-ns"}
+
+	servicesForServiceAccount := map[service.K8sServiceAccount][]service.MeshService{
+		bookbuyerSA:     {{Name: "bookbuyer", Namespace: "bookbuyer-ns"}},
+		bookstoreSA:     {{Name: "bookstore-v1", Namespace: "bookstore-ns"}, {Name: "bookstore-v2", Namespace: "bookstore-ns"}},
+		bookwarehouseSA: {{Name: "bookwarehouse", Namespace: "bookwarehouse-ns"}},
+	}
+
+	newTrafficTarget := func(name string, destination service.K8sServiceAccount, sources ...service.K8sServiceAccount) *smiAccess.TrafficTarget {
+		trafficTarget := &smiAccess.TrafficTarget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: destination.Namespace,
+			},
+			Spec: smiAccess.TrafficTargetSpec{
+				Destination: smiAccess.IdentityBindingSubject{
+					Kind:      "ServiceAccount",
+					Name:      destination.Name,
+					Namespace: destination.Namespace,
+				},
+				Rules: []smiAccess.TrafficTargetRule{{
+					Kind: "HTTPRouteGroup",
+					Name: "routes",
+				}},
+			},
+		}
+		for _, source := range sources {
+			trafficTarget.Spec.Sources = append(trafficTarget.Spec.Sources, smiAccess.IdentityBindingSubject{
+				Kind:      "ServiceAccount",
+				Name:      source.Name,
+				Namespace: source.Namespace,
+			})
+		}
+		return trafficTarget
+	}
+
+	serviceNode := func(namespace, name string) trafficpolicy.MeshTopologyNode {
+		return trafficpolicy.MeshTopologyNode{Kind: trafficpolicy.MeshTopologyServiceNode, Namespace: namespace, Name: name}
+	}
+
+	testCases := []struct {
+		name             string
+		permissiveMode   bool
+		trafficTargets   []*smiAccess.TrafficTarget
+		expectedTopology trafficpolicy.MeshTopology
+	}{
+		{
+			name: "edges from each service of the sources to each service of the destination",
+			trafficTargets: []*smiAccess.TrafficTarget{
+				newTrafficTarget("bookstore-access", bookstoreSA, bookbuyerSA),
+				newTrafficTarget("bookwarehouse-access", bookwarehouseSA, bookstoreSA),
+			},
+			expectedTopology: trafficpolicy.MeshTopology{Edges: []trafficpolicy.MeshTopologyEdge{
+				{
+					Source:        serviceNode("bookbuyer-ns", "bookbuyer"),
+					Destination:   serviceNode("bookstore-ns", "bookstore-v1"),
+					TrafficTarget: "bookstore-ns/bookstore-access",
+				},
+				{
+					Source:        serviceNode("bookbuyer-ns", "bookbuyer"),
+					Destination:   serviceNode("bookstore-ns", "bookstore-v2"),
+					TrafficTarget: "bookstore-ns/bookstore-access",
+				},
+				{
+					Source:        serviceNode("bookstore-ns", "bookstore-v1"),
+					Destination:   serviceNode("bookwarehouse-ns", "bookwarehouse"),
+					TrafficTarget: "bookwarehouse-ns/bookwarehouse-access",
+				},
+				{
+					Source:        serviceNode("bookstore-ns", "bookstore-v2"),
+					Destination:   serviceNode("bookwarehouse-ns", "bookwarehouse"),
+					TrafficTarget: "bookwarehouse-ns/bookwarehouse-access",
+				},
+			}},
+		},
+		{
+			name: "service account nodes for the source service accounts without services, no edges for the traffic targets without rules",
+			trafficTargets: []*smiAccess.TrafficTarget{
+				newTrafficTarget("bookwarehouse-access", bookwarehouseSA, bookthiefSA, bookbuyerSA),
+				func() *smiAccess.TrafficTarget {
+					trafficTarget := newTrafficTarget("bookstore-access", bookstoreSA, bookbuyerSA)
+					trafficTarget.Spec.Rules = nil
+					return trafficTarget
+				}(),
+			},
+			expectedTopology: trafficpolicy.MeshTopology{Edges: []trafficpolicy.MeshTopologyEdge{
+				{
+					Source:        serviceNode("bookbuyer-ns", "bookbuyer"),
+					Destination:   serviceNode("bookwarehouse-ns", "bookwarehouse"),
+					TrafficTarget: "bookwarehouse-ns/bookwarehouse-access",
+				},
+				{
+					Source: trafficpolicy.MeshTopologyNode{
+						Kind:      trafficpolicy.MeshTopologyServiceAccountNode,
+						Namespace: "bookthief-ns",
+						Name:      "bookthief",
+					},
+					Destination:   serviceNode("bookwarehouse-ns", "bookwarehouse"),
+					TrafficTarget: "bookwarehouse-ns/bookwarehouse-access",
+				},
+			}},
+		},
+		{
+			name:           "permissive topology without edges in permissive mode",
+			permissiveMode: true,
+			trafficTargets: []*smiAccess.TrafficTarget{
+				newTrafficTarget("bookstore-access", bookstoreSA, bookbuyerSA),
+			},
+			expectedTopology: trafficpolicy.MeshTopology{Permissive: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+			mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			mc := MeshCatalog{
+				meshSpec:           mockMeshSpec,
+				endpointsProviders: []endpoint.Provider{mockEndpointProvider},
+				configurator:       mockConfigurator,
+			}
+
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(tc.permissiveMode).Times(1)
+			mockMeshSpec.EXPECT().ListTrafficTargets().Return(tc.trafficTargets).AnyTimes()
+			mockEndpointProvider.EXPECT().GetServicesForServiceAccount(gomock.Any()).DoAndReturn(func(sa service.K8sServiceAccount) ([]service.MeshService, error) {
+				return servicesForServiceAccount[sa], nil
+			}).AnyTimes()
+			mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
+
+			assert.Equal(tc.expectedTopology, mc.GetMeshTopology())
+		})
+	}
+}
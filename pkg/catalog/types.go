@@ -9,6 +9,7 @@ import (
 	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -52,6 +53,9 @@ type MeshCatalog struct {
 
 	// Maintain a mapping of pod UID to certificate SerialNumber of the Envoy on the given pod
 	podUIDToCertificateSerialNumber sync.Map
+
+	// eventRecorder posts the Kubernetes events reporting the validation of SMI policies
+	eventRecorder record.EventRecorder
 }
 
 // MeshCataloger is the mechanism by which the Service Mesh controller discovers all Envoy proxies connected to the catalog.
@@ -59,9 +63,15 @@ type MeshCataloger interface {
 	// GetSMISpec returns the SMI spec
 	GetSMISpec() smi.MeshSpec
 
+	// IsConfigReadyForProxy returns whether the mesh config of the proxy with the given certificate common name can be computed
+	IsConfigReadyForProxy(certificate.CommonName) bool
+
 	// ListTrafficPolicies returns all the traffic policies for a given service that Envoy proxy should be aware of.
 	ListTrafficPolicies(service.MeshService) ([]trafficpolicy.TrafficTarget, error)
 
+	// HasMaxRequestBytesOverrides returns true if the routes of any HTTPRouteGroup override the mesh wide limit on the size of requests
+	HasMaxRequestBytesOverrides() bool
+
 	// ListTrafficPoliciesForServiceAccount returns all inbound and outbound traffic policies related to the given service account
 	ListTrafficPoliciesForServiceAccount(service.K8sServiceAccount) ([]*trafficpolicy.InboundTrafficPolicy, []*trafficpolicy.OutboundTrafficPolicy, error)
 
@@ -71,6 +81,9 @@ type MeshCataloger interface {
 	// ListAllowedOutboundServicesForIdentity list the services the given service account is allowed to initiate outbound connections to
 	ListAllowedOutboundServicesForIdentity(service.K8sServiceAccount) []service.MeshService
 
+	// GetMeshTopology returns the graph of the communications allowed by the SMI TrafficTarget policies
+	GetMeshTopology() trafficpolicy.MeshTopology
+
 	// ListAllowedInboundServiceAccounts lists the downstream service accounts that can connect to the given service account
 	ListAllowedInboundServiceAccounts(service.K8sServiceAccount) ([]service.K8sServiceAccount, error)
 
@@ -86,6 +99,10 @@ type MeshCataloger interface {
 	// ListEndpointsForService returns the list of individual instance endpoint backing a service
 	ListEndpointsForService(service.MeshService) ([]endpoint.Endpoint, error)
 
+	// ListAllEndpointsForService returns the list of individual instance endpoint backing a service, including the
+	// instances not ready to serve traffic, which are flagged NotReady
+	ListAllEndpointsForService(service.MeshService) ([]endpoint.Endpoint, error)
+
 	// GetResolvableServiceEndpoints returns the resolvable set of endpoint over which a service is accessible using its FQDN.
 	// These are the endpoint destinations we'd expect client applications sends the traffic towards to, when attempting to
 	// reach a specific service.
@@ -98,6 +115,12 @@ type MeshCataloger interface {
 	// GetServicesFromEnvoyCertificate returns a list of services the given Envoy is a member of based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
 	GetServicesFromEnvoyCertificate(certificate.CommonName) ([]service.MeshService, error)
 
+	// GetInboundPortExclusionListForProxy returns the inbound ports of the pod of the given Envoy that are excluded from interception
+	GetInboundPortExclusionListForProxy(certificate.CommonName) []int
+
+	// GetInboundPlaintextProbePortsForProxy returns the inbound ports of the pod of the given Envoy the kubelet probes in plaintext, and the IP of the pod's node
+	GetInboundPlaintextProbePortsForProxy(certificate.CommonName) ([]uint32, string)
+
 	// RegisterProxy registers a newly connected proxy with the service mesh catalog.
 	RegisterProxy(*envoy.Proxy)
 
@@ -119,6 +142,84 @@ type MeshCataloger interface {
 	// ListMonitoredNamespaces lists namespaces monitored by the control plane
 	ListMonitoredNamespaces() []string
 
+	// GetUpstreamSNIForService returns the SNI override for connections to the given upstream service, or an empty string if none is set
+	GetUpstreamSNIForService(service.MeshService) string
+
+	// IsExternalTLSOriginationEnabledForService returns whether the downstream proxies of the given service outside the mesh originate TLS to it
+	IsExternalTLSOriginationEnabledForService(service.MeshService) bool
+
+	// GetFailoverServicesForService returns the ordered list of services that traffic to the given upstream service fails over to, or nil if none are set
+	GetFailoverServicesForService(service.MeshService) []service.MeshService
+
+	// ListAllowedFailoverServicesForService returns the ordered list of services that traffic to the given upstream service fails over to and the given downstream identity is allowed to access
+	ListAllowedFailoverServicesForService(service.K8sServiceAccount, service.MeshService) []service.MeshService
+
+	// GetMirrorPoliciesForService returns the policies mirroring requests to the given upstream service to shadow services
+	GetMirrorPoliciesForService(service.MeshService) []trafficpolicy.MirrorPolicy
+
+	// ListAllowedMirrorPoliciesForService returns the policies mirroring requests to the given upstream service to the shadow services the given downstream identity is allowed to access
+	ListAllowedMirrorPoliciesForService(service.K8sServiceAccount, service.MeshService) []trafficpolicy.MirrorPolicy
+
+	// GetOutlierDetectionForService returns the overrides of the outlier detection of the given upstream service's endpoints, or nil if none are set
+	GetOutlierDetectionForService(service.MeshService) *trafficpolicy.OutlierDetection
+
+	// GetCircuitBreakingForService returns the circuit breaker thresholds of the given upstream service per routing priority, or nil if none are set
+	GetCircuitBreakingForService(service.MeshService) *trafficpolicy.CircuitBreaking
+
+	// GetHealthCheckForService returns the active health checking of the given upstream service's endpoints, or nil if it is not enabled
+	GetHealthCheckForService(service.MeshService) *trafficpolicy.HealthCheck
+
+	// IsOutboundDisabledForService returns true if the proxies of the given service must not be programmed with the outbound filter chains and clusters of their upstreams
+	IsOutboundDisabledForService(service.MeshService) bool
+
+	// GetAllowedSourceIPRangesForService returns the source IP CIDR ranges allowed to connect to the given service
+	GetAllowedSourceIPRangesForService(service.MeshService) []string
+
+	// GetClusterTypeForService returns the type of the cluster of the given service set by the service, or an empty string if not set
+	GetClusterTypeForService(service.MeshService) string
+
+	// GetUpstreamBindSourceAddressForService returns the source address connections to the given service are bound to set by the service, or an empty string if not set
+	GetUpstreamBindSourceAddressForService(service.MeshService) string
+
+	// GetDNSRefreshRateForService returns the rate at which the DNS name of the given service's DNS clusters is resolved set by the service, or 0 if not set
+	GetDNSRefreshRateForService(service.MeshService) time.Duration
+
+	// GetUpstreamIdleTimeoutForService returns the idle timeout of the connections to the given service set by the service, or 0 if not set
+	GetUpstreamIdleTimeoutForService(service.MeshService) time.Duration
+
+	// GetRequestTimeoutForService returns the timeout of the requests to the given service set by the service, or 0 if not set
+	GetRequestTimeoutForService(service.MeshService) time.Duration
+
+	// IsDNSTTLRespectedForService returns whether the given service's DNS clusters respect the TTL of DNS records, and whether it is set by the service
+	IsDNSTTLRespectedForService(service.MeshService) (respected bool, ok bool)
+
+	// GetHTTP2MaxConcurrentStreamsForService returns the maximum number of concurrent streams on an inbound HTTP/2 connection to the given service set by the service, or 0 if not set
+	GetHTTP2MaxConcurrentStreamsForService(service.MeshService) uint32
+
+	// GetAccessLogSamplingPercentageForService returns the percentage of the requests to the given service logged by its proxies, 100 if not set
+	GetAccessLogSamplingPercentageForService(service.MeshService) float64
+
+	// GetPerConnectionBufferLimitBytesForService returns the limit in bytes on the connection buffers of the listeners of the given service's proxies set by the service, or 0 if not set
+	GetPerConnectionBufferLimitBytesForService(service.MeshService) uint32
+
+	// GetHTTP1ProtocolOptionsForService returns the HTTP/1.1 options of the connections of the given service's proxies to the local application set by the service, or nil if not set
+	GetHTTP1ProtocolOptionsForService(service.MeshService) *trafficpolicy.HTTP1ProtocolOptions
+
+	// IsTLSSessionTicketsDisabledForService returns true if the given service's proxies must not resume inbound TLS sessions using session tickets
+	IsTLSSessionTicketsDisabledForService(service.MeshService) bool
+
+	// GetClientAddressDetectionForService returns how the proxies of the given service determine the client address of inbound HTTP requests set by the service, or nil if not set
+	GetClientAddressDetectionForService(service.MeshService) *trafficpolicy.ClientAddressDetection
+
+	// GetDirectResponseForService returns the direct response sent instead of routing requests to the given service, or nil if requests are routed
+	GetDirectResponseForService(service.MeshService) *trafficpolicy.DirectResponse
+
+	// GetHashPolicyForService returns the hash policy used to load balance requests to the given service by consistent hashing, or nil if none is set
+	GetHashPolicyForService(service.MeshService) *trafficpolicy.HashPolicy
+
+	// GetRetryPolicyForService returns the policy retrying requests to the given service, or nil if requests are not retried
+	GetRetryPolicyForService(service.MeshService) *trafficpolicy.RetryPolicy
+
 	// GetTargetPortToProtocolMappingForService returns a mapping of the service's ports to their corresponding application protocol.
 	// The ports returned are the actual ports on which the application exposes the service derived from the service's endpoints,
 	// ie. 'spec.ports[].targetPort' instead of 'spec.ports[].port' for a Kubernetes service.
@@ -2,11 +2,14 @@ package catalog
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -101,6 +104,132 @@ func (mc *MeshCatalog) filterTrafficSplitServices(services []v1.Service) []v1.Se
 	return filteredServices
 }
 
+// GetInboundPortExclusionListForProxy returns the inbound ports of the pod of the Envoy with the given certificate
+// that are excluded from interception, as set by the pod's annotation. Errors are logged and no port is excluded.
+func (mc *MeshCatalog) GetInboundPortExclusionListForProxy(cn certificate.CommonName) []int {
+	pod, err := GetPodFromCertificate(cn, mc.kubeController)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting pod of Envoy with certificate CN=%s", cn)
+		return nil
+	}
+
+	ports, err := k8s.GetInboundPortExclusionList(pod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting inbound port exclusion list of pod %s/%s", pod.Namespace, pod.Name)
+		return nil
+	}
+	return ports
+}
+
+// GetInboundPlaintextProbePortsForProxy returns the inbound ports of the pod of the Envoy with the given certificate
+// the kubelet probes and Prometheus scrapes in plaintext, and the IP of the pod's node these connections are accepted
+// from. The ports are derived from the TCP and HTTP probes of the pod's containers and the port scraped by Prometheus as
+// set by the pod's annotation, unless the pod overrides them with its inbound plaintext probe ports annotation. Envoy's
+// own ports and the ports excluded from interception are not returned. No port is returned if the pod's node IP is not
+// known yet.
+func (mc *MeshCatalog) GetInboundPlaintextProbePortsForProxy(cn certificate.CommonName) ([]uint32, string) {
+	pod, err := GetPodFromCertificate(cn, mc.kubeController)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting pod of Envoy with certificate CN=%s", cn)
+		return nil, ""
+	}
+
+	var probePorts []int
+	if _, ok := pod.Annotations[constants.InboundPlaintextProbePortsAnnotation]; ok {
+		probePorts, err = k8s.GetInboundPlaintextProbePorts(pod)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting inbound plaintext probe ports of pod %s/%s", pod.Namespace, pod.Name)
+			return nil, ""
+		}
+	} else {
+		probePorts = getProbedAndScrapedPorts(pod)
+	}
+	if len(probePorts) == 0 {
+		return nil, ""
+	}
+	if pod.Status.HostIP == "" {
+		log.Debug().Msgf("Node IP of pod %s/%s is not known yet, not accepting plaintext probes", pod.Namespace, pod.Name)
+		return nil, ""
+	}
+
+	skipped := map[int]bool{
+		constants.EnvoyAdminPort:                     true,
+		constants.EnvoyInboundListenerPort:           true,
+		constants.EnvoyOutboundListenerPort:          true,
+		constants.EnvoyPrometheusInboundListenerPort: true,
+		constants.EnvoyLivenessProbePort:             true,
+		constants.EnvoyReadinessProbePort:            true,
+		constants.EnvoyStartupProbePort:              true,
+	}
+	excludedPorts, err := k8s.GetInboundPortExclusionList(pod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting inbound port exclusion list of pod %s/%s", pod.Namespace, pod.Name)
+	}
+	for _, port := range excludedPorts {
+		skipped[port] = true
+	}
+
+	var ports []uint32
+	for _, port := range probePorts {
+		if skipped[port] {
+			continue
+		}
+		skipped[port] = true
+		ports = append(ports, uint32(port))
+	}
+	return ports, pod.Status.HostIP
+}
+
+// getProbedAndScrapedPorts returns the ports of the TCP and HTTP probes of the given pod's containers, and the port
+// scraped by Prometheus as set by the pod's annotation
+func getProbedAndScrapedPorts(pod *v1.Pod) []int {
+	var ports []int
+	addPort := func(port int) {
+		if port > 0 && port <= math.MaxUint16 {
+			ports = append(ports, port)
+		}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, probe := range []*v1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+			if probe == nil {
+				continue
+			}
+			if probe.HTTPGet != nil {
+				addPort(getContainerPort(probe.HTTPGet.Port, container.Ports))
+			}
+			if probe.TCPSocket != nil {
+				addPort(getContainerPort(probe.TCPSocket.Port, container.Ports))
+			}
+		}
+	}
+
+	if metricsPort, ok := pod.Annotations[constants.PrometheusPortAnnotation]; ok {
+		port, err := strconv.Atoi(strings.TrimSpace(metricsPort))
+		if err != nil {
+			log.Error().Err(err).Msgf("Ignoring invalid value %q of annotation %s of pod %s/%s, must be a port", metricsPort, constants.PrometheusPortAnnotation, pod.Namespace, pod.Name)
+		} else {
+			addPort(port)
+		}
+	}
+
+	return ports
+}
+
+// getContainerPort returns the number of the given port, looking up the port by name in the given container ports if
+// it is a named port. It returns 0 if the named port is not found.
+func getContainerPort(port intstr.IntOrString, containerPorts []v1.ContainerPort) int {
+	if port.Type == intstr.Int {
+		return port.IntValue()
+	}
+	for _, containerPort := range containerPorts {
+		if containerPort.Name == port.String() {
+			return int(containerPort.ContainerPort)
+		}
+	}
+	return 0
+}
+
 // GetPodFromCertificate returns the Kubernetes Pod object for a given certificate.
 func GetPodFromCertificate(cn certificate.CommonName, kubecontroller k8s.Controller) (*v1.Pod, error) {
 	cnMeta, err := getCertificateCommonNameMeta(cn)
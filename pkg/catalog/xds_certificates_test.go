@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -181,6 +182,27 @@ var _ = Describe("Test XDS certificate tooling", func() {
 		})
 	})
 
+	Context("Test IsConfigReadyForProxy()", func() {
+		It("is ready once the pod of the proxy is observed", func() {
+			namespace := uuid.New().String()
+			proxyUUID := uuid.New()
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			meshCatalog := &MeshCatalog{kubeController: mockKubeController}
+			newCN := certificate.CommonName(fmt.Sprintf("%s.%s.%s", proxyUUID, tests.BookstoreServiceAccountName, namespace))
+
+			// The proxy connects before its pod is observed
+			mockKubeController.EXPECT().ListPods().Return(nil).Times(1)
+			Expect(meshCatalog.IsConfigReadyForProxy(newCN)).To(BeFalse())
+
+			newPod := tests.NewPodFixture(namespace, uuid.New().String(), tests.BookstoreServiceAccountName, map[string]string{
+				constants.EnvoyUniqueIDLabelName: proxyUUID.String(),
+			})
+			mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{&newPod}).Times(1)
+			mockKubeController.EXPECT().ListServices().Return(nil).Times(1)
+			Expect(meshCatalog.IsConfigReadyForProxy(newCN)).To(BeTrue())
+		})
+	})
+
 	Context("Test GetPodFromCertificate()", func() {
 		It("fails with invalid certificate", func() {
 			namespace := uuid.New().String()
@@ -500,6 +522,85 @@ var _ = Describe("Test XDS certificate tooling", func() {
 		})
 	})
 
+	Context("Test GetInboundPlaintextProbePortsForProxy()", func() {
+		proxyUUID := uuid.New()
+		namespace := uuid.New().String()
+		podCN := certificate.CommonName(fmt.Sprintf("%s.%s.%s", proxyUUID, tests.BookstoreServiceAccountName, namespace))
+
+		newPod := func(annotations map[string]string) *v1.Pod {
+			pod := tests.NewPodFixture(namespace, uuid.New().String(), tests.BookstoreServiceAccountName, tests.PodLabels)
+			pod.Labels[constants.EnvoyUniqueIDLabelName] = proxyUUID.String()
+			pod.Annotations = annotations
+			pod.Status.HostIP = "10.240.0.4"
+			return &pod
+		}
+
+		It("returns the ports overridden by the pod's annotation that are not excluded, and the pod's node IP", func() {
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			meshCatalog := &MeshCatalog{kubeController: mockKubeController}
+			pod := newPod(map[string]string{
+				constants.InboundPlaintextProbePortsAnnotation: fmt.Sprintf("8081,6060,%d", constants.EnvoyInboundListenerPort),
+				constants.InboundPortExclusionListAnnotation:   "6060",
+			})
+			mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{pod})
+
+			ports, nodeIP := meshCatalog.GetInboundPlaintextProbePortsForProxy(podCN)
+			Expect(ports).To(Equal([]uint32{8081}))
+			Expect(nodeIP).To(Equal("10.240.0.4"))
+		})
+
+		It("returns the ports of the pod's TCP and HTTP probes and its Prometheus scrape port when not overridden", func() {
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			meshCatalog := &MeshCatalog{kubeController: mockKubeController}
+			pod := newPod(map[string]string{
+				constants.PrometheusPortAnnotation:           "9090",
+				constants.InboundPortExclusionListAnnotation: "6060",
+			})
+			pod.Spec.Containers = []v1.Container{{
+				Name:  "app",
+				Ports: []v1.ContainerPort{{Name: "health", ContainerPort: 8081}},
+				LivenessProbe: &v1.Probe{
+					Handler: v1.Handler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromString("health")}},
+				},
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{Port: intstr.FromInt(constants.EnvoyReadinessProbePort)}},
+				},
+				StartupProbe: &v1.Probe{
+					Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{Port: intstr.FromInt(6060)}},
+				},
+			}}
+			mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{pod})
+
+			ports, nodeIP := meshCatalog.GetInboundPlaintextProbePortsForProxy(podCN)
+			Expect(ports).To(Equal([]uint32{8081, 9090}))
+			Expect(nodeIP).To(Equal("10.240.0.4"))
+		})
+
+		It("returns no port when the pod overrides the ports with an empty annotation", func() {
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			meshCatalog := &MeshCatalog{kubeController: mockKubeController}
+			pod := newPod(map[string]string{
+				constants.InboundPlaintextProbePortsAnnotation: "",
+				constants.PrometheusPortAnnotation:             "9090",
+			})
+			mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{pod})
+
+			ports, _ := meshCatalog.GetInboundPlaintextProbePortsForProxy(podCN)
+			Expect(ports).To(BeNil())
+		})
+
+		It("returns no port when the pod's node IP is not known yet", func() {
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			meshCatalog := &MeshCatalog{kubeController: mockKubeController}
+			pod := newPod(map[string]string{constants.PrometheusPortAnnotation: "9090"})
+			pod.Status.HostIP = ""
+			mockKubeController.EXPECT().ListPods().Return([]*v1.Pod{pod})
+
+			ports, _ := meshCatalog.GetInboundPlaintextProbePortsForProxy(podCN)
+			Expect(ports).To(BeNil())
+		})
+	})
+
 	Context("Test GetServiceAccountFromProxyCertificate", func() {
 		It("should correctly return the ServiceAccount encoded in the XDS certificate CN", func() {
 			cn := certificate.CommonName(fmt.Sprintf("%s.sa-name.sa-namespace", uuid.New().String()))
@@ -25,6 +25,19 @@ func EncodeCertDERtoPEM(derBytes []byte) (pem.Certificate, error) {
 	return certOut.Bytes(), nil
 }
 
+// EncodeCRLDERtoPEM encodes the certificate revocation list provided in DER format into PEM format
+func EncodeCRLDERtoPEM(derBytes []byte) (pem.CertificateRevocationList, error) {
+	crlOut := &bytes.Buffer{}
+	block := pemEnc.Block{
+		Type:  TypeCertificateRevocationList,
+		Bytes: derBytes,
+	}
+	if err := pemEnc.Encode(crlOut, &block); err != nil {
+		return nil, errors.Wrap(err, errEncodeCRL.Error())
+	}
+	return crlOut.Bytes(), nil
+}
+
 // EncodeKeyDERtoPEM converts a DER encoded private key into a PEM encoded key
 func EncodeKeyDERtoPEM(priv *rsa.PrivateKey) (pem.PrivateKey, error) {
 	keyOut := &bytes.Buffer{}
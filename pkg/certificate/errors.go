@@ -6,6 +6,7 @@ import (
 
 var errEncodeKey = errors.New("encode key")
 var errEncodeCert = errors.New("encode cert")
+var errEncodeCRL = errors.New("encode CRL")
 var errMarshalPrivateKey = errors.New("marshal private key")
 var errNoCertificateInPEM = errors.New("no certificate in PEM")
 var errNoPrivateKeyInPEM = errors.New("no private Key in PEM")
@@ -0,0 +1,272 @@
+package certificate_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/certificate/rotor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+var errFakeCertNotFound = errors.New("certificate not found")
+
+// fakeCertificate is a certificate issued by fakeProvider
+type fakeCertificate struct {
+	commonName   certificate.CommonName
+	serialNumber certificate.SerialNumber
+	expiration   time.Time
+	issuingCA    []byte
+}
+
+func (c fakeCertificate) GetCommonName() certificate.CommonName {
+	return c.commonName
+}
+
+func (c fakeCertificate) GetCertificateChain() []byte {
+	return []byte(fmt.Sprintf("chain-%s", c.serialNumber))
+}
+
+func (c fakeCertificate) GetPrivateKey() []byte {
+	return []byte(fmt.Sprintf("key-%s", c.serialNumber))
+}
+
+func (c fakeCertificate) GetIssuingCA() []byte {
+	return c.issuingCA
+}
+
+func (c fakeCertificate) GetExpiration() time.Time {
+	return c.expiration
+}
+
+func (c fakeCertificate) GetSerialNumber() certificate.SerialNumber {
+	return c.serialNumber
+}
+
+// fakeProvider is an in-memory certificate.Manager standing for a certificate provider other than the built-in ones
+type fakeProvider struct {
+	mu            sync.Mutex
+	serialNumber  int
+	validity      time.Duration
+	root          fakeCertificate
+	certificates  map[certificate.CommonName]certificate.Certificater
+	announcements chan announcements.Announcement
+}
+
+func newFakeProvider(validity time.Duration) *fakeProvider {
+	return &fakeProvider{
+		validity: validity,
+		root: fakeCertificate{
+			commonName:   "fake-root",
+			serialNumber: "0",
+			expiration:   time.Now().Add(24 * time.Hour),
+			issuingCA:    []byte("fake-root-ca"),
+		},
+		certificates:  make(map[certificate.CommonName]certificate.Certificater),
+		announcements: make(chan announcements.Announcement),
+	}
+}
+
+func (p *fakeProvider) issue(cn certificate.CommonName, validity time.Duration) certificate.Certificater {
+	p.serialNumber++
+	cert := fakeCertificate{
+		commonName:   cn,
+		serialNumber: certificate.SerialNumber(fmt.Sprintf("%d", p.serialNumber)),
+		expiration:   time.Now().Add(validity),
+		issuingCA:    p.root.issuingCA,
+	}
+	p.certificates[cn] = cert
+	return cert
+}
+
+func (p *fakeProvider) IssueCertificate(cn certificate.CommonName, validity time.Duration) (certificate.Certificater, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cert, ok := p.certificates[cn]; ok {
+		return cert, nil
+	}
+	return p.issue(cn, validity), nil
+}
+
+func (p *fakeProvider) GetCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cert, ok := p.certificates[cn]; ok {
+		return cert, nil
+	}
+	return nil, errFakeCertNotFound
+}
+
+func (p *fakeProvider) RotateCertificate(cn certificate.CommonName) (certificate.Certificater, error) {
+	p.mu.Lock()
+	cert := p.issue(cn, p.validity)
+	p.mu.Unlock()
+	p.announcements <- announcements.Announcement{}
+	return cert, nil
+}
+
+func (p *fakeProvider) GetRootCertificate() (certificate.Certificater, error) {
+	return p.root, nil
+}
+
+func (p *fakeProvider) ListCertificates() ([]certificate.Certificater, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var certs []certificate.Certificater
+	for _, cert := range p.certificates {
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+func (p *fakeProvider) ReleaseCertificate(cn certificate.CommonName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.certificates, cn)
+}
+
+func (p *fakeProvider) GetAnnouncementsChannel() <-chan announcements.Announcement {
+	return p.announcements
+}
+
+// The certificate.Manager contract every certificate provider must honor, so that the provider is pluggable into the
+// components issuing and rotating certificates through it
+var _ = Describe("Test the certificate.Manager contract", func() {
+	validity := 1 * time.Hour
+
+	providers := map[string]func() certificate.Manager{
+		"fake provider": func() certificate.Manager {
+			return newFakeProvider(validity)
+		},
+		"Tresor provider": func() certificate.Manager {
+			mockConfigurator := configurator.NewMockConfigurator(gomock.NewController(GinkgoT()))
+			mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(validity).AnyTimes()
+			return tresor.NewFakeCertManager(mockConfigurator)
+		},
+	}
+
+	for name, newManager := range providers {
+		name, newManager := name, newManager
+
+		Context(name, func() {
+			var (
+				certManager certificate.Manager
+				announced   chan struct{}
+				stop        chan struct{}
+			)
+
+			BeforeEach(func() {
+				certManager = newManager()
+				announced = make(chan struct{}, 1)
+				stop = make(chan struct{})
+				go func(announcementsCh <-chan announcements.Announcement, announced chan<- struct{}, stop <-chan struct{}) {
+					for {
+						select {
+						case <-announcementsCh:
+							select {
+							case announced <- struct{}{}:
+							default:
+							}
+						case <-stop:
+							return
+						}
+					}
+				}(certManager.GetAnnouncementsChannel(), announced, stop)
+			})
+
+			AfterEach(func() {
+				close(stop)
+			})
+
+			It("issues certificates signed by its root certificate", func() {
+				cn := certificate.CommonName("bookstore.default.cluster.local")
+				cert, err := certManager.IssueCertificate(cn, validity)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert.GetCommonName()).To(Equal(cn))
+				Expect(cert.GetCertificateChain()).ToNot(BeEmpty())
+				Expect(cert.GetPrivateKey()).ToNot(BeEmpty())
+				Expect(cert.GetExpiration()).To(BeTemporally("~", time.Now().Add(validity), time.Minute))
+
+				root, err := certManager.GetRootCertificate()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert.GetIssuingCA()).ToNot(BeEmpty())
+				Expect(cert.GetIssuingCA()).To(Equal(root.GetIssuingCA()))
+			})
+
+			It("returns the certificate issued for a common name until it is released", func() {
+				cn := certificate.CommonName("bookbuyer.default.cluster.local")
+				_, err := certManager.GetCertificate(cn)
+				Expect(err).To(HaveOccurred())
+
+				cert, err := certManager.IssueCertificate(cn, validity)
+				Expect(err).ToNot(HaveOccurred())
+				reissued, err := certManager.IssueCertificate(cn, validity)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reissued.GetSerialNumber()).To(Equal(cert.GetSerialNumber()))
+				got, err := certManager.GetCertificate(cn)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(got.GetSerialNumber()).To(Equal(cert.GetSerialNumber()))
+
+				certs, err := certManager.ListCertificates()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(certs).To(HaveLen(1))
+				Expect(certs[0].GetSerialNumber()).To(Equal(cert.GetSerialNumber()))
+
+				certManager.ReleaseCertificate(cn)
+				_, err = certManager.GetCertificate(cn)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("rotates a certificate and announces the rotation", func() {
+				cn := certificate.CommonName("bookwarehouse.default.cluster.local")
+				cert, err := certManager.IssueCertificate(cn, validity)
+				Expect(err).ToNot(HaveOccurred())
+
+				rotated, err := certManager.RotateCertificate(cn)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rotated.GetCommonName()).To(Equal(cn))
+				Expect(rotated.GetSerialNumber()).ToNot(Equal(cert.GetSerialNumber()))
+				Eventually(announced).Should(Receive())
+
+				got, err := certManager.GetCertificate(cn)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(got.GetSerialNumber()).To(Equal(rotated.GetSerialNumber()))
+			})
+		})
+	}
+
+	It("rotates the expiring certificates of a provider other than the built-in ones with the certificate rotor", func() {
+		provider := newFakeProvider(validity)
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			for {
+				select {
+				case <-provider.GetAnnouncementsChannel():
+				case <-stop:
+					return
+				}
+			}
+		}()
+
+		cn := certificate.CommonName("bookthief.default.cluster.local")
+		expiring, err := provider.IssueCertificate(cn, time.Second)
+		Expect(err).ToNot(HaveOccurred())
+
+		rotor.New(provider).Start(10 * time.Millisecond)
+
+		Eventually(func() certificate.SerialNumber {
+			cert, err := provider.GetCertificate(cn)
+			Expect(err).ToNot(HaveOccurred())
+			return cert.GetSerialNumber()
+		}).ShouldNot(Equal(expiring.GetSerialNumber()))
+	})
+})
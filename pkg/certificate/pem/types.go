@@ -14,3 +14,6 @@ type RootPrivateKey []byte
 
 // CertificateRequest is an SSL certificate request.
 type CertificateRequest []byte
+
+// CertificateRevocationList is a list of revoked SSL certificates signed by their issuer.
+type CertificateRevocationList []byte
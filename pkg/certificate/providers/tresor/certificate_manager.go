@@ -5,13 +5,16 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"math/big"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/certificate/rotor"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 )
 
 func (cm *CertManager) issue(cn certificate.CommonName, validityPeriod time.Duration) (certificate.Certificater, error) {
@@ -178,3 +181,95 @@ func (cm *CertManager) GetRootCertificate() (certificate.Certificater, error) {
 func (cm *CertManager) GetAnnouncementsChannel() <-chan announcements.Announcement {
 	return cm.announcements
 }
+
+// RevokeCertificate implements certificate.RevocationListPublisher and revokes the certificate issued for the given Common Name (CN).
+// The revoked certificate is removed from the cache, so that a new certificate is issued for the CN when requested, and
+// the updated CRL is pushed to the proxies with an SDS broadcast.
+func (cm *CertManager) RevokeCertificate(cn certificate.CommonName) error {
+	certInterface, exists := cm.cache.Load(cn)
+	if !exists {
+		return errCertNotFound
+	}
+	cert := certInterface.(certificate.Certificater)
+
+	serialNumber, ok := new(big.Int).SetString(cert.GetSerialNumber().String(), 10)
+	if !ok {
+		log.Error().Msgf("Error parsing SerialNumber=%s of certificate with CN=%s", cert.GetSerialNumber(), cn)
+		return errInvalidSerialNumber
+	}
+
+	cm.revokedCertificates.Store(cert.GetSerialNumber(), revokedCertificate{
+		RevokedCertificate: pkix.RevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: time.Now(),
+		},
+		expiration: cert.GetExpiration(),
+	})
+	cm.deleteFromCache(cn)
+
+	// The announcement is dropped when no one is receiving it, so that revoking does not block
+	select {
+	case cm.announcements <- announcements.Announcement{}:
+	default:
+	}
+	events.GetPubSubInstance().Publish(events.PubSubMessage{
+		AnnouncementType: announcements.CertificateRevoked,
+		OldObj:           cn,
+	})
+
+	log.Debug().Msgf("Revoked certificate with SerialNumber=%s and CN=%s", cert.GetSerialNumber(), cn)
+
+	return nil
+}
+
+// GetCertificateRevocationList implements certificate.RevocationListPublisher and returns the PEM encoded CRL
+// signed by the CA, or nil if no certificate has been revoked. Expired certificates are rejected regardless of the CRL,
+// so they are dropped from the CRL to keep it from growing with every revocation.
+func (cm *CertManager) GetCertificateRevocationList() (pem.CertificateRevocationList, error) {
+	var revokedCerts []pkix.RevokedCertificate
+	now := time.Now()
+	cm.revokedCertificates.Range(func(serialNumber interface{}, revokedCertInterface interface{}) bool {
+		revokedCert := revokedCertInterface.(revokedCertificate)
+		if now.After(revokedCert.expiration) {
+			cm.revokedCertificates.Delete(serialNumber)
+			return true // continue the iteration
+		}
+		revokedCerts = append(revokedCerts, revokedCert.RevokedCertificate)
+		return true // continue the iteration
+	})
+	if len(revokedCerts) == 0 {
+		return nil, nil
+	}
+
+	if cm.ca == nil {
+		log.Error().Msg("Invalid CA provided for signing the certificate revocation list")
+		return nil, errNoIssuingCA
+	}
+
+	x509Root, err := certificate.DecodePEMCertificate(cm.ca.GetCertificateChain())
+	if err != nil {
+		log.Error().Err(err).Msg("Error decoding Root Certificate's PEM")
+		return nil, err
+	}
+
+	rsaKeyRoot, err := certificate.DecodePEMPrivateKey(cm.ca.GetPrivateKey())
+	if err != nil {
+		log.Error().Err(err).Msg("Error decoding Root Certificate's Private Key PEM")
+		return nil, err
+	}
+
+	// The CRL is valid for as long as the CA, since peers reject all certificates once the CRL they were given expires
+	template := &x509.RevocationList{
+		Number:              big.NewInt(time.Now().UnixNano()),
+		ThisUpdate:          time.Now(),
+		NextUpdate:          cm.ca.GetExpiration(),
+		RevokedCertificates: revokedCerts,
+	}
+	derBytes, err := x509.CreateRevocationList(rand.Reader, template, x509Root, rsaKeyRoot)
+	if err != nil {
+		log.Error().Err(err).Msg("Error issuing x509.CreateRevocationList command")
+		return nil, errors.Wrap(err, errCreateCRL.Error())
+	}
+
+	return certificate.EncodeCRLDERtoPEM(derBytes)
+}
@@ -1,14 +1,17 @@
 package tresor
 
 import (
+	"crypto/x509"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 )
 
 var _ = Describe("Test Certificate Manager", func() {
@@ -92,4 +95,68 @@ var _ = Describe("Test Certificate Manager", func() {
 			Expect(cachedCert).To(Equal(cert))
 		})
 	})
+
+	Context("Test revoking a certificate", func() {
+		validity := 1 * time.Hour
+		cn := certificate.CommonName("Test CA")
+		rootCertCountry := "US"
+		rootCertLocality := "CA"
+		rootCertOrganization := "Open Service Mesh Tresor"
+
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(validity).AnyTimes()
+
+		rootCert, err := NewCA(cn, validity, rootCertCountry, rootCertLocality, rootCertOrganization)
+		if err != nil {
+			GinkgoT().Fatalf("Error creating CA: %s", err.Error())
+		}
+		m, newCertError := NewCertManager(rootCert, "org", mockConfigurator)
+		It("should publish a certificate revocation list containing the revoked certificate", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+
+			crl, err := m.GetCertificateRevocationList()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(crl).To(BeNil())
+
+			cert, issueCertificateError := m.IssueCertificate(serviceFQDN, validity)
+			Expect(issueCertificateError).ToNot(HaveOccurred())
+
+			// Revoking does not block without a receiver of the announcement, and requests an SDS broadcast
+			rcvRevokedChannel := events.GetPubSubInstance().Subscribe(announcements.CertificateRevoked)
+			defer events.GetPubSubInstance().Unsub(rcvRevokedChannel)
+			Expect(m.RevokeCertificate(serviceFQDN)).To(Succeed())
+			Eventually(rcvRevokedChannel).Should(Receive())
+			Expect(m.RevokeCertificate("not.issued")).To(HaveOccurred())
+
+			_, getCertificateError := m.GetCertificate(serviceFQDN)
+			Expect(getCertificateError).To(HaveOccurred())
+
+			crl, err = m.GetCertificateRevocationList()
+			Expect(err).ToNot(HaveOccurred())
+
+			certList, err := x509.ParseCRL(crl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certList.TBSCertList.RevokedCertificates).To(HaveLen(1))
+			Expect(certList.TBSCertList.RevokedCertificates[0].SerialNumber.String()).To(Equal(cert.GetSerialNumber().String()))
+
+			x509Root, err := certificate.DecodePEMCertificate(rootCert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(x509Root.CheckCRLSignature(certList)).To(Succeed())
+		})
+
+		It("should drop expired certificates from the certificate revocation list", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+
+			_, issueCertificateError := m.IssueCertificate("expiring.cert", 1*time.Nanosecond)
+			Expect(issueCertificateError).ToNot(HaveOccurred())
+			Expect(m.RevokeCertificate("expiring.cert")).To(Succeed())
+
+			crl, err := m.GetCertificateRevocationList()
+			Expect(err).ToNot(HaveOccurred())
+			certList, err := x509.ParseCRL(crl)
+			Expect(err).ToNot(HaveOccurred())
+			// Only the certificate revoked by the previous test is listed
+			Expect(certList.TBSCertList.RevokedCertificates).To(HaveLen(1))
+		})
+	})
 })
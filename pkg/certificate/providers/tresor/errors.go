@@ -9,3 +9,5 @@ var errGeneratingSerialNumber = errors.New("generate serial number")
 var errGeneratingPrivateKey = errors.New("generate private")
 var errNoIssuingCA = errors.New("no issuing CA")
 var errCertNotFound = errors.New("certificate not found")
+var errInvalidSerialNumber = errors.New("invalid serial number")
+var errCreateCRL = errors.New("create CRL")
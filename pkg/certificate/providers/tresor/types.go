@@ -1,6 +1,7 @@
 package tresor
 
 import (
+	"crypto/x509/pkix"
 	"math/big"
 	"sync"
 	"time"
@@ -40,6 +41,10 @@ type CertManager struct {
 	// Types: map[certificate.CommonName]certificate.Certificater
 	cache sync.Map
 
+	// The certificates revoked by this certificate manager
+	// Types: map[certificate.SerialNumber]revokedCertificate
+	revokedCertificates sync.Map
+
 	certificatesOrganization string
 
 	cfg configurator.Configurator
@@ -63,3 +68,11 @@ type Certificate struct {
 	// Certificate authority signing this certificate
 	issuingCA pem.RootCertificate
 }
+
+// revokedCertificate is a certificate revoked by the certificate manager, listed in the CRL until it expires
+type revokedCertificate struct {
+	pkix.RevokedCertificate
+
+	// When the revoked cert expires
+	expiration time.Time
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
 )
 
 const (
@@ -16,6 +17,9 @@ const (
 	// TypeCertificateRequest is a string constant to be used in the generation
 	// of a certificate requests.
 	TypeCertificateRequest = "CERTIFICATE REQUEST"
+
+	// TypeCertificateRevocationList is a string constant to be used in the generation of a certificate revocation list.
+	TypeCertificateRevocationList = "X509 CRL"
 )
 
 // SerialNumber is the Serial Number of the given certificate.
@@ -78,3 +82,13 @@ type Manager interface {
 	// GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the issued certificates.
 	GetAnnouncementsChannel() <-chan announcements.Announcement
 }
+
+// RevocationListPublisher is the interface implemented by the certificate managers able to revoke the certificates they issued.
+// The certificate revocation list (CRL) it publishes is used to reject revoked peer certificates during TLS validation.
+type RevocationListPublisher interface {
+	// RevokeCertificate revokes the certificate issued for the given Common Name (CN)
+	RevokeCertificate(CommonName) error
+
+	// GetCertificateRevocationList returns the PEM encoded CRL, or nil if no certificate has been revoked
+	GetCertificateRevocationList() (pem.CertificateRevocationList, error)
+}
@@ -53,6 +53,153 @@ const (
 
 	// outboundIPRangeExclusionListKey is the key name used to specify the ip ranges to exclude from outbound sidecar interception
 	outboundIPRangeExclusionListKey = "outbound_ip_range_exclusion_list"
+
+	// envoyStartupProbeFailureThresholdKey is the key name used to specify the failure threshold of the Envoy sidecar's startup probe
+	envoyStartupProbeFailureThresholdKey = "envoy_startup_probe_failure_threshold"
+
+	// envoyStartupProbePeriodSecondsKey is the key name used to specify the period in seconds of the Envoy sidecar's startup probe
+	envoyStartupProbePeriodSecondsKey = "envoy_startup_probe_period_seconds"
+
+	// enableInboundHTTP3Key is the key name used to enable the experimental HTTP/3 (QUIC) inbound listener in the ConfigMap
+	enableInboundHTTP3Key = "enable_inbound_http3"
+
+	// tracingCustomTagsKey is the key name used to specify custom tracing span tags derived from request headers
+	tracingCustomTagsKey = "tracing_custom_tags"
+
+	// enableEnvoyReadinessGateKey is the key name used to enable a pod readiness gate tracking the Envoy sidecar's initial config ACK
+	enableEnvoyReadinessGateKey = "enable_envoy_readiness_gate"
+
+	// enableDeltaXDSKey is the key name used to configure injected Envoy sidecars to use incremental (delta) xDS
+	enableDeltaXDSKey = "enable_delta_xds"
+
+	// envoyStatsTagsKey is the key name used to specify custom tag extraction rules for Envoy stats
+	envoyStatsTagsKey = "envoy_stats_tags"
+
+	// excludeNotReadyEndpointsKey is the key name used to exclude endpoints that are not ready from EDS responses
+	excludeNotReadyEndpointsKey = "exclude_not_ready_endpoints"
+
+	// envoySidecarEnvVarsKey is the key name used to specify additional environment variables for the injected Envoy sidecar
+	envoySidecarEnvVarsKey = "envoy_sidecar_env_vars"
+
+	// maxRequestBytesKey is the key name used to specify the mesh wide default limit on the size of inbound HTTP requests
+	maxRequestBytesKey = "max_request_bytes"
+
+	// enableOutboundBlackholeKey is the key name used to route unmatched outbound traffic to a blackhole cluster
+	enableOutboundBlackholeKey = "enable_outbound_blackhole"
+
+	// enableHTTPMethodStatsKey is the key name used to enable per method and path request stats for inbound HTTP routes
+	enableHTTPMethodStatsKey = "enable_http_method_stats"
+
+	// tlsMinimumProtocolVersionKey is the key name used to specify the minimum TLS protocol version for mTLS between proxies
+	tlsMinimumProtocolVersionKey = "tls_minimum_protocol_version"
+
+	// tlsMaximumProtocolVersionKey is the key name used to specify the maximum TLS protocol version for mTLS between proxies
+	tlsMaximumProtocolVersionKey = "tls_maximum_protocol_version"
+
+	// tlsCipherSuitesKey is the key name used to specify the cipher suites for mTLS between proxies
+	tlsCipherSuitesKey = "tls_cipher_suites"
+
+	// enableInboundUnmatchedSNIPassthroughKey is the key name used to pass inbound connections with an unmatched SNI through to the local application
+	enableInboundUnmatchedSNIPassthroughKey = "enable_inbound_unmatched_sni_passthrough"
+
+	// envoyStatsSinksKey is the key name used to specify additional sinks for Envoy stats
+	envoyStatsSinksKey = "envoy_stats_sinks"
+
+	// envoyStatsFlushIntervalKey is the key name used to specify the interval at which Envoy flushes stats to its sinks
+	envoyStatsFlushIntervalKey = "envoy_stats_flush_interval"
+
+	// listenerDSCPKey is the key name used to specify the DSCP value marked on the packets sent by the sidecar's inbound and outbound listeners
+	listenerDSCPKey = "listener_dscp"
+
+	// enableListenerReusePortKey is the key name used to specify whether the sockets of the sidecar's inbound and outbound listeners set SO_REUSEPORT
+	enableListenerReusePortKey = "enable_listener_reuse_port"
+
+	// proxyConfigPinTTLKey is the key name used to specify the duration after which a proxy pinned to its config is unpinned
+	proxyConfigPinTTLKey = "proxy_config_pin_ttl"
+
+	// tcpAccessLogFormatKey is the key name used to specify the format of the access log of TCP connections
+	tcpAccessLogFormatKey = "tcp_access_log_format"
+
+	// xdsKeepaliveTimeKey is the key name used to specify the interval at which the xDS server pings idle connections
+	xdsKeepaliveTimeKey = "xds_keepalive_time"
+
+	// xdsKeepaliveTimeoutKey is the key name used to specify how long the xDS server waits for a ping ack before closing the connection
+	xdsKeepaliveTimeoutKey = "xds_keepalive_timeout"
+
+	// xdsMaxConnectionAgeKey is the key name used to specify the maximum duration of a connection to the xDS server
+	xdsMaxConnectionAgeKey = "xds_max_connection_age"
+
+	// enableOutboundOriginalDstKey is the key name used to enable the original_dst mode of the outbound listener
+	enableOutboundOriginalDstKey = "enable_outbound_original_dst"
+
+	// enableSourceIPRangeMTLSBypassKey is the key name used to allow plaintext inbound connections from the allowed source IP ranges of a service
+	enableSourceIPRangeMTLSBypassKey = "enable_source_ip_range_mtls_bypass"
+
+	// defaultClusterTypeKey is the key name used to specify the default type of the upstream service clusters
+	defaultClusterTypeKey = "default_cluster_type"
+
+	// upstreamBindSourceAddressKey is the key name used for the source address upstream connections are bound to in the ConfigMap
+	upstreamBindSourceAddressKey = "upstream_bind_source_address"
+
+	// proxyReconnectGracePeriodKey is the key name used for the grace period within which a reconnecting proxy keeps its config state in the ConfigMap
+	proxyReconnectGracePeriodKey = "proxy_reconnect_grace_period"
+
+	// dnsRefreshRateKey is the key name used for the DNS refresh rate of DNS clusters in the ConfigMap
+	dnsRefreshRateKey = "dns_refresh_rate"
+
+	// respectDNSTTLKey is the key name used to specify whether DNS clusters respect the TTL of DNS records in the ConfigMap
+	respectDNSTTLKey = "respect_dns_ttl"
+
+	// enableListenerExactBalanceKey is the key name used to enable the exact balance of inbound connections across worker threads in the ConfigMap
+	enableListenerExactBalanceKey = "enable_listener_exact_balance"
+
+	// endpointMetadataNodeLabelsKey is the key name used for the node labels added to the metadata of EDS endpoints in the ConfigMap
+	endpointMetadataNodeLabelsKey = "endpoint_metadata_node_labels"
+
+	// defaultEndpointZoneKey is the key name used for the locality zone of the EDS endpoints without a zone in the ConfigMap
+	defaultEndpointZoneKey = "default_endpoint_zone"
+
+	// trafficSplitEmptyBackendModeKey is the key name used for the handling of the backends of a TrafficSplit without endpoints in the ConfigMap
+	trafficSplitEmptyBackendModeKey = "traffic_split_empty_backend_mode"
+
+	// ingressStatPrefixKey is the key name used for the stat prefix of the HTTP connection managers of the ingress filter chains in the ConfigMap
+	ingressStatPrefixKey = "ingress_stat_prefix"
+
+	// permissiveModeSANAuthorizationKey is the key name used to authorize inbound connections by the SAN of the client certificate in permissive mode in the ConfigMap
+	permissiveModeSANAuthorizationKey = "enable_permissive_mode_san_authorization"
+
+	// outboundUnknownHostModeKey is the key name used for the handling of the outbound HTTP requests to unknown hosts in the ConfigMap
+	outboundUnknownHostModeKey = "outbound_unknown_host_mode"
+
+	// upstreamIdleTimeoutKey is the key name used for the idle timeout of the connections to upstream services in the ConfigMap
+	upstreamIdleTimeoutKey = "upstream_idle_timeout"
+
+	// tracingPropagationFormatKey is the key name used for the format of the trace context propagated across the mesh in the ConfigMap
+	tracingPropagationFormatKey = "tracing_propagation_format"
+
+	// outboundRequestTimeoutKey is the key name used for the timeout of the outbound HTTP requests of the sidecars in the ConfigMap
+	outboundRequestTimeoutKey = "outbound_request_timeout"
+
+	// perConnectionBufferLimitBytesKey is the key name used for the per connection buffer limit of the listeners of the sidecars in the ConfigMap
+	perConnectionBufferLimitBytesKey = "per_connection_buffer_limit_bytes"
+
+	// enableUpstreamClusterHeaderKey is the key name used to add a header identifying the upstream cluster to the responses to outbound HTTP requests
+	enableUpstreamClusterHeaderKey = "enable_upstream_cluster_header"
+
+	// dnsLookupFamilyKey is the key name used for the IP address family DNS clusters resolve DNS names to in the ConfigMap
+	dnsLookupFamilyKey = "dns_lookup_family"
+
+	// inboundTLSHandshakeTimeoutKey is the key name used for the timeout of the TLS handshakes of the inbound connections in the ConfigMap
+	inboundTLSHandshakeTimeoutKey = "inbound_tls_handshake_timeout"
+
+	// envoyConcurrencyKey is the key name used to specify the default number of worker threads of the Envoy sidecars
+	envoyConcurrencyKey = "envoy_concurrency"
+
+	// envoyRuntimeFlagsKey is the key name used to specify the flags of the static runtime layer of the Envoy sidecars
+	envoyRuntimeFlagsKey = "envoy_runtime_flags"
+
+	// enableFailStaticKey is the key name used to have the proxies connecting before the mesh config is ready reject requests with a 503
+	enableFailStaticKey = "enable_fail_static"
 )
 
 // NewConfigurator implements configurator.Configurator and creates the Kubernetes client to manage namespaces.
@@ -158,6 +305,40 @@ func (c *Client) configMapListener() {
 				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TracingAddress != newConfigMap.TracingAddress)
 				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TracingEndpoint != newConfigMap.TracingEndpoint)
 				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TracingPort != newConfigMap.TracingPort)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableInboundHTTP3 != newConfigMap.EnableInboundHTTP3)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TracingCustomTags != newConfigMap.TracingCustomTags)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.ExcludeNotReadyEndpoints != newConfigMap.ExcludeNotReadyEndpoints)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.MaxRequestBytes != newConfigMap.MaxRequestBytes)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableOutboundBlackhole != newConfigMap.EnableOutboundBlackhole)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableHTTPMethodStats != newConfigMap.EnableHTTPMethodStats)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TLSMinimumProtocolVersion != newConfigMap.TLSMinimumProtocolVersion)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TLSMaximumProtocolVersion != newConfigMap.TLSMaximumProtocolVersion)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TLSCipherSuites != newConfigMap.TLSCipherSuites)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableInboundUnmatchedSNIPassthrough != newConfigMap.EnableInboundUnmatchedSNIPassthrough)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.ListenerDSCP != newConfigMap.ListenerDSCP)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableListenerReusePort != newConfigMap.EnableListenerReusePort)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TCPAccessLogFormat != newConfigMap.TCPAccessLogFormat)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableOutboundOriginalDst != newConfigMap.EnableOutboundOriginalDst)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableSourceIPRangeMTLSBypass != newConfigMap.EnableSourceIPRangeMTLSBypass)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.DefaultClusterType != newConfigMap.DefaultClusterType)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.UpstreamBindSourceAddress != newConfigMap.UpstreamBindSourceAddress)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.DNSRefreshRate != newConfigMap.DNSRefreshRate)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.RespectDNSTTL != newConfigMap.RespectDNSTTL)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableListenerExactBalance != newConfigMap.EnableListenerExactBalance)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EndpointMetadataNodeLabels != newConfigMap.EndpointMetadataNodeLabels)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.DefaultEndpointZone != newConfigMap.DefaultEndpointZone)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TrafficSplitEmptyBackendMode != newConfigMap.TrafficSplitEmptyBackendMode)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.IngressStatPrefix != newConfigMap.IngressStatPrefix)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.PermissiveModeSANAuthorization != newConfigMap.PermissiveModeSANAuthorization)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.OutboundUnknownHostMode != newConfigMap.OutboundUnknownHostMode)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.UpstreamIdleTimeout != newConfigMap.UpstreamIdleTimeout)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TracingPropagationFormat != newConfigMap.TracingPropagationFormat)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.OutboundRequestTimeout != newConfigMap.OutboundRequestTimeout)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.PerConnectionBufferLimitBytes != newConfigMap.PerConnectionBufferLimitBytes)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableUpstreamClusterHeader != newConfigMap.EnableUpstreamClusterHeader)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.DNSLookupFamily != newConfigMap.DNSLookupFamily)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.InboundTLSHandshakeTimeout != newConfigMap.InboundTLSHandshakeTimeout)
+				triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnableFailStatic != newConfigMap.EnableFailStatic)
 
 				if triggerGlobalBroadcast {
 					log.Debug().Msgf("[%s] OSM ConfigMap update triggered global proxy broadcast",
@@ -219,6 +400,153 @@ type osmConfig struct {
 
 	// OutboundIPRangeExclusionList is the list of outbound IP ranges to exclude from sidecar interception
 	OutboundIPRangeExclusionList string `yaml:"outbound_ip_range_exclusion_list"`
+
+	// EnvoyStartupProbeFailureThreshold is the number of failed startup probes tolerated before the Envoy sidecar is restarted
+	EnvoyStartupProbeFailureThreshold int `yaml:"envoy_startup_probe_failure_threshold"`
+
+	// EnvoyStartupProbePeriodSeconds is how often (in seconds) the Envoy sidecar's startup probe is performed
+	EnvoyStartupProbePeriodSeconds int `yaml:"envoy_startup_probe_period_seconds"`
+
+	// EnableInboundHTTP3 is a bool toggle used to enable or disable the experimental HTTP/3 (QUIC) inbound listener
+	EnableInboundHTTP3 bool `yaml:"enable_inbound_http3"`
+
+	// TracingCustomTags is the list of span tags to populate from request headers
+	TracingCustomTags string `yaml:"tracing_custom_tags"`
+
+	// EnableEnvoyReadinessGate adds a readiness gate to injected pods which is satisfied once Envoy ACKs its initial config
+	EnableEnvoyReadinessGate bool `yaml:"enable_envoy_readiness_gate"`
+
+	// EnableDeltaXDS configures injected Envoy sidecars to use incremental (delta) xDS
+	EnableDeltaXDS bool `yaml:"enable_delta_xds"`
+
+	// EnvoyStatsTags is the list of tag extraction rules applied to the stats of Envoy sidecars
+	EnvoyStatsTags string `yaml:"envoy_stats_tags"`
+
+	// ExcludeNotReadyEndpoints excludes endpoints that are not ready from EDS responses instead of marking them unhealthy
+	ExcludeNotReadyEndpoints bool `yaml:"exclude_not_ready_endpoints"`
+
+	// EnvoySidecarEnvVars is the list of additional environment variables of the injected Envoy sidecar
+	EnvoySidecarEnvVars string `yaml:"envoy_sidecar_env_vars"`
+
+	// MaxRequestBytes is the mesh wide default limit in bytes on the size of inbound HTTP requests, 0 meaning unlimited
+	MaxRequestBytes int `yaml:"max_request_bytes"`
+
+	// EnableOutboundBlackhole routes outbound traffic not matching any traffic policy to a blackhole cluster when egress is disabled
+	EnableOutboundBlackhole bool `yaml:"enable_outbound_blackhole"`
+
+	// EnableHTTPMethodStats enables per method and path request stats for inbound HTTP routes
+	EnableHTTPMethodStats bool `yaml:"enable_http_method_stats"`
+
+	// TLSMinimumProtocolVersion is the minimum TLS protocol version for mTLS between proxies
+	TLSMinimumProtocolVersion string `yaml:"tls_minimum_protocol_version"`
+
+	// TLSMaximumProtocolVersion is the maximum TLS protocol version for mTLS between proxies
+	TLSMaximumProtocolVersion string `yaml:"tls_maximum_protocol_version"`
+
+	// TLSCipherSuites is the comma separated list of cipher suites for mTLS between proxies
+	TLSCipherSuites string `yaml:"tls_cipher_suites"`
+
+	// EnableInboundUnmatchedSNIPassthrough passes inbound connections not matching any filter chain through to the local application instead of rejecting them
+	EnableInboundUnmatchedSNIPassthrough bool `yaml:"enable_inbound_unmatched_sni_passthrough"`
+
+	// EnvoyStatsSinks is the comma separated list of additional sinks for the stats of Envoy sidecars
+	EnvoyStatsSinks string `yaml:"envoy_stats_sinks"`
+
+	// EnvoyStatsFlushInterval is the interval at which Envoy sidecars flush stats to their sinks
+	EnvoyStatsFlushInterval string `yaml:"envoy_stats_flush_interval"`
+
+	// ListenerDSCP is the DSCP value marked on the packets sent on the sockets of the inbound and outbound listeners, 0 meaning unmarked
+	ListenerDSCP int `yaml:"listener_dscp"`
+
+	// EnableListenerReusePort is a bool toggle used to set SO_REUSEPORT on the sockets of the inbound and outbound listeners
+	EnableListenerReusePort bool `yaml:"enable_listener_reuse_port"`
+
+	// ProxyConfigPinTTL is the duration after which a proxy pinned to its last acknowledged config is unpinned
+	ProxyConfigPinTTL string `yaml:"proxy_config_pin_ttl"`
+
+	// TCPAccessLogFormat is the Envoy format string used to log TCP connections
+	TCPAccessLogFormat string `yaml:"tcp_access_log_format"`
+
+	// XDSKeepaliveTime is the interval at which the xDS server pings idle connections
+	XDSKeepaliveTime string `yaml:"xds_keepalive_time"`
+
+	// XDSKeepaliveTimeout is how long the xDS server waits for a ping ack before closing the connection
+	XDSKeepaliveTimeout string `yaml:"xds_keepalive_timeout"`
+
+	// XDSMaxConnectionAge is the maximum duration of a connection to the xDS server
+	XDSMaxConnectionAge string `yaml:"xds_max_connection_age"`
+
+	// EnableOutboundOriginalDst enables the original_dst mode of the outbound listener, forwarding outbound traffic to its original destination
+	EnableOutboundOriginalDst bool `yaml:"enable_outbound_original_dst"`
+
+	// EnableSourceIPRangeMTLSBypass allows inbound connections from the allowed source IP ranges of a service without mTLS
+	EnableSourceIPRangeMTLSBypass bool `yaml:"enable_source_ip_range_mtls_bypass"`
+
+	// DefaultClusterType is the default type of the upstream service clusters, one of EDS, STRICT_DNS or LOGICAL_DNS
+	DefaultClusterType string `yaml:"default_cluster_type"`
+
+	// UpstreamBindSourceAddress is the source address Envoy binds the connections to upstream services to
+	UpstreamBindSourceAddress string `yaml:"upstream_bind_source_address"`
+
+	// ProxyReconnectGracePeriod is the period within which a proxy reconnecting to the xDS server keeps its config state
+	ProxyReconnectGracePeriod string `yaml:"proxy_reconnect_grace_period"`
+
+	// DNSRefreshRate is the rate at which the DNS names of DNS clusters are resolved
+	DNSRefreshRate string `yaml:"dns_refresh_rate"`
+
+	// RespectDNSTTL specifies whether DNS clusters resolve DNS names again once their DNS records expire
+	RespectDNSTTL string `yaml:"respect_dns_ttl"`
+
+	// EnableListenerExactBalance enables the exact balance of the inbound listener's connections across the sidecar's worker threads
+	EnableListenerExactBalance bool `yaml:"enable_listener_exact_balance"`
+
+	// EndpointMetadataNodeLabels is the comma separated list of the labels of the nodes of endpoints added to the metadata of EDS endpoints
+	EndpointMetadataNodeLabels string `yaml:"endpoint_metadata_node_labels"`
+
+	// DefaultEndpointZone is the locality zone of the EDS endpoints whose node has no zone
+	DefaultEndpointZone string `yaml:"default_endpoint_zone"`
+
+	// TrafficSplitEmptyBackendMode is the handling of the backends of a TrafficSplit without endpoints
+	TrafficSplitEmptyBackendMode string `yaml:"traffic_split_empty_backend_mode"`
+
+	// IngressStatPrefix is the stat prefix of the HTTP connection managers of the ingress filter chains
+	IngressStatPrefix string `yaml:"ingress_stat_prefix"`
+
+	// PermissiveModeSANAuthorization authorizes inbound connections by the SAN of the client certificate in permissive mode
+	PermissiveModeSANAuthorization bool `yaml:"enable_permissive_mode_san_authorization"`
+
+	// OutboundUnknownHostMode is the handling of the outbound HTTP requests to unknown hosts
+	OutboundUnknownHostMode string `yaml:"outbound_unknown_host_mode"`
+
+	// UpstreamIdleTimeout is the idle timeout of the connections to upstream services
+	UpstreamIdleTimeout string `yaml:"upstream_idle_timeout"`
+
+	// TracingPropagationFormat is the format of the trace context propagated across the mesh
+	TracingPropagationFormat string `yaml:"tracing_propagation_format"`
+
+	// OutboundRequestTimeout is the timeout of the outbound HTTP requests of the sidecars
+	OutboundRequestTimeout string `yaml:"outbound_request_timeout"`
+
+	// PerConnectionBufferLimitBytes is the mesh wide default limit in bytes on the buffers of the connections of the listeners, 0 meaning the Envoy default
+	PerConnectionBufferLimitBytes int `yaml:"per_connection_buffer_limit_bytes"`
+
+	// EnableUpstreamClusterHeader adds a header identifying the upstream cluster to the responses to outbound HTTP requests
+	EnableUpstreamClusterHeader bool `yaml:"enable_upstream_cluster_header"`
+
+	// DNSLookupFamily is the IP address family DNS clusters resolve DNS names to
+	DNSLookupFamily string `yaml:"dns_lookup_family"`
+
+	// InboundTLSHandshakeTimeout is the timeout of the TLS handshakes of the inbound connections of the sidecars
+	InboundTLSHandshakeTimeout string `yaml:"inbound_tls_handshake_timeout"`
+
+	// EnvoyConcurrency is the default number of worker threads of the Envoy sidecars
+	EnvoyConcurrency int `yaml:"envoy_concurrency"`
+
+	// EnvoyRuntimeFlags is the list of flags of the static runtime layer of the Envoy sidecars
+	EnvoyRuntimeFlags string `yaml:"envoy_runtime_flags"`
+
+	// EnableFailStatic is a bool toggle used to have the proxies connecting before the mesh config is ready reject requests with a 503
+	EnableFailStatic bool `yaml:"enable_fail_static"`
 }
 
 func (c *Client) run(stop <-chan struct{}) {
@@ -271,6 +599,55 @@ func parseOSMConfigMap(configMap *v1.ConfigMap) *osmConfig {
 	osmConfigMap.EnvoyLogLevel, _ = GetStringValueForKey(configMap, envoyLogLevel)
 	osmConfigMap.ServiceCertValidityDuration, _ = GetStringValueForKey(configMap, serviceCertValidityDurationKey)
 	osmConfigMap.OutboundIPRangeExclusionList, _ = GetStringValueForKey(configMap, outboundIPRangeExclusionListKey)
+	osmConfigMap.EnvoyStartupProbeFailureThreshold, _ = GetIntValueForKey(configMap, envoyStartupProbeFailureThresholdKey)
+	osmConfigMap.EnvoyStartupProbePeriodSeconds, _ = GetIntValueForKey(configMap, envoyStartupProbePeriodSecondsKey)
+	osmConfigMap.EnableInboundHTTP3, _ = GetBoolValueForKey(configMap, enableInboundHTTP3Key)
+	osmConfigMap.TracingCustomTags, _ = GetStringValueForKey(configMap, tracingCustomTagsKey)
+	osmConfigMap.EnableEnvoyReadinessGate, _ = GetBoolValueForKey(configMap, enableEnvoyReadinessGateKey)
+	osmConfigMap.EnableDeltaXDS, _ = GetBoolValueForKey(configMap, enableDeltaXDSKey)
+	osmConfigMap.EnvoyStatsTags, _ = GetStringValueForKey(configMap, envoyStatsTagsKey)
+	osmConfigMap.ExcludeNotReadyEndpoints, _ = GetBoolValueForKey(configMap, excludeNotReadyEndpointsKey)
+	osmConfigMap.EnvoySidecarEnvVars, _ = GetStringValueForKey(configMap, envoySidecarEnvVarsKey)
+	osmConfigMap.MaxRequestBytes, _ = GetIntValueForKey(configMap, maxRequestBytesKey)
+	osmConfigMap.EnableOutboundBlackhole, _ = GetBoolValueForKey(configMap, enableOutboundBlackholeKey)
+	osmConfigMap.EnableHTTPMethodStats, _ = GetBoolValueForKey(configMap, enableHTTPMethodStatsKey)
+	osmConfigMap.TLSMinimumProtocolVersion, _ = GetStringValueForKey(configMap, tlsMinimumProtocolVersionKey)
+	osmConfigMap.TLSMaximumProtocolVersion, _ = GetStringValueForKey(configMap, tlsMaximumProtocolVersionKey)
+	osmConfigMap.TLSCipherSuites, _ = GetStringValueForKey(configMap, tlsCipherSuitesKey)
+	osmConfigMap.EnableInboundUnmatchedSNIPassthrough, _ = GetBoolValueForKey(configMap, enableInboundUnmatchedSNIPassthroughKey)
+	osmConfigMap.EnvoyStatsSinks, _ = GetStringValueForKey(configMap, envoyStatsSinksKey)
+	osmConfigMap.EnvoyStatsFlushInterval, _ = GetStringValueForKey(configMap, envoyStatsFlushIntervalKey)
+	osmConfigMap.ListenerDSCP, _ = GetIntValueForKey(configMap, listenerDSCPKey)
+	osmConfigMap.EnableListenerReusePort, _ = GetBoolValueForKey(configMap, enableListenerReusePortKey)
+	osmConfigMap.ProxyConfigPinTTL, _ = GetStringValueForKey(configMap, proxyConfigPinTTLKey)
+	osmConfigMap.TCPAccessLogFormat, _ = GetStringValueForKey(configMap, tcpAccessLogFormatKey)
+	osmConfigMap.XDSKeepaliveTime, _ = GetStringValueForKey(configMap, xdsKeepaliveTimeKey)
+	osmConfigMap.XDSKeepaliveTimeout, _ = GetStringValueForKey(configMap, xdsKeepaliveTimeoutKey)
+	osmConfigMap.XDSMaxConnectionAge, _ = GetStringValueForKey(configMap, xdsMaxConnectionAgeKey)
+	osmConfigMap.EnableOutboundOriginalDst, _ = GetBoolValueForKey(configMap, enableOutboundOriginalDstKey)
+	osmConfigMap.EnableSourceIPRangeMTLSBypass, _ = GetBoolValueForKey(configMap, enableSourceIPRangeMTLSBypassKey)
+	osmConfigMap.DefaultClusterType, _ = GetStringValueForKey(configMap, defaultClusterTypeKey)
+	osmConfigMap.UpstreamBindSourceAddress, _ = GetStringValueForKey(configMap, upstreamBindSourceAddressKey)
+	osmConfigMap.ProxyReconnectGracePeriod, _ = GetStringValueForKey(configMap, proxyReconnectGracePeriodKey)
+	osmConfigMap.DNSRefreshRate, _ = GetStringValueForKey(configMap, dnsRefreshRateKey)
+	osmConfigMap.RespectDNSTTL, _ = GetStringValueForKey(configMap, respectDNSTTLKey)
+	osmConfigMap.EnableListenerExactBalance, _ = GetBoolValueForKey(configMap, enableListenerExactBalanceKey)
+	osmConfigMap.EndpointMetadataNodeLabels, _ = GetStringValueForKey(configMap, endpointMetadataNodeLabelsKey)
+	osmConfigMap.DefaultEndpointZone, _ = GetStringValueForKey(configMap, defaultEndpointZoneKey)
+	osmConfigMap.TrafficSplitEmptyBackendMode, _ = GetStringValueForKey(configMap, trafficSplitEmptyBackendModeKey)
+	osmConfigMap.IngressStatPrefix, _ = GetStringValueForKey(configMap, ingressStatPrefixKey)
+	osmConfigMap.PermissiveModeSANAuthorization, _ = GetBoolValueForKey(configMap, permissiveModeSANAuthorizationKey)
+	osmConfigMap.OutboundUnknownHostMode, _ = GetStringValueForKey(configMap, outboundUnknownHostModeKey)
+	osmConfigMap.UpstreamIdleTimeout, _ = GetStringValueForKey(configMap, upstreamIdleTimeoutKey)
+	osmConfigMap.TracingPropagationFormat, _ = GetStringValueForKey(configMap, tracingPropagationFormatKey)
+	osmConfigMap.OutboundRequestTimeout, _ = GetStringValueForKey(configMap, outboundRequestTimeoutKey)
+	osmConfigMap.PerConnectionBufferLimitBytes, _ = GetIntValueForKey(configMap, perConnectionBufferLimitBytesKey)
+	osmConfigMap.EnableUpstreamClusterHeader, _ = GetBoolValueForKey(configMap, enableUpstreamClusterHeaderKey)
+	osmConfigMap.DNSLookupFamily, _ = GetStringValueForKey(configMap, dnsLookupFamilyKey)
+	osmConfigMap.InboundTLSHandshakeTimeout, _ = GetStringValueForKey(configMap, inboundTLSHandshakeTimeoutKey)
+	osmConfigMap.EnvoyConcurrency, _ = GetIntValueForKey(configMap, envoyConcurrencyKey)
+	osmConfigMap.EnvoyRuntimeFlags, _ = GetStringValueForKey(configMap, envoyRuntimeFlagsKey)
+	osmConfigMap.EnableFailStatic, _ = GetBoolValueForKey(configMap, enableFailStaticKey)
 
 	if osmConfigMap.TracingEnable {
 		osmConfigMap.TracingAddress, _ = GetStringValueForKey(configMap, tracingAddressKey)
@@ -51,18 +51,67 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 
 		It("Tag matches const key for all fields of OSM ConfigMap struct", func() {
 			fieldNameTag := map[string]string{
-				"PermissiveTrafficPolicyMode":  PermissiveTrafficPolicyModeKey,
-				"Egress":                       egressKey,
-				"EnableDebugServer":            enableDebugServer,
-				"PrometheusScraping":           prometheusScrapingKey,
-				"TracingEnable":                tracingEnableKey,
-				"TracingAddress":               tracingAddressKey,
-				"TracingPort":                  tracingPortKey,
-				"TracingEndpoint":              tracingEndpointKey,
-				"UseHTTPSIngress":              useHTTPSIngressKey,
-				"EnvoyLogLevel":                envoyLogLevel,
-				"ServiceCertValidityDuration":  serviceCertValidityDurationKey,
-				"OutboundIPRangeExclusionList": outboundIPRangeExclusionListKey,
+				"PermissiveTrafficPolicyMode":          PermissiveTrafficPolicyModeKey,
+				"Egress":                               egressKey,
+				"EnableDebugServer":                    enableDebugServer,
+				"PrometheusScraping":                   prometheusScrapingKey,
+				"TracingEnable":                        tracingEnableKey,
+				"TracingAddress":                       tracingAddressKey,
+				"TracingPort":                          tracingPortKey,
+				"TracingEndpoint":                      tracingEndpointKey,
+				"UseHTTPSIngress":                      useHTTPSIngressKey,
+				"EnvoyLogLevel":                        envoyLogLevel,
+				"ServiceCertValidityDuration":          serviceCertValidityDurationKey,
+				"OutboundIPRangeExclusionList":         outboundIPRangeExclusionListKey,
+				"EnvoyStartupProbeFailureThreshold":    envoyStartupProbeFailureThresholdKey,
+				"EnvoyStartupProbePeriodSeconds":       envoyStartupProbePeriodSecondsKey,
+				"EnableInboundHTTP3":                   enableInboundHTTP3Key,
+				"TracingCustomTags":                    tracingCustomTagsKey,
+				"EnableEnvoyReadinessGate":             enableEnvoyReadinessGateKey,
+				"EnableDeltaXDS":                       enableDeltaXDSKey,
+				"EnvoyStatsTags":                       envoyStatsTagsKey,
+				"ExcludeNotReadyEndpoints":             excludeNotReadyEndpointsKey,
+				"EnvoySidecarEnvVars":                  envoySidecarEnvVarsKey,
+				"MaxRequestBytes":                      maxRequestBytesKey,
+				"EnableOutboundBlackhole":              enableOutboundBlackholeKey,
+				"EnableHTTPMethodStats":                enableHTTPMethodStatsKey,
+				"TLSMinimumProtocolVersion":            tlsMinimumProtocolVersionKey,
+				"TLSMaximumProtocolVersion":            tlsMaximumProtocolVersionKey,
+				"TLSCipherSuites":                      tlsCipherSuitesKey,
+				"EnableInboundUnmatchedSNIPassthrough": enableInboundUnmatchedSNIPassthroughKey,
+				"EnvoyStatsSinks":                      envoyStatsSinksKey,
+				"EnvoyStatsFlushInterval":              envoyStatsFlushIntervalKey,
+				"ListenerDSCP":                         listenerDSCPKey,
+				"EnableListenerReusePort":              enableListenerReusePortKey,
+				"ProxyConfigPinTTL":                    proxyConfigPinTTLKey,
+				"TCPAccessLogFormat":                   tcpAccessLogFormatKey,
+				"XDSKeepaliveTime":                     xdsKeepaliveTimeKey,
+				"XDSKeepaliveTimeout":                  xdsKeepaliveTimeoutKey,
+				"XDSMaxConnectionAge":                  xdsMaxConnectionAgeKey,
+				"EnableOutboundOriginalDst":            enableOutboundOriginalDstKey,
+				"EnableSourceIPRangeMTLSBypass":        enableSourceIPRangeMTLSBypassKey,
+				"DefaultClusterType":                   defaultClusterTypeKey,
+				"UpstreamBindSourceAddress":            upstreamBindSourceAddressKey,
+				"ProxyReconnectGracePeriod":            proxyReconnectGracePeriodKey,
+				"DNSRefreshRate":                       dnsRefreshRateKey,
+				"RespectDNSTTL":                        respectDNSTTLKey,
+				"EnableListenerExactBalance":           enableListenerExactBalanceKey,
+				"EndpointMetadataNodeLabels":           endpointMetadataNodeLabelsKey,
+				"DefaultEndpointZone":                  defaultEndpointZoneKey,
+				"TrafficSplitEmptyBackendMode":         trafficSplitEmptyBackendModeKey,
+				"IngressStatPrefix":                    ingressStatPrefixKey,
+				"PermissiveModeSANAuthorization":       permissiveModeSANAuthorizationKey,
+				"OutboundUnknownHostMode":              outboundUnknownHostModeKey,
+				"UpstreamIdleTimeout":                  upstreamIdleTimeoutKey,
+				"TracingPropagationFormat":             tracingPropagationFormatKey,
+				"OutboundRequestTimeout":               outboundRequestTimeoutKey,
+				"PerConnectionBufferLimitBytes":        perConnectionBufferLimitBytesKey,
+				"EnableUpstreamClusterHeader":          enableUpstreamClusterHeaderKey,
+				"DNSLookupFamily":                      dnsLookupFamilyKey,
+				"InboundTLSHandshakeTimeout":           inboundTLSHandshakeTimeoutKey,
+				"EnvoyConcurrency":                     envoyConcurrencyKey,
+				"EnvoyRuntimeFlags":                    envoyRuntimeFlagsKey,
+				"EnableFailStatic":                     enableFailStaticKey,
 			}
 			t := reflect.TypeOf(osmConfig{})
 
@@ -3,15 +3,34 @@ package configurator
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 const (
 	// defaultServiceCertValidityDuration is the default validity duration for service certificates
 	defaultServiceCertValidityDuration = 24 * time.Hour
+
+	// defaultProxyConfigPinTTL is the default duration after which a proxy pinned to its config is unpinned
+	defaultProxyConfigPinTTL = time.Hour
+
+	// defaultXDSKeepaliveTime is the default interval after which the xDS server pings a connection without activity
+	defaultXDSKeepaliveTime = 60 * time.Second
+
+	// defaultXDSKeepaliveTimeout is the default duration the xDS server waits for a ping to be acknowledged
+	defaultXDSKeepaliveTimeout = 20 * time.Second
+
+	// defaultInboundTLSHandshakeTimeout is the default time after which the sidecars close the inbound connections that
+	// have not completed their TLS handshake
+	defaultInboundTLSHandshakeTimeout = 10 * time.Second
 )
 
 // The functions in this file implement the configurator.Configurator interface
@@ -130,3 +149,532 @@ func (c *Client) GetOutboundIPRangeExclusionList() []string {
 
 	return exclusionList
 }
+
+// GetEnvoyStartupProbeFailureThreshold returns the failure threshold for the Envoy sidecar's startup probe.
+// A value of 0 means the startup probe is not configured.
+func (c *Client) GetEnvoyStartupProbeFailureThreshold() int32 {
+	threshold := c.getConfigMap().EnvoyStartupProbeFailureThreshold
+	if threshold < 0 {
+		return 0
+	}
+	return int32(threshold)
+}
+
+// GetEnvoyStartupProbePeriodSeconds returns the period in seconds for the Envoy sidecar's startup probe.
+// A value of 0 means the Kubernetes default is used.
+func (c *Client) GetEnvoyStartupProbePeriodSeconds() int32 {
+	period := c.getConfigMap().EnvoyStartupProbePeriodSeconds
+	if period < 0 {
+		return 0
+	}
+	return int32(period)
+}
+
+// IsInboundHTTP3Enabled determines whether the experimental HTTP/3 (QUIC) inbound listener is enabled
+func (c *Client) IsInboundHTTP3Enabled() bool {
+	return c.getConfigMap().EnableInboundHTTP3
+}
+
+// GetTracingCustomTags returns a mapping of custom tracing span tag names to the request headers they are populated from.
+// Entries are comma separated and of the form 'tag=header'; an entry without '=' uses the header name as the tag name.
+func (c *Client) GetTracingCustomTags() map[string]string {
+	tagsStr := c.getConfigMap().TracingCustomTags
+	if tagsStr == "" {
+		return nil
+	}
+
+	customTags := make(map[string]string)
+	for _, entry := range strings.Split(tagsStr, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		tag, header := entry, entry
+		if i := strings.Index(entry, "="); i >= 0 {
+			tag, header = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+		if tag == "" || header == "" {
+			log.Error().Msgf("Ignoring invalid tracing custom tag entry %q", entry)
+			continue
+		}
+		customTags[tag] = header
+	}
+
+	return customTags
+}
+
+// IsEnvoyReadinessGateEnabled returns whether injected pods are gated on the Envoy sidecar ACKing its initial config
+func (c *Client) IsEnvoyReadinessGateEnabled() bool {
+	return c.getConfigMap().EnableEnvoyReadinessGate
+}
+
+// IsDeltaXDSEnabled returns whether injected Envoy sidecars use incremental (delta) xDS
+func (c *Client) IsDeltaXDSEnabled() bool {
+	return c.getConfigMap().EnableDeltaXDS
+}
+
+// GetEnvoyStatsTags returns a mapping of Envoy stats tag names to the regular expressions used to extract them from stat names.
+// Entries are newline separated and of the form 'tag=regex'.
+func (c *Client) GetEnvoyStatsTags() map[string]string {
+	tagsStr := c.getConfigMap().EnvoyStatsTags
+	if tagsStr == "" {
+		return nil
+	}
+
+	statsTags := make(map[string]string)
+	for _, entry := range strings.Split(tagsStr, "\n") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.Index(entry, "=")
+		if i <= 0 || i == len(entry)-1 {
+			log.Error().Msgf("Ignoring invalid Envoy stats tag entry %q", entry)
+			continue
+		}
+		statsTags[strings.TrimSpace(entry[:i])] = strings.TrimSpace(entry[i+1:])
+	}
+
+	return statsTags
+}
+
+// IsExcludeNotReadyEndpointsEnabled returns whether endpoints that are not ready are excluded from EDS responses,
+// instead of being sent with an unhealthy health status
+func (c *Client) IsExcludeNotReadyEndpointsEnabled() bool {
+	return c.getConfigMap().ExcludeNotReadyEndpoints
+}
+
+// GetEnvoySidecarEnvVars returns a mapping of the names of additional environment variables for the injected Envoy sidecar
+// to their values. Entries are newline separated and of the form 'NAME=value'. A value of the form 'fieldRef:<field path>'
+// is sourced from the given pod field using the downward API.
+func (c *Client) GetEnvoySidecarEnvVars() map[string]string {
+	envVarsStr := c.getConfigMap().EnvoySidecarEnvVars
+	if envVarsStr == "" {
+		return nil
+	}
+
+	envVars := make(map[string]string)
+	for _, entry := range strings.Split(envVarsStr, "\n") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.Index(entry, "=")
+		if i <= 0 {
+			log.Error().Msgf("Ignoring invalid Envoy sidecar environment variable entry %q", entry)
+			continue
+		}
+		name := strings.TrimSpace(entry[:i])
+		if _, exists := envVars[name]; exists {
+			log.Error().Msgf("Ignoring duplicate Envoy sidecar environment variable entry %q", entry)
+			continue
+		}
+		envVars[name] = strings.TrimSpace(entry[i+1:])
+	}
+
+	return envVars
+}
+
+// GetMaxRequestBytes returns the mesh wide default limit in bytes on the size of inbound HTTP requests.
+// A value of 0 means the request size is unlimited.
+func (c *Client) GetMaxRequestBytes() uint32 {
+	maxRequestBytes := c.getConfigMap().MaxRequestBytes
+	if maxRequestBytes < 0 {
+		return 0
+	}
+	return uint32(maxRequestBytes)
+}
+
+// IsOutboundBlackholeEnabled returns whether outbound traffic not matching any traffic policy is routed to a blackhole cluster
+// responding with a 503, instead of being rejected. Egress takes precedence, passing such traffic through when enabled.
+func (c *Client) IsOutboundBlackholeEnabled() bool {
+	return c.getConfigMap().EnableOutboundBlackhole
+}
+
+// IsHTTPMethodStatsEnabled returns whether per method and path request stats are enabled for inbound HTTP routes.
+// The stats are emitted for an Envoy virtual cluster per route, and are disabled by default to bound the number of stats.
+func (c *Client) IsHTTPMethodStatsEnabled() bool {
+	return c.getConfigMap().EnableHTTPMethodStats
+}
+
+// GetTLSMinimumProtocolVersion returns the minimum TLS protocol version for mTLS between proxies, such as TLSv1_2
+func (c *Client) GetTLSMinimumProtocolVersion() string {
+	version := c.getConfigMap().TLSMinimumProtocolVersion
+	if version != "" {
+		return version
+	}
+	return constants.DefaultTLSMinimumProtocolVersion
+}
+
+// GetTLSMaximumProtocolVersion returns the maximum TLS protocol version for mTLS between proxies, such as TLSv1_3
+func (c *Client) GetTLSMaximumProtocolVersion() string {
+	version := c.getConfigMap().TLSMaximumProtocolVersion
+	if version != "" {
+		return version
+	}
+	return constants.DefaultTLSMaximumProtocolVersion
+}
+
+// GetTLSCipherSuites returns the list of cipher suites for mTLS between proxies.
+// Envoy's default cipher suites are used when the list is empty.
+func (c *Client) GetTLSCipherSuites() []string {
+	cipherSuitesStr := c.getConfigMap().TLSCipherSuites
+	if cipherSuitesStr == "" {
+		return nil
+	}
+
+	cipherSuites := strings.Split(cipherSuitesStr, ",")
+	for i := range cipherSuites {
+		cipherSuites[i] = strings.TrimSpace(cipherSuites[i])
+	}
+
+	return cipherSuites
+}
+
+// IsInboundUnmatchedSNIPassthroughEnabled returns whether inbound connections not matching any filter chain, such as
+// connections with an unknown SNI, are passed through to the local application. Such connections are rejected by default.
+func (c *Client) IsInboundUnmatchedSNIPassthroughEnabled() bool {
+	return c.getConfigMap().EnableInboundUnmatchedSNIPassthrough
+}
+
+// GetEnvoyStatsSinks returns the additional sinks to which Envoy sidecars flush their stats, besides exposing them to Prometheus.
+// Invalid sinks are ignored.
+func (c *Client) GetEnvoyStatsSinks() []StatsSink {
+	sinksStr := c.getConfigMap().EnvoyStatsSinks
+	if sinksStr == "" {
+		return nil
+	}
+
+	var sinks []StatsSink
+	for _, entry := range strings.Split(sinksStr, ",") {
+		sink, err := parseStatsSink(strings.TrimSpace(entry))
+		if err != nil {
+			log.Error().Err(err).Msgf("Ignoring invalid Envoy stats sink %q", entry)
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return sinks
+}
+
+// parseStatsSink parses a stats sink of the form <type>://<IP address>:<port>
+func parseStatsSink(sinkStr string) (StatsSink, error) {
+	sinkURL, err := url.Parse(sinkStr)
+	if err != nil {
+		return StatsSink{}, errors.Wrapf(errInvalidStatsSink, "%s: %v", sinkStr, err)
+	}
+	if sinkURL.Scheme != StatsdSinkType && sinkURL.Scheme != DogStatsdSinkType {
+		return StatsSink{}, errors.Wrapf(errInvalidStatsSink, "%s: unsupported type %q", sinkStr, sinkURL.Scheme)
+	}
+	if net.ParseIP(sinkURL.Hostname()) == nil {
+		return StatsSink{}, errors.Wrapf(errInvalidStatsSink, "%s: address must be an IP address", sinkStr)
+	}
+	port, err := strconv.ParseUint(sinkURL.Port(), 10, 16)
+	if err != nil || port == 0 {
+		return StatsSink{}, errors.Wrapf(errInvalidStatsSink, "%s: invalid port", sinkStr)
+	}
+
+	return StatsSink{
+		Type:    sinkURL.Scheme,
+		Address: sinkURL.Hostname(),
+		Port:    uint32(port),
+	}, nil
+}
+
+// GetEnvoyStatsFlushInterval returns the interval at which Envoy sidecars flush stats to their sinks.
+// A value of 0 means Envoy's default flush interval is used.
+func (c *Client) GetEnvoyStatsFlushInterval() time.Duration {
+	intervalStr := c.getConfigMap().EnvoyStatsFlushInterval
+	if intervalStr == "" {
+		return 0
+	}
+
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil || interval < 0 {
+		log.Error().Err(err).Msgf("Error parsing Envoy stats flush interval %s, using Envoy's default", intervalStr)
+		return 0
+	}
+	return interval
+}
+
+// GetListenerDSCP returns the DSCP value marked on the packets sent on the sockets of the inbound and outbound listeners.
+// A value of 0 means the packets are not marked.
+func (c *Client) GetListenerDSCP() uint32 {
+	dscp := c.getConfigMap().ListenerDSCP
+	if dscp < 0 || dscp > maxDSCP {
+		return 0
+	}
+	return uint32(dscp)
+}
+
+// IsListenerReusePortEnabled returns whether the sockets of the inbound and outbound listeners set SO_REUSEPORT, letting
+// the kernel balance connections across Envoy's worker threads
+func (c *Client) IsListenerReusePortEnabled() bool {
+	return c.getConfigMap().EnableListenerReusePort
+}
+
+// GetProxyConfigPinTTL returns the duration after which a proxy pinned to its last acknowledged config is automatically
+// unpinned, resuming config updates to the proxy
+func (c *Client) GetProxyConfigPinTTL() time.Duration {
+	ttlStr := c.getConfigMap().ProxyConfigPinTTL
+	if ttlStr == "" {
+		return defaultProxyConfigPinTTL
+	}
+
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil || ttl <= 0 {
+		log.Error().Err(err).Msgf("Error parsing proxy config pin TTL %s, using the default of %s", ttlStr, defaultProxyConfigPinTTL)
+		return defaultProxyConfigPinTTL
+	}
+	return ttl
+}
+
+// GetTCPAccessLogFormat returns the Envoy format string used to log the connections proxied by TCP filter chains
+func (c *Client) GetTCPAccessLogFormat() string {
+	return c.getConfigMap().TCPAccessLogFormat
+}
+
+// GetXDSKeepaliveTime returns the interval after which the xDS server pings a connection without activity
+func (c *Client) GetXDSKeepaliveTime() time.Duration {
+	return getPositiveDuration(c.getConfigMap().XDSKeepaliveTime, xdsKeepaliveTimeKey, defaultXDSKeepaliveTime)
+}
+
+// GetXDSKeepaliveTimeout returns how long the xDS server waits for a ping to be acknowledged before closing the connection
+func (c *Client) GetXDSKeepaliveTimeout() time.Duration {
+	return getPositiveDuration(c.getConfigMap().XDSKeepaliveTimeout, xdsKeepaliveTimeoutKey, defaultXDSKeepaliveTimeout)
+}
+
+// GetXDSMaxConnectionAge returns the maximum duration of a connection to the xDS server, or 0 if connections are not
+// closed based on their age
+func (c *Client) GetXDSMaxConnectionAge() time.Duration {
+	return getPositiveDuration(c.getConfigMap().XDSMaxConnectionAge, xdsMaxConnectionAgeKey, 0)
+}
+
+// getPositiveDuration parses the given value of the given key as a positive duration, returning the given default
+// duration if the value is not set or invalid
+func getPositiveDuration(value string, key string, defaultDuration time.Duration) time.Duration {
+	if value == "" {
+		return defaultDuration
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Error().Err(err).Msgf("Error parsing %s %s as a positive duration, using the default of %s", key, value, defaultDuration)
+		return defaultDuration
+	}
+	return duration
+}
+
+// IsOutboundOriginalDstEnabled returns whether the outbound listener forwards all outbound traffic to its original destination
+// through an ORIGINAL_DST cluster, instead of routing it explicitly to the allowed upstream services
+func (c *Client) IsOutboundOriginalDstEnabled() bool {
+	return c.getConfigMap().EnableOutboundOriginalDst
+}
+
+// IsSourceIPRangeMTLSBypassEnabled returns whether inbound connections from the source IP ranges allowed by a service are
+// accepted without mTLS, for legacy clients that cannot present a certificate yet
+func (c *Client) IsSourceIPRangeMTLSBypassEnabled() bool {
+	return c.getConfigMap().EnableSourceIPRangeMTLSBypass
+}
+
+// GetDefaultClusterType returns the type of the upstream service clusters not overridden by their service, such as EDS
+func (c *Client) GetDefaultClusterType() string {
+	clusterType := c.getConfigMap().DefaultClusterType
+	if clusterType != "" {
+		return clusterType
+	}
+	return constants.DefaultClusterType
+}
+
+// GetUpstreamBindSourceAddress returns the source address Envoy binds the connections to upstream services to,
+// or an empty string to let Envoy pick the source address
+func (c *Client) GetUpstreamBindSourceAddress() string {
+	return c.getConfigMap().UpstreamBindSourceAddress
+}
+
+// GetProxyReconnectGracePeriod returns the period within which a proxy reconnecting to the xDS server is recognized and
+// keeps the config state of its previous connection, so that only the config that changed in the meantime is pushed to
+// it. A proxy is handled as a new proxy when it reconnects after the grace period, or when it is 0.
+func (c *Client) GetProxyReconnectGracePeriod() time.Duration {
+	return getPositiveDuration(c.getConfigMap().ProxyReconnectGracePeriod, proxyReconnectGracePeriodKey, 0)
+}
+
+// GetDNSRefreshRate returns the default rate at which the DNS names of STRICT_DNS and LOGICAL_DNS clusters are resolved.
+// It returns 0 when not set, in which case the Envoy default applies.
+func (c *Client) GetDNSRefreshRate() time.Duration {
+	return getPositiveDuration(c.getConfigMap().DNSRefreshRate, dnsRefreshRateKey, 0)
+}
+
+// IsDNSTTLRespected returns whether STRICT_DNS and LOGICAL_DNS clusters resolve DNS names again once the TTL of their DNS
+// records expires by default, instead of at the DNS refresh rate. DNS TTLs are respected when not set.
+func (c *Client) IsDNSTTLRespected() bool {
+	value := c.getConfigMap().RespectDNSTTL
+	if value == "" {
+		return true
+	}
+
+	respected, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing %s %s as a boolean, respecting DNS TTLs", respectDNSTTLKey, value)
+		return true
+	}
+	return respected
+}
+
+// IsListenerExactBalanceEnabled returns whether the inbound listener of the sidecars balances the connections it accepts
+// exactly across the sidecar's worker threads
+func (c *Client) IsListenerExactBalanceEnabled() bool {
+	return c.getConfigMap().EnableListenerExactBalance
+}
+
+// GetEndpointMetadataNodeLabels returns the keys of the labels of the nodes running the endpoints of services which are
+// added to the metadata of the endpoints in EDS responses, for subset load balancing
+func (c *Client) GetEndpointMetadataNodeLabels() []string {
+	labelsStr := c.getConfigMap().EndpointMetadataNodeLabels
+	if labelsStr == "" {
+		return nil
+	}
+
+	var nodeLabels []string
+	for _, label := range strings.Split(labelsStr, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			nodeLabels = append(nodeLabels, label)
+		}
+	}
+	return nodeLabels
+}
+
+// GetDefaultEndpointZone returns the locality zone of the endpoints in EDS responses whose node has no zone label.
+// It returns an empty string when not set.
+func (c *Client) GetDefaultEndpointZone() string {
+	return strings.TrimSpace(c.getConfigMap().DefaultEndpointZone)
+}
+
+// GetTrafficSplitEmptyBackendMode returns the handling of the backends of a TrafficSplit without endpoints, strict or redistribute
+func (c *Client) GetTrafficSplitEmptyBackendMode() string {
+	mode := c.getConfigMap().TrafficSplitEmptyBackendMode
+	if mode != "" {
+		return mode
+	}
+	return constants.DefaultTrafficSplitEmptyBackendMode
+}
+
+// GetIngressStatPrefix returns the stat prefix of the HTTP connection managers handling traffic from the ingress, or an empty
+// string to use the default prefix
+func (c *Client) GetIngressStatPrefix() string {
+	return c.getConfigMap().IngressStatPrefix
+}
+
+// IsPermissiveModeSANAuthorizationEnabled returns whether inbound mesh connections are authorized by the identity in the SAN
+// of the client certificate in permissive traffic policy mode
+func (c *Client) IsPermissiveModeSANAuthorizationEnabled() bool {
+	return c.getConfigMap().PermissiveModeSANAuthorization
+}
+
+// GetOutboundUnknownHostMode returns the handling of the outbound HTTP requests to hosts unknown to the mesh, deny or passthrough
+func (c *Client) GetOutboundUnknownHostMode() string {
+	mode := c.getConfigMap().OutboundUnknownHostMode
+	if mode != "" {
+		return mode
+	}
+	return constants.DefaultOutboundUnknownHostMode
+}
+
+// GetUpstreamIdleTimeout returns the default time after which the connections of the sidecars to upstream services are
+// closed when they have no active requests. It returns 0 when not set, in which case the Envoy default applies.
+func (c *Client) GetUpstreamIdleTimeout() time.Duration {
+	return getPositiveDuration(c.getConfigMap().UpstreamIdleTimeout, upstreamIdleTimeoutKey, 0)
+}
+
+// GetTracingPropagationFormat returns the format of the trace context propagated across the mesh, b3 or w3c
+func (c *Client) GetTracingPropagationFormat() string {
+	format := c.getConfigMap().TracingPropagationFormat
+	if format != "" {
+		return format
+	}
+	return constants.DefaultTracingPropagationFormat
+}
+
+// GetOutboundRequestTimeout returns the default time the sidecars wait for the response to an outbound HTTP request
+// before timing the request out. It returns 0 when not set, in which case the Envoy default applies.
+func (c *Client) GetOutboundRequestTimeout() time.Duration {
+	return getPositiveDuration(c.getConfigMap().OutboundRequestTimeout, outboundRequestTimeoutKey, 0)
+}
+
+// GetPerConnectionBufferLimitBytes returns the mesh wide default soft limit in bytes on the read and write buffers of
+// the connections of the inbound and outbound listeners of the sidecars. It returns 0 when not set, in which case the
+// Envoy default applies.
+func (c *Client) GetPerConnectionBufferLimitBytes() uint32 {
+	perConnectionBufferLimitBytes := c.getConfigMap().PerConnectionBufferLimitBytes
+	if perConnectionBufferLimitBytes < 0 || int64(perConnectionBufferLimitBytes) > math.MaxUint32 {
+		return 0
+	}
+	return uint32(perConnectionBufferLimitBytes)
+}
+
+// IsUpstreamClusterHeaderEnabled returns whether the responses to outbound HTTP requests carry a header identifying the
+// upstream cluster that served them, e.g. to debug the backend of a TrafficSplit serving a request. Disabled by default.
+func (c *Client) IsUpstreamClusterHeaderEnabled() bool {
+	return c.getConfigMap().EnableUpstreamClusterHeader
+}
+
+// GetDNSLookupFamily returns the IP address family STRICT_DNS and LOGICAL_DNS clusters resolve DNS names to, one of
+// V4_ONLY, V6_ONLY or AUTO
+func (c *Client) GetDNSLookupFamily() string {
+	if family := c.getConfigMap().DNSLookupFamily; family != "" {
+		return family
+	}
+	return constants.DefaultDNSLookupFamily
+}
+
+// GetInboundTLSHandshakeTimeout returns the time after which the sidecars close the inbound connections that have not
+// completed their TLS handshake, so that stalled handshakes do not hold on to the resources of the inbound listener
+func (c *Client) GetInboundTLSHandshakeTimeout() time.Duration {
+	return getPositiveDuration(c.getConfigMap().InboundTLSHandshakeTimeout, inboundTLSHandshakeTimeoutKey, defaultInboundTLSHandshakeTimeout)
+}
+
+// GetEnvoyConcurrency returns the default number of worker threads of the Envoy sidecars.
+// A value of 0 means Envoy's default of one worker thread per hardware thread is used.
+func (c *Client) GetEnvoyConcurrency() int {
+	concurrency := c.getConfigMap().EnvoyConcurrency
+	if concurrency < 0 {
+		return 0
+	}
+	return concurrency
+}
+
+// GetEnvoyRuntimeFlags returns a mapping of Envoy runtime keys, e.g. envoy.reloadable_features.* feature flags, to the
+// values set in the static runtime layer of the Envoy sidecars. Entries are newline separated and of the form 'key=value'.
+func (c *Client) GetEnvoyRuntimeFlags() map[string]string {
+	flagsStr := c.getConfigMap().EnvoyRuntimeFlags
+	if flagsStr == "" {
+		return nil
+	}
+
+	runtimeFlags := make(map[string]string)
+	for _, entry := range strings.Split(flagsStr, "\n") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.Index(entry, "=")
+		if i <= 0 || i == len(entry)-1 {
+			log.Error().Msgf("Ignoring invalid Envoy runtime flag entry %q", entry)
+			continue
+		}
+		runtimeFlags[strings.TrimSpace(entry[:i])] = strings.TrimSpace(entry[i+1:])
+	}
+
+	return runtimeFlags
+}
+
+// IsFailStaticEnabled returns whether the proxies connecting before the mesh config is ready are programmed with
+// listeners rejecting requests with a 503, instead of being programmed with the incomplete mesh config
+func (c *Client) IsFailStaticEnabled() bool {
+	return c.getConfigMap().EnableFailStatic
+}
@@ -12,6 +12,7 @@ import (
 	testclient "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 )
 
@@ -568,4 +569,264 @@ var _ = Describe("Test Envoy configuration creation", func() {
 			Expect(actual).Should(ConsistOf(expected))
 		})
 	})
+	Context("test tracing_custom_tags", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		var confChannel chan interface{}
+
+		BeforeEach(func() {
+			confChannel = events.GetPubSubInstance().Subscribe(
+				announcements.ConfigMapAdded,
+				announcements.ConfigMapDeleted,
+				announcements.ConfigMapUpdated)
+		})
+
+		AfterEach(func() {
+			events.GetPubSubInstance().Unsub(confChannel)
+		})
+
+		It("correctly parses the custom tags", func() {
+			configMapData := map[string]string{
+				tracingCustomTagsKey: "tenant=x-tenant-id, x-request-id, =x-invalid",
+			}
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: configMapData,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-confChannel
+
+			Expect(cfg.GetTracingCustomTags()).To(Equal(map[string]string{
+				"tenant":       "x-tenant-id",
+				"x-request-id": "x-request-id",
+			}))
+		})
+	})
+	Context("test envoy_sidecar_env_vars", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		var confChannel chan interface{}
+
+		BeforeEach(func() {
+			confChannel = events.GetPubSubInstance().Subscribe(
+				announcements.ConfigMapAdded,
+				announcements.ConfigMapDeleted,
+				announcements.ConfigMapUpdated)
+		})
+
+		AfterEach(func() {
+			events.GetPubSubInstance().Unsub(confChannel)
+		})
+
+		It("correctly parses the environment variables", func() {
+			configMapData := map[string]string{
+				envoySidecarEnvVarsKey: "POD_NAME=fieldRef:metadata.name\nREGION = us-east-1\nEMPTY=\nREGION=us-west-2\n=invalid",
+			}
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: configMapData,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-confChannel
+
+			Expect(cfg.GetEnvoySidecarEnvVars()).To(Equal(map[string]string{
+				"POD_NAME": "fieldRef:metadata.name",
+				"REGION":   "us-east-1",
+				"EMPTY":    "",
+			}))
+		})
+	})
+	Context("test TLS parameters", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		var confChannel chan interface{}
+
+		BeforeEach(func() {
+			confChannel = events.GetPubSubInstance().Subscribe(
+				announcements.ConfigMapAdded,
+				announcements.ConfigMapDeleted,
+				announcements.ConfigMapUpdated)
+		})
+
+		AfterEach(func() {
+			events.GetPubSubInstance().Unsub(confChannel)
+		})
+
+		It("returns the default TLS parameters when not specified", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-confChannel
+
+			Expect(cfg.GetTLSMinimumProtocolVersion()).To(Equal(constants.DefaultTLSMinimumProtocolVersion))
+			Expect(cfg.GetTLSMaximumProtocolVersion()).To(Equal(constants.DefaultTLSMaximumProtocolVersion))
+			Expect(cfg.GetTLSCipherSuites()).To(BeNil())
+		})
+
+		It("returns the configured TLS parameters", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					tlsMinimumProtocolVersionKey: "TLSv1_3",
+					tlsMaximumProtocolVersionKey: "TLSv1_3",
+					tlsCipherSuitesKey:           "ECDHE-ECDSA-AES256-GCM-SHA384, ECDHE-RSA-AES256-GCM-SHA384",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-confChannel
+
+			Expect(cfg.GetTLSMinimumProtocolVersion()).To(Equal("TLSv1_3"))
+			Expect(cfg.GetTLSMaximumProtocolVersion()).To(Equal("TLSv1_3"))
+			Expect(cfg.GetTLSCipherSuites()).To(Equal([]string{"ECDHE-ECDSA-AES256-GCM-SHA384", "ECDHE-RSA-AES256-GCM-SHA384"}))
+		})
+	})
+	Context("test Envoy stats sinks", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		var confChannel chan interface{}
+
+		BeforeEach(func() {
+			confChannel = events.GetPubSubInstance().Subscribe(
+				announcements.ConfigMapAdded,
+				announcements.ConfigMapDeleted,
+				announcements.ConfigMapUpdated)
+		})
+
+		AfterEach(func() {
+			events.GetPubSubInstance().Unsub(confChannel)
+		})
+
+		It("correctly parses the stats sinks and flush interval, ignoring invalid sinks", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					envoyStatsSinksKey:         "statsd://10.0.0.10:8125, dogstatsd://[fd00::1]:8126, statsd://statsd.monitoring:8125, graphite://10.0.0.12:2003",
+					envoyStatsFlushIntervalKey: "10s",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-confChannel
+
+			Expect(cfg.GetEnvoyStatsSinks()).To(Equal([]StatsSink{
+				{Type: StatsdSinkType, Address: "10.0.0.10", Port: 8125},
+				{Type: DogStatsdSinkType, Address: "fd00::1", Port: 8126},
+			}))
+			Expect(cfg.GetEnvoyStatsFlushInterval()).To(Equal(10 * time.Second))
+		})
+	})
+	Context("test xDS keepalive parameters", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		var confChannel chan interface{}
+
+		BeforeEach(func() {
+			confChannel = events.GetPubSubInstance().Subscribe(
+				announcements.ConfigMapAdded,
+				announcements.ConfigMapDeleted,
+				announcements.ConfigMapUpdated)
+		})
+
+		AfterEach(func() {
+			events.GetPubSubInstance().Unsub(confChannel)
+		})
+
+		It("returns the defaults when the parameters are not set", func() {
+			Expect(cfg.GetXDSKeepaliveTime()).To(Equal(defaultXDSKeepaliveTime))
+			Expect(cfg.GetXDSKeepaliveTimeout()).To(Equal(defaultXDSKeepaliveTimeout))
+			Expect(cfg.GetXDSMaxConnectionAge()).To(BeZero())
+		})
+
+		It("correctly parses the parameters, ignoring invalid durations", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					xdsKeepaliveTimeKey:    "30s",
+					xdsKeepaliveTimeoutKey: "-5s",
+					xdsMaxConnectionAgeKey: "1h",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-confChannel
+
+			Expect(cfg.GetXDSKeepaliveTime()).To(Equal(30 * time.Second))
+			Expect(cfg.GetXDSKeepaliveTimeout()).To(Equal(defaultXDSKeepaliveTimeout))
+			Expect(cfg.GetXDSMaxConnectionAge()).To(Equal(time.Hour))
+		})
+	})
+	Context("test inbound TLS handshake timeout", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		var confChannel chan interface{}
+
+		BeforeEach(func() {
+			confChannel = events.GetPubSubInstance().Subscribe(
+				announcements.ConfigMapAdded,
+				announcements.ConfigMapDeleted,
+				announcements.ConfigMapUpdated)
+		})
+
+		AfterEach(func() {
+			events.GetPubSubInstance().Unsub(confChannel)
+		})
+
+		It("returns the default when the timeout is not set", func() {
+			Expect(cfg.GetInboundTLSHandshakeTimeout()).To(Equal(defaultInboundTLSHandshakeTimeout))
+		})
+
+		It("correctly parses the timeout", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					inboundTLSHandshakeTimeoutKey: "5s",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-confChannel
+
+			Expect(cfg.GetInboundTLSHandshakeTimeout()).To(Equal(5 * time.Second))
+		})
+	})
 })
@@ -49,6 +49,90 @@ func (mr *MockConfiguratorMockRecorder) GetConfigMap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockConfigurator)(nil).GetConfigMap))
 }
 
+// GetDNSLookupFamily mocks base method
+func (m *MockConfigurator) GetDNSLookupFamily() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSLookupFamily")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDNSLookupFamily indicates an expected call of GetDNSLookupFamily
+func (mr *MockConfiguratorMockRecorder) GetDNSLookupFamily() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSLookupFamily", reflect.TypeOf((*MockConfigurator)(nil).GetDNSLookupFamily))
+}
+
+// GetDNSRefreshRate mocks base method
+func (m *MockConfigurator) GetDNSRefreshRate() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSRefreshRate")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDNSRefreshRate indicates an expected call of GetDNSRefreshRate
+func (mr *MockConfiguratorMockRecorder) GetDNSRefreshRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSRefreshRate", reflect.TypeOf((*MockConfigurator)(nil).GetDNSRefreshRate))
+}
+
+// GetDefaultClusterType mocks base method
+func (m *MockConfigurator) GetDefaultClusterType() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultClusterType")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDefaultClusterType indicates an expected call of GetDefaultClusterType
+func (mr *MockConfiguratorMockRecorder) GetDefaultClusterType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultClusterType", reflect.TypeOf((*MockConfigurator)(nil).GetDefaultClusterType))
+}
+
+// GetDefaultEndpointZone mocks base method
+func (m *MockConfigurator) GetDefaultEndpointZone() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultEndpointZone")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDefaultEndpointZone indicates an expected call of GetDefaultEndpointZone
+func (mr *MockConfiguratorMockRecorder) GetDefaultEndpointZone() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultEndpointZone", reflect.TypeOf((*MockConfigurator)(nil).GetDefaultEndpointZone))
+}
+
+// GetEndpointMetadataNodeLabels mocks base method
+func (m *MockConfigurator) GetEndpointMetadataNodeLabels() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpointMetadataNodeLabels")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetEndpointMetadataNodeLabels indicates an expected call of GetEndpointMetadataNodeLabels
+func (mr *MockConfiguratorMockRecorder) GetEndpointMetadataNodeLabels() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpointMetadataNodeLabels", reflect.TypeOf((*MockConfigurator)(nil).GetEndpointMetadataNodeLabels))
+}
+
+// GetEnvoyConcurrency mocks base method
+func (m *MockConfigurator) GetEnvoyConcurrency() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyConcurrency")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetEnvoyConcurrency indicates an expected call of GetEnvoyConcurrency
+func (mr *MockConfiguratorMockRecorder) GetEnvoyConcurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyConcurrency", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyConcurrency))
+}
+
 // GetEnvoyLogLevel mocks base method
 func (m *MockConfigurator) GetEnvoyLogLevel() string {
 	m.ctrl.T.Helper()
@@ -63,6 +147,160 @@ func (mr *MockConfiguratorMockRecorder) GetEnvoyLogLevel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyLogLevel", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyLogLevel))
 }
 
+// GetEnvoyRuntimeFlags mocks base method
+func (m *MockConfigurator) GetEnvoyRuntimeFlags() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyRuntimeFlags")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetEnvoyRuntimeFlags indicates an expected call of GetEnvoyRuntimeFlags
+func (mr *MockConfiguratorMockRecorder) GetEnvoyRuntimeFlags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyRuntimeFlags", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyRuntimeFlags))
+}
+
+// GetEnvoySidecarEnvVars mocks base method
+func (m *MockConfigurator) GetEnvoySidecarEnvVars() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoySidecarEnvVars")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetEnvoySidecarEnvVars indicates an expected call of GetEnvoySidecarEnvVars
+func (mr *MockConfiguratorMockRecorder) GetEnvoySidecarEnvVars() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoySidecarEnvVars", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoySidecarEnvVars))
+}
+
+// GetEnvoyStartupProbeFailureThreshold mocks base method
+func (m *MockConfigurator) GetEnvoyStartupProbeFailureThreshold() int32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyStartupProbeFailureThreshold")
+	ret0, _ := ret[0].(int32)
+	return ret0
+}
+
+// GetEnvoyStartupProbeFailureThreshold indicates an expected call of GetEnvoyStartupProbeFailureThreshold
+func (mr *MockConfiguratorMockRecorder) GetEnvoyStartupProbeFailureThreshold() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyStartupProbeFailureThreshold", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyStartupProbeFailureThreshold))
+}
+
+// GetEnvoyStartupProbePeriodSeconds mocks base method
+func (m *MockConfigurator) GetEnvoyStartupProbePeriodSeconds() int32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyStartupProbePeriodSeconds")
+	ret0, _ := ret[0].(int32)
+	return ret0
+}
+
+// GetEnvoyStartupProbePeriodSeconds indicates an expected call of GetEnvoyStartupProbePeriodSeconds
+func (mr *MockConfiguratorMockRecorder) GetEnvoyStartupProbePeriodSeconds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyStartupProbePeriodSeconds", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyStartupProbePeriodSeconds))
+}
+
+// GetEnvoyStatsFlushInterval mocks base method
+func (m *MockConfigurator) GetEnvoyStatsFlushInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyStatsFlushInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetEnvoyStatsFlushInterval indicates an expected call of GetEnvoyStatsFlushInterval
+func (mr *MockConfiguratorMockRecorder) GetEnvoyStatsFlushInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyStatsFlushInterval", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyStatsFlushInterval))
+}
+
+// GetEnvoyStatsSinks mocks base method
+func (m *MockConfigurator) GetEnvoyStatsSinks() []StatsSink {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyStatsSinks")
+	ret0, _ := ret[0].([]StatsSink)
+	return ret0
+}
+
+// GetEnvoyStatsSinks indicates an expected call of GetEnvoyStatsSinks
+func (mr *MockConfiguratorMockRecorder) GetEnvoyStatsSinks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyStatsSinks", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyStatsSinks))
+}
+
+// GetEnvoyStatsTags mocks base method
+func (m *MockConfigurator) GetEnvoyStatsTags() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyStatsTags")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetEnvoyStatsTags indicates an expected call of GetEnvoyStatsTags
+func (mr *MockConfiguratorMockRecorder) GetEnvoyStatsTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyStatsTags", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyStatsTags))
+}
+
+// GetInboundTLSHandshakeTimeout mocks base method
+func (m *MockConfigurator) GetInboundTLSHandshakeTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundTLSHandshakeTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetInboundTLSHandshakeTimeout indicates an expected call of GetInboundTLSHandshakeTimeout
+func (mr *MockConfiguratorMockRecorder) GetInboundTLSHandshakeTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundTLSHandshakeTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetInboundTLSHandshakeTimeout))
+}
+
+// GetIngressStatPrefix mocks base method
+func (m *MockConfigurator) GetIngressStatPrefix() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngressStatPrefix")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetIngressStatPrefix indicates an expected call of GetIngressStatPrefix
+func (mr *MockConfiguratorMockRecorder) GetIngressStatPrefix() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressStatPrefix", reflect.TypeOf((*MockConfigurator)(nil).GetIngressStatPrefix))
+}
+
+// GetListenerDSCP mocks base method
+func (m *MockConfigurator) GetListenerDSCP() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListenerDSCP")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetListenerDSCP indicates an expected call of GetListenerDSCP
+func (mr *MockConfiguratorMockRecorder) GetListenerDSCP() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListenerDSCP", reflect.TypeOf((*MockConfigurator)(nil).GetListenerDSCP))
+}
+
+// GetMaxRequestBytes mocks base method
+func (m *MockConfigurator) GetMaxRequestBytes() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxRequestBytes")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetMaxRequestBytes indicates an expected call of GetMaxRequestBytes
+func (mr *MockConfiguratorMockRecorder) GetMaxRequestBytes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxRequestBytes", reflect.TypeOf((*MockConfigurator)(nil).GetMaxRequestBytes))
+}
+
 // GetOSMNamespace mocks base method
 func (m *MockConfigurator) GetOSMNamespace() string {
 	m.ctrl.T.Helper()
@@ -91,6 +329,76 @@ func (mr *MockConfiguratorMockRecorder) GetOutboundIPRangeExclusionList() *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundIPRangeExclusionList", reflect.TypeOf((*MockConfigurator)(nil).GetOutboundIPRangeExclusionList))
 }
 
+// GetOutboundRequestTimeout mocks base method
+func (m *MockConfigurator) GetOutboundRequestTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboundRequestTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetOutboundRequestTimeout indicates an expected call of GetOutboundRequestTimeout
+func (mr *MockConfiguratorMockRecorder) GetOutboundRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundRequestTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetOutboundRequestTimeout))
+}
+
+// GetOutboundUnknownHostMode mocks base method
+func (m *MockConfigurator) GetOutboundUnknownHostMode() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboundUnknownHostMode")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetOutboundUnknownHostMode indicates an expected call of GetOutboundUnknownHostMode
+func (mr *MockConfiguratorMockRecorder) GetOutboundUnknownHostMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundUnknownHostMode", reflect.TypeOf((*MockConfigurator)(nil).GetOutboundUnknownHostMode))
+}
+
+// GetPerConnectionBufferLimitBytes mocks base method
+func (m *MockConfigurator) GetPerConnectionBufferLimitBytes() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerConnectionBufferLimitBytes")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetPerConnectionBufferLimitBytes indicates an expected call of GetPerConnectionBufferLimitBytes
+func (mr *MockConfiguratorMockRecorder) GetPerConnectionBufferLimitBytes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerConnectionBufferLimitBytes", reflect.TypeOf((*MockConfigurator)(nil).GetPerConnectionBufferLimitBytes))
+}
+
+// GetProxyConfigPinTTL mocks base method
+func (m *MockConfigurator) GetProxyConfigPinTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyConfigPinTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetProxyConfigPinTTL indicates an expected call of GetProxyConfigPinTTL
+func (mr *MockConfiguratorMockRecorder) GetProxyConfigPinTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyConfigPinTTL", reflect.TypeOf((*MockConfigurator)(nil).GetProxyConfigPinTTL))
+}
+
+// GetProxyReconnectGracePeriod mocks base method
+func (m *MockConfigurator) GetProxyReconnectGracePeriod() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyReconnectGracePeriod")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetProxyReconnectGracePeriod indicates an expected call of GetProxyReconnectGracePeriod
+func (mr *MockConfiguratorMockRecorder) GetProxyReconnectGracePeriod() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyReconnectGracePeriod", reflect.TypeOf((*MockConfigurator)(nil).GetProxyReconnectGracePeriod))
+}
+
 // GetServiceCertValidityPeriod mocks base method
 func (m *MockConfigurator) GetServiceCertValidityPeriod() time.Duration {
 	m.ctrl.T.Helper()
@@ -105,6 +413,76 @@ func (mr *MockConfiguratorMockRecorder) GetServiceCertValidityPeriod() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceCertValidityPeriod", reflect.TypeOf((*MockConfigurator)(nil).GetServiceCertValidityPeriod))
 }
 
+// GetTCPAccessLogFormat mocks base method
+func (m *MockConfigurator) GetTCPAccessLogFormat() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTCPAccessLogFormat")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTCPAccessLogFormat indicates an expected call of GetTCPAccessLogFormat
+func (mr *MockConfiguratorMockRecorder) GetTCPAccessLogFormat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTCPAccessLogFormat", reflect.TypeOf((*MockConfigurator)(nil).GetTCPAccessLogFormat))
+}
+
+// GetTLSCipherSuites mocks base method
+func (m *MockConfigurator) GetTLSCipherSuites() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSCipherSuites")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetTLSCipherSuites indicates an expected call of GetTLSCipherSuites
+func (mr *MockConfiguratorMockRecorder) GetTLSCipherSuites() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSCipherSuites", reflect.TypeOf((*MockConfigurator)(nil).GetTLSCipherSuites))
+}
+
+// GetTLSMaximumProtocolVersion mocks base method
+func (m *MockConfigurator) GetTLSMaximumProtocolVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSMaximumProtocolVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTLSMaximumProtocolVersion indicates an expected call of GetTLSMaximumProtocolVersion
+func (mr *MockConfiguratorMockRecorder) GetTLSMaximumProtocolVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSMaximumProtocolVersion", reflect.TypeOf((*MockConfigurator)(nil).GetTLSMaximumProtocolVersion))
+}
+
+// GetTLSMinimumProtocolVersion mocks base method
+func (m *MockConfigurator) GetTLSMinimumProtocolVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSMinimumProtocolVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTLSMinimumProtocolVersion indicates an expected call of GetTLSMinimumProtocolVersion
+func (mr *MockConfiguratorMockRecorder) GetTLSMinimumProtocolVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSMinimumProtocolVersion", reflect.TypeOf((*MockConfigurator)(nil).GetTLSMinimumProtocolVersion))
+}
+
+// GetTracingCustomTags mocks base method
+func (m *MockConfigurator) GetTracingCustomTags() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracingCustomTags")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetTracingCustomTags indicates an expected call of GetTracingCustomTags
+func (mr *MockConfiguratorMockRecorder) GetTracingCustomTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingCustomTags", reflect.TypeOf((*MockConfigurator)(nil).GetTracingCustomTags))
+}
+
 // GetTracingEndpoint mocks base method
 func (m *MockConfigurator) GetTracingEndpoint() string {
 	m.ctrl.T.Helper()
@@ -147,6 +525,118 @@ func (mr *MockConfiguratorMockRecorder) GetTracingPort() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingPort", reflect.TypeOf((*MockConfigurator)(nil).GetTracingPort))
 }
 
+// GetTracingPropagationFormat mocks base method
+func (m *MockConfigurator) GetTracingPropagationFormat() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracingPropagationFormat")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTracingPropagationFormat indicates an expected call of GetTracingPropagationFormat
+func (mr *MockConfiguratorMockRecorder) GetTracingPropagationFormat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingPropagationFormat", reflect.TypeOf((*MockConfigurator)(nil).GetTracingPropagationFormat))
+}
+
+// GetTrafficSplitEmptyBackendMode mocks base method
+func (m *MockConfigurator) GetTrafficSplitEmptyBackendMode() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficSplitEmptyBackendMode")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTrafficSplitEmptyBackendMode indicates an expected call of GetTrafficSplitEmptyBackendMode
+func (mr *MockConfiguratorMockRecorder) GetTrafficSplitEmptyBackendMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficSplitEmptyBackendMode", reflect.TypeOf((*MockConfigurator)(nil).GetTrafficSplitEmptyBackendMode))
+}
+
+// GetUpstreamBindSourceAddress mocks base method
+func (m *MockConfigurator) GetUpstreamBindSourceAddress() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamBindSourceAddress")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetUpstreamBindSourceAddress indicates an expected call of GetUpstreamBindSourceAddress
+func (mr *MockConfiguratorMockRecorder) GetUpstreamBindSourceAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamBindSourceAddress", reflect.TypeOf((*MockConfigurator)(nil).GetUpstreamBindSourceAddress))
+}
+
+// GetUpstreamIdleTimeout mocks base method
+func (m *MockConfigurator) GetUpstreamIdleTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamIdleTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetUpstreamIdleTimeout indicates an expected call of GetUpstreamIdleTimeout
+func (mr *MockConfiguratorMockRecorder) GetUpstreamIdleTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamIdleTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetUpstreamIdleTimeout))
+}
+
+// GetXDSKeepaliveTime mocks base method
+func (m *MockConfigurator) GetXDSKeepaliveTime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSKeepaliveTime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetXDSKeepaliveTime indicates an expected call of GetXDSKeepaliveTime
+func (mr *MockConfiguratorMockRecorder) GetXDSKeepaliveTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSKeepaliveTime", reflect.TypeOf((*MockConfigurator)(nil).GetXDSKeepaliveTime))
+}
+
+// GetXDSKeepaliveTimeout mocks base method
+func (m *MockConfigurator) GetXDSKeepaliveTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSKeepaliveTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetXDSKeepaliveTimeout indicates an expected call of GetXDSKeepaliveTimeout
+func (mr *MockConfiguratorMockRecorder) GetXDSKeepaliveTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSKeepaliveTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetXDSKeepaliveTimeout))
+}
+
+// GetXDSMaxConnectionAge mocks base method
+func (m *MockConfigurator) GetXDSMaxConnectionAge() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSMaxConnectionAge")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetXDSMaxConnectionAge indicates an expected call of GetXDSMaxConnectionAge
+func (mr *MockConfiguratorMockRecorder) GetXDSMaxConnectionAge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSMaxConnectionAge", reflect.TypeOf((*MockConfigurator)(nil).GetXDSMaxConnectionAge))
+}
+
+// IsDNSTTLRespected mocks base method
+func (m *MockConfigurator) IsDNSTTLRespected() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDNSTTLRespected")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDNSTTLRespected indicates an expected call of IsDNSTTLRespected
+func (mr *MockConfiguratorMockRecorder) IsDNSTTLRespected() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDNSTTLRespected", reflect.TypeOf((*MockConfigurator)(nil).IsDNSTTLRespected))
+}
+
 // IsDebugServerEnabled mocks base method
 func (m *MockConfigurator) IsDebugServerEnabled() bool {
 	m.ctrl.T.Helper()
@@ -161,6 +651,20 @@ func (mr *MockConfiguratorMockRecorder) IsDebugServerEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDebugServerEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsDebugServerEnabled))
 }
 
+// IsDeltaXDSEnabled mocks base method
+func (m *MockConfigurator) IsDeltaXDSEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDeltaXDSEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDeltaXDSEnabled indicates an expected call of IsDeltaXDSEnabled
+func (mr *MockConfiguratorMockRecorder) IsDeltaXDSEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDeltaXDSEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsDeltaXDSEnabled))
+}
+
 // IsEgressEnabled mocks base method
 func (m *MockConfigurator) IsEgressEnabled() bool {
 	m.ctrl.T.Helper()
@@ -175,6 +679,160 @@ func (mr *MockConfiguratorMockRecorder) IsEgressEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEgressEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsEgressEnabled))
 }
 
+// IsEnvoyReadinessGateEnabled mocks base method
+func (m *MockConfigurator) IsEnvoyReadinessGateEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnvoyReadinessGateEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsEnvoyReadinessGateEnabled indicates an expected call of IsEnvoyReadinessGateEnabled
+func (mr *MockConfiguratorMockRecorder) IsEnvoyReadinessGateEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnvoyReadinessGateEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsEnvoyReadinessGateEnabled))
+}
+
+// IsExcludeNotReadyEndpointsEnabled mocks base method
+func (m *MockConfigurator) IsExcludeNotReadyEndpointsEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExcludeNotReadyEndpointsEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsExcludeNotReadyEndpointsEnabled indicates an expected call of IsExcludeNotReadyEndpointsEnabled
+func (mr *MockConfiguratorMockRecorder) IsExcludeNotReadyEndpointsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExcludeNotReadyEndpointsEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsExcludeNotReadyEndpointsEnabled))
+}
+
+// IsFailStaticEnabled mocks base method
+func (m *MockConfigurator) IsFailStaticEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFailStaticEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsFailStaticEnabled indicates an expected call of IsFailStaticEnabled
+func (mr *MockConfiguratorMockRecorder) IsFailStaticEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFailStaticEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsFailStaticEnabled))
+}
+
+// IsHTTPMethodStatsEnabled mocks base method
+func (m *MockConfigurator) IsHTTPMethodStatsEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHTTPMethodStatsEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsHTTPMethodStatsEnabled indicates an expected call of IsHTTPMethodStatsEnabled
+func (mr *MockConfiguratorMockRecorder) IsHTTPMethodStatsEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHTTPMethodStatsEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsHTTPMethodStatsEnabled))
+}
+
+// IsInboundHTTP3Enabled mocks base method
+func (m *MockConfigurator) IsInboundHTTP3Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInboundHTTP3Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsInboundHTTP3Enabled indicates an expected call of IsInboundHTTP3Enabled
+func (mr *MockConfiguratorMockRecorder) IsInboundHTTP3Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInboundHTTP3Enabled", reflect.TypeOf((*MockConfigurator)(nil).IsInboundHTTP3Enabled))
+}
+
+// IsInboundUnmatchedSNIPassthroughEnabled mocks base method
+func (m *MockConfigurator) IsInboundUnmatchedSNIPassthroughEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInboundUnmatchedSNIPassthroughEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsInboundUnmatchedSNIPassthroughEnabled indicates an expected call of IsInboundUnmatchedSNIPassthroughEnabled
+func (mr *MockConfiguratorMockRecorder) IsInboundUnmatchedSNIPassthroughEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInboundUnmatchedSNIPassthroughEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsInboundUnmatchedSNIPassthroughEnabled))
+}
+
+// IsListenerExactBalanceEnabled mocks base method
+func (m *MockConfigurator) IsListenerExactBalanceEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsListenerExactBalanceEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsListenerExactBalanceEnabled indicates an expected call of IsListenerExactBalanceEnabled
+func (mr *MockConfiguratorMockRecorder) IsListenerExactBalanceEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsListenerExactBalanceEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsListenerExactBalanceEnabled))
+}
+
+// IsListenerReusePortEnabled mocks base method
+func (m *MockConfigurator) IsListenerReusePortEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsListenerReusePortEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsListenerReusePortEnabled indicates an expected call of IsListenerReusePortEnabled
+func (mr *MockConfiguratorMockRecorder) IsListenerReusePortEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsListenerReusePortEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsListenerReusePortEnabled))
+}
+
+// IsOutboundBlackholeEnabled mocks base method
+func (m *MockConfigurator) IsOutboundBlackholeEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsOutboundBlackholeEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsOutboundBlackholeEnabled indicates an expected call of IsOutboundBlackholeEnabled
+func (mr *MockConfiguratorMockRecorder) IsOutboundBlackholeEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOutboundBlackholeEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsOutboundBlackholeEnabled))
+}
+
+// IsOutboundOriginalDstEnabled mocks base method
+func (m *MockConfigurator) IsOutboundOriginalDstEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsOutboundOriginalDstEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsOutboundOriginalDstEnabled indicates an expected call of IsOutboundOriginalDstEnabled
+func (mr *MockConfiguratorMockRecorder) IsOutboundOriginalDstEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOutboundOriginalDstEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsOutboundOriginalDstEnabled))
+}
+
+// IsPermissiveModeSANAuthorizationEnabled mocks base method
+func (m *MockConfigurator) IsPermissiveModeSANAuthorizationEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPermissiveModeSANAuthorizationEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPermissiveModeSANAuthorizationEnabled indicates an expected call of IsPermissiveModeSANAuthorizationEnabled
+func (mr *MockConfiguratorMockRecorder) IsPermissiveModeSANAuthorizationEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPermissiveModeSANAuthorizationEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsPermissiveModeSANAuthorizationEnabled))
+}
+
 // IsPermissiveTrafficPolicyMode mocks base method
 func (m *MockConfigurator) IsPermissiveTrafficPolicyMode() bool {
 	m.ctrl.T.Helper()
@@ -203,6 +861,20 @@ func (mr *MockConfiguratorMockRecorder) IsPrometheusScrapingEnabled() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrometheusScrapingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsPrometheusScrapingEnabled))
 }
 
+// IsSourceIPRangeMTLSBypassEnabled mocks base method
+func (m *MockConfigurator) IsSourceIPRangeMTLSBypassEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSourceIPRangeMTLSBypassEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSourceIPRangeMTLSBypassEnabled indicates an expected call of IsSourceIPRangeMTLSBypassEnabled
+func (mr *MockConfiguratorMockRecorder) IsSourceIPRangeMTLSBypassEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSourceIPRangeMTLSBypassEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsSourceIPRangeMTLSBypassEnabled))
+}
+
 // IsTracingEnabled mocks base method
 func (m *MockConfigurator) IsTracingEnabled() bool {
 	m.ctrl.T.Helper()
@@ -217,6 +889,20 @@ func (mr *MockConfiguratorMockRecorder) IsTracingEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTracingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsTracingEnabled))
 }
 
+// IsUpstreamClusterHeaderEnabled mocks base method
+func (m *MockConfigurator) IsUpstreamClusterHeaderEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUpstreamClusterHeaderEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsUpstreamClusterHeaderEnabled indicates an expected call of IsUpstreamClusterHeaderEnabled
+func (mr *MockConfiguratorMockRecorder) IsUpstreamClusterHeaderEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUpstreamClusterHeaderEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsUpstreamClusterHeaderEnabled))
+}
+
 // UseHTTPSIngress mocks base method
 func (m *MockConfigurator) UseHTTPSIngress() bool {
 	m.ctrl.T.Helper()
@@ -21,6 +21,26 @@ type Client struct {
 	cacheSynced      chan interface{}
 }
 
+const (
+	// StatsdSinkType is the type of a statsd stats sink
+	StatsdSinkType = "statsd"
+
+	// DogStatsdSinkType is the type of a DogStatsD stats sink
+	DogStatsdSinkType = "dogstatsd"
+)
+
+// StatsSink is a sink to which Envoy sidecars flush their stats
+type StatsSink struct {
+	// Type is the type of the stats sink, such as statsd
+	Type string
+
+	// Address is the IP address of the stats sink
+	Address string
+
+	// Port is the UDP port of the stats sink
+	Port uint32
+}
+
 // Configurator is the controller interface for K8s namespaces
 type Configurator interface {
 	// GetOSMNamespace returns the namespace in which OSM controller pod resides
@@ -64,4 +84,153 @@ type Configurator interface {
 
 	// GetOutboundIPRangeExclusionList returns the list of IP ranges of the form x.x.x.x/y to exclude from outbound sidecar interception
 	GetOutboundIPRangeExclusionList() []string
+
+	// GetEnvoyStartupProbeFailureThreshold returns the failure threshold for the Envoy sidecar's startup probe, 0 if not configured
+	GetEnvoyStartupProbeFailureThreshold() int32
+
+	// GetEnvoyStartupProbePeriodSeconds returns the period in seconds for the Envoy sidecar's startup probe, 0 if not configured
+	GetEnvoyStartupProbePeriodSeconds() int32
+
+	// IsInboundHTTP3Enabled determines whether the experimental HTTP/3 (QUIC) inbound listener is enabled
+	IsInboundHTTP3Enabled() bool
+
+	// GetTracingCustomTags returns a mapping of custom tracing span tag names to the request headers they are populated from
+	GetTracingCustomTags() map[string]string
+
+	// IsEnvoyReadinessGateEnabled returns whether injected pods are gated on the Envoy sidecar ACKing its initial config
+	IsEnvoyReadinessGateEnabled() bool
+
+	// IsDeltaXDSEnabled returns whether injected Envoy sidecars use incremental (delta) xDS
+	IsDeltaXDSEnabled() bool
+
+	// GetEnvoyStatsTags returns a mapping of Envoy stats tag names to the regular expressions used to extract them from stat names
+	GetEnvoyStatsTags() map[string]string
+
+	// IsExcludeNotReadyEndpointsEnabled returns whether endpoints that are not ready are excluded from EDS responses
+	IsExcludeNotReadyEndpointsEnabled() bool
+
+	// GetEnvoySidecarEnvVars returns a mapping of the names of additional environment variables for the injected Envoy sidecar to their values
+	GetEnvoySidecarEnvVars() map[string]string
+
+	// GetMaxRequestBytes returns the mesh wide default limit in bytes on the size of inbound HTTP requests, 0 if unlimited
+	GetMaxRequestBytes() uint32
+
+	// IsOutboundBlackholeEnabled returns whether unmatched outbound traffic is routed to a blackhole cluster when egress is disabled
+	IsOutboundBlackholeEnabled() bool
+
+	// IsHTTPMethodStatsEnabled returns whether per method and path request stats are enabled for inbound HTTP routes
+	IsHTTPMethodStatsEnabled() bool
+
+	// GetTLSMinimumProtocolVersion returns the minimum TLS protocol version for mTLS between proxies
+	GetTLSMinimumProtocolVersion() string
+
+	// GetTLSMaximumProtocolVersion returns the maximum TLS protocol version for mTLS between proxies
+	GetTLSMaximumProtocolVersion() string
+
+	// GetTLSCipherSuites returns the list of cipher suites for mTLS between proxies
+	GetTLSCipherSuites() []string
+
+	// IsInboundUnmatchedSNIPassthroughEnabled returns whether inbound connections not matching any filter chain are passed through to the local application
+	IsInboundUnmatchedSNIPassthroughEnabled() bool
+
+	// GetEnvoyStatsSinks returns the additional sinks to which Envoy sidecars flush their stats
+	GetEnvoyStatsSinks() []StatsSink
+
+	// GetEnvoyStatsFlushInterval returns the interval at which Envoy sidecars flush stats to their sinks
+	GetEnvoyStatsFlushInterval() time.Duration
+
+	// GetListenerDSCP returns the DSCP value marked on the packets sent by the inbound and outbound listeners, 0 if not marked
+	GetListenerDSCP() uint32
+
+	// IsListenerReusePortEnabled returns whether the sockets of the inbound and outbound listeners set SO_REUSEPORT
+	IsListenerReusePortEnabled() bool
+
+	// GetProxyConfigPinTTL returns the duration after which a proxy pinned to its last acknowledged config is unpinned
+	GetProxyConfigPinTTL() time.Duration
+
+	// GetTCPAccessLogFormat returns the Envoy format string used to log the connections proxied by TCP filter chains,
+	// or an empty string to use the default JSON format
+	GetTCPAccessLogFormat() string
+
+	// GetXDSKeepaliveTime returns the interval after which the xDS server pings a connection without activity
+	GetXDSKeepaliveTime() time.Duration
+
+	// GetXDSKeepaliveTimeout returns how long the xDS server waits for a ping to be acknowledged before closing the connection
+	GetXDSKeepaliveTimeout() time.Duration
+
+	// GetXDSMaxConnectionAge returns the maximum duration of a connection to the xDS server, after which the connection is
+	// closed and the proxy reconnects. Connections are not closed based on their age when 0 is returned.
+	GetXDSMaxConnectionAge() time.Duration
+
+	// IsOutboundOriginalDstEnabled returns whether the outbound listener forwards all outbound traffic to its original destination
+	IsOutboundOriginalDstEnabled() bool
+
+	// IsSourceIPRangeMTLSBypassEnabled returns whether connections from the allowed source IP ranges of a service bypass the mTLS requirement
+	IsSourceIPRangeMTLSBypassEnabled() bool
+
+	// GetDefaultClusterType returns the default type of the upstream service clusters, such as EDS
+	GetDefaultClusterType() string
+
+	// GetUpstreamBindSourceAddress returns the source address the connections to upstream services are bound to, or an empty string if unset
+	GetUpstreamBindSourceAddress() string
+
+	// GetProxyReconnectGracePeriod returns the period within which a proxy reconnecting to the xDS server keeps its config state, 0 meaning disabled
+	GetProxyReconnectGracePeriod() time.Duration
+
+	// GetDNSRefreshRate returns the default rate at which the DNS names of DNS clusters are resolved, 0 meaning the Envoy default
+	GetDNSRefreshRate() time.Duration
+
+	// IsDNSTTLRespected returns whether DNS clusters resolve DNS names again once their DNS records expire by default
+	IsDNSTTLRespected() bool
+
+	// IsListenerExactBalanceEnabled returns whether the inbound listener balances its connections exactly across the sidecar's worker threads
+	IsListenerExactBalanceEnabled() bool
+
+	// GetEndpointMetadataNodeLabels returns the labels of the nodes of endpoints added to the metadata of EDS endpoints
+	GetEndpointMetadataNodeLabels() []string
+
+	// GetDefaultEndpointZone returns the locality zone of the EDS endpoints whose node has no zone
+	GetDefaultEndpointZone() string
+
+	// GetTrafficSplitEmptyBackendMode returns the handling of the backends of a TrafficSplit without endpoints, strict or redistribute
+	GetTrafficSplitEmptyBackendMode() string
+
+	// GetIngressStatPrefix returns the stat prefix of the HTTP connection managers handling traffic from the ingress, or an empty string to use the default prefix
+	GetIngressStatPrefix() string
+
+	// IsPermissiveModeSANAuthorizationEnabled returns whether inbound mesh connections are authorized by the identity in the SAN of the client certificate in permissive traffic policy mode
+	IsPermissiveModeSANAuthorizationEnabled() bool
+
+	// GetOutboundUnknownHostMode returns the handling of the outbound HTTP requests to hosts unknown to the mesh, deny or passthrough
+	GetOutboundUnknownHostMode() string
+
+	// GetUpstreamIdleTimeout returns the default idle timeout of the connections to upstream services, 0 meaning the Envoy default
+	GetUpstreamIdleTimeout() time.Duration
+
+	// GetTracingPropagationFormat returns the format of the trace context propagated across the mesh, b3 or w3c
+	GetTracingPropagationFormat() string
+
+	// GetOutboundRequestTimeout returns the default timeout of the outbound HTTP requests of the sidecars, 0 meaning the Envoy default
+	GetOutboundRequestTimeout() time.Duration
+
+	// GetPerConnectionBufferLimitBytes returns the mesh wide default limit in bytes on the buffers of the connections of the listeners, 0 meaning the Envoy default
+	GetPerConnectionBufferLimitBytes() uint32
+
+	// IsUpstreamClusterHeaderEnabled returns whether the responses to outbound HTTP requests carry a header identifying the upstream cluster that served them
+	IsUpstreamClusterHeaderEnabled() bool
+
+	// GetDNSLookupFamily returns the IP address family DNS clusters resolve DNS names to, one of V4_ONLY, V6_ONLY or AUTO
+	GetDNSLookupFamily() string
+
+	// GetInboundTLSHandshakeTimeout returns the time after which the sidecars close the inbound connections that have not completed their TLS handshake
+	GetInboundTLSHandshakeTimeout() time.Duration
+
+	// GetEnvoyConcurrency returns the default number of worker threads of the Envoy sidecars, 0 if not configured
+	GetEnvoyConcurrency() int
+
+	// GetEnvoyRuntimeFlags returns a mapping of Envoy runtime keys to the values set in the static runtime layer of the Envoy sidecars
+	GetEnvoyRuntimeFlags() map[string]string
+
+	// IsFailStaticEnabled returns whether the proxies connecting before the mesh config is ready reject requests with a 503
+	IsFailStaticEnabled() bool
 }
@@ -30,11 +30,42 @@ var (
 	deserializer = codecs.UniversalDeserializer()
 
 	// boolFields are the fields in osm-config that take in a boolean
-	boolFields = []string{"egress", "enable_debug_server", "permissive_traffic_policy_mode", "prometheus_scraping", "tracing_enable", "use_https_ingress"}
+	boolFields = []string{"egress", "enable_debug_server", "permissive_traffic_policy_mode", "prometheus_scraping", "tracing_enable", "use_https_ingress", "enable_inbound_http3", "enable_envoy_readiness_gate", "enable_delta_xds", "exclude_not_ready_endpoints", "enable_outbound_blackhole", "enable_http_method_stats", "enable_inbound_unmatched_sni_passthrough", "enable_listener_reuse_port", "enable_outbound_original_dst", "enable_source_ip_range_mtls_bypass", "respect_dns_ttl", "enable_listener_exact_balance", "enable_permissive_mode_san_authorization", "enable_upstream_cluster_header", "enable_fail_static"}
+
+	// nonNegativeIntFields are the fields in osm-config that take in a non-negative integer
+	nonNegativeIntFields = []string{envoyStartupProbeFailureThresholdKey, envoyStartupProbePeriodSecondsKey, maxRequestBytesKey, perConnectionBufferLimitBytesKey, envoyConcurrencyKey}
 
 	// ValidEnvoyLogLevels is a list of envoy log levels
 	ValidEnvoyLogLevels = []string{"trace", "debug", "info", "warning", "warn", "error", "critical", "off"}
 
+	// ValidTLSProtocolVersions is a list of TLS protocol versions
+	ValidTLSProtocolVersions = []string{"TLSv1_0", "TLSv1_1", "TLSv1_2", "TLSv1_3"}
+
+	// ValidTLSCipherSuites is a list of the cipher suites supported by Envoy for TLS 1.2 and lower
+	ValidTLSCipherSuites = []string{
+		"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-ECDSA-AES256-GCM-SHA384", "ECDHE-RSA-AES256-GCM-SHA384",
+		"ECDHE-ECDSA-CHACHA20-POLY1305", "ECDHE-RSA-CHACHA20-POLY1305", "ECDHE-PSK-CHACHA20-POLY1305",
+		"ECDHE-ECDSA-AES128-SHA", "ECDHE-RSA-AES128-SHA", "ECDHE-PSK-AES128-CBC-SHA",
+		"ECDHE-ECDSA-AES256-SHA", "ECDHE-RSA-AES256-SHA", "ECDHE-PSK-AES256-CBC-SHA",
+		"AES128-GCM-SHA256", "AES256-GCM-SHA384", "AES128-SHA", "AES256-SHA",
+		"PSK-AES128-CBC-SHA", "PSK-AES256-CBC-SHA", "DES-CBC3-SHA",
+	}
+
+	// ValidClusterTypes is a list of the types of the upstream service clusters
+	ValidClusterTypes = []string{constants.ClusterTypeEDS, constants.ClusterTypeStrictDNS, constants.ClusterTypeLogicalDNS}
+
+	// ValidDNSLookupFamilies is a list of the IP address families DNS clusters resolve DNS names to
+	ValidDNSLookupFamilies = []string{constants.DNSLookupFamilyV4Only, constants.DNSLookupFamilyV6Only, constants.DNSLookupFamilyAuto}
+
+	// ValidTrafficSplitEmptyBackendModes is a list of the handlings of the backends of a TrafficSplit without endpoints
+	ValidTrafficSplitEmptyBackendModes = []string{constants.TrafficSplitEmptyBackendModeStrict, constants.TrafficSplitEmptyBackendModeRedistribute}
+
+	// ValidOutboundUnknownHostModes is a list of the handlings of the outbound HTTP requests to unknown hosts
+	ValidOutboundUnknownHostModes = []string{constants.OutboundUnknownHostModeDeny, constants.OutboundUnknownHostModePassthrough}
+
+	// ValidTracingPropagationFormats is a list of the formats of the trace context propagated across the mesh
+	ValidTracingPropagationFormats = []string{constants.TracingPropagationFormatB3, constants.TracingPropagationFormatW3C}
+
 	// defaultFields are the default fields in osm-config
 	defaultFields = []string{"egress", "enable_debug_server", "permissive_traffic_policy_mode", "prometheus_scraping", "use_https_ingress", "envoy_log_level", "service_cert_validity_duration", "tracing_enable"}
 )
@@ -64,8 +95,47 @@ const (
 	// mustBeInPortRange is the reason for denial for tracing_port field
 	mustBeInPortRange = ": must be between 0 and 65535"
 
+	// mustBeNonNegativeInt is the reason for denial for a non-negative integer field
+	mustBeNonNegativeInt = ": must be a non-negative integer"
+
+	// mustBeValidTLSProtocolVersion is the reason for denial for the TLS protocol version fields
+	mustBeValidTLSProtocolVersion = ": invalid TLS protocol version"
+
+	// mustBeValidTLSProtocolVersionRange is the reason for denial for a minimum TLS protocol version above the maximum version
+	mustBeValidTLSProtocolVersionRange = ": must not be greater than the maximum TLS protocol version"
+
+	// mustBeValidTLSCipherSuites is the reason for denial for the tls_cipher_suites field
+	mustBeValidTLSCipherSuites = ": must be a comma separated list of cipher suites supported by Envoy"
+
+	// mustBeValidClusterType is the reason for denial for the default_cluster_type field
+	mustBeValidClusterType = ": must be one of EDS, STRICT_DNS or LOGICAL_DNS"
+
+	// mustBeValidDNSLookupFamily is the reason for denial for the dns_lookup_family field
+	mustBeValidDNSLookupFamily = ": must be one of V4_ONLY, V6_ONLY or AUTO"
+
+	// mustBeValidTrafficSplitEmptyBackendMode is the reason for denial for the traffic_split_empty_backend_mode field
+	mustBeValidTrafficSplitEmptyBackendMode = ": must be one of strict or redistribute"
+
+	// mustBeValidOutboundUnknownHostMode is the reason for denial for the outbound_unknown_host_mode field
+	mustBeValidOutboundUnknownHostMode = ": must be one of deny or passthrough"
+
+	// mustBeValidTracingPropagationFormat is the reason for denial for the tracing_propagation_format field
+	mustBeValidTracingPropagationFormat = ": must be one of b3 or w3c"
+
+	// mustBeValidStatsSinks is the reason for denial for the envoy_stats_sinks field
+	mustBeValidStatsSinks = ": must be a list of stats sinks of the form <statsd|dogstatsd>://<IP address>:<port>"
+
+	// mustBePositiveDuration is the reason for denial for a field that takes in a positive duration
+	mustBePositiveDuration = ": must be a positive duration"
+
 	mustBeValidIPRange = ": must be a list of valid IP addresses of the form a.b.c.d/x"
 
+	// mustBeValidIPAddress is the reason for denial for the upstream_bind_source_address field
+	mustBeValidIPAddress = ": must be a valid IP address"
+
+	// mustBeValidDSCP is the reason for denial for the listener_dscp field
+	mustBeValidDSCP = ": must be an integer between 0 and 63"
+
 	// cannotChangeMetadata is the reason for denial for changes to configmap metadata
 	cannotChangeMetadata = ": cannot change metadata"
 
@@ -74,6 +144,9 @@ const (
 
 	maxPortNum = 65535
 
+	// maxDSCP is the largest DSCP value, which is a 6-bit field
+	maxDSCP = 63
+
 	validatorServiceName = "osm-config-validator"
 )
 
@@ -247,6 +320,9 @@ func (whc *webhookConfig) validateFields(configMap corev1.ConfigMap, resp *v1bet
 		if !checkBoolFields(field, value, boolFields) {
 			reasonForDenial(resp, mustBeBool, field)
 		}
+		if !checkNonNegativeIntFields(field, value, nonNegativeIntFields) {
+			reasonForDenial(resp, mustBeNonNegativeInt, field)
+		}
 		if field == "envoy_log_level" && !checkEnvoyLogLevels(field, value) {
 			reasonForDenial(resp, mustBeValidLogLvl, field)
 		}
@@ -268,6 +344,57 @@ func (whc *webhookConfig) validateFields(configMap corev1.ConfigMap, resp *v1bet
 		if field == outboundIPRangeExclusionListKey && !checkOutboundIPRangeExclusionList(value) {
 			reasonForDenial(resp, mustBeValidIPRange, field)
 		}
+		if field == listenerDSCPKey {
+			if dscp, err := strconv.Atoi(value); err != nil || dscp < 0 || dscp > maxDSCP {
+				reasonForDenial(resp, mustBeValidDSCP, field)
+			}
+		}
+		if field == envoyStatsSinksKey && !checkStatsSinks(value) {
+			reasonForDenial(resp, mustBeValidStatsSinks, field)
+		}
+		if field == envoyStatsFlushIntervalKey || field == proxyConfigPinTTLKey ||
+			field == xdsKeepaliveTimeKey || field == xdsKeepaliveTimeoutKey || field == xdsMaxConnectionAgeKey ||
+			field == proxyReconnectGracePeriodKey || field == dnsRefreshRateKey || field == upstreamIdleTimeoutKey ||
+			field == outboundRequestTimeoutKey || field == inboundTLSHandshakeTimeoutKey {
+			if interval, err := time.ParseDuration(value); err != nil || interval <= 0 {
+				reasonForDenial(resp, mustBePositiveDuration, field)
+			}
+		}
+		if (field == tlsMinimumProtocolVersionKey || field == tlsMaximumProtocolVersionKey) && !checkTLSProtocolVersion(value) {
+			reasonForDenial(resp, mustBeValidTLSProtocolVersion, field)
+		}
+		if field == tlsCipherSuitesKey && !checkTLSCipherSuites(value) {
+			reasonForDenial(resp, mustBeValidTLSCipherSuites, field)
+		}
+		if field == defaultClusterTypeKey && !IsValidClusterType(value) {
+			reasonForDenial(resp, mustBeValidClusterType, field)
+		}
+		if field == dnsLookupFamilyKey && !isValidDNSLookupFamily(value) {
+			reasonForDenial(resp, mustBeValidDNSLookupFamily, field)
+		}
+		if field == trafficSplitEmptyBackendModeKey && !isValidTrafficSplitEmptyBackendMode(value) {
+			reasonForDenial(resp, mustBeValidTrafficSplitEmptyBackendMode, field)
+		}
+		if field == outboundUnknownHostModeKey && !isValidOutboundUnknownHostMode(value) {
+			reasonForDenial(resp, mustBeValidOutboundUnknownHostMode, field)
+		}
+		if field == tracingPropagationFormatKey && !isValidTracingPropagationFormat(value) {
+			reasonForDenial(resp, mustBeValidTracingPropagationFormat, field)
+		}
+		if field == upstreamBindSourceAddressKey && value != "" && net.ParseIP(value) == nil {
+			reasonForDenial(resp, mustBeValidIPAddress, field)
+		}
+	}
+
+	minVersion, maxVersion := configMap.Data[tlsMinimumProtocolVersionKey], configMap.Data[tlsMaximumProtocolVersionKey]
+	if minVersion == "" {
+		minVersion = constants.DefaultTLSMinimumProtocolVersion
+	}
+	if maxVersion == "" {
+		maxVersion = constants.DefaultTLSMaximumProtocolVersion
+	}
+	if checkTLSProtocolVersion(minVersion) && checkTLSProtocolVersion(maxVersion) && !IsValidTLSProtocolVersionRange(minVersion, maxVersion) {
+		reasonForDenial(resp, mustBeValidTLSProtocolVersionRange, tlsMinimumProtocolVersionKey)
 	}
 
 	defConfigMap, _ := whc.kubeClient.CoreV1().ConfigMaps(whc.osmNamespace).Get(context.TODO(), constants.OSMConfigMap, metav1.GetOptions{})
@@ -296,6 +423,131 @@ func checkEnvoyLogLevels(configMapField, configMapValue string) bool {
 	return valid
 }
 
+// checkTLSProtocolVersion checks that the field value is a valid TLS protocol version
+func checkTLSProtocolVersion(configMapValue string) bool {
+	for _, version := range ValidTLSProtocolVersions {
+		if configMapValue == version {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidTLSProtocolVersionRange returns whether both TLS protocol versions are valid and the minimum version is not
+// greater than the maximum version
+func IsValidTLSProtocolVersionRange(minVersion, maxVersion string) bool {
+	minIndex, maxIndex := -1, -1
+	for i, version := range ValidTLSProtocolVersions {
+		if minVersion == version {
+			minIndex = i
+		}
+		if maxVersion == version {
+			maxIndex = i
+		}
+	}
+	return minIndex >= 0 && maxIndex >= 0 && minIndex <= maxIndex
+}
+
+// checkTLSCipherSuites checks that the field value is a comma separated list of valid cipher suites
+func checkTLSCipherSuites(configMapValue string) bool {
+	if configMapValue == "" {
+		return true
+	}
+	for _, cipherSuite := range strings.Split(configMapValue, ",") {
+		if !IsValidTLSCipherSuite(strings.TrimSpace(cipherSuite)) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidTLSCipherSuite returns whether the given value is a cipher suite supported by Envoy, or an equal-preference
+// group of such cipher suites, such as [ECDHE-ECDSA-AES128-GCM-SHA256|ECDHE-ECDSA-CHACHA20-POLY1305]
+func IsValidTLSCipherSuite(cipherSuite string) bool {
+	if strings.HasPrefix(cipherSuite, "[") && strings.HasSuffix(cipherSuite, "]") {
+		for _, groupCipherSuite := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(cipherSuite, "["), "]"), "|") {
+			if !isValidTLSCipherSuiteName(groupCipherSuite) {
+				return false
+			}
+		}
+		return true
+	}
+	return isValidTLSCipherSuiteName(cipherSuite)
+}
+
+// isValidTLSCipherSuiteName returns whether the given value is the name of a cipher suite supported by Envoy
+func isValidTLSCipherSuiteName(cipherSuite string) bool {
+	for _, validCipherSuite := range ValidTLSCipherSuites {
+		if cipherSuite == validCipherSuite {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidClusterType returns whether the given value is a valid type of the upstream service clusters
+func IsValidClusterType(clusterType string) bool {
+	for _, validClusterType := range ValidClusterTypes {
+		if clusterType == validClusterType {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidDNSLookupFamily returns whether the given value is a valid IP address family for DNS clusters
+func isValidDNSLookupFamily(family string) bool {
+	for _, validFamily := range ValidDNSLookupFamilies {
+		if family == validFamily {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidTrafficSplitEmptyBackendMode returns whether the given value is a valid handling of the backends of a
+// TrafficSplit without endpoints
+func isValidTrafficSplitEmptyBackendMode(mode string) bool {
+	for _, validMode := range ValidTrafficSplitEmptyBackendModes {
+		if mode == validMode {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidOutboundUnknownHostMode returns whether the given value is a valid handling of the outbound HTTP requests to
+// unknown hosts
+func isValidOutboundUnknownHostMode(mode string) bool {
+	for _, validMode := range ValidOutboundUnknownHostModes {
+		if mode == validMode {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidTracingPropagationFormat returns whether the given value is a valid format of the trace context propagated
+// across the mesh
+func isValidTracingPropagationFormat(format string) bool {
+	for _, validFormat := range ValidTracingPropagationFormats {
+		if format == validFormat {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStatsSinks checks that the field value is a list of valid stats sinks
+func checkStatsSinks(sinksStr string) bool {
+	for _, sink := range strings.Split(sinksStr, ",") {
+		if _, err := parseStatsSink(strings.TrimSpace(sink)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func checkOutboundIPRangeExclusionList(ipRangesStr string) bool {
 	exclusionList := strings.Split(ipRangesStr, ",")
 	for i := range exclusionList {
@@ -320,6 +572,19 @@ func checkBoolFields(configMapField, configMapValue string, fields []string) boo
 	return true
 }
 
+// checkNonNegativeIntFields checks that the value is a non-negative integer for fields that take in a non-negative integer
+func checkNonNegativeIntFields(configMapField, configMapValue string, fields []string) bool {
+	for _, f := range fields {
+		if configMapField == f {
+			intValue, err := strconv.Atoi(configMapValue)
+			if err != nil || intValue < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // reasonForDenial rejects and appends rejection reason(s) to v1beta1.AdmissionResponse
 func reasonForDenial(resp *v1beta1.AdmissionResponse, mustBe string, field string) {
 	resp.Allowed = false
@@ -339,6 +339,346 @@ func TestValidateFields(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "Accept configmap with valid TLS protocol versions",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_minimum_protocol_version": "TLSv1_3",
+					"tls_maximum_protocol_version": "TLSv1_3",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid TLS protocol version",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_minimum_protocol_version": "TLS1.3",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidTLSProtocolVersion,
+				},
+			},
+		},
+		{
+			testName: "Reject configmap with a minimum TLS protocol version greater than the maximum version",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_minimum_protocol_version": "TLSv1_3",
+					"tls_maximum_protocol_version": "TLSv1_2",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidTLSProtocolVersionRange,
+				},
+			},
+		},
+		{
+			testName: "Reject configmap with a minimum TLS protocol version greater than the default maximum version",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_maximum_protocol_version": "TLSv1_1",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidTLSProtocolVersionRange,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid TLS cipher suites",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_cipher_suites": "[ECDHE-ECDSA-AES128-GCM-SHA256|ECDHE-ECDSA-CHACHA20-POLY1305], ECDHE-RSA-AES256-GCM-SHA384",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid TLS cipher suite",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_cipher_suites": "ECDHE-RSA-AES256-GCM-SHA384,ECDHE-RSA-AES256-GCM",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidTLSCipherSuites,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid default cluster type",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"default_cluster_type": "STRICT_DNS",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid default cluster type",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"default_cluster_type": "ORIGINAL_DST",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidClusterType,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid DNS lookup family",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"dns_lookup_family": "AUTO",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid DNS lookup family",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"dns_lookup_family": "V4_PREFERRED",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidDNSLookupFamily,
+				},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid traffic split empty backend mode",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"traffic_split_empty_backend_mode": "drop",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidTrafficSplitEmptyBackendMode,
+				},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid outbound unknown host mode",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"outbound_unknown_host_mode": "allow",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidOutboundUnknownHostMode,
+				},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid tracing propagation format",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tracing_propagation_format": "jaeger",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidTracingPropagationFormat,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid upstream bind source address",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"upstream_bind_source_address": "10.1.2.3",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid upstream bind source address",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"upstream_bind_source_address": "10.1.2.3/32",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidIPAddress,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid DNS refresh config",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"dns_refresh_rate": "1s",
+					"respect_dns_ttl":  "false",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid DNS refresh config",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"dns_refresh_rate": "0s",
+					"respect_dns_ttl":  "sometimes",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeBool + mustBePositiveDuration,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid Envoy stats sinks and flush interval",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"envoy_stats_sinks":          "statsd://10.0.0.10:8125, dogstatsd://10.0.0.11:8125",
+					"envoy_stats_flush_interval": "10s",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid Envoy stats sinks and flush interval",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"envoy_stats_sinks":          "statsd://statsd.monitoring:8125",
+					"envoy_stats_flush_interval": "0s",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidStatsSinks + mustBePositiveDuration,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid xDS keepalive parameters",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"xds_keepalive_time":     "30s",
+					"xds_keepalive_timeout":  "10s",
+					"xds_max_connection_age": "1h",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid xDS keepalive timeout",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"xds_keepalive_timeout": "-10s",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBePositiveDuration,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid listener socket options",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"listener_dscp":              "46",
+					"enable_listener_reuse_port": "true",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with an out of range listener DSCP value",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"listener_dscp": "64",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeValidDSCP,
+				},
+			},
+		},
+		{
+			testName: "Accept configmap with valid Envoy startup probe settings",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"envoy_startup_probe_failure_threshold": "30",
+					"envoy_startup_probe_period_seconds":    "10",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject configmap with invalid Envoy startup probe settings",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"envoy_startup_probe_failure_threshold": "-1",
+				},
+			},
+			expRes: &v1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Reason: mustBeNonNegativeInt,
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.testName, func(t *testing.T) {
@@ -21,6 +21,9 @@ const (
 	// EnvoyInboundListenerPortName is Envoy's inbound listener port name.
 	EnvoyInboundListenerPortName = "proxy-inbound"
 
+	// EnvoyInboundListenerName is the name of Envoy's inbound listener.
+	EnvoyInboundListenerName = "inbound-listener"
+
 	// EnvoyInboundPrometheusListenerPortName is Envoy's inbound listener port name for prometheus.
 	EnvoyInboundPrometheusListenerPortName = "proxy-metrics"
 
@@ -54,9 +57,81 @@ const (
 	// DefaultEnvoyLogLevel is the default envoy log level if not defined in the osm configmap
 	DefaultEnvoyLogLevel = "error"
 
+	// DefaultTLSMinimumProtocolVersion is the default minimum TLS protocol version if not defined in the osm configmap
+	DefaultTLSMinimumProtocolVersion = "TLSv1_2"
+
+	// DefaultTLSMaximumProtocolVersion is the default maximum TLS protocol version if not defined in the osm configmap
+	DefaultTLSMaximumProtocolVersion = "TLSv1_3"
+
+	// ClusterTypeEDS is the type of the upstream service clusters whose endpoints are discovered using EDS
+	ClusterTypeEDS = "EDS"
+
+	// ClusterTypeStrictDNS is the type of the upstream service clusters whose endpoints are all the addresses resolved from the service's DNS name
+	ClusterTypeStrictDNS = "STRICT_DNS"
+
+	// ClusterTypeLogicalDNS is the type of the upstream service clusters connecting to the first address resolved from the service's DNS name
+	ClusterTypeLogicalDNS = "LOGICAL_DNS"
+
+	// DefaultClusterType is the default type of the upstream service clusters if not defined in the osm configmap
+	DefaultClusterType = ClusterTypeEDS
+
+	// DNSLookupFamilyV4Only resolves the DNS names of DNS clusters to IPv4 addresses only
+	DNSLookupFamilyV4Only = "V4_ONLY"
+
+	// DNSLookupFamilyV6Only resolves the DNS names of DNS clusters to IPv6 addresses only
+	DNSLookupFamilyV6Only = "V6_ONLY"
+
+	// DNSLookupFamilyAuto resolves the DNS names of DNS clusters to IPv6 addresses, falling back to IPv4 addresses
+	DNSLookupFamilyAuto = "AUTO"
+
+	// DefaultDNSLookupFamily is the default IP address family of the DNS clusters if not defined in the osm configmap
+	DefaultDNSLookupFamily = DNSLookupFamilyV4Only
+
+	// TrafficSplitEmptyBackendModeStrict keeps the backends of a TrafficSplit without endpoints in the split, so that the
+	// requests routed to them fail
+	TrafficSplitEmptyBackendModeStrict = "strict"
+
+	// TrafficSplitEmptyBackendModeRedistribute drops the backends of a TrafficSplit without endpoints from the split, so
+	// that their weight is redistributed to the backends with endpoints
+	TrafficSplitEmptyBackendModeRedistribute = "redistribute"
+
+	// DefaultTrafficSplitEmptyBackendMode is the default handling of the backends of a TrafficSplit without endpoints if
+	// not defined in the osm configmap
+	DefaultTrafficSplitEmptyBackendMode = TrafficSplitEmptyBackendModeStrict
+
+	// OutboundUnknownHostModeDeny responds with a 404 to the outbound HTTP requests to hosts unknown to the mesh
+	OutboundUnknownHostModeDeny = "deny"
+
+	// OutboundUnknownHostModePassthrough forwards the outbound HTTP requests to hosts unknown to the mesh to their
+	// original destination
+	OutboundUnknownHostModePassthrough = "passthrough"
+
+	// DefaultOutboundUnknownHostMode is the default handling of the outbound HTTP requests to hosts unknown to the mesh
+	// if not defined in the osm configmap
+	DefaultOutboundUnknownHostMode = OutboundUnknownHostModeDeny
+
+	// TracingPropagationFormatB3 propagates the trace context across the mesh in the B3 x-b3-* headers
+	TracingPropagationFormatB3 = "b3"
+
+	// TracingPropagationFormatW3C propagates the trace context across the mesh in the W3C traceparent header
+	TracingPropagationFormatW3C = "w3c"
+
+	// DefaultTracingPropagationFormat is the default format of the trace context propagated across the mesh if not
+	// defined in the osm configmap
+	DefaultTracingPropagationFormat = TracingPropagationFormatB3
+
 	// EnvoyPrometheusInboundListenerPort is Envoy's inbound listener port number for prometheus
 	EnvoyPrometheusInboundListenerPort = 15010
 
+	// EnvoyLivenessProbePort is the port of Envoy's listener serving the rewritten liveness probes of the app
+	EnvoyLivenessProbePort = 15901
+
+	// EnvoyReadinessProbePort is the port of Envoy's listener serving the rewritten readiness probes of the app
+	EnvoyReadinessProbePort = 15902
+
+	// EnvoyStartupProbePort is the port of Envoy's listener serving the rewritten startup probes of the app
+	EnvoyStartupProbePort = 15903
+
 	// InjectorWebhookPort is the port on which the sidecar injection webhook listens
 	InjectorWebhookPort = 9090
 
@@ -131,6 +206,9 @@ const (
 	// InitContainerName is the name of the init container
 	InitContainerName = "osm-init"
 
+	// EnvoyConfigAckedPodCondition is the pod condition type used as a readiness gate, set once the Envoy sidecar has ACKed its initial config
+	EnvoyConfigAckedPodCondition = "osm.openservicemesh.io/envoy-config-acked"
+
 	// EnvoyServiceNodeSeparator is the character separating the strings used to create an Envoy service node parameter.
 	// Example use: envoy --service-node 52883c80-6e0d-4c64-b901-cbcb75134949/bookstore/10.144.2.91/bookstore-v1/bookstore-v1
 	EnvoyServiceNodeSeparator = "/"
@@ -144,8 +222,275 @@ const (
 	// SidecarInjectionAnnotation is the annotation used for sidecar injection
 	SidecarInjectionAnnotation = "openservicemesh.io/sidecar-injection"
 
+	// SidecarInjectionSelectorAnnotation is the annotation used on a namespace to set the label selector its pods must
+	// match to be injected with the sidecar
+	SidecarInjectionSelectorAnnotation = "openservicemesh.io/sidecar-injection-selector"
+
+	// SidecarInjectedAnnotation is the annotation added by the injector to the pods it injected the sidecar into
+	SidecarInjectedAnnotation = "openservicemesh.io/sidecar-injected"
+
+	// EnvoyVersionAnnotation is the annotation added by the injector to record the version of the injected Envoy sidecar
+	EnvoyVersionAnnotation = "openservicemesh.io/envoy-version"
+
 	// MetricsAnnotation is the annotation used for enabling/disabling metrics
 	MetricsAnnotation = "openservicemesh.io/metrics"
+
+	// SidecarImageAnnotation is the annotation used on a namespace or pod to override the sidecar image
+	SidecarImageAnnotation = "openservicemesh.io/sidecar-image"
+
+	// EnvoyLogLevelAnnotation is the annotation used on a namespace or pod to override the sidecar's log level
+	EnvoyLogLevelAnnotation = "openservicemesh.io/envoy-log-level"
+
+	// EnvoyConcurrencyAnnotation is the annotation used on a namespace or pod to override the number of worker threads
+	// of the sidecar
+	EnvoyConcurrencyAnnotation = "openservicemesh.io/envoy-concurrency"
+
+	// SidecarCPURequestAnnotation is the annotation used on a namespace or pod to set the sidecar's CPU request
+	SidecarCPURequestAnnotation = "openservicemesh.io/sidecar-cpu-request"
+
+	// SidecarCPULimitAnnotation is the annotation used on a namespace or pod to set the sidecar's CPU limit
+	SidecarCPULimitAnnotation = "openservicemesh.io/sidecar-cpu-limit"
+
+	// SidecarMemoryRequestAnnotation is the annotation used on a namespace or pod to set the sidecar's memory request
+	SidecarMemoryRequestAnnotation = "openservicemesh.io/sidecar-memory-request"
+
+	// SidecarMemoryLimitAnnotation is the annotation used on a namespace or pod to set the sidecar's memory limit
+	SidecarMemoryLimitAnnotation = "openservicemesh.io/sidecar-memory-limit"
+
+	// CriticalPodAnnotation is the annotation used on a pod to flag it as critical, keeping it off the spot nodes
+	// configured in the injector
+	CriticalPodAnnotation = "openservicemesh.io/critical"
+
+	// InboundPortExclusionListAnnotation is the annotation used on a pod to exclude a comma separated list of inbound
+	// ports from interception by the sidecar, such as health or metrics ports that must be reachable without mTLS
+	InboundPortExclusionListAnnotation = "openservicemesh.io/inbound-port-exclusion-list"
+
+	// InboundPlaintextProbePortsAnnotation is the annotation used on a pod to override the comma separated list of inbound
+	// ports accepting plaintext connections from the pod's node, derived by default from the pod's probes and Prometheus
+	// scrape annotation
+	InboundPlaintextProbePortsAnnotation = "openservicemesh.io/inbound-plaintext-probe-ports"
+
+	// XDSAddressAnnotation is the annotation used on a pod to override the host:port of the xDS server its sidecar
+	// connects to, e.g. to point the sidecars of some pods at a canary control plane
+	XDSAddressAnnotation = "openservicemesh.io/xds-address"
+
+	// UpstreamSNIAnnotation is the annotation used on a service to override the SNI used by downstream proxies to connect to it
+	UpstreamSNIAnnotation = "openservicemesh.io/upstream-sni"
+
+	// ExternalTLSOriginationAnnotation is the annotation used on a service representing a destination outside the mesh
+	// to originate TLS to it, validating its certificate with the CA bundle of the sidecar instead of the mesh CA
+	ExternalTLSOriginationAnnotation = "openservicemesh.io/external-tls-origination"
+
+	// FailoverServicesAnnotation is the annotation used on a service to specify the ordered list of services that
+	// downstream proxies fail over to when the service is unhealthy. The downstream proxies only fail over to the
+	// services they are allowed to access.
+	FailoverServicesAnnotation = "openservicemesh.io/failover-services"
+
+	// MirrorServiceAnnotation is the annotation used on a service to specify the comma separated list of shadow services
+	// that downstream proxies mirror requests to the service to, each optionally followed by ':<percentage>'. Requests
+	// are only mirrored by the downstream proxies allowed to access the shadow services.
+	MirrorServiceAnnotation = "openservicemesh.io/mirror-service"
+
+	// MirrorPercentageAnnotation is the annotation used on a service to specify the percentage of requests to the
+	// service that are mirrored to its shadow services not specifying a percentage
+	MirrorPercentageAnnotation = "openservicemesh.io/mirror-percentage"
+
+	// OutlierDetectionConsecutive5xxAnnotation is the annotation used on a service to specify the number of consecutive
+	// 5xx responses after which downstream proxies eject an endpoint of the service
+	OutlierDetectionConsecutive5xxAnnotation = "openservicemesh.io/outlier-detection-consecutive-5xx"
+
+	// OutlierDetectionMaxEjectionPercentAnnotation is the annotation used on a service to specify the maximum percentage
+	// of the endpoints of the service that downstream proxies can eject
+	OutlierDetectionMaxEjectionPercentAnnotation = "openservicemesh.io/outlier-detection-max-ejection-percent"
+
+	// OutlierDetectionEnforcingConsecutive5xxAnnotation is the annotation used on a service to specify the percentage
+	// of ejections due to consecutive 5xx responses that downstream proxies enforce
+	OutlierDetectionEnforcingConsecutive5xxAnnotation = "openservicemesh.io/outlier-detection-enforcing-consecutive-5xx"
+
+	// OutlierDetectionSplitExternalLocalOriginErrorsAnnotation is the annotation used on a service to specify whether
+	// downstream proxies count the local-origin failures to reach an endpoint of the service, such as connection
+	// failures, separately from the 5xx responses of the endpoint
+	OutlierDetectionSplitExternalLocalOriginErrorsAnnotation = "openservicemesh.io/outlier-detection-split-external-local-origin-errors"
+
+	// OutlierDetectionConsecutiveLocalOriginFailureAnnotation is the annotation used on a service to specify the number
+	// of consecutive local-origin failures after which downstream proxies eject an endpoint of the service
+	OutlierDetectionConsecutiveLocalOriginFailureAnnotation = "openservicemesh.io/outlier-detection-consecutive-local-origin-failure"
+
+	// OutlierDetectionEnforcingConsecutiveLocalOriginFailureAnnotation is the annotation used on a service to specify
+	// the percentage of ejections due to consecutive local-origin failures that downstream proxies enforce
+	OutlierDetectionEnforcingConsecutiveLocalOriginFailureAnnotation = "openservicemesh.io/outlier-detection-enforcing-consecutive-local-origin-failure"
+
+	// CircuitBreakerMaxConnectionsAnnotation is the annotation used on a service to set the maximum number of connections its downstream proxies open to the service
+	// for requests routed at the default priority
+	CircuitBreakerMaxConnectionsAnnotation = "openservicemesh.io/circuit-breaker-max-connections"
+
+	// CircuitBreakerMaxPendingRequestsAnnotation is the annotation used on a service to set the maximum number of requests its downstream proxies queue while waiting for a connection to the service
+	// for requests routed at the default priority
+	CircuitBreakerMaxPendingRequestsAnnotation = "openservicemesh.io/circuit-breaker-max-pending-requests"
+
+	// CircuitBreakerMaxRequestsAnnotation is the annotation used on a service to set the maximum number of parallel requests its downstream proxies send to the service
+	// for requests routed at the default priority
+	CircuitBreakerMaxRequestsAnnotation = "openservicemesh.io/circuit-breaker-max-requests"
+
+	// CircuitBreakerMaxRetriesAnnotation is the annotation used on a service to set the maximum number of parallel retries its downstream proxies send to the service
+	// for requests routed at the default priority
+	CircuitBreakerMaxRetriesAnnotation = "openservicemesh.io/circuit-breaker-max-retries"
+
+	// CircuitBreakerHighPriorityMaxConnectionsAnnotation is the annotation used on a service to set the maximum number of connections its downstream proxies open to the service
+	// for requests routed at the high priority
+	CircuitBreakerHighPriorityMaxConnectionsAnnotation = "openservicemesh.io/circuit-breaker-high-priority-max-connections"
+
+	// CircuitBreakerHighPriorityMaxPendingRequestsAnnotation is the annotation used on a service to set the maximum number of requests its downstream proxies queue while waiting for a connection to the service
+	// for requests routed at the high priority
+	CircuitBreakerHighPriorityMaxPendingRequestsAnnotation = "openservicemesh.io/circuit-breaker-high-priority-max-pending-requests"
+
+	// CircuitBreakerHighPriorityMaxRequestsAnnotation is the annotation used on a service to set the maximum number of parallel requests its downstream proxies send to the service
+	// for requests routed at the high priority
+	CircuitBreakerHighPriorityMaxRequestsAnnotation = "openservicemesh.io/circuit-breaker-high-priority-max-requests"
+
+	// CircuitBreakerHighPriorityMaxRetriesAnnotation is the annotation used on a service to set the maximum number of parallel retries its downstream proxies send to the service
+	// for requests routed at the high priority
+	CircuitBreakerHighPriorityMaxRetriesAnnotation = "openservicemesh.io/circuit-breaker-high-priority-max-retries"
+
+	// OutboundDisabledAnnotation is the annotation used on a service to disable the outbound filter chains and clusters
+	// of the upstreams of the proxies of the service, for ingress-only workloads that never originate mesh traffic
+	OutboundDisabledAnnotation = "openservicemesh.io/outbound-disabled"
+
+	// StickyCanaryAnnotation is the annotation used on a TrafficSplit to keep routing a client to the backend it was first
+	// routed to by weight, using a cookie set on the client named osm-canary.<namespace>.<root service>
+	StickyCanaryAnnotation = "openservicemesh.io/sticky-canary"
+
+	// AllowedSourceIPRangesAnnotation is the annotation used on a service to restrict the inbound connections of the sources
+	// allowed by their identity to a comma separated list of source IP CIDR ranges, or to allow the connections from the
+	// ranges regardless of their identity when they bypass mTLS
+	AllowedSourceIPRangesAnnotation = "openservicemesh.io/allowed-source-ip-ranges"
+
+	// ClusterTypeAnnotation is the annotation used on a service to set the type of the clusters of the service on its
+	// downstream proxies, overriding the mesh-wide default cluster type
+	ClusterTypeAnnotation = "openservicemesh.io/cluster-type"
+
+	// UpstreamBindSourceAddressAnnotation is the annotation used on a service to set the source address its downstream
+	// proxies bind their connections to the service to, overriding the mesh-wide upstream bind source address
+	UpstreamBindSourceAddressAnnotation = "openservicemesh.io/upstream-bind-source-address"
+
+	// DNSRefreshRateAnnotation is the annotation used on a service to set the rate at which its downstream proxies resolve
+	// the DNS name of the service's DNS clusters, overriding the mesh-wide DNS refresh rate
+	DNSRefreshRateAnnotation = "openservicemesh.io/dns-refresh-rate"
+
+	// UpstreamIdleTimeoutAnnotation is the annotation used on a service to set the time after which the idle connections
+	// of its downstream proxies to the service are closed, overriding the mesh-wide upstream idle timeout
+	UpstreamIdleTimeoutAnnotation = "openservicemesh.io/upstream-idle-timeout"
+
+	// RequestTimeoutAnnotation is the annotation used on a service to set the time its downstream proxies wait for the
+	// response to a request to the service, overriding the mesh-wide outbound request timeout
+	RequestTimeoutAnnotation = "openservicemesh.io/request-timeout"
+
+	// HealthCheckIntervalAnnotation is the annotation used on a service to enable the active health checking of its
+	// endpoints by its downstream proxies, at the given interval
+	HealthCheckIntervalAnnotation = "openservicemesh.io/health-check-interval"
+
+	// HealthCheckPathAnnotation is the annotation used on a service to set the path requested by the HTTP health checks
+	// of its endpoints. The health checks are authorized by the inbound RBAC of the service like other requests: with
+	// SMI traffic policies, the HTTPRouteGroup of the TrafficTarget allowing the downstream service must match GET
+	// requests to the path.
+	HealthCheckPathAnnotation = "openservicemesh.io/health-check-path"
+
+	// HealthCheckExpectedStatusesAnnotation is the annotation used on a service to set the comma separated list of HTTP
+	// status codes and inclusive ranges of status codes, e.g. 200-299, of the responses to the HTTP health checks
+	// of its endpoints considered healthy
+	HealthCheckExpectedStatusesAnnotation = "openservicemesh.io/health-check-expected-statuses"
+
+	// HealthCheckSendAnnotation is the annotation used on a TCP service to set the hex encoded payload sent by the TCP
+	// health checks of its endpoints
+	HealthCheckSendAnnotation = "openservicemesh.io/health-check-send"
+
+	// HealthCheckReceiveAnnotation is the annotation used on a TCP service to set the hex encoded payload expected in the
+	// responses to the TCP health checks of its endpoints
+	HealthCheckReceiveAnnotation = "openservicemesh.io/health-check-receive"
+
+	// RespectDNSTTLAnnotation is the annotation used on a service to set whether its downstream proxies resolve the DNS
+	// name of the service's DNS clusters again once their DNS records expire, overriding the mesh-wide setting
+	RespectDNSTTLAnnotation = "openservicemesh.io/respect-dns-ttl"
+
+	// HTTP2MaxConcurrentStreamsAnnotation is the annotation used on a service to limit the number of concurrent streams
+	// its proxies accept on a single inbound HTTP/2 connection
+	HTTP2MaxConcurrentStreamsAnnotation = "openservicemesh.io/http2-max-concurrent-streams"
+
+	// AccessLogSamplingAnnotation is the annotation used on a service to have its proxies log a sample of the requests
+	// to the service, either a percentage of the requests, e.g. 10%, or 1 in N requests, e.g. 1/1000
+	AccessLogSamplingAnnotation = "openservicemesh.io/access-log-sampling"
+
+	// PerConnectionBufferLimitBytesAnnotation is the annotation used on a service to set the limit in bytes on the buffers
+	// of the connections of the listeners of its proxies, overriding the mesh-wide per connection buffer limit
+	PerConnectionBufferLimitBytesAnnotation = "openservicemesh.io/per-connection-buffer-limit-bytes"
+
+	// UseRemoteAddressAnnotation is the annotation used on a service to set whether its proxies use the address of the
+	// downstream connection as the client address of inbound HTTP requests, instead of the X-Forwarded-For header
+	UseRemoteAddressAnnotation = "openservicemesh.io/use-remote-address"
+
+	// XFFNumTrustedHopsAnnotation is the annotation used on a service to set the number of trusted proxies in front of
+	// its proxies, whose addresses are skipped from the X-Forwarded-For header of inbound HTTP requests
+	XFFNumTrustedHopsAnnotation = "openservicemesh.io/xff-num-trusted-hops"
+
+	// DirectResponseStatusAnnotation is the annotation used on a service to have its downstream proxies respond to
+	// requests to the service with the given HTTP status code instead of routing them, e.g. during maintenance
+	DirectResponseStatusAnnotation = "openservicemesh.io/direct-response-status"
+
+	// DirectResponseBodyAnnotation is the annotation used on a service to specify the inline body of its direct
+	// responses, of at most 4KB
+	DirectResponseBodyAnnotation = "openservicemesh.io/direct-response-body"
+
+	// DirectResponsePathRegexAnnotation is the annotation used on a service to restrict its direct responses to the
+	// requests whose path matches the given regex
+	DirectResponsePathRegexAnnotation = "openservicemesh.io/direct-response-path-regex"
+
+	// HashPolicyAnnotation is the annotation used on a service to have its downstream proxies load balance requests to
+	// the service by consistent hashing on a header ('header:<name>'), a cookie ('cookie:<name>') or the source IP ('source-ip')
+	HashPolicyAnnotation = "openservicemesh.io/hash-policy"
+
+	// RetryOnAnnotation is the annotation used on a service to have its downstream proxies retry requests to the service
+	// on the given comma separated list of Envoy retry conditions, e.g. '5xx,reset'
+	RetryOnAnnotation = "openservicemesh.io/retry-on"
+
+	// RetryPerTryTimeoutAnnotation is the annotation used on a service to set the timeout of each attempt of the requests
+	// to the service retried by its downstream proxies
+	RetryPerTryTimeoutAnnotation = "openservicemesh.io/retry-per-try-timeout"
+
+	// RetriableStatusCodesAnnotation is the annotation used on a service to have its downstream proxies retry requests
+	// to the service answered with one of the given comma separated list of HTTP status codes
+	RetriableStatusCodesAnnotation = "openservicemesh.io/retriable-status-codes"
+
+	// HTTP1ProperCaseHeadersAnnotation is the annotation used on a service to have its proxies send the header keys of
+	// HTTP/1.1 requests to the local application in proper case, e.g. 'Content-Type', for applications sensitive to
+	// the case of header keys
+	HTTP1ProperCaseHeadersAnnotation = "openservicemesh.io/http1-proper-case-headers"
+
+	// HTTP1KeepaliveTimeAnnotation is the annotation used on a service to have its proxies send TCP keepalive probes on
+	// the connections to the local application idle for the given duration, keeping them alive
+	HTTP1KeepaliveTimeAnnotation = "openservicemesh.io/http1-keepalive-time"
+
+	// InboundConnectionLimitAnnotation is the annotation used on a pod to limit the number of concurrent connections
+	// its proxy accepts on the inbound listener, rejecting the connections beyond the limit. The limit is set in the
+	// bootstrap config of the proxy, so changing it only applies to pods created afterwards.
+	InboundConnectionLimitAnnotation = "openservicemesh.io/inbound-connection-limit"
+
+	// TLSSessionTicketsDisabledAnnotation is the annotation used on a service to disable the resumption of the inbound
+	// TLS sessions of its proxies using session tickets
+	TLSSessionTicketsDisabledAnnotation = "openservicemesh.io/tls-session-tickets-disabled"
+
+	// IngressPrefixRewriteAnnotation is the annotation used on an ingress to rewrite the matched path prefix of its routes
+	IngressPrefixRewriteAnnotation = "openservicemesh.io/prefix-rewrite"
+
+	// IngressRegexRewritePatternAnnotation is the annotation used on an ingress to specify the regex matching the part
+	// of the path of its routes to rewrite
+	IngressRegexRewritePatternAnnotation = "openservicemesh.io/regex-rewrite-pattern"
+
+	// IngressRegexRewriteSubstitutionAnnotation is the annotation used on an ingress to specify the substitution
+	// of the part of the path of its routes matched by the regex rewrite pattern
+	IngressRegexRewriteSubstitutionAnnotation = "openservicemesh.io/regex-rewrite-substitution"
+
+	// MaxRequestBytesAnnotation is the annotation used on an HTTPRouteGroup to override the limit on the size of requests to its routes
+	MaxRequestBytesAnnotation = "openservicemesh.io/max-request-bytes"
 )
 
 // Annotations used for Metrics
@@ -14,9 +14,12 @@ import (
 	v1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	v1alpha20 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
 
+	catalog "github.com/openservicemesh/osm/pkg/catalog"
 	certificate "github.com/openservicemesh/osm/pkg/certificate"
 	envoy "github.com/openservicemesh/osm/pkg/envoy"
 	service "github.com/openservicemesh/osm/pkg/service"
+	smi "github.com/openservicemesh/osm/pkg/smi"
+	trafficpolicy "github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // MockCertificateManagerDebugger is a mock of CertificateManagerDebugger interface
@@ -79,6 +82,20 @@ func (m *MockMeshCatalogDebugger) EXPECT() *MockMeshCatalogDebuggerMockRecorder
 	return m.recorder
 }
 
+// GetMeshTopology mocks base method
+func (m *MockMeshCatalogDebugger) GetMeshTopology() trafficpolicy.MeshTopology {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshTopology")
+	ret0, _ := ret[0].(trafficpolicy.MeshTopology)
+	return ret0
+}
+
+// GetMeshTopology indicates an expected call of GetMeshTopology
+func (mr *MockMeshCatalogDebuggerMockRecorder) GetMeshTopology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshTopology", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).GetMeshTopology))
+}
+
 // ListConnectedProxies mocks base method
 func (m *MockMeshCatalogDebugger) ListConnectedProxies() map[certificate.CommonName]*envoy.Proxy {
 	m.ctrl.T.Helper()
@@ -153,6 +170,20 @@ func (mr *MockMeshCatalogDebuggerMockRecorder) ListSMIPolicies() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSMIPolicies", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).ListSMIPolicies))
 }
 
+// NewCandidateCatalog mocks base method
+func (m *MockMeshCatalogDebugger) NewCandidateCatalog(arg0 smi.CandidatePolicies) catalog.MeshCataloger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewCandidateCatalog", arg0)
+	ret0, _ := ret[0].(catalog.MeshCataloger)
+	return ret0
+}
+
+// NewCandidateCatalog indicates an expected call of NewCandidateCatalog
+func (mr *MockMeshCatalogDebuggerMockRecorder) NewCandidateCatalog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCandidateCatalog", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).NewCandidateCatalog), arg0)
+}
+
 // MockXDSDebugger is a mock of XDSDebugger interface
 type MockXDSDebugger struct {
 	ctrl     *gomock.Controller
@@ -190,6 +221,62 @@ func (mr *MockXDSDebuggerMockRecorder) GetXDSLog() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSLog", reflect.TypeOf((*MockXDSDebugger)(nil).GetXDSLog))
 }
 
+// ListPinnedProxies mocks base method
+func (m *MockXDSDebugger) ListPinnedProxies() map[certificate.CommonName]time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPinnedProxies")
+	ret0, _ := ret[0].(map[certificate.CommonName]time.Time)
+	return ret0
+}
+
+// ListPinnedProxies indicates an expected call of ListPinnedProxies
+func (mr *MockXDSDebuggerMockRecorder) ListPinnedProxies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPinnedProxies", reflect.TypeOf((*MockXDSDebugger)(nil).ListPinnedProxies))
+}
+
+// ListProxyConfigStatus mocks base method
+func (m *MockXDSDebugger) ListProxyConfigStatus() []envoy.ProxyConfigStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProxyConfigStatus")
+	ret0, _ := ret[0].([]envoy.ProxyConfigStatus)
+	return ret0
+}
+
+// ListProxyConfigStatus indicates an expected call of ListProxyConfigStatus
+func (mr *MockXDSDebuggerMockRecorder) ListProxyConfigStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProxyConfigStatus", reflect.TypeOf((*MockXDSDebugger)(nil).ListProxyConfigStatus))
+}
+
+// PinProxyConfig mocks base method
+func (m *MockXDSDebugger) PinProxyConfig(arg0 certificate.CommonName) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinProxyConfig", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinProxyConfig indicates an expected call of PinProxyConfig
+func (mr *MockXDSDebuggerMockRecorder) PinProxyConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinProxyConfig", reflect.TypeOf((*MockXDSDebugger)(nil).PinProxyConfig), arg0)
+}
+
+// UnpinProxyConfig mocks base method
+func (m *MockXDSDebugger) UnpinProxyConfig(arg0 certificate.CommonName) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinProxyConfig", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// UnpinProxyConfig indicates an expected call of UnpinProxyConfig
+func (mr *MockXDSDebuggerMockRecorder) UnpinProxyConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinProxyConfig", reflect.TypeOf((*MockXDSDebugger)(nil).UnpinProxyConfig), arg0)
+}
+
 // MockDebugServer is a mock of DebugServer interface
 type MockDebugServer struct {
 	ctrl     *gomock.Controller
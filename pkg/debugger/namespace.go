@@ -4,22 +4,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 )
 
 type namespaces struct {
 	Namespaces []string `json:"namespaces"`
 }
 
+// getMonitoredNamespacesHandler returns a handler listing the namespaces currently monitored by the control plane.
+// The list reflects the live state of the namespaces labeled for monitoring, as observed by the Kubernetes controller.
 func (ds DebugConfig) getMonitoredNamespacesHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var n namespaces
 		n.Namespaces = ds.meshCatalogDebugger.ListMonitoredNamespaces()
+		sort.Strings(n.Namespaces)
 
-		jsonPolicies, err := json.Marshal(n)
+		jsonNamespaces, err := json.Marshal(n)
 		if err != nil {
-			log.Error().Err(err).Msgf("Error marshalling policy %+v", n)
+			log.Error().Err(err).Msgf("Error marshalling monitored namespaces %+v", n)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
 
-		_, _ = fmt.Fprint(w, string(jsonPolicies))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonNamespaces))
 	})
 }
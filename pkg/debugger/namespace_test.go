@@ -34,3 +34,22 @@ func TestMonitoredNamespaceHandler(t *testing.T) {
 	expectedResponseBody := `{"namespaces":["default"]}`
 	assert.Equal(expectedResponseBody, actualResponseBody, "Actual value did not match expectations:\n%s", actualResponseBody)
 }
+
+// Tests getMonitoredNamespaces through HTTP handler returns the monitored namespaces sorted as JSON
+func TestMonitoredNamespaceHandlerSorted(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mock := NewMockMeshCatalogDebugger(mockCtrl)
+
+	ds := DebugConfig{
+		meshCatalogDebugger: mock,
+	}
+	monitoredNamespacesHandler := ds.getMonitoredNamespacesHandler()
+
+	mock.EXPECT().ListMonitoredNamespaces().Return([]string{"ns-3", "ns-1", "ns-2"})
+
+	responseRecorder := httptest.NewRecorder()
+	monitoredNamespacesHandler.ServeHTTP(responseRecorder, nil)
+	assert.Equal("application/json", responseRecorder.Header().Get("Content-Type"))
+	assert.Equal(`{"namespaces":["ns-1","ns-2","ns-3"]}`, responseRecorder.Body.String())
+}
@@ -0,0 +1,65 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+// getPinHandler returns a handler managing the pinning of proxies to their last acknowledged config.
+// GET lists the pinned proxies, POST pins and DELETE unpins the proxy whose certificate CN is given by the 'proxy' query parameter.
+func (ds DebugConfig) getPinHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			ds.listPinnedProxies(w)
+			return
+		}
+
+		cn := certificate.CommonName(r.URL.Query().Get(specificProxyQueryKey))
+		if cn == "" {
+			http.Error(w, fmt.Sprintf("missing '%s' query parameter", specificProxyQueryKey), http.StatusBadRequest)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			if err := ds.xdsDebugger.PinProxyConfig(cn); err != nil {
+				log.Error().Err(err).Msgf("Error pinning proxy with certificate CN=%s", cn)
+				http.Error(w, fmt.Sprintf("error pinning proxy %s: %s", cn, err), http.StatusConflict)
+				return
+			}
+			_, _ = fmt.Fprintf(w, "Pinned proxy %s\n", cn)
+
+		case http.MethodDelete:
+			if !ds.xdsDebugger.UnpinProxyConfig(cn) {
+				http.Error(w, fmt.Sprintf("proxy %s is not pinned", cn), http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprintf(w, "Unpinned proxy %s\n", cn)
+
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (ds DebugConfig) listPinnedProxies(w http.ResponseWriter) {
+	pinned := make(map[string]time.Time)
+	for cn, expiresAt := range ds.xdsDebugger.ListPinnedProxies() {
+		pinned[cn.String()] = expiresAt
+	}
+
+	jsonPinned, err := json.Marshal(pinned)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling pinned proxies %+v", pinned)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprint(w, string(jsonPinned))
+}
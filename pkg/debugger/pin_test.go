@@ -0,0 +1,85 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+func TestPinHandler(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockXDSDebugger := NewMockXDSDebugger(mockCtrl)
+
+	ds := DebugConfig{
+		xdsDebugger: mockXDSDebugger,
+	}
+	pinHandler := ds.getPinHandler()
+
+	cn := certificate.CommonName("proxy.sa.ns.cluster.local")
+	expiresAt := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mockXDSDebugger.EXPECT().PinProxyConfig(cn).Return(nil).Times(1)
+	mockXDSDebugger.EXPECT().ListPinnedProxies().Return(map[certificate.CommonName]time.Time{cn: expiresAt}).Times(1)
+	mockXDSDebugger.EXPECT().UnpinProxyConfig(cn).Return(true).Times(1)
+	mockXDSDebugger.EXPECT().UnpinProxyConfig(cn).Return(false).Times(1)
+
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "pin a proxy",
+			method:       http.MethodPost,
+			url:          "/debug/pin?proxy=" + cn.String(),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "list the pinned proxies",
+			method:       http.MethodGet,
+			url:          "/debug/pin",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"proxy.sa.ns.cluster.local":"2021-03-01T10:00:00Z"}`,
+		},
+		{
+			name:         "unpin a pinned proxy",
+			method:       http.MethodDelete,
+			url:          "/debug/pin?proxy=" + cn.String(),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unpin a proxy that is not pinned",
+			method:       http.MethodDelete,
+			url:          "/debug/pin?proxy=" + cn.String(),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "pin without a proxy",
+			method:       http.MethodPost,
+			url:          "/debug/pin",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			pinHandler.ServeHTTP(responseRecorder, httptest.NewRequest(tc.method, tc.url, nil))
+
+			assert.Equal(tc.expectedCode, responseRecorder.Code)
+			if tc.expectedBody != "" {
+				assert.Equal(tc.expectedBody, responseRecorder.Body.String())
+			}
+		})
+	}
+}
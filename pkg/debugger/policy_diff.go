@@ -0,0 +1,231 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/cds"
+	"github.com/openservicemesh/osm/pkg/envoy/lds"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+// policyDiffDecoderBufferSize is the number of bytes the decoder of the candidate policies looks ahead to tell JSON from YAML
+const policyDiffDecoderBufferSize = 4096
+
+// proxyConfigDiff is the difference between the live config of a proxy and its config with the candidate policies applied
+type proxyConfigDiff struct {
+	Proxy     string       `json:"proxy"`
+	Listeners resourceDiff `json:"listeners"`
+	Clusters  resourceDiff `json:"clusters"`
+	Routes    resourceDiff `json:"routes"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// resourceDiff lists the names of the xDS resources of a given type added, removed and changed by the candidate policies
+type resourceDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+func (d resourceDiff) isEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// getPolicyDiffHandler returns a handler previewing the effect of the SMI policies POSTed as a stream of YAML or JSON
+// documents. The policies are overlaid on the live SMI policies, and the handler lists, per connected proxy whose
+// config would change, the listeners, clusters and routes the policies would add, remove or change. Routes are named
+// <route configuration>/<virtual host>.
+func (ds DebugConfig) getPolicyDiffHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		candidate, err := parseCandidatePolicies(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error parsing the candidate SMI policies: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		// The live config is computed from a snapshot of the live policies as well, so that both configs are computed
+		// from the same state of the cluster
+		liveCatalog := ds.meshCatalogDebugger.NewCandidateCatalog(smi.CandidatePolicies{})
+		candidateCatalog := ds.meshCatalogDebugger.NewCandidateCatalog(candidate)
+
+		var diffs []proxyConfigDiff
+		for cn, proxy := range ds.meshCatalogDebugger.ListConnectedProxies() {
+			diff, err := diffProxyConfig(liveCatalog, candidateCatalog, proxy, ds.configurator)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error computing the config diff of proxy with certificate CN=%s", cn)
+				diffs = append(diffs, proxyConfigDiff{Proxy: cn.String(), Error: err.Error()})
+				continue
+			}
+			if diff.Listeners.isEmpty() && diff.Clusters.isEmpty() && diff.Routes.isEmpty() {
+				continue
+			}
+			diff.Proxy = cn.String()
+			diffs = append(diffs, diff)
+		}
+		sort.Slice(diffs, func(i, j int) bool {
+			return diffs[i].Proxy < diffs[j].Proxy
+		})
+
+		jsonDiffs, err := json.Marshal(diffs)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling policy diff %+v", diffs)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonDiffs))
+	})
+}
+
+// parseCandidatePolicies decodes the TrafficSplit, HTTPRouteGroup, TCPRoute and TrafficTarget resources of the given
+// stream of YAML or JSON documents
+func parseCandidatePolicies(reader io.Reader) (smi.CandidatePolicies, error) {
+	var candidate smi.CandidatePolicies
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, policyDiffDecoderBufferSize)
+	for {
+		var object unstructured.Unstructured
+		if err := decoder.Decode(&object.Object); err == io.EOF {
+			return candidate, nil
+		} else if err != nil {
+			return candidate, err
+		}
+		if object.Object == nil {
+			continue
+		}
+
+		var err error
+		switch object.GetKind() {
+		case "TrafficSplit":
+			trafficSplit := &smiSplit.TrafficSplit{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, trafficSplit)
+			candidate.TrafficSplits = append(candidate.TrafficSplits, trafficSplit)
+		case "HTTPRouteGroup":
+			routeGroup := &smiSpecs.HTTPRouteGroup{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, routeGroup)
+			candidate.HTTPRouteGroups = append(candidate.HTTPRouteGroups, routeGroup)
+		case "TCPRoute":
+			tcpRoute := &smiSpecs.TCPRoute{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, tcpRoute)
+			candidate.TCPRoutes = append(candidate.TCPRoutes, tcpRoute)
+		case "TrafficTarget":
+			trafficTarget := &smiAccess.TrafficTarget{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, trafficTarget)
+			candidate.TrafficTargets = append(candidate.TrafficTargets, trafficTarget)
+		default:
+			return candidate, errors.Errorf("unsupported kind %q of %s/%s", object.GetKind(), object.GetNamespace(), object.GetName())
+		}
+		if err != nil {
+			return candidate, errors.Wrapf(err, "invalid %s %s/%s", object.GetKind(), object.GetNamespace(), object.GetName())
+		}
+	}
+}
+
+// diffProxyConfig returns the difference between the LDS, CDS and RDS responses built for the given proxy from the live
+// and the candidate catalogs
+func diffProxyConfig(liveCatalog, candidateCatalog catalog.MeshCataloger, proxy *envoy.Proxy, cfg configurator.Configurator) (proxyConfigDiff, error) {
+	var diff proxyConfigDiff
+	for _, builder := range []struct {
+		newResponse func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error)
+		diff        *resourceDiff
+	}{
+		{lds.NewResponse, &diff.Listeners},
+		{cds.NewResponse, &diff.Clusters},
+		{rds.NewResponse, &diff.Routes},
+	} {
+		live, err := builder.newResponse(liveCatalog, proxy, nil, cfg, nil)
+		if err != nil {
+			return diff, errors.Wrap(err, "error building the live response")
+		}
+		candidate, err := builder.newResponse(candidateCatalog, proxy, nil, cfg, nil)
+		if err != nil {
+			return diff, errors.Wrap(err, "error building the candidate response")
+		}
+
+		liveResources, err := getResourcesByName(live)
+		if err != nil {
+			return diff, err
+		}
+		candidateResources, err := getResourcesByName(candidate)
+		if err != nil {
+			return diff, err
+		}
+		*builder.diff = diffResources(liveResources, candidateResources)
+	}
+	return diff, nil
+}
+
+// getResourcesByName returns the resources of the given response keyed by name. The virtual hosts of the route
+// configurations are returned in place of the route configurations, so that a change of routes is reported per host.
+func getResourcesByName(response *xds_discovery.DiscoveryResponse) (map[string]proto.Message, error) {
+	resources := make(map[string]proto.Message)
+	if response == nil {
+		return resources, nil
+	}
+	for _, resource := range response.Resources {
+		var message ptypes.DynamicAny
+		if err := ptypes.UnmarshalAny(resource, &message); err != nil {
+			return nil, err
+		}
+
+		name := cachev3.GetResourceName(message.Message)
+		if routeConfig, ok := message.Message.(*xds_route.RouteConfiguration); ok {
+			for _, virtualHost := range routeConfig.VirtualHosts {
+				resources[name+"/"+virtualHost.Name] = virtualHost
+			}
+			continue
+		}
+		resources[name] = message.Message
+	}
+	return resources, nil
+}
+
+// diffResources returns the sorted names of the resources added, removed and changed from the live to the candidate resources
+func diffResources(live, candidate map[string]proto.Message) resourceDiff {
+	var diff resourceDiff
+	for name, candidateResource := range candidate {
+		liveResource, ok := live[name]
+		if !ok {
+			diff.Added = append(diff.Added, name)
+		} else if !proto.Equal(liveResource, candidateResource) {
+			diff.Changed = append(diff.Changed, name)
+		}
+	}
+	for name := range live {
+		if _, ok := candidate[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
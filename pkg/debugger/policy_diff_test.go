@@ -0,0 +1,116 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestParseCandidatePolicies(t *testing.T) {
+	assert := tassert.New(t)
+
+	candidate, err := parseCandidatePolicies(strings.NewReader(`
+apiVersion: split.smi-spec.io/v1alpha2
+kind: TrafficSplit
+metadata:
+  name: bookstore-split
+  namespace: bookstore
+spec:
+  service: bookstore
+  backends:
+  - service: bookstore-v2
+    weight: 100
+---
+apiVersion: access.smi-spec.io/v1alpha3
+kind: TrafficTarget
+metadata:
+  name: bookbuyer-access-bookstore
+  namespace: bookstore
+spec:
+  destination:
+    kind: ServiceAccount
+    name: bookstore
+    namespace: bookstore
+  sources:
+  - kind: ServiceAccount
+    name: bookbuyer
+    namespace: bookbuyer
+`))
+	assert.Nil(err)
+	assert.Len(candidate.TrafficSplits, 1)
+	assert.Equal("bookstore-split", candidate.TrafficSplits[0].Name)
+	assert.Equal(100, candidate.TrafficSplits[0].Spec.Backends[0].Weight)
+	assert.Len(candidate.TrafficTargets, 1)
+	assert.Equal("bookbuyer", candidate.TrafficTargets[0].Spec.Sources[0].Name)
+	assert.Empty(candidate.HTTPRouteGroups)
+	assert.Empty(candidate.TCPRoutes)
+
+	_, err = parseCandidatePolicies(strings.NewReader(`{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "bookstore"}}`))
+	assert.NotNil(err)
+}
+
+func TestPolicyDiffHandlerRejectsGet(t *testing.T) {
+	assert := tassert.New(t)
+
+	responseRecorder := httptest.NewRecorder()
+	DebugConfig{}.getPolicyDiffHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/policy-diff", nil))
+
+	assert.Equal(http.StatusMethodNotAllowed, responseRecorder.Code)
+	assert.Equal("POST", responseRecorder.Header().Get("Allow"))
+}
+
+func TestDiffResources(t *testing.T) {
+	assert := tassert.New(t)
+
+	newResponse := func(messages ...proto.Message) *xds_discovery.DiscoveryResponse {
+		response := &xds_discovery.DiscoveryResponse{}
+		for _, message := range messages {
+			marshalled, err := ptypes.MarshalAny(message)
+			assert.Nil(err)
+			response.Resources = append(response.Resources, marshalled)
+		}
+		return response
+	}
+
+	live, err := getResourcesByName(newResponse(
+		&xds_cluster.Cluster{Name: "bookstore/bookstore-v1"},
+		&xds_cluster.Cluster{Name: "bookstore/bookstore-v2", ConnectTimeout: ptypes.DurationProto(time.Second)},
+	))
+	assert.Nil(err)
+	candidate, err := getResourcesByName(newResponse(
+		&xds_cluster.Cluster{Name: "bookstore/bookstore-v2", ConnectTimeout: ptypes.DurationProto(2 * time.Second)},
+		&xds_cluster.Cluster{Name: "bookstore/bookstore-v3"},
+	))
+	assert.Nil(err)
+	assert.Equal(resourceDiff{
+		Added:   []string{"bookstore/bookstore-v3"},
+		Removed: []string{"bookstore/bookstore-v1"},
+		Changed: []string{"bookstore/bookstore-v2"},
+	}, diffResources(live, candidate))
+
+	liveRoutes, err := getResourcesByName(newResponse(&xds_route.RouteConfiguration{
+		Name:         "RDS_Outbound",
+		VirtualHosts: []*xds_route.VirtualHost{{Name: "outbound_virtualHost|bookstore"}},
+	}))
+	assert.Nil(err)
+	candidateRoutes, err := getResourcesByName(newResponse(&xds_route.RouteConfiguration{
+		Name: "RDS_Outbound",
+		VirtualHosts: []*xds_route.VirtualHost{
+			{Name: "outbound_virtualHost|bookstore"},
+			{Name: "outbound_virtualHost|bookwarehouse"},
+		},
+	}))
+	assert.Nil(err)
+	assert.Equal(resourceDiff{Added: []string{"RDS_Outbound/outbound_virtualHost|bookwarehouse"}}, diffResources(liveRoutes, candidateRoutes))
+
+	assert.True(diffResources(candidateRoutes, candidateRoutes).isEmpty())
+}
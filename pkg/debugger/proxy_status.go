@@ -0,0 +1,25 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// getProxyConfigStatusHandler returns a handler listing the proxies connected to the XDS server and, per xDS type,
+// whether they acknowledged the config last sent to them
+func (ds DebugConfig) getProxyConfigStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statuses := ds.xdsDebugger.ListProxyConfigStatus()
+
+		jsonStatuses, err := json.Marshal(statuses)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling proxy config status %+v", statuses)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonStatuses))
+	})
+}
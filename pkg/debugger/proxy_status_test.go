@@ -0,0 +1,51 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestProxyConfigStatusHandler(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockXDSDebugger := NewMockXDSDebugger(mockCtrl)
+
+	ds := DebugConfig{
+		xdsDebugger: mockXDSDebugger,
+	}
+
+	mockXDSDebugger.EXPECT().ListProxyConfigStatus().Return([]envoy.ProxyConfigStatus{
+		{
+			CommonName:   "proxy.sa.ns.cluster.local",
+			SerialNumber: "123",
+			PodUID:       "pod-uid",
+			ConnectedAt:  time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+			Types: map[envoy.TypeURI]envoy.XDSTypeConfigStatus{
+				envoy.TypeCDS: {SentVersion: "2", SentNonce: "n2", AckedVersion: "1", UpToDate: false},
+			},
+		},
+	}).Times(1)
+
+	responseRecorder := httptest.NewRecorder()
+	ds.getProxyConfigStatusHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/proxy-status", nil))
+
+	assert.Equal(http.StatusOK, responseRecorder.Code)
+	assert.Equal("application/json", responseRecorder.Header().Get("Content-Type"))
+	assert.JSONEq(`[{
+		"common_name": "proxy.sa.ns.cluster.local",
+		"serial_number": "123",
+		"pod_uid": "pod-uid",
+		"connected_at": "2021-03-01T10:00:00Z",
+		"types": {
+			"type.googleapis.com/envoy.config.cluster.v3.Cluster": {"sent_version": "2", "sent_nonce": "n2", "acked_version": "1", "up_to_date": false}
+		}
+	}]`, responseRecorder.Body.String())
+}
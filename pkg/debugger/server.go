@@ -20,7 +20,11 @@ func (ds DebugConfig) GetHandlers() map[string]http.Handler {
 		"/debug/policies":      ds.getSMIPoliciesHandler(),
 		"/debug/config":        ds.getOSMConfigHandler(),
 		"/debug/namespaces":    ds.getMonitoredNamespacesHandler(),
+		"/debug/pin":           ds.getPinHandler(),
+		"/debug/proxy-status":  ds.getProxyConfigStatusHandler(),
 		"/debug/feature-flags": ds.getFeatureFlags(),
+		"/debug/policy-diff":   ds.getPolicyDiffHandler(),
+		"/debug/topology":      ds.getMeshTopologyHandler(),
 
 		// Pprof handlers
 		"/debug/pprof/":        http.HandlerFunc(pprof.Index),
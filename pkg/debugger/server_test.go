@@ -40,6 +40,9 @@ func TestGetHandlers(t *testing.T) {
 		"/debug/policies",
 		"/debug/config",
 		"/debug/namespaces",
+		"/debug/pin",
+		"/debug/proxy-status",
+		"/debug/topology",
 		// Pprof handlers
 		"/debug/pprof/",
 		"/debug/pprof/cmdline",
@@ -0,0 +1,77 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// permissiveMeshTopology marks the mesh topology of a mesh in permissive traffic policy mode, where any workload is
+// allowed to communicate with any service
+const permissiveMeshTopology = "all-to-all"
+
+// meshTopology is the graph of the communications allowed in the mesh
+type meshTopology struct {
+	Permissive string             `json:"permissive,omitempty"`
+	Nodes      []meshTopologyNode `json:"nodes"`
+	Edges      []meshTopologyEdge `json:"edges"`
+}
+
+// meshTopologyNode is a service, or a source service account without services, of the form <namespace>/<name>
+type meshTopologyNode struct {
+	Kind trafficpolicy.MeshTopologyNodeKind `json:"kind"`
+	Name string                             `json:"name"`
+}
+
+// meshTopologyEdge is a communication from a source node to a destination service, both of the form
+// <namespace>/<name>, allowed by a TrafficTarget of the form <namespace>/<name>
+type meshTopologyEdge struct {
+	Source        string                             `json:"source"`
+	SourceKind    trafficpolicy.MeshTopologyNodeKind `json:"source_kind"`
+	Destination   string                             `json:"destination"`
+	TrafficTarget string                             `json:"traffic_target"`
+}
+
+// getMeshTopologyHandler returns a handler listing the communications allowed by the live SMI TrafficTarget policies,
+// as the nodes and edges of a graph. In permissive traffic policy mode, the graph is marked as all-to-all and lists
+// no nodes or edges.
+func (ds DebugConfig) getMeshTopologyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		topology := meshTopology{Nodes: []meshTopologyNode{}, Edges: []meshTopologyEdge{}}
+		meshTopo := ds.meshCatalogDebugger.GetMeshTopology()
+		if meshTopo.Permissive {
+			topology.Permissive = permissiveMeshTopology
+		}
+
+		nodes := make(map[trafficpolicy.MeshTopologyNode]bool)
+		addNode := func(node trafficpolicy.MeshTopologyNode) {
+			if nodes[node] {
+				return
+			}
+			nodes[node] = true
+			topology.Nodes = append(topology.Nodes, meshTopologyNode{Kind: node.Kind, Name: node.String()})
+		}
+		for _, edge := range meshTopo.Edges {
+			addNode(edge.Source)
+			addNode(edge.Destination)
+			topology.Edges = append(topology.Edges, meshTopologyEdge{
+				Source:        edge.Source.String(),
+				SourceKind:    edge.Source.Kind,
+				Destination:   edge.Destination.String(),
+				TrafficTarget: edge.TrafficTarget,
+			})
+		}
+
+		jsonTopology, err := json.Marshal(topology)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling mesh topology %+v", topology)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonTopology))
+	})
+}
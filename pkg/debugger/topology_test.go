@@ -0,0 +1,60 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// Tests getMeshTopologyHandler returns the allowed communications as the nodes and edges of a graph
+func TestMeshTopologyHandler(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mock := NewMockMeshCatalogDebugger(mockCtrl)
+
+	ds := DebugConfig{
+		meshCatalogDebugger: mock,
+	}
+
+	bookbuyerNode := trafficpolicy.MeshTopologyNode{Kind: trafficpolicy.MeshTopologyServiceNode, Namespace: "default", Name: "bookbuyer"}
+	bookthiefNode := trafficpolicy.MeshTopologyNode{Kind: trafficpolicy.MeshTopologyServiceAccountNode, Namespace: "default", Name: "bookthief"}
+	bookstoreNode := trafficpolicy.MeshTopologyNode{Kind: trafficpolicy.MeshTopologyServiceNode, Namespace: "default", Name: "bookstore-v1"}
+	mock.EXPECT().GetMeshTopology().Return(trafficpolicy.MeshTopology{Edges: []trafficpolicy.MeshTopologyEdge{
+		{
+			Source:        bookbuyerNode,
+			Destination:   bookstoreNode,
+			TrafficTarget: "default/bookbuyer-access-bookstore",
+		},
+		{
+			Source:        bookthiefNode,
+			Destination:   bookstoreNode,
+			TrafficTarget: "default/bookbuyer-access-bookstore",
+		},
+	}})
+
+	responseRecorder := httptest.NewRecorder()
+	ds.getMeshTopologyHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/topology", nil))
+	assert.Equal("application/json", responseRecorder.Header().Get("Content-Type"))
+	assert.Equal(`{"nodes":[{"kind":"service","name":"default/bookbuyer"},{"kind":"service","name":"default/bookstore-v1"},{"kind":"service_account","name":"default/bookthief"}],`+
+		`"edges":[{"source":"default/bookbuyer","source_kind":"service","destination":"default/bookstore-v1","traffic_target":"default/bookbuyer-access-bookstore"},`+
+		`{"source":"default/bookthief","source_kind":"service_account","destination":"default/bookstore-v1","traffic_target":"default/bookbuyer-access-bookstore"}]}`, responseRecorder.Body.String())
+
+	// No edges are listed as an empty list rather than null
+	mock.EXPECT().GetMeshTopology().Return(trafficpolicy.MeshTopology{})
+
+	responseRecorder = httptest.NewRecorder()
+	ds.getMeshTopologyHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/topology", nil))
+	assert.Equal(`{"nodes":[],"edges":[]}`, responseRecorder.Body.String())
+
+	// The topology of a mesh in permissive mode is marked as all-to-all
+	mock.EXPECT().GetMeshTopology().Return(trafficpolicy.MeshTopology{Permissive: true})
+
+	responseRecorder = httptest.NewRecorder()
+	ds.getMeshTopologyHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/topology", nil))
+	assert.Equal(`{"permissive":"all-to-all","nodes":[],"edges":[]}`, responseRecorder.Body.String())
+}
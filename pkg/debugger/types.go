@@ -9,12 +9,15 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var log = logger.New("debugger")
@@ -52,10 +55,28 @@ type MeshCatalogDebugger interface {
 
 	// ListMonitoredNamespaces lists the namespaces that the control plan knows about.
 	ListMonitoredNamespaces() []string
+
+	// GetMeshTopology returns the graph of the communications allowed by the SMI TrafficTarget policies.
+	GetMeshTopology() trafficpolicy.MeshTopology
+
+	// NewCandidateCatalog returns a mesh catalog with the given candidate SMI policies overlaid on the live SMI policies.
+	NewCandidateCatalog(smi.CandidatePolicies) catalog.MeshCataloger
 }
 
 // XDSDebugger is an interface providing debugging server with methods introspecting XDS.
 type XDSDebugger interface {
 	// GetXDSLog returns a log of the XDS responses sent to Envoy proxies.
 	GetXDSLog() *map[certificate.CommonName]map[envoy.TypeURI][]time.Time
+
+	// PinProxyConfig pins the proxy with the given certificate CN to its last acknowledged config.
+	PinProxyConfig(certificate.CommonName) error
+
+	// UnpinProxyConfig unpins the proxy with the given certificate CN, returning false if it was not pinned.
+	UnpinProxyConfig(certificate.CommonName) bool
+
+	// ListPinnedProxies returns the pinned proxies and the time their pin expires.
+	ListPinnedProxies() map[certificate.CommonName]time.Time
+
+	// ListProxyConfigStatus returns the status of the config of the proxies connected to the XDS server.
+	ListProxyConfigStatus() []envoy.ProxyConfigStatus
 }
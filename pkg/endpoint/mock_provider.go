@@ -49,6 +49,20 @@ func (mr *MockProviderMockRecorder) ListEndpointsForService(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpointsForService", reflect.TypeOf((*MockProvider)(nil).ListEndpointsForService), arg0)
 }
 
+// ListAllEndpointsForService mocks base method
+func (m *MockProvider) ListAllEndpointsForService(arg0 service.MeshService) []Endpoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllEndpointsForService", arg0)
+	ret0, _ := ret[0].([]Endpoint)
+	return ret0
+}
+
+// ListAllEndpointsForService indicates an expected call of ListAllEndpointsForService
+func (mr *MockProviderMockRecorder) ListAllEndpointsForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllEndpointsForService", reflect.TypeOf((*MockProvider)(nil).ListAllEndpointsForService), arg0)
+}
+
 // GetServicesForServiceAccount mocks base method
 func (m *MockProvider) GetServicesForServiceAccount(arg0 service.K8sServiceAccount) ([]service.MeshService, error) {
 	m.ctrl.T.Helper()
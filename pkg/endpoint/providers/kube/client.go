@@ -21,6 +21,7 @@ func NewProvider(kubeClient kubernetes.Interface, kubeController k8s.Controller,
 		providerIdent:  providerIdent,
 		kubeClient:     kubeClient,
 		kubeController: kubeController,
+		cfg:            cfg,
 	}
 
 	return &client, nil
@@ -34,6 +35,18 @@ func (c *Client) GetID() string {
 
 // ListEndpointsForService retrieves the list of IP addresses for the given service
 func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
+	return c.listEndpointsForService(svc, false)
+}
+
+// ListAllEndpointsForService retrieves the list of IP addresses for the given service, including the addresses of the
+// instances that are not ready, flagged NotReady
+func (c Client) ListAllEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
+	return c.listEndpointsForService(svc, true)
+}
+
+// listEndpointsForService retrieves the list of IP addresses for the given service, including the addresses that are
+// not ready if requested
+func (c Client) listEndpointsForService(svc service.MeshService, includeNotReady bool) []endpoint.Endpoint {
 	log.Trace().Msgf("[%s] Getting Endpoints for service %s on Kubernetes", c.providerIdent, svc)
 	var endpoints []endpoint.Endpoint
 
@@ -48,25 +61,85 @@ func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endp
 		return endpoints
 	}
 
+	servicePorts := getServicePortsByName(c.kubeController.GetService(svc))
 	for _, kubernetesEndpoint := range kubernetesEndpoints.Subsets {
-		for _, address := range kubernetesEndpoint.Addresses {
-			for _, port := range kubernetesEndpoint.Ports {
-				ip := net.ParseIP(address.IP)
-				if ip == nil {
-					log.Error().Msgf("[%s] Error parsing IP address %s", c.providerIdent, address.IP)
-					break
-				}
-				ept := endpoint.Endpoint{
-					IP:   ip,
-					Port: endpoint.Port(port.Port),
-				}
-				endpoints = append(endpoints, ept)
+		endpoints = append(endpoints, c.getEndpointsForAddresses(kubernetesEndpoint.Addresses, kubernetesEndpoint.Ports, servicePorts, false)...)
+		if includeNotReady {
+			endpoints = append(endpoints, c.getEndpointsForAddresses(kubernetesEndpoint.NotReadyAddresses, kubernetesEndpoint.Ports, servicePorts, true)...)
+		}
+	}
+	return endpoints
+}
+
+// getServicePortsByName returns the ports of the given Kubernetes service keyed by name. The ports of the Endpoints of a
+// service are named after the ports of the service they are the target ports of.
+func getServicePortsByName(k8sSvc *corev1.Service) map[string]endpoint.Port {
+	if k8sSvc == nil {
+		return nil
+	}
+	servicePorts := make(map[string]endpoint.Port)
+	for _, port := range k8sSvc.Spec.Ports {
+		servicePorts[port.Name] = endpoint.Port(port.Port)
+	}
+	return servicePorts
+}
+
+// getEndpointsForAddresses returns the endpoints for each of the given addresses and ports of a Kubernetes Endpoints
+// subset, mapping each port to the given service port of the same name
+func (c Client) getEndpointsForAddresses(addresses []corev1.EndpointAddress, ports []corev1.EndpointPort, servicePorts map[string]endpoint.Port, notReady bool) []endpoint.Endpoint {
+	var endpoints []endpoint.Endpoint
+	for _, address := range addresses {
+		zone, metadata := c.getNodeLocality(address.NodeName)
+		for _, port := range ports {
+			ip := net.ParseIP(address.IP)
+			if ip == nil {
+				log.Error().Msgf("[%s] Error parsing IP address %s", c.providerIdent, address.IP)
+				break
+			}
+			ept := endpoint.Endpoint{
+				IP:          ip,
+				Port:        endpoint.Port(port.Port),
+				ServicePort: servicePorts[port.Name],
+				NotReady:    notReady,
+				Zone:        zone,
+				Metadata:    metadata,
 			}
+			endpoints = append(endpoints, ept)
 		}
 	}
 	return endpoints
 }
 
+// getNodeLocality returns the zone of the node with the given name and the metadata of the endpoints running on it,
+// made of the node's labels selected by the mesh config. The zone is empty if unknown.
+func (c Client) getNodeLocality(nodeName *string) (string, map[string]string) {
+	if nodeName == nil {
+		return "", nil
+	}
+	node := c.kubeController.GetNode(*nodeName)
+	if node == nil {
+		return "", nil
+	}
+
+	zone := node.Labels[corev1.LabelZoneFailureDomainStable]
+	if zone == "" {
+		zone = node.Labels[corev1.LabelZoneFailureDomain]
+	}
+
+	var metadata map[string]string
+	for _, label := range c.cfg.GetEndpointMetadataNodeLabels() {
+		value, ok := node.Labels[label]
+		if !ok {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[label] = value
+	}
+	return zone, metadata
+}
+
 // GetServicesForServiceAccount retrieves a list of services for the given service account.
 func (c Client) GetServicesForServiceAccount(svcAccount service.K8sServiceAccount) ([]service.MeshService, error) {
 	services := mapset.NewSet()
@@ -57,6 +57,7 @@ var _ = Describe("Test Kube Client Provider (w/o kubecontroller)", func() {
 
 	It("should correctly return a list of endpoints for a service", func() {
 		// Should be empty for now
+		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(nil)
 		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: tests.BookbuyerService.Namespace,
@@ -85,6 +86,174 @@ var _ = Describe("Test Kube Client Provider (w/o kubecontroller)", func() {
 		}))
 	})
 
+	It("should not return the endpoints that are not ready", func() {
+		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(nil)
+		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: tests.BookbuyerService.Namespace,
+			},
+			Subsets: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{
+						{
+							IP: "8.8.8.8",
+						},
+					},
+					NotReadyAddresses: []v1.EndpointAddress{
+						{
+							IP: "9.9.9.9",
+						},
+					},
+					Ports: []v1.EndpointPort{
+						{
+							Port: 88,
+						},
+					},
+				},
+			},
+		}, nil)
+
+		Expect(provider.ListEndpointsForService(tests.BookbuyerService)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:   net.IPv4(8, 8, 8, 8),
+				Port: 88,
+			},
+		}))
+	})
+
+	It("should return all the endpoints with the ones that are not ready flagged as not ready", func() {
+		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(nil)
+		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: tests.BookbuyerService.Namespace,
+			},
+			Subsets: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{
+						{
+							IP: "8.8.8.8",
+						},
+					},
+					NotReadyAddresses: []v1.EndpointAddress{
+						{
+							IP: "9.9.9.9",
+						},
+					},
+					Ports: []v1.EndpointPort{
+						{
+							Port: 88,
+						},
+					},
+				},
+			},
+		}, nil)
+
+		Expect(provider.ListAllEndpointsForService(tests.BookbuyerService)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:   net.IPv4(8, 8, 8, 8),
+				Port: 88,
+			},
+			{
+				IP:       net.IPv4(9, 9, 9, 9),
+				Port:     88,
+				NotReady: true,
+			},
+		}))
+	})
+
+	It("should return the zone and the selected node labels of the endpoints", func() {
+		nodeName := "node-1"
+		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(nil)
+		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: tests.BookbuyerService.Namespace,
+			},
+			Subsets: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{
+						{
+							IP:       "8.8.8.8",
+							NodeName: &nodeName,
+						},
+					},
+					Ports: []v1.EndpointPort{
+						{
+							Port: 88,
+						},
+					},
+				},
+			},
+		}, nil)
+		mockKubeController.EXPECT().GetNode(nodeName).Return(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+				Labels: map[string]string{
+					corev1.LabelZoneFailureDomainStable: "us-east-1a",
+					corev1.LabelInstanceTypeStable:      "m5.large",
+					corev1.LabelOSStable:                "linux",
+				},
+			},
+		}).Times(1)
+		mockConfigurator.EXPECT().GetEndpointMetadataNodeLabels().Return([]string{corev1.LabelInstanceTypeStable, "missing"}).Times(1)
+
+		Expect(provider.ListEndpointsForService(tests.BookbuyerService)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:       net.IPv4(8, 8, 8, 8),
+				Port:     88,
+				Zone:     "us-east-1a",
+				Metadata: map[string]string{corev1.LabelInstanceTypeStable: "m5.large"},
+			},
+		}))
+	})
+
+	It("should map the ports of the endpoints to the ports of the service of the same name", func() {
+		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(&corev1.Service{
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "tcp-db", Port: 5432},
+					{Name: "tcp-admin", Port: 9000},
+				},
+			},
+		})
+		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: tests.BookbuyerService.Namespace,
+			},
+			Subsets: []v1.EndpointSubset{
+				{
+					Addresses: []v1.EndpointAddress{
+						{
+							IP: "8.8.8.8",
+						},
+					},
+					Ports: []v1.EndpointPort{
+						{
+							Name: "tcp-db",
+							Port: 15432,
+						},
+						{
+							Name: "tcp-admin",
+							Port: 19000,
+						},
+					},
+				},
+			},
+		}, nil)
+
+		Expect(provider.ListEndpointsForService(tests.BookbuyerService)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:          net.IPv4(8, 8, 8, 8),
+				Port:        15432,
+				ServicePort: 5432,
+			},
+			{
+				IP:          net.IPv4(8, 8, 8, 8),
+				Port:        19000,
+				ServicePort: 9000,
+			},
+		}))
+	})
+
 	It("GetResolvableEndpoints should properly return endpoints based on ClusterIP when set", func() {
 		// If the service has cluster IP, expect the cluster IP + port
 		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(&corev1.Service{
@@ -115,7 +284,7 @@ var _ = Describe("Test Kube Client Provider (w/o kubecontroller)", func() {
 
 	It("GetResolvableEndpoints should properly return actual endpoints without ClusterIP when ClusterIP is not set", func() {
 		// Expect the individual pod endpoints, when no cluster IP is assigned to the service
-		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(&corev1.Service{
+		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Times(2).Return(&corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      tests.BookbuyerService.Name,
 				Namespace: tests.BookbuyerService.Namespace,
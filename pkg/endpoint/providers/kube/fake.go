@@ -39,6 +39,11 @@ func (f fakeClient) ListEndpointsForService(svc service.MeshService) []endpoint.
 	panic(fmt.Sprintf("You are asking for MeshService=%s but the fake Kubernetes client has not been initialized with this. What we have is: %+v", svc.String(), f.endpoints))
 }
 
+// Retrieve the IP addresses comprising the given service, including the ones not ready.
+func (f fakeClient) ListAllEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
+	return f.ListEndpointsForService(svc)
+}
+
 func (f fakeClient) GetServicesForServiceAccount(svcAccount service.K8sServiceAccount) ([]service.MeshService, error) {
 	services, ok := f.services[svcAccount]
 	if !ok {
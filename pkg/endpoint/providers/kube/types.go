@@ -3,6 +3,7 @@ package kube
 import (
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
@@ -16,4 +17,5 @@ type Client struct {
 	providerIdent  string
 	kubeClient     kubernetes.Interface
 	kubeController k8s.Controller
+	cfg            configurator.Configurator
 }
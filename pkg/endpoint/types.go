@@ -12,6 +12,10 @@ type Provider interface {
 	// Retrieve the IP addresses comprising the given service.
 	ListEndpointsForService(service.MeshService) []Endpoint
 
+	// ListAllEndpointsForService retrieves the IP addresses comprising the given service, including the instances not
+	// ready to serve traffic, which are flagged NotReady
+	ListAllEndpointsForService(service.MeshService) []Endpoint
+
 	// Retrieve the namespaced services for a given service account
 	GetServicesForServiceAccount(service.K8sServiceAccount) ([]service.MeshService, error)
 
@@ -30,6 +34,18 @@ type Provider interface {
 type Endpoint struct {
 	net.IP `json:"ip"`
 	Port   `json:"port"`
+
+	// ServicePort is the port of the service that maps to the port of the instance, or 0 if unknown
+	ServicePort Port `json:"service_port,omitempty"`
+
+	// NotReady is set when the instance of the service is not ready to serve traffic
+	NotReady bool `json:"not_ready,omitempty"`
+
+	// Zone is the locality zone of the instance of the service, or empty if unknown
+	Zone string `json:"zone,omitempty"`
+
+	// Metadata is the metadata of the instance of the service used for subset load balancing
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 func (ep Endpoint) String() string {
@@ -0,0 +1,315 @@
+package ads
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+// deltaResourceVersions maps the name of each resource of a given xDS type to the version last sent to a proxy
+type deltaResourceVersions map[string]string
+
+// deltaSubscription is the state of a proxy's subscription to the resources of a given xDS type
+type deltaSubscription struct {
+	// sent holds the versions of the resources last sent to the proxy
+	sent deltaResourceVersions
+
+	// nonce and version of the delta response last sent to the proxy, used to record the config applied by the proxy
+	// when it ACKs the response
+	nonce   string
+	version uint64
+}
+
+// DeltaAggregatedResources handles incremental (delta) xDS streams from the connected Envoy proxies.
+// The full set of resources for a given xDS type is computed by the same handlers used for state-of-the-world
+// xDS, and only the resources which were added, changed, or removed since the last response are sent to the proxy.
+func (s *Server) DeltaAggregatedResources(server xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
+	certCommonName, certSerialNumber, err := utils.ValidateClient(server.Context(), nil)
+	if err != nil {
+		return errors.Wrap(err, "Could not start Delta Aggregated Discovery Service gRPC stream for newly connected Envoy proxy")
+	}
+
+	log.Trace().Msgf("Envoy with certificate SerialNumber=%s connected over Delta xDS", certSerialNumber)
+	metricsstore.DefaultMetricsStore.ProxyConnectCount.Inc()
+	defer metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
+
+	proxy := envoy.NewProxy(certCommonName, certSerialNumber, utils.GetIPFromContext(server.Context()))
+	s.catalog.RegisterProxy(proxy) // First of Two invocations.  Second one will be during xDS hand-shake!
+
+	defer s.catalog.UnregisterProxy(proxy)
+	defer s.configPins.forget(proxy)
+	defer s.rememberDisconnectedProxy(proxy)
+	defer s.forgetPersistedResponses(proxy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	quit := make(chan struct{})
+	requests := make(chan *xds_discovery.DeltaDiscoveryRequest)
+
+	go receiveDelta(requests, server, proxy, quit, s.catalog)
+
+	// Register to Envoy global broadcast updates
+	broadcastUpdate := events.GetPubSubInstance().Subscribe(announcements.ProxyBroadcast)
+	defer events.GetPubSubInstance().Unsub(broadcastUpdate)
+
+	// A proxy reconnecting within the grace period resumes from its previous config versions and pinned config. The
+	// resources it already has are reported by the proxy in its initial resource versions, so only changed resources
+	// are sent to it.
+	if s.restoreReconnectedProxy(proxy) {
+		log.Debug().Msgf("Proxy with SerialNumber=%s reconnected over Delta xDS, resuming from its previous config state", proxy.GetCertificateSerialNumber())
+	}
+
+	// The subscriptions of the proxy, for each xDS type the proxy subscribed to
+	subscriptions := make(map[envoy.TypeURI]*deltaSubscription)
+
+	// Tracks whether the pod's Envoy config ACK readiness condition has been set
+	podConfigAcked := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-quit:
+			log.Debug().Msgf("Delta gRPC stream with Envoy on Pod with UID=%s closed!", proxy.GetPodUID())
+			return nil
+
+		case request, ok := <-requests:
+			if !ok {
+				log.Error().Msgf("Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s closed Delta gRPC!", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+				return errGrpcClosed
+			}
+
+			if request.ErrorDetail != nil {
+				log.Error().Msgf("[NACK] DeltaDiscoveryRequest error from Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s: %s",
+					proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), request.ErrorDetail)
+				continue
+			}
+
+			typeURI, ok := envoy.ValidURI[request.TypeUrl]
+			if !ok {
+				log.Error().Msgf("Unknown/Unsupported URI: %s", request.TypeUrl)
+				continue
+			}
+
+			subscription, subscribed := subscriptions[typeURI]
+			if subscribed && request.ResponseNonce != "" {
+				// ACK of a previously sent response
+				log.Debug().Msgf("[ACK] Delta %s response with Nonce=%s from Envoy on Pod with UID=%s",
+					envoy.XDSShortURINames[typeURI], request.ResponseNonce, proxy.GetPodUID())
+				s.recordDeltaAck(proxy, typeURI, subscription, request.ResponseNonce, &podConfigAcked)
+				if len(request.ResourceNamesSubscribe) == 0 && len(request.ResourceNamesUnsubscribe) == 0 {
+					continue
+				}
+			}
+
+			if !subscribed {
+				// Resources the proxy already has, e.g. after reconnecting to the control plane, need not be sent again
+				subscription = &deltaSubscription{
+					sent: make(deltaResourceVersions, len(request.InitialResourceVersions)),
+				}
+				for name, version := range request.InitialResourceVersions {
+					subscription.sent[name] = version
+				}
+				subscriptions[typeURI] = subscription
+			}
+			for _, name := range request.ResourceNamesUnsubscribe {
+				delete(subscription.sent, name)
+			}
+
+			// Always respond to a new subscription, even when the proxy is already up to date
+			if err := s.sendDeltaResponse(typeURI, proxy, server, subscription, !subscribed); err != nil {
+				log.Error().Err(err).Msgf("Failed to create and send delta %s update to Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s",
+					envoy.XDSShortURINames[typeURI], proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+			}
+
+		case broadcastMessage := <-broadcastUpdate:
+			typeURIs := getBroadcastTypeURIs(broadcastMessage)
+			log.Debug().Msgf("Broadcast update for %v received for Delta xDS Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s", typeURIs, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+			s.sendDeltaResponses(proxy, server, subscriptions, typeURIs...)
+
+		case <-proxy.GetAnnouncementsChannel():
+			log.Debug().Msgf("Individual update for Delta xDS Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+			s.sendDeltaResponses(proxy, server, subscriptions, envoy.XDSResponseOrder...)
+		}
+	}
+}
+
+// recordDeltaAck records the ACK of the delta response of the given xDS type with the given nonce. Delta requests do
+// not carry the version of the config applied by the proxy, so only the ACK of the response last sent over the given
+// subscription is recorded, as the version of that response is known. The ACK of an older response is superseded by
+// the ACK of the response sent after it.
+func (s *Server) recordDeltaAck(proxy *envoy.Proxy, typeURI envoy.TypeURI, subscription *deltaSubscription, nonce string, podConfigAcked *bool) {
+	if nonce != subscription.nonce {
+		log.Debug().Msgf("Ignoring ACK of stale delta %s response with Nonce=%s from Envoy on Pod with UID=%s",
+			envoy.XDSShortURINames[typeURI], nonce, proxy.GetPodUID())
+		return
+	}
+	s.recordAck(proxy, typeURI, subscription.version, nonce, podConfigAcked)
+}
+
+// sendDeltaResponses sends delta responses for the given xDS types the proxy subscribed to, in the order defined by envoy.XDSResponseOrder
+func (s *Server) sendDeltaResponses(proxy *envoy.Proxy, server xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer, subscriptions map[envoy.TypeURI]*deltaSubscription, typeURIs ...envoy.TypeURI) {
+	requestedTypeURIs := make(map[envoy.TypeURI]struct{}, len(typeURIs))
+	for _, typeURI := range typeURIs {
+		requestedTypeURIs[typeURI] = struct{}{}
+	}
+
+	success := true
+	defer xdsPathTimeTrack(time.Now(), ADSUpdateStr, proxy.GetCertificateCommonName().String(), &success)
+
+	for _, typeURI := range envoy.XDSResponseOrder {
+		subscription, subscribed := subscriptions[typeURI]
+		if _, ok := requestedTypeURIs[typeURI]; !ok || !subscribed {
+			continue
+		}
+
+		if err := s.sendDeltaResponse(typeURI, proxy, server, subscription, false); err != nil {
+			log.Error().Err(err).Msgf("Failed to create and send delta %s update to Proxy %s",
+				envoy.XDSShortURINames[typeURI], proxy.GetCertificateCommonName())
+			success = false
+		}
+	}
+}
+
+// sendDeltaResponse computes the full set of resources of the given xDS type for the proxy, and sends the proxy
+// the resources that changed since they were last sent along with the names of the removed resources.
+// The subscription is updated in place. No response is sent when nothing changed, unless force is set.
+func (s *Server) sendDeltaResponse(typeURI envoy.TypeURI, proxy *envoy.Proxy, server xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer, subscription *deltaSubscription, force bool) error {
+	success := false
+	xdsShortName := envoy.XDSShortURINames[typeURI]
+	defer xdsPathTimeTrack(time.Now(), xdsShortName, proxy.GetCertificateCommonName().String(), &success)
+
+	// For SDS we need to add ResourceNames
+	request := &xds_discovery.DiscoveryRequest{TypeUrl: string(typeURI)}
+	if typeURI == envoy.TypeSDS {
+		if request = makeRequestForAllSecrets(proxy, s.catalog); request == nil {
+			return errCreatingResponse
+		}
+	}
+
+	discoveryResponse, err := s.newAggregatedDiscoveryResponse(proxy, request, s.cfg)
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Failed to create delta response for proxy with SerialNumber=%s on Pod with UID=%s", xdsShortName, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return err
+	}
+
+	deltaResponse, err := newDeltaDiscoveryResponse(discoveryResponse, subscription.sent)
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Failed to compute delta response for proxy with SerialNumber=%s on Pod with UID=%s", xdsShortName, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return err
+	}
+
+	if !force && len(deltaResponse.Resources) == 0 && len(deltaResponse.RemovedResources) == 0 {
+		log.Trace().Msgf("[%s] No changes to send to proxy with SerialNumber=%s on Pod with UID=%s", xdsShortName, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		success = true
+		return nil
+	}
+
+	if err := server.Send(deltaResponse); err != nil {
+		log.Error().Err(err).Msgf("[%s] Error sending delta response to proxy with SerialNumber=%s on Pod with UID=%s", xdsShortName, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return err
+	}
+
+	// Only record what was sent once the response made it to the proxy
+	for _, name := range deltaResponse.RemovedResources {
+		delete(subscription.sent, name)
+	}
+	for _, resource := range deltaResponse.Resources {
+		subscription.sent[resource.Name] = resource.Version
+	}
+	subscription.nonce = deltaResponse.Nonce
+	if subscription.version, err = strconv.ParseUint(deltaResponse.SystemVersionInfo, 10, 64); err != nil {
+		log.Error().Err(err).Msgf("[%s] Error parsing version %s of delta response sent to proxy with SerialNumber=%s on Pod with UID=%s", xdsShortName, deltaResponse.SystemVersionInfo, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+	}
+
+	success = true // read by deferred function
+	return nil
+}
+
+// newDeltaDiscoveryResponse returns a delta response holding the resources of the given state-of-the-world response
+// which differ from the previously sent resources, and the names of the previously sent resources no longer present.
+func newDeltaDiscoveryResponse(response *xds_discovery.DiscoveryResponse, sent deltaResourceVersions) (*xds_discovery.DeltaDiscoveryResponse, error) {
+	deltaResponse := &xds_discovery.DeltaDiscoveryResponse{
+		TypeUrl:           response.TypeUrl,
+		SystemVersionInfo: response.VersionInfo,
+		Nonce:             response.Nonce,
+	}
+
+	current := make(map[string]struct{}, len(response.Resources))
+	for _, resource := range response.Resources {
+		var message ptypes.DynamicAny
+		if err := ptypes.UnmarshalAny(resource, &message); err != nil {
+			return nil, err
+		}
+
+		name := cachev3.GetResourceName(message.Message)
+		marshalled, err := cachev3.MarshalResource(message.Message)
+		if err != nil {
+			return nil, err
+		}
+		version := fmt.Sprintf("%x", sha256.Sum256(marshalled))
+
+		current[name] = struct{}{}
+		if sent[name] == version {
+			continue
+		}
+		deltaResponse.Resources = append(deltaResponse.Resources, &xds_discovery.Resource{
+			Name:     name,
+			Version:  version,
+			Resource: resource,
+		})
+	}
+
+	for name := range sent {
+		if _, ok := current[name]; !ok {
+			deltaResponse.RemovedResources = append(deltaResponse.RemovedResources, name)
+		}
+	}
+
+	return deltaResponse, nil
+}
+
+func receiveDelta(requests chan *xds_discovery.DeltaDiscoveryRequest, server xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer, proxy *envoy.Proxy, quit chan struct{}, catalog catalog.MeshCataloger) {
+	defer close(requests)
+	defer close(quit)
+	for {
+		request, recvErr := server.Recv()
+		if recvErr != nil {
+			if status.Code(recvErr) == codes.Canceled || recvErr == io.EOF {
+				log.Debug().Err(recvErr).Msgf("[grpc] Delta connection terminated")
+				return
+			}
+			log.Error().Err(recvErr).Msgf("[grpc] Delta connection error")
+			return
+		}
+		if request.TypeUrl == "" {
+			log.Warn().Msgf("[grpc] Received a delta request for an unknown TypeURL: %+v", request.TypeUrl)
+			continue
+		}
+		if !proxy.HasPodMetadata() {
+			// Set the Pod metadata on the given proxy only once. This could arrive with the first few XDS requests.
+			recordEnvoyPodMetadata(request.Node, proxy, catalog)
+		}
+		log.Trace().Msgf("[grpc] Received DeltaDiscoveryRequest from Envoy with certificate SerialNumber %s", proxy.GetCertificateSerialNumber())
+		requests <- request
+	}
+}
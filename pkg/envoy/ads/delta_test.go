@@ -0,0 +1,121 @@
+package ads
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+var _ = Describe("Test Delta xDS", func() {
+	newClusterResponse := func(clusters ...*xds_cluster.Cluster) *xds_discovery.DiscoveryResponse {
+		response := &xds_discovery.DiscoveryResponse{
+			TypeUrl:     string(envoy.TypeCDS),
+			VersionInfo: "1",
+			Nonce:       "nonce",
+		}
+		for _, cluster := range clusters {
+			marshalled, err := ptypes.MarshalAny(cluster)
+			Expect(err).ToNot(HaveOccurred())
+			response.Resources = append(response.Resources, marshalled)
+		}
+		return response
+	}
+
+	Context("Test newDeltaDiscoveryResponse()", func() {
+		It("sends all resources when none were sent before", func() {
+			response := newClusterResponse(&xds_cluster.Cluster{Name: "a"}, &xds_cluster.Cluster{Name: "b"})
+
+			deltaResponse, err := newDeltaDiscoveryResponse(response, deltaResourceVersions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deltaResponse.TypeUrl).To(Equal(string(envoy.TypeCDS)))
+			Expect(deltaResponse.SystemVersionInfo).To(Equal("1"))
+			Expect(deltaResponse.Nonce).To(Equal("nonce"))
+			Expect(deltaResponse.Resources).To(HaveLen(2))
+			Expect(deltaResponse.Resources[0].Name).To(Equal("a"))
+			Expect(deltaResponse.Resources[1].Name).To(Equal("b"))
+			Expect(deltaResponse.RemovedResources).To(BeEmpty())
+		})
+
+		It("sends only added and changed resources, and the names of removed resources", func() {
+			initial, err := newDeltaDiscoveryResponse(newClusterResponse(
+				&xds_cluster.Cluster{Name: "unchanged"},
+				&xds_cluster.Cluster{Name: "changed"},
+				&xds_cluster.Cluster{Name: "removed"},
+			), deltaResourceVersions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			sent := deltaResourceVersions{}
+			for _, resource := range initial.Resources {
+				sent[resource.Name] = resource.Version
+			}
+
+			deltaResponse, err := newDeltaDiscoveryResponse(newClusterResponse(
+				&xds_cluster.Cluster{Name: "unchanged"},
+				&xds_cluster.Cluster{Name: "changed", AltStatName: "changed"},
+				&xds_cluster.Cluster{Name: "added"},
+			), sent)
+			Expect(err).ToNot(HaveOccurred())
+
+			var names []string
+			for _, resource := range deltaResponse.Resources {
+				names = append(names, resource.Name)
+				Expect(resource.Version).ToNot(Equal(sent[resource.Name]))
+			}
+			Expect(names).To(ConsistOf("changed", "added"))
+			Expect(deltaResponse.RemovedResources).To(ConsistOf("removed"))
+		})
+
+		It("sends nothing when no resources changed", func() {
+			response := newClusterResponse(&xds_cluster.Cluster{Name: "a"})
+			initial, err := newDeltaDiscoveryResponse(response, deltaResourceVersions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			sent := deltaResourceVersions{initial.Resources[0].Name: initial.Resources[0].Version}
+			deltaResponse, err := newDeltaDiscoveryResponse(response, sent)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deltaResponse.Resources).To(BeEmpty())
+			Expect(deltaResponse.RemovedResources).To(BeEmpty())
+		})
+	})
+
+	Context("Test recordDeltaAck()", func() {
+		var (
+			mockCtrl *gomock.Controller
+			s        *Server
+			proxy    *envoy.Proxy
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			s = &Server{cfg: configurator.NewMockConfigurator(mockCtrl), configPins: newProxyConfigPins()}
+			proxy = envoy.NewProxy(certificate.CommonName("proxy.sa.ns.cluster.local"), "", nil)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("records the version of the last sent response as applied when it is ACKed", func() {
+			podConfigAcked := false
+			subscription := &deltaSubscription{sent: deltaResourceVersions{}, nonce: "nonce-2", version: 2}
+
+			s.recordDeltaAck(proxy, envoy.TypeCDS, subscription, "nonce-2", &podConfigAcked)
+			Expect(proxy.GetLastAppliedVersion(envoy.TypeCDS)).To(Equal(uint64(2)))
+		})
+
+		It("ignores the ACK of a response sent before the last one", func() {
+			podConfigAcked := false
+			subscription := &deltaSubscription{sent: deltaResourceVersions{}, nonce: "nonce-2", version: 2}
+
+			s.recordDeltaAck(proxy, envoy.TypeCDS, subscription, "nonce-1", &podConfigAcked)
+			Expect(proxy.GetLastAppliedVersion(envoy.TypeCDS)).To(Equal(uint64(0)))
+		})
+	})
+})
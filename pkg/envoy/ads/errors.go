@@ -7,3 +7,6 @@ import (
 var errUnknownTypeURL = errors.New("unknown TypeUrl")
 var errCreatingResponse = errors.New("creating response")
 var errGrpcClosed = errors.New("grpc closed")
+var errProxyNotConnected = errors.New("proxy not connected")
+var errNoAckedConfig = errors.New("no acknowledged config")
+var errInvalidSnapshot = errors.New("invalid snapshot")
@@ -3,6 +3,7 @@ package ads
 import (
 	"io"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 
 	"google.golang.org/grpc/codes"
@@ -29,7 +30,7 @@ func receive(requests chan xds_discovery.DiscoveryRequest, server *xds_discovery
 		if request.TypeUrl != "" {
 			if !proxy.HasPodMetadata() {
 				// Set the Pod metadata on the given proxy only once. This could arrive with the first few XDS requests.
-				recordEnvoyPodMetadata(request, proxy, catalog)
+				recordEnvoyPodMetadata(request.Node, proxy, catalog)
 			}
 			log.Trace().Msgf("[grpc] Received DiscoveryRequest from Envoy with certificate SerialNumber %s", proxy.GetCertificateSerialNumber())
 			requests <- *request
@@ -39,10 +40,10 @@ func receive(requests chan xds_discovery.DiscoveryRequest, server *xds_discovery
 	}
 }
 
-func recordEnvoyPodMetadata(request *xds_discovery.DiscoveryRequest, proxy *envoy.Proxy, catalog catalog.MeshCataloger) {
-	if request != nil && request.Node != nil {
-		if meta, err := envoy.ParseEnvoyServiceNodeID(request.Node.Id); err != nil {
-			log.Error().Err(err).Msgf("Error parsing Envoy Node ID: %s", request.Node.Id)
+func recordEnvoyPodMetadata(node *xds_core.Node, proxy *envoy.Proxy, catalog catalog.MeshCataloger) {
+	if node != nil {
+		if meta, err := envoy.ParseEnvoyServiceNodeID(node.Id); err != nil {
+			log.Error().Err(err).Msgf("Error parsing Envoy Node ID: %s", node.Id)
 		} else {
 			log.Trace().Msgf("Recorded metadata for Envoy with xDS Certificate SerialNumber=%s: podUID=%s, podNamespace=%s, serviceAccountName=%s, envoyNodeID=%s",
 				proxy.GetCertificateSerialNumber(), meta.UID, meta.Namespace, meta.ServiceAccount, meta.EnvoyNodeID)
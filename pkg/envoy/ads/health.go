@@ -1,13 +1,39 @@
 package ads
 
 // Liveness is the Kubernetes liveness probe handler.
+// The xDS server is live until its gRPC server stops serving.
 func (s *Server) Liveness() bool {
-	return true
+	select {
+	case <-s.stopped:
+		log.Error().Msg("xDS gRPC server is no longer serving")
+		return false
+	default:
+		return true
+	}
 }
 
 // Readiness is the Kubernetes readiness probe handler.
+// The xDS server is ready once it is serving, the Kubernetes API server is reachable and the catalog has been populated.
 func (s *Server) Readiness() bool {
-	return s.ready
+	if !s.ready {
+		return false
+	}
+
+	if s.kubeClient == nil || s.kubeController == nil {
+		return false
+	}
+
+	if _, err := s.kubeClient.Discovery().ServerVersion(); err != nil {
+		log.Error().Err(err).Msg("xDS server is not ready: error connecting to the Kubernetes API server")
+		return false
+	}
+
+	if !s.kubeController.IsCacheSynced() {
+		log.Warn().Msg("xDS server is not ready: Kubernetes caches have not been populated")
+		return false
+	}
+
+	return true
 }
 
 // GetID returns the ID of the probe
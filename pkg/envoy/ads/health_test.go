@@ -0,0 +1,61 @@
+package ads
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/fake"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+var _ = Describe("Test xDS server health probes", func() {
+	var (
+		mockCtrl           *gomock.Controller
+		mockKubeController *k8s.MockController
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeController = k8s.NewMockController(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("Test Readiness()", func() {
+		It("is not ready before the server is started", func() {
+			s := NewADSServer(nil, false, "", nil, nil, fake.NewSimpleClientset(), mockKubeController)
+			Expect(s.Readiness()).To(BeFalse())
+		})
+
+		It("is not ready until the Kubernetes caches are populated", func() {
+			s := NewADSServer(nil, false, "", nil, nil, fake.NewSimpleClientset(), mockKubeController)
+			s.ready = true
+
+			mockKubeController.EXPECT().IsCacheSynced().Return(false).Times(1)
+			Expect(s.Readiness()).To(BeFalse())
+
+			mockKubeController.EXPECT().IsCacheSynced().Return(true).Times(1)
+			Expect(s.Readiness()).To(BeTrue())
+		})
+
+		It("is not ready without a Kubernetes client", func() {
+			s := NewADSServer(nil, false, "", nil, nil, nil, mockKubeController)
+			s.ready = true
+			Expect(s.Readiness()).To(BeFalse())
+		})
+	})
+
+	Context("Test Liveness()", func() {
+		It("is live until the gRPC server stops serving", func() {
+			s := NewADSServer(nil, false, "", nil, nil, fake.NewSimpleClientset(), mockKubeController)
+			s.stopped = make(chan struct{})
+			Expect(s.Liveness()).To(BeTrue())
+
+			close(s.stopped)
+			Expect(s.Liveness()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,241 @@
+package ads
+
+import (
+	"sync"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// proxyUpdateTimeout is how long to wait for a proxy's stream to accept an update once the proxy is unpinned
+const proxyUpdateTimeout = 5 * time.Second
+
+// proxyConfigPins keeps track of the config last sent to and acknowledged by each connected proxy, and of the proxies
+// pinned to their last acknowledged config. Pins are kept in memory only and expire after a TTL.
+type proxyConfigPins struct {
+	sync.Mutex
+
+	// proxies holds the config state of the connected proxies
+	proxies map[certificate.CommonName]*proxyConfigState
+
+	// pins holds the pinned proxies
+	pins map[certificate.CommonName]*proxyConfigPin
+}
+
+// proxyConfigState is the config last sent to and acknowledged by a proxy, per xDS type
+type proxyConfigState struct {
+	proxy *envoy.Proxy
+	sent  map[envoy.TypeURI]*xds_discovery.DiscoveryResponse
+	acked map[envoy.TypeURI]*xds_discovery.DiscoveryResponse
+}
+
+// proxyConfigPin is the config a proxy is pinned to
+type proxyConfigPin struct {
+	responses map[envoy.TypeURI]*xds_discovery.DiscoveryResponse
+	expiresAt time.Time
+	timer     *time.Timer
+}
+
+func newProxyConfigPins() *proxyConfigPins {
+	return &proxyConfigPins{
+		proxies: make(map[certificate.CommonName]*proxyConfigState),
+		pins:    make(map[certificate.CommonName]*proxyConfigPin),
+	}
+}
+
+// recordSent records the given response as the last one sent to the given proxy for the response's xDS type
+func (p *proxyConfigPins) recordSent(proxy *envoy.Proxy, typeURI envoy.TypeURI, response *xds_discovery.DiscoveryResponse) {
+	p.Lock()
+	defer p.Unlock()
+
+	state, ok := p.proxies[proxy.GetCertificateCommonName()]
+	if !ok || state.proxy != proxy {
+		state = &proxyConfigState{
+			proxy: proxy,
+			sent:  make(map[envoy.TypeURI]*xds_discovery.DiscoveryResponse),
+			acked: make(map[envoy.TypeURI]*xds_discovery.DiscoveryResponse),
+		}
+		p.proxies[proxy.GetCertificateCommonName()] = state
+	}
+	state.sent[typeURI] = response
+}
+
+// recordAck records the response last sent to the given proxy for the given xDS type as acknowledged by the proxy,
+// if the given nonce is the one of that response
+func (p *proxyConfigPins) recordAck(proxy *envoy.Proxy, typeURI envoy.TypeURI, nonce string) {
+	p.Lock()
+	defer p.Unlock()
+
+	state, ok := p.proxies[proxy.GetCertificateCommonName()]
+	if !ok || state.proxy != proxy {
+		return
+	}
+	if sent, ok := state.sent[typeURI]; ok && sent.Nonce == nonce {
+		state.acked[typeURI] = sent
+	}
+}
+
+// forget removes the config state of the given disconnected proxy. A pin on the proxy is kept, so the proxy remains
+// pinned when it reconnects.
+func (p *proxyConfigPins) forget(proxy *envoy.Proxy) {
+	p.Lock()
+	defer p.Unlock()
+
+	if state, ok := p.proxies[proxy.GetCertificateCommonName()]; ok && state.proxy == proxy {
+		delete(p.proxies, proxy.GetCertificateCommonName())
+	}
+}
+
+// getState returns the responses last sent to and acknowledged by the given proxy, per xDS type
+func (p *proxyConfigPins) getState(proxy *envoy.Proxy) (sent, acked map[envoy.TypeURI]*xds_discovery.DiscoveryResponse) {
+	p.Lock()
+	defer p.Unlock()
+
+	sent = make(map[envoy.TypeURI]*xds_discovery.DiscoveryResponse)
+	acked = make(map[envoy.TypeURI]*xds_discovery.DiscoveryResponse)
+	if state, ok := p.proxies[proxy.GetCertificateCommonName()]; ok && state.proxy == proxy {
+		for typeURI, response := range state.sent {
+			sent[typeURI] = response
+		}
+		for typeURI, response := range state.acked {
+			acked[typeURI] = response
+		}
+	}
+	return sent, acked
+}
+
+// setState sets the responses last sent to and acknowledged by the given proxy, per xDS type
+func (p *proxyConfigPins) setState(proxy *envoy.Proxy, sent, acked map[envoy.TypeURI]*xds_discovery.DiscoveryResponse) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.proxies[proxy.GetCertificateCommonName()] = &proxyConfigState{
+		proxy: proxy,
+		sent:  sent,
+		acked: acked,
+	}
+}
+
+// pin pins the proxy with the given certificate common name to its last acknowledged config for the given duration.
+// Secrets are not pinned, so that certificates keep being rotated.
+func (p *proxyConfigPins) pin(cn certificate.CommonName, ttl time.Duration) error {
+	p.Lock()
+	defer p.Unlock()
+
+	state, ok := p.proxies[cn]
+	if !ok {
+		return errProxyNotConnected
+	}
+
+	responses := make(map[envoy.TypeURI]*xds_discovery.DiscoveryResponse)
+	for typeURI, response := range state.acked {
+		if typeURI == envoy.TypeSDS {
+			continue
+		}
+		responses[typeURI] = response
+	}
+	if len(responses) == 0 {
+		return errNoAckedConfig
+	}
+
+	if existing, ok := p.pins[cn]; ok {
+		existing.timer.Stop()
+	}
+	p.pins[cn] = &proxyConfigPin{
+		responses: responses,
+		expiresAt: time.Now().Add(ttl),
+		timer: time.AfterFunc(ttl, func() {
+			log.Info().Msgf("Config pin of proxy with certificate CN=%s expired", cn)
+			p.unpin(cn)
+		}),
+	}
+	return nil
+}
+
+// unpin unpins the proxy with the given certificate common name and pushes the current config to the proxy if it is
+// connected. It returns false if the proxy was not pinned.
+func (p *proxyConfigPins) unpin(cn certificate.CommonName) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	pin, ok := p.pins[cn]
+	if !ok {
+		return false
+	}
+	pin.timer.Stop()
+	delete(p.pins, cn)
+
+	if state, ok := p.proxies[cn]; ok {
+		go notifyProxy(state.proxy)
+	}
+	return true
+}
+
+// getPinnedResponse returns a copy of the response of the given xDS type the given proxy is pinned to, or nil if the
+// proxy is not pinned
+func (p *proxyConfigPins) getPinnedResponse(proxy *envoy.Proxy, typeURI envoy.TypeURI) *xds_discovery.DiscoveryResponse {
+	p.Lock()
+	defer p.Unlock()
+
+	pin, ok := p.pins[proxy.GetCertificateCommonName()]
+	if !ok {
+		return nil
+	}
+	response, ok := pin.responses[typeURI]
+	if !ok {
+		return nil
+	}
+	return proto.Clone(response).(*xds_discovery.DiscoveryResponse)
+}
+
+// list returns the pinned proxies and the time their pin expires
+func (p *proxyConfigPins) list() map[certificate.CommonName]time.Time {
+	p.Lock()
+	defer p.Unlock()
+
+	pinned := make(map[certificate.CommonName]time.Time, len(p.pins))
+	for cn, pin := range p.pins {
+		pinned[cn] = pin.expiresAt
+	}
+	return pinned
+}
+
+// notifyProxy triggers an update of all the config of the given proxy
+func notifyProxy(proxy *envoy.Proxy) {
+	select {
+	case proxy.GetAnnouncementsChannel() <- announcements.Announcement{}:
+	case <-time.After(proxyUpdateTimeout):
+		log.Warn().Msgf("Timed out triggering a config update for proxy with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+	}
+}
+
+// PinProxyConfig pins the proxy with the given certificate common name to its last acknowledged config, so that
+// changes to the mesh are not pushed to the proxy until it is unpinned or the pin expires
+func (s *Server) PinProxyConfig(cn certificate.CommonName) error {
+	ttl := s.cfg.GetProxyConfigPinTTL()
+	if err := s.configPins.pin(cn, ttl); err != nil {
+		return err
+	}
+	log.Info().Msgf("Pinned proxy with certificate CN=%s to its last acknowledged config for %s", cn, ttl)
+	return nil
+}
+
+// UnpinProxyConfig unpins the proxy with the given certificate common name, resuming config updates to the proxy.
+// It returns false if the proxy was not pinned.
+func (s *Server) UnpinProxyConfig(cn certificate.CommonName) bool {
+	if !s.configPins.unpin(cn) {
+		return false
+	}
+	log.Info().Msgf("Unpinned proxy with certificate CN=%s", cn)
+	return true
+}
+
+// ListPinnedProxies returns the proxies pinned to their last acknowledged config and the time their pin expires
+func (s *Server) ListPinnedProxies() map[certificate.CommonName]time.Time {
+	return s.configPins.list()
+}
@@ -0,0 +1,109 @@
+package ads
+
+import (
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+var _ = Describe("Test pinning proxies to their config", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+		s                *Server
+		proxy            *envoy.Proxy
+		clusterName      string
+	)
+
+	proxyCN := certificate.CommonName("proxy.sa.ns.cluster.local")
+
+	getClusterNames := func(response *xds_discovery.DiscoveryResponse) []string {
+		var names []string
+		for _, resource := range response.Resources {
+			cluster := &xds_cluster.Cluster{}
+			Expect(ptypes.UnmarshalAny(resource, cluster)).To(Succeed())
+			names = append(names, cluster.Name)
+		}
+		return names
+	}
+
+	// sendAndAck creates a CDS response for the proxy and acknowledges it
+	sendAndAck := func() *xds_discovery.DiscoveryResponse {
+		response, err := s.newAggregatedDiscoveryResponse(proxy, &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		s.configPins.recordAck(proxy, envoy.TypeCDS, response.Nonce)
+		return response
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		proxy = envoy.NewProxy(proxyCN, "", nil)
+		clusterName = "known-good"
+
+		s = &Server{
+			xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error){
+				envoy.TypeCDS: func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
+					marshalled, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: clusterName})
+					if err != nil {
+						return nil, err
+					}
+					return &xds_discovery.DiscoveryResponse{TypeUrl: string(envoy.TypeCDS), Resources: []*any.Any{marshalled}}, nil
+				},
+			},
+			cfg:        mockConfigurator,
+			configPins: newProxyConfigPins(),
+		}
+	})
+
+	It("sends the pinned proxy its last acknowledged config until it is unpinned", func() {
+		mockConfigurator.EXPECT().GetProxyConfigPinTTL().Return(time.Hour).Times(1)
+
+		sendAndAck()
+		Expect(s.PinProxyConfig(proxyCN)).To(Succeed())
+		Expect(s.ListPinnedProxies()).To(HaveKey(proxyCN))
+
+		// Changes to the mesh are not sent to the pinned proxy
+		clusterName = "bad-policy"
+		pinned := sendAndAck()
+		Expect(getClusterNames(pinned)).To(ConsistOf("known-good"))
+		Expect(pinned.Nonce).To(Equal(proxy.GetLastSentNonce(envoy.TypeCDS)))
+
+		Expect(s.UnpinProxyConfig(proxyCN)).To(BeTrue())
+		Expect(s.ListPinnedProxies()).To(BeEmpty())
+		Expect(getClusterNames(sendAndAck())).To(ConsistOf("bad-policy"))
+		Expect(s.UnpinProxyConfig(proxyCN)).To(BeFalse())
+	})
+
+	It("unpins the proxy once the pin expires", func() {
+		mockConfigurator.EXPECT().GetProxyConfigPinTTL().Return(50 * time.Millisecond).Times(1)
+
+		sendAndAck()
+		Expect(s.PinProxyConfig(proxyCN)).To(Succeed())
+		Eventually(s.ListPinnedProxies).Should(BeEmpty())
+
+		clusterName = "updated"
+		Expect(getClusterNames(sendAndAck())).To(ConsistOf("updated"))
+	})
+
+	It("does not pin a proxy without acknowledged config", func() {
+		mockConfigurator.EXPECT().GetProxyConfigPinTTL().Return(time.Hour).AnyTimes()
+
+		Expect(s.PinProxyConfig(proxyCN)).To(MatchError(errProxyNotConnected))
+
+		_, err := s.newAggregatedDiscoveryResponse(proxy, &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.PinProxyConfig(proxyCN)).To(MatchError(errNoAckedConfig))
+	})
+})
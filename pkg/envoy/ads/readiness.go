@@ -0,0 +1,74 @@
+package ads
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+const (
+	// envoyConfigAckedReason is the reason set on the Envoy config ACK pod condition
+	envoyConfigAckedReason = "EnvoyConfigAcked"
+)
+
+// hasAckedInitialConfig returns true if the proxy has ACKed both its listener and cluster configuration
+func hasAckedInitialConfig(proxy *envoy.Proxy) bool {
+	return proxy.GetLastAppliedVersion(envoy.TypeLDS) > 0 && proxy.GetLastAppliedVersion(envoy.TypeCDS) > 0
+}
+
+// markPodConfigAcked sets the Envoy config ACK condition to True on the pod fronted by the given proxy,
+// satisfying the readiness gate added to the pod by the sidecar injector.
+func (s *Server) markPodConfigAcked(proxy *envoy.Proxy) error {
+	pod, err := catalog.GetPodFromCertificate(proxy.GetCertificateCommonName(), s.kubeController)
+	if err != nil {
+		return err
+	}
+
+	if !hasReadinessGate(pod) {
+		// The pod was injected before the readiness gate was enabled
+		return nil
+	}
+
+	condition := corev1.PodCondition{
+		Type:               constants.EnvoyConfigAckedPodCondition,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             envoyConfigAckedReason,
+		Message:            "Envoy sidecar has ACKed its initial listener and cluster configuration",
+	}
+
+	updatedPod := pod.DeepCopy()
+	for idx := range updatedPod.Status.Conditions {
+		if updatedPod.Status.Conditions[idx].Type != constants.EnvoyConfigAckedPodCondition {
+			continue
+		}
+		if updatedPod.Status.Conditions[idx].Status == corev1.ConditionTrue {
+			return nil
+		}
+		updatedPod.Status.Conditions[idx] = condition
+		return s.updatePodStatus(updatedPod)
+	}
+
+	updatedPod.Status.Conditions = append(updatedPod.Status.Conditions, condition)
+	return s.updatePodStatus(updatedPod)
+}
+
+func (s *Server) updatePodStatus(pod *corev1.Pod) error {
+	_, err := s.kubeClient.CoreV1().Pods(pod.Namespace).UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+	return err
+}
+
+// hasReadinessGate returns true if the pod has a readiness gate for the Envoy config ACK condition
+func hasReadinessGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == constants.EnvoyConfigAckedPodCondition {
+			return true
+		}
+	}
+	return false
+}
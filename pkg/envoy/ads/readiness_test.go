@@ -0,0 +1,115 @@
+package ads
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Test Envoy config ACK readiness gate", func() {
+	var (
+		mockCtrl           *gomock.Controller
+		mockKubeController *k8s.MockController
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeController = k8s.NewMockController(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	newPod := func(proxyUUID uuid.UUID, readinessGate bool) *corev1.Pod {
+		pod := tests.NewPodFixture(tests.Namespace, "pod-name", tests.BookstoreServiceAccountName, map[string]string{
+			constants.EnvoyUniqueIDLabelName: proxyUUID.String(),
+		})
+		if readinessGate {
+			pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: constants.EnvoyConfigAckedPodCondition}}
+		}
+		return &pod
+	}
+
+	It("requires both LDS and CDS to be ACKed", func() {
+		proxy := envoy.NewProxy(certificate.CommonName("cn"), "", nil)
+		Expect(hasAckedInitialConfig(proxy)).To(BeFalse())
+
+		proxy.SetLastAppliedVersion(envoy.TypeLDS, 1)
+		Expect(hasAckedInitialConfig(proxy)).To(BeFalse())
+
+		proxy.SetLastAppliedVersion(envoy.TypeCDS, 1)
+		Expect(hasAckedInitialConfig(proxy)).To(BeTrue())
+	})
+
+	It("sets the pod condition when the pod has the readiness gate", func() {
+		proxyUUID := uuid.New()
+		pod := newPod(proxyUUID, true)
+		kubeClient := fake.NewSimpleClientset(pod)
+		mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{pod})
+
+		s := &Server{kubeClient: kubeClient, kubeController: mockKubeController}
+		cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, tests.BookstoreServiceAccountName, tests.Namespace)
+		Expect(s.markPodConfigAcked(envoy.NewProxy(cn, "", nil))).To(Succeed())
+
+		updatedPod, err := kubeClient.CoreV1().Pods(tests.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedPod.Status.Conditions).To(HaveLen(1))
+		Expect(updatedPod.Status.Conditions[0].Type).To(Equal(corev1.PodConditionType(constants.EnvoyConfigAckedPodCondition)))
+		Expect(updatedPod.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+	})
+
+	It("sets the pod condition on the ACK of the initial config when the pod has the readiness gate", func() {
+		proxyUUID := uuid.New()
+		pod := newPod(proxyUUID, true)
+		kubeClient := fake.NewSimpleClientset(pod)
+		mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{pod}).Times(1)
+
+		// The mesh config is not consulted, as pods injected with the readiness gate need the condition to become ready
+		s := &Server{kubeClient: kubeClient, kubeController: mockKubeController, configPins: newProxyConfigPins()}
+		cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, tests.BookstoreServiceAccountName, tests.Namespace)
+		proxy := envoy.NewProxy(cn, "", nil)
+		podConfigAcked := false
+
+		s.recordAck(proxy, envoy.TypeLDS, 1, "nonce-1", &podConfigAcked)
+		Expect(podConfigAcked).To(BeFalse())
+		s.recordAck(proxy, envoy.TypeCDS, 1, "nonce-2", &podConfigAcked)
+		Expect(podConfigAcked).To(BeTrue())
+
+		updatedPod, err := kubeClient.CoreV1().Pods(tests.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedPod.Status.Conditions).To(HaveLen(1))
+		Expect(updatedPod.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+
+		// The pod is not looked up again on subsequent ACKs
+		s.recordAck(proxy, envoy.TypeCDS, 2, "nonce-3", &podConfigAcked)
+	})
+
+	It("does not set the pod condition when the pod has no readiness gate", func() {
+		proxyUUID := uuid.New()
+		pod := newPod(proxyUUID, false)
+		kubeClient := fake.NewSimpleClientset(pod)
+		mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{pod})
+
+		s := &Server{kubeClient: kubeClient, kubeController: mockKubeController}
+		cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, tests.BookstoreServiceAccountName, tests.Namespace)
+		Expect(s.markPodConfigAcked(envoy.NewProxy(cn, "", nil))).To(Succeed())
+
+		updatedPod, err := kubeClient.CoreV1().Pods(tests.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedPod.Status.Conditions).To(BeEmpty())
+	})
+})
@@ -0,0 +1,145 @@
+package ads
+
+import (
+	"sync"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// disconnectedProxies keeps the config state of the proxies which disconnected from the xDS server, so that a proxy
+// reconnecting within the configured grace period resumes from its previous config state instead of being handled as
+// a new proxy
+type disconnectedProxies struct {
+	sync.Mutex
+
+	proxies map[certificate.CommonName]*disconnectedProxy
+}
+
+// disconnectedProxy is the config state of a proxy at the time it disconnected
+type disconnectedProxy struct {
+	disconnectedAt     time.Time
+	lastSentVersion    map[envoy.TypeURI]uint64
+	lastAppliedVersion map[envoy.TypeURI]uint64
+	sent               map[envoy.TypeURI]*xds_discovery.DiscoveryResponse
+	acked              map[envoy.TypeURI]*xds_discovery.DiscoveryResponse
+}
+
+func newDisconnectedProxies() *disconnectedProxies {
+	return &disconnectedProxies{
+		proxies: make(map[certificate.CommonName]*disconnectedProxy),
+	}
+}
+
+// remember records the config state of the given proxy which disconnected, and forgets the proxies which disconnected
+// longer than the given grace period ago
+func (d *disconnectedProxies) remember(proxy *envoy.Proxy, configPins *proxyConfigPins, gracePeriod time.Duration) {
+	state := &disconnectedProxy{
+		disconnectedAt:     time.Now(),
+		lastSentVersion:    make(map[envoy.TypeURI]uint64),
+		lastAppliedVersion: make(map[envoy.TypeURI]uint64),
+	}
+	for _, typeURI := range envoy.XDSResponseOrder {
+		state.lastSentVersion[typeURI] = proxy.GetLastSentVersion(typeURI)
+		state.lastAppliedVersion[typeURI] = proxy.GetLastAppliedVersion(typeURI)
+	}
+	state.sent, state.acked = configPins.getState(proxy)
+
+	d.Lock()
+	defer d.Unlock()
+
+	for cn, disconnected := range d.proxies {
+		if time.Since(disconnected.disconnectedAt) > gracePeriod {
+			delete(d.proxies, cn)
+		}
+	}
+	d.proxies[proxy.GetCertificateCommonName()] = state
+}
+
+// restore restores the config state of the given proxy if a proxy with the same certificate common name disconnected
+// within the given grace period. It returns false if the proxy must be handled as a new proxy.
+func (d *disconnectedProxies) restore(proxy *envoy.Proxy, configPins *proxyConfigPins, gracePeriod time.Duration) bool {
+	d.Lock()
+	state, ok := d.proxies[proxy.GetCertificateCommonName()]
+	delete(d.proxies, proxy.GetCertificateCommonName())
+	d.Unlock()
+
+	if !ok || time.Since(state.disconnectedAt) > gracePeriod {
+		return false
+	}
+
+	for typeURI, version := range state.lastSentVersion {
+		proxy.SetLastSentVersion(typeURI, version)
+	}
+	for typeURI, version := range state.lastAppliedVersion {
+		proxy.SetLastAppliedVersion(typeURI, version)
+	}
+	configPins.setState(proxy, state.sent, state.acked)
+	return true
+}
+
+// rememberDisconnectedProxy records the config state of the given proxy which disconnected, if reconnecting proxies
+// are recognized
+func (s *Server) rememberDisconnectedProxy(proxy *envoy.Proxy) {
+	gracePeriod := s.cfg.GetProxyReconnectGracePeriod()
+	if gracePeriod == 0 {
+		return
+	}
+	s.disconnected.remember(proxy, s.configPins, gracePeriod)
+}
+
+// restoreReconnectedProxy restores the config state the given proxy had before it disconnected, if it reconnected
+// within the configured grace period. It returns false if the proxy must be handled as a new proxy.
+func (s *Server) restoreReconnectedProxy(proxy *envoy.Proxy) bool {
+	gracePeriod := s.cfg.GetProxyReconnectGracePeriod()
+	if gracePeriod == 0 {
+		return false
+	}
+	return s.disconnected.restore(proxy, s.configPins, gracePeriod)
+}
+
+// sendChangedResponses sends the given reconnected proxy the config which changed since it was last sent to it.
+// Secrets are always sent, so that certificates rotated while the proxy was disconnected are delivered.
+func (s *Server) sendChangedResponses(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, cfg configurator.Configurator) {
+	sent, _ := s.configPins.getState(proxy)
+
+	var changed []envoy.TypeURI
+	for _, typeURI := range envoy.XDSResponseOrder {
+		if typeURI != envoy.TypeSDS && !s.hasConfigChanged(proxy, typeURI, sent[typeURI], cfg) {
+			continue
+		}
+		changed = append(changed, typeURI)
+	}
+
+	log.Debug().Msgf("Proxy with SerialNumber=%s on Pod with UID=%s reconnected, sending changed config %v", proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), changed)
+	s.sendResponses(proxy, server, cfg, changed...)
+}
+
+// hasConfigChanged returns true if the resources of the given xDS type computed for the given proxy differ from the
+// ones of the given response last sent to it
+func (s *Server) hasConfigChanged(proxy *envoy.Proxy, typeURI envoy.TypeURI, lastSent *xds_discovery.DiscoveryResponse, cfg configurator.Configurator) bool {
+	if lastSent == nil {
+		return true
+	}
+
+	response, err := s.xdsHandlers[typeURI](s.catalog, proxy, &xds_discovery.DiscoveryRequest{TypeUrl: string(typeURI)}, cfg, s.certManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error computing %s config of proxy with SerialNumber=%s on Pod with UID=%s", envoy.XDSShortURINames[typeURI], proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return true
+	}
+
+	if len(response.Resources) != len(lastSent.Resources) {
+		return true
+	}
+	for i := range response.Resources {
+		if !proto.Equal(response.Resources[i], lastSent.Resources[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package ads
+
+import (
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+var _ = Describe("Test recognizing reconnecting proxies", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+		s                *Server
+		proxy            *envoy.Proxy
+		clusterName      string
+	)
+
+	proxyCN := certificate.CommonName("proxy.sa.ns.cluster.local")
+
+	// disconnect sends and acknowledges a CDS response to the proxy, then disconnects it and returns the sent response
+	disconnect := func() *xds_discovery.DiscoveryResponse {
+		response, err := s.newAggregatedDiscoveryResponse(proxy, &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		proxy.SetLastAppliedVersion(envoy.TypeCDS, proxy.GetLastSentVersion(envoy.TypeCDS))
+		s.configPins.recordAck(proxy, envoy.TypeCDS, response.Nonce)
+
+		s.rememberDisconnectedProxy(proxy)
+		s.configPins.forget(proxy)
+		return response
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		proxy = envoy.NewProxy(proxyCN, "", nil)
+		clusterName = "cluster"
+
+		s = &Server{
+			xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error){
+				envoy.TypeCDS: func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
+					marshalled, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: clusterName})
+					if err != nil {
+						return nil, err
+					}
+					return &xds_discovery.DiscoveryResponse{TypeUrl: string(envoy.TypeCDS), Resources: []*any.Any{marshalled}}, nil
+				},
+			},
+			cfg:          mockConfigurator,
+			configPins:   newProxyConfigPins(),
+			disconnected: newDisconnectedProxies(),
+		}
+	})
+
+	It("restores the config state of a proxy reconnecting within the grace period", func() {
+		mockConfigurator.EXPECT().GetProxyReconnectGracePeriod().Return(time.Hour).AnyTimes()
+
+		lastSent := disconnect()
+
+		reconnected := envoy.NewProxy(proxyCN, "", nil)
+		Expect(s.restoreReconnectedProxy(reconnected)).To(BeTrue())
+		Expect(reconnected.GetLastSentVersion(envoy.TypeCDS)).To(Equal(uint64(1)))
+		Expect(reconnected.GetLastAppliedVersion(envoy.TypeCDS)).To(Equal(uint64(1)))
+
+		sent, acked := s.configPins.getState(reconnected)
+		Expect(sent).To(HaveKeyWithValue(envoy.TypeCDS, lastSent))
+		Expect(acked).To(HaveKeyWithValue(envoy.TypeCDS, lastSent))
+
+		// Unchanged config is not sent again, changed config is
+		Expect(s.hasConfigChanged(reconnected, envoy.TypeCDS, sent[envoy.TypeCDS], mockConfigurator)).To(BeFalse())
+		clusterName = "updated"
+		Expect(s.hasConfigChanged(reconnected, envoy.TypeCDS, sent[envoy.TypeCDS], mockConfigurator)).To(BeTrue())
+
+		// The state is only restored once
+		Expect(s.restoreReconnectedProxy(envoy.NewProxy(proxyCN, "", nil))).To(BeFalse())
+	})
+
+	It("handles a proxy reconnecting after the grace period as a new proxy", func() {
+		mockConfigurator.EXPECT().GetProxyReconnectGracePeriod().Return(50 * time.Millisecond).AnyTimes()
+
+		disconnect()
+		time.Sleep(100 * time.Millisecond)
+
+		reconnected := envoy.NewProxy(proxyCN, "", nil)
+		Expect(s.restoreReconnectedProxy(reconnected)).To(BeFalse())
+		Expect(reconnected.GetLastSentVersion(envoy.TypeCDS)).To(BeZero())
+
+		sent, _ := s.configPins.getState(reconnected)
+		Expect(sent).To(BeEmpty())
+	})
+
+	It("does not recognize reconnecting proxies when the grace period is not set", func() {
+		mockConfigurator.EXPECT().GetProxyReconnectGracePeriod().Return(time.Duration(0)).AnyTimes()
+
+		disconnect()
+		Expect(s.restoreReconnectedProxy(envoy.NewProxy(proxyCN, "", nil))).To(BeFalse())
+	})
+})
@@ -0,0 +1,231 @@
+package ads
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/ingress"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+const (
+	replayTestdataDir = "testdata/replay"
+	replayMeshName    = "osm"
+
+	// replayBookstoreProxyUUID is the proxy UUID of the bookstore pod of testdata/replay/mesh.json
+	replayBookstoreProxyUUID = "12345678-5791-9876-abcd-1234567890ab"
+)
+
+// Replaying a captured request:
+//
+//	OSM_REPLAY_REQUEST=/path/to/request.json OSM_REPLAY_OUTPUT=/path/to/response.json go test ./pkg/envoy/ads/ -args -ginkgo.focus=OSM_REPLAY_REQUEST
+//
+// The computed response is written as JSON to OSM_REPLAY_OUTPUT, or to the GinkgoWriter if not set. The mesh is built
+// from the Kubernetes and SMI objects of the List read from OSM_REPLAY_MESH, or from testdata/replay/mesh.json
+// otherwise, and the request is replayed for its bookbuyer proxy unless OSM_REPLAY_PROXY_CN is set. The OSM ConfigMap
+// is read from OSM_REPLAY_CONFIGMAP, or from testdata/replay/osm-config.json otherwise.
+var _ = Describe("Test replaying captured discovery requests", func() {
+	var (
+		mc          catalog.MeshCataloger
+		cfg         configurator.Configurator
+		certManager certificate.Manager
+		proxyCN     certificate.CommonName
+		stop        chan struct{}
+	)
+
+	BeforeEach(func() {
+		stop = make(chan struct{})
+
+		configMap := &corev1.ConfigMap{}
+		readReplayObject("OSM_REPLAY_CONFIGMAP", filepath.Join(replayTestdataDir, "osm-config.json"), configMap)
+		meshList := &corev1.List{}
+		readReplayObject("OSM_REPLAY_MESH", filepath.Join(replayTestdataDir, "mesh.json"), meshList)
+
+		kubeObjects, smiObjects := decodeReplayMesh(meshList)
+		kubeClient := testclient.NewSimpleClientset(append(kubeObjects, configMap)...)
+		cfg = configurator.NewConfigurator(kubeClient, stop, configMap.Namespace, configMap.Name)
+		certManager = tresor.NewFakeCertManager(cfg)
+		mc = newReplayMeshCatalog(kubeClient, smiObjects, configMap.Namespace, cfg, certManager, stop)
+		proxyCN = certificate.CommonName(fmt.Sprintf("%s.%s.%s", tests.ProxyUUID, tests.BookbuyerServiceAccountName, tests.Namespace))
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	// replay computes the response to the request captured in the given file and writes it to the given file, or to
+	// the GinkgoWriter if no file is given
+	replay := func(requestPath string, cn certificate.CommonName, outputPath string) []*any.Any {
+		request, err := loadDiscoveryRequest(requestPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := replayDiscoveryRequest(mc, cfg, certManager, cn, request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.TypeUrl).To(Equal(request.TypeUrl))
+
+		marshalled, err := marshalDiscoveryResponse(response)
+		Expect(err).ToNot(HaveOccurred())
+		if outputPath != "" {
+			Expect(ioutil.WriteFile(outputPath, marshalled, 0600)).To(Succeed())
+		} else {
+			_, _ = fmt.Fprintf(GinkgoWriter, "%s\n", marshalled)
+		}
+
+		return response.Resources
+	}
+
+	It("replays a captured CDS request", func() {
+		var clusterNames []string
+		for _, resource := range replay(filepath.Join(replayTestdataDir, "cds-request.json"), proxyCN, "") {
+			cluster := &xds_cluster.Cluster{}
+			Expect(ptypes.UnmarshalAny(resource, cluster)).To(Succeed())
+			clusterNames = append(clusterNames, cluster.Name)
+		}
+		Expect(clusterNames).To(ContainElements(
+			envoy.GetLocalClusterNameForService(tests.BookbuyerService),
+			tests.BookstoreV1Service.String(),
+			envoy.OutboundPassthroughCluster,
+		))
+	})
+
+	It("replays a captured LDS request", func() {
+		// bookstore is the destination of the mesh's traffic, so its proxy has an inbound listener
+		bookstoreCN := certificate.CommonName(fmt.Sprintf("%s.%s.%s", replayBookstoreProxyUUID, tests.BookstoreServiceAccountName, tests.Namespace))
+
+		var listenerNames []string
+		for _, resource := range replay(filepath.Join(replayTestdataDir, "lds-request.json"), bookstoreCN, "") {
+			listener := &xds_listener.Listener{}
+			Expect(ptypes.UnmarshalAny(resource, listener)).To(Succeed())
+			listenerNames = append(listenerNames, listener.Name)
+		}
+		Expect(listenerNames).To(ContainElements("inbound-listener", "outbound-listener"))
+	})
+
+	It("replays the request given by OSM_REPLAY_REQUEST", func() {
+		requestPath := os.Getenv("OSM_REPLAY_REQUEST")
+		if requestPath == "" {
+			Skip("OSM_REPLAY_REQUEST is not set")
+		}
+		cn := proxyCN
+		if replayCN := os.Getenv("OSM_REPLAY_PROXY_CN"); replayCN != "" {
+			cn = certificate.CommonName(replayCN)
+		}
+
+		replay(requestPath, cn, os.Getenv("OSM_REPLAY_OUTPUT"))
+	})
+})
+
+// readReplayObject decodes the JSON object read from the file given by the given environment variable, or from the
+// given default file if the variable is not set
+func readReplayObject(envVar, defaultPath string, obj runtime.Object) {
+	path := os.Getenv(envVar)
+	if path == "" {
+		path = defaultPath
+	}
+	marshalled, err := ioutil.ReadFile(path)
+	Expect(err).ToNot(HaveOccurred())
+	_, _, err = replayDecoder().Decode(marshalled, nil, obj)
+	Expect(err).ToNot(HaveOccurred())
+}
+
+// decodeReplayMesh decodes the items of the given List, returning its Kubernetes and SMI objects
+func decodeReplayMesh(meshList *corev1.List) ([]runtime.Object, []runtime.Object) {
+	var kubeObjects, smiObjects []runtime.Object
+	for _, item := range meshList.Items {
+		obj, _, err := replayDecoder().Decode(item.Raw, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		switch obj.(type) {
+		case *split.TrafficSplit, *spec.HTTPRouteGroup, *spec.TCPRoute, *access.TrafficTarget:
+			smiObjects = append(smiObjects, obj)
+		default:
+			kubeObjects = append(kubeObjects, obj)
+		}
+	}
+	return kubeObjects, smiObjects
+}
+
+// replayDecoder returns a decoder of the Kubernetes and SMI objects a replayed mesh is made of
+func replayDecoder() runtime.Decoder {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(access.AddToScheme(scheme)).To(Succeed())
+	Expect(spec.AddToScheme(scheme)).To(Succeed())
+	Expect(split.AddToScheme(scheme)).To(Succeed())
+	return serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
+
+// newReplayMeshCatalog returns a MeshCatalog computing the mesh from the objects of the given Kubernetes client and the
+// given SMI objects, through the same controllers and endpoints provider as the OSM controller
+func newReplayMeshCatalog(kubeClient *testclient.Clientset, smiObjects []runtime.Object, osmNamespace string, cfg configurator.Configurator, certManager certificate.Manager, stop chan struct{}) catalog.MeshCataloger {
+	kubeController, err := k8s.NewKubernetesController(kubeClient, replayMeshName, stop)
+	Expect(err).ToNot(HaveOccurred())
+	meshSpec, err := smi.NewFakeMeshSpecClientFromObjects(kubeClient, kubeController, osmNamespace, smiObjects, stop)
+	Expect(err).ToNot(HaveOccurred())
+	endpointsProvider, err := kube.NewProvider(kubeClient, kubeController, "replay", cfg)
+	Expect(err).ToNot(HaveOccurred())
+	ingressMonitor, err := ingress.NewIngressClient(kubeClient, kubeController, stop, cfg)
+	Expect(err).ToNot(HaveOccurred())
+
+	return catalog.NewMeshCatalog(kubeController, kubeClient, meshSpec, certManager, ingressMonitor, stop, cfg, endpointsProvider)
+}
+
+// replayDiscoveryRequest computes the response to the given captured DiscoveryRequest of the proxy with the given
+// certificate common name, through the same code path as the requests of connected proxies
+func replayDiscoveryRequest(meshCatalog catalog.MeshCataloger, cfg configurator.Configurator, certManager certificate.Manager, cn certificate.CommonName, request *xds_discovery.DiscoveryRequest) (*xds_discovery.DiscoveryResponse, error) {
+	s := NewADSServer(meshCatalog, false, "", cfg, certManager, nil, nil)
+	proxy := envoy.NewProxy(cn, "", nil)
+	return s.newAggregatedDiscoveryResponse(proxy, request, cfg)
+}
+
+// loadDiscoveryRequest reads a DiscoveryRequest captured in JSON from the given file
+func loadDiscoveryRequest(path string) (*xds_discovery.DiscoveryRequest, error) {
+	marshalled, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &xds_discovery.DiscoveryRequest{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(marshalled), request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// marshalDiscoveryResponse returns the given DiscoveryResponse as indented JSON.
+// NOTE: The JSON of an SDS response holds the private keys of the proxy's certificates.
+func marshalDiscoveryResponse(response *xds_discovery.DiscoveryResponse) ([]byte, error) {
+	marshaler := jsonpb.Marshaler{Indent: "  "}
+	marshalled, err := marshaler.MarshalToString(response)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(marshalled), nil
+}
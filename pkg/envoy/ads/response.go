@@ -43,7 +43,17 @@ func (s *Server) sendTypeResponse(tURI envoy.TypeURI,
 }
 
 func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, cfg configurator.Configurator) {
-	log.Trace().Msgf("A change announcement triggered *DS update for proxy with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+	s.sendResponses(proxy, server, cfg, envoy.XDSResponseOrder...)
+}
+
+// sendResponses sends responses for the given xDS types to the proxy, in the order defined by envoy.XDSResponseOrder
+func (s *Server) sendResponses(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, cfg configurator.Configurator, typeURIs ...envoy.TypeURI) {
+	log.Trace().Msgf("A change announcement triggered %v update for proxy with SerialNumber=%s on Pod with UID=%s", typeURIs, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+
+	requestedTypeURIs := make(map[envoy.TypeURI]struct{}, len(typeURIs))
+	for _, typeURI := range typeURIs {
+		requestedTypeURIs[typeURI] = struct{}{}
+	}
 
 	// Tracks the success of this full update of all its XDS paths. If a single XDS response path fails for this full update,
 	// the full updated will be considered as failed for metric purposes (success = false)
@@ -53,6 +63,10 @@ func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.Aggr
 	// Order is important: CDS, EDS, LDS, RDS
 	// See: https://github.com/envoyproxy/go-control-plane/issues/59
 	for _, typeURI := range envoy.XDSResponseOrder {
+		if _, ok := requestedTypeURIs[typeURI]; !ok {
+			continue
+		}
+
 		// For SDS we need to add ResourceNames
 		var request *xds_discovery.DiscoveryRequest
 		if typeURI == envoy.TypeSDS {
@@ -144,15 +158,29 @@ func (s *Server) newAggregatedDiscoveryResponse(proxy *envoy.Proxy, request *xds
 		nodeID = request.Node.Id
 	}
 
-	log.Trace().Msgf("Invoking handler for type %s; request from Envoy with Node ID %s", typeURL, nodeID)
-	response, err := handler(s.catalog, proxy, request, cfg, s.certManager)
-	if err != nil {
-		log.Error().Msgf("Responder for TypeUrl %s is not implemented", request.TypeUrl)
-		return nil, errCreatingResponse
+	// A proxy pinned to its config is sent the resources it last acknowledged, regardless of changes to the mesh
+	response := s.configPins.getPinnedResponse(proxy, typeURL)
+	if response != nil {
+		log.Debug().Msgf("Proxy with SerialNumber=%s on Pod with UID=%s is pinned, sending its pinned %s config", proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), envoy.XDSShortURINames[typeURL])
+	} else if response = s.getPersistedResponse(proxy, typeURL); response != nil {
+		// While the control plane recovers from a restart, the proxy is sent the config persisted before the restart
+		log.Debug().Msgf("Sending persisted %s config to proxy with SerialNumber=%s on Pod with UID=%s", envoy.XDSShortURINames[typeURL], proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+	} else {
+		log.Trace().Msgf("Invoking handler for type %s; request from Envoy with Node ID %s", typeURL, nodeID)
+		var err error
+		response, err = handler(s.catalog, proxy, request, cfg, s.certManager)
+		if err != nil {
+			log.Error().Msgf("Responder for TypeUrl %s is not implemented", request.TypeUrl)
+			return nil, errCreatingResponse
+		}
+		if s.snapshots != nil {
+			s.snapshots.save(proxy, response)
+		}
 	}
 
 	response.Nonce = proxy.SetNewNonce(typeURL)
 	response.VersionInfo = strconv.FormatUint(proxy.IncrementLastSentVersion(typeURL), 10)
+	s.configPins.recordSent(proxy, typeURL, response)
 
 	// NOTE: Never log entire 'response' - will contain secrets!
 	log.Trace().Msgf("Constructed %s response: VersionInfo=%s", response.TypeUrl, response.VersionInfo)
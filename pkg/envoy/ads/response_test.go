@@ -37,6 +37,9 @@ var _ = Describe("Test ADS response functions", func() {
 
 	mockCtrl = gomock.NewController(GinkgoT())
 	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 	mockCertManager = certificate.NewMockManager(mockCtrl)
 
 	// --- setup
@@ -115,13 +118,38 @@ var _ = Describe("Test ADS response functions", func() {
 		server, actualResponses := tests.NewFakeXDSServer(cert, nil, nil)
 
 		mockConfigurator.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+
+		mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+
+		mockConfigurator.EXPECT().GetInboundTLSHandshakeTimeout().Return(10 * time.Second).AnyTimes()
+
+		mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).AnyTimes()
+
+		mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).AnyTimes()
+
+		mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).AnyTimes()
+
+		mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).AnyTimes()
+
+		mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(0)).AnyTimes()
+		mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+		mockConfigurator.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModeDeny).AnyTimes()
+		mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().IsHTTPMethodStatsEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().IsUpstreamClusterHeaderEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetTrafficSplitEmptyBackendMode().Return(constants.TrafficSplitEmptyBackendModeStrict).AnyTimes()
+		mockConfigurator.EXPECT().GetOutboundRequestTimeout().Return(time.Duration(0)).AnyTimes()
 		mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
 		mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
 		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
 		mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(certDuration).AnyTimes()
+		mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
 
 		It("returns Aggregated Discovery Service response", func() {
-			s := NewADSServer(mc, true, tests.Namespace, mockConfigurator, mockCertManager)
+			s := NewADSServer(mc, true, tests.Namespace, mockConfigurator, mockCertManager, nil, nil)
 
 			Expect(s).ToNot(BeNil())
 
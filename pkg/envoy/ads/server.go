@@ -5,6 +5,8 @@ import (
 	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/grpc/keepalive"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -15,14 +17,18 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/lds"
 	"github.com/openservicemesh/osm/pkg/envoy/rds"
 	"github.com/openservicemesh/osm/pkg/envoy/sds"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 // ServerType is the type identifier for the ADS server
 const ServerType = "ADS"
 
+// maxConnectionAgeGrace is how long a connection closed for reaching its maximum age is kept open for its streams to end
+const maxConnectionAgeGrace = 30 * time.Second
+
 // NewADSServer creates a new Aggregated Discovery Service server
-func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamespace string, cfg configurator.Configurator, certManager certificate.Manager) *Server {
+func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamespace string, cfg configurator.Configurator, certManager certificate.Manager, kubeClient kubernetes.Interface, kubeController k8s.Controller) *Server {
 	server := Server{
 		catalog: meshCatalog,
 		xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error){
@@ -36,6 +42,12 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamesp
 		osmNamespace: osmNamespace,
 		cfg:          cfg,
 		certManager:  certManager,
+
+		kubeClient:     kubeClient,
+		kubeController: kubeController,
+
+		configPins:   newProxyConfigPins(),
+		disconnected: newDisconnectedProxies(),
 	}
 
 	if enableDebug {
@@ -47,20 +59,34 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamesp
 
 // Start starts the ADS server
 func (s *Server) Start(ctx context.Context, cancel context.CancelFunc, port int, adsCert certificate.Certificater) error {
-	grpcServer, lis, err := utils.NewGrpc(ServerType, port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA())
+	grpcServer, lis, err := utils.NewGrpc(ServerType, port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA(), getKeepaliveParams(s.cfg))
 	if err != nil {
 		log.Error().Err(err).Msg("Error starting ADS server")
 		return err
 	}
 
 	xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, s)
-	go utils.GrpcServe(ctx, grpcServer, lis, cancel, ServerType, nil)
+	s.stopped = make(chan struct{})
+	go func() {
+		utils.GrpcServe(ctx, grpcServer, lis, cancel, ServerType, nil)
+		close(s.stopped)
+	}()
 	s.ready = true
 
 	return nil
 }
 
-// DeltaAggregatedResources implements discovery.AggregatedDiscoveryServiceServer
-func (s *Server) DeltaAggregatedResources(xds_discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesServer) error {
-	panic("NotImplemented")
+// getKeepaliveParams returns the keepalive parameters of the xDS gRPC server. Idle connections are pinged so that
+// dead connections are closed, and connections are closed after their configured maximum age so that long-lived
+// streams are recycled.
+func getKeepaliveParams(cfg configurator.Configurator) keepalive.ServerParameters {
+	params := keepalive.ServerParameters{
+		Time:    cfg.GetXDSKeepaliveTime(),
+		Timeout: cfg.GetXDSKeepaliveTimeout(),
+	}
+	if maxConnectionAge := cfg.GetXDSMaxConnectionAge(); maxConnectionAge > 0 {
+		params.MaxConnectionAge = maxConnectionAge
+		params.MaxConnectionAgeGrace = maxConnectionAgeGrace
+	}
+	return params
 }
@@ -0,0 +1,46 @@
+package ads
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+var _ = Describe("Test getKeepaliveParams", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(30 * time.Second).Times(1)
+		mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(10 * time.Second).Times(1)
+	})
+
+	It("does not limit the age of connections by default", func() {
+		mockConfigurator.EXPECT().GetXDSMaxConnectionAge().Return(time.Duration(0)).Times(1)
+
+		Expect(getKeepaliveParams(mockConfigurator)).To(Equal(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}))
+	})
+
+	It("closes connections after their maximum age", func() {
+		mockConfigurator.EXPECT().GetXDSMaxConnectionAge().Return(time.Hour).Times(1)
+
+		Expect(getKeepaliveParams(mockConfigurator)).To(Equal(keepalive.ServerParameters{
+			Time:                  30 * time.Second,
+			Timeout:               10 * time.Second,
+			MaxConnectionAge:      time.Hour,
+			MaxConnectionAgeGrace: maxConnectionAgeGrace,
+		}))
+	})
+})
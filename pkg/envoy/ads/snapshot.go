@@ -0,0 +1,476 @@
+package ads
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+)
+
+// SnapshotStore persists the config last computed for each proxy, so that it can be served to the proxies as soon as
+// the control plane restarts, while their config is being recomputed
+type SnapshotStore interface {
+	// Save persists the given response as the last one computed for the proxy with the given certificate common name
+	Save(certificate.CommonName, *xds_discovery.DiscoveryResponse) error
+
+	// Load returns the persisted response of the given xDS type for the proxy with the given certificate common name,
+	// or nil if there is none
+	Load(certificate.CommonName, envoy.TypeURI) (*xds_discovery.DiscoveryResponse, error)
+
+	// Delete deletes the persisted responses of the proxy with the given certificate common name
+	Delete(certificate.CommonName) error
+
+	// List returns the certificate common names of the proxies with persisted responses
+	List() ([]certificate.CommonName, error)
+}
+
+// fileSnapshotStore is a SnapshotStore persisting each snapshot to a file of a local directory
+type fileSnapshotStore struct {
+	dir string
+}
+
+// NewFileSnapshotStore returns a SnapshotStore persisting the snapshots to files in the given directory, which is
+// created if it does not exist
+func NewFileSnapshotStore(dir string) (SnapshotStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "error creating xDS snapshot directory %s", dir)
+	}
+	return &fileSnapshotStore{dir: dir}, nil
+}
+
+// getPath returns the path of the file holding the snapshot of the given xDS type for the given proxy
+func (f *fileSnapshotStore) getPath(cn certificate.CommonName, typeURI envoy.TypeURI) string {
+	return filepath.Join(f.dir, fmt.Sprintf("%s.%s", cn, envoy.XDSShortURINames[typeURI]))
+}
+
+// Save persists the given response, replacing the previous snapshot atomically so that a crash never leaves a partially written snapshot
+func (f *fileSnapshotStore) Save(cn certificate.CommonName, response *xds_discovery.DiscoveryResponse) error {
+	marshalled, err := proto.Marshal(response)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling xDS snapshot")
+	}
+
+	tmp, err := ioutil.TempFile(f.dir, ".snapshot-")
+	if err != nil {
+		return errors.Wrap(err, "error creating xDS snapshot file")
+	}
+	defer os.Remove(tmp.Name()) //nolint: errcheck
+
+	if _, err := tmp.Write(marshalled); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "error writing xDS snapshot file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "error writing xDS snapshot file")
+	}
+	return os.Rename(tmp.Name(), f.getPath(cn, envoy.TypeURI(response.TypeUrl)))
+}
+
+// Load returns the persisted snapshot of the given xDS type for the given proxy
+func (f *fileSnapshotStore) Load(cn certificate.CommonName, typeURI envoy.TypeURI) (*xds_discovery.DiscoveryResponse, error) {
+	marshalled, err := ioutil.ReadFile(f.getPath(cn, typeURI))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading xDS snapshot file")
+	}
+
+	response := &xds_discovery.DiscoveryResponse{}
+	if err := proto.Unmarshal(marshalled, response); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling xDS snapshot")
+	}
+	return response, nil
+}
+
+// Delete deletes the persisted snapshots of all xDS types of the given proxy
+func (f *fileSnapshotStore) Delete(cn certificate.CommonName) error {
+	for typeURI := range envoy.XDSShortURINames {
+		if err := os.Remove(f.getPath(cn, typeURI)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "error deleting xDS snapshot file")
+		}
+	}
+	return nil
+}
+
+// List returns the proxies with a persisted snapshot of any xDS type
+func (f *fileSnapshotStore) List() ([]certificate.CommonName, error) {
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing xDS snapshot files")
+	}
+
+	listed := make(map[certificate.CommonName]struct{})
+	var cns []certificate.CommonName
+	for _, file := range files {
+		// Temporary files of snapshots being saved are hidden
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		idx := strings.LastIndex(file.Name(), ".")
+		if idx <= 0 {
+			continue
+		}
+		cn := certificate.CommonName(file.Name()[:idx])
+		if _, ok := listed[cn]; !ok {
+			listed[cn] = struct{}{}
+			cns = append(cns, cn)
+		}
+	}
+	return cns, nil
+}
+
+// validateSnapshot returns an error if the given persisted response is not a valid response of the given xDS type
+func validateSnapshot(response *xds_discovery.DiscoveryResponse, typeURI envoy.TypeURI) error {
+	if response.TypeUrl != string(typeURI) {
+		return errors.Wrapf(errInvalidSnapshot, "snapshot has type %s, expected %s", response.TypeUrl, typeURI)
+	}
+
+	for _, resource := range response.Resources {
+		if resource.TypeUrl != response.TypeUrl {
+			return errors.Wrapf(errInvalidSnapshot, "resource has type %s, expected %s", resource.TypeUrl, response.TypeUrl)
+		}
+
+		var dynamic ptypes.DynamicAny
+		if err := ptypes.UnmarshalAny(resource, &dynamic); err != nil {
+			return errors.Wrapf(errInvalidSnapshot, "error unmarshalling resource: %s", err)
+		}
+		if validator, ok := dynamic.Message.(interface{ Validate() error }); ok {
+			if err := validator.Validate(); err != nil {
+				return errors.Wrapf(errInvalidSnapshot, "invalid resource: %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+// snapshotRecovery persists the config computed for the proxies and serves it to the proxies connecting during the
+// recovery period following a restart of the control plane
+type snapshotRecovery struct {
+	sync.Mutex
+
+	store    SnapshotStore
+	deadline time.Time
+
+	// maxProxies bounds the number of proxies with persisted snapshots, and evictionDelay is the time after which the
+	// snapshots of a disconnected proxy which did not reconnect are evicted
+	maxProxies    int
+	evictionDelay time.Duration
+
+	// recovering holds the proxies served a persisted snapshot, which are sent their recomputed config once the
+	// recovery period ends
+	recovering map[*envoy.Proxy]struct{}
+
+	// persisted holds the proxies whose snapshots were saved since the control plane started
+	persisted map[certificate.CommonName]persistedProxy
+
+	// podProxies maps the UID of the pods of the proxies in persisted to the certificate common names of the proxies
+	podProxies map[string]certificate.CommonName
+
+	// evictions holds the timers evicting the snapshots of the disconnected proxies
+	evictions map[certificate.CommonName]*time.Timer
+
+	// pending holds the snapshot writes not yet performed, coalesced per proxy, which are performed by the writer
+	// goroutine so that persisting a snapshot never blocks sending it
+	pending map[certificate.CommonName]*pendingSnapshots
+	wakeup  chan struct{}
+
+	// stop stops the goroutines writing the snapshots and evicting the snapshots of deleted pods
+	stop chan struct{}
+}
+
+// persistedProxy holds the time the snapshots of a proxy were last saved and the UID of the proxy's pod
+type persistedProxy struct {
+	savedAt time.Time
+	podUID  string
+}
+
+// pendingSnapshots holds the snapshot writes of a proxy not yet performed
+type pendingSnapshots struct {
+	// evict is true if the persisted snapshots of the proxy must be deleted before the responses are saved
+	evict     bool
+	responses map[envoy.TypeURI]*xds_discovery.DiscoveryResponse
+}
+
+// EnableSnapshotPersistence persists the config computed for each proxy to the given store. Proxies connecting within
+// the given recovery period are first served their persisted config, and are sent their recomputed config once the
+// period ends, which avoids config gaps while the control plane catches up after a restart.
+// Snapshots are persisted for at most the given number of proxies, evicting the least recently saved proxy, and are
+// evicted when the pod of the proxy is deleted or the proxy does not reconnect within the recovery period.
+// It must be called before the server is started.
+func (s *Server) EnableSnapshotPersistence(store SnapshotStore, recoveryPeriod time.Duration, maxProxies int) {
+	s.snapshots = &snapshotRecovery{
+		store:         store,
+		deadline:      time.Now().Add(recoveryPeriod),
+		maxProxies:    maxProxies,
+		evictionDelay: recoveryPeriod,
+		recovering:    make(map[*envoy.Proxy]struct{}),
+		persisted:     make(map[certificate.CommonName]persistedProxy),
+		podProxies:    make(map[string]certificate.CommonName),
+		evictions:     make(map[certificate.CommonName]*time.Timer),
+		pending:       make(map[certificate.CommonName]*pendingSnapshots),
+		wakeup:        make(chan struct{}, 1),
+	}
+	s.snapshots.stop = s.snapshots.snapshotWriter()
+	time.AfterFunc(recoveryPeriod, s.snapshots.endRecovery)
+}
+
+// getPersistedResponse returns the validated persisted response of the given xDS type for the given proxy, if the
+// recovery period is ongoing and the proxy has not been sent a response of that type yet. Secrets are never persisted.
+func (r *snapshotRecovery) getPersistedResponse(proxy *envoy.Proxy, typeURI envoy.TypeURI) *xds_discovery.DiscoveryResponse {
+	if typeURI == envoy.TypeSDS || time.Now().After(r.deadline) || proxy.GetLastSentNonce(typeURI) != "" {
+		return nil
+	}
+
+	response, err := r.store.Load(proxy.GetCertificateCommonName(), typeURI)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error loading persisted %s config of proxy with SerialNumber=%s on Pod with UID=%s", envoy.XDSShortURINames[typeURI], proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return nil
+	}
+	if response == nil {
+		return nil
+	}
+	if err := validateSnapshot(response, typeURI); err != nil {
+		log.Warn().Err(err).Msgf("Not serving persisted %s config of proxy with SerialNumber=%s on Pod with UID=%s", envoy.XDSShortURINames[typeURI], proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return nil
+	}
+
+	r.Lock()
+	r.recovering[proxy] = struct{}{}
+	r.Unlock()
+	return response
+}
+
+// save schedules persisting the given computed response of the given proxy. Secrets are never persisted.
+func (r *snapshotRecovery) save(proxy *envoy.Proxy, response *xds_discovery.DiscoveryResponse) {
+	typeURI := envoy.TypeURI(response.TypeUrl)
+	if typeURI == envoy.TypeSDS {
+		return
+	}
+	cn := proxy.GetCertificateCommonName()
+
+	r.Lock()
+	if timer, ok := r.evictions[cn]; ok {
+		timer.Stop()
+		delete(r.evictions, cn)
+	}
+	if _, ok := r.persisted[cn]; !ok && r.maxProxies > 0 && len(r.persisted) >= r.maxProxies {
+		r.evictLeastRecentlySavedLocked()
+	}
+	persisted := persistedProxy{savedAt: time.Now(), podUID: proxy.GetPodUID()}
+	r.persisted[cn] = persisted
+	if persisted.podUID != "" {
+		r.podProxies[persisted.podUID] = cn
+	}
+	pending, ok := r.pending[cn]
+	if !ok {
+		pending = &pendingSnapshots{}
+		r.pending[cn] = pending
+	}
+	if pending.responses == nil {
+		pending.responses = make(map[envoy.TypeURI]*xds_discovery.DiscoveryResponse)
+	}
+	// The response is copied, as its nonce and version are set while it is being persisted
+	pending.responses[typeURI] = proto.Clone(response).(*xds_discovery.DiscoveryResponse)
+	r.Unlock()
+
+	r.wakeupWriter()
+}
+
+// forget schedules evicting the snapshots of the given disconnected proxy, unless it reconnects and is sent recomputed
+// config before the eviction delay elapses. A proxy disconnecting because the control plane shuts down keeps its
+// snapshots, as the control plane does not outlive the eviction delay.
+func (r *snapshotRecovery) forget(proxy *envoy.Proxy) {
+	cn := proxy.GetCertificateCommonName()
+
+	r.Lock()
+	defer r.Unlock()
+	delete(r.recovering, proxy)
+	if _, ok := r.persisted[cn]; !ok {
+		return
+	}
+	if timer, ok := r.evictions[cn]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(r.evictionDelay, func() {
+		r.Lock()
+		if r.evictions[cn] == timer {
+			delete(r.evictions, cn)
+			r.evictLocked(cn)
+		}
+		r.Unlock()
+		r.wakeupWriter()
+	})
+	r.evictions[cn] = timer
+}
+
+// evictPod evicts the snapshots of the proxy of the deleted pod with the given UID
+func (r *snapshotRecovery) evictPod(podUID string) {
+	r.Lock()
+	cn, ok := r.podProxies[podUID]
+	if ok {
+		if timer, found := r.evictions[cn]; found {
+			timer.Stop()
+			delete(r.evictions, cn)
+		}
+		r.evictLocked(cn)
+	}
+	r.Unlock()
+
+	if ok {
+		log.Debug().Msgf("Evicting persisted config of proxy %s of deleted Pod with UID=%s", cn, podUID)
+		r.wakeupWriter()
+	}
+}
+
+// evictLeastRecentlySavedLocked evicts the snapshots of the proxy whose snapshots were saved the least recently.
+// The lock must be held.
+func (r *snapshotRecovery) evictLeastRecentlySavedLocked() {
+	var oldestCN certificate.CommonName
+	var oldest time.Time
+	for cn, persisted := range r.persisted {
+		if oldestCN == "" || persisted.savedAt.Before(oldest) {
+			oldestCN, oldest = cn, persisted.savedAt
+		}
+	}
+	log.Debug().Msgf("Persisted config of %d proxies, evicting the least recently saved persisted config of proxy %s", r.maxProxies, oldestCN)
+	r.evictLocked(oldestCN)
+}
+
+// evictLocked schedules deleting the snapshots of the proxy with the given certificate common name, replacing its
+// pending writes. The lock must be held.
+func (r *snapshotRecovery) evictLocked(cn certificate.CommonName) {
+	if persisted, ok := r.persisted[cn]; ok {
+		if r.podProxies[persisted.podUID] == cn {
+			delete(r.podProxies, persisted.podUID)
+		}
+		delete(r.persisted, cn)
+	}
+	r.pending[cn] = &pendingSnapshots{evict: true}
+}
+
+// wakeupWriter notifies the writer goroutine of pending writes without blocking
+func (r *snapshotRecovery) wakeupWriter() {
+	select {
+	case r.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// snapshotWriter performs the pending snapshot writes, and evicts the snapshots of the proxies of deleted pods.
+// It returns a stop channel which can be used to stop the inner handler.
+func (r *snapshotRecovery) snapshotWriter() chan struct{} {
+	podDeleteSubscription := events.GetPubSubInstance().Subscribe(announcements.PodDeleted)
+	stop := make(chan struct{})
+
+	go func() {
+		defer events.GetPubSubInstance().Unsub(podDeleteSubscription)
+		for {
+			select {
+			case <-stop:
+				return
+			case podDeletedMsg := <-podDeleteSubscription:
+				psubMessage, castOk := podDeletedMsg.(events.PubSubMessage)
+				if !castOk {
+					log.Error().Msgf("Error casting PubSubMessage: %v", psubMessage)
+					continue
+				}
+				deletedPodObj, castOk := psubMessage.OldObj.(*v1.Pod)
+				if !castOk {
+					log.Error().Msgf("Failed to cast to *v1.Pod: %v", psubMessage.OldObj)
+					continue
+				}
+				r.evictPod(string(deletedPodObj.GetUID()))
+			case <-r.wakeup:
+				r.writePending()
+			}
+		}
+	}()
+
+	return stop
+}
+
+// writePending performs the pending snapshot writes
+func (r *snapshotRecovery) writePending() {
+	r.Lock()
+	pending := r.pending
+	r.pending = make(map[certificate.CommonName]*pendingSnapshots)
+	r.Unlock()
+
+	for cn, snapshots := range pending {
+		if snapshots.evict {
+			if err := r.store.Delete(cn); err != nil {
+				log.Error().Err(err).Msgf("Error evicting persisted config of proxy %s", cn)
+			}
+		}
+		for typeURI, response := range snapshots.responses {
+			if err := r.store.Save(cn, response); err != nil {
+				log.Error().Err(err).Msgf("Error persisting %s config of proxy %s", envoy.XDSShortURINames[typeURI], cn)
+			}
+		}
+	}
+}
+
+// endRecovery sends their recomputed config to the proxies served a persisted snapshot, and evicts the snapshots of
+// the proxies which did not reconnect during the recovery period
+func (r *snapshotRecovery) endRecovery() {
+	persistedCNs, err := r.store.List()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing persisted xDS config")
+	}
+
+	r.Lock()
+	recovering := r.recovering
+	r.recovering = make(map[*envoy.Proxy]struct{})
+	reconnected := make(map[certificate.CommonName]struct{}, len(recovering))
+	for proxy := range recovering {
+		reconnected[proxy.GetCertificateCommonName()] = struct{}{}
+	}
+	for _, cn := range persistedCNs {
+		_, saved := r.persisted[cn]
+		_, served := reconnected[cn]
+		if !saved && !served {
+			r.evictLocked(cn)
+		}
+	}
+	r.Unlock()
+	r.wakeupWriter()
+
+	log.Info().Msgf("xDS snapshot recovery period ended, sending recomputed config to %d proxies", len(recovering))
+	for proxy := range recovering {
+		go notifyProxy(proxy)
+	}
+}
+
+// getPersistedResponse returns the persisted response of the given xDS type to serve to the given proxy, or nil if
+// snapshot persistence is disabled or no persisted response should be served
+func (s *Server) getPersistedResponse(proxy *envoy.Proxy, typeURI envoy.TypeURI) *xds_discovery.DiscoveryResponse {
+	if s.snapshots == nil {
+		return nil
+	}
+	return s.snapshots.getPersistedResponse(proxy, typeURI)
+}
+
+// forgetPersistedResponses schedules evicting the persisted responses of the given disconnected proxy, if snapshot
+// persistence is enabled
+func (s *Server) forgetPersistedResponses(proxy *envoy.Proxy) {
+	if s.snapshots == nil {
+		return
+	}
+	s.snapshots.forget(proxy)
+}
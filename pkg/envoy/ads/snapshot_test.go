@@ -0,0 +1,252 @@
+package ads
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+)
+
+var _ = Describe("Test xDS snapshot persistence", func() {
+	var (
+		dir   string
+		store SnapshotStore
+	)
+
+	proxyCN := certificate.CommonName("proxy.sa.ns.cluster.local")
+
+	newClusterResponse := func(clusterName string) *xds_discovery.DiscoveryResponse {
+		marshalled, err := ptypes.MarshalAny(&xds_cluster.Cluster{Name: clusterName})
+		Expect(err).ToNot(HaveOccurred())
+		return &xds_discovery.DiscoveryResponse{TypeUrl: string(envoy.TypeCDS), Resources: []*any.Any{marshalled}}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "xds-snapshots")
+		Expect(err).ToNot(HaveOccurred())
+		store, err = NewFileSnapshotStore(dir)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("Test fileSnapshotStore", func() {
+		It("loads the last saved snapshot", func() {
+			Expect(store.Save(proxyCN, newClusterResponse("first"))).To(Succeed())
+			Expect(store.Save(proxyCN, newClusterResponse("second"))).To(Succeed())
+
+			loaded, err := store.Load(proxyCN, envoy.TypeCDS)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(proto.Equal(loaded, newClusterResponse("second"))).To(BeTrue())
+
+			// No temporary files are left behind
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+		})
+
+		It("deletes and lists the snapshots of proxies", func() {
+			otherCN := certificate.CommonName("other.sa.ns.cluster.local")
+			Expect(store.Save(proxyCN, newClusterResponse("cluster"))).To(Succeed())
+			Expect(store.Save(otherCN, newClusterResponse("cluster"))).To(Succeed())
+
+			listed, err := store.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(listed).To(ConsistOf(proxyCN, otherCN))
+
+			Expect(store.Delete(proxyCN)).To(Succeed())
+			loaded, err := store.Load(proxyCN, envoy.TypeCDS)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded).To(BeNil())
+
+			listed, err = store.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(listed).To(ConsistOf(otherCN))
+		})
+
+		It("returns no snapshot when none was saved", func() {
+			Expect(store.Save(proxyCN, newClusterResponse("cluster"))).To(Succeed())
+
+			loaded, err := store.Load(proxyCN, envoy.TypeLDS)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded).To(BeNil())
+
+			loaded, err = store.Load("other.sa.ns.cluster.local", envoy.TypeCDS)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded).To(BeNil())
+		})
+	})
+
+	Context("Test validateSnapshot()", func() {
+		It("accepts a valid snapshot", func() {
+			Expect(validateSnapshot(newClusterResponse("cluster"), envoy.TypeCDS)).To(Succeed())
+		})
+
+		It("rejects a snapshot of another type", func() {
+			Expect(validateSnapshot(newClusterResponse("cluster"), envoy.TypeLDS)).ToNot(Succeed())
+		})
+
+		It("rejects a snapshot with invalid resources", func() {
+			// A cluster must have a name
+			Expect(validateSnapshot(newClusterResponse(""), envoy.TypeCDS)).ToNot(Succeed())
+
+			response := newClusterResponse("cluster")
+			response.Resources[0].Value = []byte("not a cluster")
+			Expect(validateSnapshot(response, envoy.TypeCDS)).ToNot(Succeed())
+		})
+	})
+
+	Context("Test serving persisted snapshots", func() {
+		var (
+			s           *Server
+			clusterName string
+		)
+
+		getClusterNames := func(response *xds_discovery.DiscoveryResponse) []string {
+			var names []string
+			for _, resource := range response.Resources {
+				cluster := &xds_cluster.Cluster{}
+				Expect(ptypes.UnmarshalAny(resource, cluster)).To(Succeed())
+				names = append(names, cluster.Name)
+			}
+			return names
+		}
+
+		BeforeEach(func() {
+			clusterName = "recomputed"
+			s = &Server{
+				xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error){
+					envoy.TypeCDS: func(catalog.MeshCataloger, *envoy.Proxy, *xds_discovery.DiscoveryRequest, configurator.Configurator, certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
+						return newClusterResponse(clusterName), nil
+					},
+				},
+				configPins: newProxyConfigPins(),
+			}
+		})
+
+		AfterEach(func() {
+			if s.snapshots != nil {
+				close(s.snapshots.stop)
+			}
+		})
+
+		isPersisted := func(cn certificate.CommonName) func() bool {
+			return func() bool {
+				loaded, err := store.Load(cn, envoy.TypeCDS)
+				Expect(err).ToNot(HaveOccurred())
+				return loaded != nil
+			}
+		}
+
+		It("serves the persisted snapshot to a connecting proxy during the recovery period", func() {
+			Expect(store.Save(proxyCN, newClusterResponse("persisted"))).To(Succeed())
+			s.EnableSnapshotPersistence(store, time.Hour, 10)
+
+			proxy := envoy.NewProxy(proxyCN, "", nil)
+			request := &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}
+			response, err := s.newAggregatedDiscoveryResponse(proxy, request, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getClusterNames(response)).To(ConsistOf("persisted"))
+
+			// Subsequent responses are recomputed and persisted asynchronously
+			response, err = s.newAggregatedDiscoveryResponse(proxy, request, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getClusterNames(response)).To(ConsistOf("recomputed"))
+
+			Eventually(func() []string {
+				loaded, err := store.Load(proxyCN, envoy.TypeCDS)
+				Expect(err).ToNot(HaveOccurred())
+				return getClusterNames(loaded)
+			}).Should(ConsistOf("recomputed"))
+		})
+
+		It("does not serve an invalid persisted snapshot", func() {
+			Expect(store.Save(proxyCN, newClusterResponse(""))).To(Succeed())
+			s.EnableSnapshotPersistence(store, time.Hour, 10)
+
+			response, err := s.newAggregatedDiscoveryResponse(envoy.NewProxy(proxyCN, "", nil), &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getClusterNames(response)).To(ConsistOf("recomputed"))
+		})
+
+		It("sends the recomputed config once the recovery period ends", func() {
+			Expect(store.Save(proxyCN, newClusterResponse("persisted"))).To(Succeed())
+			s.EnableSnapshotPersistence(store, 50*time.Millisecond, 10)
+
+			proxy := envoy.NewProxy(proxyCN, "", nil)
+			response, err := s.newAggregatedDiscoveryResponse(proxy, &xds_discovery.DiscoveryRequest{TypeUrl: string(envoy.TypeCDS)}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getClusterNames(response)).To(ConsistOf("persisted"))
+
+			Eventually(proxy.GetAnnouncementsChannel()).Should(Receive())
+		})
+
+		It("bounds the number of proxies with persisted snapshots", func() {
+			s.EnableSnapshotPersistence(store, time.Hour, 1)
+			otherCN := certificate.CommonName("other.sa.ns.cluster.local")
+
+			s.snapshots.save(envoy.NewProxy(proxyCN, "", nil), newClusterResponse("cluster"))
+			Eventually(isPersisted(proxyCN)).Should(BeTrue())
+
+			// The least recently saved proxy is evicted
+			s.snapshots.save(envoy.NewProxy(otherCN, "", nil), newClusterResponse("cluster"))
+			Eventually(isPersisted(otherCN)).Should(BeTrue())
+			Eventually(isPersisted(proxyCN)).Should(BeFalse())
+		})
+
+		It("evicts the snapshots of the proxy of a deleted pod", func() {
+			s.EnableSnapshotPersistence(store, time.Hour, 10)
+			proxy := envoy.NewProxy(proxyCN, "", nil)
+			proxy.PodMetadata = &envoy.PodMetadata{UID: "pod-uid"}
+
+			s.snapshots.save(proxy, newClusterResponse("cluster"))
+			Eventually(isPersisted(proxyCN)).Should(BeTrue())
+
+			events.GetPubSubInstance().Publish(events.PubSubMessage{
+				AnnouncementType: announcements.PodDeleted,
+				OldObj:           &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: types.UID("pod-uid")}},
+			})
+			Eventually(isPersisted(proxyCN)).Should(BeFalse())
+		})
+
+		It("evicts the snapshots of a disconnected proxy unless it reconnects within the recovery period", func() {
+			s.EnableSnapshotPersistence(store, 50*time.Millisecond, 10)
+			otherCN := certificate.CommonName("other.sa.ns.cluster.local")
+			proxy := envoy.NewProxy(proxyCN, "", nil)
+			otherProxy := envoy.NewProxy(otherCN, "", nil)
+
+			s.snapshots.save(proxy, newClusterResponse("cluster"))
+			s.snapshots.save(otherProxy, newClusterResponse("cluster"))
+			Eventually(isPersisted(proxyCN)).Should(BeTrue())
+			Eventually(isPersisted(otherCN)).Should(BeTrue())
+
+			s.forgetPersistedResponses(proxy)
+			s.forgetPersistedResponses(otherProxy)
+			// The other proxy reconnects and is sent recomputed config
+			s.snapshots.save(envoy.NewProxy(otherCN, "", nil), newClusterResponse("cluster"))
+
+			Eventually(isPersisted(proxyCN)).Should(BeFalse())
+			Consistently(isPersisted(otherCN), 200*time.Millisecond).Should(BeTrue())
+		})
+	})
+})
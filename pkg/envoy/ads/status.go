@@ -0,0 +1,52 @@
+package ads
+
+import (
+	"sort"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// status returns the status of the config of the connected proxies, sorted by certificate common name
+func (p *proxyConfigPins) status() []envoy.ProxyConfigStatus {
+	p.Lock()
+	defer p.Unlock()
+
+	statuses := make([]envoy.ProxyConfigStatus, 0, len(p.proxies))
+	for cn, state := range p.proxies {
+		status := envoy.ProxyConfigStatus{
+			CommonName:   cn,
+			SerialNumber: state.proxy.GetCertificateSerialNumber(),
+			ConnectedAt:  state.proxy.GetConnectedAt(),
+			Types:        make(map[envoy.TypeURI]envoy.XDSTypeConfigStatus, len(state.sent)),
+		}
+		if podMetadata := state.proxy.PodMetadata; podMetadata != nil {
+			status.PodUID = podMetadata.UID
+			status.Namespace = podMetadata.Namespace
+			status.ServiceAccount = podMetadata.ServiceAccount
+		}
+
+		for typeURI, sent := range state.sent {
+			typeStatus := envoy.XDSTypeConfigStatus{
+				SentVersion: sent.VersionInfo,
+				SentNonce:   sent.Nonce,
+			}
+			if acked, ok := state.acked[typeURI]; ok {
+				typeStatus.AckedVersion = acked.VersionInfo
+				typeStatus.UpToDate = acked == sent
+			}
+			status.Types[typeURI] = typeStatus
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].CommonName < statuses[j].CommonName
+	})
+	return statuses
+}
+
+// ListProxyConfigStatus returns the status of the config of the proxies connected to the xDS server, reflecting the
+// config last sent to each proxy on its stream and whether the proxy acknowledged it
+func (s *Server) ListProxyConfigStatus() []envoy.ProxyConfigStatus {
+	return s.configPins.status()
+}
@@ -0,0 +1,77 @@
+package ads
+
+import (
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+var _ = Describe("Test the status of the config of the connected proxies", func() {
+	var (
+		s      *Server
+		proxyA *envoy.Proxy
+		proxyB *envoy.Proxy
+	)
+
+	// send records a response of the given xDS type as sent to the given proxy
+	send := func(proxy *envoy.Proxy, typeURI envoy.TypeURI, version string) *xds_discovery.DiscoveryResponse {
+		response := &xds_discovery.DiscoveryResponse{TypeUrl: string(typeURI), VersionInfo: version, Nonce: "nonce-" + version}
+		s.configPins.recordSent(proxy, typeURI, response)
+		return response
+	}
+
+	BeforeEach(func() {
+		s = &Server{configPins: newProxyConfigPins()}
+		proxyA = envoy.NewProxy(certificate.CommonName("a.sa.ns.cluster.local"), "1", nil)
+		proxyA.PodMetadata = &envoy.PodMetadata{UID: "pod-a", Namespace: "ns", ServiceAccount: "sa"}
+		proxyB = envoy.NewProxy(certificate.CommonName("b.sa.ns.cluster.local"), "2", nil)
+	})
+
+	It("lists no proxies when none are connected", func() {
+		Expect(s.ListProxyConfigStatus()).To(BeEmpty())
+	})
+
+	It("reports whether each connected proxy acknowledged the config last sent to it", func() {
+		send(proxyA, envoy.TypeCDS, "1")
+		s.configPins.recordAck(proxyA, envoy.TypeCDS, "nonce-1")
+		send(proxyA, envoy.TypeLDS, "1")
+		s.configPins.recordAck(proxyA, envoy.TypeLDS, "nonce-1")
+		// The proxy is stuck on the previous LDS config
+		send(proxyA, envoy.TypeLDS, "2")
+
+		send(proxyB, envoy.TypeCDS, "1")
+
+		statuses := s.ListProxyConfigStatus()
+		Expect(statuses).To(HaveLen(2))
+
+		Expect(statuses[0].CommonName).To(Equal(proxyA.GetCertificateCommonName()))
+		Expect(statuses[0].SerialNumber).To(Equal(certificate.SerialNumber("1")))
+		Expect(statuses[0].PodUID).To(Equal("pod-a"))
+		Expect(statuses[0].Namespace).To(Equal("ns"))
+		Expect(statuses[0].ServiceAccount).To(Equal("sa"))
+		Expect(statuses[0].Types).To(Equal(map[envoy.TypeURI]envoy.XDSTypeConfigStatus{
+			envoy.TypeCDS: {SentVersion: "1", SentNonce: "nonce-1", AckedVersion: "1", UpToDate: true},
+			envoy.TypeLDS: {SentVersion: "2", SentNonce: "nonce-2", AckedVersion: "1", UpToDate: false},
+		}))
+
+		Expect(statuses[1].CommonName).To(Equal(proxyB.GetCertificateCommonName()))
+		Expect(statuses[1].PodUID).To(BeEmpty())
+		Expect(statuses[1].Types).To(Equal(map[envoy.TypeURI]envoy.XDSTypeConfigStatus{
+			envoy.TypeCDS: {SentVersion: "1", SentNonce: "nonce-1", UpToDate: false},
+		}))
+	})
+
+	It("stops listing a proxy once its stream is closed", func() {
+		send(proxyA, envoy.TypeCDS, "1")
+		send(proxyB, envoy.TypeCDS, "1")
+
+		s.configPins.forget(proxyA)
+
+		statuses := s.ListProxyConfigStatus()
+		Expect(statuses).To(HaveLen(1))
+		Expect(statuses[0].CommonName).To(Equal(proxyB.GetCertificateCommonName()))
+	})
+})
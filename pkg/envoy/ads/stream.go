@@ -37,6 +37,9 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 	s.catalog.RegisterProxy(proxy) // First of Two invocations.  Second one will be during xDS hand-shake!
 
 	defer s.catalog.UnregisterProxy(proxy)
+	defer s.configPins.forget(proxy)
+	defer s.rememberDisconnectedProxy(proxy)
+	defer s.forgetPersistedResponses(proxy)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -53,8 +56,17 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 	// Issues a send all response on a connecting envoy
 	// If this were to fail, it most likely just means we still have configuration being applied on flight,
-	// which will get triggered by the dispatcher anyway
-	s.sendAllResponses(proxy, &server, s.cfg)
+	// which will get triggered by the dispatcher anyway.
+	// A proxy reconnecting within the grace period resumes from its previous config state and is only sent the config
+	// which changed while it was disconnected.
+	if s.restoreReconnectedProxy(proxy) {
+		s.sendChangedResponses(proxy, &server, s.cfg)
+	} else {
+		s.sendAllResponses(proxy, &server, s.cfg)
+	}
+
+	// Tracks whether the pod's Envoy config ACK readiness condition has been set
+	podConfigAcked := false
 
 	for {
 		select {
@@ -117,7 +129,7 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 				proxy.GetCertificateSerialNumber(),
 				proxy.GetPodUID())
 
-			proxy.SetLastAppliedVersion(typeURL, ackVersion)
+			s.recordAck(proxy, typeURL, ackVersion, discoveryRequest.ResponseNonce, &podConfigAcked)
 
 			// In the DiscoveryRequest we have a VersionInfo field.
 			// When this is smaller or equal to what we last sent to this proxy - it is
@@ -159,9 +171,10 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 					envoy.XDSShortURINames[typeURL], proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
 			}
 
-		case <-broadcastUpdate:
-			log.Debug().Msgf("Broadcast update received for Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
-			s.sendAllResponses(proxy, &server, s.cfg)
+		case broadcastMessage := <-broadcastUpdate:
+			typeURIs := getBroadcastTypeURIs(broadcastMessage)
+			log.Debug().Msgf("Broadcast update for %v received for Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s", typeURIs, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+			s.sendResponses(proxy, &server, s.cfg, typeURIs...)
 
 		case <-proxy.GetAnnouncementsChannel():
 			log.Debug().Msgf("Individual update for Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
@@ -169,3 +182,37 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 		}
 	}
 }
+
+// recordAck records the given version of the config of the given xDS type as applied by the proxy, acknowledging the
+// response with the given nonce, and marks the Envoy config ACK condition on the pod once the proxy applied its initial
+// config. The condition is only set on pods with the readiness gate, which keep getting it after the readiness gate is
+// disabled for newly injected pods. podConfigAcked tracks whether the pod has been handled over the lifetime of the
+// proxy's stream.
+func (s *Server) recordAck(proxy *envoy.Proxy, typeURI envoy.TypeURI, version uint64, nonce string, podConfigAcked *bool) {
+	proxy.SetLastAppliedVersion(typeURI, version)
+	s.configPins.recordAck(proxy, typeURI, nonce)
+
+	if !*podConfigAcked && hasAckedInitialConfig(proxy) {
+		if err := s.markPodConfigAcked(proxy); err != nil {
+			log.Error().Err(err).Msgf("Error marking Envoy config ACK condition on Pod with UID=%s", proxy.GetPodUID())
+		} else {
+			*podConfigAcked = true
+		}
+	}
+}
+
+// getBroadcastTypeURIs returns the xDS types affected by a proxy broadcast message.
+// All xDS types are considered affected when the message does not specify any.
+func getBroadcastTypeURIs(message interface{}) []envoy.TypeURI {
+	psubMessage, ok := message.(events.PubSubMessage)
+	if !ok {
+		return envoy.XDSResponseOrder
+	}
+
+	typeURIs, ok := psubMessage.NewObj.([]envoy.TypeURI)
+	if !ok || len(typeURIs) == 0 {
+		return envoy.XDSResponseOrder
+	}
+
+	return typeURIs
+}
@@ -0,0 +1,30 @@
+package ads
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+)
+
+var _ = Describe("Test ADS stream functions", func() {
+	Context("Test getBroadcastTypeURIs()", func() {
+		It("returns the xDS types carried by the broadcast message", func() {
+			message := events.PubSubMessage{
+				AnnouncementType: announcements.ProxyBroadcast,
+				NewObj:           []envoy.TypeURI{envoy.TypeLDS, envoy.TypeRDS},
+			}
+			Expect(getBroadcastTypeURIs(message)).To(Equal([]envoy.TypeURI{envoy.TypeLDS, envoy.TypeRDS}))
+		})
+
+		It("returns all xDS types when the broadcast message does not specify any", func() {
+			message := events.PubSubMessage{
+				AnnouncementType: announcements.ProxyBroadcast,
+			}
+			Expect(getBroadcastTypeURIs(message)).To(Equal(envoy.XDSResponseOrder))
+			Expect(getBroadcastTypeURIs(nil)).To(Equal(envoy.XDSResponseOrder))
+		})
+	})
+})
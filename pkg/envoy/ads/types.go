@@ -4,11 +4,13 @@ import (
 	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
@@ -26,4 +28,20 @@ type Server struct {
 	cfg          configurator.Configurator
 	certManager  certificate.Manager
 	ready        bool
+
+	// stopped is closed when the gRPC server stops serving
+	stopped chan struct{}
+
+	// kubeClient and kubeController are used to mark the Envoy config ACK readiness gate on pods
+	kubeClient     kubernetes.Interface
+	kubeController k8s.Controller
+
+	// configPins tracks the config acknowledged by the proxies and the proxies pinned to it
+	configPins *proxyConfigPins
+
+	// snapshots persists the config computed for the proxies and serves it to them after a restart, if enabled
+	snapshots *snapshotRecovery
+
+	// disconnected keeps the config state of the disconnected proxies, so that reconnecting proxies resume from it
+	disconnected *disconnectedProxies
 }
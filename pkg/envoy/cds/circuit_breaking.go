@@ -0,0 +1,47 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyCircuitBreaking configures the circuit breaker thresholds of the given upstream cluster for the default and high
+// routing priorities, if any. The thresholds of a priority replace the ones already set on the cluster for that
+// priority, such as by backpressure, and a priority without thresholds is left unchanged, that is to Envoy's defaults
+// unless set otherwise.
+func applyCircuitBreaking(remoteCluster *xds_cluster.Cluster, circuitBreaking *trafficpolicy.CircuitBreaking) {
+	if circuitBreaking == nil {
+		return
+	}
+
+	if remoteCluster.CircuitBreakers == nil {
+		remoteCluster.CircuitBreakers = &xds_cluster.CircuitBreakers{}
+	}
+	setPriorityThresholds(remoteCluster.CircuitBreakers, xds_core.RoutingPriority_DEFAULT, circuitBreaking.Default)
+	setPriorityThresholds(remoteCluster.CircuitBreakers, xds_core.RoutingPriority_HIGH, circuitBreaking.High)
+}
+
+// setPriorityThresholds sets the thresholds of the given routing priority on the given circuit breakers
+func setPriorityThresholds(circuitBreakers *xds_cluster.CircuitBreakers, priority xds_core.RoutingPriority, thresholds *trafficpolicy.CircuitBreakerThresholds) {
+	if thresholds == nil {
+		return
+	}
+
+	priorityThresholds := &xds_cluster.CircuitBreakers_Thresholds{
+		Priority:           priority,
+		MaxConnections:     getUInt32Value(thresholds.MaxConnections),
+		MaxPendingRequests: getUInt32Value(thresholds.MaxPendingRequests),
+		MaxRequests:        getUInt32Value(thresholds.MaxRequests),
+		MaxRetries:         getUInt32Value(thresholds.MaxRetries),
+	}
+
+	for i, existing := range circuitBreakers.Thresholds {
+		if existing.Priority == priority {
+			circuitBreakers.Thresholds[i] = priorityThresholds
+			return
+		}
+	}
+	circuitBreakers.Thresholds = append(circuitBreakers.Thresholds, priorityThresholds)
+}
@@ -1,11 +1,13 @@
 package cds
 
 import (
+	"fmt"
 	"time"
 
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_aggregate_cluster "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	"github.com/golang/protobuf/ptypes"
@@ -21,19 +23,28 @@ import (
 const (
 	// clusterConnectTimeout is the timeout duration used by Envoy to timeout connections to the cluster
 	clusterConnectTimeout = 1 * time.Second
+
+	// aggregateClusterType is the name of the Envoy cluster type used for aggregate clusters
+	aggregateClusterType = "envoy.clusters.aggregate"
 )
 
-// getUpstreamServiceCluster returns an Envoy Cluster corresponding to the given upstream service
-func getUpstreamServiceCluster(upstreamSvc, downstreamSvc service.MeshService, cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
+// getUpstreamServiceCluster returns an Envoy Cluster corresponding to the given upstream service.
+// The SNI used to connect to the upstream service is derived from the service unless sniOverride is set.
+func getUpstreamServiceCluster(upstreamSvc, downstreamSvc service.MeshService, cfg configurator.Configurator, sniOverride string) (*xds_cluster.Cluster, error) {
 	clusterName := upstreamSvc.String()
+	sni := upstreamSvc.ServerName()
+	if sniOverride != "" {
+		sni = sniOverride
+	}
 	marshalledUpstreamTLSContext, err := ptypes.MarshalAny(
-		envoy.GetUpstreamTLSContext(downstreamSvc, upstreamSvc))
+		envoy.GetUpstreamTLSContextWithSNI(downstreamSvc, upstreamSvc, sni, cfg))
 	if err != nil {
 		return nil, err
 	}
 
 	remoteCluster := &xds_cluster.Cluster{
 		Name:           clusterName,
+		AltStatName:    getClusterStatName(upstreamSvc),
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
 		TransportSocket: &xds_core.TransportSocket{
 			Name: wellknown.TransportSocketTls,
@@ -59,6 +70,40 @@ func getUpstreamServiceCluster(upstreamSvc, downstreamSvc service.MeshService, c
 	return remoteCluster, nil
 }
 
+// getClusterStatName returns the name used in the stats of the given upstream service's cluster, of the form <namespace>.<name>.
+// Separating the namespace and name with a dot allows extracting them as stats tags to reduce stats cardinality.
+func getClusterStatName(upstreamSvc service.MeshService) string {
+	return fmt.Sprintf("%s.%s", upstreamSvc.Namespace, upstreamSvc.Name)
+}
+
+// getFailoverCluster returns an Envoy aggregate cluster failing over from the cluster of the given upstream service
+// to the clusters of the given failover services, in order, when the preceding clusters are unhealthy.
+func getFailoverCluster(upstreamSvc service.MeshService, failoverServices []service.MeshService) (*xds_cluster.Cluster, error) {
+	memberClusters := []string{upstreamSvc.String()}
+	for _, failoverSvc := range failoverServices {
+		memberClusters = append(memberClusters, failoverSvc.String())
+	}
+
+	marshalledAggregateClusterConfig, err := ptypes.MarshalAny(&xds_aggregate_cluster.ClusterConfig{
+		Clusters: memberClusters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_cluster.Cluster{
+		Name:           envoy.GetFailoverClusterNameForService(upstreamSvc),
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_ClusterType{
+			ClusterType: &xds_cluster.Cluster_CustomClusterType{
+				Name:        aggregateClusterType,
+				TypedConfig: marshalledAggregateClusterConfig,
+			},
+		},
+		LbPolicy: xds_cluster.Cluster_CLUSTER_PROVIDED,
+	}, nil
+}
+
 // getOutboundPassthroughCluster returns an Envoy cluster that is used for outbound passthrough traffic
 func getOutboundPassthroughCluster() *xds_cluster.Cluster {
 	return &xds_cluster.Cluster{
@@ -73,6 +118,47 @@ func getOutboundPassthroughCluster() *xds_cluster.Cluster {
 	}
 }
 
+// getOutboundOriginalDstCluster returns an Envoy cluster forwarding the connections of the original_dst mode of the
+// outbound listener to their original destination
+func getOutboundOriginalDstCluster() *xds_cluster.Cluster {
+	return &xds_cluster.Cluster{
+		Name:           envoy.OutboundOriginalDstCluster,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_ORIGINAL_DST,
+		},
+		LbPolicy: xds_cluster.Cluster_CLUSTER_PROVIDED,
+	}
+}
+
+// getInboundPassthroughCluster returns an Envoy cluster passing inbound connections not matching any filter chain
+// through to their original destination on the local application
+func getInboundPassthroughCluster() *xds_cluster.Cluster {
+	return &xds_cluster.Cluster{
+		Name:           envoy.InboundPassthroughCluster,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_ORIGINAL_DST,
+		},
+		LbPolicy: xds_cluster.Cluster_CLUSTER_PROVIDED,
+	}
+}
+
+// getOutboundBlackholeCluster returns an Envoy cluster without endpoints, used for outbound traffic not matching any traffic policy.
+// HTTP requests routed to this cluster get a 503 response.
+func getOutboundBlackholeCluster() *xds_cluster.Cluster {
+	return &xds_cluster.Cluster{
+		Name:           envoy.OutboundBlackholeCluster,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_STATIC,
+		},
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: envoy.OutboundBlackholeCluster,
+		},
+	}
+}
+
 // getLocalServiceCluster returns an Envoy Cluster corresponding to the local service
 func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, clusterName string) (*xds_cluster.Cluster, error) {
 	xdsCluster := xds_cluster.Cluster{
@@ -1,14 +1,24 @@
 package cds
 
 import (
+	"fmt"
+
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_aggregate_cluster "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var _ = Describe("Cluster configurations", func() {
@@ -19,6 +29,9 @@ var _ = Describe("Cluster configurations", func() {
 
 	mockCtrl = gomock.NewController(GinkgoT())
 	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 
 	downstreamSvc := tests.BookbuyerService
 	upstreamSvc := tests.BookstoreV1Service
@@ -26,7 +39,7 @@ var _ = Describe("Cluster configurations", func() {
 		It("Returns an EDS based cluster when permissive mode is disabled", func() {
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
 
-			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator)
+			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_EDS))
 			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_ROUND_ROBIN))
@@ -36,11 +49,192 @@ var _ = Describe("Cluster configurations", func() {
 		It("Returns an Original Destination based cluster when permissive mode is enabled", func() {
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).Times(1)
 
-			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator)
+			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_ORIGINAL_DST))
 			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_CLUSTER_PROVIDED))
 			Expect(remoteCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL))
 		})
+
+		It("Returns a cluster whose upstream TLS context uses the SNI derived from the upstream service", func() {
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+
+			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+			err = ptypes.UnmarshalAny(remoteCluster.TransportSocket.GetTypedConfig(), upstreamTLSContext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(upstreamTLSContext.Sni).To(Equal(upstreamSvc.ServerName()))
+		})
+
+		It("Returns a cluster whose upstream TLS context uses the SNI override", func() {
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+
+			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "bookstore.example.com")
+			Expect(err).ToNot(HaveOccurred())
+
+			upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+			err = ptypes.UnmarshalAny(remoteCluster.TransportSocket.GetTypedConfig(), upstreamTLSContext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(upstreamTLSContext.Sni).To(Equal("bookstore.example.com"))
+		})
+
+		It("Returns a cluster with an alternate stat name while preserving the cluster name", func() {
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+
+			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remoteCluster.Name).To(Equal(upstreamSvc.String()))
+			Expect(remoteCluster.AltStatName).To(Equal(fmt.Sprintf("%s.%s", upstreamSvc.Namespace, upstreamSvc.Name)))
+		})
+	})
+
+	Context("Test getFailoverCluster", func() {
+		It("Returns an aggregate cluster failing over from the upstream service's cluster to its failover service's cluster", func() {
+			failoverCluster, err := getFailoverCluster(upstreamSvc, []service.MeshService{tests.BookstoreV2Service})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(failoverCluster.Name).To(Equal(envoy.GetFailoverClusterNameForService(upstreamSvc)))
+			Expect(failoverCluster.LbPolicy).To(Equal(xds_cluster.Cluster_CLUSTER_PROVIDED))
+
+			clusterType := failoverCluster.GetClusterType()
+			Expect(clusterType.Name).To(Equal("envoy.clusters.aggregate"))
+
+			aggregateClusterConfig := &xds_aggregate_cluster.ClusterConfig{}
+			err = ptypes.UnmarshalAny(clusterType.TypedConfig, aggregateClusterConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(aggregateClusterConfig.Clusters).To(Equal([]string{upstreamSvc.String(), tests.BookstoreV2Service.String()}))
+		})
+	})
+
+	Context("Test getOutboundBlackholeCluster", func() {
+		It("Returns a static cluster without endpoints", func() {
+			blackholeCluster := getOutboundBlackholeCluster()
+			Expect(blackholeCluster.Name).To(Equal(envoy.OutboundBlackholeCluster))
+			Expect(blackholeCluster.GetType()).To(Equal(xds_cluster.Cluster_STATIC))
+			Expect(blackholeCluster.LoadAssignment.ClusterName).To(Equal(envoy.OutboundBlackholeCluster))
+			Expect(blackholeCluster.LoadAssignment.Endpoints).To(BeEmpty())
+		})
+	})
+
+	Context("Test getInboundPassthroughCluster", func() {
+		It("Returns an original destination cluster", func() {
+			passthroughCluster := getInboundPassthroughCluster()
+			Expect(passthroughCluster.Name).To(Equal(envoy.InboundPassthroughCluster))
+			Expect(passthroughCluster.GetType()).To(Equal(xds_cluster.Cluster_ORIGINAL_DST))
+			Expect(passthroughCluster.LbPolicy).To(Equal(xds_cluster.Cluster_CLUSTER_PROVIDED))
+		})
+	})
+
+	Context("Test applyOutlierDetection", func() {
+		It("Leaves the outlier detection unset without overrides", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String()}
+
+			applyOutlierDetection(remoteCluster, nil)
+			Expect(remoteCluster.OutlierDetection).To(BeNil())
+		})
+
+		It("Caps the ejection of the endpoints of a two-endpoint service to one of them", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String()}
+
+			// Ejecting at most 50% of the 2 endpoints of the service always leaves one endpoint serving traffic
+			maxEjectionPercent := uint32(50)
+			consecutive5xx := uint32(10)
+			applyOutlierDetection(remoteCluster, &trafficpolicy.OutlierDetection{
+				Consecutive5xx:     &consecutive5xx,
+				MaxEjectionPercent: &maxEjectionPercent,
+			})
+			Expect(remoteCluster.OutlierDetection).ToNot(BeNil())
+			Expect(remoteCluster.OutlierDetection.MaxEjectionPercent.GetValue()).To(Equal(maxEjectionPercent))
+			Expect(remoteCluster.OutlierDetection.Consecutive_5Xx.GetValue()).To(Equal(consecutive5xx))
+			Expect(remoteCluster.OutlierDetection.EnforcingConsecutive_5Xx).To(BeNil())
+		})
+
+		It("Disables the ejection of endpoints due to consecutive 5xx responses", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String()}
+
+			enforcingConsecutive5xx := uint32(0)
+			applyOutlierDetection(remoteCluster, &trafficpolicy.OutlierDetection{
+				EnforcingConsecutive5xx: &enforcingConsecutive5xx,
+			})
+			Expect(remoteCluster.OutlierDetection.EnforcingConsecutive_5Xx).ToNot(BeNil())
+			Expect(remoteCluster.OutlierDetection.EnforcingConsecutive_5Xx.GetValue()).To(BeZero())
+			Expect(remoteCluster.OutlierDetection.MaxEjectionPercent).To(BeNil())
+		})
+
+		It("Ejects endpoints for local-origin failures separately from 5xx responses", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String()}
+
+			consecutiveLocalOriginFailure := uint32(3)
+			enforcingConsecutiveLocalOriginFailure := uint32(100)
+			applyOutlierDetection(remoteCluster, &trafficpolicy.OutlierDetection{
+				SplitExternalLocalOriginErrors:         true,
+				ConsecutiveLocalOriginFailure:          &consecutiveLocalOriginFailure,
+				EnforcingConsecutiveLocalOriginFailure: &enforcingConsecutiveLocalOriginFailure,
+			})
+			Expect(remoteCluster.OutlierDetection.SplitExternalLocalOriginErrors).To(BeTrue())
+			Expect(remoteCluster.OutlierDetection.ConsecutiveLocalOriginFailure.GetValue()).To(Equal(consecutiveLocalOriginFailure))
+			Expect(remoteCluster.OutlierDetection.EnforcingConsecutiveLocalOriginFailure.GetValue()).To(Equal(enforcingConsecutiveLocalOriginFailure))
+			Expect(remoteCluster.OutlierDetection.Consecutive_5Xx).To(BeNil())
+		})
+
+		It("Counts local-origin failures as 5xx responses by default", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String()}
+
+			consecutive5xx := uint32(10)
+			applyOutlierDetection(remoteCluster, &trafficpolicy.OutlierDetection{
+				Consecutive5xx: &consecutive5xx,
+			})
+			Expect(remoteCluster.OutlierDetection.SplitExternalLocalOriginErrors).To(BeFalse())
+			Expect(remoteCluster.OutlierDetection.ConsecutiveLocalOriginFailure).To(BeNil())
+			Expect(remoteCluster.OutlierDetection.EnforcingConsecutiveLocalOriginFailure).To(BeNil())
+		})
+	})
+
+	Context("Test applyCircuitBreaking", func() {
+		It("Leaves the circuit breakers unset without thresholds", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String()}
+
+			applyCircuitBreaking(remoteCluster, nil)
+			Expect(remoteCluster.CircuitBreakers).To(BeNil())
+		})
+
+		It("Replaces the default priority thresholds set by backpressure and leaves the high priority to Envoy's defaults", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String()}
+			backpressureMaxConnections := uint32(5)
+			remoteCluster.CircuitBreakers = &xds_cluster.CircuitBreakers{
+				Thresholds: makeThresholds(&backpressureMaxConnections),
+			}
+
+			maxRetries := uint32(3)
+			applyCircuitBreaking(remoteCluster, &trafficpolicy.CircuitBreaking{
+				Default: &trafficpolicy.CircuitBreakerThresholds{
+					MaxRetries: &maxRetries,
+				},
+			})
+			Expect(remoteCluster.CircuitBreakers.Thresholds).To(HaveLen(1))
+			Expect(remoteCluster.CircuitBreakers.Thresholds[0].Priority).To(Equal(xds_core.RoutingPriority_DEFAULT))
+			Expect(remoteCluster.CircuitBreakers.Thresholds[0].MaxRetries.GetValue()).To(Equal(maxRetries))
+			Expect(remoteCluster.CircuitBreakers.Thresholds[0].MaxConnections).To(BeNil())
+		})
+	})
+
+	Context("Test applyConsistentHashing", func() {
+		It("Load balances the cluster of a service with a hash policy by consistent hashing", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String(), LbPolicy: xds_cluster.Cluster_ROUND_ROBIN}
+
+			applyConsistentHashing(remoteCluster, nil)
+			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_ROUND_ROBIN))
+
+			applyConsistentHashing(remoteCluster, &trafficpolicy.HashPolicy{Header: "x-user-id"})
+			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_RING_HASH))
+		})
+
+		It("Leaves original destination clusters unchanged", func() {
+			remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String(), LbPolicy: xds_cluster.Cluster_CLUSTER_PROVIDED}
+
+			applyConsistentHashing(remoteCluster, &trafficpolicy.HashPolicy{Header: "x-user-id"})
+			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_CLUSTER_PROVIDED))
+		})
 	})
 })
@@ -0,0 +1,104 @@
+package cds
+
+import (
+	"sort"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+var errNoServicePorts = errors.New("service has no ports")
+
+// getClusterType returns the type of the cluster of the given upstream service, which is set by the service's
+// annotation or defaults to the mesh-wide default cluster type
+func getClusterType(meshCatalog catalog.MeshCataloger, upstreamSvc service.MeshService, cfg configurator.Configurator) string {
+	if clusterType := meshCatalog.GetClusterTypeForService(upstreamSvc); clusterType != "" {
+		return clusterType
+	}
+	return cfg.GetDefaultClusterType()
+}
+
+// getDNSLookupFamily returns the IP address family DNS clusters resolve DNS names to, set mesh-wide
+func getDNSLookupFamily(cfg configurator.Configurator) xds_cluster.Cluster_DnsLookupFamily {
+	switch cfg.GetDNSLookupFamily() {
+	case constants.DNSLookupFamilyV6Only:
+		return xds_cluster.Cluster_V6_ONLY
+	case constants.DNSLookupFamilyAuto:
+		return xds_cluster.Cluster_AUTO
+	default:
+		return xds_cluster.Cluster_V4_ONLY
+	}
+}
+
+// applyClusterType configures the service discovery of the given upstream cluster according to its cluster type.
+// EDS clusters are left unchanged. DNS clusters resolve the FQDN of the service to addresses of the configured IP
+// address family at the configured DNS refresh rate and connect to the service's lowest port. errNoServicePorts is
+// returned for a DNS cluster of a service without ports, which has no port to connect to.
+// Cluster types do not apply in permissive mode, where upstream clusters are original destination clusters.
+func applyClusterType(remoteCluster *xds_cluster.Cluster, upstreamSvc service.MeshService, meshCatalog catalog.MeshCataloger, cfg configurator.Configurator) error {
+	if cfg.IsPermissiveTrafficPolicyMode() {
+		return nil
+	}
+
+	var discoveryType xds_cluster.Cluster_DiscoveryType
+	switch getClusterType(meshCatalog, upstreamSvc, cfg) {
+	case constants.ClusterTypeStrictDNS:
+		discoveryType = xds_cluster.Cluster_STRICT_DNS
+	case constants.ClusterTypeLogicalDNS:
+		discoveryType = xds_cluster.Cluster_LOGICAL_DNS
+	default:
+		return nil
+	}
+
+	portToProtocol, err := meshCatalog.GetPortToProtocolMappingForService(upstreamSvc)
+	if err != nil {
+		return err
+	}
+	if len(portToProtocol) == 0 {
+		return errors.Wrapf(errNoServicePorts, "error configuring DNS cluster for service %s", upstreamSvc)
+	}
+	var ports []uint32
+	for port := range portToProtocol {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	remoteCluster.ClusterDiscoveryType = &xds_cluster.Cluster_Type{Type: discoveryType}
+	remoteCluster.EdsClusterConfig = nil
+	remoteCluster.DnsLookupFamily = getDNSLookupFamily(cfg)
+	applyDNSRefreshConfig(remoteCluster, upstreamSvc, meshCatalog, cfg)
+	remoteCluster.LoadAssignment = &xds_endpoint.ClusterLoadAssignment{
+		ClusterName: remoteCluster.Name,
+		Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+			{
+				LbEndpoints: []*xds_endpoint.LbEndpoint{
+					{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: &xds_core.Address{
+									Address: &xds_core.Address_SocketAddress{
+										SocketAddress: &xds_core.SocketAddress{
+											Protocol: xds_core.SocketAddress_TCP,
+											Address:  upstreamSvc.ServerName(),
+											PortSpecifier: &xds_core.SocketAddress_PortValue{
+												PortValue: ports[0],
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package cds
+
+import (
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Cluster types", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockCatalog      *catalog.MockMeshCataloger
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	downstreamSvc := tests.BookbuyerService
+	upstreamSvc := tests.BookstoreV1Service
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+		mockCatalog.EXPECT().IsDNSTTLRespectedForService(upstreamSvc).Return(false, false).AnyTimes()
+		mockCatalog.EXPECT().GetDNSRefreshRateForService(upstreamSvc).Return(time.Duration(0)).AnyTimes()
+		mockConfigurator.EXPECT().IsDNSTTLRespected().Return(true).AnyTimes()
+		mockConfigurator.EXPECT().GetDNSRefreshRate().Return(time.Duration(0)).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	getCluster := func() *xds_cluster.Cluster {
+		remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applyClusterType(remoteCluster, upstreamSvc, mockCatalog, mockConfigurator)).To(Succeed())
+		return remoteCluster
+	}
+
+	It("Leaves an EDS cluster unchanged with the default cluster type", func() {
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return("").Times(1)
+		mockConfigurator.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).Times(1)
+
+		remoteCluster := getCluster()
+		Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_EDS))
+		Expect(remoteCluster.EdsClusterConfig).ToNot(BeNil())
+		Expect(remoteCluster.LoadAssignment).To(BeNil())
+		Expect(remoteCluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_AUTO))
+	})
+
+	It("Returns a STRICT_DNS cluster resolving the service's FQDN when it is the mesh-wide default", func() {
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return("").Times(1)
+		mockConfigurator.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeStrictDNS).Times(1)
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{8080: "http", 80: "http"}, nil).Times(1)
+		mockConfigurator.EXPECT().GetDNSLookupFamily().Return(constants.DefaultDNSLookupFamily).Times(1)
+
+		remoteCluster := getCluster()
+		Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_STRICT_DNS))
+		Expect(remoteCluster.EdsClusterConfig).To(BeNil())
+		Expect(remoteCluster.RespectDnsTtl).To(BeTrue())
+		Expect(remoteCluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V4_ONLY))
+
+		Expect(remoteCluster.LoadAssignment.ClusterName).To(Equal(upstreamSvc.String()))
+		Expect(remoteCluster.LoadAssignment.Endpoints).To(HaveLen(1))
+		Expect(remoteCluster.LoadAssignment.Endpoints[0].LbEndpoints).To(HaveLen(1))
+		socketAddress := remoteCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress()
+		Expect(socketAddress.Address).To(Equal(upstreamSvc.ServerName()))
+		Expect(socketAddress.GetPortValue()).To(Equal(uint32(80)))
+	})
+
+	It("Returns a LOGICAL_DNS cluster when set by the service, overriding the mesh-wide default", func() {
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return(constants.ClusterTypeLogicalDNS).Times(1)
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{5432: "tcp"}, nil).Times(1)
+		mockConfigurator.EXPECT().GetDNSLookupFamily().Return(constants.DNSLookupFamilyV6Only).Times(1)
+
+		remoteCluster := getCluster()
+		Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_LOGICAL_DNS))
+		Expect(remoteCluster.EdsClusterConfig).To(BeNil())
+		socketAddress := remoteCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress()
+		Expect(socketAddress.GetPortValue()).To(Equal(uint32(5432)))
+		Expect(remoteCluster.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V6_ONLY))
+	})
+
+	It("Resolves the DNS name of a DNS cluster to both IPv6 and IPv4 addresses with the AUTO lookup family", func() {
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return(constants.ClusterTypeStrictDNS).Times(1)
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{80: "http"}, nil).Times(1)
+		mockConfigurator.EXPECT().GetDNSLookupFamily().Return(constants.DNSLookupFamilyAuto).Times(1)
+
+		Expect(getCluster().DnsLookupFamily).To(Equal(xds_cluster.Cluster_AUTO))
+	})
+
+	It("Returns an EDS cluster when set by the service, overriding a DNS mesh-wide default", func() {
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return(constants.ClusterTypeEDS).Times(1)
+
+		Expect(getCluster().GetType()).To(Equal(xds_cluster.Cluster_EDS))
+	})
+
+	It("Returns an error for a DNS cluster of a service without ports", func() {
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return(constants.ClusterTypeStrictDNS).Times(1)
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{}, nil).Times(1)
+
+		remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applyClusterType(remoteCluster, upstreamSvc, mockCatalog, mockConfigurator)).To(MatchError(ContainSubstring(errNoServicePorts.Error())))
+	})
+})
@@ -0,0 +1,29 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// applyDNSRefreshConfig configures how often the DNS name of the given DNS cluster of the given upstream service is
+// resolved. The refresh rate and whether the TTL of DNS records is respected are set by the service's annotations or
+// default to the mesh-wide settings. Envoy's default refresh rate applies when no refresh rate is configured.
+func applyDNSRefreshConfig(remoteCluster *xds_cluster.Cluster, upstreamSvc service.MeshService, meshCatalog catalog.MeshCataloger, cfg configurator.Configurator) {
+	respectDNSTTL, ok := meshCatalog.IsDNSTTLRespectedForService(upstreamSvc)
+	if !ok {
+		respectDNSTTL = cfg.IsDNSTTLRespected()
+	}
+	remoteCluster.RespectDnsTtl = respectDNSTTL
+
+	refreshRate := meshCatalog.GetDNSRefreshRateForService(upstreamSvc)
+	if refreshRate == 0 {
+		refreshRate = cfg.GetDNSRefreshRate()
+	}
+	if refreshRate > 0 {
+		remoteCluster.DnsRefreshRate = ptypes.DurationProto(refreshRate)
+	}
+}
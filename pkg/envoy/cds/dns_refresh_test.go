@@ -0,0 +1,89 @@
+package cds
+
+import (
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("DNS refresh config", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockCatalog      *catalog.MockMeshCataloger
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	downstreamSvc := tests.BookbuyerService
+	upstreamSvc := tests.BookstoreV1Service
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+		mockConfigurator.EXPECT().GetDNSLookupFamily().Return(constants.DefaultDNSLookupFamily).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	getCluster := func(clusterType string) *xds_cluster.Cluster {
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return(clusterType).Times(1)
+		remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applyClusterType(remoteCluster, upstreamSvc, mockCatalog, mockConfigurator)).To(Succeed())
+		return remoteCluster
+	}
+
+	It("Sets the mesh-wide DNS refresh rate on DNS clusters", func() {
+		mockCatalog.EXPECT().IsDNSTTLRespectedForService(upstreamSvc).Return(false, false).Times(1)
+		mockCatalog.EXPECT().GetDNSRefreshRateForService(upstreamSvc).Return(time.Duration(0)).Times(1)
+		mockConfigurator.EXPECT().IsDNSTTLRespected().Return(false).Times(1)
+		mockConfigurator.EXPECT().GetDNSRefreshRate().Return(2 * time.Second).Times(1)
+
+		remoteCluster := getCluster(constants.ClusterTypeStrictDNS)
+		Expect(remoteCluster.RespectDnsTtl).To(BeFalse())
+		Expect(remoteCluster.DnsRefreshRate).To(Equal(ptypes.DurationProto(2 * time.Second)))
+	})
+
+	It("Sets the DNS refresh rate and TTL policy of the service, overriding the mesh-wide settings", func() {
+		mockCatalog.EXPECT().IsDNSTTLRespectedForService(upstreamSvc).Return(false, true).Times(1)
+		mockCatalog.EXPECT().GetDNSRefreshRateForService(upstreamSvc).Return(500 * time.Millisecond).Times(1)
+
+		remoteCluster := getCluster(constants.ClusterTypeLogicalDNS)
+		Expect(remoteCluster.RespectDnsTtl).To(BeFalse())
+		Expect(remoteCluster.DnsRefreshRate).To(Equal(ptypes.DurationProto(500 * time.Millisecond)))
+	})
+
+	It("Leaves the Envoy default DNS refresh rate when not configured", func() {
+		mockCatalog.EXPECT().IsDNSTTLRespectedForService(upstreamSvc).Return(false, false).Times(1)
+		mockCatalog.EXPECT().GetDNSRefreshRateForService(upstreamSvc).Return(time.Duration(0)).Times(1)
+		mockConfigurator.EXPECT().IsDNSTTLRespected().Return(true).Times(1)
+		mockConfigurator.EXPECT().GetDNSRefreshRate().Return(time.Duration(0)).Times(1)
+
+		remoteCluster := getCluster(constants.ClusterTypeStrictDNS)
+		Expect(remoteCluster.RespectDnsTtl).To(BeTrue())
+		Expect(remoteCluster.DnsRefreshRate).To(BeNil())
+	})
+
+	It("Ignores the DNS refresh rate for EDS clusters", func() {
+		remoteCluster := getCluster(constants.ClusterTypeEDS)
+		Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_EDS))
+		Expect(remoteCluster.DnsRefreshRate).To(BeNil())
+		Expect(remoteCluster.RespectDnsTtl).To(BeFalse())
+	})
+})
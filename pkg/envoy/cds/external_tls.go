@@ -0,0 +1,38 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// applyExternalTLSOrigination replaces the mesh mTLS of the given cluster of the given upstream service with TLS
+// originated to a destination outside the mesh when set by the service's annotation. The destination's certificate is
+// validated with the CA bundle of the sidecar, which must be configured for the connections to succeed.
+func applyExternalTLSOrigination(remoteCluster *xds_cluster.Cluster, upstreamSvc service.MeshService, meshCatalog catalog.MeshCataloger, cfg configurator.Configurator) error {
+	if !meshCatalog.IsExternalTLSOriginationEnabledForService(upstreamSvc) {
+		return nil
+	}
+
+	sni := meshCatalog.GetUpstreamSNIForService(upstreamSvc)
+	if sni == "" {
+		sni = upstreamSvc.ServerName()
+	}
+	marshalledUpstreamTLSContext, err := ptypes.MarshalAny(envoy.GetExternalUpstreamTLSContext(sni, cfg))
+	if err != nil {
+		return err
+	}
+	remoteCluster.TransportSocket = &xds_core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &xds_core.TransportSocket_TypedConfig{
+			TypedConfig: marshalledUpstreamTLSContext,
+		},
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cds
+
+import (
+	"time"
+
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("External TLS origination", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockCatalog      *catalog.MockMeshCataloger
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	upstreamSvc := tests.BookstoreV1Service
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+		mockConfigurator.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+		mockConfigurator.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().GetHashPolicyForService(upstreamSvc).Return(nil).AnyTimes()
+		mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(upstreamSvc).Return(time.Duration(0)).AnyTimes()
+		mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(upstreamSvc).Return("").AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	getUpstreamTLSContext := func() *xds_auth.UpstreamTlsContext {
+		remoteCluster, err := buildUpstreamServiceCluster(mockCatalog, upstreamSvc, tests.BookbuyerService, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remoteCluster.TransportSocket).ToNot(BeNil())
+
+		upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+		err = ptypes.UnmarshalAny(remoteCluster.TransportSocket.GetTypedConfig(), upstreamTLSContext)
+		Expect(err).ToNot(HaveOccurred())
+		return upstreamTLSContext
+	}
+
+	It("Originates mesh mTLS when not set by the service", func() {
+		mockCatalog.EXPECT().GetUpstreamSNIForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(upstreamSvc).Return(false).Times(1)
+
+		upstreamTLSContext := getUpstreamTLSContext()
+		Expect(upstreamTLSContext.CommonTlsContext.GetValidationContextSdsSecretConfig().GetName()).ToNot(Equal(envoy.ExternalCABundleSecretName))
+		Expect(upstreamTLSContext.CommonTlsContext.TlsCertificateSdsSecretConfigs).ToNot(BeEmpty())
+	})
+
+	It("Originates TLS validated by the CA bundle of the sidecar when set by the service", func() {
+		mockCatalog.EXPECT().GetUpstreamSNIForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(upstreamSvc).Return(true).Times(1)
+
+		upstreamTLSContext := getUpstreamTLSContext()
+		Expect(upstreamTLSContext.CommonTlsContext.GetValidationContextSdsSecretConfig().GetName()).To(Equal(envoy.ExternalCABundleSecretName))
+		Expect(upstreamTLSContext.CommonTlsContext.TlsCertificateSdsSecretConfigs).To(BeEmpty())
+		Expect(upstreamTLSContext.Sni).To(Equal(upstreamSvc.ServerName()))
+	})
+
+	It("Originates TLS with the SNI override of the service", func() {
+		mockCatalog.EXPECT().GetUpstreamSNIForService(upstreamSvc).Return("api.example.com").AnyTimes()
+		mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(upstreamSvc).Return(true).Times(1)
+
+		upstreamTLSContext := getUpstreamTLSContext()
+		Expect(upstreamTLSContext.CommonTlsContext.GetValidationContextSdsSecretConfig().GetName()).To(Equal(envoy.ExternalCABundleSecretName))
+		Expect(upstreamTLSContext.Sni).To(Equal("api.example.com"))
+	})
+})
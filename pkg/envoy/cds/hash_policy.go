@@ -0,0 +1,18 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyConsistentHashing configures the given upstream cluster to load balance requests by consistent hashing when the
+// upstream service has a hash policy, so that the requests with the same hash, as set by the routes to the service, are
+// sent to the same endpoint. Clusters which are not load balanced round robin, such as original destination clusters
+// in permissive mode, are left unchanged.
+func applyConsistentHashing(remoteCluster *xds_cluster.Cluster, hashPolicy *trafficpolicy.HashPolicy) {
+	if hashPolicy == nil || remoteCluster.LbPolicy != xds_cluster.Cluster_ROUND_ROBIN {
+		return
+	}
+	remoteCluster.LbPolicy = xds_cluster.Cluster_RING_HASH
+}
@@ -0,0 +1,90 @@
+package cds
+
+import (
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// healthCheckTimeout is the time to wait for the response to a health check
+	healthCheckTimeout = 1 * time.Second
+
+	// healthCheckUnhealthyThreshold is the number of failed health checks after which an endpoint is marked unhealthy
+	healthCheckUnhealthyThreshold = 3
+
+	// healthCheckHealthyThreshold is the number of successful health checks after which an unhealthy endpoint is marked healthy
+	healthCheckHealthyThreshold = 2
+
+	// defaultHealthCheckPath is the path requested by the HTTP health checks when not specified
+	defaultHealthCheckPath = "/"
+)
+
+// applyHealthCheck configures the active health checking of the endpoints of the given upstream cluster of the given
+// service, if enabled. The endpoints of TCP services are checked with TCP health checks, and the endpoints of other
+// services with HTTP/2 health checks, matching the protocol of the cluster's connections to the upstream proxies.
+// The HTTP health checks are requested for the FQDN of the service, for them to match the service's virtual host on
+// the inbound listener of the upstream proxies instead of Envoy's default host, the name of the cluster.
+func applyHealthCheck(remoteCluster *xds_cluster.Cluster, svc service.MeshService, healthCheck *trafficpolicy.HealthCheck) {
+	if healthCheck == nil {
+		return
+	}
+
+	hc := &xds_core.HealthCheck{
+		Timeout:            ptypes.DurationProto(healthCheckTimeout),
+		Interval:           ptypes.DurationProto(healthCheck.Interval),
+		UnhealthyThreshold: &wrappers.UInt32Value{Value: healthCheckUnhealthyThreshold},
+		HealthyThreshold:   &wrappers.UInt32Value{Value: healthCheckHealthyThreshold},
+	}
+
+	if healthCheck.TCP {
+		tcpHealthCheck := &xds_core.HealthCheck_TcpHealthCheck{}
+		if healthCheck.Send != "" {
+			tcpHealthCheck.Send = &xds_core.HealthCheck_Payload{
+				Payload: &xds_core.HealthCheck_Payload_Text{Text: healthCheck.Send},
+			}
+		}
+		if healthCheck.Receive != "" {
+			tcpHealthCheck.Receive = []*xds_core.HealthCheck_Payload{{
+				Payload: &xds_core.HealthCheck_Payload_Text{Text: healthCheck.Receive},
+			}}
+		}
+		hc.HealthChecker = &xds_core.HealthCheck_TcpHealthCheck_{TcpHealthCheck: tcpHealthCheck}
+	} else {
+		path := healthCheck.Path
+		if path == "" {
+			path = defaultHealthCheckPath
+		}
+		hc.HealthChecker = &xds_core.HealthCheck_HttpHealthCheck_{
+			HttpHealthCheck: &xds_core.HealthCheck_HttpHealthCheck{
+				Host:             svc.ServerName(),
+				Path:             path,
+				CodecClientType:  xds_type.CodecClientType_HTTP2,
+				ExpectedStatuses: getExpectedStatuses(healthCheck.ExpectedStatuses),
+			},
+		}
+	}
+
+	remoteCluster.HealthChecks = []*xds_core.HealthCheck{hc}
+}
+
+// getExpectedStatuses returns the ranges of status codes of the responses to the HTTP health checks considered healthy.
+// Envoy's ranges are half open, unlike the inclusive ranges of the health check. No range is returned for a health
+// check without expected statuses, leaving Envoy to only consider 200 healthy.
+func getExpectedStatuses(statusCodeRanges []trafficpolicy.StatusCodeRange) []*xds_type.Int64Range {
+	var expectedStatuses []*xds_type.Int64Range
+	for _, statusCodeRange := range statusCodeRanges {
+		expectedStatuses = append(expectedStatuses, &xds_type.Int64Range{
+			Start: int64(statusCodeRange.Start),
+			End:   int64(statusCodeRange.End) + 1,
+		})
+	}
+	return expectedStatuses
+}
@@ -0,0 +1,114 @@
+package cds
+
+import (
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Active health checking of upstream clusters", func() {
+	svc := service.MeshService{Name: "bookstore", Namespace: "bookstore-ns"}
+
+	It("does not health check the endpoints when health checking is not enabled", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, nil)
+		Expect(cluster.HealthChecks).To(BeNil())
+	})
+
+	It("health checks the endpoints of a TCP service with TCP health checks exchanging the payloads", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, &trafficpolicy.HealthCheck{
+			Interval: 10 * time.Second,
+			TCP:      true,
+			Send:     "50494e47",
+			Receive:  "504f4e47",
+		})
+
+		Expect(cluster.HealthChecks).To(HaveLen(1))
+		healthCheck := cluster.HealthChecks[0]
+		Expect(healthCheck.Interval).To(Equal(ptypes.DurationProto(10 * time.Second)))
+		Expect(healthCheck.Timeout).To(Equal(ptypes.DurationProto(healthCheckTimeout)))
+		Expect(healthCheck.GetHttpHealthCheck()).To(BeNil())
+
+		tcpHealthCheck := healthCheck.GetTcpHealthCheck()
+		Expect(tcpHealthCheck).ToNot(BeNil())
+		Expect(tcpHealthCheck.Send.GetText()).To(Equal("50494e47"))
+		Expect(tcpHealthCheck.Receive).To(HaveLen(1))
+		Expect(tcpHealthCheck.Receive[0].GetText()).To(Equal("504f4e47"))
+		Expect(healthCheck.Validate()).To(Succeed())
+	})
+
+	It("health checks the endpoints of a TCP service with connect-only health checks when no payload is set", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, &trafficpolicy.HealthCheck{Interval: 5 * time.Second, TCP: true})
+
+		tcpHealthCheck := cluster.HealthChecks[0].GetTcpHealthCheck()
+		Expect(tcpHealthCheck).ToNot(BeNil())
+		Expect(tcpHealthCheck.Send).To(BeNil())
+		Expect(tcpHealthCheck.Receive).To(BeEmpty())
+	})
+
+	It("health checks the endpoints of an HTTP service with HTTP/2 health checks", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, &trafficpolicy.HealthCheck{Interval: 5 * time.Second, Path: "/healthz"})
+
+		Expect(cluster.HealthChecks).To(HaveLen(1))
+		Expect(cluster.HealthChecks[0].GetTcpHealthCheck()).To(BeNil())
+		httpHealthCheck := cluster.HealthChecks[0].GetHttpHealthCheck()
+		Expect(httpHealthCheck).ToNot(BeNil())
+		Expect(httpHealthCheck.Path).To(Equal("/healthz"))
+		Expect(httpHealthCheck.CodecClientType).To(Equal(xds_type.CodecClientType_HTTP2))
+		Expect(cluster.HealthChecks[0].Validate()).To(Succeed())
+	})
+
+	It("requests the HTTP health checks for a host matching the inbound virtual host of the service", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, &trafficpolicy.HealthCheck{Interval: 5 * time.Second, Path: "/healthz"})
+
+		// The domains of the inbound virtual host of the service on the upstream proxies
+		k8sSvc := tests.NewServiceFixture(svc.Name, svc.Namespace, map[string]string{})
+		inboundDomains := k8s.GetHostnamesForService(k8sSvc, false)
+
+		host := cluster.HealthChecks[0].GetHttpHealthCheck().Host
+		Expect(host).To(Equal("bookstore.bookstore-ns.svc.cluster.local"))
+		Expect(inboundDomains).To(ContainElement(host))
+	})
+
+	It("requests the default path when the HTTP health check path is not set", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, &trafficpolicy.HealthCheck{Interval: 5 * time.Second})
+
+		Expect(cluster.HealthChecks[0].GetHttpHealthCheck().Path).To(Equal(defaultHealthCheckPath))
+	})
+
+	It("only considers the responses with status 200 healthy when the expected statuses are not set", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, &trafficpolicy.HealthCheck{Interval: 5 * time.Second})
+
+		Expect(cluster.HealthChecks[0].GetHttpHealthCheck().ExpectedStatuses).To(BeEmpty())
+	})
+
+	It("considers the responses with the expected statuses healthy", func() {
+		cluster := &xds_cluster.Cluster{}
+		applyHealthCheck(cluster, svc, &trafficpolicy.HealthCheck{
+			Interval:         5 * time.Second,
+			ExpectedStatuses: []trafficpolicy.StatusCodeRange{{Start: 204, End: 204}, {Start: 300, End: 399}},
+		})
+
+		httpHealthCheck := cluster.HealthChecks[0].GetHttpHealthCheck()
+		Expect(httpHealthCheck.ExpectedStatuses).To(Equal([]*xds_type.Int64Range{
+			{Start: 204, End: 205},
+			{Start: 300, End: 400},
+		}))
+		Expect(cluster.HealthChecks[0].Validate()).To(Succeed())
+	})
+})
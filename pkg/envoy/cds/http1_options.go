@@ -0,0 +1,36 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyHTTP1ProtocolOptions configures the HTTP/1.1 connections of the given local cluster to the local application.
+// The header keys of the requests are sent in proper case when required by the application, and TCP keepalive probes
+// are sent on the idle connections when a keepalive time is set. Envoy's defaults apply when no options are set.
+func applyHTTP1ProtocolOptions(localCluster *xds_cluster.Cluster, options *trafficpolicy.HTTP1ProtocolOptions) {
+	if options == nil {
+		return
+	}
+
+	if options.ProperCaseHeaders {
+		localCluster.HttpProtocolOptions = &xds_core.Http1ProtocolOptions{
+			HeaderKeyFormat: &xds_core.Http1ProtocolOptions_HeaderKeyFormat{
+				HeaderFormat: &xds_core.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords_{
+					ProperCaseWords: &xds_core.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords{},
+				},
+			},
+		}
+	}
+
+	if options.KeepaliveTime > 0 {
+		localCluster.UpstreamConnectionOptions = &xds_cluster.UpstreamConnectionOptions{
+			TcpKeepalive: &xds_core.TcpKeepalive{
+				KeepaliveTime: &wrappers.UInt32Value{Value: uint32(options.KeepaliveTime.Seconds())},
+			},
+		}
+	}
+}
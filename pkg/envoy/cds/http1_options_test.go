@@ -0,0 +1,40 @@
+package cds
+
+import (
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("HTTP/1.1 options of the local cluster", func() {
+	It("leaves Envoy's defaults when the service sets no options", func() {
+		localCluster := &xds_cluster.Cluster{}
+		applyHTTP1ProtocolOptions(localCluster, nil)
+		Expect(localCluster.HttpProtocolOptions).To(BeNil())
+		Expect(localCluster.UpstreamConnectionOptions).To(BeNil())
+	})
+
+	It("sends the header keys in proper case and keeps the connections alive for a legacy service", func() {
+		localCluster := &xds_cluster.Cluster{Name: "legacy/legacy-local"}
+		applyHTTP1ProtocolOptions(localCluster, &trafficpolicy.HTTP1ProtocolOptions{
+			ProperCaseHeaders: true,
+			KeepaliveTime:     30 * time.Second,
+		})
+
+		Expect(localCluster.HttpProtocolOptions.GetHeaderKeyFormat().GetProperCaseWords()).ToNot(BeNil())
+		Expect(localCluster.UpstreamConnectionOptions.GetTcpKeepalive().GetKeepaliveTime().GetValue()).To(Equal(uint32(30)))
+		Expect(localCluster.Validate()).To(Succeed())
+	})
+
+	It("only keeps the connections alive when proper case headers are not required", func() {
+		localCluster := &xds_cluster.Cluster{}
+		applyHTTP1ProtocolOptions(localCluster, &trafficpolicy.HTTP1ProtocolOptions{KeepaliveTime: time.Minute})
+
+		Expect(localCluster.HttpProtocolOptions).To(BeNil())
+		Expect(localCluster.UpstreamConnectionOptions.GetTcpKeepalive().GetKeepaliveTime().GetValue()).To(Equal(uint32(60)))
+	})
+})
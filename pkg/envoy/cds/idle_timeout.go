@@ -0,0 +1,29 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// applyUpstreamIdleTimeout configures the time after which the connections of the given cluster to the given upstream
+// service are closed when they have no active requests. The idle timeout is set by the service's annotation or defaults
+// to the mesh-wide idle timeout. Envoy's default applies when no idle timeout is configured.
+func applyUpstreamIdleTimeout(remoteCluster *xds_cluster.Cluster, upstreamSvc service.MeshService, meshCatalog catalog.MeshCataloger, cfg configurator.Configurator) {
+	idleTimeout := meshCatalog.GetUpstreamIdleTimeoutForService(upstreamSvc)
+	if idleTimeout == 0 {
+		idleTimeout = cfg.GetUpstreamIdleTimeout()
+	}
+	if idleTimeout == 0 {
+		return
+	}
+
+	if remoteCluster.CommonHttpProtocolOptions == nil {
+		remoteCluster.CommonHttpProtocolOptions = &xds_core.HttpProtocolOptions{}
+	}
+	remoteCluster.CommonHttpProtocolOptions.IdleTimeout = ptypes.DurationProto(idleTimeout)
+}
@@ -0,0 +1,74 @@
+package cds
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Upstream idle timeout", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockCatalog      *catalog.MockMeshCataloger
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	upstreamSvc := tests.BookstoreV1Service
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+		mockConfigurator.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+		mockCatalog.EXPECT().GetUpstreamSNIForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(upstreamSvc).Return(false).AnyTimes()
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().GetHashPolicyForService(upstreamSvc).Return(nil).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Sets the mesh-wide idle timeout on the upstream cluster without changing the cluster name", func() {
+		mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(upstreamSvc).Return(time.Duration(0)).Times(1)
+		mockConfigurator.EXPECT().GetUpstreamIdleTimeout().Return(5 * time.Minute).Times(1)
+
+		remoteCluster, err := buildUpstreamServiceCluster(mockCatalog, upstreamSvc, tests.BookbuyerService, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remoteCluster.Name).To(Equal(upstreamSvc.String()))
+		Expect(remoteCluster.CommonHttpProtocolOptions.IdleTimeout).To(Equal(ptypes.DurationProto(5 * time.Minute)))
+	})
+
+	It("Sets the idle timeout of the service, overriding the mesh-wide idle timeout", func() {
+		mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(upstreamSvc).Return(30 * time.Second).Times(1)
+
+		remoteCluster, err := buildUpstreamServiceCluster(mockCatalog, upstreamSvc, tests.BookbuyerService, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remoteCluster.Name).To(Equal(upstreamSvc.String()))
+		Expect(remoteCluster.CommonHttpProtocolOptions.IdleTimeout).To(Equal(ptypes.DurationProto(30 * time.Second)))
+	})
+
+	It("Leaves the Envoy default idle timeout when not configured", func() {
+		mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(upstreamSvc).Return(time.Duration(0)).Times(1)
+		mockConfigurator.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).Times(1)
+
+		remoteCluster, err := buildUpstreamServiceCluster(mockCatalog, upstreamSvc, tests.BookbuyerService, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remoteCluster.CommonHttpProtocolOptions).To(BeNil())
+	})
+})
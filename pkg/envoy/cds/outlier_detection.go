@@ -0,0 +1,33 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyOutlierDetection configures the outlier detection of the endpoints of the given upstream cluster with the given
+// overrides, if any. Parameters without an override are left to Envoy's defaults.
+func applyOutlierDetection(remoteCluster *xds_cluster.Cluster, outlierDetection *trafficpolicy.OutlierDetection) {
+	if outlierDetection == nil {
+		return
+	}
+
+	remoteCluster.OutlierDetection = &xds_cluster.OutlierDetection{
+		Consecutive_5Xx:          getUInt32Value(outlierDetection.Consecutive5xx),
+		MaxEjectionPercent:       getUInt32Value(outlierDetection.MaxEjectionPercent),
+		EnforcingConsecutive_5Xx: getUInt32Value(outlierDetection.EnforcingConsecutive5xx),
+
+		SplitExternalLocalOriginErrors:         outlierDetection.SplitExternalLocalOriginErrors,
+		ConsecutiveLocalOriginFailure:          getUInt32Value(outlierDetection.ConsecutiveLocalOriginFailure),
+		EnforcingConsecutiveLocalOriginFailure: getUInt32Value(outlierDetection.EnforcingConsecutiveLocalOriginFailure),
+	}
+}
+
+func getUInt32Value(value *uint32) *wrappers.UInt32Value {
+	if value == nil {
+		return nil
+	}
+	return &wrappers.UInt32Value{Value: *value}
+}
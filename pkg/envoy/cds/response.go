@@ -5,12 +5,15 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 // NewResponse creates a new Cluster Discovery Response.
@@ -32,9 +35,26 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		return nil, err
 	}
 
+	// Upstream clusters are not programmed for ingress-only workloads that never originate mesh traffic
+	outboundDisabled := meshCatalog.IsOutboundDisabledForService(proxyServiceName)
+	if outboundDisabled {
+		log.Debug().Msgf("Outbound is disabled for proxy %s, not programming upstream clusters", proxyServiceName)
+	}
+
 	// Build remote clusters based on allowed outbound services
-	for _, dstService := range meshCatalog.ListAllowedOutboundServicesForIdentity(proxyIdentity) {
-		cluster, err := getUpstreamServiceCluster(dstService, proxyServiceName, cfg)
+	var allowedOutboundServices []service.MeshService
+	if !outboundDisabled {
+		allowedOutboundServices = meshCatalog.ListAllowedOutboundServicesForIdentity(proxyIdentity)
+	}
+	// Requests are only mirrored and failed over to allowed outbound services, so the clusters of shadow and failover
+	// services are among these clusters
+	var builtServices []service.MeshService
+	for _, dstService := range allowedOutboundServices {
+		cluster, err := buildUpstreamServiceCluster(meshCatalog, dstService, proxyServiceName, cfg)
+		if errors.Is(err, errNoServicePorts) {
+			log.Warn().Err(err).Msgf("Skipping DNS cluster of service %s without ports for proxy %s", dstService, proxyServiceName)
+			continue
+		}
 		if err != nil {
 			log.Error().Err(err).Msgf("Failed to construct service cluster for service %s for proxy %s", dstService.Name, proxyServiceName)
 			return nil, err
@@ -44,7 +64,49 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 			enableBackpressure(meshCatalog, cluster, dstService)
 		}
 
+		applyCircuitBreaking(cluster, meshCatalog.GetCircuitBreakingForService(dstService))
+
+		applyOutlierDetection(cluster, meshCatalog.GetOutlierDetectionForService(dstService))
+
+		applyHealthCheck(cluster, dstService, meshCatalog.GetHealthCheckForService(dstService))
+
 		clusters = append(clusters, cluster)
+
+		// Build a cluster for each port of the service routed to a cluster of its own, so that the connections to the port
+		// reach the target port it maps to
+		clusters = append(clusters, getServicePortClusters(meshCatalog, cluster, dstService)...)
+
+		builtServices = append(builtServices, dstService)
+	}
+
+	// Build an aggregate cluster failing over from the cluster of each service to the already built clusters of its
+	// failover services the proxy is allowed to access
+	for _, dstService := range builtServices {
+		var failoverServices []service.MeshService
+		for _, failoverService := range meshCatalog.GetFailoverServicesForService(dstService) {
+			if !containsService(allowedOutboundServices, failoverService) {
+				log.Debug().Msgf("Not failing over from service %s to service %s for proxy %s, the proxy is not allowed to access it", dstService, failoverService, proxyServiceName)
+				continue
+			}
+			failoverServices = append(failoverServices, failoverService)
+		}
+		if len(failoverServices) == 0 {
+			continue
+		}
+
+		// The failover services whose cluster was skipped are not members of the aggregate cluster
+		var memberServices []service.MeshService
+		for _, failoverService := range failoverServices {
+			if containsService(builtServices, failoverService) {
+				memberServices = append(memberServices, failoverService)
+			}
+		}
+		failoverCluster, err := getFailoverCluster(dstService, memberServices)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to construct failover cluster for service %s for proxy %s", dstService, proxyServiceName)
+			return nil, err
+		}
+		clusters = append(clusters, failoverCluster)
 	}
 
 	// Create a local cluster for the service.
@@ -55,11 +117,31 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		log.Error().Err(err).Msgf("Failed to get local cluster config for proxy %s", proxyServiceName)
 		return nil, err
 	}
+	applyHTTP1ProtocolOptions(localCluster, meshCatalog.GetHTTP1ProtocolOptionsForService(proxyServiceName))
 	clusters = append(clusters, localCluster)
 
-	// Add an outbound passthrough cluster for egress
-	if cfg.IsEgressEnabled() {
-		clusters = append(clusters, getOutboundPassthroughCluster())
+	// The clusters of the default filter chain of the outbound listener are kept when outbound is disabled, as outbound
+	// traffic keeps being redirected to the proxy
+	if cfg.IsOutboundOriginalDstEnabled() {
+		// Add an outbound original destination cluster for the original_dst mode of the outbound listener, which
+		// takes precedence over the other ways of handling traffic not matching any traffic policy
+		clusters = append(clusters, getOutboundOriginalDstCluster())
+	} else {
+		// Add an outbound passthrough cluster for egress, and for the HTTP requests to unknown hosts when passed through
+		if cfg.IsEgressEnabled() || cfg.GetOutboundUnknownHostMode() == constants.OutboundUnknownHostModePassthrough {
+			clusters = append(clusters, getOutboundPassthroughCluster())
+		}
+		if !cfg.IsEgressEnabled() && cfg.IsOutboundBlackholeEnabled() {
+			// Add an outbound blackhole cluster for traffic not matching any traffic policy
+			clusters = append(clusters, getOutboundBlackholeCluster())
+		}
+	}
+
+	// Add an inbound passthrough cluster for inbound connections not matching any filter chain, and for the plaintext
+	// connections to the ports the kubelet probes in plaintext
+	plaintextProbePorts, _ := meshCatalog.GetInboundPlaintextProbePortsForProxy(proxy.GetCertificateCommonName())
+	if cfg.IsInboundUnmatchedSNIPassthroughEnabled() || len(plaintextProbePorts) > 0 {
+		clusters = append(clusters, getInboundPassthroughCluster())
 	}
 
 	// Add an inbound prometheus cluster (from Prometheus to localhost)
@@ -95,3 +177,32 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	return resp, nil
 }
+
+// buildUpstreamServiceCluster returns the cluster of the given upstream service on the proxy of the given downstream
+// service, configured with the cluster type, TLS origination and upstream bind config of the upstream service
+func buildUpstreamServiceCluster(meshCatalog catalog.MeshCataloger, upstreamSvc, downstreamSvc service.MeshService, cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
+	remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, cfg, meshCatalog.GetUpstreamSNIForService(upstreamSvc))
+	if err != nil {
+		return nil, err
+	}
+	if err := applyClusterType(remoteCluster, upstreamSvc, meshCatalog, cfg); err != nil {
+		return nil, err
+	}
+	if err := applyExternalTLSOrigination(remoteCluster, upstreamSvc, meshCatalog, cfg); err != nil {
+		return nil, err
+	}
+	applyUpstreamBindConfig(remoteCluster, getUpstreamBindSourceAddress(meshCatalog, upstreamSvc, cfg))
+	applyConsistentHashing(remoteCluster, meshCatalog.GetHashPolicyForService(upstreamSvc))
+	applyUpstreamIdleTimeout(remoteCluster, upstreamSvc, meshCatalog, cfg)
+	return remoteCluster, nil
+}
+
+// containsService returns true if the given service is in the given list of services
+func containsService(services []service.MeshService, svc service.MeshService) bool {
+	for _, s := range services {
+		if s == svc {
+			return true
+		}
+	}
+	return false
+}
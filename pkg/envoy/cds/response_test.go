@@ -8,6 +8,7 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_aggregate_cluster "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
@@ -26,7 +27,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var _ = Describe("CDS Response", func() {
@@ -37,9 +40,12 @@ var _ = Describe("CDS Response", func() {
 
 	mockCtrl = gomock.NewController(GinkgoT())
 	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 
 	kubeClient := testclient.NewSimpleClientset()
-	catalog := catalog.NewFakeMeshCatalog(kubeClient)
+	meshCatalog := catalog.NewFakeMeshCatalog(kubeClient)
 	proxyServiceName := tests.BookbuyerServiceName
 	proxyServiceAccountName := tests.BookbuyerServiceAccountName
 	proxyService := tests.BookbuyerService
@@ -79,10 +85,16 @@ var _ = Describe("CDS Response", func() {
 			mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(true).AnyTimes()
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).AnyTimes()
 			mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+			mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+			mockConfigurator.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+			mockConfigurator.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+			mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).AnyTimes()
+			mockConfigurator.EXPECT().GetDNSLookupFamily().Return(constants.DefaultDNSLookupFamily).AnyTimes()
 
-			resp, err := NewResponse(catalog, proxy, nil, mockConfigurator, nil)
+			resp, err := NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// There are to any.Any resources in the ClusterDiscoveryStruct (Clusters)
@@ -95,11 +107,342 @@ var _ = Describe("CDS Response", func() {
 			numExpectedClusters := 7 // source and destination clusters
 			Expect(len((*resp).Resources)).To(Equal(numExpectedClusters))
 		})
+
+		It("Returns the circuit breaker thresholds of both routing priorities of an upstream service cluster", func() {
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), proxyServiceAccountName, tests.Namespace)), "", nil)
+			defaultMaxConnections := uint32(100)
+			defaultMaxRequests := uint32(200)
+			highMaxConnections := uint32(10)
+			circuitBreaking := &trafficpolicy.CircuitBreaking{
+				Default: &trafficpolicy.CircuitBreakerThresholds{
+					MaxConnections: &defaultMaxConnections,
+					MaxRequests:    &defaultMaxRequests,
+				},
+				High: &trafficpolicy.CircuitBreakerThresholds{
+					MaxConnections: &highMaxConnections,
+				},
+			}
+
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{proxyService}, nil).Times(1)
+			mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(false).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+			mockCatalog.EXPECT().GetUpstreamSNIForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(gomock.Any()).Return(false).AnyTimes()
+			mockCatalog.EXPECT().GetFailoverServicesForService(tests.BookstoreV1Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{80: "http"}, nil).Times(1)
+			mockCatalog.EXPECT().GetCircuitBreakingForService(tests.BookstoreV1Service).Return(circuitBreaking).Times(1)
+			mockCatalog.EXPECT().GetOutlierDetectionForService(tests.BookstoreV1Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetHealthCheckForService(tests.BookstoreV1Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetClusterTypeForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetHashPolicyForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+			mockCatalog.EXPECT().ListEndpointsForService(proxyService).Return(nil, nil).Times(1)
+			mockCatalog.EXPECT().GetHTTP1ProtocolOptionsForService(proxyService).Return(nil).Times(1)
+
+			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModeDeny).AnyTimes()
+			mockCfg.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+			mockCfg.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+			mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(gomock.Any()).Return(nil, "").AnyTimes()
+			mockCfg.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			var upstreamCluster *xds_cluster.Cluster
+			for _, resource := range resp.Resources {
+				cluster := &xds_cluster.Cluster{}
+				Expect(ptypes.UnmarshalAny(resource, cluster)).To(Succeed())
+				if cluster.Name == tests.BookstoreV1Service.String() {
+					upstreamCluster = cluster
+				}
+			}
+			Expect(upstreamCluster).ToNot(BeNil())
+			Expect(upstreamCluster.CircuitBreakers).ToNot(BeNil())
+
+			thresholds := upstreamCluster.CircuitBreakers.Thresholds
+			Expect(thresholds).To(HaveLen(2))
+			Expect(thresholds[0].Priority).To(Equal(xds_core.RoutingPriority_DEFAULT))
+			Expect(thresholds[0].MaxConnections.GetValue()).To(Equal(defaultMaxConnections))
+			Expect(thresholds[0].MaxRequests.GetValue()).To(Equal(defaultMaxRequests))
+			Expect(thresholds[0].MaxPendingRequests).To(BeNil())
+			Expect(thresholds[1].Priority).To(Equal(xds_core.RoutingPriority_HIGH))
+			Expect(thresholds[1].MaxConnections.GetValue()).To(Equal(highMaxConnections))
+			Expect(thresholds[1].MaxRequests).To(BeNil())
+		})
+
+		It("Returns no upstream clusters but keeps the default outbound clusters when outbound is disabled for the proxy's service", func() {
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), proxyServiceAccountName, tests.Namespace)), "", nil)
+
+			// Outbound services must not be looked up
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{proxyService}, nil).Times(1)
+			mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(true).Times(1)
+			mockCatalog.EXPECT().ListEndpointsForService(proxyService).Return(nil, nil).Times(1)
+			mockCatalog.EXPECT().GetHTTP1ProtocolOptionsForService(proxyService).Return(nil).Times(1)
+
+			mockCfg.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+			mockCfg.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModeDeny).AnyTimes()
+			mockCfg.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+			mockCfg.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(true).AnyTimes()
+			mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(gomock.Any()).Return(nil, "").AnyTimes()
+			mockCfg.EXPECT().IsPrometheusScrapingEnabled().Return(true).AnyTimes()
+			mockCfg.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			var clusterNames []string
+			for _, resource := range resp.Resources {
+				cluster := xds_cluster.Cluster{}
+				Expect(ptypes.UnmarshalAny(resource, &cluster)).To(Succeed())
+				clusterNames = append(clusterNames, cluster.Name)
+			}
+			// Only the local cluster, the egress passthrough cluster, and the inbound passthrough and Prometheus clusters
+			// are present
+			Expect(clusterNames).To(ConsistOf(
+				envoy.GetLocalClusterNameForService(proxyService),
+				envoy.OutboundPassthroughCluster,
+				envoy.InboundPassthroughCluster,
+				constants.EnvoyMetricsCluster,
+			))
+		})
+		It("Skips the DNS cluster of an upstream service without ports and returns the other upstream clusters", func() {
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), proxyServiceAccountName, tests.Namespace)), "", nil)
+
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{proxyService}, nil).Times(1)
+			mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(false).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return([]service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service}).Times(1)
+			mockCatalog.EXPECT().GetClusterTypeForService(tests.BookstoreV1Service).Return(constants.ClusterTypeStrictDNS).AnyTimes()
+			mockCatalog.EXPECT().GetClusterTypeForService(tests.BookstoreV2Service).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{}, nil).AnyTimes()
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV2Service).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamSNIForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(gomock.Any()).Return(false).AnyTimes()
+			mockCatalog.EXPECT().GetFailoverServicesForService(tests.BookstoreV2Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetCircuitBreakingForService(tests.BookstoreV2Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetOutlierDetectionForService(tests.BookstoreV2Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetHealthCheckForService(tests.BookstoreV2Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetHashPolicyForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+			mockCatalog.EXPECT().ListEndpointsForService(proxyService).Return(nil, nil).Times(1)
+			mockCatalog.EXPECT().GetHTTP1ProtocolOptionsForService(proxyService).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(gomock.Any()).Return(nil, "").AnyTimes()
+
+			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModeDeny).AnyTimes()
+			mockCfg.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+			mockCfg.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			var clusterNames []string
+			for _, resource := range resp.Resources {
+				cluster := xds_cluster.Cluster{}
+				Expect(ptypes.UnmarshalAny(resource, &cluster)).To(Succeed())
+				clusterNames = append(clusterNames, cluster.Name)
+			}
+			Expect(clusterNames).ToNot(ContainElement(tests.BookstoreV1Service.String()))
+			Expect(clusterNames).To(ContainElement(tests.BookstoreV2Service.String()))
+		})
+
+		It("Fails over only to the allowed failover services, reusing the clusters built for them", func() {
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), proxyServiceAccountName, tests.Namespace)), "", nil)
+			notAllowedSvc := service.MeshService{Name: "bookstore-secret", Namespace: "other"}
+			maxConnections := uint32(10)
+
+			// bookstore-v1 fails over to a service the proxy is not allowed to access, and to bookstore-v2 which the proxy
+			// is allowed to access directly
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{proxyService}, nil).Times(1)
+			mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(false).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return([]service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service}).Times(1)
+			mockCatalog.EXPECT().GetFailoverServicesForService(tests.BookstoreV1Service).Return([]service.MeshService{notAllowedSvc, tests.BookstoreV2Service}).Times(1)
+			mockCatalog.EXPECT().GetFailoverServicesForService(tests.BookstoreV2Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetCircuitBreakingForService(tests.BookstoreV1Service).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetCircuitBreakingForService(tests.BookstoreV2Service).Return(&trafficpolicy.CircuitBreaking{
+				Default: &trafficpolicy.CircuitBreakerThresholds{MaxConnections: &maxConnections},
+			}).Times(1)
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(gomock.Any()).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+			mockCatalog.EXPECT().GetClusterTypeForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamSNIForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(gomock.Any()).Return(false).AnyTimes()
+			mockCatalog.EXPECT().GetOutlierDetectionForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetHealthCheckForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetHashPolicyForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+			mockCatalog.EXPECT().ListEndpointsForService(proxyService).Return(nil, nil).Times(1)
+			mockCatalog.EXPECT().GetHTTP1ProtocolOptionsForService(proxyService).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(gomock.Any()).Return(nil, "").AnyTimes()
+
+			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModeDeny).AnyTimes()
+			mockCfg.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+			mockCfg.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			clustersByName := make(map[string]*xds_cluster.Cluster)
+			var clusterNames []string
+			for _, resource := range resp.Resources {
+				cluster := &xds_cluster.Cluster{}
+				Expect(ptypes.UnmarshalAny(resource, cluster)).To(Succeed())
+				clustersByName[cluster.Name] = cluster
+				clusterNames = append(clusterNames, cluster.Name)
+			}
+
+			// No cluster is built for the service the proxy is not allowed to access, and the cluster of bookstore-v2 is
+			// built once with its circuit breakers
+			Expect(clusterNames).ToNot(ContainElement(notAllowedSvc.String()))
+			Expect(clusterNames).To(HaveLen(len(clustersByName)))
+			Expect(clustersByName[tests.BookstoreV2Service.String()].GetCircuitBreakers()).ToNot(BeNil())
+
+			failoverCluster := clustersByName[envoy.GetFailoverClusterNameForService(tests.BookstoreV1Service)]
+			Expect(failoverCluster).ToNot(BeNil())
+			aggregateClusterConfig := &xds_aggregate_cluster.ClusterConfig{}
+			Expect(ptypes.UnmarshalAny(failoverCluster.GetClusterType().TypedConfig, aggregateClusterConfig)).To(Succeed())
+			Expect(aggregateClusterConfig.Clusters).To(Equal([]string{tests.BookstoreV1Service.String(), tests.BookstoreV2Service.String()}))
+		})
+
+		It("Returns no failover cluster when no failover service is allowed", func() {
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), proxyServiceAccountName, tests.Namespace)), "", nil)
+			notAllowedSvc := service.MeshService{Name: "bookstore-secret", Namespace: "other"}
+
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{proxyService}, nil).Times(1)
+			mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(false).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+			mockCatalog.EXPECT().GetFailoverServicesForService(tests.BookstoreV1Service).Return([]service.MeshService{notAllowedSvc}).Times(1)
+			mockCatalog.EXPECT().GetCircuitBreakingForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(gomock.Any()).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+			mockCatalog.EXPECT().GetClusterTypeForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamSNIForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(gomock.Any()).Return(false).AnyTimes()
+			mockCatalog.EXPECT().GetOutlierDetectionForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetHealthCheckForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(gomock.Any()).Return("").AnyTimes()
+			mockCatalog.EXPECT().GetHashPolicyForService(gomock.Any()).Return(nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+			mockCatalog.EXPECT().ListEndpointsForService(proxyService).Return(nil, nil).Times(1)
+			mockCatalog.EXPECT().GetHTTP1ProtocolOptionsForService(proxyService).Return(nil).Times(1)
+			mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(gomock.Any()).Return(nil, "").AnyTimes()
+
+			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModeDeny).AnyTimes()
+			mockCfg.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+			mockCfg.EXPECT().GetUpstreamBindSourceAddress().Return("").AnyTimes()
+			mockCfg.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+			mockCfg.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			var clusterNames []string
+			for _, resource := range resp.Resources {
+				cluster := &xds_cluster.Cluster{}
+				Expect(ptypes.UnmarshalAny(resource, cluster)).To(Succeed())
+				clusterNames = append(clusterNames, cluster.Name)
+			}
+			Expect(clusterNames).To(ContainElement(tests.BookstoreV1Service.String()))
+			Expect(clusterNames).ToNot(ContainElement(notAllowedSvc.String()))
+			Expect(clusterNames).ToNot(ContainElement(envoy.GetFailoverClusterNameForService(tests.BookstoreV1Service)))
+		})
+
+		It("Returns the original destination cluster when the original_dst mode of the outbound listener is enabled", func() {
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), proxyServiceAccountName, tests.Namespace)), "", nil)
+
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{proxyService}, nil).Times(1)
+			mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(false).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return(nil).Times(1)
+			mockCatalog.EXPECT().ListEndpointsForService(proxyService).Return(nil, nil).Times(1)
+			mockCatalog.EXPECT().GetHTTP1ProtocolOptionsForService(proxyService).Return(nil).Times(1)
+
+			// The original destination cluster replaces the egress passthrough cluster
+			mockCfg.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+			mockCfg.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModeDeny).AnyTimes()
+			mockCfg.EXPECT().IsOutboundOriginalDstEnabled().Return(true).AnyTimes()
+			mockCfg.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+			mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(gomock.Any()).Return(nil, "").AnyTimes()
+			mockCfg.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+			mockCfg.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Resources).To(HaveLen(2))
+
+			cluster := xds_cluster.Cluster{}
+			Expect(ptypes.UnmarshalAny(resp.Resources[1], &cluster)).To(Succeed())
+			Expect(cluster.Name).To(Equal(envoy.OutboundOriginalDstCluster))
+			Expect(cluster.GetType()).To(Equal(xds_cluster.Cluster_ORIGINAL_DST))
+			Expect(cluster.LbPolicy).To(Equal(xds_cluster.Cluster_CLUSTER_PROVIDED))
+		})
 	})
 
 	Context("Test cds clusters", func() {
 		It("Returns a local cluster object", func() {
-			localCluster, err := getLocalServiceCluster(catalog, proxyService, envoy.GetLocalClusterNameForService(proxyService))
+			localCluster, err := getLocalServiceCluster(meshCatalog, proxyService, envoy.GetLocalClusterNameForService(proxyService))
 			Expect(err).ToNot(HaveOccurred())
 
 			expectedClusterLoadAssignment := &xds_endpoint.ClusterLoadAssignment{
@@ -154,7 +497,7 @@ var _ = Describe("CDS Response", func() {
 
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
 
-			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator)
+			remoteCluster, err := getUpstreamServiceCluster(upstreamSvc, downstreamSvc, mockConfigurator, "")
 			Expect(err).ToNot(HaveOccurred())
 
 			expectedClusterLoadAssignment := &xds_endpoint.ClusterLoadAssignment{
@@ -188,7 +531,7 @@ var _ = Describe("CDS Response", func() {
 
 			// Checking for the value by generating the same value the same way is redundant
 			// Nonetheless, as getUpstreamServiceCluster logic gets more complicated, this might just be ok to have
-			upstreamTLSProto, err := ptypes.MarshalAny(envoy.GetUpstreamTLSContext(proxyService, upstreamSvc))
+			upstreamTLSProto, err := ptypes.MarshalAny(envoy.GetUpstreamTLSContext(proxyService, upstreamSvc, mockConfigurator))
 			Expect(err).ToNot(HaveOccurred())
 
 			expectedCluster := xds_cluster.Cluster{
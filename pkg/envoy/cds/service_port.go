@@ -0,0 +1,43 @@
+package cds
+
+import (
+	"fmt"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// getServicePortClusters returns a copy of the given cluster of the given upstream service for each of the service's
+// ports with a cluster of their own. The endpoints of the cluster of a port are the instances of the service listening
+// on the target port the service port maps to, or the service's hostname on the port for a DNS cluster.
+func getServicePortClusters(meshCatalog catalog.MeshCataloger, remoteCluster *xds_cluster.Cluster, upstreamSvc service.MeshService) []*xds_cluster.Cluster {
+	portToProtocol, err := meshCatalog.GetPortToProtocolMappingForService(upstreamSvc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting the ports of upstream service %s, not building the clusters of its ports", upstreamSvc)
+		return nil
+	}
+
+	var portClusters []*xds_cluster.Cluster
+	for _, port := range envoy.GetServicePortsWithCluster(portToProtocol) {
+		portCluster := proto.Clone(remoteCluster).(*xds_cluster.Cluster)
+		portCluster.Name = envoy.GetClusterNameForServicePort(upstreamSvc, port)
+		portCluster.AltStatName = fmt.Sprintf("%s.%d", getClusterStatName(upstreamSvc), port)
+		if loadAssignment := portCluster.GetLoadAssignment(); loadAssignment != nil {
+			loadAssignment.ClusterName = portCluster.Name
+			for _, localityEndpoints := range loadAssignment.Endpoints {
+				for _, lbEndpoint := range localityEndpoints.LbEndpoints {
+					if socketAddress := lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress(); socketAddress != nil {
+						socketAddress.PortSpecifier = &xds_core.SocketAddress_PortValue{PortValue: port}
+					}
+				}
+			}
+		}
+		portClusters = append(portClusters, portCluster)
+	}
+	return portClusters
+}
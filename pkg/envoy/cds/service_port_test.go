@@ -0,0 +1,83 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Service port clusters", func() {
+	var (
+		mockCtrl    *gomock.Controller
+		mockCatalog *catalog.MockMeshCataloger
+	)
+
+	upstreamSvc := tests.BookstoreV1Service
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Returns no cluster for a service exposing a single port", func() {
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{5432: "tcp"}, nil).Times(1)
+
+		remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String(), ClusterDiscoveryType: &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS}}
+		Expect(getServicePortClusters(mockCatalog, remoteCluster, upstreamSvc)).To(BeEmpty())
+	})
+
+	It("Returns an EDS cluster for each port of a service exposing several ports", func() {
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{8080: "http", 9090: "http", 5432: "tcp"}, nil).Times(1)
+
+		remoteCluster := &xds_cluster.Cluster{Name: upstreamSvc.String(), ClusterDiscoveryType: &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS}}
+		portClusters := getServicePortClusters(mockCatalog, remoteCluster, upstreamSvc)
+		Expect(portClusters).To(HaveLen(3))
+		Expect(portClusters[0].Name).To(Equal(envoy.GetClusterNameForServicePort(upstreamSvc, 5432)))
+		Expect(portClusters[0].GetType()).To(Equal(xds_cluster.Cluster_EDS))
+		Expect(portClusters[1].Name).To(Equal(envoy.GetClusterNameForServicePort(upstreamSvc, 8080)))
+		Expect(portClusters[2].Name).To(Equal(envoy.GetClusterNameForServicePort(upstreamSvc, 9090)))
+
+		// The service cluster is left unchanged
+		Expect(remoteCluster.Name).To(Equal(upstreamSvc.String()))
+	})
+
+	It("Resolves the service's hostname on the port of the cluster of a port of a DNS cluster", func() {
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{5432: "tcp", 9000: "tcp"}, nil).Times(1)
+
+		remoteCluster := &xds_cluster.Cluster{
+			Name:                 upstreamSvc.String(),
+			ClusterDiscoveryType: &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_STRICT_DNS},
+			LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+				ClusterName: upstreamSvc.String(),
+				Endpoints: []*xds_endpoint.LocalityLbEndpoints{{
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{Address: envoy.GetAddress(upstreamSvc.ServerName(), 5432)},
+						},
+					}},
+				}},
+			},
+		}
+		portClusters := getServicePortClusters(mockCatalog, remoteCluster, upstreamSvc)
+		Expect(portClusters).To(HaveLen(2))
+
+		getSocketAddress := func(cluster *xds_cluster.Cluster) *xds_core.SocketAddress {
+			return cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress()
+		}
+		Expect(portClusters[1].LoadAssignment.ClusterName).To(Equal(portClusters[1].Name))
+		Expect(getSocketAddress(portClusters[1]).Address).To(Equal(upstreamSvc.ServerName()))
+		Expect(getSocketAddress(portClusters[1]).GetPortValue()).To(Equal(uint32(9000)))
+		Expect(getSocketAddress(remoteCluster).GetPortValue()).To(Equal(uint32(5432)))
+	})
+})
@@ -18,7 +18,8 @@ func getTracingCluster(cfg configurator.Configurator) *xds_cluster.Cluster {
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
 			Type: xds_cluster.Cluster_LOGICAL_DNS,
 		},
-		LbPolicy: xds_cluster.Cluster_ROUND_ROBIN,
+		DnsLookupFamily: getDNSLookupFamily(cfg),
+		LbPolicy:        xds_cluster.Cluster_ROUND_ROBIN,
 		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
 			ClusterName: constants.EnvoyTracingCluster,
 			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
@@ -0,0 +1,38 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// getUpstreamBindSourceAddress returns the source address the connections to the given upstream service are bound to,
+// which is set by the service's annotation or defaults to the mesh-wide upstream bind source address
+func getUpstreamBindSourceAddress(meshCatalog catalog.MeshCataloger, upstreamSvc service.MeshService, cfg configurator.Configurator) string {
+	if address := meshCatalog.GetUpstreamBindSourceAddressForService(upstreamSvc); address != "" {
+		return address
+	}
+	return cfg.GetUpstreamBindSourceAddress()
+}
+
+// applyUpstreamBindConfig binds the upstream connections of the given cluster to the given source address.
+// Envoy picks the source address when the given address is empty.
+func applyUpstreamBindConfig(remoteCluster *xds_cluster.Cluster, sourceAddress string) {
+	if sourceAddress == "" {
+		return
+	}
+
+	remoteCluster.UpstreamBindConfig = &xds_core.BindConfig{
+		SourceAddress: &xds_core.SocketAddress{
+			Protocol: xds_core.SocketAddress_TCP,
+			Address:  sourceAddress,
+			PortSpecifier: &xds_core.SocketAddress_PortValue{
+				// Port 0 lets the kernel pick an ephemeral source port
+				PortValue: 0,
+			},
+		},
+	}
+}
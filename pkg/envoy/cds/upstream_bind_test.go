@@ -0,0 +1,73 @@
+package cds
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Upstream bind config", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockCatalog      *catalog.MockMeshCataloger
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	upstreamSvc := tests.BookstoreV1Service
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultClusterType().Return(constants.ClusterTypeEDS).AnyTimes()
+		mockConfigurator.EXPECT().GetUpstreamIdleTimeout().Return(time.Duration(0)).AnyTimes()
+		mockCatalog.EXPECT().GetUpstreamSNIForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().IsExternalTLSOriginationEnabledForService(upstreamSvc).Return(false).AnyTimes()
+		mockCatalog.EXPECT().GetClusterTypeForService(upstreamSvc).Return("").AnyTimes()
+		mockCatalog.EXPECT().GetHashPolicyForService(upstreamSvc).Return(nil).AnyTimes()
+		mockCatalog.EXPECT().GetUpstreamIdleTimeoutForService(upstreamSvc).Return(time.Duration(0)).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Leaves the upstream bind config unset when no source address is configured", func() {
+		mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(upstreamSvc).Return("").Times(1)
+		mockConfigurator.EXPECT().GetUpstreamBindSourceAddress().Return("").Times(1)
+
+		remoteCluster, err := buildUpstreamServiceCluster(mockCatalog, upstreamSvc, tests.BookbuyerService, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remoteCluster.UpstreamBindConfig).To(BeNil())
+	})
+
+	It("Binds the upstream connections to the mesh-wide source address", func() {
+		mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(upstreamSvc).Return("").Times(1)
+		mockConfigurator.EXPECT().GetUpstreamBindSourceAddress().Return("10.0.0.5").Times(1)
+
+		remoteCluster, err := buildUpstreamServiceCluster(mockCatalog, upstreamSvc, tests.BookbuyerService, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remoteCluster.UpstreamBindConfig).ToNot(BeNil())
+		Expect(remoteCluster.UpstreamBindConfig.SourceAddress.Address).To(Equal("10.0.0.5"))
+		Expect(remoteCluster.UpstreamBindConfig.SourceAddress.GetPortValue()).To(BeZero())
+	})
+
+	It("Binds the upstream connections to the source address set by the service over the mesh-wide source address", func() {
+		mockCatalog.EXPECT().GetUpstreamBindSourceAddressForService(upstreamSvc).Return("10.0.0.6").Times(1)
+
+		remoteCluster, err := buildUpstreamServiceCluster(mockCatalog, upstreamSvc, tests.BookbuyerService, mockConfigurator)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remoteCluster.UpstreamBindConfig.SourceAddress.Address).To(Equal("10.0.0.6"))
+	})
+})
@@ -1,6 +1,7 @@
 package cds
 
 import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -22,11 +23,13 @@ var _ = Describe("Test CDS Tracing Configuration", func() {
 		It("Returns Tracing cluster config", func() {
 			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).Times(1)
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).Times(1)
+			mockConfigurator.EXPECT().GetDNSLookupFamily().Return(constants.DNSLookupFamilyV4Only).Times(1)
 
 			actual := *getTracingCluster(mockConfigurator)
 			Expect(actual.Name).To(Equal(constants.EnvoyTracingCluster))
 			Expect(actual.AltStatName).To(Equal(constants.EnvoyTracingCluster))
 			Expect(len(actual.GetLoadAssignment().GetEndpoints())).To(Equal(1))
+			Expect(actual.DnsLookupFamily).To(Equal(xds_cluster.Cluster_V4_ONLY))
 		})
 	})
 })
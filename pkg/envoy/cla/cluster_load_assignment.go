@@ -1,8 +1,11 @@
 package cla
 
 import (
+	"sort"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 
@@ -13,20 +16,23 @@ import (
 
 const (
 	zone = "zone"
+
+	// lbMetadataKey is the filter metadata namespace of the endpoint metadata used by the load balancer for subset selection
+	lbMetadataKey = "envoy.lb"
+
+	// zoneMetadataKey is the key of the endpoint metadata holding the endpoint's zone
+	zoneMetadataKey = "zone"
 )
 
 // NewClusterLoadAssignment constructs the Envoy struct necessary for TrafficSplit implementation.
-func NewClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints []endpoint.Endpoint) *xds_endpoint.ClusterLoadAssignment {
+// Endpoints are grouped by the locality of their zone, endpoints without a zone being in the given default zone.
+func NewClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints []endpoint.Endpoint, defaultZone string) *xds_endpoint.ClusterLoadAssignment {
+	if defaultZone == "" {
+		defaultZone = zone
+	}
+
 	cla := &xds_endpoint.ClusterLoadAssignment{
 		ClusterName: serviceName.String(),
-		Endpoints: []*xds_endpoint.LocalityLbEndpoints{
-			{
-				Locality: &xds_core.Locality{
-					Zone: zone,
-				},
-				LbEndpoints: []*xds_endpoint.LbEndpoint{},
-			},
-		},
 	}
 
 	lenIPs := len(serviceEndpoints)
@@ -35,8 +41,16 @@ func NewClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints
 	}
 	weight := uint32(100 / lenIPs)
 
+	localityEndpoints := map[string]*xds_endpoint.LocalityLbEndpoints{
+		defaultZone: newLocalityLbEndpoints(defaultZone),
+	}
 	for _, meshEndpoint := range serviceEndpoints {
 		log.Trace().Msgf("[EDS][ClusterLoadAssignment] Adding Endpoint: Cluster=%s, Services=%s, Endpoint=%+v, Weight=%d", serviceName.String(), serviceName.String(), meshEndpoint, weight)
+		endpointZone := meshEndpoint.Zone
+		if endpointZone == "" {
+			endpointZone = defaultZone
+		}
+
 		lbEpt := xds_endpoint.LbEndpoint{
 			HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 				Endpoint: &xds_endpoint.Endpoint{
@@ -46,9 +60,65 @@ func NewClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints
 			LoadBalancingWeight: &wrappers.UInt32Value{
 				Value: weight,
 			},
+			HealthStatus: getHealthStatus(meshEndpoint),
+			Metadata:     getEndpointMetadata(meshEndpoint, endpointZone),
+		}
+
+		locality, ok := localityEndpoints[endpointZone]
+		if !ok {
+			locality = newLocalityLbEndpoints(endpointZone)
+			localityEndpoints[endpointZone] = locality
+		}
+		locality.LbEndpoints = append(locality.LbEndpoints, &lbEpt)
+	}
+
+	for localityZone, locality := range localityEndpoints {
+		// The default locality is only kept when it has endpoints, or when the service has no endpoints at all
+		if len(locality.LbEndpoints) == 0 && len(localityEndpoints) > 1 {
+			continue
 		}
-		cla.Endpoints[0].LbEndpoints = append(cla.Endpoints[0].LbEndpoints, &lbEpt)
+		log.Trace().Msgf("[EDS][ClusterLoadAssignment] Adding locality with Zone=%s to Cluster=%s", localityZone, serviceName)
+		cla.Endpoints = append(cla.Endpoints, locality)
 	}
+	sort.Slice(cla.Endpoints, func(i, j int) bool {
+		return cla.Endpoints[i].Locality.Zone < cla.Endpoints[j].Locality.Zone
+	})
+
 	log.Debug().Msgf("[EDS] Constructed ClusterLoadAssignment: %+v", cla)
 	return cla
 }
+
+// newLocalityLbEndpoints returns the locality holding the endpoints in the given zone
+func newLocalityLbEndpoints(localityZone string) *xds_endpoint.LocalityLbEndpoints {
+	return &xds_endpoint.LocalityLbEndpoints{
+		Locality: &xds_core.Locality{
+			Zone: localityZone,
+		},
+		LbEndpoints: []*xds_endpoint.LbEndpoint{},
+	}
+}
+
+// getHealthStatus returns the health status of the given endpoint based on its readiness
+func getHealthStatus(meshEndpoint endpoint.Endpoint) xds_core.HealthStatus {
+	if meshEndpoint.NotReady {
+		return xds_core.HealthStatus_UNHEALTHY
+	}
+	return xds_core.HealthStatus_HEALTHY
+}
+
+// getEndpointMetadata returns the load balancer metadata of the given endpoint in the given zone, made of its zone and
+// its metadata, for the subset load balancer to select endpoints. The zone is written last so that metadata copied
+// from a node label named after the zone key cannot override the locality of the endpoint.
+func getEndpointMetadata(meshEndpoint endpoint.Endpoint, endpointZone string) *xds_core.Metadata {
+	fields := make(map[string]*structpb.Value)
+	for key, value := range meshEndpoint.Metadata {
+		fields[key] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: value}}
+	}
+	fields[zoneMetadataKey] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: endpointZone}}
+
+	return &xds_core.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			lbMetadataKey: {Fields: fields},
+		},
+	}
+}
@@ -3,6 +3,8 @@ package cla
 import (
 	"net"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/service"
 
@@ -29,13 +31,13 @@ var _ = Describe("Testing Cluster Load Assignment", func() {
 				},
 			}
 
-			cla := NewClusterLoadAssignment(namespacedServices[0], allServiceEndpoints[namespacedServices[0]])
+			cla := NewClusterLoadAssignment(namespacedServices[0], allServiceEndpoints[namespacedServices[0]], "")
 			Expect(cla).NotTo(Equal(nil))
 			Expect(cla.ClusterName).To(Equal("osm/bookstore-1"))
 			Expect(len(cla.Endpoints)).To(Equal(1))
 			Expect(len(cla.Endpoints[0].LbEndpoints)).To(Equal(1))
 			Expect(cla.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value).To(Equal(uint32(100)))
-			cla2 := NewClusterLoadAssignment(namespacedServices[1], allServiceEndpoints[namespacedServices[1]])
+			cla2 := NewClusterLoadAssignment(namespacedServices[1], allServiceEndpoints[namespacedServices[1]], "")
 			Expect(cla2).NotTo(Equal(nil))
 			Expect(cla2.ClusterName).To(Equal("osm/bookstore-2"))
 			Expect(len(cla2.Endpoints)).To(Equal(1))
@@ -43,5 +45,64 @@ var _ = Describe("Testing Cluster Load Assignment", func() {
 			Expect(cla2.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value).To(Equal(uint32(50)))
 			Expect(cla2.Endpoints[0].LbEndpoints[1].GetLoadBalancingWeight().Value).To(Equal(uint32(50)))
 		})
+
+		It("Sets the health status of endpoints based on their readiness", func() {
+			svc := service.MeshService{Namespace: "osm", Name: "bookstore-1"}
+			endpoints := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 80},
+				{IP: net.ParseIP("10.0.0.2"), Port: 80, NotReady: true},
+			}
+
+			cla := NewClusterLoadAssignment(svc, endpoints, "")
+			Expect(cla.Endpoints[0].LbEndpoints).To(HaveLen(2))
+			Expect(cla.Endpoints[0].LbEndpoints[0].HealthStatus).To(Equal(xds_core.HealthStatus_HEALTHY))
+			Expect(cla.Endpoints[0].LbEndpoints[1].HealthStatus).To(Equal(xds_core.HealthStatus_UNHEALTHY))
+		})
+
+		It("Groups endpoints by zone, endpoints without a zone being in the default zone", func() {
+			svc := service.MeshService{Namespace: "osm", Name: "bookstore-1"}
+			endpoints := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 80, Zone: "us-east-1b"},
+				{IP: net.ParseIP("10.0.0.2"), Port: 80},
+				{IP: net.ParseIP("10.0.0.3"), Port: 80, Zone: "us-east-1b"},
+			}
+
+			cla := NewClusterLoadAssignment(svc, endpoints, "us-east-1a")
+			Expect(cla.Endpoints).To(HaveLen(2))
+			Expect(cla.Endpoints[0].Locality.Zone).To(Equal("us-east-1a"))
+			Expect(cla.Endpoints[0].LbEndpoints).To(HaveLen(1))
+			Expect(cla.Endpoints[1].Locality.Zone).To(Equal("us-east-1b"))
+			Expect(cla.Endpoints[1].LbEndpoints).To(HaveLen(2))
+		})
+
+		It("Only keeps the default locality when it has endpoints", func() {
+			svc := service.MeshService{Namespace: "osm", Name: "bookstore-1"}
+
+			cla := NewClusterLoadAssignment(svc, []endpoint.Endpoint{{IP: net.ParseIP("10.0.0.1"), Port: 80, Zone: "us-east-1b"}}, "")
+			Expect(cla.Endpoints).To(HaveLen(1))
+			Expect(cla.Endpoints[0].Locality.Zone).To(Equal("us-east-1b"))
+
+			cla = NewClusterLoadAssignment(svc, nil, "")
+			Expect(cla.Endpoints).To(HaveLen(1))
+			Expect(cla.Endpoints[0].Locality.Zone).To(Equal(zone))
+			Expect(cla.Endpoints[0].LbEndpoints).To(BeEmpty())
+		})
+	})
+
+	Context("Testing getEndpointMetadata", func() {
+		It("Returns the zone and metadata of the endpoint", func() {
+			metadata := getEndpointMetadata(endpoint.Endpoint{Metadata: map[string]string{"tier": "gold"}}, "us-east-1a")
+			fields := metadata.FilterMetadata[lbMetadataKey].Fields
+			Expect(fields).To(HaveLen(2))
+			Expect(fields[zoneMetadataKey].GetStringValue()).To(Equal("us-east-1a"))
+			Expect(fields["tier"].GetStringValue()).To(Equal("gold"))
+		})
+
+		It("Does not let the metadata of the endpoint override its zone", func() {
+			metadata := getEndpointMetadata(endpoint.Endpoint{Metadata: map[string]string{zoneMetadataKey: "rack-1"}}, "us-east-1a")
+			fields := metadata.FilterMetadata[lbMetadataKey].Fields
+			Expect(fields).To(HaveLen(1))
+			Expect(fields[zoneMetadataKey].GetStringValue()).To(Equal("us-east-1a"))
+		})
 	})
 })
@@ -0,0 +1,41 @@
+package envoy
+
+import (
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+// ProxyConfigStatus is the status of the config of an Envoy proxy connected to the control plane
+type ProxyConfigStatus struct {
+	// CommonName is the common name of the certificate of the proxy
+	CommonName certificate.CommonName `json:"common_name"`
+
+	// SerialNumber is the serial number of the certificate of the proxy
+	SerialNumber certificate.SerialNumber `json:"serial_number"`
+
+	// PodUID, Namespace and ServiceAccount describe the pod of the proxy, and are empty until the proxy reports them
+	PodUID         string `json:"pod_uid,omitempty"`
+	Namespace      string `json:"namespace,omitempty"`
+	ServiceAccount string `json:"service_account,omitempty"`
+
+	// ConnectedAt is the time the proxy connected to the control plane
+	ConnectedAt time.Time `json:"connected_at"`
+
+	// Types is the status of the config of each xDS type sent to the proxy
+	Types map[TypeURI]XDSTypeConfigStatus `json:"types"`
+}
+
+// XDSTypeConfigStatus is the status of the config of an xDS type sent to an Envoy proxy
+type XDSTypeConfigStatus struct {
+	// SentVersion and SentNonce identify the config last sent to the proxy
+	SentVersion string `json:"sent_version"`
+	SentNonce   string `json:"sent_nonce"`
+
+	// AckedVersion is the version of the config last acknowledged by the proxy, or empty if the proxy has not
+	// acknowledged any config
+	AckedVersion string `json:"acked_version,omitempty"`
+
+	// UpToDate is set when the proxy acknowledged the config last sent to it
+	UpToDate bool `json:"up_to_date"`
+}
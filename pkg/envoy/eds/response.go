@@ -1,6 +1,7 @@
 package eds
 
 import (
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 
 	"github.com/golang/protobuf/ptypes"
@@ -16,7 +17,7 @@ import (
 )
 
 // NewResponse creates a new Endpoint Discovery Response.
-func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, _ configurator.Configurator, _ certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
+func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
 	svcList, err := meshCatalog.GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
@@ -31,9 +32,19 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		return nil, err
 	}
 
+	// Requests are only failed over to allowed outbound services, so the endpoints of failover services are among the
+	// endpoints of these services
 	outboundServicesEndpoints := make(map[service.MeshService][]endpoint.Endpoint)
 	for _, dstSvc := range meshCatalog.ListAllowedOutboundServicesForIdentity(proxyIdentity) {
-		endpoints, err := meshCatalog.ListEndpointsForService(dstSvc)
+		if _, ok := outboundServicesEndpoints[dstSvc]; ok {
+			continue
+		}
+		// The endpoints that are not ready are sent with an unhealthy health status, unless excluded
+		listEndpoints := meshCatalog.ListAllEndpointsForService
+		if cfg.IsExcludeNotReadyEndpointsEnabled() {
+			listEndpoints = meshCatalog.ListEndpointsForService
+		}
+		endpoints, err := listEndpoints(dstSvc)
 		if err != nil {
 			log.Error().Err(err).Msgf("Failed listing endpoints for service %s", dstSvc)
 			continue
@@ -43,9 +54,15 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	log.Trace().Msgf("Outbound service endpoints for proxy %s: %v", proxyServiceName, outboundServicesEndpoints)
 
-	var protos []*any.Any
+	var loadAssignments []*xds_endpoint.ClusterLoadAssignment
 	for svc, endpoints := range outboundServicesEndpoints {
-		loadAssignment := cla.NewClusterLoadAssignment(svc, endpoints)
+		defaultZone := cfg.GetDefaultEndpointZone()
+		loadAssignments = append(loadAssignments, cla.NewClusterLoadAssignment(svc, endpoints, defaultZone))
+		loadAssignments = append(loadAssignments, getServicePortLoadAssignments(meshCatalog, svc, endpoints, defaultZone)...)
+	}
+
+	var protos []*any.Any
+	for _, loadAssignment := range loadAssignments {
 		proto, err := ptypes.MarshalAny(loadAssignment)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshalling EDS payload for proxy %s: %+v", proxyServiceName, loadAssignment)
@@ -60,3 +77,27 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 	}
 	return resp, nil
 }
+
+// getServicePortLoadAssignments returns the load assignments of the clusters of the ports of the given service with a
+// cluster of their own, made of the given endpoints of the service listening on the target port the service port maps to
+func getServicePortLoadAssignments(meshCatalog catalog.MeshCataloger, svc service.MeshService, endpoints []endpoint.Endpoint, defaultZone string) []*xds_endpoint.ClusterLoadAssignment {
+	portToProtocol, err := meshCatalog.GetPortToProtocolMappingForService(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting the ports of service %s, not building the load assignments of its ports", svc)
+		return nil
+	}
+
+	var loadAssignments []*xds_endpoint.ClusterLoadAssignment
+	for _, port := range envoy.GetServicePortsWithCluster(portToProtocol) {
+		var portEndpoints []endpoint.Endpoint
+		for _, ep := range endpoints {
+			if ep.ServicePort == endpoint.Port(port) {
+				portEndpoints = append(portEndpoints, ep)
+			}
+		}
+		loadAssignment := cla.NewClusterLoadAssignment(svc, portEndpoints, defaultZone)
+		loadAssignment.ClusterName = envoy.GetClusterNameForServicePort(svc, port)
+		loadAssignments = append(loadAssignments, loadAssignment)
+	}
+	return loadAssignments
+}
@@ -3,7 +3,10 @@ package eds
 import (
 	"context"
 	"fmt"
+	"net"
 
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
@@ -16,7 +19,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -30,7 +35,7 @@ var _ = Describe("Test EDS response", func() {
 	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
 
 	kubeClient := testclient.NewSimpleClientset()
-	catalog := catalog.NewFakeMeshCatalog(kubeClient)
+	meshCatalog := catalog.NewFakeMeshCatalog(kubeClient)
 
 	Context("Test eds.NewResponse", func() {
 		It("Correctly returns an response for endpoints when the certificate and service are valid", func() {
@@ -66,7 +71,9 @@ var _ = Describe("Test EDS response", func() {
 				Expect(err).ToNot(HaveOccurred())
 			}
 
-			_, err := NewResponse(catalog, proxy, nil, mockConfigurator, nil)
+			mockConfigurator.EXPECT().IsExcludeNotReadyEndpointsEnabled().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetDefaultEndpointZone().Return("").AnyTimes()
+			_, err := NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -83,8 +90,140 @@ var _ = Describe("Test EDS response", func() {
 			// Don't create a pod/service for this proxy, this should result in an error when the
 			// service is being looked up based on the proxy's certificate
 
-			_, err := NewResponse(catalog, proxy, nil, mockConfigurator, nil)
+			_, err := NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("Test eds.NewResponse with endpoints that are not ready", func() {
+		proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), tests.BookbuyerServiceAccountName, tests.Namespace)), "", nil)
+		ready := endpoint.Endpoint{IP: net.ParseIP("10.0.0.1"), Port: 80}
+		notReady := endpoint.Endpoint{IP: net.ParseIP("10.0.0.2"), Port: 80, NotReady: true}
+
+		// getLoadAssignment returns the load assignment of the upstream service in the EDS response of the proxy
+		getLoadAssignment := func(excludeNotReady bool) *xds_endpoint.ClusterLoadAssignment {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{tests.BookbuyerService}, nil).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{80: "http"}, nil).Times(1)
+			if excludeNotReady {
+				mockCatalog.EXPECT().ListEndpointsForService(tests.BookstoreV1Service).Return([]endpoint.Endpoint{ready}, nil).Times(1)
+			} else {
+				mockCatalog.EXPECT().ListAllEndpointsForService(tests.BookstoreV1Service).Return([]endpoint.Endpoint{ready, notReady}, nil).Times(1)
+			}
+			mockCfg.EXPECT().IsExcludeNotReadyEndpointsEnabled().Return(excludeNotReady).Times(1)
+			mockCfg.EXPECT().GetDefaultEndpointZone().Return("").Times(1)
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Resources).To(HaveLen(1))
+
+			loadAssignment := &xds_endpoint.ClusterLoadAssignment{}
+			Expect(ptypes.UnmarshalAny(resp.Resources[0], loadAssignment)).To(Succeed())
+			return loadAssignment
+		}
+
+		It("sends the endpoints that are not ready as unhealthy by default", func() {
+			loadAssignment := getLoadAssignment(false)
+			Expect(loadAssignment.Endpoints).To(HaveLen(1))
+			Expect(loadAssignment.Endpoints[0].LbEndpoints).To(HaveLen(2))
+		})
+
+		It("removes the endpoints that are not ready from the load assignment when excluding them", func() {
+			loadAssignment := getLoadAssignment(true)
+			Expect(loadAssignment.Endpoints).To(HaveLen(1))
+			Expect(loadAssignment.Endpoints[0].LbEndpoints).To(HaveLen(1))
+			Expect(loadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress().Address).To(Equal(ready.IP.String()))
+		})
+	})
+
+	Context("Test eds.NewResponse with endpoint localities and metadata", func() {
+		It("propagates the zone and metadata of the catalog endpoints to the load assignment", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), tests.BookbuyerServiceAccountName, tests.Namespace)), "", nil)
+
+			endpoints := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 80, Zone: "zone-b", Metadata: map[string]string{"node.kubernetes.io/instance-type": "m5.large"}},
+				{IP: net.ParseIP("10.0.0.2"), Port: 80},
+			}
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{tests.BookbuyerService}, nil).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{80: "http"}, nil).Times(1)
+			mockCatalog.EXPECT().ListAllEndpointsForService(tests.BookstoreV1Service).Return(endpoints, nil).Times(1)
+			mockCfg.EXPECT().IsExcludeNotReadyEndpointsEnabled().Return(false).Times(1)
+			mockCfg.EXPECT().GetDefaultEndpointZone().Return("zone-a").Times(1)
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Resources).To(HaveLen(1))
+
+			loadAssignment := &xds_endpoint.ClusterLoadAssignment{}
+			Expect(ptypes.UnmarshalAny(resp.Resources[0], loadAssignment)).To(Succeed())
+			Expect(loadAssignment.Endpoints).To(HaveLen(2))
+
+			Expect(loadAssignment.Endpoints[0].Locality.Zone).To(Equal("zone-a"))
+			Expect(loadAssignment.Endpoints[0].LbEndpoints).To(HaveLen(1))
+			defaultZoneMetadata := loadAssignment.Endpoints[0].LbEndpoints[0].Metadata.FilterMetadata["envoy.lb"].Fields
+			Expect(defaultZoneMetadata).To(HaveLen(1))
+			Expect(defaultZoneMetadata["zone"].GetStringValue()).To(Equal("zone-a"))
+
+			Expect(loadAssignment.Endpoints[1].Locality.Zone).To(Equal("zone-b"))
+			Expect(loadAssignment.Endpoints[1].LbEndpoints).To(HaveLen(1))
+			metadata := loadAssignment.Endpoints[1].LbEndpoints[0].Metadata.FilterMetadata["envoy.lb"].Fields
+			Expect(metadata).To(HaveLen(2))
+			Expect(metadata["zone"].GetStringValue()).To(Equal("zone-b"))
+			Expect(metadata["node.kubernetes.io/instance-type"].GetStringValue()).To(Equal("m5.large"))
+		})
+	})
+
+	Context("Test eds.NewResponse with a service exposing several ports", func() {
+		It("assigns the endpoints listening on the target port of each service port to the cluster of the port", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCfg := configurator.NewMockConfigurator(mockCtrl)
+			proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), tests.BookbuyerServiceAccountName, tests.Namespace)), "", nil)
+
+			// The service port 8080 maps to the target port 18080, and the service port 9090 to the target port 19090
+			endpoints := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 18080, ServicePort: 8080},
+				{IP: net.ParseIP("10.0.0.1"), Port: 19090, ServicePort: 9090},
+				{IP: net.ParseIP("10.0.0.2"), Port: 18080, ServicePort: 8080},
+				{IP: net.ParseIP("10.0.0.2"), Port: 19090, ServicePort: 9090},
+			}
+			mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(gomock.Any()).Return([]service.MeshService{tests.BookbuyerService}, nil).Times(1)
+			mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gomock.Any()).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+			mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{8080: "http", 9090: "http"}, nil).Times(1)
+			mockCatalog.EXPECT().ListAllEndpointsForService(tests.BookstoreV1Service).Return(endpoints, nil).Times(1)
+			mockCfg.EXPECT().IsExcludeNotReadyEndpointsEnabled().Return(false).Times(1)
+			mockCfg.EXPECT().GetDefaultEndpointZone().Return("").Times(1)
+
+			resp, err := NewResponse(mockCatalog, proxy, nil, mockCfg, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			endpointPortsByCluster := make(map[string][]uint32)
+			for _, resource := range resp.Resources {
+				loadAssignment := &xds_endpoint.ClusterLoadAssignment{}
+				Expect(ptypes.UnmarshalAny(resource, loadAssignment)).To(Succeed())
+				for _, localityEndpoints := range loadAssignment.Endpoints {
+					for _, lbEndpoint := range localityEndpoints.LbEndpoints {
+						port := lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress().GetPortValue()
+						endpointPortsByCluster[loadAssignment.ClusterName] = append(endpointPortsByCluster[loadAssignment.ClusterName], port)
+					}
+				}
+			}
+			Expect(endpointPortsByCluster).To(Equal(map[string][]uint32{
+				tests.BookstoreV1Service.String():                                  {18080, 19090, 18080, 19090},
+				envoy.GetClusterNameForServicePort(tests.BookstoreV1Service, 8080): {18080, 18080},
+				envoy.GetClusterNameForServicePort(tests.BookstoreV1Service, 9090): {19090, 19090},
+			}))
+		})
+	})
 })
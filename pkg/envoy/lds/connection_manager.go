@@ -1,19 +1,33 @@
 package lds
 
 import (
+	"math"
+
+	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_buffer "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 const (
 	statPrefix = "http"
+
+	// accessLogSamplingRuntimeKey is the runtime key overriding the percentage of the requests logged by the access logs
+	accessLogSamplingRuntimeKey = "osm.access_log_sampling"
+
+	// accessLogSamplingPercentageScale scales a percentage to the numerator of a fraction of a million
+	accessLogSamplingPercentageScale = 10000
 )
 
 func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *xds_hcm.HttpConnectionManager {
@@ -50,6 +64,60 @@ func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *
 	return connManager
 }
 
+// applyMaxRequestBytes adds a buffer filter to the given HTTP connection manager when a mesh wide limit on the size
+// of requests is configured, or when routes override the limit. Requests exceeding the limit are rejected with a 413
+// by the buffer filter. Without a mesh wide limit, the filter is disabled on the routes not overriding the limit.
+func applyMaxRequestBytes(connManager *xds_hcm.HttpConnectionManager, cfg configurator.Configurator, hasRouteOverrides bool) error {
+	maxRequestBytes := cfg.GetMaxRequestBytes()
+	if maxRequestBytes == 0 {
+		if !hasRouteOverrides {
+			return nil
+		}
+		// The limit of the buffer filter is required, so the largest limit stands in for the unlimited mesh wide limit
+		maxRequestBytes = math.MaxUint32
+	}
+
+	marshalledBuffer, err := ptypes.MarshalAny(&xds_buffer.Buffer{
+		MaxRequestBytes: &wrappers.UInt32Value{Value: maxRequestBytes},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling Buffer filter config")
+		return err
+	}
+
+	bufferFilter := &xds_hcm.HttpFilter{
+		Name:       wellknown.Buffer,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{TypedConfig: marshalledBuffer},
+	}
+	// The buffer filter must precede the router filter
+	connManager.HttpFilters = append([]*xds_hcm.HttpFilter{bufferFilter}, connManager.HttpFilters...)
+	return nil
+}
+
+// applyHTTP2MaxConcurrentStreams limits the number of concurrent streams on a single HTTP/2 connection of the given
+// HTTP connection manager. Envoy's default applies when the given limit is 0.
+func applyHTTP2MaxConcurrentStreams(connManager *xds_hcm.HttpConnectionManager, maxConcurrentStreams uint32) {
+	if maxConcurrentStreams == 0 {
+		return
+	}
+
+	connManager.Http2ProtocolOptions = &xds_core.Http2ProtocolOptions{
+		MaxConcurrentStreams: &wrappers.UInt32Value{Value: maxConcurrentStreams},
+	}
+}
+
+// applyClientAddressDetection sets how the given HTTP connection manager determines the client address of requests.
+// Envoy's defaults, which take the client address from the X-Forwarded-For header without trusting any hop, apply
+// when the given detection is nil.
+func applyClientAddressDetection(connManager *xds_hcm.HttpConnectionManager, detection *trafficpolicy.ClientAddressDetection) {
+	if detection == nil {
+		return
+	}
+
+	connManager.UseRemoteAddress = &wrappers.BoolValue{Value: detection.UseRemoteAddress}
+	connManager.XffNumTrustedHops = detection.XFFNumTrustedHops
+}
+
 func getPrometheusConnectionManager(listenerName string, routeName string, clusterName string) *xds_hcm.HttpConnectionManager {
 	return &xds_hcm.HttpConnectionManager{
 		StatPrefix: listenerName,
@@ -83,3 +151,26 @@ func getPrometheusConnectionManager(listenerName string, routeName string, clust
 		AccessLog: envoy.GetAccessLog(),
 	}
 }
+
+// applyAccessLogSampling has the access logs of the given HTTP connection manager only log the given percentage of the
+// requests, sampled by a runtime filter whose runtime key can override the percentage. All requests are logged when
+// the percentage is 100 or more.
+func applyAccessLogSampling(connManager *xds_hcm.HttpConnectionManager, percentage float64) {
+	if percentage >= 100 {
+		return
+	}
+
+	for _, accessLog := range connManager.AccessLog {
+		accessLog.Filter = &xds_accesslog.AccessLogFilter{
+			FilterSpecifier: &xds_accesslog.AccessLogFilter_RuntimeFilter{
+				RuntimeFilter: &xds_accesslog.RuntimeFilter{
+					RuntimeKey: accessLogSamplingRuntimeKey,
+					PercentSampled: &xds_type.FractionalPercent{
+						Numerator:   uint32(math.Round(percentage * accessLogSamplingPercentageScale)),
+						Denominator: xds_type.FractionalPercent_MILLION,
+					},
+				},
+			},
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// failStaticStatPrefix is the stat prefix of the fail-static listeners, counting the rejected requests in the
+	// http.fail_static.downstream_rq_5xx stat
+	failStaticStatPrefix = "fail_static"
+
+	failStaticFilterChainName    = "fail-static-filter-chain"
+	failStaticTLSFilterChainName = "fail-static-tls-filter-chain"
+
+	// failStaticResponseBody is the body of the responses to the requests rejected by the fail-static listeners
+	failStaticResponseBody = "The config of the OSM sidecar is not ready yet\n"
+)
+
+// newFailStaticResponse returns an LDS response programming the proxy with fail-static inbound and outbound listeners,
+// which reject all requests with a 503 and a body explaining the sidecar's config is not ready, instead of dropping
+// the connections for lack of a matching filter chain. The inbound listener terminates the mTLS connections of mesh
+// peers with the certificate of the given service account for them to get the 503 rather than a failed handshake.
+// The listeners are replaced by the mesh listeners once the mesh config is ready.
+func newFailStaticResponse(svcAccount service.K8sServiceAccount, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+	filterChain, err := buildFailStaticFilterChain()
+	if err != nil {
+		return nil, err
+	}
+	tlsFilterChain, err := buildFailStaticTLSFilterChain(svcAccount, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	inboundListener := newInboundListener()
+	inboundListener.FilterChains = []*xds_listener.FilterChain{tlsFilterChain, filterChain}
+
+	outboundListener := &xds_listener.Listener{
+		Name:             outboundListenerName,
+		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort),
+		TrafficDirection: xds_core.TrafficDirection_OUTBOUND,
+		FilterChains:     []*xds_listener.FilterChain{filterChain},
+	}
+
+	resp := &xds_discovery.DiscoveryResponse{
+		TypeUrl: string(envoy.TypeLDS),
+	}
+	for _, listener := range []*xds_listener.Listener{outboundListener, inboundListener} {
+		marshalledListener, err := ptypes.MarshalAny(listener)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling fail-static listener %s", listener.Name)
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, marshalledListener)
+	}
+	return resp, nil
+}
+
+// buildFailStaticFilterChain returns a filter chain responding to all requests with a 503
+func buildFailStaticFilterChain() (*xds_listener.FilterChain, error) {
+	connManager := &xds_hcm.HttpConnectionManager{
+		StatPrefix: failStaticStatPrefix,
+		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
+		HttpFilters: []*xds_hcm.HttpFilter{{
+			Name: wellknown.Router,
+		}},
+		RouteSpecifier: &xds_hcm.HttpConnectionManager_RouteConfig{
+			RouteConfig: &xds_route.RouteConfiguration{
+				VirtualHosts: []*xds_route.VirtualHost{{
+					Name:    failStaticStatPrefix,
+					Domains: []string{"*"},
+					Routes: []*xds_route.Route{{
+						Match: &xds_route.RouteMatch{
+							PathSpecifier: &xds_route.RouteMatch_Prefix{
+								Prefix: "/",
+							},
+						},
+						Action: &xds_route.Route_DirectResponse{
+							DirectResponse: &xds_route.DirectResponseAction{
+								Status: 503,
+								Body: &xds_core.DataSource{
+									Specifier: &xds_core.DataSource_InlineString{InlineString: failStaticResponseBody},
+								},
+							},
+						},
+					}},
+				}},
+			},
+		},
+		AccessLog: envoy.GetAccessLog(),
+	}
+	marshalledConnManager, err := ptypes.MarshalAny(connManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object for the fail-static filter chain")
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: failStaticFilterChainName,
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.HTTPConnectionManager,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledConnManager},
+			},
+		},
+	}, nil
+}
+
+// buildFailStaticTLSFilterChain returns a filter chain terminating TLS with the certificate of the given service
+// account and responding to all requests with a 503. The certificate of the service account is served over SDS as
+// the service certificate of its synthetic service, since the services of the proxy cannot be resolved yet. Client
+// certificates are not required, as no request is forwarded to the application.
+func buildFailStaticTLSFilterChain(svcAccount service.K8sServiceAccount, cfg configurator.Configurator) (*xds_listener.FilterChain, error) {
+	filterChain, err := buildFailStaticFilterChain()
+	if err != nil {
+		return nil, err
+	}
+
+	downstreamTLSContext := &xds_auth.DownstreamTlsContext{
+		CommonTlsContext: &xds_auth.CommonTlsContext{
+			TlsParams: envoy.GetTLSParams(cfg),
+			TlsCertificateSdsSecretConfigs: []*xds_auth.SdsSecretConfig{{
+				Name: envoy.SDSCert{
+					MeshService: svcAccount.GetSyntheticService(),
+					CertType:    envoy.ServiceCertType,
+				}.String(),
+				SdsConfig: envoy.GetADSConfigSource(),
+			}},
+		},
+	}
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(downstreamTLSContext)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext for the fail-static filter chain of service account %s", svcAccount)
+		return nil, err
+	}
+
+	filterChain.Name = failStaticTLSFilterChainName
+	filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+		TransportProtocol: envoy.TransportProtocolTLS,
+	}
+	filterChain.TransportSocket = &xds_core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &xds_core.TransportSocket_TypedConfig{
+			TypedConfig: marshalledDownstreamTLSContext,
+		},
+	}
+	return filterChain, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
@@ -14,6 +15,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 const (
@@ -31,14 +33,24 @@ func getIngressTransportProtocol(forHTTPS bool) string {
 	return ""
 }
 
-func newIngressHTTPFilterChain(cfg configurator.Configurator, svc service.MeshService, svcPort uint32) *xds_listener.FilterChain {
-	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(svc, false /* TLS */))
+func newIngressHTTPFilterChain(cfg configurator.Configurator, svc service.MeshService, svcPort uint32, clientAddressDetection *trafficpolicy.ClientAddressDetection, accessLogSamplingPercentage float64, hasMaxRequestBytesOverrides bool, downstreamTLSContext *xds_auth.DownstreamTlsContext) *xds_listener.FilterChain {
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(downstreamTLSContext)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for proxy %s", svc)
 		return nil
 	}
 
 	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+	// The stats of the traffic from the ingress are told apart from the stats of mesh traffic by a distinct prefix
+	if ingressStatPrefix := cfg.GetIngressStatPrefix(); ingressStatPrefix != "" {
+		inboundConnManager.StatPrefix = ingressStatPrefix
+	}
+	if err := applyMaxRequestBytes(inboundConnManager, cfg, hasMaxRequestBytesOverrides); err != nil {
+		log.Error().Err(err).Msgf("Error applying request size limit for proxy %s", svc)
+		return nil
+	}
+	applyClientAddressDetection(inboundConnManager, clientAddressDetection)
+	applyAccessLogSampling(inboundConnManager, accessLogSamplingPercentage)
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager object for proxy %s", svc)
@@ -74,6 +86,13 @@ func (lb *listenerBuilder) getIngressFilterChains(svc service.MeshService) []*xd
 		return ingressFilterChains
 	}
 
+	// The client address of requests from the ingress is determined as set by the service
+	clientAddressDetection := lb.meshCatalog.GetClientAddressDetectionForService(svc)
+	accessLogSamplingPercentage := lb.meshCatalog.GetAccessLogSamplingPercentageForService(svc)
+	hasMaxRequestBytesOverrides := lb.meshCatalog.HasMaxRequestBytesOverrides()
+	// Connections from the ingress use TLS without client certificates
+	downstreamTLSContext := lb.getDownstreamTLSContext(svc, false /* TLS */)
+
 	// Create protocol specific inbound filter chains per port to handle different ports serving different protocols
 	for port, appProtocol := range protocolToPortMap {
 		switch appProtocol {
@@ -81,14 +100,14 @@ func (lb *listenerBuilder) getIngressFilterChains(svc service.MeshService) []*xd
 			// Ingress filter chain for HTTP port
 			if lb.cfg.UseHTTPSIngress() {
 				// Filter chain with SNI matching enabled for HTTPS clients that set the SNI
-				ingressFilterChainWithSNI := newIngressHTTPFilterChain(lb.cfg, svc, port)
+				ingressFilterChainWithSNI := newIngressHTTPFilterChain(lb.cfg, svc, port, clientAddressDetection, accessLogSamplingPercentage, hasMaxRequestBytesOverrides, downstreamTLSContext)
 				ingressFilterChainWithSNI.Name = fmt.Sprintf("%s:%d", inboundIngressHTTPSFilterChain, port)
 				ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.ServerName()}
 				ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithSNI)
 			}
 
 			// Filter chain without SNI matching enabled for HTTP clients and HTTPS clients that don't set the SNI
-			ingressFilterChainWithoutSNI := newIngressHTTPFilterChain(lb.cfg, svc, port)
+			ingressFilterChainWithoutSNI := newIngressHTTPFilterChain(lb.cfg, svc, port, clientAddressDetection, accessLogSamplingPercentage, hasMaxRequestBytesOverrides, downstreamTLSContext)
 			ingressFilterChainWithoutSNI.Name = fmt.Sprintf("%s:%d", inboundIngressNonSNIFilterChain, port)
 			ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithoutSNI)
 
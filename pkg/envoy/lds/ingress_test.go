@@ -8,12 +8,16 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 func TestGetIngressFilterChains(t *testing.T) {
@@ -24,10 +28,12 @@ func TestGetIngressFilterChains(t *testing.T) {
 	proxyService := tests.BookstoreV1Service
 
 	testCases := []struct {
-		name                 string
-		httpsIngress         bool // true for https, false for http
-		svcPortToProtocolMap map[uint32]string
-		portToProtocolErr    error // error to return if port:protocol mapping returns an error
+		name                   string
+		httpsIngress           bool // true for https, false for http
+		svcPortToProtocolMap   map[uint32]string
+		portToProtocolErr      error // error to return if port:protocol mapping returns an error
+		clientAddressDetection *trafficpolicy.ClientAddressDetection
+		ingressStatPrefix      string
 
 		expectedFilterChainCount               int
 		expectedFilterNamesPerFilterChain      []string
@@ -60,6 +66,12 @@ func TestGetIngressFilterChains(t *testing.T) {
 			httpsIngress:         true,
 			svcPortToProtocolMap: map[uint32]string{80: "http", 90: "http"},
 			portToProtocolErr:    nil,
+			clientAddressDetection: &trafficpolicy.ClientAddressDetection{
+				UseRemoteAddress:  true,
+				XFFNumTrustedHops: 1,
+			},
+
+			ingressStatPrefix: "ingress",
 
 			expectedFilterChainCount:          4, // number of ports * 2; 2 because for HTTPS 2 filter chains are created: with and without SNI matching
 			expectedFilterNamesPerFilterChain: []string{wellknown.HTTPConnectionManager},
@@ -89,7 +101,11 @@ func TestGetIngressFilterChains(t *testing.T) {
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(gomock.Any()).Return(false).AnyTimes()
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+			mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+			mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 
 			lb := &listenerBuilder{
 				meshCatalog: mockCatalog,
@@ -99,10 +115,15 @@ func TestGetIngressFilterChains(t *testing.T) {
 
 			// Mock catalog call to get port:protocol mapping for service
 			mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(proxyService).Return(tc.svcPortToProtocolMap, tc.portToProtocolErr).Times(1)
+			mockCatalog.EXPECT().GetClientAddressDetectionForService(proxyService).Return(tc.clientAddressDetection).Times(1)
+			mockCatalog.EXPECT().GetAccessLogSamplingPercentageForService(proxyService).Return(float64(100)).Times(1)
 			// Mock configurator calls to determine HTTP vs HTTPS ingress
 			mockConfigurator.EXPECT().UseHTTPSIngress().Return(tc.httpsIngress).AnyTimes()
 			// Mock calls used to build the HTTP connection manager
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+			mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetIngressStatPrefix().Return(tc.ingressStatPrefix).AnyTimes()
 
 			filterChains := lb.getIngressFilterChains(proxyService)
 
@@ -115,6 +136,23 @@ func TestGetIngressFilterChains(t *testing.T) {
 				for i, filter := range filterChain.Filters {
 					assert.Equal(tc.expectedFilterNamesPerFilterChain[i], filter.Name)
 				}
+
+				// The client address of requests from the ingress is determined as set by the service
+				hcm := &xds_hcm.HttpConnectionManager{}
+				assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), hcm))
+				if tc.clientAddressDetection == nil {
+					assert.Nil(hcm.UseRemoteAddress)
+					assert.Zero(hcm.XffNumTrustedHops)
+				} else {
+					assert.Equal(tc.clientAddressDetection.UseRemoteAddress, hcm.UseRemoteAddress.GetValue())
+					assert.Equal(tc.clientAddressDetection.XFFNumTrustedHops, hcm.XffNumTrustedHops)
+				}
+				// The stats of the ingress traffic have the configured prefix
+				if tc.ingressStatPrefix == "" {
+					assert.Equal(statPrefix, hcm.StatPrefix)
+				} else {
+					assert.Equal(tc.ingressStatPrefix, hcm.StatPrefix)
+				}
 				actualFilterChainMatchPerFilterChain = append(actualFilterChainMatchPerFilterChain, filterChain.FilterChainMatch)
 			}
 
@@ -2,13 +2,16 @@ package lds
 
 import (
 	"fmt"
+	"net"
 	"sort"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_quic "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
@@ -16,19 +19,24 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rbac"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
 	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
-	inboundMeshHTTPFilterChainPrefix  = "inbound-mesh-http-filter-chain"
-	outboundMeshHTTPFilterChainPrefix = "outbound-mesh-http-filter-chain"
-	inboundMeshTCPFilterChainPrefix   = "inbound-mesh-tcp-filter-chain"
-	outboundMeshTCPFilterChainPrefix  = "outbound-mesh-tcp-filter-chain"
-	httpAppProtocol                   = "http"
-	tcpAppProtocol                    = "tcp"
-	gRPCAppProtocol                   = "grpc"
+	inboundMeshHTTPFilterChainPrefix      = "inbound-mesh-http-filter-chain"
+	outboundMeshHTTPFilterChainPrefix     = "outbound-mesh-http-filter-chain"
+	inboundMeshTCPFilterChainPrefix       = "inbound-mesh-tcp-filter-chain"
+	inboundMeshHTTP3FilterChainPrefix     = "inbound-mesh-http3-filter-chain"
+	inboundSourceIPRangeFilterChainPrefix = "inbound-source-ip-range-filter-chain"
+	inboundPlaintextFilterChainPrefix     = "inbound-plaintext-filter-chain"
+	inboundMTLSRequiredFilterChainPrefix  = "inbound-mtls-required-filter-chain"
+	outboundMeshTCPFilterChainPrefix      = "outbound-mesh-tcp-filter-chain"
+	httpAppProtocol                       = "http"
+	tcpAppProtocol                        = "tcp"
+	gRPCAppProtocol                       = "grpc"
 )
 
 func (lb *listenerBuilder) getInboundMeshFilterChains(proxyService service.MeshService) []*xds_listener.FilterChain {
@@ -65,26 +73,251 @@ func (lb *listenerBuilder) getInboundMeshFilterChains(proxyService service.MeshS
 		}
 	}
 
+	// Accept plaintext connections from the source IP ranges allowed by the service when they may bypass mTLS
+	if lb.cfg.IsSourceIPRangeMTLSBypassEnabled() {
+		if sourceIPRanges := lb.meshCatalog.GetAllowedSourceIPRangesForService(proxyService); len(sourceIPRanges) > 0 {
+			var sourceIPRangeFilterChains []*xds_listener.FilterChain
+			for _, mtlsFilterChain := range filterChains {
+				filterChain, err := getInboundSourceIPRangeFilterChain(mtlsFilterChain, sourceIPRanges)
+				if err != nil {
+					log.Error().Err(err).Msgf("Error building inbound source IP range filter chain for proxy:port %s:%d", proxyService, mtlsFilterChain.FilterChainMatch.DestinationPort.GetValue())
+					continue
+				}
+				sourceIPRangeFilterChains = append(sourceIPRangeFilterChains, filterChain)
+			}
+			filterChains = append(filterChains, sourceIPRangeFilterChains...)
+		}
+	}
+
 	return filterChains
 }
 
-func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshService) ([]*xds_listener.Filter, error) {
+// getInboundSourceIPRangeFilterChain returns a filter chain accepting plaintext connections from the given source IP ranges
+// to the port of the given mTLS filter chain. The connections are handled by the filters of the mTLS filter chain, whose
+// RBAC filter allows the source IP ranges.
+func getInboundSourceIPRangeFilterChain(mtlsFilterChain *xds_listener.FilterChain, sourceIPRanges []string) (*xds_listener.FilterChain, error) {
+	var sourcePrefixRanges []*xds_core.CidrRange
+	for _, cidr := range sourceIPRanges {
+		cidrRange, err := rbac.GetCIDRRange(cidr)
+		if err != nil {
+			return nil, err
+		}
+		sourcePrefixRanges = append(sourcePrefixRanges, cidrRange)
+	}
+
+	servicePort := mtlsFilterChain.FilterChainMatch.DestinationPort.GetValue()
+	return &xds_listener.FilterChain{
+		Name:    fmt.Sprintf("%s:%d", inboundSourceIPRangeFilterChainPrefix, servicePort),
+		Filters: mtlsFilterChain.Filters,
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			DestinationPort: &wrapperspb.UInt32Value{
+				Value: servicePort,
+			},
+			SourcePrefixRanges: sourcePrefixRanges,
+
+			// Only match plaintext connections, connections using TLS are matched by the mTLS filter chain
+			TransportProtocol: envoy.TransportProtocolRawBuffer,
+		},
+	}, nil
+}
+
+// removeExcludedPortFilterChains returns the given filter chains without the ones matching the given excluded ports
+func removeExcludedPortFilterChains(filterChains []*xds_listener.FilterChain, excludedPorts []int) []*xds_listener.FilterChain {
+	if len(excludedPorts) == 0 {
+		return filterChains
+	}
+
+	excluded := make(map[uint32]bool, len(excludedPorts))
+	for _, port := range excludedPorts {
+		excluded[uint32(port)] = true
+	}
+
+	var filtered []*xds_listener.FilterChain
+	for _, filterChain := range filterChains {
+		if port := filterChain.GetFilterChainMatch().GetDestinationPort(); port != nil && excluded[port.GetValue()] {
+			log.Debug().Msgf("Skipping inbound filter chain %s for port %d excluded from interception", filterChain.Name, port.GetValue())
+			continue
+		}
+		filtered = append(filtered, filterChain)
+	}
+	return filtered
+}
+
+// buildInboundPlaintextFilterChain returns a filter chain accepting plaintext connections from the given node IP to the
+// given port, such as kubelet probes that cannot present a mesh certificate, and passing them through to the local
+// application without requiring mTLS. Plaintext connections from other sources are left to the filter chain rejecting
+// them, and connections using TLS to the mTLS filter chains.
+func buildInboundPlaintextFilterChain(port uint32, nodeIP string, tcpAccessLogFormat string) (*xds_listener.FilterChain, error) {
+	filterChainName := fmt.Sprintf("%s:%d", inboundPlaintextFilterChainPrefix, port)
+	nodeIPRange, err := getHostCIDRRange(nodeIP)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing node IP for the inbound plaintext filter chain on port %d", port)
+		return nil, err
+	}
+
+	marshalledTCPProxy, err := ptypes.MarshalAny(&xds_tcp_proxy.TcpProxy{
+		StatPrefix:       filterChainName,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.InboundPassthroughCluster},
+		AccessLog:        envoy.GetTCPAccessLog(tcpAccessLogFormat),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for the inbound plaintext filter chain on port %d", port)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: filterChainName,
+		Filters: []*xds_listener.Filter{{
+			Name:       wellknown.TCPProxy,
+			ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+		}},
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			DestinationPort: &wrapperspb.UInt32Value{
+				Value: port,
+			},
+			SourcePrefixRanges: []*xds_core.CidrRange{nodeIPRange},
+
+			// Only match plaintext connections, connections using TLS are matched by the mTLS filter chain
+			TransportProtocol: envoy.TransportProtocolRawBuffer,
+		},
+	}, nil
+}
+
+// getHostCIDRRange returns the CIDR range made of the given IP address only
+func getHostCIDRRange(ip string) (*xds_core.CidrRange, error) {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return nil, errors.Errorf("invalid IP address %q", ip)
+	}
+	prefixLen := net.IPv6len * 8
+	if parsedIP.To4() != nil {
+		prefixLen = net.IPv4len * 8
+	}
+	return rbac.GetCIDRRange(fmt.Sprintf("%s/%d", ip, prefixLen))
+}
+
+// getInboundMeshHTTP3FilterChains returns the filter chains for the experimental inbound HTTP/3 (QUIC) listener.
+// Inbound UDP traffic is redirected to the QUIC listener by the init container, which loses the destination port
+// of the original packets, so a single filter chain matching on the SNI of the service serves all its HTTP and gRPC
+// ports. Requests are routed to the right port based on their host header. TCP ports are left to the TCP inbound
+// listener.
+func (lb *listenerBuilder) getInboundMeshHTTP3FilterChains(proxyService service.MeshService) []*xds_listener.FilterChain {
+	var filterChains []*xds_listener.FilterChain
+
+	protocolToPortMap, err := lb.meshCatalog.GetTargetPortToProtocolMappingForService(proxyService)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error retrieving port to protocol mapping for service %s", proxyService)
+		return filterChains
+	}
+
+	hasHTTPPort := false
+	for port, appProtocol := range protocolToPortMap {
+		switch strings.ToLower(appProtocol) {
+		case httpAppProtocol, gRPCAppProtocol:
+			hasHTTPPort = true
+
+		default:
+			log.Trace().Msgf("Skipping inbound HTTP/3 for protocol %s on proxy:port %s:%d", appProtocol, proxyService, port)
+		}
+	}
+	if !hasHTTPPort {
+		return filterChains
+	}
+
+	filterChain, err := lb.getInboundMeshHTTP3FilterChain(proxyService)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building inbound HTTP/3 filter chain for proxy service %s", proxyService)
+		return filterChains
+	}
+
+	return append(filterChains, filterChain)
+}
+
+func (lb *listenerBuilder) getInboundMeshHTTP3FilterChain(proxyService service.MeshService) (*xds_listener.FilterChain, error) {
+	filterchainName := fmt.Sprintf("%s:%s", inboundMeshHTTP3FilterChainPrefix, proxyService)
+
+	// Construct HTTP filters with an HTTP/3 codec
+	filters, err := lb.getInboundHTTPFilters(proxyService, xds_hcm.HttpConnectionManager_HTTP3, filterchainName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error constructing inbound HTTP/3 filters for proxy service %s", proxyService)
+		return nil, err
+	}
+
+	// Construct the QUIC transport socket wrapping the downstream TLS context
+	marshalledQUICTransport, err := ptypes.MarshalAny(&xds_quic.QuicDownstreamTransport{
+		DownstreamTlsContext: lb.getDownstreamTLSContext(proxyService, true /* mTLS */),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling QuicDownstreamTransport for proxy service %s", proxyService)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name:    filterchainName,
+		Filters: filters,
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			// The ServerName is the SNI set by the downstream during the QUIC handshake. There is no match on
+			// the destination port because the UDP redirection to the QUIC listener rewrites it.
+			ServerNames: []string{proxyService.ServerName()},
+		},
+		TransportSocket: &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketQuic,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledQUICTransport,
+			},
+		},
+	}, nil
+}
+
+// getInboundHTTPFilters returns the filters of an inbound HTTP filter chain. The stats of the HTTP connection manager
+// are prefixed with the given stat prefix, which must be unique per filter chain for the stats of each port to be
+// distinguishable.
+func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshService, codecType xds_hcm.HttpConnectionManager_CodecType, statPrefix string) ([]*xds_listener.Filter, error) {
 	var filters []*xds_listener.Filter
+	var httpRBACFilter *xds_hcm.HttpFilter
 
 	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must be the first filter in the list of filters.
 	if !lb.cfg.IsPermissiveTrafficPolicyMode() {
 		// Apply RBAC policies on the inbound filters based on configured policies
-		rbacFilter, err := lb.buildRBACFilter()
+		rbacFilter, err := lb.buildRBACFilter(proxyService)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error applying RBAC filter for proxy service %s", proxyService)
 			return nil, err
 		}
 		// RBAC filter should be the very first filter in the filter chain
 		filters = append(filters, rbacFilter)
+
+		// Apply the per route RBAC policies restricting the sources allowed to access each route
+		httpRBACFilter, err = getHTTPRBACFilter()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building HTTP RBAC filter for proxy service %s", proxyService)
+			return nil, err
+		}
+	} else if lb.cfg.IsPermissiveModeSANAuthorizationEnabled() {
+		// Authorize the connections from the principals of the mesh by the SAN of their certificate
+		rbacFilter, err := buildTrustDomainRBACFilter()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error applying trust domain RBAC filter for proxy service %s", proxyService)
+			return nil, err
+		}
+		filters = append(filters, rbacFilter)
 	}
 
 	// Apply the HTTP Connection Manager Filter
 	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, lb.cfg)
+	inboundConnManager.StatPrefix = statPrefix
+	inboundConnManager.CodecType = codecType
+	if err := applyMaxRequestBytes(inboundConnManager, lb.cfg, lb.meshCatalog.HasMaxRequestBytesOverrides()); err != nil {
+		log.Error().Err(err).Msgf("Error applying request size limit for proxy service %s", proxyService)
+		return nil, err
+	}
+	applyHTTP2MaxConcurrentStreams(inboundConnManager, lb.meshCatalog.GetHTTP2MaxConcurrentStreamsForService(proxyService))
+	applyClientAddressDetection(inboundConnManager, lb.meshCatalog.GetClientAddressDetectionForService(proxyService))
+	applyAccessLogSampling(inboundConnManager, lb.meshCatalog.GetAccessLogSamplingPercentageForService(proxyService))
+	if httpRBACFilter != nil {
+		// The HTTP RBAC filter must precede the router filter
+		inboundConnManager.HttpFilters = append([]*xds_hcm.HttpFilter{httpRBACFilter}, inboundConnManager.HttpFilters...)
+	}
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager for proxy  service %s", proxyService)
@@ -102,21 +335,22 @@ func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshServic
 }
 
 func (lb *listenerBuilder) getInboundMeshHTTPFilterChain(proxyService service.MeshService, servicePort uint32) (*xds_listener.FilterChain, error) {
+	filterchainName := fmt.Sprintf("%s:%d", inboundMeshHTTPFilterChainPrefix, servicePort)
+
 	// Construct HTTP filters
-	filters, err := lb.getInboundHTTPFilters(proxyService)
+	filters, err := lb.getInboundHTTPFilters(proxyService, xds_hcm.HttpConnectionManager_AUTO, filterchainName)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error constructing inbound HTTP filters for proxy service %s", proxyService)
 		return nil, err
 	}
 
 	// Construct downstream TLS context
-	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(proxyService, true /* mTLS */))
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(lb.getDownstreamTLSContext(proxyService, true /* mTLS */))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext for proxy service %s", proxyService)
 		return nil, err
 	}
 
-	filterchainName := fmt.Sprintf("%s:%d", inboundMeshHTTPFilterChainPrefix, servicePort)
 	filterChain := &xds_listener.FilterChain{
 		Name:    filterchainName,
 		Filters: filters,
@@ -159,7 +393,7 @@ func (lb *listenerBuilder) getInboundMeshTCPFilterChain(proxyService service.Mes
 	}
 
 	// Construct downstream TLS context
-	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(proxyService, true /* mTLS */))
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(lb.getDownstreamTLSContext(proxyService, true /* mTLS */))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext for proxy service %s", proxyService)
 		return nil, err
@@ -200,19 +434,28 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must be the first filter in the list of filters.
 	if !lb.cfg.IsPermissiveTrafficPolicyMode() {
 		// Apply RBAC policies on the inbound filters based on configured policies
-		rbacFilter, err := lb.buildRBACFilter()
+		rbacFilter, err := lb.buildRBACFilter(proxyService)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error applying RBAC filter for proxy service %s", proxyService)
 			return nil, err
 		}
 		// RBAC filter should be the very first filter in the filter chain
 		filters = append(filters, rbacFilter)
+	} else if lb.cfg.IsPermissiveModeSANAuthorizationEnabled() {
+		// Authorize the connections from the principals of the mesh by the SAN of their certificate
+		rbacFilter, err := buildTrustDomainRBACFilter()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error applying trust domain RBAC filter for proxy service %s", proxyService)
+			return nil, err
+		}
+		filters = append(filters, rbacFilter)
 	}
 
 	// Apply the TCP Proxy Filter
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       "inbound-mesh-tcp-proxy",
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.GetLocalClusterNameForService(proxyService)},
+		AccessLog:        envoy.GetTCPAccessLog(lb.cfg.GetTCPAccessLogFormat()),
 	}
 	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
 	if err != nil {
@@ -317,9 +560,9 @@ func (lb *listenerBuilder) getOutboundHTTPFilterChainForService(upstream service
 	}, nil
 }
 
-func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.MeshService, port uint32) (*xds_listener.FilterChain, error) {
+func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.MeshService, port uint32, clusterName string) (*xds_listener.FilterChain, error) {
 	// Get TCP filter for service
-	filter, err := lb.getOutboundTCPFilter(upstream)
+	filter, err := lb.getOutboundTCPFilter(upstream, clusterName)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error getting outbound TCP filter for upstream service %s", upstream)
 		return nil, err
@@ -340,10 +583,11 @@ func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.
 	}, nil
 }
 
-func (lb *listenerBuilder) getOutboundTCPFilter(upstream service.MeshService) (*xds_listener.Filter, error) {
+func (lb *listenerBuilder) getOutboundTCPFilter(upstream service.MeshService, clusterName string) (*xds_listener.Filter, error) {
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       fmt.Sprintf("%s:%s", outboundMeshTCPFilterChainPrefix, upstream),
-		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: upstream.String()},
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: clusterName},
+		AccessLog:        envoy.GetTCPAccessLog(lb.cfg.GetTCPAccessLogFormat()),
 	}
 	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
 	if err != nil {
@@ -401,6 +645,13 @@ func (lb *listenerBuilder) getOutboundFilterChainPerUpstream() []*xds_listener.F
 			continue
 		}
 
+		// The TCP ports of a service exposing several ports are routed to the cluster of their port, whose endpoints listen
+		// on the target port the service port maps to
+		portsWithCluster := mapset.NewSet()
+		for _, port := range envoy.GetServicePortsWithCluster(protocolToPortMap) {
+			portsWithCluster.Add(port)
+		}
+
 		// Create protocol specific inbound filter chains per port to handle different ports serving different protocols
 		for port, appProtocol := range protocolToPortMap {
 			switch strings.ToLower(appProtocol) {
@@ -414,7 +665,11 @@ func (lb *listenerBuilder) getOutboundFilterChainPerUpstream() []*xds_listener.F
 
 			case tcpAppProtocol:
 				// Construct TCP filter chain
-				if tcpFilterChain, err := lb.getOutboundTCPFilterChainForService(upstream, port); err != nil {
+				clusterName := upstream.String()
+				if portsWithCluster.Contains(port) {
+					clusterName = envoy.GetClusterNameForServicePort(upstream, port)
+				}
+				if tcpFilterChain, err := lb.getOutboundTCPFilterChainForService(upstream, port, clusterName); err != nil {
 					log.Error().Err(err).Msgf("Error constructing outbound TCP filter chain for upstream service %s on proxy with identity %s", upstream, lb.svcAccount)
 				} else {
 					filterChains = append(filterChains, tcpFilterChain)
@@ -10,12 +10,19 @@ import (
 
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/tests"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
@@ -28,10 +35,16 @@ func TestGetOutboundHTTPFilterChainForService(t *testing.T) {
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-api").AnyTimes()
+	mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatB3).AnyTimes()
 
 	lb := &listenerBuilder{
 		meshCatalog: mockCatalog,
@@ -105,11 +118,18 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(gomock.Any()).Return(false).AnyTimes()
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-api").AnyTimes()
+	mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatB3).AnyTimes()
 
 	lb := &listenerBuilder{
 		meshCatalog: mockCatalog,
@@ -120,12 +140,16 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 	proxyService := tests.BookbuyerService
 
 	testCases := []struct {
-		name           string
-		permissiveMode bool
-		port           uint32
+		name             string
+		permissiveMode   bool
+		sanAuthorization bool
+		port             uint32
 
+		maxConcurrentStreams     uint32
+		clientAddressDetection   *trafficpolicy.ClientAddressDetection
 		expectedFilterChainMatch *xds_listener.FilterChainMatch
 		expectedFilterNames      []string
+		expectedHTTPFilterNames  []string
 		expectError              bool
 	}{
 		{
@@ -138,22 +162,45 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 				TransportProtocol:    "tls",
 				ApplicationProtocols: []string{"osm"},
 			},
-			expectedFilterNames: []string{wellknown.RoleBasedAccessControl, wellknown.HTTPConnectionManager},
-			expectError:         false,
+			expectedFilterNames:     []string{wellknown.RoleBasedAccessControl, wellknown.HTTPConnectionManager},
+			expectedHTTPFilterNames: []string{wellknown.HTTPRoleBasedAccessControl, wellknown.Router},
+			expectError:             false,
 		},
 
 		{
-			name:           "inbound HTTP filter chain with permissive mode enabled",
-			permissiveMode: true,
-			port:           90,
+			name:                 "inbound HTTP filter chain with permissive mode enabled and max concurrent streams",
+			permissiveMode:       true,
+			port:                 90,
+			maxConcurrentStreams: 100,
+			clientAddressDetection: &trafficpolicy.ClientAddressDetection{
+				UseRemoteAddress:  true,
+				XFFNumTrustedHops: 2,
+			},
 			expectedFilterChainMatch: &xds_listener.FilterChainMatch{
 				DestinationPort:      &wrapperspb.UInt32Value{Value: 90},
 				ServerNames:          []string{proxyService.ServerName()},
 				TransportProtocol:    "tls",
 				ApplicationProtocols: []string{"osm"},
 			},
-			expectedFilterNames: []string{wellknown.HTTPConnectionManager},
-			expectError:         false,
+			expectedFilterNames:     []string{wellknown.HTTPConnectionManager},
+			expectedHTTPFilterNames: []string{wellknown.Router},
+			expectError:             false,
+		},
+
+		{
+			name:             "inbound HTTP filter chain with permissive mode and SAN authorization enabled",
+			permissiveMode:   true,
+			sanAuthorization: true,
+			port:             90,
+			expectedFilterChainMatch: &xds_listener.FilterChainMatch{
+				DestinationPort:      &wrapperspb.UInt32Value{Value: 90},
+				ServerNames:          []string{proxyService.ServerName()},
+				TransportProtocol:    "tls",
+				ApplicationProtocols: []string{"osm"},
+			},
+			expectedFilterNames:     []string{wellknown.RoleBasedAccessControl, wellknown.HTTPConnectionManager},
+			expectedHTTPFilterNames: []string{wellknown.Router},
+			expectError:             false,
 		},
 	}
 
@@ -172,9 +219,16 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(tc.permissiveMode).Times(1)
+			if tc.permissiveMode {
+				mockConfigurator.EXPECT().IsPermissiveModeSANAuthorizationEnabled().Return(tc.sanAuthorization).Times(1)
+			}
+			mockCatalog.EXPECT().GetHTTP2MaxConcurrentStreamsForService(proxyService).Return(tc.maxConcurrentStreams).Times(1)
+			mockCatalog.EXPECT().GetClientAddressDetectionForService(proxyService).Return(tc.clientAddressDetection).Times(1)
+			mockCatalog.EXPECT().GetAccessLogSamplingPercentageForService(proxyService).Return(float64(100)).Times(1)
 			if !tc.permissiveMode {
 				// mock catalog calls used to build the RBAC filter
 				mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(lb.svcAccount).Return(trafficTargets, nil).Times(1)
+				mockCatalog.EXPECT().GetAllowedSourceIPRangesForService(gomock.Any()).Return(nil).Times(1)
 			}
 
 			filterChain, err := lb.getInboundMeshHTTPFilterChain(proxyService, tc.port)
@@ -185,21 +239,110 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 			for i, filter := range filterChain.Filters {
 				assert.Equal(filter.Name, tc.expectedFilterNames[i])
 			}
+
+			// Clients must present a certificate validated against the mesh root certificate
+			downstreamTLSContext := &xds_auth.DownstreamTlsContext{}
+			assert.Nil(ptypes.UnmarshalAny(filterChain.TransportSocket.GetTypedConfig(), downstreamTLSContext))
+			assert.True(downstreamTLSContext.RequireClientCertificate.GetValue())
+
+			// The HTTP connection manager is the last filter
+			hcm := &xds_hcm.HttpConnectionManager{}
+			err = ptypes.UnmarshalAny(filterChain.Filters[len(filterChain.Filters)-1].GetTypedConfig(), hcm)
+			assert.Nil(err)
+			var httpFilterNames []string
+			for _, httpFilter := range hcm.HttpFilters {
+				httpFilterNames = append(httpFilterNames, httpFilter.Name)
+			}
+			assert.Equal(tc.expectedHTTPFilterNames, httpFilterNames)
+			assert.Equal(fmt.Sprintf("%s:%d", inboundMeshHTTPFilterChainPrefix, tc.port), hcm.StatPrefix)
+
+			// Envoy's default applies unless the service limits the concurrent HTTP/2 streams
+			if tc.maxConcurrentStreams == 0 {
+				assert.Nil(hcm.Http2ProtocolOptions)
+			} else {
+				assert.Equal(tc.maxConcurrentStreams, hcm.Http2ProtocolOptions.GetMaxConcurrentStreams().GetValue())
+			}
+
+			// Envoy's defaults apply unless the service sets how the client address is determined
+			if tc.clientAddressDetection == nil {
+				assert.Nil(hcm.UseRemoteAddress)
+				assert.Zero(hcm.XffNumTrustedHops)
+			} else {
+				assert.Equal(tc.clientAddressDetection.UseRemoteAddress, hcm.UseRemoteAddress.GetValue())
+				assert.Equal(tc.clientAddressDetection.XFFNumTrustedHops, hcm.XffNumTrustedHops)
+			}
+
+			// HTTP filter chains log requests using the HTTP access log, not the TCP access log
+			assert.Len(hcm.AccessLog, 1)
+			fileAccessLog := &xds_accesslog.FileAccessLog{}
+			assert.Nil(ptypes.UnmarshalAny(hcm.AccessLog[0].GetTypedConfig(), fileAccessLog))
+			assert.Contains(fileAccessLog.GetLogFormat().GetJsonFormat().GetFields(), "method")
 		})
 	}
 }
 
+func TestInboundMeshHTTPFilterChainStatPrefixPerPort(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(gomock.Any()).Return(false).AnyTimes()
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveModeSANAuthorizationEnabled().Return(false).AnyTimes()
+	mockCatalog.EXPECT().GetHTTP2MaxConcurrentStreamsForService(gomock.Any()).Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().GetClientAddressDetectionForService(gomock.Any()).Return(nil).AnyTimes()
+	mockCatalog.EXPECT().GetAccessLogSamplingPercentageForService(gomock.Any()).Return(float64(100)).AnyTimes()
+
+	lb := &listenerBuilder{
+		meshCatalog: mockCatalog,
+		cfg:         mockConfigurator,
+		svcAccount:  tests.BookstoreServiceAccount,
+	}
+	proxyService := tests.BookstoreV1Service
+
+	var statPrefixes []string
+	for _, port := range []uint32{8080, 9090} {
+		filterChain, err := lb.getInboundMeshHTTPFilterChain(proxyService, port)
+		assert.Nil(err)
+
+		hcm := &xds_hcm.HttpConnectionManager{}
+		assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[len(filterChain.Filters)-1].GetTypedConfig(), hcm))
+		statPrefixes = append(statPrefixes, hcm.StatPrefix)
+	}
+
+	// The stats of the HTTP connection managers of the ports are distinguishable
+	assert.Equal([]string{"inbound-mesh-http-filter-chain:8080", "inbound-mesh-http-filter-chain:9090"}, statPrefixes)
+}
+
 func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 	assert := tassert.New(t)
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(gomock.Any()).Return(false).AnyTimes()
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-api").AnyTimes()
+	mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatB3).AnyTimes()
+
+	tcpAccessLogFormat := "%DOWNSTREAM_REMOTE_ADDRESS% %BYTES_SENT% %BYTES_RECEIVED% %DURATION%\n"
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return(tcpAccessLogFormat).AnyTimes()
 
 	lb := &listenerBuilder{
 		meshCatalog: mockCatalog,
@@ -210,9 +353,10 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 	proxyService := tests.BookbuyerService
 
 	testCases := []struct {
-		name           string
-		permissiveMode bool
-		port           uint32
+		name             string
+		permissiveMode   bool
+		sanAuthorization bool
+		port             uint32
 
 		expectedFilterChainMatch *xds_listener.FilterChainMatch
 		expectedFilterNames      []string
@@ -245,6 +389,21 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 			expectedFilterNames: []string{wellknown.TCPProxy},
 			expectError:         false,
 		},
+
+		{
+			name:             "inbound TCP filter chain with permissive mode and SAN authorization enabled",
+			permissiveMode:   true,
+			sanAuthorization: true,
+			port:             90,
+			expectedFilterChainMatch: &xds_listener.FilterChainMatch{
+				DestinationPort:      &wrapperspb.UInt32Value{Value: 90},
+				ServerNames:          []string{proxyService.ServerName()},
+				TransportProtocol:    "tls",
+				ApplicationProtocols: []string{"osm"},
+			},
+			expectedFilterNames: []string{wellknown.RoleBasedAccessControl, wellknown.TCPProxy},
+			expectError:         false,
+		},
 	}
 
 	trafficTargets := []trafficpolicy.TrafficTargetWithRoutes{
@@ -262,9 +421,13 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(tc.permissiveMode).Times(1)
+			if tc.permissiveMode {
+				mockConfigurator.EXPECT().IsPermissiveModeSANAuthorizationEnabled().Return(tc.sanAuthorization).Times(1)
+			}
 			if !tc.permissiveMode {
 				// mock catalog calls used to build the RBAC filter
 				mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(lb.svcAccount).Return(trafficTargets, nil).Times(1)
+				mockCatalog.EXPECT().GetAllowedSourceIPRangesForService(gomock.Any()).Return(nil).Times(1)
 			}
 
 			filterChain, err := lb.getInboundMeshTCPFilterChain(proxyService, tc.port)
@@ -275,6 +438,15 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 			for i, filter := range filterChain.Filters {
 				assert.Equal(filter.Name, tc.expectedFilterNames[i])
 			}
+
+			// The TCP proxy is the last filter and logs connections using the TCP access log format
+			tcpProxy := &xds_tcp_proxy.TcpProxy{}
+			err = ptypes.UnmarshalAny(filterChain.Filters[len(filterChain.Filters)-1].GetTypedConfig(), tcpProxy)
+			assert.Nil(err)
+			assert.Len(tcpProxy.AccessLog, 1)
+			fileAccessLog := &xds_accesslog.FileAccessLog{}
+			assert.Nil(ptypes.UnmarshalAny(tcpProxy.AccessLog[0].GetTypedConfig(), fileAccessLog))
+			assert.Equal(tcpAccessLogFormat, fileAccessLog.GetLogFormat().GetTextFormat())
 		})
 	}
 }
@@ -285,6 +457,9 @@ func TestGetOutboundFilterChainMatchForService(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 
 	lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceAccount, mockConfigurator)
@@ -419,3 +594,114 @@ func TestGetOutboundFilterChainMatchForService(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInboundMeshFilterChainsWithSourceIPRanges(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(gomock.Any()).Return(false).AnyTimes()
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveModeSANAuthorizationEnabled().Return(false).AnyTimes()
+
+	lb := &listenerBuilder{
+		meshCatalog: mockCatalog,
+		cfg:         mockConfigurator,
+		svcAccount:  tests.BookbuyerServiceAccount,
+	}
+
+	proxyService := tests.BookbuyerService
+
+	testCases := []struct {
+		name           string
+		mtlsBypass     bool
+		sourceIPRanges []string
+
+		expectedSourceIPRangeFilterChain bool
+	}{
+		{
+			name:                             "plaintext connections from the allowed source IP ranges are accepted when mTLS bypass is enabled",
+			mtlsBypass:                       true,
+			sourceIPRanges:                   []string{"10.0.0.0/8", "192.168.1.10/32"},
+			expectedSourceIPRangeFilterChain: true,
+		},
+		{
+			name:                             "connections from the allowed source IP ranges require mTLS when mTLS bypass is disabled",
+			mtlsBypass:                       false,
+			sourceIPRanges:                   []string{"10.0.0.0/8"},
+			expectedSourceIPRangeFilterChain: false,
+		},
+		{
+			name:                             "plaintext connections are denied when no source IP range is allowed",
+			mtlsBypass:                       true,
+			sourceIPRanges:                   nil,
+			expectedSourceIPRangeFilterChain: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
+			mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(proxyService).Return(map[uint32]string{80: "tcp"}, nil).Times(1)
+			mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(tc.mtlsBypass).Times(1)
+			if tc.mtlsBypass {
+				mockCatalog.EXPECT().GetAllowedSourceIPRangesForService(proxyService).Return(tc.sourceIPRanges).Times(1)
+			}
+
+			filterChains := lb.getInboundMeshFilterChains(proxyService)
+			if !tc.expectedSourceIPRangeFilterChain {
+				// Only the mTLS filter chain is configured
+				assert.Len(filterChains, 1)
+				assert.Equal(envoy.TransportProtocolTLS, filterChains[0].FilterChainMatch.TransportProtocol)
+				return
+			}
+
+			assert.Len(filterChains, 2)
+			mtlsFilterChain, sourceIPRangeFilterChain := filterChains[0], filterChains[1]
+			assert.Equal(fmt.Sprintf("%s:%d", inboundSourceIPRangeFilterChainPrefix, 80), sourceIPRangeFilterChain.Name)
+			assert.Equal(mtlsFilterChain.Filters, sourceIPRangeFilterChain.Filters)
+			assert.Nil(sourceIPRangeFilterChain.TransportSocket)
+
+			// Only plaintext connections from the allowed source IP ranges match the filter chain
+			match := sourceIPRangeFilterChain.FilterChainMatch
+			assert.Equal(uint32(80), match.DestinationPort.GetValue())
+			assert.Equal(envoy.TransportProtocolRawBuffer, match.TransportProtocol)
+			assert.Empty(match.ServerNames)
+			assert.Len(match.SourcePrefixRanges, len(tc.sourceIPRanges))
+			for i, cidr := range tc.sourceIPRanges {
+				_, ipNet, err := net.ParseCIDR(cidr)
+				assert.Nil(err)
+				prefixLen, _ := ipNet.Mask.Size()
+				assert.Equal(ipNet.IP.String(), match.SourcePrefixRanges[i].AddressPrefix)
+				assert.Equal(uint32(prefixLen), match.SourcePrefixRanges[i].PrefixLen.GetValue())
+			}
+		})
+	}
+}
+
+func TestRemoveExcludedPortFilterChains(t *testing.T) {
+	assert := tassert.New(t)
+
+	newFilterChain := func(name string, port uint32) *xds_listener.FilterChain {
+		return &xds_listener.FilterChain{
+			Name: name,
+			FilterChainMatch: &xds_listener.FilterChainMatch{
+				DestinationPort: &wrapperspb.UInt32Value{Value: port},
+			},
+		}
+	}
+	appFilterChain := newFilterChain("inbound-mesh-http-filter-chain:default/bookstore:8080", 8080)
+	metricsFilterChain := newFilterChain("inbound-mesh-http-filter-chain:default/bookstore:9090", 9090)
+	filterChains := []*xds_listener.FilterChain{appFilterChain, metricsFilterChain}
+
+	// Without excluded ports, all the filter chains are kept
+	assert.Equal(filterChains, removeExcludedPortFilterChains(filterChains, nil))
+
+	// The filter chain of the excluded metrics port is removed
+	assert.Equal([]*xds_listener.FilterChain{appFilterChain}, removeExcludedPortFilterChains(filterChains, []int{9090}))
+}
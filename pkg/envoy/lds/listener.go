@@ -1,33 +1,92 @@
 package lds
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
-	inboundListenerName           = "inbound-listener"
-	outboundListenerName          = "outbound-listener"
-	prometheusListenerName        = "inbound-prometheus-listener"
-	outboundEgressFilterChainName = "outbound-egress-filter-chain"
-	singleIpv4Mask                = 32
+	inboundListenerName                = constants.EnvoyInboundListenerName
+	outboundListenerName               = "outbound-listener"
+	inboundQUICListenerName            = "inbound-quic-listener"
+	prometheusListenerName             = "inbound-prometheus-listener"
+	outboundEgressFilterChainName      = "outbound-egress-filter-chain"
+	outboundBlackholeFilterChainName   = "outbound-blackhole-filter-chain"
+	outboundPassthroughFilterChainName = "outbound-passthrough-filter-chain"
+	outboundOriginalDstFilterChainName = "outbound-original-dst-filter-chain"
+	inboundUnmatchedFilterChainName    = "inbound-unmatched-filter-chain"
+	inboundUnmatchedSNIStatPrefix      = "inbound-unmatched-sni"
+	singleIpv4Mask                     = 32
+
+	// quicListenerName is the name of the UDP listener factory used by Envoy to accept QUIC connections
+	quicListenerName = "quic_listener"
+
+	// outboundPassthroughStatPrefix is the stat prefix of the outbound passthrough HTTP filter chains, and the name of
+	// their virtual host and virtual cluster, which are part of the stat names of the passed through requests
+	outboundPassthroughStatPrefix = "outbound-passthrough"
+
+	// outboundPassthroughTCPFilterChainName is the name of the outbound passthrough filter chain of the connections
+	// other than plaintext HTTP, such as TLS and raw TCP connections
+	outboundPassthroughTCPFilterChainName = "outbound-passthrough-tcp-filter-chain"
+
+	// outboundPassthroughTCPStatPrefix is the stat prefix of the outbound passthrough TCP filter chain
+	outboundPassthroughTCPStatPrefix = "outbound-passthrough-tcp"
+
+	// outboundPassthroughProtocolDetectionTimeout is how long the outbound listener waits for the first bytes of a
+	// connection to detect plaintext HTTP when passing through unknown hosts, after which the connection is passed
+	// through as TCP, e.g. for server-first protocols
+	outboundPassthroughProtocolDetectionTimeout = 100 * time.Millisecond
 )
 
-func (lb *listenerBuilder) newOutboundListener() (*xds_listener.Listener, error) {
-	serviceFilterChains := lb.getOutboundFilterChainPerUpstream()
+// plaintextHTTPApplicationProtocols are the application protocols the HTTP inspector listener filter detects for
+// plaintext HTTP connections
+var plaintextHTTPApplicationProtocols = []string{"http/1.0", "http/1.1", "h2c"}
+
+// Socket option levels and names, as defined by Linux, used to configure the sockets of the listeners
+const (
+	solSocket   = 1  // SOL_SOCKET
+	soReusePort = 15 // SO_REUSEPORT
+	ipprotoIP   = 0  // IPPROTO_IP
+	ipTOS       = 1  // IP_TOS
+
+	// dscpTOSShift is the offset of the DSCP value within the TOS byte, whose 2 lower bits are used for ECN
+	dscpTOSShift = 2
+)
+
+// newOutboundListener returns the outbound listener, with a filter chain per allowed upstream unless outbound is
+// disabled. The outbound listener of a proxy with outbound disabled only handles the traffic redirected to it by the
+// default filter chain, as outbound traffic keeps being redirected to the proxy.
+func (lb *listenerBuilder) newOutboundListener(outboundDisabled bool) (*xds_listener.Listener, error) {
+	var serviceFilterChains []*xds_listener.FilterChain
+	if !outboundDisabled {
+		serviceFilterChains = lb.getOutboundFilterChainPerUpstream()
+	}
 
 	listener := &xds_listener.Listener{
 		Name:             outboundListenerName,
 		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort),
 		TrafficDirection: xds_core.TrafficDirection_OUTBOUND,
 		FilterChains:     serviceFilterChains,
+		SocketOptions:    getListenerSocketOptions(lb.cfg),
 		ListenerFilters: []*xds_listener.ListenerFilter{
 			{
 				// The OriginalDestination ListenerFilter is used to redirect traffic
@@ -37,16 +96,54 @@ func (lb *listenerBuilder) newOutboundListener() (*xds_listener.Listener, error)
 		},
 	}
 
-	// Create filter chain for egress if egress is enabled
-	// This filter chain matches any traffic not filtered by allow rules, it will be treated as egress
-	// traffic when enabled
-	if lb.cfg.IsEgressEnabled() {
-		egressFilterChain, err := buildEgressFilterChain()
+	// In the original_dst mode used for transparent proxying, traffic not filtered by allow rules is forwarded to its
+	// original destination, while mesh traffic keeps being routed by the per-upstream mTLS filter chains
+	if lb.cfg.IsOutboundOriginalDstEnabled() {
+		originalDstFilterChain, err := buildOutboundOriginalDstFilterChain(lb.cfg.GetTCPAccessLogFormat())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting filter chain for the outbound original destination")
+			return nil, err
+		}
+		listener.DefaultFilterChain = originalDstFilterChain
+	} else if lb.cfg.IsEgressEnabled() {
+		// Create filter chain for egress if egress is enabled
+		// This filter chain matches any traffic not filtered by allow rules, it will be treated as egress
+		// traffic when enabled
+		egressFilterChain, err := buildEgressFilterChain(lb.cfg.GetTCPAccessLogFormat())
 		if err != nil {
 			log.Error().Err(err).Msgf("Error getting filter chain for Egress")
 			return nil, err
 		}
 		listener.DefaultFilterChain = egressFilterChain
+	} else if lb.cfg.GetOutboundUnknownHostMode() == constants.OutboundUnknownHostModePassthrough {
+		// Otherwise, the connections to hosts unknown to the mesh are forwarded to their original destination when
+		// passed through: plaintext HTTP requests detected by the HTTP inspector are routed by the passthrough HTTP
+		// filter chains, and other connections, such as TLS and raw TCP connections, by the default filter chain
+		passthroughFilterChains, err := buildOutboundPassthroughFilterChains(getFilterChainDestinationPorts(serviceFilterChains))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting filter chains for the outbound passthrough")
+			return nil, err
+		}
+		passthroughTCPFilterChain, err := buildOutboundPassthroughTCPFilterChain(lb.cfg.GetTCPAccessLogFormat())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting TCP filter chain for the outbound passthrough")
+			return nil, err
+		}
+		listener.FilterChains = append(listener.FilterChains, passthroughFilterChains...)
+		listener.DefaultFilterChain = passthroughTCPFilterChain
+		listener.ListenerFilters = append(listener.ListenerFilters, &xds_listener.ListenerFilter{
+			Name: wellknown.HttpInspector,
+		})
+		listener.ListenerFiltersTimeout = ptypes.DurationProto(outboundPassthroughProtocolDetectionTimeout)
+		listener.ContinueOnListenerFiltersTimeout = true
+	} else if lb.cfg.IsOutboundBlackholeEnabled() {
+		// Otherwise, traffic not filtered by allow rules is routed to the blackhole cluster when enabled
+		blackholeFilterChain, err := buildBlackholeFilterChain()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting filter chain for the outbound blackhole")
+			return nil, err
+		}
+		listener.DefaultFilterChain = blackholeFilterChain
 	}
 
 	if len(listener.FilterChains) == 0 && listener.DefaultFilterChain == nil {
@@ -60,6 +157,34 @@ func (lb *listenerBuilder) newOutboundListener() (*xds_listener.Listener, error)
 	return listener, nil
 }
 
+// getListenerSocketOptions returns the socket options configured for the sockets of the inbound and outbound listeners,
+// or nil if none are configured
+func getListenerSocketOptions(cfg configurator.Configurator) []*xds_core.SocketOption {
+	var socketOptions []*xds_core.SocketOption
+
+	if dscp := cfg.GetListenerDSCP(); dscp > 0 {
+		socketOptions = append(socketOptions, &xds_core.SocketOption{
+			Description: "DSCP",
+			Level:       ipprotoIP,
+			Name:        ipTOS,
+			Value:       &xds_core.SocketOption_IntValue{IntValue: int64(dscp << dscpTOSShift)},
+			State:       xds_core.SocketOption_STATE_PREBIND,
+		})
+	}
+
+	if cfg.IsListenerReusePortEnabled() {
+		socketOptions = append(socketOptions, &xds_core.SocketOption{
+			Description: "SO_REUSEPORT",
+			Level:       solSocket,
+			Name:        soReusePort,
+			Value:       &xds_core.SocketOption_IntValue{IntValue: 1},
+			State:       xds_core.SocketOption_STATE_PREBIND,
+		})
+	}
+
+	return socketOptions
+}
+
 func newInboundListener() *xds_listener.Listener {
 	return &xds_listener.Listener{
 		Name:             inboundListenerName,
@@ -80,6 +205,44 @@ func newInboundListener() *xds_listener.Listener {
 	}
 }
 
+// newInboundQUICListener returns an experimental UDP listener accepting inbound HTTP/3 (QUIC) traffic.
+// It listens on the same port number as the TCP inbound listener, which is unaffected by this listener.
+func newInboundQUICListener() (*xds_listener.Listener, error) {
+	marshalledQUICOptions, err := ptypes.MarshalAny(&xds_listener.QuicProtocolOptions{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling QuicProtocolOptions object")
+		return nil, err
+	}
+
+	return &xds_listener.Listener{
+		Name:             inboundQUICListenerName,
+		Address:          getUDPAddress(constants.WildcardIPAddr, constants.EnvoyInboundListenerPort),
+		TrafficDirection: xds_core.TrafficDirection_INBOUND,
+		FilterChains:     []*xds_listener.FilterChain{},
+		UdpListenerConfig: &xds_listener.UdpListenerConfig{
+			UdpListenerName: quicListenerName,
+			ConfigType: &xds_listener.UdpListenerConfig_TypedConfig{
+				TypedConfig: marshalledQUICOptions,
+			},
+		},
+	}, nil
+}
+
+// getUDPAddress returns an Envoy UDP socket address for the given address and port
+func getUDPAddress(address string, port uint32) *xds_core.Address {
+	return &xds_core.Address{
+		Address: &xds_core.Address_SocketAddress{
+			SocketAddress: &xds_core.SocketAddress{
+				Protocol: xds_core.SocketAddress_UDP,
+				Address:  address,
+				PortSpecifier: &xds_core.SocketAddress_PortValue{
+					PortValue: port,
+				},
+			},
+		},
+	}
+}
+
 func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_listener.Listener, error) {
 	marshalledConnManager, err := ptypes.MarshalAny(connManager)
 	if err != nil {
@@ -106,10 +269,11 @@ func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_l
 	}, nil
 }
 
-func buildEgressFilterChain() (*xds_listener.FilterChain, error) {
+func buildEgressFilterChain(tcpAccessLogFormat string) (*xds_listener.FilterChain, error) {
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       envoy.OutboundPassthroughCluster,
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.OutboundPassthroughCluster},
+		AccessLog:        envoy.GetTCPAccessLog(tcpAccessLogFormat),
 	}
 	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
 	if err != nil {
@@ -127,3 +291,368 @@ func buildEgressFilterChain() (*xds_listener.FilterChain, error) {
 		},
 	}, nil
 }
+
+// buildOutboundOriginalDstFilterChain returns a filter chain forwarding all connections to the original destination
+// restored by the OriginalDestination listener filter, using the ORIGINAL_DST cluster of the original_dst mode
+func buildOutboundOriginalDstFilterChain(tcpAccessLogFormat string) (*xds_listener.FilterChain, error) {
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       envoy.OutboundOriginalDstCluster,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.OutboundOriginalDstCluster},
+		AccessLog:        envoy.GetTCPAccessLog(tcpAccessLogFormat),
+	}
+	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling TcpProxy object for the outbound original destination filter chain")
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: outboundOriginalDstFilterChainName,
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+	}, nil
+}
+
+// buildBlackholeFilterChain returns a filter chain routing all HTTP requests to the blackhole cluster, which responds with a 503
+func buildBlackholeFilterChain() (*xds_listener.FilterChain, error) {
+	connManager := &xds_hcm.HttpConnectionManager{
+		StatPrefix: envoy.OutboundBlackholeCluster,
+		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
+		HttpFilters: []*xds_hcm.HttpFilter{{
+			Name: wellknown.Router,
+		}},
+		RouteSpecifier: &xds_hcm.HttpConnectionManager_RouteConfig{
+			RouteConfig: &xds_route.RouteConfiguration{
+				VirtualHosts: []*xds_route.VirtualHost{{
+					Name:    envoy.OutboundBlackholeCluster,
+					Domains: []string{"*"},
+					Routes: []*xds_route.Route{{
+						Match: &xds_route.RouteMatch{
+							PathSpecifier: &xds_route.RouteMatch_Prefix{
+								Prefix: "/",
+							},
+						},
+						Action: &xds_route.Route_Route{
+							Route: &xds_route.RouteAction{
+								ClusterSpecifier: &xds_route.RouteAction_Cluster{
+									Cluster: envoy.OutboundBlackholeCluster,
+								},
+							},
+						},
+					}},
+				}},
+			},
+		},
+		AccessLog: envoy.GetAccessLog(),
+	}
+	marshalledConnManager, err := ptypes.MarshalAny(connManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object for the outbound blackhole filter chain")
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: outboundBlackholeFilterChainName,
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.HTTPConnectionManager,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledConnManager},
+			},
+		},
+	}, nil
+}
+
+// getFilterChainDestinationPorts returns the sorted destination ports matched by the given filter chains
+func getFilterChainDestinationPorts(filterChains []*xds_listener.FilterChain) []uint32 {
+	seenPorts := make(map[uint32]bool)
+	var ports []uint32
+	for _, filterChain := range filterChains {
+		destinationPort := filterChain.GetFilterChainMatch().GetDestinationPort()
+		if destinationPort == nil || seenPorts[destinationPort.GetValue()] {
+			continue
+		}
+		seenPorts[destinationPort.GetValue()] = true
+		ports = append(ports, destinationPort.GetValue())
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports
+}
+
+// buildOutboundPassthroughFilterChains returns the filter chains passing the plaintext HTTP requests to unknown hosts
+// through: one matching any port, and one per given port of the mesh services, as Envoy only considers the filter
+// chains matching the port of a connection when some do
+func buildOutboundPassthroughFilterChains(meshPorts []uint32) ([]*xds_listener.FilterChain, error) {
+	filterChain, err := buildOutboundPassthroughFilterChain()
+	if err != nil {
+		return nil, err
+	}
+	filterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+		ApplicationProtocols: plaintextHTTPApplicationProtocols,
+	}
+	filterChains := []*xds_listener.FilterChain{filterChain}
+
+	for _, port := range meshPorts {
+		portFilterChain, err := buildOutboundPassthroughFilterChain()
+		if err != nil {
+			return nil, err
+		}
+		portFilterChain.Name = fmt.Sprintf("%s:%d", outboundPassthroughFilterChainName, port)
+		portFilterChain.FilterChainMatch = &xds_listener.FilterChainMatch{
+			DestinationPort:      &wrapperspb.UInt32Value{Value: port},
+			ApplicationProtocols: plaintextHTTPApplicationProtocols,
+		}
+		filterChains = append(filterChains, portFilterChain)
+	}
+	return filterChains, nil
+}
+
+// buildOutboundPassthroughTCPFilterChain returns the filter chain forwarding the connections to unknown hosts other
+// than plaintext HTTP requests, such as TLS and raw TCP connections, to the outbound passthrough cluster
+func buildOutboundPassthroughTCPFilterChain(tcpAccessLogFormat string) (*xds_listener.FilterChain, error) {
+	marshalledTCPProxy, err := ptypes.MarshalAny(&xds_tcp_proxy.TcpProxy{
+		StatPrefix:       outboundPassthroughTCPStatPrefix,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.OutboundPassthroughCluster},
+		AccessLog:        envoy.GetTCPAccessLog(tcpAccessLogFormat),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling TcpProxy object for the outbound passthrough TCP filter chain")
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: outboundPassthroughTCPFilterChainName,
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+	}, nil
+}
+
+// buildOutboundPassthroughFilterChain returns a filter chain routing all HTTP requests to the outbound passthrough
+// cluster, which forwards them to their original destination. The requests are counted by a virtual cluster of their
+// own to audit unexpected egress.
+func buildOutboundPassthroughFilterChain() (*xds_listener.FilterChain, error) {
+	connManager := &xds_hcm.HttpConnectionManager{
+		StatPrefix: outboundPassthroughStatPrefix,
+		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
+		HttpFilters: []*xds_hcm.HttpFilter{{
+			Name: wellknown.Router,
+		}},
+		RouteSpecifier: &xds_hcm.HttpConnectionManager_RouteConfig{
+			RouteConfig: &xds_route.RouteConfiguration{
+				VirtualHosts: []*xds_route.VirtualHost{{
+					Name:    outboundPassthroughStatPrefix,
+					Domains: []string{"*"},
+					Routes: []*xds_route.Route{{
+						Match: &xds_route.RouteMatch{
+							PathSpecifier: &xds_route.RouteMatch_Prefix{
+								Prefix: "/",
+							},
+						},
+						Action: &xds_route.Route_Route{
+							Route: &xds_route.RouteAction{
+								ClusterSpecifier: &xds_route.RouteAction_Cluster{
+									Cluster: envoy.OutboundPassthroughCluster,
+								},
+							},
+						},
+					}},
+					VirtualClusters: []*xds_route.VirtualCluster{{
+						Name: outboundPassthroughStatPrefix,
+						Headers: []*xds_route.HeaderMatcher{{
+							Name:                 ":path",
+							HeaderMatchSpecifier: &xds_route.HeaderMatcher_PrefixMatch{PrefixMatch: "/"},
+						}},
+					}},
+				}},
+			},
+		},
+		AccessLog: envoy.GetAccessLog(),
+	}
+	marshalledConnManager, err := ptypes.MarshalAny(connManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object for the outbound passthrough filter chain")
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: outboundPassthroughFilterChainName,
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.HTTPConnectionManager,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledConnManager},
+			},
+		},
+	}, nil
+}
+
+// buildInboundUnmatchedFilterChain returns the default filter chain of the inbound listener, which handles inbound
+// connections not matching any filter chain, such as connections presenting an unknown SNI. Such connections are
+// passed through to the local application if passthrough is enabled, and rejected by a deny-all RBAC filter otherwise.
+// Rejected connections are counted by the RBAC filter's stats and are logged by the TCP access log.
+func buildInboundUnmatchedFilterChain(passthrough bool, tcpAccessLogFormat string) (*xds_listener.FilterChain, error) {
+	var filters []*xds_listener.Filter
+
+	if !passthrough {
+		denyAllFilter, err := buildDenyAllRBACFilter(inboundUnmatchedSNIStatPrefix)
+		if err != nil {
+			log.Error().Err(err).Msg("Error building RBAC filter for the inbound unmatched filter chain")
+			return nil, err
+		}
+		filters = append(filters, denyAllFilter)
+	}
+
+	marshalledTCPProxy, err := ptypes.MarshalAny(&xds_tcp_proxy.TcpProxy{
+		StatPrefix:       inboundUnmatchedSNIStatPrefix,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.InboundPassthroughCluster},
+		AccessLog:        envoy.GetTCPAccessLog(tcpAccessLogFormat),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling TcpProxy object for the inbound unmatched filter chain")
+		return nil, err
+	}
+	filters = append(filters, &xds_listener.Filter{
+		Name:       wellknown.TCPProxy,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+	})
+
+	return &xds_listener.FilterChain{
+		Name:    inboundUnmatchedFilterChainName,
+		Filters: filters,
+	}, nil
+}
+
+// buildDenyAllRBACFilter returns a network RBAC filter denying all connections, counted by the filter's
+// <statPrefix>.rbac.denied stat
+func buildDenyAllRBACFilter(statPrefix string) (*xds_listener.Filter, error) {
+	// An RBAC policy allowing only principals matching one of its policies denies all connections without policies
+	marshalledDenyAll, err := ptypes.MarshalAny(&xds_network_rbac.RBAC{
+		StatPrefix: statPrefix,
+		Rules: &xds_rbac.RBAC{
+			Action: xds_rbac.RBAC_ALLOW,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &xds_listener.Filter{
+		Name:       wellknown.RoleBasedAccessControl,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledDenyAll},
+	}, nil
+}
+
+// getInboundMTLSRequiredFilterChains returns, for each port of the given inbound filter chains only accepting TLS
+// connections, a filter chain rejecting the plaintext connections to the port, such as connections from clients
+// without a sidecar. Without it such connections fall through to the default filter chain and are indistinguishable
+// from connections presenting an unknown SNI. Rejected connections are counted by the
+// inbound-mtls-required-filter-chain:<port>.rbac.denied stat and are logged by the TCP access log. Ports already
+// accepting plaintext connections from any source, such as ports serving a plaintext ingress, are skipped. Ports
+// accepting plaintext connections from given sources only, such as the node probing them, keep rejecting the others.
+func getInboundMTLSRequiredFilterChains(filterChains []*xds_listener.FilterChain, tcpAccessLogFormat string) []*xds_listener.FilterChain {
+	var tlsPorts []uint32
+	seenTLSPorts := make(map[uint32]bool)
+	plaintextPorts := make(map[uint32]bool)
+	for _, filterChain := range filterChains {
+		match := filterChain.GetFilterChainMatch()
+		if match.GetDestinationPort() == nil {
+			continue
+		}
+		port := match.GetDestinationPort().GetValue()
+		switch {
+		case match.GetTransportProtocol() == envoy.TransportProtocolTLS:
+			if !seenTLSPorts[port] {
+				seenTLSPorts[port] = true
+				tlsPorts = append(tlsPorts, port)
+			}
+		case len(match.GetSourcePrefixRanges()) == 0:
+			// Plaintext connections from any source are already handled on this port
+			plaintextPorts[port] = true
+		}
+	}
+
+	var mtlsRequiredFilterChains []*xds_listener.FilterChain
+	for _, port := range tlsPorts {
+		if plaintextPorts[port] {
+			continue
+		}
+		filterChain, err := buildInboundMTLSRequiredFilterChain(port, tcpAccessLogFormat)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building inbound mTLS required filter chain for port %d", port)
+			continue
+		}
+		mtlsRequiredFilterChains = append(mtlsRequiredFilterChains, filterChain)
+	}
+	return mtlsRequiredFilterChains
+}
+
+// buildInboundMTLSRequiredFilterChain returns a filter chain rejecting the plaintext connections to the given port
+func buildInboundMTLSRequiredFilterChain(port uint32, tcpAccessLogFormat string) (*xds_listener.FilterChain, error) {
+	filterChainName := fmt.Sprintf("%s:%d", inboundMTLSRequiredFilterChainPrefix, port)
+
+	denyAllFilter, err := buildDenyAllRBACFilter(filterChainName)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building RBAC filter for the inbound mTLS required filter chain on port %d", port)
+		return nil, err
+	}
+
+	// The TcpProxy is never reached by the rejected connections, but logs them and terminates the filter chain
+	marshalledTCPProxy, err := ptypes.MarshalAny(&xds_tcp_proxy.TcpProxy{
+		StatPrefix:       filterChainName,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.InboundPassthroughCluster},
+		AccessLog:        envoy.GetTCPAccessLog(tcpAccessLogFormat),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for the inbound mTLS required filter chain on port %d", port)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: filterChainName,
+		Filters: []*xds_listener.Filter{
+			denyAllFilter,
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			DestinationPort: &wrapperspb.UInt32Value{
+				Value: port,
+			},
+			TransportProtocol: envoy.TransportProtocolRawBuffer,
+		},
+	}, nil
+}
+
+// getConnectionBalanceConfig returns the config balancing the connections accepted by the inbound listener exactly
+// across the proxy's worker threads, or nil to let the kernel balance them when exact balance is not enabled
+func getConnectionBalanceConfig(cfg configurator.Configurator) *xds_listener.Listener_ConnectionBalanceConfig {
+	if !cfg.IsListenerExactBalanceEnabled() {
+		return nil
+	}
+
+	return &xds_listener.Listener_ConnectionBalanceConfig{
+		BalanceType: &xds_listener.Listener_ConnectionBalanceConfig_ExactBalance_{
+			ExactBalance: &xds_listener.Listener_ConnectionBalanceConfig_ExactBalance{},
+		},
+	}
+}
+
+// getPerConnectionBufferLimitBytes returns the soft limit on the buffers of the connections of the listeners of the
+// proxies of the given service, set by the service or by the mesh-wide default, or nil for the Envoy default to apply
+func getPerConnectionBufferLimitBytes(meshCatalog catalog.MeshCataloger, cfg configurator.Configurator, svc service.MeshService) *wrappers.UInt32Value {
+	limit := meshCatalog.GetPerConnectionBufferLimitBytesForService(svc)
+	if limit == 0 {
+		limit = cfg.GetPerConnectionBufferLimitBytes()
+	}
+	if limit == 0 {
+		return nil
+	}
+	return &wrappers.UInt32Value{Value: limit}
+}
@@ -1,20 +1,33 @@
 package lds
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_tracing "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	xds_buffer "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 // Tests TestGetFilterForService checks that a proper filter type is properly returned
@@ -31,6 +44,8 @@ func TestGetFilterForService(t *testing.T) {
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false)
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(true)
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-endpoint")
+	mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatB3)
+	mockConfigurator.EXPECT().GetTracingCustomTags().Return(nil)
 
 	// Check we get HTTP connection manager filter without Permissive mode
 	filter, err := lb.getOutboundHTTPFilter()
@@ -42,6 +57,8 @@ func TestGetFilterForService(t *testing.T) {
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true)
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(true)
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-endpoint")
+	mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatB3)
+	mockConfigurator.EXPECT().GetTracingCustomTags().Return(nil)
 
 	filter, err = lb.getOutboundHTTPFilter()
 	assert.NoError(err)
@@ -96,12 +113,59 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).Times(1)
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).Times(1)
 			mockConfigurator.EXPECT().GetTracingEndpoint().Return(constants.DefaultTracingEndpoint).Times(1)
+			mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatB3).Times(1)
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().GetTracingCustomTags().Return(nil).Times(1)
 
 			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
 
 			Expect(connManager.Tracing.Verbose).To(Equal(true))
 			Expect(connManager.Tracing.Provider.Name).To(Equal("envoy.tracers.zipkin"))
+			Expect(connManager.Tracing.CustomTags).To(BeNil())
+		})
+
+		It("Propagates the trace context in the W3C traceparent header when configured", func() {
+			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).Times(1)
+			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).Times(1)
+			mockConfigurator.EXPECT().GetTracingEndpoint().Return(constants.DefaultTracingEndpoint).Times(1)
+			mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatW3C).Times(1)
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().GetTracingCustomTags().Return(nil).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+			Expect(connManager.Tracing.Provider.Name).To(Equal("envoy.tracers.opencensus"))
+			openCensusConf := &xds_tracing.OpenCensusConfig{}
+			Expect(ptypes.UnmarshalAny(connManager.Tracing.Provider.GetTypedConfig(), openCensusConf)).To(Succeed())
+			Expect(openCensusConf.OutgoingTraceContext).To(Equal([]xds_tracing.OpenCensusConfig_TraceContext{xds_tracing.OpenCensusConfig_TRACE_CONTEXT}))
+			Expect(openCensusConf.IncomingTraceContext).To(ContainElement(xds_tracing.OpenCensusConfig_TRACE_CONTEXT))
+			Expect(openCensusConf.ZipkinExporterEnabled).To(BeTrue())
+			Expect(openCensusConf.ZipkinUrl).To(Equal(fmt.Sprintf("http://%s:%d%s", constants.DefaultTracingHost, constants.DefaultTracingPort, constants.DefaultTracingEndpoint)))
+		})
+
+		It("Returns custom tracing tags populated from request headers", func() {
+			mockConfigurator.EXPECT().GetTracingEndpoint().Return(constants.DefaultTracingEndpoint).Times(1)
+			mockConfigurator.EXPECT().GetTracingPropagationFormat().Return(constants.TracingPropagationFormatB3).Times(1)
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().GetTracingCustomTags().Return(map[string]string{
+				"tenant":     "x-tenant-id",
+				"request-id": "x-request-id",
+			}).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+			// Ensure the custom tags survive marshalling of the HTTP connection manager
+			marshalled, err := ptypes.MarshalAny(connManager)
+			Expect(err).ToNot(HaveOccurred())
+			unmarshalled := &xds_hcm.HttpConnectionManager{}
+			Expect(ptypes.UnmarshalAny(marshalled, unmarshalled)).To(Succeed())
+
+			customTags := unmarshalled.Tracing.CustomTags
+			Expect(customTags).To(HaveLen(2))
+			Expect(customTags[0].Tag).To(Equal("request-id"))
+			Expect(customTags[0].GetRequestHeader().Name).To(Equal("x-request-id"))
+			Expect(customTags[1].Tag).To(Equal("tenant"))
+			Expect(customTags[1].GetRequestHeader().Name).To(Equal("x-tenant-id"))
 		})
 
 		It("Returns proper Zipkin config given when tracing is disabled", func() {
@@ -113,4 +177,274 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			Expect(connManager.Tracing).To(Equal(nilHcmTrace))
 		})
 	})
+
+	Context("Test applying the request size limit to the HTTP connection manager", func() {
+		It("Does not limit the request size by default", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+			Expect(applyMaxRequestBytes(connManager, mockConfigurator, false)).To(Succeed())
+
+			Expect(connManager.HttpFilters).To(HaveLen(1))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(wellknown.Router))
+		})
+
+		It("Rejects requests exceeding the mesh wide limit using a buffer filter preceding the router", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(1024)).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+			Expect(applyMaxRequestBytes(connManager, mockConfigurator, false)).To(Succeed())
+
+			Expect(connManager.HttpFilters).To(HaveLen(2))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(wellknown.Buffer))
+			Expect(connManager.HttpFilters[1].Name).To(Equal(wellknown.Router))
+
+			buffer := &xds_buffer.Buffer{}
+			Expect(ptypes.UnmarshalAny(connManager.HttpFilters[0].GetTypedConfig(), buffer)).To(Succeed())
+			Expect(buffer.MaxRequestBytes.Value).To(Equal(uint32(1024)))
+		})
+
+		It("Programs a buffer filter without a mesh wide limit when routes override the limit", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+			Expect(applyMaxRequestBytes(connManager, mockConfigurator, true)).To(Succeed())
+
+			Expect(connManager.HttpFilters).To(HaveLen(2))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(wellknown.Buffer))
+
+			buffer := &xds_buffer.Buffer{}
+			Expect(ptypes.UnmarshalAny(connManager.HttpFilters[0].GetTypedConfig(), buffer)).To(Succeed())
+			Expect(buffer.MaxRequestBytes.Value).To(Equal(uint32(math.MaxUint32)))
+		})
+	})
+
+	Context("Test applying the access log sampling to the HTTP connection manager", func() {
+		It("Logs all requests by default", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+			applyAccessLogSampling(connManager, 100)
+
+			Expect(connManager.AccessLog).To(HaveLen(1))
+			Expect(connManager.AccessLog[0].Filter).To(BeNil())
+		})
+
+		It("Logs the configured percentage of the requests using a runtime filter", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+			applyAccessLogSampling(connManager, 0.1)
+
+			Expect(connManager.AccessLog).To(HaveLen(1))
+			runtimeFilter := connManager.AccessLog[0].Filter.GetRuntimeFilter()
+			Expect(runtimeFilter).ToNot(BeNil())
+			Expect(runtimeFilter.RuntimeKey).To(Equal(accessLogSamplingRuntimeKey))
+			Expect(runtimeFilter.PercentSampled).To(Equal(&xds_type.FractionalPercent{
+				Numerator:   1000,
+				Denominator: xds_type.FractionalPercent_MILLION,
+			}))
+			Expect(connManager.Validate()).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Test buildBlackholeFilterChain", func() {
+	It("Routes all HTTP requests to the outbound blackhole cluster", func() {
+		filterChain, err := buildBlackholeFilterChain()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filterChain.Name).To(Equal(outboundBlackholeFilterChainName))
+		Expect(filterChain.Filters).To(HaveLen(1))
+		Expect(filterChain.Filters[0].Name).To(Equal(wellknown.HTTPConnectionManager))
+
+		connManager := &xds_hcm.HttpConnectionManager{}
+		Expect(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), connManager)).To(Succeed())
+
+		virtualHosts := connManager.GetRouteConfig().VirtualHosts
+		Expect(virtualHosts).To(HaveLen(1))
+		Expect(virtualHosts[0].Domains).To(Equal([]string{"*"}))
+		Expect(virtualHosts[0].Routes).To(HaveLen(1))
+		Expect(virtualHosts[0].Routes[0].GetRoute().GetCluster()).To(Equal(envoy.OutboundBlackholeCluster))
+	})
+})
+
+var _ = Describe("Test buildInboundUnmatchedFilterChain", func() {
+	It("Rejects connections not matching any filter chain using a deny-all RBAC filter", func() {
+		filterChain, err := buildInboundUnmatchedFilterChain(false, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filterChain.Name).To(Equal(inboundUnmatchedFilterChainName))
+		Expect(filterChain.FilterChainMatch).To(BeNil())
+		Expect(filterChain.Filters).To(HaveLen(2))
+		Expect(filterChain.Filters[0].Name).To(Equal(wellknown.RoleBasedAccessControl))
+		Expect(filterChain.Filters[1].Name).To(Equal(wellknown.TCPProxy))
+
+		rbacFilter := &xds_network_rbac.RBAC{}
+		Expect(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), rbacFilter)).To(Succeed())
+		Expect(rbacFilter.StatPrefix).To(Equal(inboundUnmatchedSNIStatPrefix))
+		Expect(rbacFilter.Rules.Action).To(Equal(xds_rbac.RBAC_ALLOW))
+		Expect(rbacFilter.Rules.Policies).To(BeEmpty())
+	})
+
+	It("Passes connections not matching any filter chain through to the local application", func() {
+		filterChain, err := buildInboundUnmatchedFilterChain(true, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filterChain.Filters).To(HaveLen(1))
+		Expect(filterChain.Filters[0].Name).To(Equal(wellknown.TCPProxy))
+
+		tcpProxy := &xds_tcp_proxy.TcpProxy{}
+		Expect(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), tcpProxy)).To(Succeed())
+		Expect(tcpProxy.GetCluster()).To(Equal(envoy.InboundPassthroughCluster))
+	})
+})
+
+var _ = Describe("Test getInboundMTLSRequiredFilterChains", func() {
+	newFilterChain := func(port uint32, transportProtocol string, sourcePrefixRanges ...*xds_core.CidrRange) *xds_listener.FilterChain {
+		return &xds_listener.FilterChain{
+			FilterChainMatch: &xds_listener.FilterChainMatch{
+				DestinationPort:    &wrapperspb.UInt32Value{Value: port},
+				TransportProtocol:  transportProtocol,
+				SourcePrefixRanges: sourcePrefixRanges,
+			},
+		}
+	}
+
+	It("Rejects plaintext connections to the ports only accepting TLS connections using a deny-all RBAC filter", func() {
+		filterChains := getInboundMTLSRequiredFilterChains([]*xds_listener.FilterChain{
+			newFilterChain(80, envoy.TransportProtocolTLS),
+			newFilterChain(80, envoy.TransportProtocolRawBuffer, &xds_core.CidrRange{AddressPrefix: "10.0.0.0", PrefixLen: &wrapperspb.UInt32Value{Value: 8}}),
+			newFilterChain(90, envoy.TransportProtocolTLS),
+			newFilterChain(90, envoy.TransportProtocolTLS),
+		}, "")
+		Expect(filterChains).To(HaveLen(2))
+
+		filterChain := filterChains[0]
+		Expect(filterChain.Name).To(Equal("inbound-mtls-required-filter-chain:80"))
+		Expect(filterChain.FilterChainMatch.DestinationPort.GetValue()).To(Equal(uint32(80)))
+		Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolRawBuffer))
+		Expect(filterChain.FilterChainMatch.SourcePrefixRanges).To(BeEmpty())
+		Expect(filterChain.Filters).To(HaveLen(2))
+		Expect(filterChain.Filters[0].Name).To(Equal(wellknown.RoleBasedAccessControl))
+		Expect(filterChain.Filters[1].Name).To(Equal(wellknown.TCPProxy))
+
+		rbacFilter := &xds_network_rbac.RBAC{}
+		Expect(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), rbacFilter)).To(Succeed())
+		Expect(rbacFilter.StatPrefix).To(Equal("inbound-mtls-required-filter-chain:80"))
+		Expect(rbacFilter.Rules.Action).To(Equal(xds_rbac.RBAC_ALLOW))
+		Expect(rbacFilter.Rules.Policies).To(BeEmpty())
+
+		Expect(filterChains[1].Name).To(Equal("inbound-mtls-required-filter-chain:90"))
+	})
+
+	It("Skips the ports already accepting plaintext connections", func() {
+		filterChains := getInboundMTLSRequiredFilterChains([]*xds_listener.FilterChain{
+			newFilterChain(80, envoy.TransportProtocolTLS),
+			newFilterChain(80, envoy.TransportProtocolRawBuffer),
+			newFilterChain(90, envoy.TransportProtocolTLS),
+			newFilterChain(90, ""),
+			newFilterChain(100, envoy.TransportProtocolRawBuffer),
+		}, "")
+		Expect(filterChains).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Test getListenerSocketOptions", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+	})
+
+	It("Returns no socket options by default", func() {
+		mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).Times(1)
+		mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).Times(1)
+
+		Expect(getListenerSocketOptions(mockConfigurator)).To(BeNil())
+	})
+
+	It("Returns the DSCP and SO_REUSEPORT socket options when configured", func() {
+		mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(46)).Times(1)
+		mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(true).Times(1)
+
+		socketOptions := getListenerSocketOptions(mockConfigurator)
+		Expect(socketOptions).To(HaveLen(2))
+
+		// DSCP 46 (expedited forwarding) is set in the upper 6 bits of the TOS byte
+		Expect(socketOptions[0].Level).To(BeEquivalentTo(ipprotoIP))
+		Expect(socketOptions[0].Name).To(BeEquivalentTo(ipTOS))
+		Expect(socketOptions[0].GetIntValue()).To(BeEquivalentTo(184))
+		Expect(socketOptions[0].State).To(Equal(xds_core.SocketOption_STATE_PREBIND))
+
+		Expect(socketOptions[1].Level).To(BeEquivalentTo(solSocket))
+		Expect(socketOptions[1].Name).To(BeEquivalentTo(soReusePort))
+		Expect(socketOptions[1].GetIntValue()).To(BeEquivalentTo(1))
+		Expect(socketOptions[1].State).To(Equal(xds_core.SocketOption_STATE_PREBIND))
+	})
+})
+
+var _ = Describe("Test getConnectionBalanceConfig", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+	})
+
+	It("Returns no connection balance config by default", func() {
+		mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).Times(1)
+
+		Expect(getConnectionBalanceConfig(mockConfigurator)).To(BeNil())
+	})
+
+	It("Returns the exact connection balance config when enabled", func() {
+		mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(true).Times(1)
+
+		balanceConfig := getConnectionBalanceConfig(mockConfigurator)
+		Expect(balanceConfig).ToNot(BeNil())
+		Expect(balanceConfig.GetExactBalance()).ToNot(BeNil())
+	})
+})
+
+var _ = Describe("Test getPerConnectionBufferLimitBytes", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockCatalog      *catalog.MockMeshCataloger
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+	})
+
+	It("Returns no buffer limit when neither the service nor the mesh-wide default sets it", func() {
+		mockCatalog.EXPECT().GetPerConnectionBufferLimitBytesForService(tests.BookstoreV1Service).Return(uint32(0)).Times(1)
+		mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(0)).Times(1)
+
+		Expect(getPerConnectionBufferLimitBytes(mockCatalog, mockConfigurator, tests.BookstoreV1Service)).To(BeNil())
+	})
+
+	It("Returns the mesh-wide buffer limit when the service does not set it", func() {
+		mockCatalog.EXPECT().GetPerConnectionBufferLimitBytesForService(tests.BookstoreV1Service).Return(uint32(0)).Times(1)
+		mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(2097152)).Times(1)
+
+		Expect(getPerConnectionBufferLimitBytes(mockCatalog, mockConfigurator, tests.BookstoreV1Service).GetValue()).To(Equal(uint32(2097152)))
+	})
+
+	It("Returns the buffer limit set by the service over the mesh-wide buffer limit", func() {
+		mockCatalog.EXPECT().GetPerConnectionBufferLimitBytesForService(tests.BookstoreV1Service).Return(uint32(16777216)).Times(1)
+		mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(2097152)).AnyTimes()
+
+		Expect(getPerConnectionBufferLimitBytes(mockCatalog, mockConfigurator, tests.BookstoreV1Service).GetValue()).To(Equal(uint32(16777216)))
+	})
 })
@@ -5,19 +5,28 @@ import (
 
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 
 	"github.com/openservicemesh/osm/pkg/envoy/rbac"
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
-// buildRBACFilter builds an RBAC filter based on SMI TrafficTarget policies.
+// allowedSourceIPRangesPolicyName is the name of the inbound RBAC policy allowing the source IP ranges allowed by a service
+const allowedSourceIPRangesPolicyName = "allowed-source-ip-ranges"
+
+// meshTrustDomainPolicyName is the name of the inbound RBAC policy allowing the principals of the mesh trust domain
+const meshTrustDomainPolicyName = "mesh-trust-domain"
+
+// buildRBACFilter builds an RBAC filter based on SMI TrafficTarget policies and the source IP ranges allowed by the given service.
 // The returned RBAC filter has policies that gives downstream principals full access to the local service.
-func (lb *listenerBuilder) buildRBACFilter() (*xds_listener.Filter, error) {
-	networkRBACPolicy, err := lb.buildInboundRBACPolicies()
+func (lb *listenerBuilder) buildRBACFilter(proxyService service.MeshService) (*xds_listener.Filter, error) {
+	networkRBACPolicy, err := lb.buildInboundRBACPolicies(proxyService)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error building inbound RBAC policies for principal %q", lb.svcAccount)
 		return nil, err
@@ -37,8 +46,58 @@ func (lb *listenerBuilder) buildRBACFilter() (*xds_listener.Filter, error) {
 	return rbacFilter, nil
 }
 
-// buildInboundRBACPolicies builds the RBAC policies based on allowed principals
-func (lb *listenerBuilder) buildInboundRBACPolicies() (*xds_network_rbac.RBAC, error) {
+// buildTrustDomainRBACFilter builds an RBAC filter allowing the downstream principals of the mesh trust domain, regardless
+// of SMI TrafficTarget policies. Connections are authorized by the identity in the SAN of the verified client certificate
+// instead of the SNI requested by the client.
+func buildTrustDomainRBACFilter() (*xds_listener.Filter, error) {
+	policy := &rbac.Policy{
+		Principals: []rbac.RulesList{{
+			OrRules: []rbac.Rule{{Attribute: rbac.DownstreamAuthPrincipalTrustDomain, Value: identity.ClusterLocalTrustDomain}},
+		}},
+	}
+	trustDomainPolicy, err := policy.Generate()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building RBAC policy for trust domain %s", identity.ClusterLocalTrustDomain)
+		return nil, err
+	}
+
+	networkRBACPolicy := &xds_network_rbac.RBAC{
+		StatPrefix: "RBAC",
+		Rules: &xds_rbac.RBAC{
+			Action:   xds_rbac.RBAC_ALLOW,
+			Policies: map[string]*xds_rbac.Policy{meshTrustDomainPolicyName: trustDomainPolicy},
+		},
+	}
+	marshalledNetworkRBACPolicy, err := ptypes.MarshalAny(networkRBACPolicy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling RBAC policy: %v", networkRBACPolicy)
+		return nil, err
+	}
+
+	return &xds_listener.Filter{
+		Name:       wellknown.RoleBasedAccessControl,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledNetworkRBACPolicy},
+	}, nil
+}
+
+// getHTTPRBACFilter returns an HTTP RBAC filter without policies of its own. Requests are authorized by the
+// per route RBAC policies configured on the inbound routes, which restrict the sources allowed to access each route.
+func getHTTPRBACFilter() (*xds_hcm.HttpFilter, error) {
+	marshalledHTTPRBAC, err := ptypes.MarshalAny(&xds_http_rbac.RBAC{})
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling HTTP RBAC filter")
+		return nil, err
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name:       wellknown.HTTPRoleBasedAccessControl,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{TypedConfig: marshalledHTTPRBAC},
+	}, nil
+}
+
+// buildInboundRBACPolicies builds the RBAC policies based on allowed principals and the source IP ranges allowed by the given service.
+// The sources are allowed by their identity and source IP range, or by their source IP range alone when allowed to bypass mTLS.
+func (lb *listenerBuilder) buildInboundRBACPolicies(proxyService service.MeshService) (*xds_network_rbac.RBAC, error) {
 	proxyIdentity := identity.ServiceIdentity(lb.svcAccount.String())
 	trafficTargets, err := lb.meshCatalog.ListInboundTrafficTargetsWithRoutes(lb.svcAccount)
 	if err != nil {
@@ -46,16 +105,35 @@ func (lb *listenerBuilder) buildInboundRBACPolicies() (*xds_network_rbac.RBAC, e
 		return nil, err
 	}
 
+	// The source IP ranges allowed by the service restrict the sources allowed by their identity to the ranges, unless
+	// connections from the ranges bypass the mTLS requirement, in which case the ranges are allowed regardless of the
+	// identity of the sources
+	sourceIPRanges := lb.meshCatalog.GetAllowedSourceIPRangesForService(proxyService)
+	mtlsBypass := len(sourceIPRanges) > 0 && lb.cfg.IsSourceIPRangeMTLSBypassEnabled()
+	var principalSourceIPRanges []string
+	if !mtlsBypass {
+		principalSourceIPRanges = sourceIPRanges
+	}
+
 	rbacPolicies := make(map[string]*xds_rbac.Policy)
 	// Build an RBAC policies based on SMI TrafficTarget policies
 	for _, targetPolicy := range trafficTargets {
-		if policy, err := buildRBACPolicyFromTrafficTarget(targetPolicy); err != nil {
+		if policy, err := buildRBACPolicyFromTrafficTarget(targetPolicy, principalSourceIPRanges); err != nil {
 			log.Error().Err(err).Msgf("Error building RBAC policy for proxy identity %s from TrafficTarget %s", proxyIdentity, targetPolicy.Name)
 		} else {
 			rbacPolicies[targetPolicy.Name] = policy
 		}
 	}
 
+	// Build an RBAC policy allowing the source IP ranges allowed by the service, regardless of the identity of the sources
+	if mtlsBypass {
+		if policy, err := buildRBACPolicyFromSourceIPRanges(sourceIPRanges); err != nil {
+			log.Error().Err(err).Msgf("Error building RBAC policy for proxy identity %s from allowed source IP ranges %v", proxyIdentity, sourceIPRanges)
+		} else {
+			rbacPolicies[allowedSourceIPRangesPolicyName] = policy
+		}
+	}
+
 	log.Debug().Msgf("RBAC policy for proxy with identity %s: %+v", proxyIdentity, rbacPolicies)
 
 	// Create an inbound RBAC policy that denies a request by default, unless a policy explicitly allows it
@@ -70,19 +148,29 @@ func (lb *listenerBuilder) buildInboundRBACPolicies() (*xds_network_rbac.RBAC, e
 	return networkRBACPolicy, nil
 }
 
-// buildRBACPolicyFromTrafficTarget creates an XDS RBAC policy from the given traffic target policy
-func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetWithRoutes) (*xds_rbac.Policy, error) {
+// buildRBACPolicyFromTrafficTarget creates an XDS RBAC policy from the given traffic target policy. When source IP ranges
+// are given, the sources of the traffic target are only allowed to connect from the ranges.
+func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetWithRoutes, sourceIPRanges []string) (*xds_rbac.Policy, error) {
 	policy := &rbac.Policy{}
 
 	// Create the list of principals for this policy
 	var principalRuleList []rbac.RulesList
 	for _, downstreamPrincipal := range trafficTarget.Sources {
-		principalRule := rbac.RulesList{
-			OrRules: []rbac.Rule{
-				{Attribute: rbac.DownstreamAuthPrincipal, Value: downstreamPrincipal.String()},
-			},
+		principal := rbac.Rule{Attribute: rbac.DownstreamAuthPrincipal, Value: downstreamPrincipal.String()}
+		if len(sourceIPRanges) == 0 {
+			principalRuleList = append(principalRuleList, rbac.RulesList{OrRules: []rbac.Rule{principal}})
+			continue
+		}
+		// The principal must connect from one of the source IP ranges
+		for _, cidr := range sourceIPRanges {
+			principalRule := rbac.RulesList{
+				AndRules: []rbac.Rule{
+					principal,
+					{Attribute: rbac.DownstreamSourceIPRange, Value: cidr},
+				},
+			}
+			principalRuleList = append(principalRuleList, principalRule)
 		}
-		principalRuleList = append(principalRuleList, principalRule)
 	}
 	policy.Principals = principalRuleList
 
@@ -109,3 +197,16 @@ func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetW
 
 	return policy.Generate()
 }
+
+// buildRBACPolicyFromSourceIPRanges creates an XDS RBAC policy allowing the connections from the given source IP CIDR ranges
+func buildRBACPolicyFromSourceIPRanges(sourceIPRanges []string) (*xds_rbac.Policy, error) {
+	var sourceIPRangeRules []rbac.Rule
+	for _, cidr := range sourceIPRanges {
+		sourceIPRangeRules = append(sourceIPRangeRules, rbac.Rule{Attribute: rbac.DownstreamSourceIPRange, Value: cidr})
+	}
+
+	policy := &rbac.Policy{
+		Principals: []rbac.RulesList{{OrRules: sourceIPRangeRules}},
+	}
+	return policy.Generate()
+}
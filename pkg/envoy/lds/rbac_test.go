@@ -2,15 +2,19 @@ package lds
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	tassert "github.com/stretchr/testify/assert"
 
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy/rbac"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -140,7 +144,7 @@ func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			// Test the RBAC policies
-			policy, err := buildRBACPolicyFromTrafficTarget(tc.trafficTarget)
+			policy, err := buildRBACPolicyFromTrafficTarget(tc.trafficTarget, nil)
 
 			assert.Equal(tc.expectErr, err != nil)
 			assert.Equal(tc.expectedPolicy, policy)
@@ -154,16 +158,21 @@ func TestBuildInboundRBACPolicies(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 	proxySvcAccount := service.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}
+	proxyService := service.MeshService{Name: "svc-1", Namespace: "ns-1"}
 
 	lb := &listenerBuilder{
 		meshCatalog: mockCatalog,
+		cfg:         mockConfigurator,
 		svcAccount:  proxySvcAccount,
 	}
 
 	testCases := []struct {
 		name           string
 		trafficTargets []trafficpolicy.TrafficTargetWithRoutes
+		sourceIPRanges []string
+		mtlsBypass     bool
 
 		expectedPolicyKeys []string
 		expectErr          bool
@@ -212,15 +221,57 @@ func TestBuildInboundRBACPolicies(t *testing.T) {
 			expectedPolicyKeys: []string{"ns-1/test-1", "ns-1/test-2"},
 			expectErr:          false, // no error
 		},
+
+		{
+			// Test 3
+			name: "traffic target and allowed source IP ranges bypassing mTLS",
+			trafficTargets: []trafficpolicy.TrafficTargetWithRoutes{
+				{
+					Name:        "ns-1/test-1",
+					Destination: identity.ServiceIdentity("sa-1.ns-1.cluster.local"),
+					Sources: []identity.ServiceIdentity{
+						identity.ServiceIdentity("sa-2.ns-2.cluster.local"),
+					},
+				},
+			},
+			sourceIPRanges: []string{"10.0.0.0/8", "192.168.1.10/32"},
+			mtlsBypass:     true,
+
+			expectedPolicyKeys: []string{"ns-1/test-1", allowedSourceIPRangesPolicyName},
+			expectErr:          false, // no error
+		},
+
+		{
+			// Test 4
+			name: "traffic target and allowed source IP ranges restricting its sources",
+			trafficTargets: []trafficpolicy.TrafficTargetWithRoutes{
+				{
+					Name:        "ns-1/test-1",
+					Destination: identity.ServiceIdentity("sa-1.ns-1.cluster.local"),
+					Sources: []identity.ServiceIdentity{
+						identity.ServiceIdentity("sa-2.ns-2.cluster.local"),
+					},
+				},
+			},
+			sourceIPRanges: []string{"10.0.0.0/8", "192.168.1.10/32"},
+			mtlsBypass:     false,
+
+			expectedPolicyKeys: []string{"ns-1/test-1"},
+			expectErr:          false, // no error
+		},
 	}
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			// Mock catalog calls
 			mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(proxySvcAccount).Return(tc.trafficTargets, nil).Times(1)
+			mockCatalog.EXPECT().GetAllowedSourceIPRangesForService(proxyService).Return(tc.sourceIPRanges).Times(1)
+			if len(tc.sourceIPRanges) > 0 {
+				mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(tc.mtlsBypass).Times(1)
+			}
 
 			// Test the RBAC policies
-			policy, err := lb.buildInboundRBACPolicies()
+			policy, err := lb.buildInboundRBACPolicies(proxyService)
 
 			assert.Equal(tc.expectErr, err != nil)
 			assert.Equal(xds_rbac.RBAC_ALLOW, policy.Rules.Action)
@@ -242,6 +293,7 @@ func TestBuildRBACFilter(t *testing.T) {
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 	proxySvcAccount := service.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}
+	proxyService := service.MeshService{Name: "svc-1", Namespace: "ns-1"}
 
 	lb := &listenerBuilder{
 		meshCatalog: mockCatalog,
@@ -301,11 +353,86 @@ func TestBuildRBACFilter(t *testing.T) {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			// Mock catalog calls
 			mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(proxySvcAccount).Return(tc.trafficTargets, nil).Times(1)
+			mockCatalog.EXPECT().GetAllowedSourceIPRangesForService(proxyService).Return(nil).Times(1)
 
-			rbacFilter, err := lb.buildRBACFilter()
+			rbacFilter, err := lb.buildRBACFilter(proxyService)
 			assert.Equal(err != nil, tc.expectErr)
 
 			assert.Equal(rbacFilter.Name, wellknown.RoleBasedAccessControl)
 		})
 	}
 }
+
+func TestBuildRBACPolicyFromSourceIPRanges(t *testing.T) {
+	assert := tassert.New(t)
+
+	policy, err := buildRBACPolicyFromSourceIPRanges([]string{"10.0.0.0/8", "192.168.1.10/32"})
+	assert.Nil(err)
+	assert.Len(policy.Permissions, 1)
+	assert.True(policy.Permissions[0].GetAny())
+	assert.Len(policy.Principals, 1)
+
+	sourceIPPrincipals := policy.Principals[0].GetOrIds().GetIds()
+	assert.Len(sourceIPPrincipals, 2)
+	assert.Equal("10.0.0.0", sourceIPPrincipals[0].GetDirectRemoteIp().GetAddressPrefix())
+	assert.Equal(uint32(8), sourceIPPrincipals[0].GetDirectRemoteIp().GetPrefixLen().GetValue())
+	assert.Equal("192.168.1.10", sourceIPPrincipals[1].GetDirectRemoteIp().GetAddressPrefix())
+	assert.Equal(uint32(32), sourceIPPrincipals[1].GetDirectRemoteIp().GetPrefixLen().GetValue())
+
+	_, err = buildRBACPolicyFromSourceIPRanges([]string{"not-a-cidr"})
+	assert.NotNil(err)
+}
+
+func TestBuildRBACPolicyFromTrafficTargetWithSourceIPRanges(t *testing.T) {
+	assert := tassert.New(t)
+
+	trafficTarget := trafficpolicy.TrafficTargetWithRoutes{
+		Name:        "ns-1/test-1",
+		Destination: identity.ServiceIdentity("sa-1.ns-1.cluster.local"),
+		Sources: []identity.ServiceIdentity{
+			identity.ServiceIdentity("sa-2.ns-2.cluster.local"),
+		},
+	}
+
+	policy, err := buildRBACPolicyFromTrafficTarget(trafficTarget, []string{"10.0.0.0/8", "192.168.1.10/32"})
+	assert.Nil(err)
+
+	// The source must have an allowed identity and connect from one of the source IP ranges
+	assert.Len(policy.Principals, 2)
+	for i, cidr := range []string{"10.0.0.0", "192.168.1.10"} {
+		andIds := policy.Principals[i].GetAndIds().GetIds()
+		assert.Len(andIds, 2)
+		assert.Equal(rbac.GetAuthenticatedPrincipal("sa-2.ns-2.cluster.local"), andIds[0])
+		assert.Equal(cidr, andIds[1].GetDirectRemoteIp().GetAddressPrefix())
+	}
+}
+
+func TestBuildTrustDomainRBACFilter(t *testing.T) {
+	assert := tassert.New(t)
+
+	filter, err := buildTrustDomainRBACFilter()
+	assert.Nil(err)
+	assert.Equal(wellknown.RoleBasedAccessControl, filter.Name)
+
+	networkRBAC := &xds_network_rbac.RBAC{}
+	assert.Nil(ptypes.UnmarshalAny(filter.GetTypedConfig(), networkRBAC))
+	assert.Equal(xds_rbac.RBAC_ALLOW, networkRBAC.Rules.Action)
+	assert.Len(networkRBAC.Rules.Policies, 1)
+
+	policy := networkRBAC.Rules.Policies[meshTrustDomainPolicyName]
+	assert.True(policy.Permissions[0].GetAny())
+	principalName := policy.Principals[0].GetOrIds().GetIds()[0].GetAuthenticated().GetPrincipalName()
+
+	// The identity in the SAN of the client certificate is matched, whatever the SNI requested by the client
+	testCases := []struct {
+		san     string
+		allowed bool
+	}{
+		{san: "bookbuyer.default.cluster.local", allowed: true},
+		{san: "osm-webhook-osm.osm-system.svc", allowed: false},
+		{san: "cluster.local", allowed: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(tc.allowed, strings.HasSuffix(tc.san, principalName.GetSuffix()), tc.san)
+	}
+}
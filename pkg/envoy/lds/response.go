@@ -15,9 +15,25 @@ import (
 // NewResponse creates a new Listener Discovery Response.
 // The response build 3 Listeners:
 // 1. Inbound listener to handle incoming traffic
-// 2. Outbound listener to handle outgoing traffic
+// 2. Outbound listener to handle outgoing traffic, only with its default filter chain when outbound is disabled for
+// the proxy's service
 // 3. Prometheus listener for metrics
+// An experimental inbound HTTP/3 (QUIC) listener is additionally built when enabled.
+// Fail-static listeners rejecting all requests are built instead while the mesh config of the proxy cannot be computed,
+// when enabled.
 func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
+	// A proxy connecting before its pod is observed rejects requests explicitly instead of being programmed with no
+	// listener, which would silently drop its traffic
+	if cfg.IsFailStaticEnabled() && !meshCatalog.IsConfigReadyForProxy(proxy.GetCertificateCommonName()) {
+		log.Warn().Msgf("Mesh config is not ready, programming fail-static listeners for Envoy with certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		svcAccount, err := catalog.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error retrieving ServiceAccount for Envoy with certificate with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+			return nil, err
+		}
+		return newFailStaticResponse(svcAccount, cfg)
+	}
+
 	svcList, err := meshCatalog.GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
@@ -37,9 +53,15 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 	}
 
 	lb := newListenerBuilder(meshCatalog, svcAccount, cfg)
+	perConnectionBufferLimitBytes := getPerConnectionBufferLimitBytes(meshCatalog, cfg, proxyServiceName)
 
 	// --- OUTBOUND -------------------
-	outboundListener, err := lb.newOutboundListener()
+	// The mesh filter chains are not programmed for ingress-only workloads that never originate mesh traffic
+	outboundDisabled := meshCatalog.IsOutboundDisabledForService(proxyServiceName)
+	if outboundDisabled {
+		log.Debug().Msgf("Outbound is disabled for proxy %s, not programming Outbound mesh filter chains", proxyServiceName)
+	}
+	outboundListener, err := lb.newOutboundListener(outboundDisabled)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error making outbound listener config for proxy %s", proxyServiceName)
 	} else {
@@ -48,6 +70,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 			// otherwise results in an error.
 			log.Debug().Msgf("Not programming Outbound listener for proxy %s", proxyServiceName)
 		} else {
+			outboundListener.PerConnectionBufferLimitBytes = perConnectionBufferLimitBytes
 			if marshalledOutbound, err := ptypes.MarshalAny(outboundListener); err != nil {
 				log.Error().Err(err).Msgf("Failed to marshal outbound listener config for proxy %s", proxyServiceName)
 			} else {
@@ -58,10 +81,25 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	// --- INBOUND -------------------
 	inboundListener := newInboundListener()
+	inboundListener.SocketOptions = getListenerSocketOptions(cfg)
+	inboundListener.ConnectionBalanceConfig = getConnectionBalanceConfig(cfg)
+	inboundListener.PerConnectionBufferLimitBytes = perConnectionBufferLimitBytes
 	// --- INBOUND: mesh filter chain
 	inboundMeshFilterChains := lb.getInboundMeshFilterChains(proxyServiceName)
+	// Connections to the inbound ports excluded from interception never reach the proxy, so they are not matched either
+	inboundMeshFilterChains = removeExcludedPortFilterChains(inboundMeshFilterChains, meshCatalog.GetInboundPortExclusionListForProxy(proxy.GetCertificateCommonName()))
 	inboundListener.FilterChains = append(inboundListener.FilterChains, inboundMeshFilterChains...)
 
+	// --- INBOUND: plaintext filter chains for the ports the kubelet probes in plaintext, opted into by the pod
+	plaintextProbePorts, nodeIP := meshCatalog.GetInboundPlaintextProbePortsForProxy(proxy.GetCertificateCommonName())
+	for _, port := range plaintextProbePorts {
+		if filterChain, err := buildInboundPlaintextFilterChain(port, nodeIP, cfg.GetTCPAccessLogFormat()); err != nil {
+			log.Error().Err(err).Msgf("Error building inbound plaintext filter chain for port %d of proxy %s", port, proxyServiceName)
+		} else {
+			inboundListener.FilterChains = append(inboundListener.FilterChains, filterChain)
+		}
+	}
+
 	// --- INGRESS -------------------
 	// Apply an ingress filter chain if there are any ingress routes
 	if ingressRoutesPerHost, err := meshCatalog.GetIngressRoutesPerHost(proxyServiceName); err != nil {
@@ -82,6 +120,24 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 	if len(inboundListener.FilterChains) > 0 {
 		// Inbound filter chains can be empty if the there both ingress and in-mesh policies are not configured.
 		// Configuring a listener without a filter chain is an error.
+
+		// Stalled TLS handshakes are dropped before they exhaust the resources of the inbound listener
+		applyTLSHandshakeTimeout(inboundListener.FilterChains, cfg.GetInboundTLSHandshakeTimeout())
+
+		inboundUnmatchedPassthrough := cfg.IsInboundUnmatchedSNIPassthroughEnabled()
+
+		// --- INBOUND: filter chains rejecting plaintext connections to the ports requiring mTLS
+		if !inboundUnmatchedPassthrough {
+			inboundListener.FilterChains = append(inboundListener.FilterChains, getInboundMTLSRequiredFilterChains(inboundListener.FilterChains, cfg.GetTCPAccessLogFormat())...)
+		}
+
+		// --- INBOUND: default filter chain for connections not matching any filter chain, such as an unknown SNI
+		if defaultFilterChain, err := buildInboundUnmatchedFilterChain(inboundUnmatchedPassthrough, cfg.GetTCPAccessLogFormat()); err != nil {
+			log.Error().Err(err).Msgf("Error building inbound default filter chain for proxy %s", proxyServiceName)
+		} else {
+			inboundListener.DefaultFilterChain = defaultFilterChain
+		}
+
 		if marshalledInbound, err := ptypes.MarshalAny(inboundListener); err != nil {
 			log.Error().Err(err).Msgf("Error marshalling inbound listener config for proxy %s", proxyServiceName)
 		} else {
@@ -89,6 +145,22 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		}
 	}
 
+	// --- INBOUND: experimental HTTP/3 (QUIC) listener
+	if cfg.IsInboundHTTP3Enabled() {
+		if quicListener, err := newInboundQUICListener(); err != nil {
+			log.Error().Err(err).Msgf("Error building inbound HTTP/3 listener config for proxy %s", proxyServiceName)
+		} else {
+			quicListener.FilterChains = lb.getInboundMeshHTTP3FilterChains(proxyServiceName)
+			if len(quicListener.FilterChains) > 0 {
+				if marshalledQUIC, err := ptypes.MarshalAny(quicListener); err != nil {
+					log.Error().Err(err).Msgf("Error marshalling inbound HTTP/3 listener config for proxy %s", proxyServiceName)
+				} else {
+					resp.Resources = append(resp.Resources, marshalledQUIC)
+				}
+			}
+		}
+	}
+
 	if cfg.IsPrometheusScrapingEnabled() {
 		// Build Prometheus listener config
 		prometheusConnManager := getPrometheusConnectionManager(prometheusListenerName, constants.PrometheusScrapePath, constants.EnvoyMetricsCluster)
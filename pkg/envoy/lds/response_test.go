@@ -1,11 +1,17 @@
 package lds
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes"
@@ -18,6 +24,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -56,6 +63,9 @@ func TestListenerConfiguration(t *testing.T) {
 	assert := tassert.New(t)
 	mockCtrl := gomock.NewController(t)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
 	kubeClient := testclient.NewSimpleClientset()
 	meshCatalog := catalog.NewFakeMeshCatalog(kubeClient)
 
@@ -67,7 +77,19 @@ func TestListenerConfiguration(t *testing.T) {
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(true).AnyTimes()
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
 	mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundTLSHandshakeTimeout().Return(10 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(2097152)).AnyTimes()
+	mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+	mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
 
 	actual, err := NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
 	assert.Empty(err)
@@ -96,6 +118,8 @@ func TestListenerConfiguration(t *testing.T) {
 	assert.NotNil(listener.DefaultFilterChain)
 	assert.Equal(listener.DefaultFilterChain.Name, outboundEgressFilterChainName)
 	assert.Equal(listener.DefaultFilterChain.Filters[0].Name, wellknown.TCPProxy)
+	assert.Empty(listener.SocketOptions)
+	assert.Equal(uint32(2097152), listener.PerConnectionBufferLimitBytes.GetValue())
 
 	// validating inbound listener
 	err = ptypes.UnmarshalAny(actual.Resources[1], &listener)
@@ -106,9 +130,22 @@ func TestListenerConfiguration(t *testing.T) {
 	assert.Equal(listener.ListenerFilters[0].Name, wellknown.TlsInspector)
 	assert.Equal(listener.ListenerFilters[1].Name, wellknown.OriginalDestination)
 	assert.NotNil(listener.FilterChains)
-	// There is 1 filter chains configured on the inbound-listner based on the configuration:
+	// There are 2 filter chains configured on the inbound-listner based on the configuration:
 	// 1. Filter chanin for bookbuyer
-	assert.Len(listener.FilterChains, 1)
+	// 2. Filter chain rejecting plaintext connections to the bookbuyer port
+	assert.Len(listener.FilterChains, 2)
+	assert.Equal(listener.FilterChains[1].Name, fmt.Sprintf("%s:%d", inboundMTLSRequiredFilterChainPrefix, listener.FilterChains[0].FilterChainMatch.DestinationPort.GetValue()))
+	assert.Equal(listener.FilterChains[1].FilterChainMatch.TransportProtocol, envoy.TransportProtocolRawBuffer)
+	assert.Equal(listener.FilterChains[1].Filters[0].Name, wellknown.RoleBasedAccessControl)
+	// Stalled TLS handshakes time out on the mTLS filter chain, plaintext filter chains have no handshake to time out
+	assert.Equal(ptypes.DurationProto(10*time.Second), listener.FilterChains[0].TransportSocketConnectTimeout)
+	assert.Nil(listener.FilterChains[1].TransportSocketConnectTimeout)
+	// Inbound connections not matching any filter chain are rejected by default
+	assert.NotNil(listener.DefaultFilterChain)
+	assert.Equal(listener.DefaultFilterChain.Name, inboundUnmatchedFilterChainName)
+	assert.Equal(listener.DefaultFilterChain.Filters[0].Name, wellknown.RoleBasedAccessControl)
+	assert.Empty(listener.SocketOptions)
+	assert.Equal(uint32(2097152), listener.PerConnectionBufferLimitBytes.GetValue())
 
 	// validating prometheus listener
 	err = ptypes.UnmarshalAny(actual.Resources[2], &listener)
@@ -118,3 +155,441 @@ func TestListenerConfiguration(t *testing.T) {
 	assert.NotNil(listener.FilterChains)
 	assert.Len(listener.FilterChains, 1)
 }
+
+func TestListenerConfigurationWithHTTP3(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	kubeClient := testclient.NewSimpleClientset()
+	meshCatalog := catalog.NewFakeMeshCatalog(kubeClient)
+
+	proxy, err := getProxy(kubeClient)
+	assert.Empty(err)
+
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundTLSHandshakeTimeout().Return(10 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+	mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
+
+	actual, err := NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Empty(err)
+	assert.NotNil(actual)
+	// There are 3 listeners configured based on the configuration:
+	// 1. Outbound listener (outbound-listener)
+	// 2. inbound listener (inbound-listener)
+	// 3. inbound HTTP/3 listener (inbound-quic-listener)
+	assert.Len(actual.Resources, 3)
+
+	listener := xds_listener.Listener{}
+
+	// validating the TCP inbound listener is unaffected
+	err = ptypes.UnmarshalAny(actual.Resources[1], &listener)
+	assert.Empty(err)
+	assert.Equal(listener.Name, inboundListenerName)
+	assert.Equal(listener.Address.GetSocketAddress().Protocol, xds_core.SocketAddress_TCP)
+	assert.Nil(listener.UdpListenerConfig)
+
+	// validating the inbound HTTP/3 listener
+	err = ptypes.UnmarshalAny(actual.Resources[2], &listener)
+	assert.Empty(err)
+	assert.Equal(listener.Name, inboundQUICListenerName)
+	assert.Equal(listener.TrafficDirection, xds_core.TrafficDirection_INBOUND)
+	assert.Equal(listener.Address.GetSocketAddress().Protocol, xds_core.SocketAddress_UDP)
+	assert.Equal(listener.UdpListenerConfig.UdpListenerName, quicListenerName)
+	assert.Len(listener.FilterChains, 1)
+	assert.Equal(listener.FilterChains[0].TransportSocket.Name, wellknown.TransportSocketQuic)
+	// UDP traffic is redirected to the QUIC listener, so the filter chain cannot match on the original port
+	assert.Nil(listener.FilterChains[0].FilterChainMatch.DestinationPort)
+
+	hcm := &xds_hcm.HttpConnectionManager{}
+	err = ptypes.UnmarshalAny(listener.FilterChains[0].Filters[len(listener.FilterChains[0].Filters)-1].GetTypedConfig(), hcm)
+	assert.Empty(err)
+	assert.Equal(hcm.CodecType, xds_hcm.HttpConnectionManager_HTTP3)
+}
+
+func TestListenerConfigurationWithOutboundDisabled(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveModeSANAuthorizationEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundTLSHandshakeTimeout().Return(10 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().GetPerConnectionBufferLimitBytesForService(gomock.Any()).Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+	mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+
+	proxyService := tests.BookbuyerService
+	proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", tests.ProxyUUID, tests.BookbuyerServiceAccountName, tests.Namespace)), "", nil)
+
+	// The mesh filter chains of the outbound listener must not be built, so no outbound services are looked up
+	mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName()).Return([]service.MeshService{proxyService}, nil).Times(1)
+	mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(true).Times(1)
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(proxyService).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+	mockCatalog.EXPECT().GetHTTP2MaxConcurrentStreamsForService(proxyService).Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().GetClientAddressDetectionForService(proxyService).Return(nil).AnyTimes()
+	mockCatalog.EXPECT().GetAccessLogSamplingPercentageForService(proxyService).Return(float64(100)).AnyTimes()
+	mockCatalog.EXPECT().GetInboundPortExclusionListForProxy(gomock.Any()).Return(nil).AnyTimes()
+	mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(proxyService).Return(false).AnyTimes()
+	mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(gomock.Any()).Return(nil, "").AnyTimes()
+	mockCatalog.EXPECT().GetIngressRoutesPerHost(proxyService).Return(nil, nil).Times(1)
+
+	actual, err := NewResponse(mockCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	assert.NotNil(actual)
+
+	// The outbound listener only has its default filter chain, as outbound traffic keeps being redirected to the proxy
+	assert.Len(actual.Resources, 2)
+	outboundListener := xds_listener.Listener{}
+	assert.Nil(ptypes.UnmarshalAny(actual.Resources[0], &outboundListener))
+	assert.Equal(outboundListenerName, outboundListener.Name)
+	assert.Empty(outboundListener.FilterChains)
+	assert.NotNil(outboundListener.DefaultFilterChain)
+
+	listener := xds_listener.Listener{}
+	assert.Nil(ptypes.UnmarshalAny(actual.Resources[1], &listener))
+	assert.Equal(inboundListenerName, listener.Name)
+	assert.Equal(xds_core.TrafficDirection_INBOUND, listener.TrafficDirection)
+	assert.Len(listener.FilterChains, 2)
+}
+
+func TestListenerConfigurationWithInboundPlaintextProbePorts(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetOutboundUnknownHostMode().Return(constants.DefaultOutboundUnknownHostMode).AnyTimes()
+	mockConfigurator.EXPECT().IsOutboundBlackholeEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsPermissiveModeSANAuthorizationEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundTLSHandshakeTimeout().Return(10 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().GetPerConnectionBufferLimitBytesForService(gomock.Any()).Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+	mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
+
+	proxyService := tests.BookbuyerService
+	proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", tests.ProxyUUID, tests.BookbuyerServiceAccountName, tests.Namespace)), "", nil)
+
+	mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName()).Return([]service.MeshService{proxyService}, nil).Times(1)
+	mockCatalog.EXPECT().IsOutboundDisabledForService(proxyService).Return(true).Times(1)
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(proxyService).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+	mockCatalog.EXPECT().GetHTTP2MaxConcurrentStreamsForService(proxyService).Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().GetClientAddressDetectionForService(proxyService).Return(nil).AnyTimes()
+	mockCatalog.EXPECT().GetAccessLogSamplingPercentageForService(proxyService).Return(float64(100)).AnyTimes()
+	mockCatalog.EXPECT().GetInboundPortExclusionListForProxy(gomock.Any()).Return(nil).AnyTimes()
+	mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(proxyService).Return(false).AnyTimes()
+	mockCatalog.EXPECT().GetInboundPlaintextProbePortsForProxy(proxy.GetCertificateCommonName()).Return([]uint32{80}, "10.240.0.4").Times(1)
+	mockCatalog.EXPECT().GetIngressRoutesPerHost(proxyService).Return(nil, nil).Times(1)
+
+	actual, err := NewResponse(mockCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	assert.NotNil(actual)
+
+	assert.Len(actual.Resources, 1)
+	listener := xds_listener.Listener{}
+	assert.Nil(ptypes.UnmarshalAny(actual.Resources[0], &listener))
+	assert.Equal(inboundListenerName, listener.Name)
+
+	// The probed service port accepts plaintext connections from the node passed through to the app, next to the mTLS
+	// filter chain of the port, while plaintext connections from other sources are still rejected
+	assert.Len(listener.FilterChains, 3)
+	assert.Equal("inbound-mtls-required-filter-chain:80", listener.FilterChains[2].Name)
+	plaintextFilterChain := listener.FilterChains[1]
+	assert.Equal("inbound-plaintext-filter-chain:80", plaintextFilterChain.Name)
+	assert.Equal(uint32(80), plaintextFilterChain.FilterChainMatch.DestinationPort.GetValue())
+	assert.Equal(envoy.TransportProtocolRawBuffer, plaintextFilterChain.FilterChainMatch.TransportProtocol)
+	assert.Len(plaintextFilterChain.FilterChainMatch.SourcePrefixRanges, 1)
+	assert.Equal("10.240.0.4", plaintextFilterChain.FilterChainMatch.SourcePrefixRanges[0].AddressPrefix)
+	assert.Equal(uint32(32), plaintextFilterChain.FilterChainMatch.SourcePrefixRanges[0].PrefixLen.GetValue())
+	assert.Nil(plaintextFilterChain.TransportSocket)
+	assert.Len(plaintextFilterChain.Filters, 1)
+	assert.Equal(wellknown.TCPProxy, plaintextFilterChain.Filters[0].Name)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(ptypes.UnmarshalAny(plaintextFilterChain.Filters[0].GetTypedConfig(), tcpProxy))
+	assert.Equal(envoy.InboundPassthroughCluster, tcpProxy.GetCluster())
+}
+
+func TestListenerConfigurationWithOutboundOriginalDst(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	kubeClient := testclient.NewSimpleClientset()
+	meshCatalog := catalog.NewFakeMeshCatalog(kubeClient)
+
+	proxy, err := getProxy(kubeClient)
+	assert.Nil(err)
+
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundTLSHandshakeTimeout().Return(10 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+	mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(true).AnyTimes()
+
+	actual, err := NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	assert.NotNil(actual)
+
+	listener := xds_listener.Listener{}
+	assert.Nil(ptypes.UnmarshalAny(actual.Resources[0], &listener))
+	assert.Equal(outboundListenerName, listener.Name)
+	assert.Equal(xds_core.TrafficDirection_OUTBOUND, listener.TrafficDirection)
+	assert.Len(listener.ListenerFilters, 1)
+	assert.Equal(wellknown.OriginalDestination, listener.ListenerFilters[0].Name)
+
+	// Mesh traffic keeps being routed by the per-upstream filter chains, as in the explicit mode
+	assert.Len(listener.FilterChains, 3)
+	for _, filterChain := range listener.FilterChains {
+		assert.True(strings.HasPrefix(filterChain.Name, outboundMeshHTTPFilterChainPrefix))
+	}
+
+	// The traffic not matching any upstream is forwarded to the original destination cluster, taking precedence over egress
+	assert.NotNil(listener.DefaultFilterChain)
+	assert.Equal(outboundOriginalDstFilterChainName, listener.DefaultFilterChain.Name)
+	assert.Len(listener.DefaultFilterChain.Filters, 1)
+	assert.Equal(wellknown.TCPProxy, listener.DefaultFilterChain.Filters[0].Name)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(ptypes.UnmarshalAny(listener.DefaultFilterChain.Filters[0].GetTypedConfig(), tcpProxy))
+	assert.Equal(envoy.OutboundOriginalDstCluster, tcpProxy.GetCluster())
+
+	assert.Nil(ptypes.UnmarshalAny(actual.Resources[1], &listener))
+	assert.Equal(inboundListenerName, listener.Name)
+}
+
+func TestListenerConfigurationWithOutboundPassthrough(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	kubeClient := testclient.NewSimpleClientset()
+	meshCatalog := catalog.NewFakeMeshCatalog(kubeClient)
+
+	proxy, err := getProxy(kubeClient)
+	assert.Nil(err)
+
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetOutboundUnknownHostMode().Return(constants.OutboundUnknownHostModePassthrough).AnyTimes()
+	mockConfigurator.EXPECT().IsInboundUnmatchedSNIPassthroughEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundTLSHandshakeTimeout().Return(10 * time.Second).AnyTimes()
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetListenerDSCP().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerReusePortEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsListenerExactBalanceEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetPerConnectionBufferLimitBytes().Return(uint32(0)).AnyTimes()
+	mockConfigurator.EXPECT().IsOutboundOriginalDstEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetTCPAccessLogFormat().Return("").AnyTimes()
+	mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
+
+	actual, err := NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	assert.Len(actual.Resources, 2)
+
+	// The requests to the mesh services are routed by their filter chains, the plaintext HTTP requests to unknown hosts
+	// are forwarded to their original destination by the passthrough HTTP filter chains, and other connections to
+	// unknown hosts by the default filter chain
+	listener := xds_listener.Listener{}
+	assert.Nil(ptypes.UnmarshalAny(actual.Resources[0], &listener))
+	assert.Equal(outboundListenerName, listener.Name)
+	assert.Nil(listener.Validate())
+
+	meshPorts := make(map[uint32]bool)
+	passthroughFilterChains := make(map[string]*xds_listener.FilterChain)
+	for _, filterChain := range listener.FilterChains {
+		if filterChain.GetFilterChainMatch().GetApplicationProtocols() == nil {
+			meshPorts[filterChain.GetFilterChainMatch().GetDestinationPort().GetValue()] = true
+			continue
+		}
+		passthroughFilterChains[filterChain.Name] = filterChain
+	}
+	assert.NotEmpty(meshPorts)
+
+	// The HTTP inspector detects plaintext HTTP, and connections of server-first protocols are passed through as TCP
+	var listenerFilterNames []string
+	for _, listenerFilter := range listener.ListenerFilters {
+		listenerFilterNames = append(listenerFilterNames, listenerFilter.Name)
+	}
+	assert.Contains(listenerFilterNames, wellknown.HttpInspector)
+	assert.True(listener.ContinueOnListenerFiltersTimeout)
+
+	// A passthrough HTTP filter chain matches any port, and one matches each port of the mesh services, which Envoy
+	// would otherwise only match against the filter chains of the mesh services
+	assert.Len(passthroughFilterChains, len(meshPorts)+1)
+	anyPortFilterChain := passthroughFilterChains[outboundPassthroughFilterChainName]
+	assert.NotNil(anyPortFilterChain)
+	assert.Nil(anyPortFilterChain.FilterChainMatch.DestinationPort)
+	assert.Equal(plaintextHTTPApplicationProtocols, anyPortFilterChain.FilterChainMatch.ApplicationProtocols)
+	for port := range meshPorts {
+		portFilterChain := passthroughFilterChains[fmt.Sprintf("%s:%d", outboundPassthroughFilterChainName, port)]
+		assert.NotNil(portFilterChain)
+		assert.Equal(port, portFilterChain.FilterChainMatch.DestinationPort.GetValue())
+		assert.Nil(portFilterChain.FilterChainMatch.PrefixRanges)
+	}
+
+	connManager := &xds_hcm.HttpConnectionManager{}
+	assert.Nil(ptypes.UnmarshalAny(anyPortFilterChain.Filters[0].GetTypedConfig(), connManager))
+	assert.Equal(outboundPassthroughStatPrefix, connManager.StatPrefix)
+	virtualHosts := connManager.GetRouteConfig().VirtualHosts
+	assert.Len(virtualHosts, 1)
+	assert.Equal([]string{"*"}, virtualHosts[0].Domains)
+	assert.Equal(envoy.OutboundPassthroughCluster, virtualHosts[0].Routes[0].GetRoute().GetCluster())
+
+	// The passed through requests are counted by a virtual cluster of their own
+	assert.Len(virtualHosts[0].VirtualClusters, 1)
+	assert.Equal(outboundPassthroughStatPrefix, virtualHosts[0].VirtualClusters[0].Name)
+
+	// TLS and raw TCP connections are passed through by the default TCP filter chain, with a stat prefix of its own
+	assert.NotNil(listener.DefaultFilterChain)
+	assert.Equal(outboundPassthroughTCPFilterChainName, listener.DefaultFilterChain.Name)
+	assert.Equal(wellknown.TCPProxy, listener.DefaultFilterChain.Filters[0].Name)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(ptypes.UnmarshalAny(listener.DefaultFilterChain.Filters[0].GetTypedConfig(), tcpProxy))
+	assert.Equal(envoy.OutboundPassthroughCluster, tcpProxy.GetCluster())
+	assert.Equal(outboundPassthroughTCPStatPrefix, tcpProxy.StatPrefix)
+}
+
+func TestListenerConfigurationDuringConfigGap(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", tests.ProxyUUID, tests.BookbuyerServiceAccountName, tests.Namespace)), "", nil)
+
+	// The pod of the proxy is not observed yet, so its mesh config cannot be computed
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(true).Times(1)
+	mockCatalog.EXPECT().IsConfigReadyForProxy(proxy.GetCertificateCommonName()).Return(false).Times(1)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return("TLSv1_2").Times(1)
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return("TLSv1_3").Times(1)
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).Times(1)
+
+	actual, err := NewResponse(mockCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	assert.NotNil(actual)
+	assert.Len(actual.Resources, 2)
+
+	// Both listeners respond to all requests with a 503, the inbound listener also to the mTLS requests of mesh peers
+	for i, tc := range []struct {
+		listenerName      string
+		filterChainNames  []string
+		expectedTLSChains int
+	}{
+		{outboundListenerName, []string{failStaticFilterChainName}, 0},
+		{inboundListenerName, []string{failStaticTLSFilterChainName, failStaticFilterChainName}, 1},
+	} {
+		listener := xds_listener.Listener{}
+		assert.Nil(ptypes.UnmarshalAny(actual.Resources[i], &listener))
+		assert.Equal(tc.listenerName, listener.Name)
+		assert.Len(listener.FilterChains, len(tc.filterChainNames))
+
+		tlsChains := 0
+		for j, filterChain := range listener.FilterChains {
+			assert.Equal(tc.filterChainNames[j], filterChain.Name)
+
+			connManager := &xds_hcm.HttpConnectionManager{}
+			assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), connManager))
+			assert.Equal(failStaticStatPrefix, connManager.StatPrefix)
+			directResponse := connManager.GetRouteConfig().VirtualHosts[0].Routes[0].GetDirectResponse()
+			assert.NotNil(directResponse)
+			assert.Equal(uint32(503), directResponse.Status)
+			assert.Equal(failStaticResponseBody, directResponse.Body.GetInlineString())
+
+			if filterChain.TransportSocket == nil {
+				continue
+			}
+			tlsChains++
+			// TLS is terminated with the certificate of the proxy's service account
+			assert.Equal(envoy.TransportProtocolTLS, filterChain.FilterChainMatch.TransportProtocol)
+			downstreamTLSContext := &xds_auth.DownstreamTlsContext{}
+			assert.Nil(ptypes.UnmarshalAny(filterChain.TransportSocket.GetTypedConfig(), downstreamTLSContext))
+			expectedSDSCert := envoy.SDSCert{
+				MeshService: tests.BookbuyerServiceAccount.GetSyntheticService(),
+				CertType:    envoy.ServiceCertType,
+			}
+			assert.Equal(expectedSDSCert.String(), downstreamTLSContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name)
+		}
+		assert.Equal(tc.expectedTLSChains, tlsChains)
+		assert.Nil(listener.Validate())
+	}
+
+	// The mesh listeners are built once the mesh config is ready
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(true).Times(1)
+	mockCatalog.EXPECT().IsConfigReadyForProxy(proxy.GetCertificateCommonName()).Return(true).Times(1)
+	mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName()).Return(nil, errors.New("no service")).Times(1)
+
+	_, err = NewResponse(mockCatalog, proxy, nil, mockConfigurator, nil)
+	assert.NotNil(err)
+}
@@ -0,0 +1,24 @@
+package lds
+
+import (
+	"time"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// applyTLSHandshakeTimeout closes the connections of the given filter chains terminating TLS that have not completed
+// their TLS handshake within the given timeout, 0 meaning no timeout, so that slow or stalled handshakes do not hold on
+// to the resources of the listener
+func applyTLSHandshakeTimeout(filterChains []*xds_listener.FilterChain, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+
+	for _, filterChain := range filterChains {
+		if filterChain.TransportSocket == nil {
+			continue
+		}
+		filterChain.TransportSocketConnectTimeout = ptypes.DurationProto(timeout)
+	}
+}
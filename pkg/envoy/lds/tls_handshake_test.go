@@ -0,0 +1,48 @@
+package lds
+
+import (
+	"testing"
+	"time"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestApplyTLSHandshakeTimeout(t *testing.T) {
+	testCases := []struct {
+		name            string
+		timeout         time.Duration
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "no timeout",
+			timeout:         0,
+			expectedTimeout: 0,
+		},
+		{
+			name:            "timeout set",
+			timeout:         5 * time.Second,
+			expectedTimeout: 5 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			tlsFilterChain := &xds_listener.FilterChain{Name: "inbound-mesh-http-filter-chain:14001", TransportSocket: &xds_core.TransportSocket{Name: "envoy.transport_sockets.tls"}}
+			plaintextFilterChain := &xds_listener.FilterChain{Name: "inbound-plaintext-filter-chain:15904"}
+
+			applyTLSHandshakeTimeout([]*xds_listener.FilterChain{tlsFilterChain, plaintextFilterChain}, tc.timeout)
+
+			if tc.expectedTimeout == 0 {
+				assert.Nil(tlsFilterChain.TransportSocketConnectTimeout)
+			} else {
+				assert.Equal(ptypes.DurationProto(tc.expectedTimeout), tlsFilterChain.TransportSocketConnectTimeout)
+			}
+			assert.Nil(plaintextFilterChain.TransportSocketConnectTimeout)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package lds
+
+import (
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// getDownstreamTLSContext returns the TLS context of the inbound connections to the given proxy service, requiring
+// client certificates for mTLS. Resuming TLS sessions using session tickets is disabled if the service disables it, and
+// left to Envoy's default otherwise.
+func (lb *listenerBuilder) getDownstreamTLSContext(proxyService service.MeshService, mTLS bool) *xds_auth.DownstreamTlsContext {
+	tlsContext := envoy.GetDownstreamTLSContext(proxyService, mTLS, lb.cfg)
+	if lb.meshCatalog.IsTLSSessionTicketsDisabledForService(proxyService) {
+		tlsContext.SessionTicketKeysType = &xds_auth.DownstreamTlsContext_DisableStatelessSessionResumption{
+			DisableStatelessSessionResumption: true,
+		}
+	}
+	return tlsContext
+}
@@ -0,0 +1,56 @@
+package lds
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestGetDownstreamTLSContextSessionTickets(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		sessionTicketsDisabled bool
+	}{
+		{
+			name:                   "session tickets are left to Envoy's default",
+			sessionTicketsDisabled: false,
+		},
+		{
+			name:                   "session tickets are disabled by the service",
+			sessionTicketsDisabled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+			mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+			mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+			mockCatalog.EXPECT().IsTLSSessionTicketsDisabledForService(tests.BookstoreV1Service).Return(tc.sessionTicketsDisabled).Times(1)
+
+			lb := &listenerBuilder{
+				meshCatalog: mockCatalog,
+				cfg:         mockConfigurator,
+			}
+
+			tlsContext := lb.getDownstreamTLSContext(tests.BookstoreV1Service, true /* mTLS */)
+			assert.True(tlsContext.RequireClientCertificate.GetValue())
+			assert.Equal(tc.sessionTicketsDisabled, tlsContext.GetDisableStatelessSessionResumption())
+			if !tc.sessionTicketsDisabled {
+				assert.Nil(tlsContext.SessionTicketKeysType)
+			}
+		})
+	}
+}
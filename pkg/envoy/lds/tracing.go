@@ -1,8 +1,12 @@
 package lds
 
 import (
+	"fmt"
+	"sort"
+
 	xds_tracing "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_tracing_type "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
 	"github.com/golang/protobuf/ptypes"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -11,6 +15,28 @@ import (
 
 // GetTracingConfig returns a configuration tracing struct for a connection manager to use
 func GetTracingConfig(cfg configurator.Configurator) (*xds_hcm.HttpConnectionManager_Tracing, error) {
+	var provider *xds_tracing.Tracing_Http
+	var err error
+	if cfg.GetTracingPropagationFormat() == constants.TracingPropagationFormatW3C {
+		provider, err = getOpenCensusTracingProvider(cfg)
+	} else {
+		provider, err = getZipkinTracingProvider(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracing := &xds_hcm.HttpConnectionManager_Tracing{
+		Verbose:    true,
+		Provider:   provider,
+		CustomTags: getTracingCustomTags(cfg.GetTracingCustomTags()),
+	}
+
+	return tracing, nil
+}
+
+// getZipkinTracingProvider returns the Zipkin tracing driver, which propagates the trace context in the B3 headers
+func getZipkinTracingProvider(cfg configurator.Configurator) (*xds_tracing.Tracing_Http, error) {
 	zipkinTracingConf := &xds_tracing.ZipkinConfig{
 		CollectorCluster:         constants.EnvoyTracingCluster,
 		CollectorEndpoint:        cfg.GetTracingEndpoint(),
@@ -23,16 +49,69 @@ func GetTracingConfig(cfg configurator.Configurator) (*xds_hcm.HttpConnectionMan
 		return nil, err
 	}
 
-	tracing := &xds_hcm.HttpConnectionManager_Tracing{
-		Verbose: true,
-		Provider: &xds_tracing.Tracing_Http{
-			// Name must refer to an instantiatable tracing driver
-			Name: "envoy.tracers.zipkin",
-			ConfigType: &xds_tracing.Tracing_Http_TypedConfig{
-				TypedConfig: zipkinConfMarshalled,
-			},
+	return &xds_tracing.Tracing_Http{
+		// Name must refer to an instantiatable tracing driver
+		Name: "envoy.tracers.zipkin",
+		ConfigType: &xds_tracing.Tracing_Http_TypedConfig{
+			TypedConfig: zipkinConfMarshalled,
+		},
+	}, nil
+}
+
+// getOpenCensusTracingProvider returns the OpenCensus tracing driver, which propagates the trace context in the W3C
+// traceparent header and exports the spans to the tracing collector in the Zipkin format. The B3 headers are still
+// accepted on incoming requests, so that traces started by B3 clients are continued.
+func getOpenCensusTracingProvider(cfg configurator.Configurator) (*xds_tracing.Tracing_Http, error) {
+	openCensusTracingConf := &xds_tracing.OpenCensusConfig{
+		ZipkinExporterEnabled: true,
+		ZipkinUrl:             fmt.Sprintf("http://%s:%d%s", cfg.GetTracingHost(), cfg.GetTracingPort(), cfg.GetTracingEndpoint()),
+		IncomingTraceContext: []xds_tracing.OpenCensusConfig_TraceContext{
+			xds_tracing.OpenCensusConfig_TRACE_CONTEXT,
+			xds_tracing.OpenCensusConfig_B3,
+		},
+		OutgoingTraceContext: []xds_tracing.OpenCensusConfig_TraceContext{
+			xds_tracing.OpenCensusConfig_TRACE_CONTEXT,
 		},
 	}
 
-	return tracing, nil
+	openCensusConfMarshalled, err := ptypes.MarshalAny(openCensusTracingConf)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling OpenCensus config")
+		return nil, err
+	}
+
+	return &xds_tracing.Tracing_Http{
+		// Name must refer to an instantiatable tracing driver
+		Name: "envoy.tracers.opencensus",
+		ConfigType: &xds_tracing.Tracing_Http_TypedConfig{
+			TypedConfig: openCensusConfMarshalled,
+		},
+	}, nil
+}
+
+// getTracingCustomTags returns the custom span tags populated from request headers, sorted by tag name
+func getTracingCustomTags(tagToHeader map[string]string) []*xds_tracing_type.CustomTag {
+	if len(tagToHeader) == 0 {
+		return nil
+	}
+
+	var tags []string
+	for tag := range tagToHeader {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	var customTags []*xds_tracing_type.CustomTag
+	for _, tag := range tags {
+		customTags = append(customTags, &xds_tracing_type.CustomTag{
+			Tag: tag,
+			Type: &xds_tracing_type.CustomTag_RequestHeader{
+				RequestHeader: &xds_tracing_type.CustomTag_Header{
+					Name: tagToHeader[tag],
+				},
+			},
+		})
+	}
+
+	return customTags
 }
@@ -1,11 +1,14 @@
 package rbac
 
 import (
+	"net"
 	"strconv"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // Generate constructs an RBAC policy for the policy object on which this method is called
@@ -37,6 +40,15 @@ func (p *Policy) Generate() (*xds_rbac.Policy, error) {
 				if andPrincipalRule.Attribute == DownstreamAuthPrincipal {
 					authPrincipal := GetAuthenticatedPrincipal(andPrincipalRule.Value)
 					andPrincipalRules = append(andPrincipalRules, authPrincipal)
+				} else if andPrincipalRule.Attribute == DownstreamAuthPrincipalTrustDomain {
+					trustDomainPrincipal := GetTrustDomainPrincipal(andPrincipalRule.Value)
+					andPrincipalRules = append(andPrincipalRules, trustDomainPrincipal)
+				} else if andPrincipalRule.Attribute == DownstreamSourceIPRange {
+					sourceIPPrincipal, err := GetSourceIPRangePrincipal(andPrincipalRule.Value)
+					if err != nil {
+						return nil, err
+					}
+					andPrincipalRules = append(andPrincipalRules, sourceIPPrincipal)
 				}
 			}
 			currentPrincipal = andPrincipals(andPrincipalRules)
@@ -49,6 +61,15 @@ func (p *Policy) Generate() (*xds_rbac.Policy, error) {
 				if orPrincipalRule.Attribute == DownstreamAuthPrincipal {
 					authPrincipal := GetAuthenticatedPrincipal(orPrincipalRule.Value)
 					orPrincipalRules = append(orPrincipalRules, authPrincipal)
+				} else if orPrincipalRule.Attribute == DownstreamAuthPrincipalTrustDomain {
+					trustDomainPrincipal := GetTrustDomainPrincipal(orPrincipalRule.Value)
+					orPrincipalRules = append(orPrincipalRules, trustDomainPrincipal)
+				} else if orPrincipalRule.Attribute == DownstreamSourceIPRange {
+					sourceIPPrincipal, err := GetSourceIPRangePrincipal(orPrincipalRule.Value)
+					if err != nil {
+						return nil, err
+					}
+					orPrincipalRules = append(orPrincipalRules, sourceIPPrincipal)
 				}
 			}
 			currentPrincipal = orPrincipals(orPrincipalRules)
@@ -148,6 +169,49 @@ func GetAuthenticatedPrincipal(principalName string) *xds_rbac.Principal {
 	}
 }
 
+// GetTrustDomainPrincipal returns an authenticated RBAC principal object matching the principals of the given trust
+// domain. The principal is the identity in the SAN of the verified peer certificate.
+func GetTrustDomainPrincipal(trustDomain string) *xds_rbac.Principal {
+	return &xds_rbac.Principal{
+		Identifier: &xds_rbac.Principal_Authenticated_{
+			Authenticated: &xds_rbac.Principal_Authenticated{
+				PrincipalName: &xds_matcher.StringMatcher{
+					MatchPattern: &xds_matcher.StringMatcher_Suffix{
+						Suffix: "." + trustDomain,
+					},
+				},
+			},
+		},
+	}
+}
+
+// GetSourceIPRangePrincipal returns an RBAC principal matching the downstream connections whose source IP is within the
+// given CIDR range. The source IP is the IP of the downstream peer connected to the proxy.
+func GetSourceIPRangePrincipal(cidr string) (*xds_rbac.Principal, error) {
+	cidrRange, err := GetCIDRRange(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return &xds_rbac.Principal{
+		Identifier: &xds_rbac.Principal_DirectRemoteIp{
+			DirectRemoteIp: cidrRange,
+		},
+	}, nil
+}
+
+// GetCIDRRange returns the Envoy CIDR range corresponding to the given CIDR string
+func GetCIDRRange(cidr string) (*xds_core.CidrRange, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid CIDR range %s", cidr)
+	}
+	prefixLen, _ := ipNet.Mask.Size()
+	return &xds_core.CidrRange{
+		AddressPrefix: ipNet.IP.String(),
+		PrefixLen:     &wrapperspb.UInt32Value{Value: uint32(prefixLen)},
+	}, nil
+}
+
 func orPrincipals(principals []*xds_rbac.Principal) *xds_rbac.Principal {
 	return &xds_rbac.Principal{
 		Identifier: &xds_rbac.Principal_OrIds{
@@ -6,7 +6,9 @@ import (
 
 	tassert "github.com/stretchr/testify/assert"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestGenerate(t *testing.T) {
@@ -51,6 +53,36 @@ func TestGenerate(t *testing.T) {
 			expectError: false,
 		},
 
+		{
+			name: "testing OR rules for principals of a trust domain",
+			p: &Policy{
+				Principals: []RulesList{
+					{
+						OrRules: []Rule{
+							{Attribute: DownstreamAuthPrincipalTrustDomain, Value: "cluster.local"},
+						},
+					},
+				},
+			},
+			expectedPrincipals: []*xds_rbac.Principal{
+				{
+					Identifier: &xds_rbac.Principal_OrIds{
+						OrIds: &xds_rbac.Principal_Set{
+							Ids: []*xds_rbac.Principal{
+								GetTrustDomainPrincipal("cluster.local"),
+							},
+						},
+					},
+				},
+			},
+			expectedPermissions: []*xds_rbac.Permission{
+				{
+					Rule: &xds_rbac.Permission_Any{Any: true},
+				},
+			},
+			expectError: false,
+		},
+
 		{
 			name: "testing OR rules for single principal",
 			p: &Policy{
@@ -273,6 +305,59 @@ func TestGenerate(t *testing.T) {
 			},
 			expectError: false,
 		},
+
+		{
+			name: "testing OR rules for source IP ranges combined with a principal",
+			p: &Policy{
+				Principals: []RulesList{
+					{
+						OrRules: []Rule{
+							{Attribute: DownstreamAuthPrincipal, Value: "foo.domain"},
+							{Attribute: DownstreamSourceIPRange, Value: "10.1.0.0/16"},
+						},
+					},
+				},
+			},
+			expectedPrincipals: []*xds_rbac.Principal{
+				{
+					Identifier: &xds_rbac.Principal_OrIds{
+						OrIds: &xds_rbac.Principal_Set{
+							Ids: []*xds_rbac.Principal{
+								GetAuthenticatedPrincipal("foo.domain"),
+								{
+									Identifier: &xds_rbac.Principal_DirectRemoteIp{
+										DirectRemoteIp: &xds_core.CidrRange{
+											AddressPrefix: "10.1.0.0",
+											PrefixLen:     &wrapperspb.UInt32Value{Value: 16},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPermissions: []*xds_rbac.Permission{
+				{
+					Rule: &xds_rbac.Permission_Any{Any: true},
+				},
+			},
+			expectError: false,
+		},
+
+		{
+			name: "testing invalid source IP range",
+			p: &Policy{
+				Principals: []RulesList{
+					{
+						OrRules: []Rule{
+							{Attribute: DownstreamSourceIPRange, Value: "10.1.0.0"},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -8,6 +8,12 @@ type RuleAttribute string
 const (
 	// DownstreamAuthPrincipal is the key used for the name of the downstream principal in a policy Rule
 	DownstreamAuthPrincipal RuleAttribute = "downstreamAuthPrincipal"
+
+	// DownstreamSourceIPRange is the key used for the CIDR range of the downstream's source IP in a policy Rule
+	DownstreamSourceIPRange RuleAttribute = "downstreamSourceIPRange"
+
+	// DownstreamAuthPrincipalTrustDomain is the key used for the trust domain of the downstream principal in a policy Rule
+	DownstreamAuthPrincipalTrustDomain RuleAttribute = "downstreamAuthPrincipalTrustDomain"
 )
 
 // Supported attributes for an RBAC permission
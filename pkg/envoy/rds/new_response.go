@@ -6,11 +6,12 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	cat "github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
 )
 
-func newResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy) (*xds_discovery.DiscoveryResponse, error) {
+func newResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	proxyIdentity, err := cat.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error looking up Service Account for Envoy with serial number=%q", proxy.GetCertificateSerialNumber())
@@ -25,6 +26,19 @@ func newResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy) (*xds_discov
 		TypeUrl: string(envoy.TypeRDS),
 	}
 
+	// Without a mesh wide limit on the size of requests, the buffer filter programmed for the rules overriding the limit
+	// must not limit the requests of the other rules
+	if cfg.GetMaxRequestBytes() == 0 && catalog.HasMaxRequestBytesOverrides() {
+		for _, inboundTrafficPolicy := range inboundTrafficPolicies {
+			for _, rule := range inboundTrafficPolicy.Rules {
+				if rule.Route.HTTPRouteMatch.MaxRequestBytes == nil {
+					unlimited := uint32(0)
+					rule.Route.HTTPRouteMatch.MaxRequestBytes = &unlimited
+				}
+			}
+		}
+	}
+
 	// TODO merge ingress policies with existing inboundTrafficPolicies (issue #2367)
 	routeConfiguration := route.BuildRouteConfiguration(inboundTrafficPolicies, outboundTrafficPolicies)
 
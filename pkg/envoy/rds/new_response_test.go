@@ -6,6 +6,8 @@ import (
 
 	set "github.com/deckarep/golang-set"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_buffer "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/mock/gomock"
 	proto "github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/tests"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
@@ -24,6 +27,7 @@ func TestNewResponse(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 
 	uuid := uuid.New().String()
 	certCommonName := certificate.CommonName(fmt.Sprintf("%s.%s.%s.one.two.three.co.uk", uuid, "some-service", "some-namespace"))
@@ -55,7 +59,11 @@ func TestNewResponse(t *testing.T) {
 
 	mockCatalog.EXPECT().ListTrafficPoliciesForServiceAccount(gomock.Any()).Return(testInbound, nil, nil).AnyTimes()
 
-	actual, err := newResponse(mockCatalog, testProxy)
+	// Routes not overriding the limit on the size of requests are not limited, as there is no mesh wide limit
+	mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+	mockCatalog.EXPECT().HasMaxRequestBytesOverrides().Return(true).AnyTimes()
+
+	actual, err := newResponse(mockCatalog, testProxy, mockConfigurator)
 	assert.Nil(err)
 
 	routeConfig := &xds_route.RouteConfiguration{}
@@ -66,4 +74,9 @@ func TestNewResponse(t *testing.T) {
 	assert.Equal("RDS_Inbound", routeConfig.Name)
 	assert.Equal(1, len(routeConfig.VirtualHosts))
 	assert.Equal("inbound_virtualHost|bookstore-v1-default", routeConfig.VirtualHosts[0].Name)
+	for _, route := range routeConfig.VirtualHosts[0].Routes {
+		bufferPerRoute := &xds_buffer.BufferPerRoute{}
+		assert.Nil(proto.UnmarshalAny(route.TypedPerFilterConfig[wellknown.Buffer], bufferPerRoute))
+		assert.True(bufferPerRoute.GetDisabled())
+	}
 }
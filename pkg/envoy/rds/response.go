@@ -1,6 +1,8 @@
 package rds
 
 import (
+	"strconv"
+
 	set "github.com/deckarep/golang-set"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
@@ -10,6 +12,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
 	"github.com/openservicemesh/osm/pkg/featureflags"
@@ -19,9 +22,9 @@ import (
 )
 
 // NewResponse creates a new Route Discovery Response.
-func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, _ configurator.Configurator, _ certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
+func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
 	if featureflags.IsRoutesV2Enabled() {
-		return newResponse(cataloger, proxy)
+		return newResponse(cataloger, proxy, cfg)
 	}
 
 	svcList, err := cataloger.GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName())
@@ -32,6 +35,12 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_dis
 	// Github Issue #1575
 	proxyServiceName := svcList[0]
 
+	proxyIdentity, err := catalog.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up proxy identity for proxy with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return nil, err
+	}
+
 	allTrafficPolicies, err := cataloger.ListTrafficPolicies(proxyServiceName)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error listing routes for Envoy on Pod with UID=%s", proxy.GetPodUID())
@@ -44,6 +53,18 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_dis
 	}
 
 	allTrafficSplits, _, _, _, _ := cataloger.ListSMIPolicies()
+	emptyBackends := set.NewSet()
+	if cfg.GetTrafficSplitEmptyBackendMode() == constants.TrafficSplitEmptyBackendModeRedistribute {
+		emptyBackends = getEmptyTrafficSplitBackends(cataloger, allTrafficSplits)
+	}
+	outboundRequestTimeout := cfg.GetOutboundRequestTimeout()
+	permissiveMode := cfg.IsPermissiveTrafficPolicyMode()
+	// Inbound routes are accessible to the source IP ranges allowed by the proxy's service regardless of the identity of
+	// the sources when connections from the ranges bypass mTLS
+	var bypassSourceIPRanges []string
+	if !permissiveMode && cfg.IsSourceIPRangeMTLSBypassEnabled() {
+		bypassSourceIPRanges = cataloger.GetAllowedSourceIPRangesForService(proxyServiceName)
+	}
 	var routeConfiguration []*xds_route.RouteConfiguration
 	outboundRouteConfig := route.NewRouteConfigurationStub(route.OutboundRouteConfigName)
 	inboundRouteConfig := route.NewRouteConfigurationStub(route.InboundRouteConfigName)
@@ -64,6 +85,56 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_dis
 			continue
 		}
 
+		// Outbound routes to a service with failover services the proxy is allowed to access point at the aggregate
+		// cluster failing over from the service's cluster
+		outboundWeightedCluster := weightedCluster
+		if isSourceService && len(cataloger.ListAllowedFailoverServicesForService(proxyIdentity, svc)) > 0 {
+			outboundWeightedCluster.ClusterName = service.ClusterName(envoy.GetFailoverClusterNameForService(svc))
+		}
+
+		// Outbound routes to a service with mirror policies mirror requests to the service's shadow services the proxy
+		// is allowed to access
+		var mirrorPolicies []trafficpolicy.MirrorPolicy
+		if isSourceService {
+			mirrorPolicies = cataloger.ListAllowedMirrorPoliciesForService(proxyIdentity, svc)
+		}
+
+		// Outbound requests to a service with a direct response are responded to instead of being routed to the service
+		directResponse := cataloger.GetDirectResponseForService(svc)
+
+		// Outbound routes to a service load balanced by consistent hashing hash the request attribute set by the service
+		hashPolicy := cataloger.GetHashPolicyForService(svc)
+
+		// Outbound routes to a service with a retry policy retry requests on the conditions set by the service
+		retryPolicy := cataloger.GetRetryPolicyForService(svc)
+
+		// Outbound requests to a service setting a request timeout time out after it instead of the mesh-wide timeout
+		requestTimeout := cataloger.GetRequestTimeoutForService(svc)
+		if requestTimeout == 0 {
+			requestTimeout = outboundRequestTimeout
+		}
+
+		// Outbound routes to a backend of a sticky TrafficSplit keep routing a client to the backend it was routed to
+		stickyCanaryCookie := getStickyCanaryCookie(svc, allTrafficSplits)
+
+		// Outbound requests to a port of a service exposing several ports are routed to the cluster of the port, unless
+		// they are routed to the aggregate cluster of the service's failover services
+		var portsWithCluster map[uint32]bool
+		if outboundWeightedCluster.ClusterName == weightedCluster.ClusterName {
+			portsWithCluster = getServicePortsWithCluster(cataloger, svc)
+		}
+
+		// Inbound routes granted by the traffic policy are only accessible to the service accounts of its source, so that
+		// a source is denied the routes, such as the gRPC methods, it is not granted
+		var sourceServiceAccounts set.Set
+		if isDestinationService && !permissiveMode {
+			sourceServiceAccounts, err = getServiceAccountsForService(cataloger, trafficPolicy.Source)
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed listing service accounts for source service %s", trafficPolicy.Source)
+				return nil, err
+			}
+		}
+
 		hostnames, err := cataloger.GetResolvableHostnamesForUpstreamService(proxyServiceName, svc)
 		//filter out traffic split service, reference to pkg/catalog/xds_certificates.go:74
 		if isTrafficSplitService(svc, allTrafficSplits) {
@@ -76,12 +147,24 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_dis
 		for _, hostname := range hostnames {
 			// All routes from a given source to destination are part of 1 traffic policy between the source and destination.
 			for _, httpRoute := range trafficPolicy.HTTPRouteMatches {
-				if isSourceService {
-					aggregateRoutesByHost(outboundAggregatedRoutesByHostnames, httpRoute, weightedCluster, hostname)
+				// Outbound routes do not route to backends of a TrafficSplit without endpoints, redistributing their weight
+				if isSourceService && !emptyBackends.Contains(svc) {
+					outboundRoute := httpRoute
+					outboundRoute.MirrorPolicies = mirrorPolicies
+					outboundRoute.StickyCanaryCookie = stickyCanaryCookie
+					outboundRoute.DirectResponse = directResponse
+					outboundRoute.HashPolicy = hashPolicy
+					outboundRoute.RetryPolicy = retryPolicy
+					outboundRoute.Timeout = requestTimeout
+					virtualHost, hostWeightedCluster := getServicePortRouteTarget(svc, hostname, outboundWeightedCluster, portsWithCluster)
+					aggregateRoutesByVirtualHost(outboundAggregatedRoutesByHostnames, virtualHost, outboundRoute, hostWeightedCluster, hostname)
 				}
 
 				if isDestinationService {
-					aggregateRoutesByHost(inboundAggregatedRoutesByHostnames, httpRoute, weightedCluster, hostname)
+					inboundRoute := httpRoute
+					inboundRoute.AllowedServiceAccounts = sourceServiceAccounts
+					inboundRoute.AllowedSourceIPRanges = bypassSourceIPRanges
+					aggregateRoutesByHost(inboundAggregatedRoutesByHostnames, inboundRoute, weightedCluster, hostname)
 				}
 			}
 		}
@@ -91,8 +174,20 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_dis
 		return nil, err
 	}
 
+	// Without a mesh wide limit on the size of requests, the buffer filter programmed for the routes overriding the limit
+	// must not limit the requests to the other routes
+	if cfg.GetMaxRequestBytes() == 0 && cataloger.HasMaxRequestBytesOverrides() {
+		disableMaxRequestBytesByDefault(inboundAggregatedRoutesByHostnames)
+	}
+
 	route.UpdateRouteConfiguration(outboundAggregatedRoutesByHostnames, outboundRouteConfig, route.OutboundRoute)
 	route.UpdateRouteConfiguration(inboundAggregatedRoutesByHostnames, inboundRouteConfig, route.InboundRoute)
+	if cfg.IsHTTPMethodStatsEnabled() {
+		route.ApplyVirtualClusters(inboundRouteConfig)
+	}
+	if cfg.IsUpstreamClusterHeaderEnabled() {
+		route.ApplyUpstreamClusterHeader(outboundRouteConfig)
+	}
 	routeConfiguration = append(routeConfiguration, outboundRouteConfig)
 	routeConfiguration = append(routeConfiguration, inboundRouteConfig)
 
@@ -107,6 +202,21 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_dis
 	return resp, nil
 }
 
+// disableMaxRequestBytesByDefault sets the routes of the given routes per host not overriding the limit on the size of
+// requests to not limit the size of their requests
+func disableMaxRequestBytesByDefault(routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters) {
+	for host, routes := range routesPerHost {
+		for pathRegex, routeWeightedClusters := range routes {
+			if routeWeightedClusters.HTTPRouteMatch.MaxRequestBytes != nil {
+				continue
+			}
+			unlimited := uint32(0)
+			routeWeightedClusters.HTTPRouteMatch.MaxRequestBytes = &unlimited
+			routesPerHost[host][pathRegex] = routeWeightedClusters
+		}
+	}
+}
+
 func isTrafficSplitService(svc service.MeshService, allTrafficSplits []*split.TrafficSplit) bool {
 	for _, trafficSplit := range allTrafficSplits {
 		if trafficSplit.Namespace == svc.Namespace && trafficSplit.Spec.Service == svc.Name {
@@ -116,8 +226,60 @@ func isTrafficSplitService(svc service.MeshService, allTrafficSplits []*split.Tr
 	return false
 }
 
+// getStickyCanaryCookie returns the name of the sticky canary cookie of the TrafficSplit annotated for sticky canary
+// routing the given service is a backend of, or an empty string if the service is not a backend of such a TrafficSplit
+func getStickyCanaryCookie(svc service.MeshService, allTrafficSplits []*split.TrafficSplit) string {
+	for _, trafficSplit := range allTrafficSplits {
+		if trafficSplit.Namespace != svc.Namespace {
+			continue
+		}
+		if sticky, _ := strconv.ParseBool(trafficSplit.Annotations[constants.StickyCanaryAnnotation]); !sticky {
+			continue
+		}
+		for _, backend := range trafficSplit.Spec.Backends {
+			if backend.Service == svc.Name {
+				return route.GetStickyCanaryCookieName(trafficSplit.Namespace, trafficSplit.Spec.Service)
+			}
+		}
+	}
+	return ""
+}
+
+// getEmptyTrafficSplitBackends returns the set of backends of the given TrafficSplits without ready endpoints. Backends
+// of a TrafficSplit none of whose backends have ready endpoints are not included, so that the split keeps routing to its
+// backends.
+func getEmptyTrafficSplitBackends(cataloger catalog.MeshCataloger, allTrafficSplits []*split.TrafficSplit) set.Set {
+	emptyBackends := set.NewSet()
+	for _, trafficSplit := range allTrafficSplits {
+		splitEmptyBackends := set.NewSet()
+		for _, backend := range trafficSplit.Spec.Backends {
+			backendService := service.MeshService{Namespace: trafficSplit.Namespace, Name: backend.Service}
+			endpoints, err := cataloger.ListEndpointsForService(backendService)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error listing endpoints of backend %s of TrafficSplit %s/%s", backendService, trafficSplit.Namespace, trafficSplit.Name)
+				continue
+			}
+			if len(endpoints) == 0 {
+				splitEmptyBackends.Add(backendService)
+			}
+		}
+		if splitEmptyBackends.Cardinality() == len(trafficSplit.Spec.Backends) {
+			continue
+		}
+		for backend := range splitEmptyBackends.Iter() {
+			log.Debug().Msgf("Redistributing the weight of backend %s of TrafficSplit %s/%s without endpoints", backend, trafficSplit.Namespace, trafficSplit.Name)
+		}
+		emptyBackends = emptyBackends.Union(splitEmptyBackends)
+	}
+	return emptyBackends
+}
+
 func aggregateRoutesByHost(routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters, routePolicy trafficpolicy.HTTPRouteMatch, weightedCluster service.WeightedCluster, hostname string) {
-	host := kubernetes.GetServiceFromHostname(hostname)
+	aggregateRoutesByVirtualHost(routesPerHost, kubernetes.GetServiceFromHostname(hostname), routePolicy, weightedCluster, hostname)
+}
+
+// aggregateRoutesByVirtualHost aggregates the given route to the given hostname into the routes of the given virtual host
+func aggregateRoutesByVirtualHost(routesPerHost map[string]map[string]trafficpolicy.RouteWeightedClusters, host string, routePolicy trafficpolicy.HTTPRouteMatch, weightedCluster service.WeightedCluster, hostname string) {
 	_, exists := routesPerHost[host]
 	if !exists {
 		// no host found, create a new route map
@@ -134,6 +296,40 @@ func aggregateRoutesByHost(routesPerHost map[string]map[string]trafficpolicy.Rou
 		for headerKey, headerValue := range routePolicy.Headers {
 			routePolicyWeightedCluster.HTTPRouteMatch.Headers[headerKey] = headerValue
 		}
+		if routePolicy.PrefixRewrite != "" || routePolicy.RegexRewrite != nil {
+			routePolicyWeightedCluster.HTTPRouteMatch.PrefixRewrite = routePolicy.PrefixRewrite
+			routePolicyWeightedCluster.HTTPRouteMatch.RegexRewrite = routePolicy.RegexRewrite
+		}
+		if routePolicy.MaxRequestBytes != nil {
+			routePolicyWeightedCluster.HTTPRouteMatch.MaxRequestBytes = routePolicy.MaxRequestBytes
+		}
+		if len(routePolicy.MirrorPolicies) > 0 {
+			routePolicyWeightedCluster.HTTPRouteMatch.MirrorPolicies = routePolicy.MirrorPolicies
+		}
+		if routePolicy.StickyCanaryCookie != "" {
+			routePolicyWeightedCluster.HTTPRouteMatch.StickyCanaryCookie = routePolicy.StickyCanaryCookie
+		}
+		if routePolicy.DirectResponse != nil {
+			routePolicyWeightedCluster.HTTPRouteMatch.DirectResponse = routePolicy.DirectResponse
+		}
+		if routePolicy.HashPolicy != nil {
+			routePolicyWeightedCluster.HTTPRouteMatch.HashPolicy = routePolicy.HashPolicy
+		}
+		if routePolicy.RetryPolicy != nil {
+			routePolicyWeightedCluster.HTTPRouteMatch.RetryPolicy = routePolicy.RetryPolicy
+		}
+		if routePolicy.Timeout > 0 {
+			routePolicyWeightedCluster.HTTPRouteMatch.Timeout = routePolicy.Timeout
+		}
+		if routePolicyWeightedCluster.HTTPRouteMatch.AllowedServiceAccounts != nil && routePolicy.AllowedServiceAccounts != nil {
+			routePolicyWeightedCluster.HTTPRouteMatch.AllowedServiceAccounts = routePolicyWeightedCluster.HTTPRouteMatch.AllowedServiceAccounts.Union(routePolicy.AllowedServiceAccounts)
+		} else {
+			// A route without allowed service accounts of its own, such as an ingress route, is accessible to all the sources
+			routePolicyWeightedCluster.HTTPRouteMatch.AllowedServiceAccounts = nil
+		}
+		if len(routePolicy.AllowedSourceIPRanges) > 0 {
+			routePolicyWeightedCluster.HTTPRouteMatch.AllowedSourceIPRanges = routePolicy.AllowedSourceIPRanges
+		}
 		routePolicyWeightedCluster.Hostnames.Add(hostname)
 		routesPerHost[host][routePolicy.PathRegex] = routePolicyWeightedCluster
 	} else {
@@ -142,6 +338,19 @@ func aggregateRoutesByHost(routesPerHost map[string]map[string]trafficpolicy.Rou
 	}
 }
 
+// getServiceAccountsForService returns the set of service accounts of the given service
+func getServiceAccountsForService(cataloger catalog.MeshCataloger, svc service.MeshService) (set.Set, error) {
+	serviceAccounts, err := cataloger.ListServiceAccountsForService(svc)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccountsSet := set.NewSet()
+	for _, svcAccount := range serviceAccounts {
+		serviceAccountsSet.Add(svcAccount)
+	}
+	return serviceAccountsSet, nil
+}
+
 func createRoutePolicyWeightedClusters(routePolicy trafficpolicy.HTTPRouteMatch, weightedCluster service.WeightedCluster, hostname string) trafficpolicy.RouteWeightedClusters {
 	return trafficpolicy.RouteWeightedClusters{
 		HTTPRouteMatch:   routePolicy,
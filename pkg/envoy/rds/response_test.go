@@ -77,6 +77,82 @@ var _ = Describe("IsTrafficSplitService", func() {
 	})
 })
 
+var _ = Describe("GetStickyCanaryCookie", func() {
+	stickyTrafficSplit := tests.TrafficSplit
+	stickyTrafficSplit.Annotations = map[string]string{constants.StickyCanaryAnnotation: "true"}
+
+	It("Returns the cookie of the root service for a backend of a sticky TrafficSplit", func() {
+		Expect(getStickyCanaryCookie(tests.BookstoreV2Service, []*split.TrafficSplit{&stickyTrafficSplit})).To(Equal("osm-canary." + tests.Namespace + "." + tests.TrafficSplit.Spec.Service))
+	})
+
+	It("Returns no cookie for a backend of a TrafficSplit without stickiness", func() {
+		Expect(getStickyCanaryCookie(tests.BookstoreV2Service, []*split.TrafficSplit{&tests.TrafficSplit})).To(BeEmpty())
+	})
+
+	It("Returns no cookie for a service that is not a backend of a sticky TrafficSplit", func() {
+		Expect(getStickyCanaryCookie(tests.BookbuyerService, []*split.TrafficSplit{&stickyTrafficSplit})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("DisableMaxRequestBytesByDefault", func() {
+	It("Disables the request size limit of the routes not overriding it", func() {
+		override := uint32(1024)
+		overridingRoute := tests.BookstoreSellHTTPRoute
+		overridingRoute.MaxRequestBytes = &override
+
+		routesPerHost := make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
+		aggregateRoutesByHost(routesPerHost, tests.BookstoreBuyHTTPRoute, tests.BookstoreV1DefaultWeightedCluster, "bookstore.mesh")
+		aggregateRoutesByHost(routesPerHost, overridingRoute, tests.BookstoreV1DefaultWeightedCluster, "bookstore.mesh")
+
+		disableMaxRequestBytesByDefault(routesPerHost)
+
+		routes := routesPerHost["bookstore"]
+		Expect(*routes[tests.BookstoreBuyHTTPRoute.PathRegex].HTTPRouteMatch.MaxRequestBytes).To(Equal(uint32(0)))
+		Expect(*routes[overridingRoute.PathRegex].HTTPRouteMatch.MaxRequestBytes).To(Equal(override))
+	})
+})
+
+var _ = Describe("GetEmptyTrafficSplitBackends", func() {
+	var (
+		mockCtrl    *gomock.Controller
+		mockCatalog *catalog.MockMeshCataloger
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Returns the backend without endpoints so that its weight is redistributed to the other backend", func() {
+		mockCatalog.EXPECT().ListEndpointsForService(tests.BookstoreV1Service).Return([]endpoint.Endpoint{tests.Endpoint}, nil)
+		mockCatalog.EXPECT().ListEndpointsForService(tests.BookstoreV2Service).Return(nil, nil)
+
+		emptyBackends := getEmptyTrafficSplitBackends(mockCatalog, []*split.TrafficSplit{&tests.TrafficSplit})
+		Expect(emptyBackends).To(Equal(set.NewSet(tests.BookstoreV2Service)))
+
+		// The route to the TrafficSplit only routes to the backend with endpoints, which receives all the traffic
+		routesPerHost := make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
+		for _, backend := range []service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service} {
+			if emptyBackends.Contains(backend) {
+				continue
+			}
+			aggregateRoutesByHost(routesPerHost, tests.BookstoreBuyHTTPRoute, service.WeightedCluster{ClusterName: service.ClusterName(backend.String()), Weight: tests.Weight90}, "bookstore-apex")
+		}
+		weightedClusters := routesPerHost["bookstore-apex"][tests.BookstoreBuyHTTPRoute.PathRegex].WeightedClusters
+		Expect(weightedClusters.ToSlice()).To(ConsistOf(service.WeightedCluster{ClusterName: service.ClusterName(tests.BookstoreV1Service.String()), Weight: tests.Weight90}))
+	})
+
+	It("Returns no backend when none of the backends of the TrafficSplit have endpoints", func() {
+		mockCatalog.EXPECT().ListEndpointsForService(gomock.Any()).Return(nil, nil).Times(2)
+
+		Expect(getEmptyTrafficSplitBackends(mockCatalog, []*split.TrafficSplit{&tests.TrafficSplit}).Cardinality()).To(Equal(0))
+	})
+})
+
 var _ = Describe("AggregateRoutesByDomain", func() {
 	domainRoutesMap := make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
 	weightedClustersMap := set.NewSet()
@@ -166,6 +242,33 @@ var _ = Describe("AggregateRoutesByDomain", func() {
 			Expect(domainRoutesMap["bookstore"][routePolicy.PathRegex].Hostnames).To(Equal(expectedDomains))
 		})
 	})
+
+	Context("Adding a route granted to other sources to an existing route", func() {
+		It("Allows the route to the service accounts of all the sources granted the route", func() {
+			weightedCluster := service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore-1"), Weight: 100}
+			routesPerHost := make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
+			bookbuyerRoute := trafficpolicy.HTTPRouteMatch{
+				PathRegex:              "/helloworld.Greeter/SayHello",
+				Methods:                []string{"POST"},
+				AllowedServiceAccounts: set.NewSet(tests.BookbuyerServiceAccount),
+			}
+			bookstoreRoute := bookbuyerRoute
+			bookstoreRoute.AllowedServiceAccounts = set.NewSet(tests.BookstoreServiceAccount)
+
+			aggregateRoutesByHost(routesPerHost, bookbuyerRoute, weightedCluster, "bookstore.mesh")
+			aggregateRoutesByHost(routesPerHost, bookstoreRoute, weightedCluster, "bookstore.mesh")
+			allowed := routesPerHost["bookstore"][bookbuyerRoute.PathRegex].HTTPRouteMatch.AllowedServiceAccounts
+			Expect(allowed.Equal(set.NewSet(tests.BookbuyerServiceAccount, tests.BookstoreServiceAccount))).To(BeTrue())
+			// The allowed service accounts of the aggregated routes are left unchanged
+			Expect(bookbuyerRoute.AllowedServiceAccounts.Cardinality()).To(Equal(1))
+
+			// A route without allowed service accounts, such as an ingress route, is accessible to all the sources
+			ingressRoute := bookbuyerRoute
+			ingressRoute.AllowedServiceAccounts = nil
+			aggregateRoutesByHost(routesPerHost, ingressRoute, weightedCluster, "bookstore.mesh")
+			Expect(routesPerHost["bookstore"][bookbuyerRoute.PathRegex].HTTPRouteMatch.AllowedServiceAccounts).To(BeNil())
+		})
+	})
 })
 
 var _ = Describe("RDS Response", func() {
@@ -0,0 +1,56 @@
+package rds
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// defaultHTTPPort is the port of the HTTP requests whose host does not name a port
+	defaultHTTPPort = 80
+)
+
+// getServicePortsWithCluster returns the set of ports of the given upstream service whose requests are routed to the
+// cluster of their port
+func getServicePortsWithCluster(cataloger catalog.MeshCataloger, upstreamSvc service.MeshService) map[uint32]bool {
+	portToProtocol, err := cataloger.GetPortToProtocolMappingForService(upstreamSvc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting the ports of upstream service %s, routing its requests to the service cluster", upstreamSvc)
+		return nil
+	}
+
+	portsWithCluster := make(map[uint32]bool)
+	for _, port := range envoy.GetServicePortsWithCluster(portToProtocol) {
+		portsWithCluster[port] = true
+	}
+	return portsWithCluster
+}
+
+// getServicePortRouteTarget returns the virtual host and the weighted cluster the outbound requests to the given
+// hostname of the given upstream service are routed to. The requests to a port of the service with a cluster of its own
+// are routed to the cluster of the port, on a virtual host of their own. The other requests are routed to the given
+// weighted cluster of the service.
+func getServicePortRouteTarget(upstreamSvc service.MeshService, hostname string, weightedCluster service.WeightedCluster, portsWithCluster map[uint32]bool) (string, service.WeightedCluster) {
+	virtualHost := kubernetes.GetServiceFromHostname(hostname)
+
+	port := uint32(defaultHTTPPort)
+	if idx := strings.LastIndex(hostname, ":"); idx != -1 {
+		parsedPort, err := strconv.ParseUint(hostname[idx+1:], 10, 32)
+		if err != nil {
+			return virtualHost, weightedCluster
+		}
+		port = uint32(parsedPort)
+	}
+	if !portsWithCluster[port] {
+		return virtualHost, weightedCluster
+	}
+
+	weightedCluster.ClusterName = service.ClusterName(envoy.GetClusterNameForServicePort(upstreamSvc, port))
+	return fmt.Sprintf("%s:%d", virtualHost, port), weightedCluster
+}
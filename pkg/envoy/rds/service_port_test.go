@@ -0,0 +1,76 @@
+package rds
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var _ = Describe("Service port routes", func() {
+	var (
+		mockCtrl    *gomock.Controller
+		mockCatalog *catalog.MockMeshCataloger
+	)
+
+	upstreamSvc := tests.BookstoreV1Service
+	weightedCluster := service.WeightedCluster{ClusterName: service.ClusterName(upstreamSvc.String()), Weight: 100}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCatalog = catalog.NewMockMeshCataloger(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Routes the requests to a service exposing a single port to the service cluster", func() {
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{8080: "http"}, nil).Times(1)
+		portsWithCluster := getServicePortsWithCluster(mockCatalog, upstreamSvc)
+
+		virtualHost, hostWeightedCluster := getServicePortRouteTarget(upstreamSvc, "bookstore-v1.default:8080", weightedCluster, portsWithCluster)
+		Expect(virtualHost).To(Equal("bookstore-v1"))
+		Expect(hostWeightedCluster).To(Equal(weightedCluster))
+	})
+
+	It("Routes the requests to each HTTP port of a service exposing two HTTP ports to the cluster of the port", func() {
+		mockCatalog.EXPECT().GetPortToProtocolMappingForService(upstreamSvc).Return(map[uint32]string{80: "http", 8080: "http"}, nil).Times(1)
+		portsWithCluster := getServicePortsWithCluster(mockCatalog, upstreamSvc)
+
+		routesPerHost := make(map[string]map[string]trafficpolicy.RouteWeightedClusters)
+		for _, hostname := range []string{"bookstore-v1.default", "bookstore-v1.default:80", "bookstore-v1.default:8080"} {
+			virtualHost, hostWeightedCluster := getServicePortRouteTarget(upstreamSvc, hostname, weightedCluster, portsWithCluster)
+			aggregateRoutesByVirtualHost(routesPerHost, virtualHost, tests.BookstoreBuyHTTPRoute, hostWeightedCluster, hostname)
+		}
+
+		routeConfig := route.NewRouteConfigurationStub(route.OutboundRouteConfigName)
+		route.UpdateRouteConfiguration(routesPerHost, routeConfig, route.OutboundRoute)
+		Expect(routeConfig.VirtualHosts).To(HaveLen(2))
+
+		clustersByDomain := make(map[string]string)
+		for _, virtualHost := range routeConfig.VirtualHosts {
+			Expect(virtualHost.Routes).ToNot(BeEmpty())
+			for _, rt := range virtualHost.Routes {
+				clusters := rt.GetRoute().GetWeightedClusters().GetClusters()
+				Expect(clusters).To(HaveLen(1))
+				for _, domain := range virtualHost.Domains {
+					clustersByDomain[domain] = clusters[0].Name
+				}
+			}
+		}
+
+		// A host without a port names the default HTTP port
+		Expect(clustersByDomain).To(Equal(map[string]string{
+			"bookstore-v1.default":      envoy.GetClusterNameForServicePort(upstreamSvc, 80),
+			"bookstore-v1.default:80":   envoy.GetClusterNameForServicePort(upstreamSvc, 80),
+			"bookstore-v1.default:8080": envoy.GetClusterNameForServicePort(upstreamSvc, 8080),
+		}))
+	})
+})
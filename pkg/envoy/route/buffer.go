@@ -0,0 +1,42 @@
+package route
+
+import (
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_buffer "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyMaxRequestBytes configures the given route to override the mesh wide limit on the size of requests,
+// if the given route match specifies an override. An override of 0 removes the limit for the route.
+func applyMaxRequestBytes(route *xds_route.Route, routeMatch trafficpolicy.HTTPRouteMatch) {
+	if routeMatch.MaxRequestBytes == nil {
+		return
+	}
+
+	bufferPerRoute := &xds_buffer.BufferPerRoute{}
+	if *routeMatch.MaxRequestBytes == 0 {
+		bufferPerRoute.Override = &xds_buffer.BufferPerRoute_Disabled{Disabled: true}
+	} else {
+		bufferPerRoute.Override = &xds_buffer.BufferPerRoute_Buffer{
+			Buffer: &xds_buffer.Buffer{
+				MaxRequestBytes: &wrappers.UInt32Value{Value: *routeMatch.MaxRequestBytes},
+			},
+		}
+	}
+
+	marshalledBufferPerRoute, err := ptypes.MarshalAny(bufferPerRoute)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling BufferPerRoute for route %s, using the mesh wide request size limit", routeMatch.PathRegex)
+		return
+	}
+
+	if route.TypedPerFilterConfig == nil {
+		route.TypedPerFilterConfig = make(map[string]*any.Any)
+	}
+	route.TypedPerFilterConfig[wellknown.Buffer] = marshalledBufferPerRoute
+}
@@ -0,0 +1,74 @@
+package route
+
+import (
+	"testing"
+
+	set "github.com/deckarep/golang-set"
+	xds_buffer "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyMaxRequestBytes(t *testing.T) {
+	assert := tassert.New(t)
+
+	largerLimit := uint32(10 * 1024 * 1024)
+	noLimit := uint32(0)
+
+	testCases := []struct {
+		name               string
+		maxRequestBytes    *uint32
+		expectedPerRoute   bool
+		expectedDisabled   bool
+		expectedRouteLimit uint32
+	}{
+		{
+			name:             "route without an override uses the mesh wide limit",
+			maxRequestBytes:  nil,
+			expectedPerRoute: false,
+		},
+		{
+			name:               "route overriding the mesh wide limit with a larger limit",
+			maxRequestBytes:    &largerLimit,
+			expectedPerRoute:   true,
+			expectedRouteLimit: largerLimit,
+		},
+		{
+			name:             "route removing the mesh wide limit",
+			maxRequestBytes:  &noLimit,
+			expectedPerRoute: true,
+			expectedDisabled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			routeMatch := trafficpolicy.HTTPRouteMatch{
+				PathRegex:       "/upload",
+				Methods:         []string{constants.WildcardHTTPMethod},
+				MaxRequestBytes: tc.maxRequestBytes,
+			}
+			route := buildRoute(routeMatch.PathRegex, constants.WildcardHTTPMethod, nil, set.NewSet(tests.BookstoreV1DefaultWeightedCluster), 100, InboundRoute)
+
+			applyMaxRequestBytes(route, routeMatch)
+
+			marshalledBufferPerRoute, ok := route.TypedPerFilterConfig[wellknown.Buffer]
+			assert.Equal(tc.expectedPerRoute, ok)
+			if !tc.expectedPerRoute {
+				return
+			}
+
+			bufferPerRoute := &xds_buffer.BufferPerRoute{}
+			assert.Nil(ptypes.UnmarshalAny(marshalledBufferPerRoute, bufferPerRoute))
+			assert.Equal(tc.expectedDisabled, bufferPerRoute.GetDisabled())
+			if !tc.expectedDisabled {
+				assert.Equal(tc.expectedRouteLimit, bufferPerRoute.GetBuffer().MaxRequestBytes.Value)
+			}
+		})
+	}
+}
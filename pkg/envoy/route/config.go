@@ -8,6 +8,8 @@ import (
 	set "github.com/deckarep/golang-set"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -88,22 +90,78 @@ func createVirtualHostStub(namePrefix string, host string, domains set.Set) *xds
 func createRoutes(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters, direction Direction) []*xds_route.Route {
 	var routes []*xds_route.Route
 	if direction == OutboundRoute {
+		// A direct response takes precedence over routing the requests it matches to the upstream clusters
+		if directResponse := getDirectResponse(routePolicyWeightedClustersMap); directResponse != nil {
+			routes = append(routes, getDirectResponseRoute(directResponse))
+		}
+
 		// For a source service, configure a wildcard route match (without any headers) with weighted routes to upstream clusters based on traffic split policies
 		weightedClusters := getDistinctWeightedClusters(routePolicyWeightedClustersMap)
 		totalClustersWeight := getTotalWeightForClusters(weightedClusters)
 		emptyHeaders := make(map[string]string)
 		route := getRoute(constants.RegexMatchAll, constants.WildcardHTTPMethod, emptyHeaders, weightedClusters, totalClustersWeight, OutboundRoute)
+		mirrorPolicies := getMirrorPolicies(routePolicyWeightedClustersMap)
+		applyMirrorPolicies(route, mirrorPolicies)
+		hashPolicy := getHashPolicy(routePolicyWeightedClustersMap)
+		applyHashPolicy(route, hashPolicy)
+		retryPolicy := getRetryPolicy(routePolicyWeightedClustersMap)
+		applyRetryPolicy(route, retryPolicy)
+		requestTimeout := getRequestTimeout(routePolicyWeightedClustersMap)
+		applyRequestTimeout(route, requestTimeout)
+		var stickyRoutes []*xds_route.Route
+		stickyCanaryCookie := getStickyCanaryCookie(routePolicyWeightedClustersMap)
+		if stickyCanaryCookie != "" {
+			stickyRoutes = getStickyCanaryRoutes(weightedClusters, stickyCanaryCookie)
+		}
+		if len(stickyRoutes) > 0 {
+			// Clients with a sticky canary cookie are routed to the cluster recorded in the cookie, before weights are applied
+			for _, stickyRoute := range stickyRoutes {
+				applyMirrorPolicies(stickyRoute, mirrorPolicies)
+				applyHashPolicy(stickyRoute, hashPolicy)
+				applyRetryPolicy(stickyRoute, retryPolicy)
+				applyRequestTimeout(stickyRoute, requestTimeout)
+				routes = append(routes, stickyRoute)
+			}
+			applyStickyCanaryCookie(route, stickyCanaryCookie)
+		}
 		routes = append(routes, route)
 		return routes
 	}
 	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
-		// For a given route path, sanitize the methods in case there
-		// is wildcard or if there are duplicates
-		allowedMethods := sanitizeHTTPMethods(routePolicyWeightedClusters.HTTPRouteMatch.Methods)
-		for _, method := range allowedMethods {
-			route := getRoute(routePolicyWeightedClusters.HTTPRouteMatch.PathRegex, method, routePolicyWeightedClusters.HTTPRouteMatch.Headers, routePolicyWeightedClusters.WeightedClusters, 100, direction)
-			routes = append(routes, route)
+		routeMatch := routePolicyWeightedClusters.HTTPRouteMatch
+		var pathRoutes []*xds_route.Route
+		if isGRPCMethodPath(routeMatch.PathRegex) {
+			// A gRPC method path only matches gRPC requests for that method, so that unlisted methods match no route
+			pathRoutes = append(pathRoutes, buildGRPCRoute(routeMatch.PathRegex, routeMatch.Headers, routePolicyWeightedClusters.WeightedClusters))
+		} else {
+			// For a given route path, sanitize the methods in case there
+			// is wildcard or if there are duplicates
+			allowedMethods := sanitizeHTTPMethods(routeMatch.Methods)
+			for _, method := range allowedMethods {
+				route := getRoute(routeMatch.PathRegex, method, routeMatch.Headers, routePolicyWeightedClusters.WeightedClusters, 100, direction)
+				applyPathRewrite(route, routeMatch)
+				pathRoutes = append(pathRoutes, route)
+			}
+		}
+
+		if routeMatch.AllowedServiceAccounts != nil {
+			rbacPerRoute, err := buildRBACPerRoute(routeMatch.AllowedServiceAccounts, routeMatch.AllowedSourceIPRanges)
+			if err != nil {
+				// Skip the routes of this path so that requests matching them are not allowed
+				log.Error().Err(err).Msgf("Error building RBAC policy for route %s", routeMatch.PathRegex)
+				continue
+			}
+			for _, route := range pathRoutes {
+				route.TypedPerFilterConfig = map[string]*any.Any{
+					wellknown.HTTPRoleBasedAccessControl: rbacPerRoute,
+				}
+			}
 		}
+
+		for _, route := range pathRoutes {
+			applyMaxRequestBytes(route, routeMatch)
+		}
+		routes = append(routes, pathRoutes...)
 	}
 	return routes
 }
@@ -130,6 +188,27 @@ func getRoute(pathRegex string, method string, headersMap map[string]string, wei
 	return &route
 }
 
+// applyPathRewrite configures the given route to rewrite the path of requests as specified by the given route match.
+// A route with a prefix rewrite is matched by the path prefix, as the matched prefix is what gets rewritten.
+func applyPathRewrite(route *xds_route.Route, routeMatch trafficpolicy.HTTPRouteMatch) {
+	switch {
+	case routeMatch.PrefixRewrite != "":
+		route.Match.PathSpecifier = &xds_route.RouteMatch_Prefix{
+			Prefix: routeMatch.PathRegex,
+		}
+		route.GetRoute().PrefixRewrite = routeMatch.PrefixRewrite
+
+	case routeMatch.RegexRewrite != nil:
+		route.GetRoute().RegexRewrite = &xds_matcher.RegexMatchAndSubstitute{
+			Pattern: &xds_matcher.RegexMatcher{
+				EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
+				Regex:      routeMatch.RegexRewrite.Pattern,
+			},
+			Substitution: routeMatch.RegexRewrite.Substitution,
+		}
+	}
+}
+
 func getHeadersForRoute(method string, headersMap map[string]string) []*xds_route.HeaderMatcher {
 	var headers []*xds_route.HeaderMatcher
 
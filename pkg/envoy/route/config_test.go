@@ -5,8 +5,11 @@ import (
 	"strings"
 
 	envoy_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	set "github.com/deckarep/golang-set"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	. "github.com/onsi/ginkgo"
@@ -14,6 +17,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
@@ -210,6 +214,160 @@ var _ = Describe("Routes with weighted clusters", func() {
 	})
 })
 
+var _ = Describe("Route path rewrites", func() {
+	Context("Testing applyPathRewrite", func() {
+		weightedClusters := set.NewSetFromSlice([]interface{}{
+			service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore-1"), Weight: 100},
+		})
+
+		It("Matches the route by prefix and rewrites the prefix", func() {
+			routePolicy := trafficpolicy.HTTPRouteMatch{
+				PathRegex:     "/api/v1",
+				Methods:       []string{"GET"},
+				PrefixRewrite: "/",
+			}
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRouteMatch: routePolicy, WeightedClusters: weightedClusters},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			Expect(rt[0].Match.GetSafeRegex()).To(BeNil())
+			Expect(rt[0].Match.GetPrefix()).To(Equal("/api/v1"))
+			Expect(rt[0].GetRoute().PrefixRewrite).To(Equal("/"))
+			Expect(rt[0].GetRoute().RegexRewrite).To(BeNil())
+		})
+
+		It("Rewrites the part of the path matched by the regex rewrite pattern", func() {
+			routePolicy := trafficpolicy.HTTPRouteMatch{
+				PathRegex: "/api/v1/.*",
+				Methods:   []string{"GET"},
+				RegexRewrite: &trafficpolicy.RegexRewrite{
+					Pattern:      "^/api/v1/(.*)$",
+					Substitution: "/\\1",
+				},
+			}
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRouteMatch: routePolicy, WeightedClusters: weightedClusters},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			Expect(rt[0].Match.GetSafeRegex().Regex).To(Equal("/api/v1/.*"))
+			Expect(rt[0].GetRoute().PrefixRewrite).To(BeEmpty())
+			Expect(rt[0].GetRoute().RegexRewrite.GetPattern().Regex).To(Equal("^/api/v1/(.*)$"))
+			Expect(rt[0].GetRoute().RegexRewrite.Substitution).To(Equal("/\\1"))
+		})
+
+		It("Does not rewrite the path by default", func() {
+			routePolicy := trafficpolicy.HTTPRouteMatch{
+				PathRegex: "/api/v1",
+				Methods:   []string{"GET"},
+			}
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRouteMatch: routePolicy, WeightedClusters: weightedClusters},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			Expect(rt[0].Match.GetSafeRegex().Regex).To(Equal("/api/v1"))
+			Expect(rt[0].GetRoute().PrefixRewrite).To(BeEmpty())
+			Expect(rt[0].GetRoute().RegexRewrite).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Routes authorizing gRPC methods", func() {
+	Context("Testing createRoutes with gRPC method paths", func() {
+		weightedClusters := set.NewSetFromSlice([]interface{}{
+			service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore-1"), Weight: 100},
+		})
+
+		It("Only allows each gRPC method to the service accounts granted the method", func() {
+			// Only bookbuyer is granted the SayHello RPC, SayGoodbye is granted to bookstore
+			sayHello := trafficpolicy.HTTPRouteMatch{
+				PathRegex:              "/helloworld.Greeter/SayHello",
+				Methods:                []string{"POST"},
+				AllowedServiceAccounts: set.NewSet(tests.BookbuyerServiceAccount),
+			}
+			sayGoodbye := trafficpolicy.HTTPRouteMatch{
+				PathRegex:              "/helloworld.Greeter/SayGoodbye",
+				Methods:                []string{"POST"},
+				AllowedServiceAccounts: set.NewSet(tests.BookstoreServiceAccount),
+			}
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				sayHello.PathRegex:   {HTTPRouteMatch: sayHello, WeightedClusters: weightedClusters},
+				sayGoodbye.PathRegex: {HTTPRouteMatch: sayGoodbye, WeightedClusters: weightedClusters},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(2))
+
+			allowedPrincipals := make(map[string][]string)
+			for _, route := range rt {
+				// gRPC routes match the exact method path of gRPC requests, so unlisted methods do not match any route
+				Expect(route.Match.GetSafeRegex()).To(BeNil())
+				Expect(route.Match.GetGrpc()).ToNot(BeNil())
+
+				rbacPerRoute := &xds_http_rbac.RBACPerRoute{}
+				err := ptypes.UnmarshalAny(route.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl], rbacPerRoute)
+				Expect(err).ToNot(HaveOccurred())
+				policy := rbacPerRoute.GetRbac().GetRules().GetPolicies()[allowedSourcesPolicyName]
+				Expect(policy).ToNot(BeNil())
+				for _, principal := range policy.Principals[0].GetOrIds().GetIds() {
+					path := route.Match.GetPath()
+					allowedPrincipals[path] = append(allowedPrincipals[path], principal.GetAuthenticated().GetPrincipalName().GetExact())
+				}
+			}
+
+			bookbuyerIdentity := identity.GetKubernetesServiceIdentity(tests.BookbuyerServiceAccount, identity.ClusterLocalTrustDomain).String()
+			bookstoreIdentity := identity.GetKubernetesServiceIdentity(tests.BookstoreServiceAccount, identity.ClusterLocalTrustDomain).String()
+			Expect(allowedPrincipals).To(Equal(map[string][]string{
+				"/helloworld.Greeter/SayHello":   {bookbuyerIdentity},
+				"/helloworld.Greeter/SayGoodbye": {bookstoreIdentity},
+			}))
+		})
+
+		It("Allows the source IP ranges bypassing mTLS to access a route regardless of their identity", func() {
+			routePolicy := trafficpolicy.HTTPRouteMatch{
+				PathRegex:              "/helloworld.Greeter/SayHello",
+				Methods:                []string{"POST"},
+				AllowedServiceAccounts: set.NewSet(tests.BookbuyerServiceAccount),
+				AllowedSourceIPRanges:  []string{"10.0.0.0/8"},
+			}
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRouteMatch: routePolicy, WeightedClusters: weightedClusters},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(1))
+
+			rbacPerRoute := &xds_http_rbac.RBACPerRoute{}
+			Expect(ptypes.UnmarshalAny(rt[0].TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl], rbacPerRoute)).To(Succeed())
+			policies := rbacPerRoute.GetRbac().GetRules().GetPolicies()
+			Expect(policies).To(HaveKey(allowedSourcesPolicyName))
+			sourceIPRangeIds := policies[allowedSourceIPRangesPolicyName].GetPrincipals()[0].GetOrIds().GetIds()
+			Expect(sourceIPRangeIds).To(HaveLen(1))
+			Expect(sourceIPRangeIds[0].GetDirectRemoteIp().GetAddressPrefix()).To(Equal("10.0.0.0"))
+		})
+
+		It("Allows all sources to access a route without allowed service accounts", func() {
+			routePolicy := trafficpolicy.HTTPRouteMatch{
+				PathRegex: "/helloworld.Greeter/SayHello",
+				Methods:   []string{"POST"},
+			}
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routePolicy.PathRegex: {HTTPRouteMatch: routePolicy, WeightedClusters: weightedClusters},
+			}
+
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			Expect(len(rt)).To(Equal(1))
+			Expect(rt[0].Match.GetPath()).To(Equal("/helloworld.Greeter/SayHello"))
+			Expect(rt[0].TypedPerFilterConfig).ToNot(HaveKey(wellknown.HTTPRoleBasedAccessControl))
+		})
+	})
+})
+
 var _ = Describe("Route Configuration", func() {
 	Context("Testing creation of RouteConfiguration object", func() {
 		It("Returns outbound route configuration", func() {
@@ -0,0 +1,46 @@
+package route
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getDirectResponse returns the direct response of the given routes, or nil if none of them responds directly
+func getDirectResponse(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) *trafficpolicy.DirectResponse {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if routePolicyWeightedClusters.HTTPRouteMatch.DirectResponse != nil {
+			return routePolicyWeightedClusters.HTTPRouteMatch.DirectResponse
+		}
+	}
+	return nil
+}
+
+// getDirectResponseRoute returns a route responding to the requests whose path matches the path regex of the given
+// direct response with its status code and body, instead of routing them to a cluster
+func getDirectResponseRoute(directResponse *trafficpolicy.DirectResponse) *xds_route.Route {
+	action := &xds_route.DirectResponseAction{
+		Status: directResponse.StatusCode,
+	}
+	if directResponse.Body != "" {
+		action.Body = &xds_core.DataSource{
+			Specifier: &xds_core.DataSource_InlineString{InlineString: directResponse.Body},
+		}
+	}
+
+	return &xds_route.Route{
+		Match: &xds_route.RouteMatch{
+			PathSpecifier: &xds_route.RouteMatch_SafeRegex{
+				SafeRegex: &xds_matcher.RegexMatcher{
+					EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
+					Regex:      directResponse.PathRegex,
+				},
+			},
+		},
+		Action: &xds_route.Route_DirectResponse{
+			DirectResponse: action,
+		},
+	}
+}
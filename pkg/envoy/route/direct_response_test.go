@@ -0,0 +1,73 @@
+package route
+
+import (
+	"testing"
+
+	set "github.com/deckarep/golang-set"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestDirectResponseRoutes(t *testing.T) {
+	testCases := []struct {
+		name               string
+		directResponse     *trafficpolicy.DirectResponse
+		expectedNumRoutes  int
+		expectedInlineBody string
+	}{
+		{
+			name:              "outbound routes without a direct response",
+			directResponse:    nil,
+			expectedNumRoutes: 1,
+		},
+		{
+			name:               "outbound routes with a direct response with an inline body",
+			directResponse:     &trafficpolicy.DirectResponse{StatusCode: 503, Body: "down for maintenance", PathRegex: constants.RegexMatchAll},
+			expectedNumRoutes:  2,
+			expectedInlineBody: "down for maintenance",
+		},
+		{
+			name:              "outbound routes with a direct response without a body restricted to a path",
+			directResponse:    &trafficpolicy.DirectResponse{StatusCode: 503, PathRegex: "/checkout.*"},
+			expectedNumRoutes: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			routeMatch := tests.BookstoreBuyHTTPRoute
+			routeMatch.DirectResponse = tc.directResponse
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routeMatch.PathRegex: {
+					HTTPRouteMatch:   routeMatch,
+					WeightedClusters: set.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+			}
+
+			routes := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			assert.Len(routes, tc.expectedNumRoutes)
+
+			// Requests not matching the direct response are routed to the upstream clusters
+			routedRoute := routes[len(routes)-1]
+			assert.Equal(constants.RegexMatchAll, routedRoute.GetMatch().GetSafeRegex().GetRegex())
+			assert.NotNil(routedRoute.GetRoute().GetWeightedClusters())
+
+			if tc.directResponse == nil {
+				return
+			}
+
+			// The direct response route precedes the routed route
+			directResponseRoute := routes[0]
+			assert.Nil(directResponseRoute.GetRoute())
+			assert.Equal(tc.directResponse.PathRegex, directResponseRoute.GetMatch().GetSafeRegex().GetRegex())
+			assert.Equal(tc.directResponse.StatusCode, directResponseRoute.GetDirectResponse().GetStatus())
+			assert.Equal(tc.expectedInlineBody, directResponseRoute.GetDirectResponse().GetBody().GetInlineString())
+			assert.Nil(directResponseRoute.Validate())
+		})
+	}
+}
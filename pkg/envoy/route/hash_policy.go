@@ -0,0 +1,51 @@
+package route
+
+import (
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyHashPolicy configures the given route to hash the request attribute of the given hash policy, used by the
+// consistent hashing clusters the route routes to. Requests without the hashed header or cookie fall back to being
+// hashed by their source IP.
+func applyHashPolicy(route *xds_route.Route, hashPolicy *trafficpolicy.HashPolicy) {
+	if hashPolicy == nil {
+		return
+	}
+
+	var hashPolicies []*xds_route.RouteAction_HashPolicy
+	switch {
+	case hashPolicy.Header != "":
+		hashPolicies = append(hashPolicies, &xds_route.RouteAction_HashPolicy{
+			PolicySpecifier: &xds_route.RouteAction_HashPolicy_Header_{
+				Header: &xds_route.RouteAction_HashPolicy_Header{HeaderName: hashPolicy.Header},
+			},
+			Terminal: true,
+		})
+	case hashPolicy.Cookie != "":
+		hashPolicies = append(hashPolicies, &xds_route.RouteAction_HashPolicy{
+			PolicySpecifier: &xds_route.RouteAction_HashPolicy_Cookie_{
+				Cookie: &xds_route.RouteAction_HashPolicy_Cookie{Name: hashPolicy.Cookie},
+			},
+			Terminal: true,
+		})
+	}
+	hashPolicies = append(hashPolicies, &xds_route.RouteAction_HashPolicy{
+		PolicySpecifier: &xds_route.RouteAction_HashPolicy_ConnectionProperties_{
+			ConnectionProperties: &xds_route.RouteAction_HashPolicy_ConnectionProperties{SourceIp: true},
+		},
+	})
+
+	route.GetRoute().HashPolicy = hashPolicies
+}
+
+// getHashPolicy returns the hash policy of the given routes, or nil if none of them load balances by consistent hashing
+func getHashPolicy(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) *trafficpolicy.HashPolicy {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if routePolicyWeightedClusters.HTTPRouteMatch.HashPolicy != nil {
+			return routePolicyWeightedClusters.HTTPRouteMatch.HashPolicy
+		}
+	}
+	return nil
+}
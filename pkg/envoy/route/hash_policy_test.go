@@ -0,0 +1,82 @@
+package route
+
+import (
+	"testing"
+
+	set "github.com/deckarep/golang-set"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyHashPolicy(t *testing.T) {
+	testCases := []struct {
+		name               string
+		hashPolicy         *trafficpolicy.HashPolicy
+		expectedHeader     string
+		expectedCookie     string
+		expectedNumHashing int
+	}{
+		{
+			name:               "outbound route without a hash policy",
+			hashPolicy:         nil,
+			expectedNumHashing: 0,
+		},
+		{
+			name:               "outbound route hashing a header, falling back to the source IP",
+			hashPolicy:         &trafficpolicy.HashPolicy{Header: "x-user-id"},
+			expectedHeader:     "x-user-id",
+			expectedNumHashing: 2,
+		},
+		{
+			name:               "outbound route hashing a cookie, falling back to the source IP",
+			hashPolicy:         &trafficpolicy.HashPolicy{Cookie: "session"},
+			expectedCookie:     "session",
+			expectedNumHashing: 2,
+		},
+		{
+			name:               "outbound route hashing the source IP",
+			hashPolicy:         &trafficpolicy.HashPolicy{},
+			expectedNumHashing: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			routeMatch := tests.BookstoreBuyHTTPRoute
+			routeMatch.HashPolicy = tc.hashPolicy
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routeMatch.PathRegex: {
+					HTTPRouteMatch:   routeMatch,
+					WeightedClusters: set.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+			}
+
+			routes := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			assert.Len(routes, 1)
+
+			hashPolicies := routes[0].GetRoute().GetHashPolicy()
+			assert.Len(hashPolicies, tc.expectedNumHashing)
+			if tc.expectedNumHashing == 0 {
+				return
+			}
+
+			// The header or cookie is hashed first, and stops the hashing when present on the request
+			if tc.expectedHeader != "" {
+				assert.Equal(tc.expectedHeader, hashPolicies[0].GetHeader().GetHeaderName())
+				assert.True(hashPolicies[0].Terminal)
+			}
+			if tc.expectedCookie != "" {
+				assert.Equal(tc.expectedCookie, hashPolicies[0].GetCookie().GetName())
+				assert.Nil(hashPolicies[0].GetCookie().GetTtl())
+				assert.True(hashPolicies[0].Terminal)
+			}
+
+			// Requests are hashed by their source IP otherwise
+			assert.True(hashPolicies[len(hashPolicies)-1].GetConnectionProperties().GetSourceIp())
+		})
+	}
+}
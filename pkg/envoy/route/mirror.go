@@ -0,0 +1,45 @@
+package route
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// mirrorPercentageDenominatorFactor is the factor converting a percentage to a numerator over a denominator of a million
+const mirrorPercentageDenominatorFactor = 10000
+
+// applyMirrorPolicies configures the given route to mirror a percentage of its requests to the cluster of the shadow
+// service of each of the given mirror policies, each sampling the requests it mirrors independently. Envoy does not
+// wait for the responses of mirrored requests and ignores them.
+func applyMirrorPolicies(route *xds_route.Route, mirrorPolicies []trafficpolicy.MirrorPolicy) {
+	if len(mirrorPolicies) == 0 {
+		return
+	}
+
+	var requestMirrorPolicies []*xds_route.RouteAction_RequestMirrorPolicy
+	for _, mirrorPolicy := range mirrorPolicies {
+		requestMirrorPolicies = append(requestMirrorPolicies, &xds_route.RouteAction_RequestMirrorPolicy{
+			Cluster: mirrorPolicy.Service.String(),
+			RuntimeFraction: &xds_core.RuntimeFractionalPercent{
+				DefaultValue: &xds_type.FractionalPercent{
+					Numerator:   uint32(mirrorPolicy.Percentage * mirrorPercentageDenominatorFactor),
+					Denominator: xds_type.FractionalPercent_MILLION,
+				},
+			},
+		})
+	}
+	route.GetRoute().RequestMirrorPolicies = requestMirrorPolicies
+}
+
+// getMirrorPolicies returns the mirror policies of the given routes, or nil if none of them mirrors requests
+func getMirrorPolicies(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) []trafficpolicy.MirrorPolicy {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if len(routePolicyWeightedClusters.HTTPRouteMatch.MirrorPolicies) > 0 {
+			return routePolicyWeightedClusters.HTTPRouteMatch.MirrorPolicies
+		}
+	}
+	return nil
+}
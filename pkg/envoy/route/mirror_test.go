@@ -0,0 +1,80 @@
+package route
+
+import (
+	"testing"
+
+	set "github.com/deckarep/golang-set"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyMirrorPolicies(t *testing.T) {
+	type expectedMirror struct {
+		cluster   string
+		numerator uint32
+	}
+
+	testCases := []struct {
+		name            string
+		mirrorPolicies  []trafficpolicy.MirrorPolicy
+		expectedMirrors []expectedMirror
+	}{
+		{
+			name:            "outbound route without a mirror policy",
+			mirrorPolicies:  nil,
+			expectedMirrors: nil,
+		},
+		{
+			name:            "outbound route mirroring all requests",
+			mirrorPolicies:  []trafficpolicy.MirrorPolicy{{Service: tests.BookstoreV2Service, Percentage: 100}},
+			expectedMirrors: []expectedMirror{{cluster: tests.BookstoreV2Service.String(), numerator: 1000000}},
+		},
+		{
+			name:            "outbound route mirroring a fraction of a percent of requests",
+			mirrorPolicies:  []trafficpolicy.MirrorPolicy{{Service: tests.BookstoreV2Service, Percentage: 0.5}},
+			expectedMirrors: []expectedMirror{{cluster: tests.BookstoreV2Service.String(), numerator: 5000}},
+		},
+		{
+			name: "outbound route mirroring requests to two shadow services at different sampling rates",
+			mirrorPolicies: []trafficpolicy.MirrorPolicy{
+				{Service: tests.BookstoreV2Service, Percentage: 100},
+				{Service: tests.BookwarehouseService, Percentage: 1},
+			},
+			expectedMirrors: []expectedMirror{
+				{cluster: tests.BookstoreV2Service.String(), numerator: 1000000},
+				{cluster: tests.BookwarehouseService.String(), numerator: 10000},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			routeMatch := tests.BookstoreBuyHTTPRoute
+			routeMatch.MirrorPolicies = tc.mirrorPolicies
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routeMatch.PathRegex: {
+					HTTPRouteMatch:   routeMatch,
+					WeightedClusters: set.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+			}
+
+			routes := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			assert.Len(routes, 1)
+			assert.Equal(constants.RegexMatchAll, routes[0].GetMatch().GetSafeRegex().GetRegex())
+
+			mirrorPolicies := routes[0].GetRoute().GetRequestMirrorPolicies()
+			assert.Len(mirrorPolicies, len(tc.expectedMirrors))
+			for i, expected := range tc.expectedMirrors {
+				assert.Equal(expected.cluster, mirrorPolicies[i].Cluster)
+				assert.Equal(expected.numerator, mirrorPolicies[i].RuntimeFraction.DefaultValue.Numerator)
+				assert.Equal(xds_type.FractionalPercent_MILLION, mirrorPolicies[i].RuntimeFraction.DefaultValue.Denominator)
+			}
+		})
+	}
+}
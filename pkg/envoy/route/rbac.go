@@ -0,0 +1,94 @@
+package route
+
+import (
+	"regexp"
+	"sort"
+
+	set "github.com/deckarep/golang-set"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/rbac"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// allowedSourcesPolicyName is the name of the per route RBAC policy allowing the sources of a route
+	allowedSourcesPolicyName = "allowed-sources"
+
+	// allowedSourceIPRangesPolicyName is the name of the per route RBAC policy allowing the source IP ranges of a route
+	allowedSourceIPRangesPolicyName = "allowed-source-ip-ranges"
+)
+
+// grpcMethodPathRegex matches the path of a gRPC method, of the form /<package>.<Service>/<Method>
+var grpcMethodPathRegex = regexp.MustCompile(`^/[A-Za-z_]\w*(\.[A-Za-z_]\w*)+/[A-Za-z_]\w*$`)
+
+// isGRPCMethodPath returns true if the given route path refers to a single gRPC method
+func isGRPCMethodPath(path string) bool {
+	return grpcMethodPathRegex.MatchString(path)
+}
+
+// buildGRPCRoute returns an inbound route that only matches gRPC requests for the given gRPC method path
+func buildGRPCRoute(methodPath string, headersMap map[string]string, weightedClusters set.Set) *xds_route.Route {
+	route := buildRoute(methodPath, constants.WildcardHTTPMethod, headersMap, weightedClusters, 100, InboundRoute)
+	route.Match.PathSpecifier = &xds_route.RouteMatch_Path{Path: methodPath}
+	route.Match.Grpc = &xds_route.RouteMatch_GrpcRouteMatchOptions{}
+	return route
+}
+
+// buildRBACPerRoute returns the marshalled per route RBAC config allowing only the given service accounts, and the given
+// source IP ranges regardless of the identity of the sources, to access a route
+func buildRBACPerRoute(allowedServiceAccounts set.Set, allowedSourceIPRanges []string) (*any.Any, error) {
+	var principals []string
+	for svcAccountIntf := range allowedServiceAccounts.Iter() {
+		svcAccount := svcAccountIntf.(service.K8sServiceAccount)
+		principals = append(principals, identity.GetKubernetesServiceIdentity(svcAccount, identity.ClusterLocalTrustDomain).String())
+	}
+	sort.Strings(principals)
+
+	var principalRules []rbac.Rule
+	for _, principal := range principals {
+		principalRules = append(principalRules, rbac.Rule{Attribute: rbac.DownstreamAuthPrincipal, Value: principal})
+	}
+
+	policy := &rbac.Policy{
+		Principals: []rbac.RulesList{{OrRules: principalRules}},
+	}
+	rbacPolicy, err := policy.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	policies := map[string]*xds_rbac.Policy{allowedSourcesPolicyName: rbacPolicy}
+
+	if len(allowedSourceIPRanges) > 0 {
+		var sourceIPRangeRules []rbac.Rule
+		for _, cidr := range allowedSourceIPRanges {
+			sourceIPRangeRules = append(sourceIPRangeRules, rbac.Rule{Attribute: rbac.DownstreamSourceIPRange, Value: cidr})
+		}
+		sourceIPRangePolicy := &rbac.Policy{
+			Principals: []rbac.RulesList{{OrRules: sourceIPRangeRules}},
+		}
+		rbacSourceIPRangePolicy, err := sourceIPRangePolicy.Generate()
+		if err != nil {
+			return nil, err
+		}
+		policies[allowedSourceIPRangesPolicyName] = rbacSourceIPRangePolicy
+	}
+
+	rbacPerRoute := &xds_http_rbac.RBACPerRoute{
+		Rbac: &xds_http_rbac.RBAC{
+			Rules: &xds_rbac.RBAC{
+				Action:   xds_rbac.RBAC_ALLOW, // Allows the request if and only if it is from an allowed source
+				Policies: policies,
+			},
+		},
+	}
+
+	return ptypes.MarshalAny(rbacPerRoute)
+}
@@ -0,0 +1,98 @@
+package route
+
+import (
+	"testing"
+
+	set "github.com/deckarep/golang-set"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestIsGRPCMethodPath(t *testing.T) {
+	assert := tassert.New(t)
+
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"/helloworld.Greeter/SayHello", true},
+		{"/grpc.health.v1.Health/Check", true},
+		{"/books/buy", false},
+		{"/helloworld.Greeter/.*", false},
+		{".*", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(tc.expected, isGRPCMethodPath(tc.path))
+		})
+	}
+}
+
+func TestBuildInboundGRPCRoutes(t *testing.T) {
+	assert := tassert.New(t)
+
+	testWeightedCluster := service.WeightedCluster{
+		ClusterName: "testCluster",
+		Weight:      100,
+	}
+	// Only bookbuyer is granted the SayHello RPC, SayGoodbye is granted to bookstore
+	input := []*trafficpolicy.Rule{
+		{
+			Route: trafficpolicy.RouteWeightedClusters{
+				HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+					PathRegex: "/helloworld.Greeter/SayHello",
+					Methods:   []string{"POST"},
+				},
+				WeightedClusters: set.NewSet(testWeightedCluster),
+			},
+			AllowedServiceAccounts: set.NewSet(tests.BookbuyerServiceAccount),
+		},
+		{
+			Route: trafficpolicy.RouteWeightedClusters{
+				HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+					PathRegex: "/helloworld.Greeter/SayGoodbye",
+					Methods:   []string{"POST"},
+				},
+				WeightedClusters: set.NewSet(testWeightedCluster),
+			},
+			AllowedServiceAccounts: set.NewSet(tests.BookstoreServiceAccount),
+		},
+	}
+
+	actual := buildInboundRoutes(input)
+	assert.Len(actual, 2)
+
+	allowedPrincipals := make(map[string][]string)
+	for _, route := range actual {
+		// gRPC routes match the exact method path of gRPC requests, so unlisted methods do not match any route
+		assert.Nil(route.GetMatch().GetSafeRegex())
+		assert.NotNil(route.GetMatch().GetGrpc())
+		assert.Equal("testCluster-local", route.GetRoute().GetWeightedClusters().Clusters[0].Name)
+
+		rbacPerRoute := &xds_http_rbac.RBACPerRoute{}
+		err := ptypes.UnmarshalAny(route.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl], rbacPerRoute)
+		assert.Nil(err)
+
+		policy := rbacPerRoute.GetRbac().GetRules().GetPolicies()[allowedSourcesPolicyName]
+		assert.NotNil(policy)
+		for _, principal := range policy.Principals[0].GetOrIds().GetIds() {
+			path := route.GetMatch().GetPath()
+			allowedPrincipals[path] = append(allowedPrincipals[path], principal.GetAuthenticated().GetPrincipalName().GetExact())
+		}
+	}
+
+	bookbuyerIdentity := identity.GetKubernetesServiceIdentity(tests.BookbuyerServiceAccount, identity.ClusterLocalTrustDomain).String()
+	bookstoreIdentity := identity.GetKubernetesServiceIdentity(tests.BookstoreServiceAccount, identity.ClusterLocalTrustDomain).String()
+	assert.Equal(map[string][]string{
+		"/helloworld.Greeter/SayHello":   {bookbuyerIdentity},
+		"/helloworld.Greeter/SayGoodbye": {bookstoreIdentity},
+	}, allowedPrincipals)
+}
@@ -0,0 +1,29 @@
+package route
+
+import (
+	"time"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyRequestTimeout configures the given route to time out requests whose response is not received within the given
+// timeout. The Envoy default applies when the timeout is 0.
+func applyRequestTimeout(route *xds_route.Route, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	route.GetRoute().Timeout = ptypes.DurationProto(timeout)
+}
+
+// getRequestTimeout returns the request timeout of the given routes, or 0 if none of them overrides the Envoy default
+func getRequestTimeout(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) time.Duration {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if routePolicyWeightedClusters.HTTPRouteMatch.Timeout > 0 {
+			return routePolicyWeightedClusters.HTTPRouteMatch.Timeout
+		}
+	}
+	return 0
+}
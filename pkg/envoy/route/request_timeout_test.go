@@ -0,0 +1,54 @@
+package route
+
+import (
+	"testing"
+	"time"
+
+	set "github.com/deckarep/golang-set"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyRequestTimeout(t *testing.T) {
+	assert := tassert.New(t)
+
+	getRoutes := func(weightedCluster service.WeightedCluster, timeout time.Duration) map[string]trafficpolicy.RouteWeightedClusters {
+		routeMatch := tests.BookstoreBuyHTTPRoute
+		routeMatch.Timeout = timeout
+		return map[string]trafficpolicy.RouteWeightedClusters{
+			routeMatch.PathRegex: {
+				HTTPRouteMatch:   routeMatch,
+				WeightedClusters: set.NewSet(weightedCluster),
+				Hostnames:        set.NewSet("bookstore"),
+			},
+		}
+	}
+
+	// The slow partner service overrides the mesh-wide timeout the other service keeps
+	domainRoutesMap := map[string]map[string]trafficpolicy.RouteWeightedClusters{
+		"bookstore-v1":   getRoutes(tests.BookstoreV1DefaultWeightedCluster, 2*time.Minute),
+		"bookstore-v2":   getRoutes(tests.BookstoreV2DefaultWeightedCluster, 10*time.Second),
+		"bookstore-apex": getRoutes(tests.BookstoreApexDefaultWeightedCluster, 0),
+	}
+	routeConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
+	UpdateRouteConfiguration(domainRoutesMap, routeConfig, OutboundRoute)
+
+	timeouts := make(map[string]time.Duration)
+	for _, virtualHost := range routeConfig.VirtualHosts {
+		assert.Len(virtualHost.Routes, 1)
+		if timeout := virtualHost.Routes[0].GetRoute().GetTimeout(); timeout != nil {
+			duration, err := ptypes.Duration(timeout)
+			assert.Nil(err)
+			timeouts[virtualHost.Name] = duration
+		}
+	}
+
+	assert.Equal(map[string]time.Duration{
+		"outbound_virtualHost|bookstore-v1": 2 * time.Minute,
+		"outbound_virtualHost|bookstore-v2": 10 * time.Second,
+	}, timeouts)
+}
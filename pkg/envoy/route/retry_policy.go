@@ -0,0 +1,36 @@
+package route
+
+import (
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// applyRetryPolicy configures the given route to retry requests on the conditions of the given retry policy, bounding
+// each attempt by the per-try timeout of the policy if set
+func applyRetryPolicy(route *xds_route.Route, retryPolicy *trafficpolicy.RetryPolicy) {
+	if retryPolicy == nil {
+		return
+	}
+
+	policy := &xds_route.RetryPolicy{
+		RetryOn:              retryPolicy.RetryOn,
+		RetriableStatusCodes: retryPolicy.RetriableStatusCodes,
+	}
+	if retryPolicy.PerTryTimeout > 0 {
+		policy.PerTryTimeout = ptypes.DurationProto(retryPolicy.PerTryTimeout)
+	}
+
+	route.GetRoute().RetryPolicy = policy
+}
+
+// getRetryPolicy returns the retry policy of the given routes, or nil if none of them retries requests
+func getRetryPolicy(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) *trafficpolicy.RetryPolicy {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if routePolicyWeightedClusters.HTTPRouteMatch.RetryPolicy != nil {
+			return routePolicyWeightedClusters.HTTPRouteMatch.RetryPolicy
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package route
+
+import (
+	"testing"
+	"time"
+
+	set "github.com/deckarep/golang-set"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		name                         string
+		retryPolicy                  *trafficpolicy.RetryPolicy
+		expectedRetryOn              string
+		expectedPerTryTimeout        time.Duration
+		expectedRetriableStatusCodes []uint32
+	}{
+		{
+			name:        "outbound route without a retry policy",
+			retryPolicy: nil,
+		},
+		{
+			name:            "outbound route retrying on retry conditions",
+			retryPolicy:     &trafficpolicy.RetryPolicy{RetryOn: "connect-failure,reset"},
+			expectedRetryOn: "connect-failure,reset",
+		},
+		{
+			name: "outbound route retrying on retriable status codes with a per-try timeout",
+			retryPolicy: &trafficpolicy.RetryPolicy{
+				RetryOn:              "reset,retriable-status-codes",
+				PerTryTimeout:        250 * time.Millisecond,
+				RetriableStatusCodes: []uint32{502, 503},
+			},
+			expectedRetryOn:              "reset,retriable-status-codes",
+			expectedPerTryTimeout:        250 * time.Millisecond,
+			expectedRetriableStatusCodes: []uint32{502, 503},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			routeMatch := tests.BookstoreBuyHTTPRoute
+			routeMatch.RetryPolicy = tc.retryPolicy
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routeMatch.PathRegex: {
+					HTTPRouteMatch:   routeMatch,
+					WeightedClusters: set.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+			}
+
+			routes := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			assert.Len(routes, 1)
+
+			retryPolicy := routes[0].GetRoute().GetRetryPolicy()
+			if tc.retryPolicy == nil {
+				assert.Nil(retryPolicy)
+				return
+			}
+
+			assert.Equal(tc.expectedRetryOn, retryPolicy.RetryOn)
+			assert.Equal(tc.expectedRetriableStatusCodes, retryPolicy.RetriableStatusCodes)
+			if tc.expectedPerTryTimeout == 0 {
+				assert.Nil(retryPolicy.PerTryTimeout)
+			} else {
+				assert.Equal(ptypes.DurationProto(tc.expectedPerTryTimeout), retryPolicy.PerTryTimeout)
+			}
+			assert.Nil(retryPolicy.Validate())
+		})
+	}
+}
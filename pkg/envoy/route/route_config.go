@@ -7,6 +7,8 @@ import (
 	set "github.com/deckarep/golang-set"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -52,18 +54,45 @@ func buildVirtualHostStub(namePrefix string, host string, domains []string) *xds
 	return &virtualHost
 }
 
-// buildInboundRoutes takes a route information from the given inbound traffic policy and returns a list of xds routes
-//	TODO: Currently, the information about which identity can access a particular route is not used but is passed in
+// buildInboundRoutes takes a route information from the given inbound traffic policy and returns a list of xds routes.
+// Each route only allows the service accounts allowed by its rule, and a rule matching a gRPC method path
+// results in a route matching only gRPC requests for that method.
 func buildInboundRoutes(rules []*trafficpolicy.Rule) []*xds_route.Route {
 	var routes []*xds_route.Route
 	for _, rule := range rules {
-		// For a given route path, sanitize the methods in case there
-		// is wildcard or if there are duplicates
-		allowedMethods := sanitizeHTTPMethods(rule.Route.HTTPRouteMatch.Methods)
-		for _, method := range allowedMethods {
-			route := buildRoute(rule.Route.HTTPRouteMatch.PathRegex, method, rule.Route.HTTPRouteMatch.Headers, rule.Route.WeightedClusters, 100, InboundRoute)
-			routes = append(routes, route)
+		var ruleRoutes []*xds_route.Route
+		if isGRPCMethodPath(rule.Route.HTTPRouteMatch.PathRegex) {
+			ruleRoutes = append(ruleRoutes, buildGRPCRoute(rule.Route.HTTPRouteMatch.PathRegex, rule.Route.HTTPRouteMatch.Headers, rule.Route.WeightedClusters))
+		} else {
+			// For a given route path, sanitize the methods in case there
+			// is wildcard or if there are duplicates
+			allowedMethods := sanitizeHTTPMethods(rule.Route.HTTPRouteMatch.Methods)
+			for _, method := range allowedMethods {
+				route := buildRoute(rule.Route.HTTPRouteMatch.PathRegex, method, rule.Route.HTTPRouteMatch.Headers, rule.Route.WeightedClusters, 100, InboundRoute)
+				applyPathRewrite(route, rule.Route.HTTPRouteMatch)
+				ruleRoutes = append(ruleRoutes, route)
+			}
 		}
+
+		if rule.AllowedServiceAccounts != nil && rule.AllowedServiceAccounts.Cardinality() > 0 {
+			rbacPerRoute, err := buildRBACPerRoute(rule.AllowedServiceAccounts, nil)
+			if err != nil {
+				// Skip the routes of this rule so that requests matching them are not allowed
+				log.Error().Err(err).Msgf("Error building RBAC policy for route %s", rule.Route.HTTPRouteMatch.PathRegex)
+				continue
+			}
+			for _, route := range ruleRoutes {
+				route.TypedPerFilterConfig = map[string]*any.Any{
+					wellknown.HTTPRoleBasedAccessControl: rbacPerRoute,
+				}
+			}
+		}
+
+		for _, route := range ruleRoutes {
+			applyMaxRequestBytes(route, rule.Route.HTTPRouteMatch)
+		}
+
+		routes = append(routes, ruleRoutes...)
 	}
 	return routes
 }
@@ -0,0 +1,94 @@
+package route
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	set "github.com/deckarep/golang-set"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// stickyCanaryCookiePrefix is the prefix of the name of the cookie recording the cluster a client was routed to by
+	// the weights of a TrafficSplit
+	stickyCanaryCookiePrefix = "osm-canary"
+
+	// cookieHeaderKey is the key of the header holding the cookies of a request
+	cookieHeaderKey = "cookie"
+
+	// setCookieHeaderKey is the key of the header setting a cookie on the client
+	setCookieHeaderKey = "set-cookie"
+)
+
+// GetStickyCanaryCookieName returns the name of the cookie recording the cluster a client was routed to by the weights
+// of the TrafficSplit with the given namespace and root service, so that the clients of several sticky TrafficSplits
+// stick to the backends of each
+func GetStickyCanaryCookieName(namespace, rootService string) string {
+	return fmt.Sprintf("%s.%s.%s", stickyCanaryCookiePrefix, namespace, rootService)
+}
+
+// applyStickyCanaryCookie configures the given weighted route to set the cookie with the given name on the client
+// recording the cluster the client was routed to by weight, so that the subsequent requests of the client are routed
+// to the same cluster
+func applyStickyCanaryCookie(route *xds_route.Route, cookieName string) {
+	for _, cluster := range route.GetRoute().GetWeightedClusters().GetClusters() {
+		if cluster.GetWeight().GetValue() == 0 {
+			continue
+		}
+		cluster.ResponseHeadersToAdd = append(cluster.ResponseHeadersToAdd, &xds_core.HeaderValueOption{
+			Header: &xds_core.HeaderValue{
+				Key:   setCookieHeaderKey,
+				Value: fmt.Sprintf("%s=%s; Path=/", cookieName, cluster.Name),
+			},
+			Append: &wrappers.BoolValue{Value: true},
+		})
+	}
+}
+
+// getStickyCanaryRoutes returns a route per given weighted cluster with a non-zero weight, routing the requests with the
+// sticky canary cookie with the given name recording the cluster to that cluster. No routes are returned unless clients
+// can be routed to more than one cluster by weight.
+func getStickyCanaryRoutes(weightedClusters set.Set, cookieName string) []*xds_route.Route {
+	var clusters []service.WeightedCluster
+	for clusterInterface := range weightedClusters.Iter() {
+		cluster := clusterInterface.(service.WeightedCluster)
+		if cluster.Weight <= 0 {
+			// A client is never routed to a cluster without weight, and a route with a total weight of 0 is invalid
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+	if len(clusters) < 2 {
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ClusterName < clusters[j].ClusterName
+	})
+
+	var routes []*xds_route.Route
+	for _, cluster := range clusters {
+		cookieHeader := map[string]string{
+			cookieHeaderKey: fmt.Sprintf("(.*;\\s*)?%s=%s(;.*)?", regexp.QuoteMeta(cookieName), regexp.QuoteMeta(cluster.ClusterName.String())),
+		}
+		routes = append(routes, getRoute(constants.RegexMatchAll, constants.WildcardHTTPMethod, cookieHeader, set.NewSet(cluster), cluster.Weight, OutboundRoute))
+	}
+	return routes
+}
+
+// getStickyCanaryCookie returns the name of the cookie recording the cluster a client was first routed to by the given
+// routes, or an empty string if none of the routes keeps routing a client to that cluster
+func getStickyCanaryCookie(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters) string {
+	for _, routePolicyWeightedClusters := range routePolicyWeightedClustersMap {
+		if cookieName := routePolicyWeightedClusters.HTTPRouteMatch.StickyCanaryCookie; cookieName != "" {
+			return cookieName
+		}
+	}
+	return ""
+}
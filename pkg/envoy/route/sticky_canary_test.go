@@ -0,0 +1,176 @@
+package route
+
+import (
+	"testing"
+
+	set "github.com/deckarep/golang-set"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestCreateRoutesWithStickyCanary(t *testing.T) {
+	v1Cluster := service.WeightedCluster{ClusterName: "default/bookstore-v1", Weight: 90}
+	v2Cluster := service.WeightedCluster{ClusterName: "default/bookstore-v2", Weight: 10}
+	v3Cluster := service.WeightedCluster{ClusterName: "default/bookstore-v3", Weight: 0}
+
+	testCases := []struct {
+		name                 string
+		stickyCanaryCookie   string
+		weightedClusters     set.Set
+		expectedStickyRoutes []string
+	}{
+		{
+			name:                 "weighted route without stickiness",
+			stickyCanaryCookie:   "",
+			weightedClusters:     set.NewSet(v1Cluster, v2Cluster),
+			expectedStickyRoutes: nil,
+		},
+		{
+			name:                 "sticky weighted route",
+			stickyCanaryCookie:   "osm-canary.default.bookstore",
+			weightedClusters:     set.NewSet(v1Cluster, v2Cluster),
+			expectedStickyRoutes: []string{"default/bookstore-v1", "default/bookstore-v2"},
+		},
+		{
+			name:                 "sticky route to a single cluster",
+			stickyCanaryCookie:   "osm-canary.default.bookstore",
+			weightedClusters:     set.NewSet(v1Cluster),
+			expectedStickyRoutes: nil,
+		},
+		{
+			name:                 "sticky weighted route with a cluster without weight",
+			stickyCanaryCookie:   "osm-canary.default.bookstore",
+			weightedClusters:     set.NewSet(v1Cluster, v2Cluster, v3Cluster),
+			expectedStickyRoutes: []string{"default/bookstore-v1", "default/bookstore-v2"},
+		},
+		{
+			name:                 "sticky route to a single cluster with weight",
+			stickyCanaryCookie:   "osm-canary.default.bookstore",
+			weightedClusters:     set.NewSet(v1Cluster, v3Cluster),
+			expectedStickyRoutes: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			routeMatch := tests.BookstoreBuyHTTPRoute
+			routeMatch.StickyCanaryCookie = tc.stickyCanaryCookie
+			routeWeightedClustersMap := map[string]trafficpolicy.RouteWeightedClusters{
+				routeMatch.PathRegex: {
+					HTTPRouteMatch:   routeMatch,
+					WeightedClusters: tc.weightedClusters,
+				},
+			}
+
+			routes := createRoutes(routeWeightedClustersMap, OutboundRoute)
+			assert.Len(routes, len(tc.expectedStickyRoutes)+1)
+
+			// The sticky routes match the cookie recording their cluster and precede the weighted route
+			for i, clusterName := range tc.expectedStickyRoutes {
+				stickyRoute := routes[i]
+				clusters := stickyRoute.GetRoute().GetWeightedClusters().GetClusters()
+				assert.Len(clusters, 1)
+				assert.Equal(clusterName, clusters[0].Name)
+				assert.NotZero(stickyRoute.GetRoute().GetWeightedClusters().GetTotalWeight().GetValue())
+				assert.Empty(clusters[0].ResponseHeadersToAdd)
+
+				var cookieRegex string
+				for _, header := range stickyRoute.GetMatch().GetHeaders() {
+					if header.Name == cookieHeaderKey {
+						cookieRegex = header.GetSafeRegexMatch().GetRegex()
+					}
+				}
+				assert.Regexp("^"+cookieRegex+"$", "session=abc; osm-canary.default.bookstore="+clusterName)
+				assert.NotRegexp("^"+cookieRegex+"$", "osm-canary.default.bookstore=default/bookstore-v3")
+				assert.NotRegexp("^"+cookieRegex+"$", "osm-canary.default.bookstore2="+clusterName)
+			}
+
+			// The weighted route sets the cookie recording the cluster a client was routed to only if it is sticky
+			weightedRoute := routes[len(routes)-1]
+			assert.Equal(constants.RegexMatchAll, weightedRoute.GetMatch().GetSafeRegex().GetRegex())
+			for _, cluster := range weightedRoute.GetRoute().GetWeightedClusters().GetClusters() {
+				if tc.expectedStickyRoutes == nil || cluster.Weight.GetValue() == 0 {
+					assert.Empty(cluster.ResponseHeadersToAdd)
+					continue
+				}
+				assert.Len(cluster.ResponseHeadersToAdd, 1)
+				assert.Equal(setCookieHeaderKey, cluster.ResponseHeadersToAdd[0].Header.Key)
+				assert.Equal("osm-canary.default.bookstore="+cluster.Name+"; Path=/", cluster.ResponseHeadersToAdd[0].Header.Value)
+			}
+		})
+	}
+}
+
+func TestUpdateRouteConfigurationWithStickyCanaries(t *testing.T) {
+	assert := tassert.New(t)
+
+	getRouteWeightedClustersMap := func(host, cookieName string, clusters ...service.WeightedCluster) map[string]trafficpolicy.RouteWeightedClusters {
+		routeMatch := tests.BookstoreBuyHTTPRoute
+		routeMatch.StickyCanaryCookie = cookieName
+		weightedClusters := set.NewSet()
+		for _, cluster := range clusters {
+			weightedClusters.Add(cluster)
+		}
+		return map[string]trafficpolicy.RouteWeightedClusters{
+			routeMatch.PathRegex: {
+				HTTPRouteMatch:   routeMatch,
+				WeightedClusters: weightedClusters,
+				Hostnames:        set.NewSet(host),
+			},
+		}
+	}
+
+	// Two sticky TrafficSplits routed to by the same proxy record their backends in cookies of their own
+	bookstoreCookie := GetStickyCanaryCookieName("default", "bookstore")
+	paymentsCookie := GetStickyCanaryCookieName("default", "payments")
+	domainRoutesMap := map[string]map[string]trafficpolicy.RouteWeightedClusters{
+		"bookstore": getRouteWeightedClustersMap("bookstore", bookstoreCookie,
+			service.WeightedCluster{ClusterName: "default/bookstore-v1", Weight: 90},
+			service.WeightedCluster{ClusterName: "default/bookstore-v2", Weight: 10}),
+		"payments": getRouteWeightedClustersMap("payments", paymentsCookie,
+			service.WeightedCluster{ClusterName: "default/payments-v1", Weight: 50},
+			service.WeightedCluster{ClusterName: "default/payments-v2", Weight: 50}),
+	}
+	expectedCookies := map[string]string{
+		outboundVirtualHost + "|bookstore": bookstoreCookie,
+		outboundVirtualHost + "|payments":  paymentsCookie,
+	}
+
+	routeConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
+	UpdateRouteConfiguration(domainRoutesMap, routeConfig, OutboundRoute)
+	assert.Len(routeConfig.VirtualHosts, 2)
+
+	for _, virtualHost := range routeConfig.VirtualHosts {
+		cookieName := expectedCookies[virtualHost.Name]
+		otherCookieName := bookstoreCookie
+		if cookieName == bookstoreCookie {
+			otherCookieName = paymentsCookie
+		}
+		assert.Len(virtualHost.Routes, 3)
+
+		// The sticky routes only match the cookie of the virtual host's TrafficSplit
+		for _, stickyRoute := range virtualHost.Routes[:2] {
+			clusterName := stickyRoute.GetRoute().GetWeightedClusters().GetClusters()[0].Name
+			var cookieRegex string
+			for _, header := range stickyRoute.GetMatch().GetHeaders() {
+				if header.Name == cookieHeaderKey {
+					cookieRegex = header.GetSafeRegexMatch().GetRegex()
+				}
+			}
+			assert.Regexp("^"+cookieRegex+"$", otherCookieName+"=default/other; "+cookieName+"="+clusterName)
+			assert.NotRegexp("^"+cookieRegex+"$", otherCookieName+"="+clusterName)
+		}
+
+		// The weighted route sets the cookie of the virtual host's TrafficSplit
+		for _, cluster := range virtualHost.Routes[2].GetRoute().GetWeightedClusters().GetClusters() {
+			assert.Len(cluster.ResponseHeadersToAdd, 1)
+			assert.Equal(cookieName+"="+cluster.Name+"; Path=/", cluster.ResponseHeadersToAdd[0].Header.Value)
+		}
+	}
+}
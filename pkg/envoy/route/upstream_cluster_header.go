@@ -0,0 +1,28 @@
+package route
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+const (
+	// UpstreamClusterHeaderKey is the key of the response header identifying the upstream cluster that served a request
+	UpstreamClusterHeaderKey = "x-osm-upstream-cluster"
+
+	// upstreamClusterCommandOperator is the Envoy command operator substituted with the name of the upstream cluster
+	upstreamClusterCommandOperator = "%UPSTREAM_CLUSTER%"
+)
+
+// ApplyUpstreamClusterHeader adds a header identifying the upstream cluster that served a request to the responses
+// routed by the given route configuration, e.g. to debug which backend of a TrafficSplit served a request. The header
+// overwrites a header of the same key set by the upstream.
+func ApplyUpstreamClusterHeader(routeConfig *xds_route.RouteConfiguration) {
+	routeConfig.ResponseHeadersToAdd = append(routeConfig.ResponseHeadersToAdd, &xds_core.HeaderValueOption{
+		Header: &xds_core.HeaderValue{
+			Key:   UpstreamClusterHeaderKey,
+			Value: upstreamClusterCommandOperator,
+		},
+		Append: &wrappers.BoolValue{Value: false},
+	})
+}
@@ -0,0 +1,21 @@
+package route
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestApplyUpstreamClusterHeader(t *testing.T) {
+	assert := tassert.New(t)
+
+	routeConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
+	ApplyUpstreamClusterHeader(routeConfig)
+
+	assert.Len(routeConfig.ResponseHeadersToAdd, 1)
+	header := routeConfig.ResponseHeadersToAdd[0]
+	assert.Equal(UpstreamClusterHeaderKey, header.Header.Key)
+	assert.Equal("%UPSTREAM_CLUSTER%", header.Header.Value)
+	assert.False(header.Append.GetValue())
+	assert.Nil(routeConfig.Validate())
+}
@@ -0,0 +1,74 @@
+package route
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// pathHeaderKey is the key of the pseudo header for the HTTP path
+	pathHeaderKey = ":path"
+
+	// virtualClusterMatchAllName is the name used for the method or path of a virtual cluster matching all of them
+	virtualClusterMatchAllName = "all"
+)
+
+// nonStatNameChars matches the characters not allowed in the name of a virtual cluster, which is part of its stat names
+var nonStatNameChars = regexp.MustCompile("[^a-zA-Z0-9]+")
+
+// ApplyVirtualClusters configures a virtual cluster per route on each virtual host of the given route configuration.
+// Envoy emits request count, response code and latency stats for each virtual cluster, which provides per method
+// and path stats for the routes.
+func ApplyVirtualClusters(routeConfig *xds_route.RouteConfiguration) {
+	for _, virtualHost := range routeConfig.VirtualHosts {
+		virtualHost.VirtualClusters = nil
+		for _, route := range virtualHost.Routes {
+			virtualHost.VirtualClusters = append(virtualHost.VirtualClusters, getVirtualClusterForRoute(route))
+		}
+	}
+}
+
+// getVirtualClusterForRoute returns a virtual cluster matching the requests matched by the given route
+func getVirtualClusterForRoute(route *xds_route.Route) *xds_route.VirtualCluster {
+	pathHeader := &xds_route.HeaderMatcher{
+		Name: pathHeaderKey,
+	}
+	var path string
+	switch pathSpecifier := route.Match.PathSpecifier.(type) {
+	case *xds_route.RouteMatch_Prefix:
+		path = pathSpecifier.Prefix
+		pathHeader.HeaderMatchSpecifier = &xds_route.HeaderMatcher_PrefixMatch{PrefixMatch: pathSpecifier.Prefix}
+	case *xds_route.RouteMatch_SafeRegex:
+		path = pathSpecifier.SafeRegex.Regex
+		pathHeader.HeaderMatchSpecifier = &xds_route.HeaderMatcher_SafeRegexMatch{SafeRegexMatch: pathSpecifier.SafeRegex}
+	}
+
+	method := constants.RegexMatchAll
+	for _, header := range route.Match.Headers {
+		if header.Name == MethodHeaderKey {
+			method = header.GetSafeRegexMatch().GetRegex()
+		}
+	}
+
+	return &xds_route.VirtualCluster{
+		Name:    fmt.Sprintf("%s_%s", getVirtualClusterNameComponent(method), getVirtualClusterNameComponent(path)),
+		Headers: append([]*xds_route.HeaderMatcher{pathHeader}, route.Match.Headers...),
+	}
+}
+
+// getVirtualClusterNameComponent returns the given method or path regex with the characters not allowed in stat names removed
+func getVirtualClusterNameComponent(regex string) string {
+	if regex == constants.RegexMatchAll {
+		return virtualClusterMatchAllName
+	}
+	name := strings.Trim(nonStatNameChars.ReplaceAllString(regex, "_"), "_")
+	if name == "" {
+		return virtualClusterMatchAllName
+	}
+	return name
+}
@@ -0,0 +1,55 @@
+package route
+
+import (
+	"testing"
+
+	set "github.com/deckarep/golang-set"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestApplyVirtualClusters(t *testing.T) {
+	assert := tassert.New(t)
+
+	weightedClusters := set.NewSet(tests.BookstoreV1DefaultWeightedCluster)
+	routeConfig := NewRouteConfigurationStub(InboundRouteConfigName)
+	routeConfig.VirtualHosts = []*xds_route.VirtualHost{
+		{
+			Name:    "inbound_virtualHost|bookstore-v1",
+			Domains: []string{"bookstore-v1"},
+			Routes: []*xds_route.Route{
+				getRoute("/books-bought", "GET", nil, weightedClusters, 100, InboundRoute),
+				getRoute(constants.RegexMatchAll, constants.WildcardHTTPMethod, nil, weightedClusters, 100, InboundRoute),
+			},
+		},
+	}
+
+	ApplyVirtualClusters(routeConfig)
+
+	virtualClusters := routeConfig.VirtualHosts[0].VirtualClusters
+	assert.Len(virtualClusters, 2)
+
+	assert.Equal("GET_books_bought", virtualClusters[0].Name)
+	assert.Len(virtualClusters[0].Headers, 2)
+	assert.Equal(pathHeaderKey, virtualClusters[0].Headers[0].Name)
+	assert.Equal("/books-bought", virtualClusters[0].Headers[0].GetSafeRegexMatch().Regex)
+	assert.Equal(MethodHeaderKey, virtualClusters[0].Headers[1].Name)
+	assert.Equal("GET", virtualClusters[0].Headers[1].GetSafeRegexMatch().Regex)
+
+	assert.Equal("all_all", virtualClusters[1].Name)
+	assert.Equal(constants.RegexMatchAll, virtualClusters[1].Headers[0].GetSafeRegexMatch().Regex)
+}
+
+func TestGetVirtualClusterForPrefixRoute(t *testing.T) {
+	assert := tassert.New(t)
+
+	route := getRoute("/books", "POST", nil, set.NewSet(tests.BookstoreV1DefaultWeightedCluster), 100, InboundRoute)
+	route.Match.PathSpecifier = &xds_route.RouteMatch_Prefix{Prefix: "/books"}
+
+	virtualCluster := getVirtualClusterForRoute(route)
+	assert.Equal("POST_books", virtualCluster.Name)
+	assert.Equal("/books", virtualCluster.Headers[0].GetPrefixMatch())
+}
@@ -21,13 +21,6 @@ import (
 func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, certManager certificate.Manager) (*xds_discovery.DiscoveryResponse, error) {
 	log.Debug().Msgf("Composing SDS Discovery Response for Envoy with certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
 
-	svcList, err := meshCatalog.GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName())
-	if err != nil {
-		log.Error().Err(err).Msgf("Error getting services associated with Envoy with certificate SerialNumber=%s on Pod with UID=%s",
-			proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
-		return nil, err
-	}
-
 	// OSM currently relies on kubernetes ServiceAccount for service identity
 	svcAccount, err := catalog.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
@@ -36,6 +29,20 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request
 		return nil, err
 	}
 
+	svcList, err := meshCatalog.GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName())
+	if err != nil {
+		if !cfg.IsFailStaticEnabled() {
+			log.Error().Err(err).Msgf("Error getting services associated with Envoy with certificate SerialNumber=%s on Pod with UID=%s",
+				proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+			return nil, err
+		}
+		// The fail-static listeners of a proxy whose services cannot be resolved yet terminate TLS with the certificate
+		// of its service account, served as the certificate of the synthetic service of the service account
+		log.Warn().Err(err).Msgf("Services associated with Envoy with certificate SerialNumber=%s on Pod with UID=%s cannot be resolved, serving the certificate of its service account %s",
+			proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), svcAccount)
+		svcList = []service.MeshService{svcAccount.GetSyntheticService()}
+	}
+
 	sdsImpl := newSDSImpl(proxy, meshCatalog, certManager, cfg, svcList, svcAccount)
 	return sdsImpl.createDiscoveryResponse(request)
 }
@@ -177,6 +184,22 @@ func (s *sdsImpl) getRootCert(cert certificate.Certificater, sdscert envoy.SDSCe
 		},
 	}
 
+	// Reject revoked peer certificates when the certificate manager publishes a certificate revocation list
+	if publisher, ok := s.certManager.(certificate.RevocationListPublisher); ok {
+		crl, err := publisher.GetCertificateRevocationList()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting the certificate revocation list for cert %s", sdscert)
+			return nil, err
+		}
+		if len(crl) > 0 {
+			secret.GetValidationContext().Crl = &xds_core.DataSource{
+				Specifier: &xds_core.DataSource_InlineBytes{
+					InlineBytes: crl,
+				},
+			}
+		}
+	}
+
 	if s.cfg.IsPermissiveTrafficPolicyMode() {
 		// In permissive mode, there are no SMI TrafficTarget policies, so
 		// SAN matching is not required.
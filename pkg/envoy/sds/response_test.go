@@ -1,16 +1,23 @@
 package sds
 
 import (
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
 	tassert "github.com/stretchr/testify/assert"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -459,3 +466,82 @@ func TestSubjectAltNamesToStr(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRootCertRevocationList(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(1 * time.Hour).AnyTimes()
+
+	certManager := tresor.NewFakeCertManager(mockConfigurator)
+	s := &sdsImpl{
+		certManager: certManager,
+		cfg:         mockConfigurator,
+	}
+
+	sdsCert := envoy.SDSCert{
+		MeshService: service.MeshService{Name: "service-2", Namespace: "ns-2"},
+		CertType:    envoy.RootCertTypeForMTLSInbound,
+	}
+	proxyService := service.MeshService{Name: "service-1", Namespace: "ns-1"}
+
+	cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New().String(), "sa-1", "ns-1"))
+	cert, err := certManager.IssueCertificate(cn, 1*time.Hour)
+	assert.Nil(err)
+
+	// No certificate has been revoked, so the validation context does not include a CRL
+	sdsSecret, err := s.getRootCert(cert, sdsCert, proxyService)
+	assert.Nil(err)
+	assert.Nil(sdsSecret.GetValidationContext().GetCrl())
+
+	assert.Nil(certManager.RevokeCertificate(cn))
+
+	// The validation context includes the CRL listing the revoked certificate
+	sdsSecret, err = s.getRootCert(cert, sdsCert, proxyService)
+	assert.Nil(err)
+	certList, err := x509.ParseCRL(sdsSecret.GetValidationContext().GetCrl().GetInlineBytes())
+	assert.Nil(err)
+	assert.Len(certList.TBSCertList.RevokedCertificates, 1)
+	assert.Equal(cert.GetSerialNumber().String(), certList.TBSCertList.RevokedCertificates[0].SerialNumber.String())
+}
+
+func TestNewResponseDuringConfigGap(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(1 * time.Hour).AnyTimes()
+	certManager := tresor.NewFakeCertManager(mockConfigurator)
+
+	svcAccount := service.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}
+	proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New().String(), svcAccount.Name, svcAccount.Namespace)), "", nil)
+	sdsCert := envoy.SDSCert{
+		MeshService: svcAccount.GetSyntheticService(),
+		CertType:    envoy.ServiceCertType,
+	}
+	request := &xds_discovery.DiscoveryRequest{
+		ResourceNames: []string{sdsCert.String()},
+	}
+
+	// The pod of the proxy is not observed yet, so its services cannot be resolved
+	mockCatalog.EXPECT().GetServicesFromEnvoyCertificate(proxy.GetCertificateCommonName()).Return(nil, errors.New("pod not found")).Times(2)
+
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(false).Times(1)
+	_, err := NewResponse(mockCatalog, proxy, request, mockConfigurator, certManager)
+	assert.NotNil(err)
+
+	// The certificate of the service account is served for the fail-static listeners to terminate TLS
+	mockConfigurator.EXPECT().IsFailStaticEnabled().Return(true).Times(1)
+	resp, err := NewResponse(mockCatalog, proxy, request, mockConfigurator, certManager)
+	assert.Nil(err)
+	assert.Len(resp.Resources, 1)
+	secret := &xds_auth.Secret{}
+	assert.Nil(ptypes.UnmarshalAny(resp.Resources[0], secret))
+	assert.Equal(sdsCert.String(), secret.Name)
+	assert.NotEmpty(secret.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
+}
@@ -66,4 +66,12 @@ const (
 	// localClusterSuffix is the tag to append to the local cluster name corresponding to a service cluster.
 	// The local cluster refers to the cluster corresponding to the service the proxy is fronting, accessible over localhost by the proxy.
 	localClusterSuffix = "-local"
+
+	// failoverClusterSuffix is the tag to append to the name of the aggregate cluster failing over from a service cluster
+	// to the clusters of its failover services.
+	failoverClusterSuffix = "-failover"
+
+	// servicePortClusterSeparator separates the name of a service cluster from the port in the name of the cluster of a
+	// single port of the service.
+	servicePortClusterSeparator = "|"
 )
@@ -3,6 +3,7 @@ package envoy
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	xds_accesslog_filter "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
@@ -15,6 +16,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jinzhu/copier"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 )
@@ -61,8 +63,25 @@ const (
 	// TransportProtocolTLS is the TLS transport protocol used in Envoy configurations
 	TransportProtocolTLS = "tls"
 
+	// TransportProtocolRawBuffer is the plaintext transport protocol used in Envoy configurations
+	TransportProtocolRawBuffer = "raw_buffer"
+
 	// OutboundPassthroughCluster is the outbound passthrough cluster name
 	OutboundPassthroughCluster = "passthrough-outbound"
+
+	// OutboundBlackholeCluster is the outbound blackhole cluster name
+	OutboundBlackholeCluster = "blackhole-outbound"
+
+	// OutboundOriginalDstCluster is the name of the cluster forwarding outbound traffic to its original destination
+	// in the original_dst mode of the outbound listener
+	OutboundOriginalDstCluster = "original-dst-outbound"
+
+	// InboundPassthroughCluster is the inbound passthrough cluster name
+	InboundPassthroughCluster = "passthrough-inbound"
+
+	// ExternalCABundleSecretName is the name of the static secret of the bootstrap config holding the additional CA
+	// certificates trusted for TLS connections to destinations outside the mesh
+	ExternalCABundleSecretName = "external-ca-bundle"
 )
 
 // Defines valid cert types
@@ -139,14 +158,44 @@ func GetAddress(address string, port uint32) *xds_core.Address {
 	}
 }
 
-// GetTLSParams creates Envoy TlsParameters struct.
-func GetTLSParams() *xds_auth.TlsParameters {
+// GetTLSParams creates Envoy TlsParameters struct using the TLS protocol versions and cipher suites configured for the mesh.
+// Invalid settings, which Envoy would reject, fall back to the default TLS protocol versions and cipher suites.
+func GetTLSParams(cfg configurator.Configurator) *xds_auth.TlsParameters {
+	minVersion := getTLSProtocolVersion(cfg.GetTLSMinimumProtocolVersion(), xds_auth.TlsParameters_TLSv1_2)
+	maxVersion := getTLSProtocolVersion(cfg.GetTLSMaximumProtocolVersion(), xds_auth.TlsParameters_TLSv1_3)
+	if minVersion > maxVersion {
+		log.Error().Msgf("Minimum TLS protocol version %s is greater than maximum TLS protocol version %s, using %s and %s",
+			minVersion, maxVersion, xds_auth.TlsParameters_TLSv1_2, xds_auth.TlsParameters_TLSv1_3)
+		minVersion, maxVersion = xds_auth.TlsParameters_TLSv1_2, xds_auth.TlsParameters_TLSv1_3
+	}
+
+	cipherSuites := cfg.GetTLSCipherSuites()
+	for _, cipherSuite := range cipherSuites {
+		if !configurator.IsValidTLSCipherSuite(cipherSuite) {
+			log.Error().Msgf("Invalid TLS cipher suite %s, using Envoy's default cipher suites", cipherSuite)
+			cipherSuites = nil
+			break
+		}
+	}
+
 	return &xds_auth.TlsParameters{
-		TlsMinimumProtocolVersion: xds_auth.TlsParameters_TLSv1_2,
-		TlsMaximumProtocolVersion: xds_auth.TlsParameters_TLSv1_3,
+		TlsMinimumProtocolVersion: minVersion,
+		TlsMaximumProtocolVersion: maxVersion,
+		CipherSuites:              cipherSuites,
 	}
 }
 
+// getTLSProtocolVersion returns the Envoy TLS protocol version for the given version name, such as TLSv1_2,
+// or the given default version if the name is not a valid TLS protocol version
+func getTLSProtocolVersion(version string, defaultVersion xds_auth.TlsParameters_TlsProtocol) xds_auth.TlsParameters_TlsProtocol {
+	protocolVersion, ok := xds_auth.TlsParameters_TlsProtocol_value[version]
+	if !ok || xds_auth.TlsParameters_TlsProtocol(protocolVersion) == xds_auth.TlsParameters_TLS_AUTO {
+		log.Error().Msgf("Invalid TLS protocol version %s, using %s", version, defaultVersion)
+		return defaultVersion
+	}
+	return xds_auth.TlsParameters_TlsProtocol(protocolVersion)
+}
+
 // GetAccessLog creates an Envoy AccessLog struct.
 func GetAccessLog() []*xds_accesslog_filter.AccessLog {
 	accessLog, err := ptypes.MarshalAny(getFileAccessLog())
@@ -162,6 +211,56 @@ func GetAccessLog() []*xds_accesslog_filter.AccessLog {
 	}
 }
 
+// GetTCPAccessLog creates an Envoy AccessLog struct logging the connections proxied by a TCP proxy filter, using the
+// given Envoy format string. Connections are logged in JSON with their L4 properties when the format is empty.
+func GetTCPAccessLog(format string) []*xds_accesslog_filter.AccessLog {
+	accessLog, err := ptypes.MarshalAny(getTCPFileAccessLog(format))
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling TCP AccessLog object")
+		return nil
+	}
+	return []*xds_accesslog_filter.AccessLog{{
+		Name: wellknown.FileAccessLog,
+		ConfigType: &xds_accesslog_filter.AccessLog_TypedConfig{
+			TypedConfig: accessLog,
+		}},
+	}
+}
+
+func getTCPFileAccessLog(format string) *xds_accesslog.FileAccessLog {
+	logFormat := &xds_core.SubstitutionFormatString{
+		Format: &xds_core.SubstitutionFormatString_JsonFormat{
+			JsonFormat: &structpb.Struct{
+				Fields: map[string]*structpb.Value{
+					"start_time":                pbStringValue(`%START_TIME%`),
+					"upstream_cluster":          pbStringValue(`%UPSTREAM_CLUSTER%`),
+					"response_flags":            pbStringValue(`%RESPONSE_FLAGS%`),
+					"bytes_received":            pbStringValue(`%BYTES_RECEIVED%`),
+					"bytes_sent":                pbStringValue(`%BYTES_SENT%`),
+					"duration":                  pbStringValue(`%DURATION%`),
+					"requested_server_name":     pbStringValue(`%REQUESTED_SERVER_NAME%`),
+					"downstream_remote_address": pbStringValue(`%DOWNSTREAM_REMOTE_ADDRESS%`),
+					"downstream_local_address":  pbStringValue(`%DOWNSTREAM_LOCAL_ADDRESS%`),
+					"upstream_host":             pbStringValue(`%UPSTREAM_HOST%`),
+					"upstream_local_address":    pbStringValue(`%UPSTREAM_LOCAL_ADDRESS%`),
+				},
+			},
+		},
+	}
+	if format != "" {
+		logFormat.Format = &xds_core.SubstitutionFormatString_TextFormat{
+			TextFormat: format,
+		}
+	}
+
+	return &xds_accesslog.FileAccessLog{
+		Path: accessLogPath,
+		AccessLogFormat: &xds_accesslog.FileAccessLog_LogFormat{
+			LogFormat: logFormat,
+		},
+	}
+}
+
 func getFileAccessLog() *xds_accesslog.FileAccessLog {
 	accessLogger := &xds_accesslog.FileAccessLog{
 		Path: accessLogPath,
@@ -209,9 +308,9 @@ func pbStringValue(v string) *structpb.Value {
 // getCommonTLSContext returns a CommonTlsContext type for a given 'tlsSDSCert' and 'peerValidationSDSCert' pair.
 // 'tlsSDSCert' determines the SDS Secret config used to present the TLS certificate.
 // 'peerValidationSDSCert' determines the SDS Secret configs used to validate the peer TLS certificate.
-func getCommonTLSContext(tlsSDSCert, peerValidationSDSCert SDSCert) *xds_auth.CommonTlsContext {
+func getCommonTLSContext(tlsSDSCert, peerValidationSDSCert SDSCert, cfg configurator.Configurator) *xds_auth.CommonTlsContext {
 	return &xds_auth.CommonTlsContext{
-		TlsParams: GetTLSParams(),
+		TlsParams: GetTLSParams(cfg),
 		TlsCertificateSdsSecretConfigs: []*xds_auth.SdsSecretConfig{{
 			// Example ==> Name: "service-cert:NameSpaceHere/ServiceNameHere"
 			Name:      tlsSDSCert.String(),
@@ -228,7 +327,7 @@ func getCommonTLSContext(tlsSDSCert, peerValidationSDSCert SDSCert) *xds_auth.Co
 }
 
 // GetDownstreamTLSContext creates a downstream Envoy TLS Context
-func GetDownstreamTLSContext(upstreamSvc service.MeshService, mTLS bool) *xds_auth.DownstreamTlsContext {
+func GetDownstreamTLSContext(upstreamSvc service.MeshService, mTLS bool, cfg configurator.Configurator) *xds_auth.DownstreamTlsContext {
 	upstreamSDSCert := SDSCert{
 		MeshService: upstreamSvc,
 		CertType:    ServiceCertType,
@@ -253,15 +352,23 @@ func GetDownstreamTLSContext(upstreamSvc service.MeshService, mTLS bool) *xds_au
 	}
 
 	tlsConfig := &xds_auth.DownstreamTlsContext{
-		CommonTlsContext: getCommonTLSContext(upstreamSDSCert, downstreamPeerValidationSDSCert),
-		// When RequireClientCertificate is enabled trusted CA certs must be provided via ValidationContextType
+		CommonTlsContext: getCommonTLSContext(upstreamSDSCert, downstreamPeerValidationSDSCert, cfg),
+		// When RequireClientCertificate is enabled trusted CA certs must be provided via ValidationContextType.
+		// Handshakes of mTLS clients presenting no certificate or a certificate failing validation are rejected, and
+		// counted by the listener's ssl.fail_verify_no_cert and ssl.fail_verify_error stats respectively.
 		RequireClientCertificate: &wrappers.BoolValue{Value: mTLS},
 	}
 	return tlsConfig
 }
 
 // GetUpstreamTLSContext creates an upstream Envoy TLS Context for the given downstream and upstream service pair
-func GetUpstreamTLSContext(downstreamSvc, upstreamSvc service.MeshService) *xds_auth.UpstreamTlsContext {
+func GetUpstreamTLSContext(downstreamSvc, upstreamSvc service.MeshService, cfg configurator.Configurator) *xds_auth.UpstreamTlsContext {
+	return GetUpstreamTLSContextWithSNI(downstreamSvc, upstreamSvc, upstreamSvc.ServerName(), cfg)
+}
+
+// GetUpstreamTLSContextWithSNI creates an upstream Envoy TLS Context for the given downstream and upstream service pair
+// using the given SNI instead of the one derived from the upstream service
+func GetUpstreamTLSContextWithSNI(downstreamSvc, upstreamSvc service.MeshService, sni string, cfg configurator.Configurator) *xds_auth.UpstreamTlsContext {
 	downstreamSDSCert := SDSCert{
 		MeshService: downstreamSvc,
 		CertType:    ServiceCertType,
@@ -270,7 +377,7 @@ func GetUpstreamTLSContext(downstreamSvc, upstreamSvc service.MeshService) *xds_
 		MeshService: upstreamSvc,
 		CertType:    RootCertTypeForMTLSOutbound,
 	}
-	commonTLSContext := getCommonTLSContext(downstreamSDSCert, upstreamPeerValidationSDSCert)
+	commonTLSContext := getCommonTLSContext(downstreamSDSCert, upstreamPeerValidationSDSCert, cfg)
 
 	// Advertise in-mesh using UpstreamTlsContext.CommonTlsContext.AlpnProtocols
 	commonTLSContext.AlpnProtocols = ALPNInMesh
@@ -280,11 +387,30 @@ func GetUpstreamTLSContext(downstreamSvc, upstreamSvc service.MeshService) *xds_
 		// The Sni field is going to be used to do FilterChainMatch in getInboundMeshHTTPFilterChain()
 		// The "Sni" field below of an incoming request will be matched against a list of server names
 		// in FilterChainMatch.ServerNames
-		Sni: upstreamSvc.ServerName(),
+		Sni: sni,
 	}
 	return tlsConfig
 }
 
+// GetExternalUpstreamTLSContext creates an upstream Envoy TLS Context originating TLS to a destination outside the mesh
+// with the given SNI. The destination's certificate is validated with the additional CA certificates of the
+// ExternalCABundleSecretName static secret of the bootstrap config, which only exists when a CA bundle is configured
+// for the sidecar. The mesh CA is not trusted for such destinations.
+func GetExternalUpstreamTLSContext(sni string, cfg configurator.Configurator) *xds_auth.UpstreamTlsContext {
+	return &xds_auth.UpstreamTlsContext{
+		CommonTlsContext: &xds_auth.CommonTlsContext{
+			TlsParams: GetTLSParams(cfg),
+			ValidationContextType: &xds_auth.CommonTlsContext_ValidationContextSdsSecretConfig{
+				ValidationContextSdsSecretConfig: &xds_auth.SdsSecretConfig{
+					// A secret without an SDS config is a static secret of the bootstrap config
+					Name: ExternalCABundleSecretName,
+				},
+			},
+		},
+		Sni: sni,
+	}
+}
+
 // GetADSConfigSource creates an Envoy ConfigSource struct.
 func GetADSConfigSource() *xds_core.ConfigSource {
 	return &xds_core.ConfigSource{
@@ -336,3 +462,33 @@ func GetLocalClusterNameForService(proxyService service.MeshService) string {
 func GetLocalClusterNameForServiceCluster(clusterName string) string {
 	return fmt.Sprintf("%s%s", clusterName, localClusterSuffix)
 }
+
+// GetFailoverClusterNameForService returns the name of the aggregate cluster failing over from the cluster of the given upstream
+// service to the clusters of its failover services.
+func GetFailoverClusterNameForService(upstreamSvc service.MeshService) string {
+	return fmt.Sprintf("%s%s", upstreamSvc, failoverClusterSuffix)
+}
+
+// GetClusterNameForServicePort returns the name of the cluster of the given port of the given upstream service, whose
+// endpoints are the instances of the service listening on the target port the service port maps to.
+func GetClusterNameForServicePort(upstreamSvc service.MeshService, port uint32) string {
+	return fmt.Sprintf("%s%s%d", upstreamSvc, servicePortClusterSeparator, port)
+}
+
+// GetServicePortsWithCluster returns the sorted ports of the given port to protocol mapping of a service whose connections
+// are routed to the cluster of their port instead of the service cluster. The cluster of a service mixes the endpoints
+// of all the target ports of the service, so each port of a service exposing several ports has a cluster of its own.
+// The TCP connections to a port are routed to the cluster of the port, and so are the HTTP requests whose host names
+// the port.
+func GetServicePortsWithCluster(portToProtocol map[uint32]string) []uint32 {
+	if len(portToProtocol) < 2 {
+		return nil
+	}
+
+	var ports []uint32
+	for port := range portToProtocol {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports
+}
@@ -2,17 +2,29 @@ package envoy
 
 import (
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
 	auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var _ = Describe("Test Envoy tools", func() {
+	mockCtrl := gomock.NewController(GinkgoT())
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinimumProtocolVersion().Return(constants.DefaultTLSMinimumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaximumProtocolVersion().Return(constants.DefaultTLSMaximumProtocolVersion).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+
 	Context("Test GetAddress()", func() {
 		It("should return address", func() {
 			addr := "blah"
@@ -130,7 +142,7 @@ var _ = Describe("Test Envoy tools", func() {
 
 	Context("Test GetDownstreamTLSContext()", func() {
 		It("should return TLS context", func() {
-			tlsContext := GetDownstreamTLSContext(tests.BookstoreV1Service, true)
+			tlsContext := GetDownstreamTLSContext(tests.BookstoreV1Service, true, mockConfigurator)
 
 			expectedTLSContext := &auth.DownstreamTlsContext{
 				CommonTlsContext: &auth.CommonTlsContext{
@@ -179,14 +191,14 @@ var _ = Describe("Test Envoy tools", func() {
 
 	Context("Test GetDownstreamTLSContext() for mTLS", func() {
 		It("should return TLS context with client certificate validation enabled", func() {
-			tlsContext := GetDownstreamTLSContext(tests.BookstoreV1Service, true)
+			tlsContext := GetDownstreamTLSContext(tests.BookstoreV1Service, true, mockConfigurator)
 			Expect(tlsContext.RequireClientCertificate).To(Equal(&wrappers.BoolValue{Value: true}))
 		})
 	})
 
 	Context("Test GetDownstreamTLSContext() for TLS", func() {
 		It("should return TLS context with client certificate validation disabled", func() {
-			tlsContext := GetDownstreamTLSContext(tests.BookstoreV1Service, false)
+			tlsContext := GetDownstreamTLSContext(tests.BookstoreV1Service, false, mockConfigurator)
 			Expect(tlsContext.RequireClientCertificate).To(Equal(&wrappers.BoolValue{Value: false}))
 		})
 	})
@@ -194,7 +206,7 @@ var _ = Describe("Test Envoy tools", func() {
 	Context("Test GetUpstreamTLSContext()", func() {
 		It("should return TLS context", func() {
 			sni := "bookstore-v1.default.svc.cluster.local"
-			tlsContext := GetUpstreamTLSContext(tests.BookbuyerService, tests.BookstoreV1Service)
+			tlsContext := GetUpstreamTLSContext(tests.BookbuyerService, tests.BookstoreV1Service, mockConfigurator)
 
 			expectedTLSContext := &auth.UpstreamTlsContext{
 				CommonTlsContext: &auth.CommonTlsContext{
@@ -241,14 +253,95 @@ var _ = Describe("Test Envoy tools", func() {
 		})
 	})
 
+	Context("Test GetExternalUpstreamTLSContext()", func() {
+		It("should return TLS context validating the destination with the external CA bundle", func() {
+			tlsContext := GetExternalUpstreamTLSContext("api.example.com", mockConfigurator)
+
+			Expect(tlsContext.Sni).To(Equal("api.example.com"))
+			Expect(tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs).To(BeEmpty())
+			Expect(tlsContext.CommonTlsContext.AlpnProtocols).To(BeEmpty())
+			Expect(tlsContext.CommonTlsContext.ValidationContextType).To(Equal(&auth.CommonTlsContext_ValidationContextSdsSecretConfig{
+				ValidationContextSdsSecretConfig: &auth.SdsSecretConfig{
+					Name: ExternalCABundleSecretName,
+				},
+			}))
+		})
+	})
+
 	Context("Test GetUpstreamTLSContext()", func() {
 		It("creates correct UpstreamTlsContext.Sni field", func() {
-			tlsContext := GetUpstreamTLSContext(tests.BookbuyerService, tests.BookstoreV1Service)
+			tlsContext := GetUpstreamTLSContext(tests.BookbuyerService, tests.BookstoreV1Service, mockConfigurator)
 			// To show the actual string for human comprehension
 			Expect(tlsContext.Sni).To(Equal(tests.BookstoreV1Service.ServerName()))
 		})
 	})
 
+	Context("Test GetTLSParams()", func() {
+		It("should return the configured TLS protocol versions and cipher suites", func() {
+			cfg := configurator.NewMockConfigurator(mockCtrl)
+			cfg.EXPECT().GetTLSMinimumProtocolVersion().Return("TLSv1_3").Times(1)
+			cfg.EXPECT().GetTLSMaximumProtocolVersion().Return("TLSv1_3").Times(1)
+			cfg.EXPECT().GetTLSCipherSuites().Return([]string{"ECDHE-ECDSA-AES256-GCM-SHA384"}).Times(1)
+
+			actual := GetTLSParams(cfg)
+
+			Expect(actual.TlsMinimumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+			Expect(actual.TlsMaximumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+			Expect(actual.CipherSuites).To(Equal([]string{"ECDHE-ECDSA-AES256-GCM-SHA384"}))
+		})
+
+		It("should return the default TLS protocol versions for invalid versions", func() {
+			cfg := configurator.NewMockConfigurator(mockCtrl)
+			cfg.EXPECT().GetTLSMinimumProtocolVersion().Return("TLS_AUTO").Times(1)
+			cfg.EXPECT().GetTLSMaximumProtocolVersion().Return("invalid").Times(1)
+			cfg.EXPECT().GetTLSCipherSuites().Return(nil).Times(1)
+
+			actual := GetTLSParams(cfg)
+
+			Expect(actual.TlsMinimumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_2))
+			Expect(actual.TlsMaximumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+			Expect(actual.CipherSuites).To(BeNil())
+		})
+
+		It("should return the default TLS protocol versions for a minimum version greater than the maximum version", func() {
+			cfg := configurator.NewMockConfigurator(mockCtrl)
+			cfg.EXPECT().GetTLSMinimumProtocolVersion().Return("TLSv1_3").Times(1)
+			cfg.EXPECT().GetTLSMaximumProtocolVersion().Return("TLSv1_1").Times(1)
+			cfg.EXPECT().GetTLSCipherSuites().Return(nil).Times(1)
+
+			actual := GetTLSParams(cfg)
+
+			Expect(actual.TlsMinimumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_2))
+			Expect(actual.TlsMaximumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+		})
+
+		It("should return the default cipher suites for an invalid cipher suite", func() {
+			cfg := configurator.NewMockConfigurator(mockCtrl)
+			cfg.EXPECT().GetTLSMinimumProtocolVersion().Return("TLSv1_2").Times(1)
+			cfg.EXPECT().GetTLSMaximumProtocolVersion().Return("TLSv1_3").Times(1)
+			cfg.EXPECT().GetTLSCipherSuites().Return([]string{"ECDHE-ECDSA-AES256-GCM-SHA384", "TLS_AES_128_GCM_SHA256"}).Times(1)
+
+			actual := GetTLSParams(cfg)
+
+			Expect(actual.CipherSuites).To(BeNil())
+		})
+
+		It("should set the TLS parameters in the downstream and upstream TLS contexts", func() {
+			cfg := configurator.NewMockConfigurator(mockCtrl)
+			cfg.EXPECT().GetTLSMinimumProtocolVersion().Return("TLSv1_3").Times(2)
+			cfg.EXPECT().GetTLSMaximumProtocolVersion().Return("TLSv1_3").Times(2)
+			cfg.EXPECT().GetTLSCipherSuites().Return(nil).Times(2)
+
+			downstreamTLSContext := GetDownstreamTLSContext(tests.BookstoreV1Service, true, cfg)
+			Expect(downstreamTLSContext.CommonTlsContext.TlsParams.TlsMinimumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+			Expect(downstreamTLSContext.CommonTlsContext.TlsParams.TlsMaximumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+
+			upstreamTLSContext := GetUpstreamTLSContext(tests.BookbuyerService, tests.BookstoreV1Service, cfg)
+			Expect(upstreamTLSContext.CommonTlsContext.TlsParams.TlsMinimumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+			Expect(upstreamTLSContext.CommonTlsContext.TlsParams.TlsMaximumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_3))
+		})
+	})
+
 	Context("Test getCommonTLSContext()", func() {
 		It("returns proper auth.CommonTlsContext for outbound mTLS", func() {
 			tlsSDSCert := SDSCert{
@@ -260,10 +353,10 @@ var _ = Describe("Test Envoy tools", func() {
 				CertType:    RootCertTypeForMTLSOutbound,
 			}
 
-			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert)
+			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert, mockConfigurator)
 
 			expected := &auth.CommonTlsContext{
-				TlsParams: GetTLSParams(),
+				TlsParams: GetTLSParams(mockConfigurator),
 				TlsCertificateSdsSecretConfigs: []*auth.SdsSecretConfig{{
 					Name:      "service-cert:default/bookbuyer",
 					SdsConfig: GetADSConfigSource(),
@@ -290,10 +383,10 @@ var _ = Describe("Test Envoy tools", func() {
 				CertType:    RootCertTypeForMTLSInbound,
 			}
 
-			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert)
+			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert, mockConfigurator)
 
 			expected := &auth.CommonTlsContext{
-				TlsParams: GetTLSParams(),
+				TlsParams: GetTLSParams(mockConfigurator),
 				TlsCertificateSdsSecretConfigs: []*auth.SdsSecretConfig{{
 					Name:      "service-cert:default/bookstore-v1",
 					SdsConfig: GetADSConfigSource(),
@@ -320,10 +413,10 @@ var _ = Describe("Test Envoy tools", func() {
 				CertType:    RootCertTypeForHTTPS,
 			}
 
-			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert)
+			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert, mockConfigurator)
 
 			expected := &auth.CommonTlsContext{
-				TlsParams: GetTLSParams(),
+				TlsParams: GetTLSParams(mockConfigurator),
 				TlsCertificateSdsSecretConfigs: []*auth.SdsSecretConfig{{
 					Name:      "service-cert:default/bookstore-v1",
 					SdsConfig: GetADSConfigSource(),
@@ -341,6 +434,35 @@ var _ = Describe("Test Envoy tools", func() {
 		})
 	})
 
+	Context("Test GetTCPAccessLog()", func() {
+		It("logs connections in JSON by default", func() {
+			accessLogs := GetTCPAccessLog("")
+			Expect(accessLogs).To(HaveLen(1))
+			Expect(accessLogs[0].Name).To(Equal(wellknown.FileAccessLog))
+
+			fileAccessLog := &accesslog.FileAccessLog{}
+			Expect(ptypes.UnmarshalAny(accessLogs[0].GetTypedConfig(), fileAccessLog)).To(Succeed())
+			Expect(fileAccessLog.Path).To(Equal(accessLogPath))
+			fields := fileAccessLog.GetLogFormat().GetJsonFormat().GetFields()
+			Expect(fields).To(HaveKey("bytes_sent"))
+			Expect(fields).To(HaveKey("bytes_received"))
+			Expect(fields).To(HaveKey("duration"))
+			Expect(fields).To(HaveKey("downstream_remote_address"))
+			Expect(fields).To(HaveKey("upstream_host"))
+			Expect(fields).ToNot(HaveKey("method"))
+		})
+
+		It("logs connections using the given format", func() {
+			format := "%DOWNSTREAM_REMOTE_ADDRESS% -> %UPSTREAM_HOST% %DURATION%\n"
+			accessLogs := GetTCPAccessLog(format)
+			Expect(accessLogs).To(HaveLen(1))
+
+			fileAccessLog := &accesslog.FileAccessLog{}
+			Expect(ptypes.UnmarshalAny(accessLogs[0].GetTypedConfig(), fileAccessLog)).To(Succeed())
+			Expect(fileAccessLog.GetLogFormat().GetTextFormat()).To(Equal(format))
+		})
+	})
+
 	Context("Test GetEnvoyServiceNodeID()", func() {
 		It("", func() {
 			actual := GetEnvoyServiceNodeID("-nodeID-")
@@ -361,4 +483,20 @@ var _ = Describe("Test Envoy tools", func() {
 			Expect(meta.EnvoyNodeID).To(Equal("-nodeID-"))
 		})
 	})
+	Context("Test GetServicePortsWithCluster()", func() {
+		It("returns no port for a service exposing a single port", func() {
+			Expect(GetServicePortsWithCluster(map[uint32]string{5432: "tcp"})).To(BeEmpty())
+		})
+
+		It("returns the sorted ports of a service exposing several ports", func() {
+			Expect(GetServicePortsWithCluster(map[uint32]string{9000: "tcp", 80: "http", 5432: "tcp"})).To(Equal([]uint32{80, 5432, 9000}))
+		})
+	})
+
+	Context("Test GetClusterNameForServicePort()", func() {
+		It("returns the name of the cluster of the given port of the service", func() {
+			svc := service.MeshService{Namespace: "ns", Name: "svc"}
+			Expect(GetClusterNameForServicePort(svc, 5432)).To(Equal("ns/svc|5432"))
+		})
+	})
 })
@@ -0,0 +1,214 @@
+package injector
+
+import (
+	"bytes"
+	"reflect"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+const bootstrapTemplateName = "envoy-bootstrap"
+
+// requiredBootstrapTemplateFields are the fields of envoyBootstrapConfigMeta a bootstrap config template must reference
+// for the Envoy sidecar to be able to securely connect to the xDS server.
+var requiredBootstrapTemplateFields = []string{"XDSClusterName", "XDSHost", "XDSPort", "RootCert", "Cert", "Key"}
+
+// ValidateBootstrapTemplate returns an error if the given Envoy bootstrap config template cannot be parsed
+// or does not reference all the placeholders required to connect to the xDS server.
+// An empty template is valid, in which case the default bootstrap config is used.
+func ValidateBootstrapTemplate(bootstrapTemplate string) error {
+	if bootstrapTemplate == "" {
+		return nil
+	}
+
+	tmpl, err := template.New(bootstrapTemplateName).Parse(bootstrapTemplate)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing Envoy bootstrap config template")
+	}
+
+	referencedFields := make(map[string]bool)
+	collectTemplateFields(tmpl.Tree.Root, referencedFields)
+
+	var missing []string
+	for _, field := range requiredBootstrapTemplateFields {
+		if !referencedFields[field] {
+			missing = append(missing, "{{."+field+"}}")
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("Envoy bootstrap config template is missing required placeholders %v", missing)
+	}
+
+	return nil
+}
+
+// collectTemplateFields records the names of the top level fields referenced by the given template node and its children
+func collectTemplateFields(node parse.Node, fields map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateFields(child, fields)
+		}
+	case *parse.ActionNode:
+		collectTemplateFields(n.Pipe, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				collectTemplateFields(arg, fields)
+			}
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) > 0 {
+			fields[n.Ident[0]] = true
+		}
+	case *parse.IfNode:
+		collectTemplateFields(n.Pipe, fields)
+		collectTemplateFields(n.List, fields)
+		collectTemplateFields(n.ElseList, fields)
+	case *parse.RangeNode:
+		collectTemplateFields(n.Pipe, fields)
+		collectTemplateFields(n.List, fields)
+		collectTemplateFields(n.ElseList, fields)
+	case *parse.WithNode:
+		collectTemplateFields(n.Pipe, fields)
+		collectTemplateFields(n.List, fields)
+		collectTemplateFields(n.ElseList, fields)
+	}
+}
+
+// renderBootstrapTemplate renders the given Envoy bootstrap config template with the per-pod bootstrap values
+func renderBootstrapTemplate(bootstrapTemplate string, config envoyBootstrapConfigMeta) ([]byte, error) {
+	tmpl, err := template.New(bootstrapTemplateName).Option("missingkey=error").Parse(bootstrapTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, config); err != nil {
+		return nil, err
+	}
+
+	return rendered.Bytes(), nil
+}
+
+// getTemplatedEnvoyConfigYAML renders the given Envoy bootstrap config template with the per-pod bootstrap values and
+// merges into it the sections of the generated bootstrap config enabling mesh-wide and per-pod features, so that these
+// features also apply to sidecars bootstrapped from the template: incremental xDS, stats tags and sinks, the static
+// runtime layer holding the runtime flags and the inbound connection limit, and the overload manager. The generated
+// values take precedence over the values the template sets for the same settings.
+func getTemplatedEnvoyConfigYAML(bootstrapTemplate string, config envoyBootstrapConfigMeta, cfg configurator.Configurator) ([]byte, error) {
+	rendered, err := renderBootstrapTemplate(bootstrapTemplate, config)
+	if err != nil {
+		return nil, err
+	}
+	templated := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(rendered, &templated); err != nil {
+		return nil, errors.Wrap(err, "Error parsing the Envoy bootstrap config rendered from the template")
+	}
+
+	// The generated config is round-tripped through YAML to be made of the same types as the rendered template
+	generatedYAML, err := getEnvoyConfigYAML(config, cfg)
+	if err != nil {
+		return nil, err
+	}
+	generated := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(generatedYAML, &generated); err != nil {
+		return nil, err
+	}
+
+	mergeGeneratedBootstrapConfig(templated, generated)
+	return yaml.Marshal(&templated)
+}
+
+// mergeGeneratedBootstrapConfig merges the feature sections of the given generated bootstrap config into the given
+// bootstrap config rendered from a template
+func mergeGeneratedBootstrapConfig(templated, generated map[interface{}]interface{}) {
+	// The ADS API type is only merged into a template connecting to the xDS server through ADS
+	if apiType := getBootstrapSection(getBootstrapSection(generated, "dynamic_resources"), "ads_config")["api_type"]; apiType == "DELTA_GRPC" {
+		if adsConfig := getBootstrapSection(getBootstrapSection(templated, "dynamic_resources"), "ads_config"); adsConfig != nil {
+			setBootstrapValue(adsConfig, "api_type", apiType)
+		}
+	}
+
+	if statsTags, ok := getBootstrapSection(generated, "stats_config")["stats_tags"].([]interface{}); ok {
+		statsConfig := getBootstrapSection(templated, "stats_config")
+		if statsConfig == nil {
+			statsConfig = make(map[interface{}]interface{})
+			templated["stats_config"] = statsConfig
+		}
+		templatedStatsTags, _ := statsConfig["stats_tags"].([]interface{})
+		statsConfig["stats_tags"] = append(templatedStatsTags, statsTags...)
+	}
+
+	if statsSinks, ok := generated["stats_sinks"].([]interface{}); ok {
+		templatedStatsSinks, _ := templated["stats_sinks"].([]interface{})
+		templated["stats_sinks"] = append(templatedStatsSinks, statsSinks...)
+	}
+	if flushInterval, ok := generated["stats_flush_interval"]; ok {
+		setBootstrapValue(templated, "stats_flush_interval", flushInterval)
+	}
+
+	if layers, ok := getBootstrapSection(generated, "layered_runtime")["layers"].([]interface{}); ok && len(layers) > 0 {
+		if generatedLayer, ok := layers[0].(map[interface{}]interface{}); ok {
+			mergeStaticRuntimeLayer(templated, getBootstrapSection(generatedLayer, "static_layer"))
+		}
+	}
+
+	if overloadManager, ok := generated["overload_manager"]; ok {
+		setBootstrapValue(templated, "overload_manager", overloadManager)
+	}
+}
+
+// mergeStaticRuntimeLayer merges the given runtime values into the first static runtime layer of the given bootstrap
+// config, or adds a static runtime layer holding them as the base layer of the config when it has none
+func mergeStaticRuntimeLayer(templated, runtimeValues map[interface{}]interface{}) {
+	layeredRuntime := getBootstrapSection(templated, "layered_runtime")
+	if layeredRuntime == nil {
+		layeredRuntime = make(map[interface{}]interface{})
+		templated["layered_runtime"] = layeredRuntime
+	}
+
+	layers, _ := layeredRuntime["layers"].([]interface{})
+	for _, layer := range layers {
+		layerConfig, _ := layer.(map[interface{}]interface{})
+		if staticLayer := getBootstrapSection(layerConfig, "static_layer"); staticLayer != nil {
+			for key, value := range runtimeValues {
+				setBootstrapValue(staticLayer, key, value)
+			}
+			return
+		}
+	}
+
+	staticLayer := map[interface{}]interface{}{
+		"name":         staticRuntimeLayerName,
+		"static_layer": runtimeValues,
+	}
+	layeredRuntime["layers"] = append([]interface{}{staticLayer}, layers...)
+}
+
+// getBootstrapSection returns the section of the given bootstrap config section with the given key, or nil if there
+// is none
+func getBootstrapSection(section map[interface{}]interface{}, key string) map[interface{}]interface{} {
+	subsection, _ := section[key].(map[interface{}]interface{})
+	return subsection
+}
+
+// setBootstrapValue sets the given key of the given bootstrap config section to the given generated value, overriding
+// the value set by the template
+func setBootstrapValue(section map[interface{}]interface{}, key, value interface{}) {
+	if templatedValue, ok := section[key]; ok && !reflect.DeepEqual(templatedValue, value) {
+		log.Warn().Msgf("Overriding %v set by the Envoy bootstrap config template with the value generated by OSM", key)
+	}
+	section[key] = value
+}
@@ -0,0 +1,187 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+const testBootstrapTemplate = `node:
+  id: {{.EnvoyNodeID}}
+  cluster: {{.EnvoyClusterID}}
+dynamic_resources:
+  ads_config:
+    api_type: GRPC
+    grpc_services:
+    - envoy_grpc:
+        cluster_name: {{.XDSClusterName}}
+static_resources:
+  clusters:
+  - name: {{.XDSClusterName}}
+    address: {{.XDSHost}}:{{.XDSPort}}
+    root_cert: {{.RootCert}}
+    cert: {{.Cert}}
+    key: {{.Key}}
+`
+
+func TestValidateBootstrapTemplate(t *testing.T) {
+	assert := tassert.New(t)
+
+	testCases := []struct {
+		name          string
+		template      string
+		expectedError bool
+	}{
+		{
+			name:          "empty template uses the default bootstrap config",
+			template:      "",
+			expectedError: false,
+		},
+		{
+			name:          "template with all required placeholders",
+			template:      testBootstrapTemplate,
+			expectedError: false,
+		},
+		{
+			name:          "required placeholders nested in conditionals",
+			template:      "{{if .XDSHost}}{{.XDSClusterName}} {{.XDSHost}}:{{.XDSPort}}{{end}}{{with .RootCert}}{{.}}{{end}}{{.RootCert}} {{.Cert}} {{.Key}}",
+			expectedError: false,
+		},
+		{
+			name:          "template missing the xDS address",
+			template:      "{{.XDSClusterName}} {{.RootCert}} {{.Cert}} {{.Key}}",
+			expectedError: true,
+		},
+		{
+			name:          "unparsable template",
+			template:      "{{.XDSHost",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBootstrapTemplate(tc.template)
+			assert.Equal(tc.expectedError, err != nil, err)
+		})
+	}
+}
+
+func TestRenderBootstrapTemplate(t *testing.T) {
+	assert := tassert.New(t)
+
+	config := envoyBootstrapConfigMeta{
+		XDSClusterName: "osm-controller",
+		XDSHost:        "osm-controller.osm-system.svc.cluster.local",
+		XDSPort:        15128,
+		RootCert:       "root",
+		Cert:           "cert",
+		Key:            "key",
+		EnvoyNodeID:    "bookbuyer",
+		EnvoyClusterID: "bookbuyer.default",
+	}
+
+	rendered, err := renderBootstrapTemplate(testBootstrapTemplate, config)
+	assert.Nil(err)
+	assert.Contains(string(rendered), "id: bookbuyer\n")
+	assert.Contains(string(rendered), "cluster: bookbuyer.default\n")
+	assert.Contains(string(rendered), "address: osm-controller.osm-system.svc.cluster.local:15128\n")
+	assert.Contains(string(rendered), "key: key\n")
+
+	_, err = renderBootstrapTemplate("{{.Unknown}}", config)
+	assert.NotNil(err)
+}
+
+func TestGetTemplatedEnvoyConfigYAML(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	bootstrapTemplate := testBootstrapTemplate + `layered_runtime:
+  layers:
+  - name: template_layer
+    static_layer:
+      envoy.reloadable_features.foo: true
+      overload.global_downstream_max_connections: 1000
+  - name: admin_layer
+    admin_layer: {}
+stats_sinks:
+- name: envoy.stat_sinks.metrics_service
+`
+	config := envoyBootstrapConfigMeta{
+		XDSClusterName:                 "osm-controller",
+		XDSHost:                        "osm-controller.osm-system.svc.cluster.local",
+		XDSPort:                        15128,
+		RootCert:                       "root",
+		Cert:                           "cert",
+		Key:                            "key",
+		EnvoyNodeID:                    "bookbuyer",
+		EnvoyClusterID:                 "bookbuyer.default",
+		EnvoyVersion:                   "v1.18.0",
+		MaxHeapSizeBytes:               268435456,
+		ShrinkHeapThreshold:            0.9,
+		StopAcceptingRequestsThreshold: 0.95,
+		InboundConnectionLimit:         100,
+	}
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(true).Times(1)
+	mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+	mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(map[string]string{"overload.global_downstream_max_connections": "50000"}).Times(1)
+	mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return([]configurator.StatsSink{{Type: configurator.StatsdSinkType, Address: "10.0.0.10", Port: 8125}}).Times(1)
+	mockConfigurator.EXPECT().GetEnvoyStatsFlushInterval().Return(10 * time.Second).Times(1)
+
+	actual, err := getTemplatedEnvoyConfigYAML(bootstrapTemplate, config, mockConfigurator)
+	assert.Nil(err)
+
+	bootstrap := make(map[interface{}]interface{})
+	assert.Nil(yaml.Unmarshal(actual, &bootstrap))
+
+	// The values rendered from the template are kept
+	assert.Equal(map[interface{}]interface{}{"id": "bookbuyer", "cluster": "bookbuyer.default"}, bootstrap["node"])
+
+	// The sidecar connects to the xDS server with incremental xDS
+	assert.Equal("DELTA_GRPC", getBootstrapSection(getBootstrapSection(bootstrap, "dynamic_resources"), "ads_config")["api_type"])
+
+	// The stats sinks are added to the sinks of the template
+	statsSinks := bootstrap["stats_sinks"].([]interface{})
+	assert.Len(statsSinks, 2)
+	assert.Equal("envoy.stat_sinks.statsd", statsSinks[1].(map[interface{}]interface{})["name"])
+	assert.Equal("10s", bootstrap["stats_flush_interval"])
+
+	// The runtime values are merged into the static layer of the template, overriding the values it sets
+	layers := getBootstrapSection(bootstrap, "layered_runtime")["layers"].([]interface{})
+	assert.Len(layers, 2)
+	assert.Equal(map[interface{}]interface{}{
+		"envoy.reloadable_features.foo":                                    true,
+		"overload.global_downstream_max_connections":                       50000,
+		"envoy.resource_limits.listener.inbound-listener.connection_limit": 100,
+	}, getBootstrapSection(layers[0].(map[interface{}]interface{}), "static_layer"))
+
+	// The overload manager sheds load as the heap grows
+	assert.NotNil(bootstrap["overload_manager"])
+}
+
+func TestMergeStaticRuntimeLayer(t *testing.T) {
+	assert := tassert.New(t)
+
+	// A static runtime layer is added as the base layer of a template without one
+	templated := map[interface{}]interface{}{
+		"layered_runtime": map[interface{}]interface{}{
+			"layers": []interface{}{
+				map[interface{}]interface{}{"name": "admin_layer", "admin_layer": map[interface{}]interface{}{}},
+			},
+		},
+	}
+	mergeStaticRuntimeLayer(templated, map[interface{}]interface{}{"envoy.resource_limits.listener.inbound-listener.connection_limit": 100})
+
+	layers := getBootstrapSection(templated, "layered_runtime")["layers"].([]interface{})
+	assert.Len(layers, 2)
+	assert.Equal(staticRuntimeLayerName, layers[0].(map[interface{}]interface{})["name"])
+	assert.Equal("admin_layer", layers[1].(map[interface{}]interface{})["name"])
+}
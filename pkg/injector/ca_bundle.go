@@ -0,0 +1,47 @@
+package injector
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// caBundleKey is the key of the additional CA certificates in the configured ConfigMap and in the bootstrap config
+// secret of the Envoy sidecar, which is mounted in the sidecar
+const caBundleKey = "ca-bundle.pem"
+
+// getCABundle returns the additional CA certificates trusted by the Envoy sidecar for TLS connections to destinations
+// outside the mesh, read from the configured ConfigMap in the given OSM namespace. Nil is returned when no CA bundle is configured.
+func (wh *mutatingWebhook) getCABundle(osmNamespace string) ([]byte, error) {
+	if wh.config.SidecarCABundleConfigMap == "" {
+		return nil, nil
+	}
+
+	configMap, err := wh.kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.Background(), wh.config.SidecarCABundleConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting CA bundle ConfigMap %s/%s", osmNamespace, wh.config.SidecarCABundleConfigMap)
+	}
+
+	caBundle, ok := configMap.Data[caBundleKey]
+	if !ok || strings.TrimSpace(caBundle) == "" {
+		return nil, errors.Wrapf(errCABundleNotFound, "ConfigMap %s/%s has no %s key", osmNamespace, wh.config.SidecarCABundleConfigMap, caBundleKey)
+	}
+	return []byte(caBundle), nil
+}
+
+// getCABundleSecret returns the static secret of the bootstrap config validating the certificates of destinations
+// outside the mesh with the CA bundle mounted at the given path
+func getCABundleSecret(caBundlePath string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": envoy.ExternalCABundleSecretName,
+		"validation_context": map[string]interface{}{
+			"trusted_ca": map[string]interface{}{
+				"filename": caBundlePath,
+			},
+		},
+	}
+}
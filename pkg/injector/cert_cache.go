@@ -0,0 +1,216 @@
+package injector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// defaultBootstrapCertIssuanceTimeout is how long an admission request waits for the bootstrap certificate of the
+	// Envoy sidecar to be issued when no pre-issued certificate is cached, if not configured
+	defaultBootstrapCertIssuanceTimeout = 5 * time.Second
+
+	// bootstrapCertCacheIdleTimeout is how long a pre-issued certificate stays cached without being used before it is
+	// evicted, so that the cache does not hold certificates for service accounts no longer running proxies
+	bootstrapCertCacheIdleTimeout = 1 * time.Hour
+
+	// certCacheHit and certCacheMiss are the results of bootstrap certificate cache lookups, used as metric labels
+	certCacheHit  = "hit"
+	certCacheMiss = "miss"
+)
+
+// bootstrapCert is a bootstrap certificate of an Envoy sidecar, whose CN identifies the proxy with the given UUID
+type bootstrapCert struct {
+	proxyUUID uuid.UUID
+	cert      certificate.Certificater
+
+	// cachedAt is when the certificate was cached
+	cachedAt time.Time
+}
+
+// bootstrapCertCache pre-issues the bootstrap certificates of the Envoy sidecars, one per service account, so that
+// certificates are issued off the admission path. A certificate taken from the cache is replaced asynchronously.
+type bootstrapCertCache struct {
+	sync.Mutex
+
+	certManager certificate.Manager
+
+	// timeout is how long to wait for a certificate to be issued when none is cached
+	timeout time.Duration
+
+	// idleTimeout is how long a certificate stays cached without being used before it is evicted
+	idleTimeout time.Duration
+
+	// certs holds the pre-issued certificate of each service account
+	certs map[service.K8sServiceAccount]*bootstrapCert
+
+	// refilling holds the service accounts whose certificate is being pre-issued
+	refilling map[service.K8sServiceAccount]bool
+}
+
+func newBootstrapCertCache(certManager certificate.Manager, timeout time.Duration) *bootstrapCertCache {
+	if timeout <= 0 {
+		timeout = defaultBootstrapCertIssuanceTimeout
+	}
+	return &bootstrapCertCache{
+		certManager: certManager,
+		timeout:     timeout,
+		idleTimeout: bootstrapCertCacheIdleTimeout,
+		certs:       make(map[service.K8sServiceAccount]*bootstrapCert),
+		refilling:   make(map[service.K8sServiceAccount]bool),
+	}
+}
+
+// get returns a bootstrap certificate for a proxy running as the given service account. A cached certificate is
+// returned if any, otherwise the call blocks until a certificate is issued or the cache's timeout elapses. Either
+// way a certificate is pre-issued for the next proxy running as the service account.
+func (c *bootstrapCertCache) get(sa service.K8sServiceAccount) (*bootstrapCert, error) {
+	c.Lock()
+	cached, ok := c.certs[sa]
+	delete(c.certs, sa)
+	c.updateSizeMetric()
+	c.Unlock()
+
+	if ok {
+		metricsstore.DefaultMetricsStore.InjectorBootstrapCertCacheLookupCount.WithLabelValues(certCacheHit).Inc()
+		go c.refill(sa)
+		return cached, nil
+	}
+	metricsstore.DefaultMetricsStore.InjectorBootstrapCertCacheLookupCount.WithLabelValues(certCacheMiss).Inc()
+
+	type result struct {
+		cert *bootstrapCert
+		err  error
+	}
+	issued := make(chan result, 1)
+	go func() {
+		cert, err := c.issue(sa)
+		issued <- result{cert: cert, err: err}
+	}()
+
+	select {
+	case res := <-issued:
+		if res.err == nil {
+			go c.refill(sa)
+		}
+		return res.cert, res.err
+	case <-time.After(c.timeout):
+		// The certificate being issued is cached for the next proxy running as the service account
+		go func() {
+			if res := <-issued; res.err == nil {
+				c.store(sa, res.cert)
+			}
+		}()
+		return nil, errors.Wrapf(errBootstrapCertTimeout, "no bootstrap certificate issued for service account %s within %s", sa, c.timeout)
+	}
+}
+
+// refill pre-issues a certificate for the given service account, unless one is cached or being issued
+func (c *bootstrapCertCache) refill(sa service.K8sServiceAccount) {
+	c.Lock()
+	if _, ok := c.certs[sa]; ok || c.refilling[sa] {
+		c.Unlock()
+		return
+	}
+	c.refilling[sa] = true
+	c.Unlock()
+
+	cert, err := c.issue(sa)
+
+	c.Lock()
+	delete(c.refilling, sa)
+	c.Unlock()
+
+	if err != nil {
+		log.Error().Err(err).Msgf("Error pre-issuing bootstrap certificate for service account %s", sa)
+		return
+	}
+	c.store(sa, cert)
+}
+
+// store caches the given certificate for the given service account, unless one is already cached, in which case the
+// given certificate is released
+func (c *bootstrapCertCache) store(sa service.K8sServiceAccount, cert *bootstrapCert) {
+	c.Lock()
+	_, ok := c.certs[sa]
+	if !ok {
+		cert.cachedAt = time.Now()
+		c.certs[sa] = cert
+		c.updateSizeMetric()
+	}
+	c.Unlock()
+
+	if ok {
+		c.certManager.ReleaseCertificate(cert.cert.GetCommonName())
+	}
+}
+
+// run evicts the certificates cached for longer than the cache's idle timeout until the given channel is closed
+func (c *bootstrapCertCache) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(c.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.evictIdle()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// evictIdle evicts the certificates cached for longer than the cache's idle timeout and releases them, as they are no
+// longer going to be used by proxies
+func (c *bootstrapCertCache) evictIdle() {
+	var evicted []*bootstrapCert
+	c.Lock()
+	for sa, cert := range c.certs {
+		if time.Since(cert.cachedAt) >= c.idleTimeout {
+			evicted = append(evicted, cert)
+			delete(c.certs, sa)
+		}
+	}
+	c.updateSizeMetric()
+	c.Unlock()
+
+	for _, cert := range evicted {
+		log.Debug().Msgf("Evicting pre-issued bootstrap certificate with CN=%s unused for %s", cert.cert.GetCommonName(), c.idleTimeout)
+		c.certManager.ReleaseCertificate(cert.cert.GetCommonName())
+	}
+}
+
+// updateSizeMetric sets the metric of the number of cached certificates, the caller must hold the cache's lock
+func (c *bootstrapCertCache) updateSizeMetric() {
+	metricsstore.DefaultMetricsStore.InjectorBootstrapCertCacheSize.Set(float64(len(c.certs)))
+}
+
+// issue issues a bootstrap certificate for a new proxy running as the given service account
+func (c *bootstrapCertCache) issue(sa service.K8sServiceAccount) (*bootstrapCert, error) {
+	return issueBootstrapCert(c.certManager, uuid.New(), sa)
+}
+
+// issueBootstrapCert issues the bootstrap certificate of the proxy with the given UUID running as the given service account
+func issueBootstrapCert(certManager certificate.Manager, proxyUUID uuid.UUID, sa service.K8sServiceAccount) (*bootstrapCert, error) {
+	cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, sa.Name, sa.Namespace)
+	startTime := time.Now()
+	cert, err := certManager.IssueCertificate(cn, constants.XDSCertificateValidityPeriod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error issuing bootstrap certificate for Envoy with CN=%s", cn)
+		return nil, err
+	}
+	elapsed := time.Since(startTime)
+
+	metricsstore.DefaultMetricsStore.CertXdsIssuedCount.Inc()
+	metricsstore.DefaultMetricsStore.CertXdsIssuedTime.
+		WithLabelValues().Observe(elapsed.Seconds())
+	return &bootstrapCert{proxyUUID: proxyUUID, cert: cert}, nil
+}
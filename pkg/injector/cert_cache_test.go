@@ -0,0 +1,118 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// isCached returns true if a pre-issued certificate is cached for the given service account
+func (c *bootstrapCertCache) isCached(sa service.K8sServiceAccount) bool {
+	c.Lock()
+	defer c.Unlock()
+	_, ok := c.certs[sa]
+	return ok
+}
+
+func TestBootstrapCertCacheGet(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	certManager := tresor.NewFakeCertManager(configurator.NewMockConfigurator(mockCtrl))
+	cache := newBootstrapCertCache(certManager, time.Second)
+	sa := service.K8sServiceAccount{Name: "bookbuyer", Namespace: "default"}
+
+	hits := testutil.ToFloat64(metricsstore.DefaultMetricsStore.InjectorBootstrapCertCacheLookupCount.WithLabelValues(certCacheHit))
+	misses := testutil.ToFloat64(metricsstore.DefaultMetricsStore.InjectorBootstrapCertCacheLookupCount.WithLabelValues(certCacheMiss))
+
+	// Without a cached certificate, the certificate is issued on the admission path
+	first, err := cache.get(sa)
+	assert.Nil(err)
+	assert.Equal(catalog.NewCertCommonNameWithProxyID(first.proxyUUID, sa.Name, sa.Namespace), first.cert.GetCommonName())
+	assert.Equal(misses+1, testutil.ToFloat64(metricsstore.DefaultMetricsStore.InjectorBootstrapCertCacheLookupCount.WithLabelValues(certCacheMiss)))
+
+	// A certificate is pre-issued for the next proxy running as the service account
+	assert.Eventually(func() bool { return cache.isCached(sa) }, time.Second, 10*time.Millisecond)
+
+	cached, err := cache.get(sa)
+	assert.Nil(err)
+	assert.NotEqual(first.proxyUUID, cached.proxyUUID)
+	assert.Equal(catalog.NewCertCommonNameWithProxyID(cached.proxyUUID, sa.Name, sa.Namespace), cached.cert.GetCommonName())
+	assert.Equal(hits+1, testutil.ToFloat64(metricsstore.DefaultMetricsStore.InjectorBootstrapCertCacheLookupCount.WithLabelValues(certCacheHit)))
+
+	// The certificates are not shared across service accounts
+	assert.False(cache.isCached(service.K8sServiceAccount{Name: "bookstore", Namespace: "default"}))
+}
+
+func TestBootstrapCertCacheTimeout(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	fakeCertManager := tresor.NewFakeCertManager(configurator.NewMockConfigurator(mockCtrl))
+	slowCertManager := certificate.NewMockManager(mockCtrl)
+	slowCertManager.EXPECT().IssueCertificate(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(cn certificate.CommonName, validity time.Duration) (certificate.Certificater, error) {
+			time.Sleep(200 * time.Millisecond)
+			return fakeCertManager.IssueCertificate(cn, validity)
+		}).AnyTimes()
+
+	cache := newBootstrapCertCache(slowCertManager, 50*time.Millisecond)
+	sa := service.K8sServiceAccount{Name: "bookbuyer", Namespace: "default"}
+
+	// The admission request does not wait for the certificate longer than the timeout
+	cert, err := cache.get(sa)
+	assert.Nil(cert)
+	assert.True(errors.Is(err, errBootstrapCertTimeout))
+
+	// The certificate issued after the timeout is cached for the next proxy
+	assert.Eventually(func() bool { return cache.isCached(sa) }, time.Second, 10*time.Millisecond)
+	cert, err = cache.get(sa)
+	assert.Nil(err)
+	assert.Equal(catalog.NewCertCommonNameWithProxyID(cert.proxyUUID, sa.Name, sa.Namespace), cert.cert.GetCommonName())
+}
+
+func TestBootstrapCertCacheEvictIdle(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	certManager := tresor.NewFakeCertManager(configurator.NewMockConfigurator(mockCtrl))
+	cache := newBootstrapCertCache(certManager, time.Second)
+	cache.idleTimeout = time.Hour
+	idle := service.K8sServiceAccount{Name: "bookbuyer", Namespace: "default"}
+	recent := service.K8sServiceAccount{Name: "bookstore", Namespace: "default"}
+
+	idleCert, err := cache.issue(idle)
+	assert.Nil(err)
+	cache.store(idle, idleCert)
+	recentCert, err := cache.issue(recent)
+	assert.Nil(err)
+	cache.store(recent, recentCert)
+
+	cache.Lock()
+	cache.certs[idle].cachedAt = time.Now().Add(-2 * time.Hour)
+	cache.Unlock()
+
+	// Only the certificate cached for longer than the idle timeout is evicted and released
+	cache.evictIdle()
+	assert.False(cache.isCached(idle))
+	assert.True(cache.isCached(recent))
+
+	_, err = certManager.GetCertificate(idleCert.cert.GetCommonName())
+	assert.NotNil(err)
+	_, err = certManager.GetCertificate(recentCert.cert.GetCommonName())
+	assert.Nil(err)
+}
@@ -0,0 +1,35 @@
+package injector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// getInboundConnectionLimit returns the maximum number of concurrent connections the inbound listener of the sidecar
+// of the given pod accepts, as set by the pod's inbound connection limit annotation, or 0 if not set for the number of
+// connections to be unlimited.
+func getInboundConnectionLimit(pod *corev1.Pod) (uint32, error) {
+	value, ok := pod.Annotations[constants.InboundConnectionLimitAnnotation]
+	if !ok {
+		return 0, nil
+	}
+
+	maxConnections, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	if err != nil || maxConnections == 0 {
+		return 0, errors.Errorf("Invalid value %q for annotation %s, must be a positive integer", value, constants.InboundConnectionLimitAnnotation)
+	}
+	return uint32(maxConnections), nil
+}
+
+// getListenerConnectionLimitRuntimeKey returns the runtime key limiting the number of concurrent connections the
+// listener with the given name accepts. Envoy rejects the connections beyond the limit across all the filter chains
+// of the listener.
+func getListenerConnectionLimitRuntimeKey(listenerName string) string {
+	return fmt.Sprintf("envoy.resource_limits.listener.%s.connection_limit", listenerName)
+}
@@ -0,0 +1,67 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetInboundConnectionLimit(t *testing.T) {
+	testCases := []struct {
+		name          string
+		annotations   map[string]string
+		expectedLimit uint32
+		expectError   bool
+	}{
+		{
+			name:          "no annotation",
+			annotations:   nil,
+			expectedLimit: 0,
+		},
+		{
+			name:          "valid limit",
+			annotations:   map[string]string{constants.InboundConnectionLimitAnnotation: " 1024 "},
+			expectedLimit: 1024,
+		},
+		{
+			name:        "zero limit",
+			annotations: map[string]string{constants.InboundConnectionLimitAnnotation: "0"},
+			expectError: true,
+		},
+		{
+			name:        "negative limit",
+			annotations: map[string]string{constants.InboundConnectionLimitAnnotation: "-1"},
+			expectError: true,
+		},
+		{
+			name:        "limit overflowing uint32",
+			annotations: map[string]string{constants.InboundConnectionLimitAnnotation: "4294967296"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			limit, err := getInboundConnectionLimit(pod)
+			assert.Equal(tc.expectError, err != nil)
+			assert.Equal(tc.expectedLimit, limit)
+		})
+	}
+}
+
+func TestGetListenerConnectionLimitRuntimeKey(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Equal("envoy.resource_limits.listener.inbound-listener.connection_limit", getListenerConnectionLimitRuntimeKey(constants.EnvoyInboundListenerName))
+}
@@ -0,0 +1,95 @@
+package injector
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// envoyHealthcheckFailPath is the path on Envoy's admin interface which fails Envoy's readiness
+	envoyHealthcheckFailPath = "/healthcheck/fail"
+
+	// envoyDrainInboundListenersPath is the path on Envoy's admin interface which gracefully drains the inbound
+	// listeners, closing them to new connections once the drain time elapses
+	envoyDrainInboundListenersPath = "/drain_listeners?graceful&inboundonly"
+
+	// defaultTerminationGracePeriodSeconds is the termination grace period Kubernetes applies when a pod sets none
+	defaultTerminationGracePeriodSeconds = int64(30)
+)
+
+// ValidateDrainConfig returns an error if the drain timings of the given config are negative
+func ValidateDrainConfig(config Config) error {
+	if config.SidecarDrainReadinessDelay < 0 {
+		return errors.Errorf("Invalid sidecar drain readiness delay %s, must not be negative", config.SidecarDrainReadinessDelay)
+	}
+	if config.SidecarDrainDuration < 0 {
+		return errors.Errorf("Invalid sidecar drain duration %s, must not be negative", config.SidecarDrainDuration)
+	}
+	return nil
+}
+
+// applyDrainConfig configures the given Envoy sidecar of the given pod to drain gracefully when the pod terminates, if
+// enabled in the given config. On termination, the sidecar's preStop hook first fails Envoy's readiness, so that the
+// pod is removed from the endpoints of its services, then gracefully drains the inbound listeners after the readiness
+// delay, which stops new inbound connections, and finally waits for the drain duration for in-flight connections to
+// complete before the sidecar is stopped. The pod's termination grace period is extended to cover the sequence.
+func applyDrainConfig(pod *corev1.Pod, sidecar *corev1.Container, config Config) {
+	if !config.SidecarDrainOnTermination {
+		return
+	}
+
+	readinessDelaySeconds := durationToSeconds(config.SidecarDrainReadinessDelay)
+	drainSeconds := durationToSeconds(config.SidecarDrainDuration)
+
+	// The readiness probe reports the failed readiness of a draining Envoy to Kubernetes
+	sidecar.ReadinessProbe = &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: envoyReadyPath,
+				Port: intstr.FromInt(constants.EnvoyAdminPort),
+			},
+		},
+		PeriodSeconds:    1,
+		FailureThreshold: 1,
+	}
+
+	// The preStop hook posts to Envoy's admin interface with the BusyBox wget of the envoy-alpine sidecar image, as the
+	// image does not ship curl
+	adminURL := fmt.Sprintf("http://127.0.0.1:%d", constants.EnvoyAdminPort)
+	sidecar.Lifecycle = &corev1.Lifecycle{
+		PreStop: &corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"sh", "-c", fmt.Sprintf("wget -q -O /dev/null --post-data '' '%s%s'; sleep %d; wget -q -O /dev/null --post-data '' '%s%s'; sleep %d",
+					adminURL, envoyHealthcheckFailPath, readinessDelaySeconds, adminURL, envoyDrainInboundListenersPath, drainSeconds)},
+			},
+		},
+	}
+
+	// Signal the draining of connections to the clients immediately, and close the listeners after the drain duration
+	sidecar.Args = append(sidecar.Args,
+		"--drain-time-s", strconv.FormatInt(drainSeconds, 10),
+		"--drain-strategy", "immediate",
+	)
+
+	gracePeriodSeconds := defaultTerminationGracePeriodSeconds
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		gracePeriodSeconds = *pod.Spec.TerminationGracePeriodSeconds
+	}
+	if requiredSeconds := readinessDelaySeconds + drainSeconds; gracePeriodSeconds < requiredSeconds {
+		log.Debug().Msgf("Extending termination grace period of pod %s/%s from %ds to %ds to drain the Envoy sidecar", pod.Namespace, pod.Name, gracePeriodSeconds, requiredSeconds)
+		pod.Spec.TerminationGracePeriodSeconds = &requiredSeconds
+	}
+}
+
+// durationToSeconds returns the given duration in seconds, rounded up
+func durationToSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
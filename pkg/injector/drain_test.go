@@ -0,0 +1,91 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestValidateDrainConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Nil(ValidateDrainConfig(Config{}))
+	assert.Nil(ValidateDrainConfig(Config{SidecarDrainReadinessDelay: 5 * time.Second, SidecarDrainDuration: 20 * time.Second}))
+	assert.NotNil(ValidateDrainConfig(Config{SidecarDrainReadinessDelay: -time.Second}))
+	assert.NotNil(ValidateDrainConfig(Config{SidecarDrainDuration: -time.Second}))
+}
+
+func TestApplyDrainConfig(t *testing.T) {
+	int64Ptr := func(i int64) *int64 {
+		return &i
+	}
+
+	testCases := []struct {
+		name                       string
+		config                     Config
+		gracePeriodSeconds         *int64
+		expectDrain                bool
+		expectedPreStopCommand     string
+		expectedGracePeriodSeconds *int64
+	}{
+		{
+			name:                       "draining disabled",
+			config:                     Config{SidecarDrainReadinessDelay: 5 * time.Second, SidecarDrainDuration: 20 * time.Second},
+			gracePeriodSeconds:         nil,
+			expectDrain:                false,
+			expectedGracePeriodSeconds: nil,
+		},
+		{
+			name: "draining within the default grace period",
+			config: Config{
+				SidecarDrainOnTermination:  true,
+				SidecarDrainReadinessDelay: 5 * time.Second,
+				SidecarDrainDuration:       20 * time.Second,
+			},
+			gracePeriodSeconds:         nil,
+			expectDrain:                true,
+			expectedPreStopCommand:     "wget -q -O /dev/null --post-data '' 'http://127.0.0.1:15000/healthcheck/fail'; sleep 5; wget -q -O /dev/null --post-data '' 'http://127.0.0.1:15000/drain_listeners?graceful&inboundonly'; sleep 20",
+			expectedGracePeriodSeconds: nil,
+		},
+		{
+			name: "draining extending the grace period of the pod",
+			config: Config{
+				SidecarDrainOnTermination:  true,
+				SidecarDrainReadinessDelay: 1500 * time.Millisecond,
+				SidecarDrainDuration:       time.Minute,
+			},
+			gracePeriodSeconds:         int64Ptr(45),
+			expectDrain:                true,
+			expectedPreStopCommand:     "wget -q -O /dev/null --post-data '' 'http://127.0.0.1:15000/healthcheck/fail'; sleep 2; wget -q -O /dev/null --post-data '' 'http://127.0.0.1:15000/drain_listeners?graceful&inboundonly'; sleep 60",
+			expectedGracePeriodSeconds: int64Ptr(62),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{Spec: corev1.PodSpec{TerminationGracePeriodSeconds: tc.gracePeriodSeconds}}
+			sidecar := &corev1.Container{Args: []string{"--log-level", "debug"}}
+			applyDrainConfig(pod, sidecar, tc.config)
+
+			assert.Equal(tc.expectedGracePeriodSeconds, pod.Spec.TerminationGracePeriodSeconds)
+			if !tc.expectDrain {
+				assert.Nil(sidecar.Lifecycle)
+				assert.Nil(sidecar.ReadinessProbe)
+				assert.Equal([]string{"--log-level", "debug"}, sidecar.Args)
+				return
+			}
+
+			assert.Equal([]string{"sh", "-c", tc.expectedPreStopCommand}, sidecar.Lifecycle.PreStop.Exec.Command)
+			assert.Equal(envoyReadyPath, sidecar.ReadinessProbe.HTTPGet.Path)
+			assert.Equal(int32(constants.EnvoyAdminPort), sidecar.ReadinessProbe.HTTPGet.Port.IntVal)
+			assert.Contains(sidecar.Args, "--drain-time-s")
+			assert.Contains(sidecar.Args, "immediate")
+		})
+	}
+}
@@ -3,8 +3,9 @@ package injector
 import (
 	"context"
 	"encoding/base64"
-	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -15,7 +16,27 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
+// staticRuntimeLayerName is the name of the static runtime layer of the Envoy bootstrap config
+const staticRuntimeLayerName = "static_layer"
+
 func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Configurator) ([]byte, error) {
+	m := getEnvoyConfig(config, cfg)
+	configYAML, err := yaml.Marshal(&m)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshaling Envoy config struct into YAML")
+		return nil, err
+	}
+	return configYAML, err
+}
+
+// getEnvoyConfig returns the bootstrap config of the Envoy sidecar generated for the given per-pod bootstrap values
+func getEnvoyConfig(config envoyBootstrapConfigMeta, cfg configurator.Configurator) map[interface{}]interface{} {
+	// Use incremental xDS if enabled, state-of-the-world xDS otherwise
+	adsAPIType := "GRPC"
+	if cfg.IsDeltaXDSEnabled() {
+		adsAPIType = "DELTA_GRPC"
+	}
+
 	m := map[interface{}]interface{}{
 		"admin": map[string]interface{}{
 			"access_log_path": "/dev/stdout",
@@ -29,7 +50,7 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 
 		"dynamic_resources": map[string]interface{}{
 			"ads_config": map[string]interface{}{
-				"api_type":              "GRPC",
+				"api_type":              adsAPIType,
 				"transport_api_version": "V3",
 				"grpc_services": []map[string]interface{}{
 					{
@@ -53,12 +74,103 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 
 	m["static_resources"] = getStaticResources(config)
 
-	configYAML, err := yaml.Marshal(&m)
-	if err != nil {
-		log.Error().Err(err).Msgf("Error marshaling Envoy config struct into YAML")
-		return nil, err
+	if statsTags := getStatsTags(cfg.GetEnvoyStatsTags()); len(statsTags) > 0 {
+		m["stats_config"] = map[string]interface{}{
+			"stats_tags": statsTags,
+		}
 	}
-	return configYAML, err
+
+	// Stats are exposed to Prometheus by the admin interface, and are additionally flushed to the configured sinks
+	if statsSinks := getStatsSinks(cfg.GetEnvoyStatsSinks()); len(statsSinks) > 0 {
+		m["stats_sinks"] = statsSinks
+		if flushInterval := cfg.GetEnvoyStatsFlushInterval(); flushInterval > 0 {
+			m["stats_flush_interval"] = flushInterval.String()
+		}
+	}
+
+	// The static runtime layer toggles Envoy runtime features, e.g. envoy.reloadable_features.* feature flags, and
+	// limits the connections of the inbound listener
+	runtimeLayer := getStaticRuntimeLayer(cfg.GetEnvoyRuntimeFlags())
+	if config.InboundConnectionLimit > 0 {
+		runtimeLayer[getListenerConnectionLimitRuntimeKey(constants.EnvoyInboundListenerName)] = config.InboundConnectionLimit
+	}
+	if len(runtimeLayer) > 0 {
+		m["layered_runtime"] = map[string]interface{}{
+			"layers": []map[string]interface{}{
+				{
+					"name":         staticRuntimeLayerName,
+					"static_layer": runtimeLayer,
+				},
+			},
+		}
+	}
+
+	// The overload manager sheds load as the heap of the sidecar grows towards its maximum size
+	if config.MaxHeapSizeBytes > 0 {
+		m["overload_manager"] = getOverloadManager(config)
+	}
+
+	return m
+}
+
+// getStatsTags returns the custom tag extraction rules for Envoy stats, sorted by tag name
+func getStatsTags(tagToRegex map[string]string) []map[string]interface{} {
+	var tagNames []string
+	for tagName := range tagToRegex {
+		tagNames = append(tagNames, tagName)
+	}
+	sort.Strings(tagNames)
+
+	var statsTags []map[string]interface{}
+	for _, tagName := range tagNames {
+		statsTags = append(statsTags, map[string]interface{}{
+			"tag_name": tagName,
+			"regex":    tagToRegex[tagName],
+		})
+	}
+	return statsTags
+}
+
+// getStaticRuntimeLayer returns the static runtime layer setting the given runtime flags. Boolean and numeric values
+// are set as such, since Envoy does not parse the feature flags set as strings.
+func getStaticRuntimeLayer(runtimeFlags map[string]string) map[string]interface{} {
+	runtimeLayer := make(map[string]interface{})
+	for key, value := range runtimeFlags {
+		// strconv.ParseBool is not used as it parses the numeric values 0 and 1 as booleans
+		if value == "true" || value == "false" {
+			runtimeLayer[key] = value == "true"
+		} else if numericValue, err := strconv.ParseFloat(value, 64); err == nil {
+			runtimeLayer[key] = numericValue
+		} else {
+			runtimeLayer[key] = value
+		}
+	}
+	return runtimeLayer
+}
+
+// getStatsSinks returns the Envoy stats sinks for the given sinks
+func getStatsSinks(sinks []configurator.StatsSink) []map[string]interface{} {
+	var statsSinks []map[string]interface{}
+	for _, sink := range sinks {
+		name, typeURL := "envoy.stat_sinks.statsd", "type.googleapis.com/envoy.config.metrics.v3.StatsdSink"
+		if sink.Type == configurator.DogStatsdSinkType {
+			name, typeURL = "envoy.stat_sinks.dog_statsd", "type.googleapis.com/envoy.config.metrics.v3.DogStatsdSink"
+		}
+
+		statsSinks = append(statsSinks, map[string]interface{}{
+			"name": name,
+			"typed_config": map[string]interface{}{
+				"@type": typeURL,
+				"address": map[string]interface{}{
+					"socket_address": map[string]interface{}{
+						"address":    sink.Address,
+						"port_value": sink.Port,
+					},
+				},
+			},
+		})
+	}
+	return statsSinks
 }
 
 // getStaticResources returns STATIC resources included in the bootstrap Envoy config.
@@ -94,6 +206,13 @@ func getStaticResources(config envoyBootstrapConfigMeta) map[string]interface{}
 		"clusters": clusters,
 	}
 
+	// Is there a CA bundle trusted for destinations outside the mesh?
+	if config.CABundlePath != "" {
+		staticResources["secrets"] = []map[string]interface{}{
+			getCABundleSecret(config.CABundlePath),
+		}
+	}
+
 	if len(listeners) > 0 {
 		staticResources["listeners"] = listeners
 	}
@@ -101,7 +220,13 @@ func getStaticResources(config envoyBootstrapConfigMeta) map[string]interface{}
 	return staticResources
 }
 
-func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert certificate.Certificater, originalHealthProbes healthProbes) (*corev1.Secret, error) {
+func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace, envoyNodeID, envoyClusterID, xdsHost string, xdsPort int, cert certificate.Certificater, originalHealthProbes healthProbes, inboundConnectionLimit uint32, envoyVersion string) (*corev1.Secret, error) {
+	caBundle, err := wh.getCABundle(osmNamespace)
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting the CA bundle of the Envoy sidecar")
+		return nil, err
+	}
+
 	configMeta := envoyBootstrapConfigMeta{
 		EnvoyAdminPort: constants.EnvoyAdminPort,
 		XDSClusterName: constants.OSMControllerName,
@@ -110,14 +235,35 @@ func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamesp
 		Cert:     base64.StdEncoding.EncodeToString(cert.GetCertificateChain()),
 		Key:      base64.StdEncoding.EncodeToString(cert.GetPrivateKey()),
 
-		XDSHost: fmt.Sprintf("%s.%s.svc.cluster.local", constants.OSMControllerName, osmNamespace),
-		XDSPort: constants.OSMControllerPort,
+		XDSHost: xdsHost,
+		XDSPort: xdsPort,
+
+		EnvoyNodeID:    envoyNodeID,
+		EnvoyClusterID: envoyClusterID,
 
 		// OriginalHealthProbes stores the path and port for liveness, readiness, and startup health probes as initially
 		// defined on the Pod Spec.
 		OriginalHealthProbes: originalHealthProbes,
+
+		EnvoyVersion: envoyVersion,
+
+		MaxHeapSizeBytes:               wh.config.SidecarMaxHeapSizeBytes,
+		ShrinkHeapThreshold:            wh.config.SidecarShrinkHeapThreshold,
+		StopAcceptingRequestsThreshold: wh.config.SidecarStopAcceptingRequestsThreshold,
+
+		InboundConnectionLimit: inboundConnectionLimit,
+	}
+	if caBundle != nil {
+		// The CA bundle is stored in the bootstrap config secret, which is mounted in the sidecar
+		configMeta.CABundlePath = strings.Join([]string{envoyProxyConfigPath, caBundleKey}, "/")
+	}
+
+	var yamlContent []byte
+	if wh.config.BootstrapTemplate != "" {
+		yamlContent, err = getTemplatedEnvoyConfigYAML(wh.config.BootstrapTemplate, configMeta, wh.configurator)
+	} else {
+		yamlContent, err = getEnvoyConfigYAML(configMeta, wh.configurator)
 	}
-	yamlContent, err := getEnvoyConfigYAML(configMeta, wh.configurator)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating Envoy bootstrap YAML")
 		return nil, err
@@ -131,6 +277,9 @@ func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamesp
 			envoyBootstrapConfigFile: yamlContent,
 		},
 	}
+	if caBundle != nil {
+		secret.Data[caBundleKey] = caBundle
+	}
 	if existing, err := wh.kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{}); err == nil {
 		log.Debug().Msgf("Updating bootstrap config Envoy: name=%s, namespace=%s", name, namespace)
 		existing.Data = secret.Data
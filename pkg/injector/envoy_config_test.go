@@ -3,17 +3,27 @@ package injector
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	mapset "github.com/deckarep/golang-set"
+	xds_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
+	xds_fixed_heap "github.com/envoyproxy/go-control-plane/envoy/config/resource_monitor/fixed_heap/v2alpha"
+	_ "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
+	k8syaml "sigs.k8s.io/yaml"
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -168,6 +178,10 @@ static_resources:
 
 	Context("create envoy config", func() {
 		It("creates envoy config", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
 			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
 
@@ -175,17 +189,214 @@ static_resources:
 				fmt.Sprintf("Expected:\n%s\nActual:\n%s\n", expectedEnvoyConfig, string(actual)))
 		})
 
+		It("creates envoy config using delta xDS when enabled", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			expected := strings.Replace(expectedEnvoyConfig, "api_type: GRPC", "api_type: DELTA_GRPC", 1)
+			Expect(string(actual)).To(Equal(expected))
+		})
+
+		It("creates envoy config with the configured stats tags", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(map[string]string{
+				"service_namespace": `^cluster\.((.+?)\.)`,
+				"app":               `^http\.((.+?)\.)`,
+			}).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(HaveSuffix(`stats_config:
+  stats_tags:
+  - regex: ^http\.((.+?)\.)
+    tag_name: app
+  - regex: ^cluster\.((.+?)\.)
+    tag_name: service_namespace
+`))
+		})
+
+		It("creates envoy config with a static runtime layer setting the configured runtime flags", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(map[string]string{
+				"envoy.reloadable_features.http_reject_path_with_fragment": "false",
+				"overload.global_downstream_max_connections":               "50000",
+				"envoy.deprecated_features:envoy.foo":                      "allowed",
+			}).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(ContainSubstring(`layered_runtime:
+  layers:
+  - name: static_layer
+    static_layer:
+      envoy.deprecated_features:envoy.foo: allowed
+      envoy.reloadable_features.http_reject_path_with_fragment: false
+      overload.global_downstream_max_connections: 50000
+`))
+		})
+
+		It("creates envoy config limiting the connections of the inbound listener in the static runtime layer", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(map[string]string{
+				"envoy.resource_limits.listener.inbound-listener.connection_limit": "50",
+			}).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			limitedConfig := config
+			limitedConfig.InboundConnectionLimit = 100
+			actual, err := getEnvoyConfigYAML(limitedConfig, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			// The limit set on the pod overrides the runtime flags
+			Expect(string(actual)).To(ContainSubstring(`layered_runtime:
+  layers:
+  - name: static_layer
+    static_layer:
+      envoy.resource_limits.listener.inbound-listener.connection_limit: 100
+`))
+		})
+
+		It("creates envoy config without a runtime layer when no runtime flag is configured", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(map[string]string{}).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).ToNot(ContainSubstring("layered_runtime"))
+		})
+
+		It("creates envoy config with the configured stats sinks and flush interval", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return([]configurator.StatsSink{
+				{Type: configurator.StatsdSinkType, Address: "10.0.0.10", Port: 8125},
+				{Type: configurator.DogStatsdSinkType, Address: "10.0.0.11", Port: 8126},
+			}).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsFlushInterval().Return(10 * time.Second).Times(1)
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(ContainSubstring(`stats_flush_interval: 10s
+stats_sinks:
+- name: envoy.stat_sinks.statsd
+  typed_config:
+    '@type': type.googleapis.com/envoy.config.metrics.v3.StatsdSink
+    address:
+      socket_address:
+        address: 10.0.0.10
+        port_value: 8125
+- name: envoy.stat_sinks.dog_statsd
+  typed_config:
+    '@type': type.googleapis.com/envoy.config.metrics.v3.DogStatsdSink
+    address:
+      socket_address:
+        address: 10.0.0.11
+        port_value: 8126
+`))
+		})
+
+		It("creates envoy config with the overload manager when a maximum heap size is configured", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			overloadConfig := config
+			overloadConfig.MaxHeapSizeBytes = 268435456
+			overloadConfig.ShrinkHeapThreshold = 0.9
+			overloadConfig.StopAcceptingRequestsThreshold = 0.95
+			actual, err := getEnvoyConfigYAML(overloadConfig, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(ContainSubstring(`overload_manager:
+  actions:
+  - name: envoy.overload_actions.shrink_heap
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: 0.9
+  - name: envoy.overload_actions.stop_accepting_requests
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: 0.95
+  refresh_interval: 0.25s
+  resource_monitors:
+  - name: envoy.resource_monitors.fixed_heap
+    typed_config:
+      '@type': type.googleapis.com/envoy.extensions.resource_monitors.fixed_heap.v3.FixedHeapConfig
+      max_heap_size_bytes: 268435456
+`))
+		})
+
+		It("creates envoy config with the overload manager understood by Envoy versions preceding its v3 config", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			overloadConfig := config
+			overloadConfig.EnvoyVersion = "v1.17.0"
+			overloadConfig.MaxHeapSizeBytes = 268435456
+			overloadConfig.ShrinkHeapThreshold = 0.9
+			overloadConfig.StopAcceptingRequestsThreshold = 0.95
+			actual, err := getEnvoyConfigYAML(overloadConfig, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(ContainSubstring(`      '@type': type.googleapis.com/envoy.config.resource_monitor.fixed_heap.v2alpha.FixedHeapConfig
+`))
+
+			// The rendered bootstrap is a valid Envoy bootstrap config
+			configJSON, err := k8syaml.YAMLToJSON(actual)
+			Expect(err).ToNot(HaveOccurred())
+			bootstrap := &xds_bootstrap.Bootstrap{}
+			Expect(protojson.Unmarshal(configJSON, bootstrap)).To(Succeed())
+			Expect(bootstrap.Validate()).To(Succeed())
+			Expect(bootstrap.OverloadManager.ResourceMonitors).To(HaveLen(1))
+			fixedHeapConfig := &xds_fixed_heap.FixedHeapConfig{}
+			Expect(ptypes.UnmarshalAny(bootstrap.OverloadManager.ResourceMonitors[0].GetTypedConfig(), fixedHeapConfig)).To(Succeed())
+			Expect(fixedHeapConfig.MaxHeapSizeBytes).To(Equal(uint64(268435456)))
+			Expect(bootstrap.OverloadManager.Actions).To(HaveLen(2))
+			Expect(bootstrap.OverloadManager.Actions[0].Triggers[0].GetThreshold().Value).To(Equal(0.9))
+			Expect(bootstrap.OverloadManager.Actions[1].Triggers[0].GetThreshold().Value).To(Equal(0.95))
+		})
+
+		It("creates envoy config without the overload manager when no maximum heap size is configured", func() {
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).ToNot(ContainSubstring("overload_manager"))
+		})
+
 		It("Creates bootstrap config for the Envoy proxy", func() {
 			wh := &mutatingWebhook{
 				kubeClient:          fake.NewSimpleClientset(),
 				kubeController:      k8s.NewMockController(gomock.NewController(GinkgoT())),
 				nonInjectNamespaces: mapset.NewSet(),
+				configurator:        mockConfigurator,
 			}
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
 			name := uuid.New().String()
 			namespace := "a"
 			osmNamespace := "b"
 
-			secret, err := wh.createEnvoyBootstrapConfig(name, namespace, osmNamespace, cert, healthProbes{})
+			secret, err := wh.createEnvoyBootstrapConfig(name, namespace, osmNamespace, "bookbuyer", "bookbuyer.a", "osm-controller.b.svc.cluster.local", 15128, cert, healthProbes{}, 0, "v1.17.0")
 			Expect(err).ToNot(HaveOccurred())
 
 			expected := corev1.Secret{
@@ -207,6 +418,136 @@ static_resources:
 			// Now check the entire struct
 			Expect(*secret).To(Equal(expected))
 		})
+
+		It("Creates bootstrap config mounting the configured CA bundle in the Envoy proxy", func() {
+			caBundle := "-----BEGIN CERTIFICATE-----\ninternal-ca\n-----END CERTIFICATE-----\n"
+			wh := &mutatingWebhook{
+				config: Config{
+					SidecarCABundleConfigMap: "internal-ca",
+				},
+				kubeClient: fake.NewSimpleClientset(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "internal-ca",
+						Namespace: "b",
+					},
+					Data: map[string]string{
+						caBundleKey: caBundle,
+					},
+				}),
+				kubeController:      k8s.NewMockController(gomock.NewController(GinkgoT())),
+				nonInjectNamespaces: mapset.NewSet(),
+				configurator:        mockConfigurator,
+			}
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			name := uuid.New().String()
+
+			secret, err := wh.createEnvoyBootstrapConfig(name, "a", "b", "bookbuyer", "bookbuyer.a", "osm-controller.b.svc.cluster.local", 15128, cert, healthProbes{}, 0, "v1.17.0")
+			Expect(err).ToNot(HaveOccurred())
+
+			// The CA bundle is stored next to the bootstrap config in the secret mounted in the Envoy sidecar
+			Expect(string(secret.Data[caBundleKey])).To(Equal(caBundle))
+			Expect(getVolumeSpec(name)[0].Secret.SecretName).To(Equal(secret.Name))
+
+			// The bootstrap config references the mounted CA bundle in a static secret, and still trusts the mesh CA
+			// for the xDS connection
+			Expect(string(secret.Data[envoyBootstrapConfigFile])).To(ContainSubstring(`  secrets:
+  - name: external-ca-bundle
+    validation_context:
+      trusted_ca:
+        filename: /etc/envoy/ca-bundle.pem
+`))
+			Expect(string(secret.Data[envoyBootstrapConfigFile])).To(ContainSubstring("inline_bytes: " + config.RootCert))
+		})
+
+		It("Fails to create bootstrap config when the configured CA bundle is missing", func() {
+			wh := &mutatingWebhook{
+				config: Config{
+					SidecarCABundleConfigMap: "internal-ca",
+				},
+				kubeClient: fake.NewSimpleClientset(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "internal-ca",
+						Namespace: "b",
+					},
+				}),
+				configurator: mockConfigurator,
+			}
+
+			_, err := wh.createEnvoyBootstrapConfig(uuid.New().String(), "a", "b", "bookbuyer", "bookbuyer.a", "osm-controller.b.svc.cluster.local", 15128, cert, healthProbes{}, 0, "v1.17.0")
+			Expect(errors.Is(err, errCABundleNotFound)).To(BeTrue())
+
+			wh.config.SidecarCABundleConfigMap = "does-not-exist"
+			_, err = wh.createEnvoyBootstrapConfig(uuid.New().String(), "a", "b", "bookbuyer", "bookbuyer.a", "osm-controller.b.svc.cluster.local", 15128, cert, healthProbes{}, 0, "v1.17.0")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Creates bootstrap config for the Envoy proxy from the configured template", func() {
+			wh := &mutatingWebhook{
+				config: Config{
+					BootstrapTemplate: "node: {{.EnvoyNodeID}}/{{.EnvoyClusterID}}\nxds: {{.XDSHost}}:{{.XDSPort}}\n",
+				},
+				kubeClient:          fake.NewSimpleClientset(),
+				kubeController:      k8s.NewMockController(gomock.NewController(GinkgoT())),
+				nonInjectNamespaces: mapset.NewSet(),
+				configurator:        mockConfigurator,
+			}
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+
+			secret, err := wh.createEnvoyBootstrapConfig(uuid.New().String(), "a", "b", "bookbuyer", "bookbuyer.a", "osm-controller.b.svc.cluster.local", 15128, cert, healthProbes{}, 0, "v1.17.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(secret.Data[envoyBootstrapConfigFile])).To(Equal("node: bookbuyer/bookbuyer.a\nxds: osm-controller.b.svc.cluster.local:15128\n"))
+		})
+
+		It("Creates bootstrap config from the configured template limiting the connections of the inbound listener", func() {
+			wh := &mutatingWebhook{
+				config: Config{
+					BootstrapTemplate: testBootstrapTemplate,
+				},
+				kubeClient:          fake.NewSimpleClientset(),
+				kubeController:      k8s.NewMockController(gomock.NewController(GinkgoT())),
+				nonInjectNamespaces: mapset.NewSet(),
+				configurator:        mockConfigurator,
+			}
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+
+			secret, err := wh.createEnvoyBootstrapConfig(uuid.New().String(), "a", "b", "bookbuyer", "bookbuyer.a", "osm-controller.b.svc.cluster.local", 15128, cert, healthProbes{}, 100, "v1.17.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(secret.Data[envoyBootstrapConfigFile])).To(ContainSubstring(`layered_runtime:
+  layers:
+  - name: static_layer
+    static_layer:
+      envoy.resource_limits.listener.inbound-listener.connection_limit: 100
+`))
+		})
+
+		It("Creates bootstrap config from the configured template not limiting the connections of the inbound listener by default", func() {
+			wh := &mutatingWebhook{
+				config: Config{
+					BootstrapTemplate: testBootstrapTemplate,
+				},
+				kubeClient:          fake.NewSimpleClientset(),
+				kubeController:      k8s.NewMockController(gomock.NewController(GinkgoT())),
+				nonInjectNamespaces: mapset.NewSet(),
+				configurator:        mockConfigurator,
+			}
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+
+			secret, err := wh.createEnvoyBootstrapConfig(uuid.New().String(), "a", "b", "bookbuyer", "bookbuyer.a", "osm-controller.b.svc.cluster.local", 15128, cert, healthProbes{}, 0, "v1.17.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(secret.Data[envoyBootstrapConfigFile])).ToNot(ContainSubstring("connection_limit"))
+		})
 	})
 
 	Context("Test getStaticResources()", func() {
@@ -251,8 +592,8 @@ var _ = Describe("Test Envoy sidecar", func() {
 
 	Context("create Envoy sidecar", func() {
 		It("creates correct Envoy sidecar spec", func() {
-			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("debug").Times(1)
-			actual := getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID, mockConfigurator, healthProbes{})
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			actual := getEnvoySidecarContainerSpec(containerName, sidecarConfig{image: envoyImage, logLevel: "debug"}, nodeID, clusterID, mockConfigurator, healthProbes{})
 
 			expected := corev1.Container{
 				Name:            containerName,
@@ -352,5 +693,30 @@ var _ = Describe("Test Envoy sidecar", func() {
 			}
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("adds a startup probe to the Envoy sidecar when configured", func() {
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(30)).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbePeriodSeconds().Return(int32(5)).Times(1)
+			actual := getEnvoySidecarContainerSpec(containerName, sidecarConfig{image: envoyImage, logLevel: "debug"}, nodeID, clusterID, mockConfigurator, healthProbes{})
+
+			expected := &corev1.Probe{
+				Handler: corev1.Handler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: "/ready",
+						Port: intstr.FromInt(constants.EnvoyAdminPort),
+					},
+				},
+				PeriodSeconds:    5,
+				FailureThreshold: 30,
+			}
+			Expect(actual.StartupProbe).To(Equal(expected))
+		})
+
+		It("passes the configured number of worker threads to the Envoy sidecar", func() {
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			actual := getEnvoySidecarContainerSpec(containerName, sidecarConfig{image: envoyImage, logLevel: "debug", concurrency: 1}, nodeID, clusterID, mockConfigurator, healthProbes{})
+
+			Expect(actual.Args[len(actual.Args)-2:]).To(Equal([]string{"--concurrency", "1"}))
+		})
 	})
 })
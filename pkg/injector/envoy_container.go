@@ -1,9 +1,11 @@
 package injector
 
 import (
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -13,12 +15,15 @@ import (
 const (
 	envoyBootstrapConfigFile = "bootstrap.yaml"
 	envoyProxyConfigPath     = "/etc/envoy"
+
+	// envoyReadyPath is the path on Envoy's admin interface which reports whether the proxy is ready to serve traffic
+	envoyReadyPath = "/ready"
 )
 
-func getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID string, cfg configurator.Configurator, originalHealthProbes healthProbes) corev1.Container {
+func getEnvoySidecarContainerSpec(containerName string, sidecarCfg sidecarConfig, nodeID, clusterID string, cfg configurator.Configurator, originalHealthProbes healthProbes) corev1.Container {
 	return corev1.Container{
 		Name:            containerName,
-		Image:           envoyImage,
+		Image:           sidecarCfg.image,
 		ImagePullPolicy: corev1.PullAlways,
 		SecurityContext: &corev1.SecurityContext{
 			RunAsUser: func() *int64 {
@@ -26,20 +31,16 @@ func getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID s
 				return &uid
 			}(),
 		},
-		Ports: getEnvoyContainerPorts(originalHealthProbes),
+		Resources:    sidecarCfg.resources,
+		Ports:        getEnvoyContainerPorts(originalHealthProbes),
+		StartupProbe: getEnvoyStartupProbe(cfg),
 		VolumeMounts: []corev1.VolumeMount{{
 			Name:      envoyBootstrapConfigVolume,
 			ReadOnly:  true,
 			MountPath: envoyProxyConfigPath,
 		}},
 		Command: []string{"envoy"},
-		Args: []string{
-			"--log-level", cfg.GetEnvoyLogLevel(),
-			"--config-path", strings.Join([]string{envoyProxyConfigPath, envoyBootstrapConfigFile}, "/"),
-			"--service-node", envoy.GetEnvoyServiceNodeID(nodeID),
-			"--service-cluster", clusterID,
-			"--bootstrap-version 3",
-		},
+		Args:    getEnvoySidecarArgs(sidecarCfg, nodeID, clusterID),
 		Env: []corev1.EnvVar{
 			{
 				Name: "POD_UID",
@@ -77,6 +78,43 @@ func getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID s
 	}
 }
 
+// getEnvoySidecarArgs returns the command line arguments of the Envoy sidecar. The number of worker threads is only
+// passed to Envoy when configured, leaving Envoy to default to one worker thread per hardware thread otherwise.
+func getEnvoySidecarArgs(sidecarCfg sidecarConfig, nodeID, clusterID string) []string {
+	args := []string{
+		"--log-level", sidecarCfg.logLevel,
+		"--config-path", strings.Join([]string{envoyProxyConfigPath, envoyBootstrapConfigFile}, "/"),
+		"--service-node", envoy.GetEnvoyServiceNodeID(nodeID),
+		"--service-cluster", clusterID,
+		"--bootstrap-version 3",
+	}
+	if sidecarCfg.concurrency > 0 {
+		args = append(args, "--concurrency", strconv.Itoa(sidecarCfg.concurrency))
+	}
+	return args
+}
+
+// getEnvoyStartupProbe returns a startup probe checking Envoy's readiness on the admin port. Kubernetes does not run
+// the liveness and readiness probes until the startup probe succeeds, which gives a slow starting Envoy time to
+// receive its configuration. A nil probe is returned when the startup probe failure threshold is not configured.
+func getEnvoyStartupProbe(cfg configurator.Configurator) *corev1.Probe {
+	failureThreshold := cfg.GetEnvoyStartupProbeFailureThreshold()
+	if failureThreshold == 0 {
+		return nil
+	}
+
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: envoyReadyPath,
+				Port: intstr.FromInt(constants.EnvoyAdminPort),
+			},
+		},
+		PeriodSeconds:    cfg.GetEnvoyStartupProbePeriodSeconds(),
+		FailureThreshold: failureThreshold,
+	}
+}
+
 func getEnvoyContainerPorts(originalHealthProbes healthProbes) []corev1.ContainerPort {
 	containerPorts := []corev1.ContainerPort{
 		{
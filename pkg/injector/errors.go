@@ -6,4 +6,7 @@ var (
 	errNamespaceNotFound   = errors.New("namespace not found")
 	errParseWebhookTimeout = errors.New("could not read webhook timeout")
 	errNilAdmissionRequest = errors.New("nil admission request")
+
+	errBootstrapCertTimeout = errors.New("timed out issuing bootstrap certificate")
+	errCABundleNotFound     = errors.New("CA bundle not found")
 )
@@ -0,0 +1,59 @@
+package injector
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ValidateExtraSidecars returns an error if an additional sidecar of the given config has no name or image,
+// or if its name is the name of another injected container.
+func ValidateExtraSidecars(config Config) error {
+	names := map[string]bool{
+		config.getSidecarContainerName(): true,
+		config.getInitContainerName():    true,
+	}
+	for _, sidecar := range config.ExtraSidecars {
+		if sidecar.Container.Name == "" {
+			return errors.New("Additional sidecar container name must not be empty")
+		}
+		if sidecar.Container.Image == "" {
+			return errors.Errorf("Additional sidecar container %s has no image", sidecar.Container.Name)
+		}
+		if names[sidecar.Container.Name] {
+			return errors.Errorf("Additional sidecar container name %s is already used by another injected container", sidecar.Container.Name)
+		}
+		names[sidecar.Container.Name] = true
+	}
+	return nil
+}
+
+// addExtraSidecars adds the given sidecar containers and their volumes to the pod
+func addExtraSidecars(pod *corev1.Pod, sidecars []ExtraSidecar) {
+	for _, sidecar := range sidecars {
+		pod.Spec.Containers = append(pod.Spec.Containers, *sidecar.Container.DeepCopy())
+		for _, volume := range sidecar.Volumes {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, *volume.DeepCopy())
+		}
+	}
+}
+
+// validatePodForExtraSidecars returns an error if a container of the pod is named like one of the given additional
+// sidecars, which could not be injected into the pod
+func validatePodForExtraSidecars(pod *corev1.Pod, sidecars []ExtraSidecar) error {
+	for _, sidecar := range sidecars {
+		if hasContainer(pod.Spec.Containers, sidecar.Container.Name) || hasContainer(pod.Spec.InitContainers, sidecar.Container.Name) {
+			return errors.Errorf("Pod container name %s conflicts with the name of an additional sidecar container", sidecar.Container.Name)
+		}
+	}
+	return nil
+}
+
+// hasContainer returns true if a container with the given name is in the given list of containers
+func hasContainer(containers []corev1.Container, name string) bool {
+	for _, container := range containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateExtraSidecars(t *testing.T) {
+	assert := tassert.New(t)
+
+	testCases := []struct {
+		name          string
+		sidecars      []ExtraSidecar
+		expectedError bool
+	}{
+		{
+			name:          "no additional sidecars",
+			sidecars:      nil,
+			expectedError: false,
+		},
+		{
+			name: "valid additional sidecars",
+			sidecars: []ExtraSidecar{
+				{Enabled: true, Container: corev1.Container{Name: "telemetry-agent", Image: "telemetry/agent:v1"}},
+				{Enabled: false, Container: corev1.Container{Name: "log-agent", Image: "log/agent:v1"}},
+			},
+			expectedError: false,
+		},
+		{
+			name:          "sidecar without a name",
+			sidecars:      []ExtraSidecar{{Container: corev1.Container{Image: "telemetry/agent:v1"}}},
+			expectedError: true,
+		},
+		{
+			name:          "sidecar without an image",
+			sidecars:      []ExtraSidecar{{Container: corev1.Container{Name: "telemetry-agent"}}},
+			expectedError: true,
+		},
+		{
+			name:          "sidecar named like the Envoy sidecar",
+			sidecars:      []ExtraSidecar{{Container: corev1.Container{Name: "envoy", Image: "telemetry/agent:v1"}}},
+			expectedError: true,
+		},
+		{
+			name: "sidecars with the same name",
+			sidecars: []ExtraSidecar{
+				{Container: corev1.Container{Name: "telemetry-agent", Image: "telemetry/agent:v1"}},
+				{Container: corev1.Container{Name: "telemetry-agent", Image: "telemetry/agent:v2"}},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateExtraSidecars(Config{ExtraSidecars: tc.sidecars})
+			assert.Equal(tc.expectedError, err != nil)
+		})
+	}
+}
+
+func TestValidatePodForExtraSidecars(t *testing.T) {
+	assert := tassert.New(t)
+
+	sidecars := []ExtraSidecar{{Enabled: true, Container: corev1.Container{Name: "telemetry-agent", Image: "telemetry/agent:v1"}}}
+
+	testCases := []struct {
+		name           string
+		containers     []corev1.Container
+		initContainers []corev1.Container
+		expectedError  bool
+	}{
+		{
+			name:          "no conflicting container",
+			containers:    []corev1.Container{{Name: "app"}},
+			expectedError: false,
+		},
+		{
+			name:          "container named like an additional sidecar",
+			containers:    []corev1.Container{{Name: "app"}, {Name: "telemetry-agent"}},
+			expectedError: true,
+		},
+		{
+			name:           "init container named like an additional sidecar",
+			containers:     []corev1.Container{{Name: "app"}},
+			initContainers: []corev1.Container{{Name: "telemetry-agent"}},
+			expectedError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: tc.containers, InitContainers: tc.initContainers}}
+			err := validatePodForExtraSidecars(pod, sidecars)
+			assert.Equal(tc.expectedError, err != nil)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package injector
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// ValidateInjectionLabelSelector returns an error if the label selector of the given config cannot be parsed
+func ValidateInjectionLabelSelector(config Config) error {
+	if _, err := labels.Parse(config.InjectionLabelSelector); err != nil {
+		return errors.Errorf("Invalid label selector %q: %s", config.InjectionLabelSelector, err)
+	}
+	return nil
+}
+
+// getInjectionLabelSelector returns the label selector the pods of the given namespace must match to be injected. The
+// selector of the namespace's annotation overrides the selector of the config. The returned selector matches all pods
+// when neither is set.
+func (c Config) getInjectionLabelSelector(ns *corev1.Namespace) (labels.Selector, error) {
+	if selector, ok := ns.Annotations[constants.SidecarInjectionSelectorAnnotation]; ok {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, errors.Errorf("Invalid label selector %q of annotation %s on namespace %s: %s", selector, constants.SidecarInjectionSelectorAnnotation, ns.Name, err)
+		}
+		return parsed, nil
+	}
+	return labels.Parse(c.InjectionLabelSelector)
+}
@@ -0,0 +1,15 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestValidateInjectionLabelSelector(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Nil(ValidateInjectionLabelSelector(Config{}))
+	assert.Nil(ValidateInjectionLabelSelector(Config{InjectionLabelSelector: "app in (bookstore,bookbuyer),!legacy"}))
+	assert.NotNil(ValidateInjectionLabelSelector(Config{InjectionLabelSelector: "app in bookstore"}))
+}
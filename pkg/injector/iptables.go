@@ -66,8 +66,23 @@ var iptablesInboundStaticRules = []string{
 	"iptables -t nat -A PROXY_INBOUND -p tcp -j PROXY_IN_REDIRECT",
 }
 
-// generateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection
-func generateIptablesCommands(outboundIPRangeExclusionList []string) []string {
+// iptablesInboundUDPStaticRules is the list of iptables rules related to inbound UDP traffic interception and
+// redirection, used when the inbound HTTP/3 (QUIC) listener is enabled. The REDIRECT target rewrites the destination
+// port of the packets, so the QUIC listener cannot tell which service port they were sent to.
+var iptablesInboundUDPStaticRules = []string{
+	// Redirects inbound UDP traffic hitting the PROXY_IN_REDIRECT chain to Envoy's inbound QUIC listener port
+	fmt.Sprintf("iptables -t nat -A PROXY_IN_REDIRECT -p udp -j REDIRECT --to-port %d", constants.EnvoyInboundListenerPort),
+
+	// For inbound UDP traffic jump from PREROUTING chain to PROXY_INBOUND chain
+	"iptables -t nat -A PREROUTING -p udp -j PROXY_INBOUND",
+
+	// Redirect remaining inbound UDP traffic to Envoy
+	"iptables -t nat -A PROXY_INBOUND -p udp -j PROXY_IN_REDIRECT",
+}
+
+// generateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection.
+// Inbound UDP traffic is only redirected when inboundUDPRedirectionEnabled is set.
+func generateIptablesCommands(outboundIPRangeExclusionList []string, inboundPortExclusionList []int, inboundUDPRedirectionEnabled bool) []string {
 	var cmd []string
 
 	// 1. Create redirection chains
@@ -78,6 +93,9 @@ func generateIptablesCommands(outboundIPRangeExclusionList []string) []string {
 
 	// 3. Create inbound rules
 	cmd = append(cmd, iptablesInboundStaticRules...)
+	if inboundUDPRedirectionEnabled {
+		cmd = append(cmd, iptablesInboundUDPStaticRules...)
+	}
 
 	// 4. Create dynamic outbound exclusion rules
 	for _, cidr := range outboundIPRangeExclusionList {
@@ -87,5 +105,16 @@ func generateIptablesCommands(outboundIPRangeExclusionList []string) []string {
 		cmd = append(cmd, rule)
 	}
 
+	// 5. Create dynamic inbound exclusion rules
+	for _, port := range inboundPortExclusionList {
+		// Inserted for the same reason as the outbound exclusion rules, so that traffic to the port is not redirected
+		rule := fmt.Sprintf("iptables -t nat -I PROXY_INBOUND -p tcp --dport %d -j RETURN", port)
+		cmd = append(cmd, rule)
+		if inboundUDPRedirectionEnabled {
+			rule = fmt.Sprintf("iptables -t nat -I PROXY_INBOUND -p udp --dport %d -j RETURN", port)
+			cmd = append(cmd, rule)
+		}
+	}
+
 	return cmd
 }
@@ -0,0 +1,39 @@
+package injector
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+)
+
+// nativeSidecarMinVersion is the minimum Kubernetes version enabling native sidecars, init containers with an
+// 'Always' restart policy, by default
+var nativeSidecarMinVersion = version.MustParseGeneric("1.29.0")
+
+// isNativeSidecarSupported returns true if the Kubernetes version of the cluster supports native sidecars
+func isNativeSidecarSupported(discoveryClient discovery.ServerVersionInterface) (bool, error) {
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return false, errors.Wrap(err, "error getting the Kubernetes server version")
+	}
+	parsedVersion, err := version.ParseGeneric(serverVersion.GitVersion)
+	if err != nil {
+		return false, errors.Wrapf(err, "error parsing the Kubernetes server version %s", serverVersion.GitVersion)
+	}
+	return parsedVersion.AtLeast(nativeSidecarMinVersion), nil
+}
+
+// getNativeSidecarPatch returns the patch setting the 'Always' restart policy of the init container at the given index,
+// which runs it as a native sidecar starting before and stopping after the app containers.
+// The restart policy is patched separately as the container API of the vendored Kubernetes client predates native sidecars.
+func getNativeSidecarPatch(initContainerIndex int) jsonpatch.JsonPatchOperation {
+	return jsonpatch.JsonPatchOperation{
+		Operation: "add",
+		Path:      fmt.Sprintf("/spec/initContainers/%d/restartPolicy", initContainerIndex),
+		Value:     corev1.RestartPolicyAlways,
+	}
+}
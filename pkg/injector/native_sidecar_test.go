@@ -0,0 +1,53 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsNativeSidecarSupported(t *testing.T) {
+	testCases := []struct {
+		name          string
+		gitVersion    string
+		expected      bool
+		expectedError bool
+	}{
+		{
+			name:       "cluster without native sidecars",
+			gitVersion: "v1.28.4",
+			expected:   false,
+		},
+		{
+			name:       "cluster with native sidecars",
+			gitVersion: "v1.29.0",
+			expected:   true,
+		},
+		{
+			name:       "managed cluster with native sidecars",
+			gitVersion: "v1.30.2-eks-1552ad0",
+			expected:   true,
+		},
+		{
+			name:          "invalid server version",
+			gitVersion:    "unknown",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			client := fake.NewSimpleClientset()
+			client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: tc.gitVersion}
+
+			supported, err := isNativeSidecarSupported(client.Discovery())
+			assert.Equal(tc.expectedError, err != nil)
+			assert.Equal(tc.expected, supported)
+		})
+	}
+}
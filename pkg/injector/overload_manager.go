@@ -0,0 +1,88 @@
+package injector
+
+import (
+	goversion "github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+)
+
+const (
+	// fixedHeapResourceMonitor is the name of the Envoy resource monitor tracking the heap size against a fixed maximum
+	fixedHeapResourceMonitor = "envoy.resource_monitors.fixed_heap"
+
+	// fixedHeapConfigTypeURL is the type URL of the config of the fixed heap resource monitor
+	fixedHeapConfigTypeURL = "type.googleapis.com/envoy.extensions.resource_monitors.fixed_heap.v3.FixedHeapConfig"
+
+	// fixedHeapConfigV2alphaTypeURL is the type URL of the config of the fixed heap resource monitor understood by the
+	// Envoy versions preceding minFixedHeapConfigV3EnvoyVersion
+	fixedHeapConfigV2alphaTypeURL = "type.googleapis.com/envoy.config.resource_monitor.fixed_heap.v2alpha.FixedHeapConfig"
+
+	// overloadManagerRefreshInterval is the interval at which the overload manager samples the heap size
+	overloadManagerRefreshInterval = "0.25s"
+)
+
+// minFixedHeapConfigV3EnvoyVersion is the earliest Envoy version with the v3 config of the fixed heap resource monitor
+var minFixedHeapConfigV3EnvoyVersion = goversion.Must(goversion.NewVersion("1.18.0"))
+
+// ValidateOverloadManagerConfig returns an error if the overload manager of the given config is enabled with a heap
+// threshold that is not a ratio of the maximum heap size, or with the sidecar shrinking its heap only after it stopped
+// accepting requests
+func ValidateOverloadManagerConfig(config Config) error {
+	if config.SidecarMaxHeapSizeBytes == 0 {
+		return nil
+	}
+	if config.SidecarShrinkHeapThreshold <= 0 || config.SidecarShrinkHeapThreshold > 1 {
+		return errors.Errorf("Invalid sidecar shrink heap threshold %v, must be greater than 0 and at most 1", config.SidecarShrinkHeapThreshold)
+	}
+	if config.SidecarStopAcceptingRequestsThreshold <= 0 || config.SidecarStopAcceptingRequestsThreshold > 1 {
+		return errors.Errorf("Invalid sidecar stop accepting requests threshold %v, must be greater than 0 and at most 1", config.SidecarStopAcceptingRequestsThreshold)
+	}
+	if config.SidecarShrinkHeapThreshold > config.SidecarStopAcceptingRequestsThreshold {
+		return errors.Errorf("Invalid sidecar shrink heap threshold %v, must be at most the stop accepting requests threshold %v", config.SidecarShrinkHeapThreshold, config.SidecarStopAcceptingRequestsThreshold)
+	}
+	return nil
+}
+
+// getOverloadManager returns the Envoy overload manager config degrading the sidecar as its heap grows towards the
+// maximum heap size of the given config, instead of the sidecar getting OOM-killed. The sidecar first returns unused
+// memory to the system, then stops accepting new requests when the heap gets closer to its maximum.
+func getOverloadManager(config envoyBootstrapConfigMeta) map[string]interface{} {
+	getHeapAction := func(name string, threshold float64) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"triggers": []map[string]interface{}{
+				{
+					"name": fixedHeapResourceMonitor,
+					"threshold": map[string]interface{}{
+						"value": threshold,
+					},
+				},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"refresh_interval": overloadManagerRefreshInterval,
+		"resource_monitors": []map[string]interface{}{
+			{
+				"name": fixedHeapResourceMonitor,
+				"typed_config": map[string]interface{}{
+					"@type":               getFixedHeapConfigTypeURL(config.EnvoyVersion),
+					"max_heap_size_bytes": config.MaxHeapSizeBytes,
+				},
+			},
+		},
+		"actions": []map[string]interface{}{
+			getHeapAction("envoy.overload_actions.shrink_heap", config.ShrinkHeapThreshold),
+			getHeapAction("envoy.overload_actions.stop_accepting_requests", config.StopAcceptingRequestsThreshold),
+		},
+	}
+}
+
+// getFixedHeapConfigTypeURL returns the type URL of the config of the fixed heap resource monitor understood by the
+// given Envoy version. The v3 config is used unless the version is known to precede it, e.g. for an image digest.
+func getFixedHeapConfigTypeURL(envoyVersion string) string {
+	if v, err := goversion.NewVersion(envoyVersion); err == nil && v.LessThan(minFixedHeapConfigV3EnvoyVersion) {
+		return fixedHeapConfigV2alphaTypeURL
+	}
+	return fixedHeapConfigTypeURL
+}
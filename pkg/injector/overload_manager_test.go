@@ -0,0 +1,27 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestValidateOverloadManagerConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Nil(ValidateOverloadManagerConfig(Config{}))
+	assert.Nil(ValidateOverloadManagerConfig(Config{SidecarMaxHeapSizeBytes: 1 << 30, SidecarShrinkHeapThreshold: 0.95, SidecarStopAcceptingRequestsThreshold: 0.98}))
+	assert.NotNil(ValidateOverloadManagerConfig(Config{SidecarMaxHeapSizeBytes: 1 << 30, SidecarShrinkHeapThreshold: 0, SidecarStopAcceptingRequestsThreshold: 0.98}))
+	assert.NotNil(ValidateOverloadManagerConfig(Config{SidecarMaxHeapSizeBytes: 1 << 30, SidecarShrinkHeapThreshold: 0.95, SidecarStopAcceptingRequestsThreshold: 1.5}))
+	assert.NotNil(ValidateOverloadManagerConfig(Config{SidecarMaxHeapSizeBytes: 1 << 30, SidecarShrinkHeapThreshold: 0.98, SidecarStopAcceptingRequestsThreshold: 0.95}))
+}
+
+func TestGetFixedHeapConfigTypeURL(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Equal(fixedHeapConfigV2alphaTypeURL, getFixedHeapConfigTypeURL("v1.17.0"))
+	assert.Equal(fixedHeapConfigV2alphaTypeURL, getFixedHeapConfigTypeURL("1.16.2"))
+	assert.Equal(fixedHeapConfigTypeURL, getFixedHeapConfigTypeURL("v1.18.3"))
+	assert.Equal(fixedHeapConfigTypeURL, getFixedHeapConfigTypeURL("latest"))
+	assert.Equal(fixedHeapConfigTypeURL, getFixedHeapConfigTypeURL("sha256:6f0b5d8a91c4ea9d2b1fbb5d9e5b8e2e3c56a7f3d4a1b9e0c2d7f8a6b5c4d3e2"))
+}
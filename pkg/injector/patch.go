@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/google/uuid"
 	"gomodules.xyz/jsonpatch/v2"
@@ -12,34 +11,58 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
-	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
-	"github.com/openservicemesh/osm/pkg/metricsstore"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *v1beta1.AdmissionRequest, proxyUUID uuid.UUID) ([]byte, error) {
 	namespace := req.Namespace
 
-	// Issue a certificate for the proxy sidecar - used for Envoy to connect to XDS (not Envoy-to-Envoy connections)
-	cn := catalog.NewCertCommonNameWithProxyID(proxyUUID, pod.Spec.ServiceAccountName, namespace)
+	// Get a certificate for the proxy sidecar - used for Envoy to connect to XDS (not Envoy-to-Envoy connections).
+	// A pre-issued certificate identifies the proxy with the UUID of its CN.
+	sa := service.K8sServiceAccount{Name: pod.Spec.ServiceAccountName, Namespace: namespace}
+	bootstrapCert, err := wh.getBootstrapCert(sa, proxyUUID)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting bootstrap certificate for Envoy on pod with service account %s", sa)
+		return nil, err
+	}
+	proxyUUID = bootstrapCert.proxyUUID
+	bootstrapCertificate := bootstrapCert.cert
+	cn := bootstrapCertificate.GetCommonName()
 	log.Debug().Msgf("Patching POD spec: service-account=%s, namespace=%s with certificate CN=%s", pod.Spec.ServiceAccountName, namespace, cn)
-	startTime := time.Now()
-	bootstrapCertificate, err := wh.certManager.IssueCertificate(cn, constants.XDSCertificateValidityPeriod)
+
+	xdsHost, xdsPort, err := getXDSAddress(pod, wh.osmNamespace)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting xDS server address of pod %s/%s", namespace, pod.Name)
+		return nil, err
+	}
+
+	inboundConnectionLimit, err := getInboundConnectionLimit(pod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting inbound connection limit of pod %s/%s", namespace, pod.Name)
+		return nil, err
+	}
+
+	// The Envoy sidecar is configured with the namespace and pod overrides of the global defaults
+	sidecarCfg, err := wh.getSidecarConfig(pod, namespace)
 	if err != nil {
-		log.Error().Err(err).Msgf("Error issuing bootstrap certificate for Envoy with CN=%s", cn)
+		log.Error().Err(err).Msgf("Error determining sidecar config for pod %s/%s", namespace, pod.Name)
 		return nil, err
 	}
-	elapsed := time.Since(startTime)
 
-	metricsstore.DefaultMetricsStore.CertXdsIssuedCount.Inc()
-	metricsstore.DefaultMetricsStore.CertXdsIssuedTime.
-		WithLabelValues().Observe(elapsed.Seconds())
 	originalHealthProbes := rewriteHealthProbes(pod)
 
+	// envoyNodeID and envoyClusterID are required for Envoy proxy to start.
+	envoyNodeID := pod.Spec.ServiceAccountName
+
+	// envoyCluster ID will be used as an identifier to the tracing sink
+	envoyClusterID := fmt.Sprintf("%s.%s", pod.Spec.ServiceAccountName, namespace)
+
 	wh.meshCatalog.ExpectProxy(cn)
 	// Create the bootstrap configuration for the Envoy proxy for the given pod
 	envoyBootstrapConfigName := fmt.Sprintf("envoy-bootstrap-config-%s", proxyUUID)
-	if _, err = wh.createEnvoyBootstrapConfig(envoyBootstrapConfigName, namespace, wh.osmNamespace, bootstrapCertificate, originalHealthProbes); err != nil {
+	if _, err = wh.createEnvoyBootstrapConfig(envoyBootstrapConfigName, namespace, wh.osmNamespace, envoyNodeID, envoyClusterID, xdsHost, xdsPort, bootstrapCertificate, originalHealthProbes, inboundConnectionLimit, getImageVersion(sidecarCfg.image)); err != nil {
 		log.Error().Err(err).Msg("Failed to create bootstrap config for Envoy sidecar")
 		return nil, err
 	}
@@ -47,19 +70,62 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *v1beta1.AdmissionRe
 	// Create volume for envoy TLS secret
 	pod.Spec.Volumes = append(pod.Spec.Volumes, getVolumeSpec(envoyBootstrapConfigName)...)
 
-	// Add the Init Container
-	initContainer := getInitContainerSpec(constants.InitContainerName, wh.config.InitContainerImage, wh.configurator.GetOutboundIPRangeExclusionList())
+	// Add the Init Container, excluding the inbound ports set by the pod's annotation from interception
+	inboundPortExclusionList, err := k8s.GetInboundPortExclusionList(pod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting inbound port exclusion list of pod %s/%s", namespace, pod.Name)
+		return nil, err
+	}
+	if _, err = k8s.GetInboundPlaintextProbePorts(pod); err != nil {
+		log.Error().Err(err).Msgf("Error getting inbound plaintext probe ports of pod %s/%s", namespace, pod.Name)
+		return nil, err
+	}
+	initContainer := getInitContainerSpec(wh.config.getInitContainerName(), wh.config.InitContainerImage, wh.configurator.GetOutboundIPRangeExclusionList(), inboundPortExclusionList, wh.configurator.IsInboundHTTP3Enabled())
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
 
-	// envoyNodeID and envoyClusterID are required for Envoy proxy to start.
-	envoyNodeID := pod.Spec.ServiceAccountName
+	// Add the Envoy sidecar
+	sidecar := getEnvoySidecarContainerSpec(wh.config.getSidecarContainerName(), sidecarCfg, envoyNodeID, envoyClusterID, wh.configurator, originalHealthProbes)
+	if sidecar.Env, err = appendSidecarEnvVars(sidecar.Env, wh.configurator.GetEnvoySidecarEnvVars()); err != nil {
+		log.Error().Err(err).Msgf("Error adding configured environment variables to the sidecar of pod %s/%s", namespace, pod.Name)
+		return nil, err
+	}
+	applyTerminationMessageConfig(&sidecar, wh.config)
+	applyDrainConfig(pod, &sidecar, wh.config)
+	applyPostStartHook(&sidecar, wh.config)
+	nativeSidecarIndex := -1
+	if wh.nativeSidecar {
+		// Run the sidecar as a native sidecar, after the init container programming traffic interception
+		nativeSidecarIndex = len(pod.Spec.InitContainers)
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, sidecar)
+	} else {
+		pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	}
 
-	// envoyCluster ID will be used as an identifier to the tracing sink
-	envoyClusterID := fmt.Sprintf("%s.%s", pod.Spec.ServiceAccountName, namespace)
+	// Add the additional sidecars injected together with the Envoy sidecar
+	addExtraSidecars(pod, wh.config.getExtraSidecars())
 
-	// Add the Envoy sidecar
-	sidecar := getEnvoySidecarContainerSpec(constants.EnvoyContainerName, wh.config.SidecarImage, envoyNodeID, envoyClusterID, wh.configurator, originalHealthProbes)
-	pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	// Merge the configured topology spread constraints and affinity into the pod's scheduling
+	if err := applyPodScheduling(pod, wh.config.PodScheduling); err != nil {
+		log.Error().Err(err).Msgf("Error merging the configured scheduling into pod %s/%s", namespace, pod.Name)
+		return nil, err
+	}
+
+	// Keep the pods flagged critical off the spot nodes
+	applySpotNodeAntiAffinity(pod, wh.config.SpotNodeLabels)
+
+	// Gate the pod's readiness on the Envoy sidecar ACKing its initial config
+	if wh.configurator.IsEnvoyReadinessGateEnabled() {
+		pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{
+			ConditionType: constants.EnvoyConfigAckedPodCondition,
+		})
+	}
+
+	// Mark the pod as injected, recording the version of the injected sidecar
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[constants.SidecarInjectedAnnotation] = strconv.FormatBool(true)
+	pod.Annotations[constants.EnvoyVersionAnnotation] = getImageVersion(sidecarCfg.image)
 
 	enableMetrics, err := wh.isMetricsEnabled(namespace)
 	if err != nil {
@@ -67,9 +133,6 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *v1beta1.AdmissionRe
 		return nil, err
 	}
 	if enableMetrics {
-		if pod.Annotations == nil {
-			pod.Annotations = make(map[string]string)
-		}
 		pod.Annotations[constants.PrometheusScrapeAnnotation] = strconv.FormatBool(true)
 		pod.Annotations[constants.PrometheusPortAnnotation] = strconv.Itoa(constants.EnvoyPrometheusInboundListenerPort)
 		pod.Annotations[constants.PrometheusPathAnnotation] = constants.PrometheusScrapePath
@@ -83,7 +146,11 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *v1beta1.AdmissionRe
 	}
 	pod.Labels[constants.EnvoyUniqueIDLabelName] = proxyUUID.String()
 
-	return json.Marshal(makePatches(req, pod))
+	patches := makePatches(req, pod)
+	if nativeSidecarIndex >= 0 {
+		patches = append(patches, getNativeSidecarPatch(nativeSidecarIndex))
+	}
+	return json.Marshal(patches)
 }
 
 func makePatches(req *v1beta1.AdmissionRequest, pod *corev1.Pod) []jsonpatch.JsonPatchOperation {
@@ -95,3 +162,13 @@ func makePatches(req *v1beta1.AdmissionRequest, pod *corev1.Pod) []jsonpatch.Jso
 	admissionResponse := admission.PatchResponseFromRaw(original, current)
 	return admissionResponse.Patches
 }
+
+// getBootstrapCert returns the bootstrap certificate of the Envoy sidecar of a pod running as the given service account.
+// The certificate is taken from the cache of pre-issued certificates if enabled, otherwise it is issued for the proxy
+// with the given UUID.
+func (wh *mutatingWebhook) getBootstrapCert(sa service.K8sServiceAccount, proxyUUID uuid.UUID) (*bootstrapCert, error) {
+	if wh.certCache != nil {
+		return wh.certCache.get(sa)
+	}
+	return issueBootstrapCert(wh.certManager, proxyUUID, sa)
+}
@@ -2,6 +2,8 @@ package injector
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
@@ -10,14 +12,17 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -37,7 +42,7 @@ var _ = Describe("Test all patch operations", func() {
 			mockCtrl := gomock.NewController(GinkgoT())
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 			mockNsController := k8s.NewMockController(mockCtrl)
-			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{})
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
 			testNamespace := &corev1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "default",
@@ -58,7 +63,16 @@ var _ = Describe("Test all patch operations", func() {
 			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
 			pod.Annotations = nil
 			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
 			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
 
 			req := &v1beta1.AdmissionRequest{Namespace: namespace}
 			jsonPatches, err := wh.createPatch(&pod, req, proxyUUID)
@@ -103,4 +117,597 @@ var _ = Describe("Test all patch operations", func() {
 				fmt.Sprintf("Actual: %s", jsonPatches))
 		})
 	})
+	Context("test createPatch() with custom container names", func() {
+		It("uses the configured container names in the patch and the re-injection guard", func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+
+			wh := &mutatingWebhook{
+				config: Config{
+					InitContainerName:    "custom-init",
+					SidecarContainerName: "custom-sidecar",
+				},
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			Expect(wh.isSidecarInjected(&pod)).To(BeFalse())
+
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			Expect(pod.Spec.InitContainers[0].Name).To(Equal("custom-init"))
+			Expect(pod.Spec.Containers[len(pod.Spec.Containers)-1].Name).To(Equal("custom-sidecar"))
+
+			// The pod must be detected as injected using the configured names only
+			Expect(wh.isSidecarInjected(&pod)).To(BeTrue())
+			defaultWh := &mutatingWebhook{}
+			Expect(defaultWh.isSidecarInjected(&pod)).To(BeFalse())
+		})
+	})
+	Context("test createPatch() with the Envoy readiness gate enabled", func() {
+		It("adds a readiness gate for the Envoy config ACK pod condition", func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+
+			wh := &mutatingWebhook{
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(true).Times(1)
+
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Spec.ReadinessGates).To(Equal([]corev1.PodReadinessGate{
+				{ConditionType: constants.EnvoyConfigAckedPodCondition},
+			}))
+		})
+	})
+	Context("test createPatch() with the xDS address annotation", func() {
+		It("points the sidecar bootstrap at the xDS server address of the annotation", func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+
+			wh := &mutatingWebhook{
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.XDSAddressAnnotation: "osm-controller-canary.osm-system.svc.cluster.local:15129"}
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets, err := client.CoreV1().Secrets(namespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secrets.Items).To(HaveLen(1))
+			bootstrap := string(secrets.Items[0].Data[envoyBootstrapConfigFile])
+			Expect(bootstrap).To(ContainSubstring("address: osm-controller-canary.osm-system.svc.cluster.local"))
+			Expect(bootstrap).To(ContainSubstring("port_value: 15129"))
+		})
+
+		It("rejects the pod when the annotation is not a host:port", func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			wh := &mutatingWebhook{
+				kubeClient:          client,
+				kubeController:      k8s.NewMockController(mockCtrl),
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.XDSAddressAnnotation: "osm-controller-canary.osm-system.svc.cluster.local"}
+
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).To(HaveOccurred())
+
+			secrets, err := client.CoreV1().Secrets(namespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secrets.Items).To(BeEmpty())
+		})
+	})
+	Context("test createPatch() marking the pod as injected", func() {
+		newWebhook := func() *mutatingWebhook {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			return &mutatingWebhook{
+				config: Config{
+					SidecarImage: "envoyproxy/envoy-alpine:v1.17.1",
+				},
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+		}
+
+		getPatches := func(wh *mutatingWebhook, pod *corev1.Pod) []jsonpatch.JsonPatchOperation {
+			raw, err := json.Marshal(pod)
+			Expect(err).ToNot(HaveOccurred())
+			req := &v1beta1.AdmissionRequest{Namespace: namespace, Object: runtime.RawExtension{Raw: raw}}
+
+			patchBytes, err := wh.createPatch(pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			var patches []jsonpatch.JsonPatchOperation
+			Expect(json.Unmarshal(patchBytes, &patches)).To(Succeed())
+			return patches
+		}
+
+		It("adds the annotations map when the pod has no annotations", func() {
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = nil
+
+			patches := getPatches(newWebhook(), &pod)
+
+			Expect(patches).To(ContainElement(jsonpatch.JsonPatchOperation{
+				Operation: "add",
+				Path:      "/metadata/annotations",
+				Value: map[string]interface{}{
+					constants.SidecarInjectedAnnotation: "true",
+					constants.EnvoyVersionAnnotation:    "v1.17.1",
+				},
+			}))
+		})
+
+		It("adds the annotations to the existing annotations of the pod", func() {
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{"foo": "bar"}
+
+			patches := getPatches(newWebhook(), &pod)
+
+			Expect(patches).To(ContainElement(jsonpatch.JsonPatchOperation{
+				Operation: "add",
+				Path:      "/metadata/annotations/openservicemesh.io~1sidecar-injected",
+				Value:     "true",
+			}))
+			Expect(patches).To(ContainElement(jsonpatch.JsonPatchOperation{
+				Operation: "add",
+				Path:      "/metadata/annotations/openservicemesh.io~1envoy-version",
+				Value:     "v1.17.1",
+			}))
+			Expect(pod.Annotations).To(HaveKeyWithValue("foo", "bar"))
+		})
+	})
+	Context("test mutate() reinvoked after another webhook added a container", func() {
+		var (
+			wh          *mutatingWebhook
+			injectedPod corev1.Pod
+		)
+
+		BeforeEach(func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).AnyTimes()
+			mockNsController.EXPECT().IsMonitoredNamespace(namespace).Return(true).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			wh = &mutatingWebhook{
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			// First invocation injecting the sidecar
+			injectedPod = tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, map[string]string{constants.SidecarInjectionAnnotation: "enabled"})
+			_, err := wh.createPatch(&injectedPod, &v1beta1.AdmissionRequest{Namespace: namespace}, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			// Webhook running after the injector adding a container
+			injectedPod.Spec.Containers = append(injectedPod.Spec.Containers, corev1.Container{Name: "other-webhook-agent", Image: "other/agent:v1"})
+		})
+
+		reinvoke := func(pod corev1.Pod) *v1beta1.AdmissionResponse {
+			raw, err := json.Marshal(pod)
+			Expect(err).ToNot(HaveOccurred())
+			return wh.mutate(&v1beta1.AdmissionRequest{UID: "reinvocation", Namespace: namespace, Object: runtime.RawExtension{Raw: raw}}, proxyUUID)
+		}
+
+		It("does not patch the pod already marked as injected", func() {
+			resp := reinvoke(injectedPod)
+
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patch).To(BeNil())
+		})
+
+		It("does not patch the pod containing the sidecar when the injected annotation was removed", func() {
+			delete(injectedPod.Annotations, constants.SidecarInjectedAnnotation)
+
+			resp := reinvoke(injectedPod)
+
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resp.Patch).To(BeNil())
+		})
+	})
+
+	Context("test createPatch() with additional sidecars", func() {
+		It("injects the enabled additional sidecars together with the Envoy sidecar", func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+
+			telemetrySidecar := ExtraSidecar{
+				Enabled: true,
+				Container: corev1.Container{
+					Name:         "telemetry-agent",
+					Image:        "telemetry/agent:v1",
+					VolumeMounts: []corev1.VolumeMount{{Name: "telemetry-config", MountPath: "/etc/telemetry"}},
+				},
+				Volumes: []corev1.Volume{{Name: "telemetry-config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			}
+			disabledSidecar := ExtraSidecar{
+				Enabled:   false,
+				Container: corev1.Container{Name: "disabled-agent", Image: "disabled/agent:v1"},
+			}
+
+			wh := &mutatingWebhook{
+				config: Config{
+					ExtraSidecars: []ExtraSidecar{telemetrySidecar, disabledSidecar},
+				},
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			numContainers := len(pod.Spec.Containers)
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Spec.Containers).To(HaveLen(numContainers + 2))
+			Expect(pod.Spec.Containers[numContainers].Name).To(Equal(constants.EnvoyContainerName))
+			Expect(pod.Spec.Containers[numContainers+1]).To(Equal(telemetrySidecar.Container))
+			Expect(pod.Spec.Volumes).To(ContainElement(telemetrySidecar.Volumes[0]))
+
+			// The pod is detected as injected by its Envoy sidecar
+			Expect(wh.isSidecarInjected(&pod)).To(BeTrue())
+
+			// A pod whose application container is named like an additional sidecar is not detected as injected
+			conflictingPod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			conflictingPod.Spec.Containers = append(conflictingPod.Spec.Containers, corev1.Container{Name: telemetrySidecar.Container.Name})
+			Expect(wh.isSidecarInjected(&conflictingPod)).To(BeFalse())
+		})
+	})
+	Context("test createPatch() with a sidecar termination message policy", func() {
+		It("applies the configured termination message policy to the injected sidecar", func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			wh := &mutatingWebhook{
+				config: Config{
+					SidecarTerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			sidecar := pod.Spec.Containers[len(pod.Spec.Containers)-1]
+			Expect(sidecar.Name).To(Equal(constants.EnvoyContainerName))
+			Expect(sidecar.TerminationMessagePolicy).To(Equal(corev1.TerminationMessageFallbackToLogsOnError))
+			Expect(sidecar.TerminationMessagePath).To(BeEmpty())
+		})
+	})
+
+	Context("test createPatch() with a sidecar postStart command", func() {
+		It("adds a postStart hook running the configured command to the injected sidecar", func() {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			wh := &mutatingWebhook{
+				config: Config{
+					SidecarPostStartCommand: "curl -s http://127.0.0.1:15000/ready",
+				},
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			sidecar := pod.Spec.Containers[len(pod.Spec.Containers)-1]
+			Expect(sidecar.Name).To(Equal(constants.EnvoyContainerName))
+			Expect(sidecar.Lifecycle).ToNot(BeNil())
+			Expect(sidecar.Lifecycle.PostStart.Exec.Command).To(Equal([]string{"sh", "-c", "curl -s http://127.0.0.1:15000/ready"}))
+		})
+	})
+
+	Context("test createPatch() with native sidecars", func() {
+		// getPatchedPod returns the pod patched by the webhook and the patches
+		getPatchedPod := func(nativeSidecar bool) (*corev1.Pod, []jsonpatch.JsonPatchOperation) {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(2)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).Times(1)
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).Times(1)
+
+			wh := &mutatingWebhook{
+				config: Config{
+					NativeSidecar: true,
+				},
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nativeSidecar:       nativeSidecar,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			raw, err := json.Marshal(pod)
+			Expect(err).ToNot(HaveOccurred())
+			req := &v1beta1.AdmissionRequest{Namespace: namespace, Object: runtime.RawExtension{Raw: raw}}
+
+			patchBytes, err := wh.createPatch(&pod, req, proxyUUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			var patches []jsonpatch.JsonPatchOperation
+			Expect(json.Unmarshal(patchBytes, &patches)).To(Succeed())
+
+			Expect(wh.isSidecarInjected(&pod)).To(BeTrue())
+			return &pod, patches
+		}
+
+		It("injects the Envoy sidecar as an init container restarted always after the init container", func() {
+			numContainers := len(tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil).Spec.Containers)
+
+			pod, patches := getPatchedPod(true)
+
+			Expect(pod.Spec.Containers).To(HaveLen(numContainers))
+			Expect(pod.Spec.InitContainers).To(HaveLen(2))
+			Expect(pod.Spec.InitContainers[0].Name).To(Equal(constants.InitContainerName))
+			Expect(pod.Spec.InitContainers[1].Name).To(Equal(constants.EnvoyContainerName))
+			Expect(patches[len(patches)-1]).To(Equal(jsonpatch.JsonPatchOperation{
+				Operation: "add",
+				Path:      "/spec/initContainers/1/restartPolicy",
+				Value:     "Always",
+			}))
+		})
+
+		It("falls back to injecting the Envoy sidecar as a regular container when native sidecars are not supported", func() {
+			pod, patches := getPatchedPod(false)
+
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			Expect(pod.Spec.Containers[len(pod.Spec.Containers)-1].Name).To(Equal(constants.EnvoyContainerName))
+			for _, patch := range patches {
+				Expect(patch.Path).ToNot(HaveSuffix("/restartPolicy"))
+			}
+		})
+	})
+
+	Context("test createPatch() with an inbound port exclusion list", func() {
+		// patchPod patches the given pod with the webhook
+		patchPod := func(pod *corev1.Pod) error {
+			client := fake.NewSimpleClientset()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockNsController := k8s.NewMockController(mockCtrl)
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(0).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyStartupProbeFailureThreshold().Return(int32(0)).AnyTimes()
+			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().IsInboundHTTP3Enabled().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().IsDeltaXDSEnabled().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyStatsTags().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyRuntimeFlags().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyStatsSinks().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoySidecarEnvVars().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().IsEnvoyReadinessGateEnabled().Return(false).AnyTimes()
+
+			wh := &mutatingWebhook{
+				kubeClient:          client,
+				kubeController:      mockNsController,
+				certManager:         tresor.NewFakeCertManager(mockConfigurator),
+				meshCatalog:         catalog.NewFakeMeshCatalog(client),
+				configurator:        mockConfigurator,
+				nonInjectNamespaces: mapset.NewSet(),
+			}
+
+			req := &v1beta1.AdmissionRequest{Namespace: namespace}
+			_, err := wh.createPatch(pod, req, proxyUUID)
+			return err
+		}
+
+		It("excludes the metrics port of the pod from the inbound redirection", func() {
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.InboundPortExclusionListAnnotation: "9090"}
+
+			Expect(patchPod(&pod)).To(Succeed())
+
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			Expect(pod.Spec.InitContainers[0].Args[1]).To(HaveSuffix("iptables -t nat -I PROXY_INBOUND -p tcp --dport 9090 -j RETURN"))
+		})
+
+		It("fails the admission of a pod with an invalid inbound port exclusion list", func() {
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.InboundPortExclusionListAnnotation: "9090,metrics"}
+
+			err := patchPod(&pod)
+			Expect(errors.Is(err, k8s.ErrInvalidInboundPortExclusionList)).To(BeTrue())
+			Expect(pod.Spec.InitContainers).To(BeEmpty())
+		})
+
+		It("fails the admission of a pod with invalid inbound plaintext probe ports", func() {
+			pod := tests.NewPodFixture(namespace, podName, tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.InboundPlaintextProbePortsAnnotation: "health"}
+
+			err := patchPod(&pod)
+			Expect(errors.Is(err, k8s.ErrInvalidInboundPlaintextProbePorts)).To(BeTrue())
+			Expect(pod.Spec.InitContainers).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,213 @@
+package injector
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// PodScheduling is the scheduling config merged into the spec of the injected pods, e.g. to co-locate apps with their
+// mesh dependencies
+type PodScheduling struct {
+	// TopologySpreadConstraints are the topology spread constraints added to the injected pods
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Affinity is the node and pod affinity merged into the affinity of the injected pods
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// ValidatePodScheduling returns an error if a topology spread constraint of the given config is invalid, or if two
+// constraints have the same topology key and unsatisfiable constraint handling, which Kubernetes rejects
+func ValidatePodScheduling(config Config) error {
+	seen := make(map[topologySpreadConstraintKey]bool)
+	for _, constraint := range config.PodScheduling.TopologySpreadConstraints {
+		if constraint.TopologyKey == "" {
+			return errors.New("Topology spread constraint must have a topology key")
+		}
+		if constraint.MaxSkew <= 0 {
+			return errors.Errorf("Topology spread constraint on topology key %s must have a positive max skew", constraint.TopologyKey)
+		}
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule && constraint.WhenUnsatisfiable != corev1.ScheduleAnyway {
+			return errors.Errorf("Topology spread constraint on topology key %s must be %s or %s when unsatisfiable", constraint.TopologyKey, corev1.DoNotSchedule, corev1.ScheduleAnyway)
+		}
+		key := getTopologySpreadConstraintKey(constraint)
+		if seen[key] {
+			return errors.Errorf("Duplicate topology spread constraint on topology key %s when %s", constraint.TopologyKey, constraint.WhenUnsatisfiable)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// ValidateSpotNodeLabels returns an error if a spot node label of the given config is not a valid label
+func ValidateSpotNodeLabels(config Config) error {
+	for key, value := range config.SpotNodeLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return errors.Errorf("Invalid spot node label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return errors.Errorf("Invalid value %q of spot node label %s: %s", value, key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// topologySpreadConstraintKey is the pair of fields Kubernetes requires to be unique among the topology spread
+// constraints of a pod
+type topologySpreadConstraintKey struct {
+	topologyKey       string
+	whenUnsatisfiable corev1.UnsatisfiableConstraintAction
+}
+
+func getTopologySpreadConstraintKey(constraint corev1.TopologySpreadConstraint) topologySpreadConstraintKey {
+	return topologySpreadConstraintKey{
+		topologyKey:       constraint.TopologyKey,
+		whenUnsatisfiable: constraint.WhenUnsatisfiable,
+	}
+}
+
+// applyPodScheduling merges the given scheduling config into the spec of the pod without overriding the scheduling the
+// pod declares. The topology spread constraints are added to the pod's constraints, and an error is returned if the pod
+// declares a different constraint for the same topology key and unsatisfiable constraint handling. The affinity terms
+// are added to the pod's terms, so that the pod must satisfy both its own and the configured affinity.
+func applyPodScheduling(pod *corev1.Pod, scheduling PodScheduling) error {
+	for _, constraint := range scheduling.TopologySpreadConstraints {
+		if err := addTopologySpreadConstraint(pod, constraint); err != nil {
+			return err
+		}
+	}
+
+	if scheduling.Affinity == nil {
+		return nil
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	mergeNodeAffinity(pod.Spec.Affinity, scheduling.Affinity.NodeAffinity)
+	mergePodAffinity(pod.Spec.Affinity, scheduling.Affinity.PodAffinity)
+	mergePodAntiAffinity(pod.Spec.Affinity, scheduling.Affinity.PodAntiAffinity)
+	return nil
+}
+
+// applySpotNodeAntiAffinity requires the given pod, if flagged critical by its annotation, to be scheduled on nodes
+// without any of the given spot node labels. The anti-affinity is merged into the node affinity the pod declares, so
+// that the pod must satisfy both.
+func applySpotNodeAntiAffinity(pod *corev1.Pod, spotNodeLabels map[string]string) {
+	if len(spotNodeLabels) == 0 {
+		return
+	}
+	if critical, _ := strconv.ParseBool(pod.Annotations[constants.CriticalPodAnnotation]); !critical {
+		return
+	}
+
+	var keys []string
+	for key := range spotNodeLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var requirements []corev1.NodeSelectorRequirement
+	for _, key := range keys {
+		if value := spotNodeLabels[key]; value != "" {
+			requirements = append(requirements, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: []string{value}})
+		} else {
+			requirements = append(requirements, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpDoesNotExist})
+		}
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	mergeNodeAffinity(pod.Spec.Affinity, &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: requirements}},
+		},
+	})
+}
+
+// addTopologySpreadConstraint adds the given topology spread constraint to the pod, unless the pod already declares it
+func addTopologySpreadConstraint(pod *corev1.Pod, constraint corev1.TopologySpreadConstraint) error {
+	key := getTopologySpreadConstraintKey(constraint)
+	for _, existing := range pod.Spec.TopologySpreadConstraints {
+		if getTopologySpreadConstraintKey(existing) != key {
+			continue
+		}
+		if existing.MaxSkew == constraint.MaxSkew && existing.LabelSelector.String() == constraint.LabelSelector.String() {
+			return nil
+		}
+		return errors.Errorf("Pod %s/%s declares a different topology spread constraint on topology key %s when %s", pod.Namespace, pod.Name, constraint.TopologyKey, constraint.WhenUnsatisfiable)
+	}
+	pod.Spec.TopologySpreadConstraints = append(pod.Spec.TopologySpreadConstraints, *constraint.DeepCopy())
+	return nil
+}
+
+// mergeNodeAffinity merges the given node affinity into the given affinity. The node selector terms of the required
+// node affinity are ORed, so each term of the pod is combined with each configured term for both to be required.
+func mergeNodeAffinity(affinity *corev1.Affinity, nodeAffinity *corev1.NodeAffinity) {
+	if nodeAffinity == nil {
+		return
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	if required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil && len(required.NodeSelectorTerms) > 0 {
+		existing := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		if existing == nil || len(existing.NodeSelectorTerms) == 0 {
+			affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required.DeepCopy()
+		} else {
+			var terms []corev1.NodeSelectorTerm
+			for _, existingTerm := range existing.NodeSelectorTerms {
+				for _, term := range required.NodeSelectorTerms {
+					merged := existingTerm.DeepCopy()
+					merged.MatchExpressions = append(merged.MatchExpressions, term.DeepCopy().MatchExpressions...)
+					merged.MatchFields = append(merged.MatchFields, term.DeepCopy().MatchFields...)
+					terms = append(terms, *merged)
+				}
+			}
+			existing.NodeSelectorTerms = terms
+		}
+	}
+
+	for _, term := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+	}
+}
+
+// mergePodAffinity adds the terms of the given pod affinity to the given affinity
+func mergePodAffinity(affinity *corev1.Affinity, podAffinity *corev1.PodAffinity) {
+	if podAffinity == nil {
+		return
+	}
+	if affinity.PodAffinity == nil {
+		affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+	for _, term := range podAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+	}
+	for _, term := range podAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+	}
+}
+
+// mergePodAntiAffinity adds the terms of the given pod anti-affinity to the given affinity
+func mergePodAntiAffinity(affinity *corev1.Affinity, podAntiAffinity *corev1.PodAntiAffinity) {
+	if podAntiAffinity == nil {
+		return
+	}
+	if affinity.PodAntiAffinity == nil {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	for _, term := range podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+	}
+	for _, term := range podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+	}
+}
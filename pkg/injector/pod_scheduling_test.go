@@ -0,0 +1,266 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestValidatePodScheduling(t *testing.T) {
+	assert := tassert.New(t)
+
+	zoneConstraint := corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway}
+
+	testCases := []struct {
+		name          string
+		constraints   []corev1.TopologySpreadConstraint
+		expectedError bool
+	}{
+		{
+			name:          "no topology spread constraints",
+			constraints:   nil,
+			expectedError: false,
+		},
+		{
+			name: "valid topology spread constraints",
+			constraints: []corev1.TopologySpreadConstraint{
+				zoneConstraint,
+				{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+			},
+			expectedError: false,
+		},
+		{
+			name:          "constraint without a topology key",
+			constraints:   []corev1.TopologySpreadConstraint{{MaxSkew: 1, WhenUnsatisfiable: corev1.ScheduleAnyway}},
+			expectedError: true,
+		},
+		{
+			name:          "constraint without a max skew",
+			constraints:   []corev1.TopologySpreadConstraint{{TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway}},
+			expectedError: true,
+		},
+		{
+			name:          "constraint with an invalid unsatisfiable constraint handling",
+			constraints:   []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: "Ignore"}},
+			expectedError: true,
+		},
+		{
+			name:          "duplicate constraints",
+			constraints:   []corev1.TopologySpreadConstraint{zoneConstraint, zoneConstraint},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePodScheduling(Config{PodScheduling: PodScheduling{TopologySpreadConstraints: tc.constraints}})
+			assert.Equal(tc.expectedError, err != nil)
+		})
+	}
+}
+
+func TestApplyPodSchedulingTopologySpreadConstraints(t *testing.T) {
+	assert := tassert.New(t)
+
+	appSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bookstore"}}
+	zoneConstraint := corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway, LabelSelector: appSelector}
+	hostConstraint := corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: appSelector}
+
+	testCases := []struct {
+		name                string
+		podConstraints      []corev1.TopologySpreadConstraint
+		expectedConstraints []corev1.TopologySpreadConstraint
+		expectedError       bool
+	}{
+		{
+			name:                "pod without constraints",
+			podConstraints:      nil,
+			expectedConstraints: []corev1.TopologySpreadConstraint{zoneConstraint},
+		},
+		{
+			name:                "pod with a constraint on another topology key",
+			podConstraints:      []corev1.TopologySpreadConstraint{hostConstraint},
+			expectedConstraints: []corev1.TopologySpreadConstraint{hostConstraint, zoneConstraint},
+		},
+		{
+			name:                "pod already declaring the constraint",
+			podConstraints:      []corev1.TopologySpreadConstraint{zoneConstraint},
+			expectedConstraints: []corev1.TopologySpreadConstraint{zoneConstraint},
+		},
+		{
+			name: "pod declaring a conflicting constraint",
+			podConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 3, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway, LabelSelector: appSelector},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{TopologySpreadConstraints: tc.podConstraints}}
+
+			err := applyPodScheduling(pod, PodScheduling{TopologySpreadConstraints: []corev1.TopologySpreadConstraint{zoneConstraint}})
+
+			assert.Equal(tc.expectedError, err != nil)
+			if !tc.expectedError {
+				assert.Equal(tc.expectedConstraints, pod.Spec.TopologySpreadConstraints)
+			}
+		})
+	}
+}
+
+func TestApplyPodSchedulingAffinity(t *testing.T) {
+	assert := tassert.New(t)
+
+	linux := corev1.NodeSelectorRequirement{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}}
+	zoneA := corev1.NodeSelectorRequirement{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+	zoneB := corev1.NodeSelectorRequirement{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}}
+	dependencyAffinity := corev1.WeightedPodAffinityTerm{
+		Weight: 100,
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}},
+			TopologyKey:   "topology.kubernetes.io/zone",
+		},
+	}
+
+	scheduling := PodScheduling{
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{linux}}},
+				},
+			},
+			PodAffinity: &corev1.PodAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{dependencyAffinity},
+			},
+		},
+	}
+
+	t.Run("pod without affinity", func(t *testing.T) {
+		pod := &corev1.Pod{}
+
+		assert.Nil(applyPodScheduling(pod, scheduling))
+		assert.Equal(scheduling.Affinity, pod.Spec.Affinity)
+	})
+
+	t.Run("pod with affinity", func(t *testing.T) {
+		pod := &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{zoneA}},
+								{MatchExpressions: []corev1.NodeSelectorRequirement{zoneB}},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		assert.Nil(applyPodScheduling(pod, scheduling))
+
+		// The pod must be scheduled on a linux node in either zone
+		assert.Equal([]corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zoneA, linux}},
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zoneB, linux}},
+		}, pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+		assert.Equal([]corev1.WeightedPodAffinityTerm{dependencyAffinity}, pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+		assert.Nil(pod.Spec.Affinity.PodAntiAffinity)
+
+		// The configured affinity must not be modified by the merge
+		assert.Len(scheduling.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+	})
+}
+
+func TestValidateSpotNodeLabels(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Nil(ValidateSpotNodeLabels(Config{}))
+	assert.Nil(ValidateSpotNodeLabels(Config{SpotNodeLabels: map[string]string{"kubernetes.azure.com/scalesetpriority": "spot", "cloud.google.com/gke-preemptible": ""}}))
+	assert.NotNil(ValidateSpotNodeLabels(Config{SpotNodeLabels: map[string]string{"spot node": "true"}}))
+	assert.NotNil(ValidateSpotNodeLabels(Config{SpotNodeLabels: map[string]string{"capacity-type": "spot/preemptible"}}))
+}
+
+func TestApplySpotNodeAntiAffinity(t *testing.T) {
+	spotNodeLabels := map[string]string{
+		"kubernetes.azure.com/scalesetpriority": "spot",
+		"cloud.google.com/gke-preemptible":      "",
+	}
+	notPreemptible := corev1.NodeSelectorRequirement{Key: "cloud.google.com/gke-preemptible", Operator: corev1.NodeSelectorOpDoesNotExist}
+	notSpot := corev1.NodeSelectorRequirement{Key: "kubernetes.azure.com/scalesetpriority", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"spot"}}
+	linux := corev1.NodeSelectorRequirement{Key: "kubernetes.io/os", Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}}
+	linuxAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{linux}}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		affinity       *corev1.Affinity
+		spotNodeLabels map[string]string
+		expectedTerms  []corev1.NodeSelectorTerm
+	}{
+		{
+			name:           "critical pod",
+			annotations:    map[string]string{constants.CriticalPodAnnotation: "true"},
+			spotNodeLabels: spotNodeLabels,
+			expectedTerms:  []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{notPreemptible, notSpot}}},
+		},
+		{
+			name:           "critical pod with node affinity",
+			annotations:    map[string]string{constants.CriticalPodAnnotation: "true"},
+			affinity:       linuxAffinity,
+			spotNodeLabels: spotNodeLabels,
+			expectedTerms:  []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{linux, notPreemptible, notSpot}}},
+		},
+		{
+			name:           "pod not flagged critical",
+			annotations:    nil,
+			spotNodeLabels: spotNodeLabels,
+			expectedTerms:  nil,
+		},
+		{
+			name:           "pod flagged not critical",
+			annotations:    map[string]string{constants.CriticalPodAnnotation: "false"},
+			affinity:       linuxAffinity,
+			spotNodeLabels: spotNodeLabels,
+			expectedTerms:  []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{linux}}},
+		},
+		{
+			name:           "critical pod without spot node labels configured",
+			annotations:    map[string]string{constants.CriticalPodAnnotation: "true"},
+			spotNodeLabels: nil,
+			expectedTerms:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       corev1.PodSpec{Affinity: tc.affinity.DeepCopy()},
+			}
+
+			applySpotNodeAntiAffinity(pod, tc.spotNodeLabels)
+
+			if tc.expectedTerms == nil {
+				assert.Equal(tc.affinity, pod.Spec.Affinity)
+				return
+			}
+			assert.Equal(tc.expectedTerms, pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package injector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyPostStartHook adds a postStart hook running the command of the given config to the given Envoy sidecar, if
+// configured, keeping the other lifecycle hooks of the sidecar
+func applyPostStartHook(sidecar *corev1.Container, config Config) {
+	if config.SidecarPostStartCommand == "" {
+		return
+	}
+
+	if sidecar.Lifecycle == nil {
+		sidecar.Lifecycle = &corev1.Lifecycle{}
+	}
+	sidecar.Lifecycle.PostStart = &corev1.Handler{
+		Exec: &corev1.ExecAction{
+			Command: []string{"sh", "-c", config.SidecarPostStartCommand},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyPostStartHook(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		config                   Config
+		expectedPostStartCommand []string
+		expectPreStop            bool
+	}{
+		{
+			name:                     "no postStart command",
+			config:                   Config{},
+			expectedPostStartCommand: nil,
+		},
+		{
+			name:                     "postStart command",
+			config:                   Config{SidecarPostStartCommand: "curl -s http://127.0.0.1:15000/ready"},
+			expectedPostStartCommand: []string{"sh", "-c", "curl -s http://127.0.0.1:15000/ready"},
+		},
+		{
+			name: "postStart command with draining on termination",
+			config: Config{
+				SidecarPostStartCommand:    "sleep 1",
+				SidecarDrainOnTermination:  true,
+				SidecarDrainReadinessDelay: 5 * time.Second,
+				SidecarDrainDuration:       20 * time.Second,
+			},
+			expectedPostStartCommand: []string{"sh", "-c", "sleep 1"},
+			expectPreStop:            true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{}
+			sidecar := &corev1.Container{}
+			applyDrainConfig(pod, sidecar, tc.config)
+			applyPostStartHook(sidecar, tc.config)
+
+			if tc.expectedPostStartCommand == nil {
+				assert.Nil(sidecar.Lifecycle)
+				return
+			}
+
+			assert.Equal(tc.expectedPostStartCommand, sidecar.Lifecycle.PostStart.Exec.Command)
+			assert.Equal(tc.expectPreStop, sidecar.Lifecycle.PreStop != nil)
+		})
+	}
+}
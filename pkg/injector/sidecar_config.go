@@ -0,0 +1,131 @@
+package injector
+
+import (
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// sidecarConfig holds the values used to configure the injected Envoy sidecar
+type sidecarConfig struct {
+	image    string
+	logLevel string
+	// concurrency is the number of worker threads of the sidecar, 0 to use Envoy's default
+	concurrency int
+	resources   corev1.ResourceRequirements
+}
+
+// sidecarResourceAnnotations maps the annotations used to override the sidecar's resources to the resource they configure
+var sidecarResourceAnnotations = []struct {
+	annotation   string
+	resourceName corev1.ResourceName
+	isLimit      bool
+}{
+	{constants.SidecarCPURequestAnnotation, corev1.ResourceCPU, false},
+	{constants.SidecarMemoryRequestAnnotation, corev1.ResourceMemory, false},
+	{constants.SidecarCPULimitAnnotation, corev1.ResourceCPU, true},
+	{constants.SidecarMemoryLimitAnnotation, corev1.ResourceMemory, true},
+}
+
+// getSidecarConfig returns the sidecar config for the given pod. Values annotated on the pod take precedence over values
+// annotated on the pod's namespace, which take precedence over the global defaults.
+func (wh *mutatingWebhook) getSidecarConfig(pod *corev1.Pod, namespace string) (sidecarConfig, error) {
+	config := sidecarConfig{
+		image:       wh.config.SidecarImage,
+		logLevel:    wh.configurator.GetEnvoyLogLevel(),
+		concurrency: wh.configurator.GetEnvoyConcurrency(),
+	}
+
+	ns := wh.kubeController.GetNamespace(namespace)
+	if ns == nil {
+		log.Error().Err(errNamespaceNotFound).Msgf("Error retrieving namespace %s", namespace)
+		return config, errNamespaceNotFound
+	}
+
+	if err := config.applyAnnotations(ns.Annotations); err != nil {
+		return config, errors.Wrapf(err, "Invalid sidecar annotation on namespace %s", namespace)
+	}
+	if err := config.applyAnnotations(pod.Annotations); err != nil {
+		return config, errors.Wrapf(err, "Invalid sidecar annotation on pod %s/%s", namespace, pod.Name)
+	}
+
+	return config, nil
+}
+
+// applyAnnotations overrides the sidecar config with the values of the given sidecar annotations
+func (c *sidecarConfig) applyAnnotations(annotations map[string]string) error {
+	if image, ok := annotations[constants.SidecarImageAnnotation]; ok && image != "" {
+		c.image = image
+	}
+
+	if logLevel, ok := annotations[constants.EnvoyLogLevelAnnotation]; ok && logLevel != "" {
+		if !isValidEnvoyLogLevel(logLevel) {
+			return errors.Errorf("Invalid value %q for annotation %s, must be one of %s", logLevel, constants.EnvoyLogLevelAnnotation, strings.Join(configurator.ValidEnvoyLogLevels, ", "))
+		}
+		c.logLevel = logLevel
+	}
+
+	if value, ok := annotations[constants.EnvoyConcurrencyAnnotation]; ok && value != "" {
+		concurrency, err := strconv.Atoi(value)
+		if err != nil || concurrency < 0 {
+			return errors.Errorf("Invalid value %q for annotation %s, must be a non-negative integer", value, constants.EnvoyConcurrencyAnnotation)
+		}
+		c.concurrency = concurrency
+	}
+
+	for _, r := range sidecarResourceAnnotations {
+		value, ok := annotations[r.annotation]
+		if !ok || value == "" {
+			continue
+		}
+
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return errors.Wrapf(err, "Invalid value %q for annotation %s", value, r.annotation)
+		}
+
+		if r.isLimit {
+			if c.resources.Limits == nil {
+				c.resources.Limits = corev1.ResourceList{}
+			}
+			c.resources.Limits[r.resourceName] = quantity
+		} else {
+			if c.resources.Requests == nil {
+				c.resources.Requests = corev1.ResourceList{}
+			}
+			c.resources.Requests[r.resourceName] = quantity
+		}
+	}
+
+	return nil
+}
+
+// isValidEnvoyLogLevel returns whether the given value is a valid Envoy log level
+func isValidEnvoyLogLevel(logLevel string) bool {
+	for _, validLogLevel := range configurator.ValidEnvoyLogLevels {
+		if logLevel == validLogLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// getImageVersion returns the version of the given container image, which is its digest or tag.
+// An image referenced without a tag or digest refers to its 'latest' tag.
+func getImageVersion(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	// A colon preceding the last slash separates the registry host from its port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return "latest"
+}
@@ -0,0 +1,200 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+func TestGetSidecarConfig(t *testing.T) {
+	const namespace = "test-ns"
+
+	testCases := []struct {
+		name                 string
+		globalConcurrency    int
+		namespaceAnnotations map[string]string
+		podAnnotations       map[string]string
+		expectedConfig       sidecarConfig
+		expectedError        bool
+	}{
+		{
+			name: "global defaults are used without overrides",
+			expectedConfig: sidecarConfig{
+				image:    "global-image",
+				logLevel: "error",
+			},
+		},
+		{
+			name: "namespace overrides take precedence over global defaults",
+			namespaceAnnotations: map[string]string{
+				constants.SidecarImageAnnotation:      "ns-image",
+				constants.EnvoyLogLevelAnnotation:     "debug",
+				constants.SidecarCPURequestAnnotation: "100m",
+			},
+			expectedConfig: sidecarConfig{
+				image:    "ns-image",
+				logLevel: "debug",
+				resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			},
+		},
+		{
+			name: "pod overrides take precedence over namespace overrides",
+			namespaceAnnotations: map[string]string{
+				constants.SidecarImageAnnotation:       "ns-image",
+				constants.EnvoyLogLevelAnnotation:      "debug",
+				constants.SidecarCPURequestAnnotation:  "100m",
+				constants.SidecarMemoryLimitAnnotation: "256Mi",
+			},
+			podAnnotations: map[string]string{
+				constants.EnvoyLogLevelAnnotation:     "trace",
+				constants.SidecarCPURequestAnnotation: "200m",
+			},
+			expectedConfig: sidecarConfig{
+				image:    "ns-image",
+				logLevel: "trace",
+				resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				},
+			},
+		},
+		{
+			name:              "global default concurrency is used without overrides",
+			globalConcurrency: 2,
+			expectedConfig: sidecarConfig{
+				image:       "global-image",
+				logLevel:    "error",
+				concurrency: 2,
+			},
+		},
+		{
+			name:              "namespace concurrency takes precedence over the global default",
+			globalConcurrency: 2,
+			namespaceAnnotations: map[string]string{
+				constants.EnvoyConcurrencyAnnotation: "1",
+			},
+			expectedConfig: sidecarConfig{
+				image:       "global-image",
+				logLevel:    "error",
+				concurrency: 1,
+			},
+		},
+		{
+			name:              "pod concurrency takes precedence over the namespace default",
+			globalConcurrency: 2,
+			namespaceAnnotations: map[string]string{
+				constants.EnvoyConcurrencyAnnotation: "1",
+			},
+			podAnnotations: map[string]string{
+				constants.EnvoyConcurrencyAnnotation: "4",
+			},
+			expectedConfig: sidecarConfig{
+				image:       "global-image",
+				logLevel:    "error",
+				concurrency: 4,
+			},
+		},
+		{
+			name: "invalid concurrency",
+			podAnnotations: map[string]string{
+				constants.EnvoyConcurrencyAnnotation: "-1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid log level",
+			podAnnotations: map[string]string{
+				constants.EnvoyLogLevelAnnotation: "verbose",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid log level on the namespace",
+			namespaceAnnotations: map[string]string{
+				constants.EnvoyLogLevelAnnotation: "INFO",
+			},
+			podAnnotations: map[string]string{
+				constants.EnvoyLogLevelAnnotation: "debug",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid resource quantity",
+			podAnnotations: map[string]string{
+				constants.SidecarMemoryRequestAnnotation: "lots",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("error").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(tc.globalConcurrency).Times(1)
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        namespace,
+					Annotations: tc.namespaceAnnotations,
+				},
+			}).Times(1)
+
+			wh := &mutatingWebhook{
+				config:         Config{SidecarImage: "global-image"},
+				configurator:   mockConfigurator,
+				kubeController: mockKubeController,
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   namespace,
+					Annotations: tc.podAnnotations,
+				},
+			}
+
+			actual, err := wh.getSidecarConfig(pod, namespace)
+			if tc.expectedError {
+				assert.NotNil(err)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(tc.expectedConfig, actual)
+		})
+	}
+}
+
+func TestGetImageVersion(t *testing.T) {
+	assert := tassert.New(t)
+
+	testCases := []struct {
+		image           string
+		expectedVersion string
+	}{
+		{"envoyproxy/envoy-alpine:v1.17.1", "v1.17.1"},
+		{"envoyproxy/envoy-alpine", "latest"},
+		{"myregistry.io:5000/envoy", "latest"},
+		{"myregistry.io:5000/envoy:v1.17.1", "v1.17.1"},
+		{"envoyproxy/envoy@sha256:abcdef", "sha256:abcdef"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.image, func(t *testing.T) {
+			assert.Equal(tc.expectedVersion, getImageVersion(tc.image))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package injector
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// fieldRefEnvVarPrefix is the prefix of configured environment variable values sourced from a pod field using the downward API
+const fieldRefEnvVarPrefix = "fieldRef:"
+
+// appendSidecarEnvVars returns the environment variables declared by the sidecar with the given configured environment
+// variables appended, sorted by name. An error is returned if a configured environment variable is already declared.
+func appendSidecarEnvVars(declared []corev1.EnvVar, configured map[string]string) ([]corev1.EnvVar, error) {
+	declaredNames := make(map[string]bool)
+	for _, envVar := range declared {
+		declaredNames[envVar.Name] = true
+	}
+
+	var names []string
+	for name := range configured {
+		if declaredNames[name] {
+			return nil, errors.Errorf("Configured environment variable %s collides with an environment variable declared by the Envoy sidecar", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	envVars := declared
+	for _, name := range names {
+		envVars = append(envVars, getEnvVar(name, configured[name]))
+	}
+	return envVars, nil
+}
+
+// getEnvVar returns the environment variable with the given name for the given configured value
+func getEnvVar(name, value string) corev1.EnvVar {
+	if strings.HasPrefix(value, fieldRefEnvVarPrefix) {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: strings.TrimPrefix(value, fieldRefEnvVarPrefix),
+				},
+			},
+		}
+	}
+
+	return corev1.EnvVar{
+		Name:  name,
+		Value: value,
+	}
+}
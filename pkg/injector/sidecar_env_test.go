@@ -0,0 +1,71 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAppendSidecarEnvVars(t *testing.T) {
+	declared := []corev1.EnvVar{
+		{
+			Name: "POD_NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		configured      map[string]string
+		expectedEnvVars []corev1.EnvVar
+		expectedError   bool
+	}{
+		{
+			name:            "no configured environment variables",
+			expectedEnvVars: declared,
+		},
+		{
+			name: "static and downward API environment variables are appended",
+			configured: map[string]string{
+				"REGION":   "us-east-1",
+				"POD_NAME": "fieldRef:metadata.name",
+			},
+			expectedEnvVars: append(declared,
+				corev1.EnvVar{
+					Name: "POD_NAME",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+					},
+				},
+				corev1.EnvVar{
+					Name:  "REGION",
+					Value: "us-east-1",
+				},
+			),
+		},
+		{
+			name: "environment variable colliding with a declared one",
+			configured: map[string]string{
+				"POD_NAMESPACE": "default",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual, err := appendSidecarEnvVars(declared, tc.configured)
+			if tc.expectedError {
+				assert.NotNil(err)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(tc.expectedEnvVars, actual)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package injector
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ValidateTerminationMessageConfig returns an error if the termination message policy of the given config is not a
+// policy supported by Kubernetes
+func ValidateTerminationMessageConfig(config Config) error {
+	switch config.SidecarTerminationMessagePolicy {
+	case "", corev1.TerminationMessageReadFile, corev1.TerminationMessageFallbackToLogsOnError:
+		return nil
+	default:
+		return errors.Errorf("Invalid sidecar termination message policy %s, must be one of %s, %s",
+			config.SidecarTerminationMessagePolicy, corev1.TerminationMessageReadFile, corev1.TerminationMessageFallbackToLogsOnError)
+	}
+}
+
+// applyTerminationMessageConfig sets the configured termination message path and policy of the given sidecar.
+// The Kubernetes defaults apply to the values not configured.
+func applyTerminationMessageConfig(sidecar *corev1.Container, config Config) {
+	if config.SidecarTerminationMessagePath != "" {
+		sidecar.TerminationMessagePath = config.SidecarTerminationMessagePath
+	}
+	if config.SidecarTerminationMessagePolicy != "" {
+		sidecar.TerminationMessagePolicy = config.SidecarTerminationMessagePolicy
+	}
+}
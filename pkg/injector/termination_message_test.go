@@ -0,0 +1,61 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateTerminationMessageConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Nil(ValidateTerminationMessageConfig(Config{}))
+	assert.Nil(ValidateTerminationMessageConfig(Config{SidecarTerminationMessagePolicy: corev1.TerminationMessageReadFile}))
+	assert.Nil(ValidateTerminationMessageConfig(Config{SidecarTerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError}))
+	assert.NotNil(ValidateTerminationMessageConfig(Config{SidecarTerminationMessagePolicy: "Always"}))
+}
+
+func TestApplyTerminationMessageConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		config         Config
+		expectedPath   string
+		expectedPolicy corev1.TerminationMessagePolicy
+	}{
+		{
+			name:           "Kubernetes defaults",
+			config:         Config{},
+			expectedPath:   "",
+			expectedPolicy: "",
+		},
+		{
+			name: "configured path and policy",
+			config: Config{
+				SidecarTerminationMessagePath:   "/tmp/envoy-termination-log",
+				SidecarTerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			},
+			expectedPath:   "/tmp/envoy-termination-log",
+			expectedPolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+		{
+			name: "configured policy only",
+			config: Config{
+				SidecarTerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			},
+			expectedPath:   "",
+			expectedPolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			sidecar := corev1.Container{Name: "envoy"}
+			applyTerminationMessageConfig(&sidecar, tc.config)
+			assert.Equal(tc.expectedPath, sidecar.TerminationMessagePath)
+			assert.Equal(tc.expectedPolicy, sidecar.TerminationMessagePolicy)
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package injector
 
 import (
+	"time"
+
 	mapset "github.com/deckarep/golang-set"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
@@ -28,6 +33,12 @@ type mutatingWebhook struct {
 	cert           certificate.Certificater
 	configurator   configurator.Configurator
 
+	// nativeSidecar is set when the Envoy sidecar is injected as a native sidecar
+	nativeSidecar bool
+
+	// certCache holds the pre-issued bootstrap certificates of the Envoy sidecars
+	certCache *bootstrapCertCache
+
 	nonInjectNamespaces mapset.Set
 }
 
@@ -42,6 +53,171 @@ type Config struct {
 	InitContainerImage string
 
 	SidecarImage string
+
+	// InitContainerName is the name of the init container that programs traffic interception
+	InitContainerName string
+
+	// SidecarContainerName is the name of the Envoy sidecar container
+	SidecarContainerName string
+
+	// BootstrapTemplate is an optional Go template used to render the Envoy sidecar's bootstrap config.
+	// It is rendered with the per-pod envoyBootstrapConfigMeta values. The default bootstrap config is used when empty.
+	BootstrapTemplate string
+
+	// ServiceAccountAllowList is the list of service account names whose pods may be injected with the sidecar.
+	// Pods running as any service account may be injected when empty.
+	ServiceAccountAllowList []string
+
+	// ServiceAccountDenyList is the list of service account names whose pods must never be injected with the sidecar
+	ServiceAccountDenyList []string
+
+	// OwnerKindAllowList is the list of kinds of controllers, such as ReplicaSet, whose pods may be injected with the sidecar.
+	// Pods without a controller are not injected when set. Pods of any controller and standalone pods may be injected when empty.
+	OwnerKindAllowList []string
+
+	// InjectionLabelSelector is the label selector, e.g. 'app in (bookstore,bookbuyer)', the pods must match to be injected
+	// with the sidecar, unless overridden by the selector annotation of their namespace. All pods match when empty.
+	InjectionLabelSelector string
+
+	// ExtraSidecars are additional sidecar containers, such as telemetry agents, injected together with the Envoy sidecar
+	ExtraSidecars []ExtraSidecar
+
+	// NativeSidecar defines whether the Envoy sidecar is injected as a Kubernetes native sidecar, an init container with
+	// an 'Always' restart policy starting before and stopping after the app containers, when the cluster supports it.
+	// The Envoy sidecar is injected as a regular container otherwise.
+	NativeSidecar bool
+
+	// SidecarTerminationMessagePath is the path of the file the Envoy sidecar's termination message is read from.
+	// The Kubernetes default applies when empty.
+	SidecarTerminationMessagePath string
+
+	// SidecarTerminationMessagePolicy defines how the Envoy sidecar's termination message is populated, such as
+	// FallbackToLogsOnError to capture the last log lines of a crashed sidecar. The Kubernetes default applies when empty.
+	SidecarTerminationMessagePolicy corev1.TerminationMessagePolicy
+
+	// BootstrapCertIssuanceTimeout is how long an admission request waits for the bootstrap certificate of the Envoy
+	// sidecar to be issued when no pre-issued certificate is cached. A default timeout applies when 0.
+	BootstrapCertIssuanceTimeout time.Duration
+
+	// SidecarCABundleConfigMap is the name of a ConfigMap in the OSM namespace holding additional CA certificates, under
+	// the 'ca-bundle.pem' key, that the Envoy sidecar trusts for TLS connections to destinations outside the mesh.
+	// The CA bundle is mounted in the sidecar at injection. No additional CA is trusted when empty.
+	SidecarCABundleConfigMap string
+
+	// SidecarDrainOnTermination defines whether the Envoy sidecar drains gracefully when its pod terminates. The sidecar
+	// fails its readiness first, stops accepting new inbound connections once SidecarDrainReadinessDelay elapses, then
+	// waits for SidecarDrainDuration for in-flight connections to complete before it is stopped.
+	SidecarDrainOnTermination bool
+
+	// SidecarDrainReadinessDelay is how long a terminating Envoy sidecar keeps accepting new inbound connections after
+	// failing its readiness, giving Kubernetes time to remove the pod from the endpoints of its services
+	SidecarDrainReadinessDelay time.Duration
+
+	// SidecarDrainDuration is how long a terminating Envoy sidecar waits for in-flight inbound connections to complete
+	// once it stops accepting new ones
+	SidecarDrainDuration time.Duration
+
+	// SidecarPostStartCommand is the shell command the postStart hook of the Envoy sidecar runs, such as a command
+	// warming up the sidecar before the app containers start. Kubernetes kills the sidecar when the command fails.
+	// No postStart hook is added when empty.
+	SidecarPostStartCommand string
+
+	// PodScheduling is the topology spread constraints and affinity merged into the spec of the injected pods, without
+	// overriding the scheduling the pods declare
+	PodScheduling PodScheduling
+
+	// SpotNodeLabels are the labels, by key, of the preemptible or spot nodes the injected pods flagged critical are kept
+	// off of, in addition to the node affinity the pods declare. A label with an empty value matches nodes with the label
+	// key regardless of its value. Critical pods are scheduled like other pods when empty.
+	SpotNodeLabels map[string]string
+
+	// SidecarMaxHeapSizeBytes is the maximum heap size of the Envoy sidecar, enabling its overload manager which sheds
+	// load as the heap grows towards it instead of the sidecar getting OOM-killed. The overload manager is disabled when 0.
+	SidecarMaxHeapSizeBytes uint64
+
+	// SidecarShrinkHeapThreshold is the ratio of the maximum heap size at which the Envoy sidecar returns unused memory
+	// to the system
+	SidecarShrinkHeapThreshold float64
+
+	// SidecarStopAcceptingRequestsThreshold is the ratio of the maximum heap size at which the Envoy sidecar stops
+	// accepting new requests
+	SidecarStopAcceptingRequestsThreshold float64
+}
+
+// ExtraSidecar is the template of an additional sidecar container injected together with the Envoy sidecar
+type ExtraSidecar struct {
+	// Enabled defines whether the sidecar is injected
+	Enabled bool `json:"enabled"`
+
+	// Container is the spec of the injected container, including its image, resources and volume mounts
+	Container corev1.Container `json:"container"`
+
+	// Volumes are the volumes added to the pod for the container
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+}
+
+// getInitContainerName returns the configured init container name, or the default name if none is configured
+func (c Config) getInitContainerName() string {
+	if c.InitContainerName == "" {
+		return constants.InitContainerName
+	}
+	return c.InitContainerName
+}
+
+// getSidecarContainerName returns the configured sidecar container name, or the default name if none is configured
+func (c Config) getSidecarContainerName() string {
+	if c.SidecarContainerName == "" {
+		return constants.EnvoyContainerName
+	}
+	return c.SidecarContainerName
+}
+
+// getExtraSidecars returns the additional sidecars enabled for injection
+func (c Config) getExtraSidecars() []ExtraSidecar {
+	var enabled []ExtraSidecar
+	for _, sidecar := range c.ExtraSidecars {
+		if sidecar.Enabled {
+			enabled = append(enabled, sidecar)
+		}
+	}
+	return enabled
+}
+
+// isServiceAccountInjectable returns true if the injection policies for service accounts permit injecting
+// the sidecar into pods running as the given service account
+func (c Config) isServiceAccountInjectable(serviceAccount string) bool {
+	for _, denied := range c.ServiceAccountDenyList {
+		if denied == serviceAccount {
+			return false
+		}
+	}
+
+	if len(c.ServiceAccountAllowList) == 0 {
+		return true
+	}
+	for _, allowed := range c.ServiceAccountAllowList {
+		if allowed == serviceAccount {
+			return true
+		}
+	}
+	return false
+}
+
+// isOwnerKindInjectable returns true if the injection policies for controllers permit injecting the sidecar
+// into pods managed by the given controller, which is nil for a standalone pod
+func (c Config) isOwnerKindInjectable(owner *metav1.OwnerReference) bool {
+	if len(c.OwnerKindAllowList) == 0 {
+		return true
+	}
+	if owner == nil {
+		return false
+	}
+	for _, allowed := range c.OwnerKindAllowList {
+		if allowed == owner.Kind {
+			return true
+		}
+	}
+	return false
 }
 
 // EnvoySidecarData is the type used to represent information about the Envoy sidecar
@@ -64,7 +240,26 @@ type envoyBootstrapConfigMeta struct {
 	XDSHost string
 	XDSPort int
 
+	// The Envoy node and cluster identifying the proxy and its service identity
+	EnvoyNodeID    string
+	EnvoyClusterID string
+
 	// The bootstrap Envoy config will be affected by the liveness, readiness, startup probes set on
 	// the pod this Envoy is fronting.
 	OriginalHealthProbes healthProbes
+
+	// Path of the additional CA certificates mounted in the sidecar, empty when no CA bundle is configured
+	CABundlePath string
+
+	// The version of the Envoy sidecar, which is the tag or digest of its image
+	EnvoyVersion string
+
+	// The maximum heap size of the sidecar and the ratios of it at which the overload manager shrinks the heap and stops
+	// accepting requests. The overload manager is disabled when the maximum heap size is 0.
+	MaxHeapSizeBytes               uint64
+	ShrinkHeapThreshold            float64
+	StopAcceptingRequestsThreshold float64
+
+	// The maximum number of concurrent connections accepted by the inbound listener, unlimited when 0
+	InboundConnectionLimit uint32
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
@@ -64,6 +66,15 @@ func NewMutatingWebhook(config Config, kubeClient kubernetes.Interface, certMana
 		return errors.Errorf("Error issuing certificate for the mutating webhook: %+v", err)
 	}
 
+	var nativeSidecar bool
+	if config.NativeSidecar {
+		if nativeSidecar, err = isNativeSidecarSupported(kubeClient.Discovery()); err != nil {
+			log.Error().Err(err).Msg("Error checking if the cluster supports native sidecars, injecting the Envoy sidecar as a regular container")
+		} else if !nativeSidecar {
+			log.Info().Msgf("Kubernetes versions older than %s do not support native sidecars, injecting the Envoy sidecar as a regular container", nativeSidecarMinVersion)
+		}
+	}
+
 	wh := mutatingWebhook{
 		config:         config,
 		kubeClient:     kubeClient,
@@ -73,6 +84,8 @@ func NewMutatingWebhook(config Config, kubeClient kubernetes.Interface, certMana
 		osmNamespace:   osmNamespace,
 		cert:           webhookHandlerCert,
 		configurator:   cfg,
+		nativeSidecar:  nativeSidecar,
+		certCache:      newBootstrapCertCache(certManager, config.BootstrapCertIssuanceTimeout),
 
 		// Envoy sidecars should never be injected in these namespaces
 		nonInjectNamespaces: mapset.NewSetFromSlice([]interface{}{
@@ -85,6 +98,9 @@ func NewMutatingWebhook(config Config, kubeClient kubernetes.Interface, certMana
 	// Start the MutatingWebhook web server
 	go wh.run(stop)
 
+	// Evict the pre-issued bootstrap certificates no longer used
+	go wh.certCache.run(stop)
+
 	// Update the MutatingWebhookConfig with the OSM CA bundle
 	if err = updateMutatingWebhookCABundle(webhookHandlerCert, webhookConfigName, wh.kubeClient); err != nil {
 		return errors.Errorf("Error configuring MutatingWebhookConfiguration %s: %+v", webhookConfigName, err)
@@ -237,6 +253,18 @@ func (wh *mutatingWebhook) mutate(req *v1beta1.AdmissionRequest, proxyUUID uuid.
 		return resp
 	}
 
+	// Never inject the sidecar twice, e.g. when the webhook is reinvoked for the same pod
+	if wh.isSidecarInjected(&pod) {
+		log.Trace().Msgf("Sidecar already injected in pod with UUID %s in namespace %s, skipping injection", proxyUUID, req.Namespace)
+		return resp
+	}
+
+	// Reject pods whose containers are named like an additional sidecar, rather than injecting them without it
+	if err := validatePodForExtraSidecars(&pod, wh.config.getExtraSidecars()); err != nil {
+		log.Error().Err(err).Msgf("Cannot inject additional sidecars into pod with UUID %s in namespace %s", proxyUUID, req.Namespace)
+		return webhook.AdmissionError(err)
+	}
+
 	patchBytes, err := wh.createPatch(&pod, req, proxyUUID)
 	if err != nil {
 		log.Error().Err(err).Msgf("Failed to create patch for pod with UUID %s in namespace %s", proxyUUID, req.Namespace)
@@ -264,11 +292,27 @@ func (wh *mutatingWebhook) isNamespaceInjectable(namespace string) bool {
 //
 // The function returns an error when it is unable to determine whether to perform sidecar injection.
 func (wh *mutatingWebhook) mustInject(pod *corev1.Pod, namespace string) (bool, error) {
+	// Never inject a pod already marked as injected by the injector
+	if injected, _ := strconv.ParseBool(pod.Annotations[constants.SidecarInjectedAnnotation]); injected {
+		log.Trace().Msgf("Mutation request is for pod with UID %s already annotated with %s", pod.ObjectMeta.UID, constants.SidecarInjectedAnnotation)
+		return false, nil
+	}
+
 	if !wh.isNamespaceInjectable(namespace) {
 		log.Warn().Msgf("Mutation request is for pod with UID %s; Injection in Namespace %s is not permitted", pod.ObjectMeta.UID, namespace)
 		return false, nil
 	}
 
+	if !wh.config.isServiceAccountInjectable(pod.Spec.ServiceAccountName) {
+		log.Info().Msgf("Mutation request is for pod with UID %s; Injection for service account %s/%s is not permitted", pod.ObjectMeta.UID, namespace, pod.Spec.ServiceAccountName)
+		return false, nil
+	}
+
+	if owner := metav1.GetControllerOf(pod); !wh.config.isOwnerKindInjectable(owner) {
+		log.Info().Msgf("Mutation request is for pod with UID %s; Injection for pods not owned by a controller of kind %v is not permitted", pod.ObjectMeta.UID, wh.config.OwnerKindAllowList)
+		return false, nil
+	}
+
 	// Check if the pod is annotated for injection
 	podInjectAnnotationExists, podInject, err := isAnnotatedForInjection(pod.Annotations, pod.Kind, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
 	if err != nil {
@@ -288,6 +332,17 @@ func (wh *mutatingWebhook) mustInject(pod *corev1.Pod, namespace string) (bool,
 		return false, err
 	}
 
+	// Check if the pod matches the label selector of the namespace
+	selector, err := wh.config.getInjectionLabelSelector(ns)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error determining the label selector of the pods enabled for sidecar injection in namespace %s", namespace)
+		return false, err
+	}
+	if !selector.Matches(labels.Set(pod.Labels)) {
+		log.Info().Msgf("Mutation request is for pod with UID %s; Injection for pods not matching label selector %q is not permitted", pod.ObjectMeta.UID, selector)
+		return false, nil
+	}
+
 	if podInjectAnnotationExists && podInject {
 		// Pod is explicitly annotated to enable sidecar injection
 		return true, nil
@@ -303,6 +358,21 @@ func (wh *mutatingWebhook) mustInject(pod *corev1.Pod, namespace string) (bool,
 	return false, nil
 }
 
+// isSidecarInjected returns true if the pod already contains the sidecar or the init container added by the injector.
+// The sidecar is an init container when injected as a native sidecar. Additional sidecars are not considered, as they
+// may be named like containers of the application.
+func (wh *mutatingWebhook) isSidecarInjected(pod *corev1.Pod) bool {
+	if hasContainer(pod.Spec.Containers, wh.config.getSidecarContainerName()) {
+		return true
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == wh.config.getInitContainerName() || container.Name == wh.config.getSidecarContainerName() {
+			return true
+		}
+	}
+	return false
+}
+
 func isAnnotatedForInjection(annotations map[string]string, objectKind string, objectName string) (exists bool, enabled bool, err error) {
 	inject := strings.ToLower(annotations[constants.SidecarInjectionAnnotation])
 	log.Trace().Msgf("%s %s has sidecar injection annotation: '%s:%s'", objectKind, objectName, constants.SidecarInjectionAnnotation, inject)
@@ -334,6 +334,243 @@ var _ = Describe("Testing mustInject, isNamespaceInjectable", func() {
 		Expect(inject).To(BeFalse())
 	})
 
+	It("should return false when the pod is already annotated as injected", func() {
+		injectedPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-already-injected",
+				Annotations: map[string]string{
+					constants.SidecarInjectionAnnotation: "enabled",
+					constants.SidecarInjectedAnnotation:  "true",
+				},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: "test-SA",
+			},
+		}
+
+		inject, err := wh.mustInject(injectedPod, namespace)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inject).To(BeFalse())
+	})
+
+	Context("with service account injection policies", func() {
+		var testNamespace *corev1.Namespace
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			testNamespace = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			}
+			pod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod-with-injection-enabled",
+					Annotations: map[string]string{
+						constants.SidecarInjectionAnnotation: "enabled",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "test-SA",
+				},
+			}
+			mockKubeController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
+		})
+
+		It("should return true when the pod's service account is allowed", func() {
+			wh.config.ServiceAccountAllowList = []string{"infra-SA", "test-SA"}
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(testNamespace)
+
+			inject, err := wh.mustInject(pod, namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeTrue())
+		})
+
+		It("should return false when the pod's service account is denied", func() {
+			wh.config.ServiceAccountAllowList = []string{"test-SA"}
+			wh.config.ServiceAccountDenyList = []string{"test-SA"}
+
+			inject, err := wh.mustInject(pod, namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeFalse())
+		})
+
+		It("should return false when the pod's service account is not in a non-empty allowlist", func() {
+			wh.config.ServiceAccountAllowList = []string{"infra-SA"}
+
+			inject, err := wh.mustInject(pod, namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeFalse())
+		})
+
+		It("should return true when the pod's service account is not in the denylist and no allowlist is set", func() {
+			wh.config.ServiceAccountDenyList = []string{"infra-SA"}
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(testNamespace)
+
+			inject, err := wh.mustInject(pod, namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeTrue())
+		})
+	})
+
+	Context("with controller injection policies", func() {
+		var testNamespace *corev1.Namespace
+
+		podOwnedBy := func(kind string) *corev1.Pod {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod-with-injection-enabled",
+					Annotations: map[string]string{
+						constants.SidecarInjectionAnnotation: "enabled",
+					},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "test-SA",
+				},
+			}
+			if kind != "" {
+				isController := true
+				pod.OwnerReferences = []metav1.OwnerReference{{
+					Kind:       kind,
+					Name:       "owner",
+					Controller: &isController,
+				}}
+			}
+			return pod
+		}
+
+		BeforeEach(func() {
+			testNamespace = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			}
+			wh.config.OwnerKindAllowList = []string{"ReplicaSet", "StatefulSet"}
+			mockKubeController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
+		})
+
+		It("should return true when the pod is owned by an allowed controller", func() {
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(testNamespace)
+
+			inject, err := wh.mustInject(podOwnedBy("ReplicaSet"), namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeTrue())
+		})
+
+		It("should return false when the pod is owned by a controller that is not allowed", func() {
+			inject, err := wh.mustInject(podOwnedBy("Job"), namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeFalse())
+		})
+
+		It("should return false when the pod is not owned by a controller", func() {
+			inject, err := wh.mustInject(podOwnedBy(""), namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeFalse())
+		})
+
+		It("should return true when the pod is owned by any controller and no controllers are configured", func() {
+			wh.config.OwnerKindAllowList = nil
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(testNamespace)
+
+			inject, err := wh.mustInject(podOwnedBy("Job"), namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeTrue())
+		})
+	})
+
+	Context("with label selector injection policies", func() {
+		podLabeled := func(labels map[string]string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "pod-with-labels",
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "test-SA",
+				},
+			}
+		}
+
+		namespaceWithSelector := func(selector string) *corev1.Namespace {
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+					Annotations: map[string]string{
+						constants.SidecarInjectionAnnotation: "enabled",
+					},
+				},
+			}
+			if selector != "" {
+				ns.Annotations[constants.SidecarInjectionSelectorAnnotation] = selector
+			}
+			return ns
+		}
+
+		BeforeEach(func() {
+			mockKubeController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
+		})
+
+		It("should return true when the pod matches the label selector of its namespace", func() {
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(namespaceWithSelector("app in (bookstore,bookbuyer)"))
+
+			inject, err := wh.mustInject(podLabeled(map[string]string{"app": "bookstore"}), namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeTrue())
+		})
+
+		It("should return false when the pod does not match the label selector of its namespace", func() {
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(namespaceWithSelector("app in (bookstore,bookbuyer)"))
+
+			inject, err := wh.mustInject(podLabeled(map[string]string{"app": "bookthief"}), namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeFalse())
+		})
+
+		It("should evaluate the configured label selector when the namespace does not set one", func() {
+			wh.config.InjectionLabelSelector = "mesh=enabled"
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(namespaceWithSelector("")).Times(2)
+
+			inject, err := wh.mustInject(podLabeled(map[string]string{"mesh": "enabled"}), namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeTrue())
+
+			mockKubeController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
+			inject, err = wh.mustInject(podLabeled(nil), namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeFalse())
+		})
+
+		It("should return true for any pod when no label selector is set", func() {
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(namespaceWithSelector(""))
+
+			inject, err := wh.mustInject(podLabeled(nil), namespace)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inject).To(BeTrue())
+		})
+
+		It("should return an error when the label selector of the namespace is invalid", func() {
+			mockKubeController.EXPECT().GetNamespace(namespace).Return(namespaceWithSelector("app in bookstore"))
+
+			inject, err := wh.mustInject(podLabeled(map[string]string{"app": "bookstore"}), namespace)
+
+			Expect(err).To(HaveOccurred())
+			Expect(inject).To(BeFalse())
+		})
+	})
+
 	It("should return false when the pod's namespace is not being monitored", func() {
 		testNamespace := &corev1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
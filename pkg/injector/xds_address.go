@@ -0,0 +1,34 @@
+package injector
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// getXDSAddress returns the host and port of the xDS server the sidecar of the given pod connects to. The address set
+// by the pod's xDS address annotation overrides the address of the OSM controller in the given namespace.
+func getXDSAddress(pod *corev1.Pod, osmNamespace string) (string, int, error) {
+	address, ok := pod.Annotations[constants.XDSAddressAnnotation]
+	if !ok {
+		return fmt.Sprintf("%s.%s.svc.cluster.local", constants.OSMControllerName, osmNamespace), constants.OSMControllerPort, nil
+	}
+
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "Invalid value %q for annotation %s, must be host:port", address, constants.XDSAddressAnnotation)
+	}
+	if host == "" {
+		return "", 0, errors.Errorf("Invalid value %q for annotation %s, host must not be empty", address, constants.XDSAddressAnnotation)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, errors.Errorf("Invalid value %q for annotation %s, port must be between 1 and 65535", address, constants.XDSAddressAnnotation)
+	}
+	return host, port, nil
+}
@@ -0,0 +1,72 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetXDSAddress(t *testing.T) {
+	testCases := []struct {
+		name         string
+		annotations  map[string]string
+		expectedHost string
+		expectedPort int
+		expectError  bool
+	}{
+		{
+			name:         "no annotation",
+			annotations:  nil,
+			expectedHost: "osm-controller.osm-system.svc.cluster.local",
+			expectedPort: constants.OSMControllerPort,
+		},
+		{
+			name:         "host name override",
+			annotations:  map[string]string{constants.XDSAddressAnnotation: "osm-controller-canary.osm-system.svc.cluster.local:15129"},
+			expectedHost: "osm-controller-canary.osm-system.svc.cluster.local",
+			expectedPort: 15129,
+		},
+		{
+			name:         "IPv6 override",
+			annotations:  map[string]string{constants.XDSAddressAnnotation: "[fd00::1]:15128"},
+			expectedHost: "fd00::1",
+			expectedPort: 15128,
+		},
+		{
+			name:        "missing port",
+			annotations: map[string]string{constants.XDSAddressAnnotation: "osm-controller-canary.osm-system.svc.cluster.local"},
+			expectError: true,
+		},
+		{
+			name:        "missing host",
+			annotations: map[string]string{constants.XDSAddressAnnotation: ":15128"},
+			expectError: true,
+		},
+		{
+			name:        "invalid port",
+			annotations: map[string]string{constants.XDSAddressAnnotation: "osm-controller-canary:http"},
+			expectError: true,
+		},
+		{
+			name:        "out of range port",
+			annotations: map[string]string{constants.XDSAddressAnnotation: "osm-controller-canary:65536"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			host, port, err := getXDSAddress(pod, "osm-system")
+			assert.Equal(tc.expectError, err != nil)
+			assert.Equal(tc.expectedHost, host)
+			assert.Equal(tc.expectedPort, port)
+		})
+	}
+}
@@ -33,6 +33,7 @@ func NewKubernetesController(kubeClient kubernetes.Interface, meshName string, s
 	client.initServicesMonitor()
 	client.initPodMonitor()
 	client.initEndpointMonitor()
+	client.initNodeMonitor()
 
 	if err := client.run(stop); err != nil {
 		log.Error().Err(err).Msg("Could not start Kubernetes Namespaces client")
@@ -108,6 +109,13 @@ func (c *Client) initEndpointMonitor() {
 	c.informers[Endpoints].AddEventHandler(GetKubernetesEventHandlers((string)(Endpoints), ProviderName, c.shouldObserve, eptEventTypes))
 }
 
+// initNodeMonitor initializes the cache of the nodes, used to look up the locality of the endpoints of services.
+// Node changes do not trigger proxy updates: they are picked up on the next update of the proxies.
+func (c *Client) initNodeMonitor() {
+	informerFactory := informers.NewSharedInformerFactory(c.kubeClient, DefaultKubeEventResyncInterval)
+	c.informers[Nodes] = informerFactory.Core().V1().Nodes().Informer()
+}
+
 func (c *Client) run(stop <-chan struct{}) error {
 	log.Info().Msg("Namespace controller client started")
 	var hasSynced []cache.InformerSynced
@@ -160,6 +168,16 @@ func (c Client) ListMonitoredNamespaces() ([]string, error) {
 	return namespaces, nil
 }
 
+// IsCacheSynced returns whether the caches of the K8s resources have been populated
+func (c Client) IsCacheSynced() bool {
+	select {
+	case <-c.cacheSynced:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetService retrieves the Kubernetes Services resource for the given MeshService
 func (c Client) GetService(svc service.MeshService) *corev1.Service {
 	// client-go cache uses <namespace>/<name> as key
@@ -225,6 +243,15 @@ func (c Client) GetEndpoints(svc service.MeshService) (*corev1.Endpoints, error)
 	return nil, nil
 }
 
+// GetNode returns the node with the given name if found, nil otherwise
+func (c Client) GetNode(name string) *corev1.Node {
+	nodeIf, exists, err := c.informers[Nodes].GetStore().GetByKey(name)
+	if exists && err == nil {
+		return nodeIf.(*corev1.Node)
+	}
+	return nil
+}
+
 // ListServiceAccountsForService lists ServiceAccounts associated with the given service
 func (c Client) ListServiceAccountsForService(svc service.MeshService) ([]service.K8sServiceAccount, error) {
 	var svcAccounts []service.K8sServiceAccount
@@ -95,6 +95,31 @@ var _ = Describe("Test Namespace KubeController Methods", func() {
 		})
 	})
 
+	Context("Testing GetNode", func() {
+		It("should return existing node if it exists", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			stop := make(chan struct{})
+			kubeController, err := NewKubernetesController(kubeClient, testMeshName, stop)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kubeController).ToNot(BeNil())
+
+			testNode := corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-1",
+					Labels: map[string]string{corev1.LabelZoneFailureDomainStable: "us-east-1a"},
+				},
+			}
+
+			nodeCreate, err := kubeClient.CoreV1().Nodes().Create(context.TODO(), &testNode, metav1.CreateOptions{})
+			Expect(err).To(BeNil())
+
+			Eventually(func() *corev1.Node {
+				return kubeController.GetNode(testNode.Name)
+			}, nsInformerSyncTimeout).Should(Equal(nodeCreate))
+			Expect(kubeController.GetNode("node-2")).To(BeNil())
+		})
+	})
+
 	Context("Testing IsMonitoredNamespace", func() {
 		It("should work as expected", func() {
 			// Create namespace controller
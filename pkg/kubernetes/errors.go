@@ -7,4 +7,10 @@ var (
 	errInitInformers     = errors.New("Informer not initialized")
 	errListingNamespaces = errors.New("Failed to list monitored namespaces")
 	errServiceNotFound   = errors.New("Service not found")
+
+	// ErrInvalidInboundPortExclusionList is returned when the inbound port exclusion list of a pod is invalid
+	ErrInvalidInboundPortExclusionList = errors.New("invalid inbound port exclusion list")
+
+	// ErrInvalidInboundPlaintextProbePorts is returned when the inbound plaintext probe ports of a pod are invalid
+	ErrInvalidInboundPlaintextProbePorts = errors.New("invalid inbound plaintext probe ports")
 )
@@ -66,6 +66,12 @@ func GenericEventRecorder() *EventRecorder {
 	return genericEventRecorder
 }
 
+// NewObjectEventRecorder returns an EventRecorder that can be used to post Kubernetes events on objects in any namespace,
+// such as SMI policies
+func NewObjectEventRecorder(kubeClient kubernetes.Interface) record.EventRecorder {
+	return eventRecorder(kubeClient, metav1.NamespaceAll)
+}
+
 // eventRecorder returns an EventRecorder that can be used to post Kubernetes events
 func eventRecorder(kubeClient kubernetes.Interface, namespace string) record.EventRecorder {
 	eventBroadcaster := record.NewBroadcaster()
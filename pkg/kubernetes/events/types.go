@@ -26,6 +26,15 @@ const (
 	CertificateIssuanceFailure = "FatalCertificateIssuanceFailure"
 )
 
+// Kubernetes event reasons for SMI policies
+const (
+	// PolicyAccepted signifies that an SMI policy is valid and enforced
+	PolicyAccepted = "Accepted"
+
+	// PolicyInvalidReference signifies that an SMI policy references resources that do not exist
+	PolicyInvalidReference = "InvalidReference"
+)
+
 // PubSubMessage represents a common messages abstraction to pass through the PubSub interface
 type PubSubMessage struct {
 	AnnouncementType announcements.AnnouncementType
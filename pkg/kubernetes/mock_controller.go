@@ -5,10 +5,12 @@
 package kubernetes
 
 import (
+	reflect "reflect"
+
 	gomock "github.com/golang/mock/gomock"
-	service "github.com/openservicemesh/osm/pkg/service"
 	v1 "k8s.io/api/core/v1"
-	reflect "reflect"
+
+	service "github.com/openservicemesh/osm/pkg/service"
 )
 
 // MockController is a mock of Controller interface
@@ -63,6 +65,20 @@ func (mr *MockControllerMockRecorder) GetNamespace(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockController)(nil).GetNamespace), arg0)
 }
 
+// GetNode mocks base method
+func (m *MockController) GetNode(arg0 string) *v1.Node {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNode", arg0)
+	ret0, _ := ret[0].(*v1.Node)
+	return ret0
+}
+
+// GetNode indicates an expected call of GetNode
+func (mr *MockControllerMockRecorder) GetNode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockController)(nil).GetNode), arg0)
+}
+
 // GetService mocks base method
 func (m *MockController) GetService(arg0 service.MeshService) *v1.Service {
 	m.ctrl.T.Helper()
@@ -77,6 +93,20 @@ func (mr *MockControllerMockRecorder) GetService(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockController)(nil).GetService), arg0)
 }
 
+// IsCacheSynced mocks base method
+func (m *MockController) IsCacheSynced() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCacheSynced")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCacheSynced indicates an expected call of IsCacheSynced
+func (mr *MockControllerMockRecorder) IsCacheSynced() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCacheSynced", reflect.TypeOf((*MockController)(nil).IsCacheSynced))
+}
+
 // IsMonitoredNamespace mocks base method
 func (m *MockController) IsMonitoredNamespace(arg0 string) bool {
 	m.ctrl.T.Helper()
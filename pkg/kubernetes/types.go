@@ -53,6 +53,8 @@ const (
 	Pods InformerKey = "Pods"
 	// Endpoints lookup identifier
 	Endpoints InformerKey = "Endpoints"
+	// Nodes lookup identifier
+	Nodes InformerKey = "Nodes"
 )
 
 // InformerCollection is the type holding the collection of informers we keep
@@ -92,4 +94,10 @@ type Controller interface {
 
 	// GetEndpoints returns the endpoints for a given service, if found
 	GetEndpoints(svc service.MeshService) (*corev1.Endpoints, error)
+
+	// GetNode returns the node with the given name present in cache, or nil if not found
+	GetNode(name string) *corev1.Node
+
+	// IsCacheSynced returns whether the caches of the K8s resources have been populated
+	IsCacheSynced() bool
 }
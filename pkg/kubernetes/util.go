@@ -2,9 +2,13 @@ package kubernetes
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 const (
@@ -77,3 +81,40 @@ func GetAppProtocolFromPortName(portName string) string {
 		return defaultAppProtocol
 	}
 }
+
+// GetInboundPortExclusionList returns the inbound ports of the given pod that are excluded from interception by the
+// sidecar, as set by the pod's annotation. It returns nil if the annotation is not set, and an error if any of its
+// entries is not a valid port.
+func GetInboundPortExclusionList(pod *corev1.Pod) ([]int, error) {
+	return getPortListAnnotation(pod, constants.InboundPortExclusionListAnnotation, ErrInvalidInboundPortExclusionList)
+}
+
+// GetInboundPlaintextProbePorts returns the inbound ports of the given pod the kubelet probes in plaintext, as set by
+// the pod's annotation. It returns nil if the annotation is not set, and an error if any of its entries is not a valid
+// port.
+func GetInboundPlaintextProbePorts(pod *corev1.Pod) ([]int, error) {
+	return getPortListAnnotation(pod, constants.InboundPlaintextProbePortsAnnotation, ErrInvalidInboundPlaintextProbePorts)
+}
+
+// getPortListAnnotation returns the ports of the comma separated list of the given annotation of the given pod, or nil
+// if the annotation is not set. An invalid port returns the given error wrapped with the invalid entry.
+func getPortListAnnotation(pod *corev1.Pod, annotation string, errInvalid error) ([]int, error) {
+	portsStr, ok := pod.Annotations[annotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var ports []int
+	for _, portStr := range strings.Split(portsStr, ",") {
+		portStr = strings.TrimSpace(portStr)
+		if portStr == "" {
+			continue
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, errors.Wrapf(errInvalid, "annotation %s has invalid port %q, must be an integer between 1 and 65535", annotation, portStr)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
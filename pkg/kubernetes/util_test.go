@@ -1,11 +1,13 @@
 package kubernetes
 
 import (
+	"errors"
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -71,4 +73,42 @@ var _ = Describe("Hostnames for a kubernetes service", func() {
 			Expect(GetServiceFromHostname(hostname)).To(Equal(service))
 		})
 	})
+
+	Context("Testing GetInboundPortExclusionList", func() {
+		It("Returns no port when the pod has no inbound port exclusion list", func() {
+			pod := tests.NewPodFixture(tests.Namespace, "pod", tests.BookstoreServiceAccountName, nil)
+			ports, err := GetInboundPortExclusionList(&pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ports).To(BeNil())
+		})
+		It("Returns the excluded ports of the pod", func() {
+			pod := tests.NewPodFixture(tests.Namespace, "pod", tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.InboundPortExclusionListAnnotation: "9090, 15020"}
+			ports, err := GetInboundPortExclusionList(&pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ports).To(Equal([]int{9090, 15020}))
+		})
+		It("Returns an error when a port is invalid", func() {
+			pod := tests.NewPodFixture(tests.Namespace, "pod", tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.InboundPortExclusionListAnnotation: "9090,70000"}
+			_, err := GetInboundPortExclusionList(&pod)
+			Expect(errors.Is(err, ErrInvalidInboundPortExclusionList)).To(BeTrue())
+		})
+	})
+
+	Context("Testing GetInboundPlaintextProbePorts", func() {
+		It("Returns the plaintext probe ports of the pod", func() {
+			pod := tests.NewPodFixture(tests.Namespace, "pod", tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.InboundPlaintextProbePortsAnnotation: "8081"}
+			ports, err := GetInboundPlaintextProbePorts(&pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ports).To(Equal([]int{8081}))
+		})
+		It("Returns an error when a port is invalid", func() {
+			pod := tests.NewPodFixture(tests.Namespace, "pod", tests.BookstoreServiceAccountName, nil)
+			pod.Annotations = map[string]string{constants.InboundPlaintextProbePortsAnnotation: "health"}
+			_, err := GetInboundPlaintextProbePorts(&pod)
+			Expect(errors.Is(err, ErrInvalidInboundPlaintextProbePorts)).To(BeTrue())
+		})
+	})
 })
@@ -45,6 +45,12 @@ type MetricsStore struct {
 	// InjectorRqTime the histogram to track times for the injector webhook calls
 	InjectorRqTime *prometheus.HistogramVec
 
+	// InjectorBootstrapCertCacheLookupCount counts the lookups of pre-issued bootstrap certificates by the injector webhook, per result
+	InjectorBootstrapCertCacheLookupCount *prometheus.CounterVec
+
+	// InjectorBootstrapCertCacheSize is the number of pre-issued bootstrap certificates cached by the injector webhook
+	InjectorBootstrapCertCacheSize prometheus.Gauge
+
 	/*
 	 * Certificate metrics
 	 */
@@ -136,6 +142,24 @@ func init() {
 			"success",
 		})
 
+	defaultMetricsStore.InjectorBootstrapCertCacheLookupCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "injector",
+			Name:      "bootstrap_cert_cache_lookup_count",
+			Help:      "Counts the lookups of pre-issued bootstrap certificates by the injector webhook",
+		},
+		[]string{
+			"result", // hit or miss
+		})
+
+	defaultMetricsStore.InjectorBootstrapCertCacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "injector",
+		Name:      "bootstrap_cert_cache_size",
+		Help:      "Represents the number of pre-issued bootstrap certificates cached by the injector webhook",
+	})
+
 	/*
 	 * Certificate metrics
 	 */
@@ -167,6 +191,8 @@ func (ms *MetricsStore) Start() {
 	ms.registry.MustRegister(ms.ProxyConfigUpdateTime)
 	ms.registry.MustRegister(ms.InjectorSidecarCount)
 	ms.registry.MustRegister(ms.InjectorRqTime)
+	ms.registry.MustRegister(ms.InjectorBootstrapCertCacheLookupCount)
+	ms.registry.MustRegister(ms.InjectorBootstrapCertCacheSize)
 	ms.registry.MustRegister(ms.CertXdsIssuedCount)
 	ms.registry.MustRegister(ms.CertXdsIssuedTime)
 }
@@ -180,6 +206,8 @@ func (ms *MetricsStore) Stop() {
 	ms.registry.Unregister(ms.ProxyConfigUpdateTime)
 	ms.registry.Unregister(ms.InjectorSidecarCount)
 	ms.registry.Unregister(ms.InjectorRqTime)
+	ms.registry.Unregister(ms.InjectorBootstrapCertCacheLookupCount)
+	ms.registry.Unregister(ms.InjectorBootstrapCertCacheSize)
 	ms.registry.Unregister(ms.CertXdsIssuedCount)
 	ms.registry.Unregister(ms.CertXdsIssuedTime)
 }
@@ -0,0 +1,163 @@
+package smi
+
+import (
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+
+	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// CandidatePolicies is a set of proposed SMI policies, e.g. to preview the mesh config they would result in before they
+// are applied to the cluster
+type CandidatePolicies struct {
+	TrafficSplits   []*smiSplit.TrafficSplit
+	HTTPRouteGroups []*smiSpecs.HTTPRouteGroup
+	TCPRoutes       []*smiSpecs.TCPRoute
+	TrafficTargets  []*smiAccess.TrafficTarget
+}
+
+// candidateMeshSpec is a MeshSpec overlaying candidate SMI policies on the policies of a live MeshSpec
+type candidateMeshSpec struct {
+	live MeshSpec
+
+	trafficSplits   []*smiSplit.TrafficSplit
+	httpRouteGroups []*smiSpecs.HTTPRouteGroup
+	tcpRoutes       []*smiSpecs.TCPRoute
+	trafficTargets  []*smiAccess.TrafficTarget
+}
+
+// NewCandidateMeshSpec returns a MeshSpec listing the SMI policies of the given live MeshSpec with the candidate
+// policies applied on top: a candidate policy replaces the live policy of the same kind with the same namespace and
+// name, and is otherwise added to the live policies.
+func NewCandidateMeshSpec(live MeshSpec, candidate CandidatePolicies) MeshSpec {
+	spec := candidateMeshSpec{live: live}
+
+	spec.trafficSplits = live.ListTrafficSplits()
+	for _, trafficSplit := range candidate.TrafficSplits {
+		spec.trafficSplits = overlayTrafficSplit(spec.trafficSplits, trafficSplit)
+	}
+
+	spec.httpRouteGroups = live.ListHTTPTrafficSpecs()
+	for _, routeGroup := range candidate.HTTPRouteGroups {
+		spec.httpRouteGroups = overlayHTTPRouteGroup(spec.httpRouteGroups, routeGroup)
+	}
+
+	spec.tcpRoutes = live.ListTCPTrafficSpecs()
+	for _, tcpRoute := range candidate.TCPRoutes {
+		spec.tcpRoutes = overlayTCPRoute(spec.tcpRoutes, tcpRoute)
+	}
+
+	spec.trafficTargets = live.ListTrafficTargets()
+	for _, trafficTarget := range candidate.TrafficTargets {
+		spec.trafficTargets = overlayTrafficTarget(spec.trafficTargets, trafficTarget)
+	}
+
+	return &spec
+}
+
+func overlayTrafficSplit(trafficSplits []*smiSplit.TrafficSplit, candidate *smiSplit.TrafficSplit) []*smiSplit.TrafficSplit {
+	overlaid := []*smiSplit.TrafficSplit{candidate}
+	for _, trafficSplit := range trafficSplits {
+		if trafficSplit.Namespace != candidate.Namespace || trafficSplit.Name != candidate.Name {
+			overlaid = append(overlaid, trafficSplit)
+		}
+	}
+	return overlaid
+}
+
+func overlayHTTPRouteGroup(routeGroups []*smiSpecs.HTTPRouteGroup, candidate *smiSpecs.HTTPRouteGroup) []*smiSpecs.HTTPRouteGroup {
+	overlaid := []*smiSpecs.HTTPRouteGroup{candidate}
+	for _, routeGroup := range routeGroups {
+		if routeGroup.Namespace != candidate.Namespace || routeGroup.Name != candidate.Name {
+			overlaid = append(overlaid, routeGroup)
+		}
+	}
+	return overlaid
+}
+
+func overlayTCPRoute(tcpRoutes []*smiSpecs.TCPRoute, candidate *smiSpecs.TCPRoute) []*smiSpecs.TCPRoute {
+	overlaid := []*smiSpecs.TCPRoute{candidate}
+	for _, tcpRoute := range tcpRoutes {
+		if tcpRoute.Namespace != candidate.Namespace || tcpRoute.Name != candidate.Name {
+			overlaid = append(overlaid, tcpRoute)
+		}
+	}
+	return overlaid
+}
+
+func overlayTrafficTarget(trafficTargets []*smiAccess.TrafficTarget, candidate *smiAccess.TrafficTarget) []*smiAccess.TrafficTarget {
+	overlaid := []*smiAccess.TrafficTarget{candidate}
+	for _, trafficTarget := range trafficTargets {
+		if trafficTarget.Namespace != candidate.Namespace || trafficTarget.Name != candidate.Name {
+			overlaid = append(overlaid, trafficTarget)
+		}
+	}
+	return overlaid
+}
+
+// ListTrafficSplits lists the live SMI TrafficSplit resources overlaid with the candidate ones
+func (s *candidateMeshSpec) ListTrafficSplits() []*smiSplit.TrafficSplit {
+	return s.trafficSplits
+}
+
+// ListTrafficSplitServices lists WeightedServices for the services specified in the overlaid TrafficSplit resources
+func (s *candidateMeshSpec) ListTrafficSplitServices() []service.WeightedService {
+	var services []service.WeightedService
+	for _, trafficSplit := range s.trafficSplits {
+		for _, backend := range trafficSplit.Spec.Backends {
+			meshService := service.MeshService{
+				Namespace: trafficSplit.Namespace,
+				Name:      backend.Service,
+			}
+			services = append(services, service.WeightedService{Service: meshService, Weight: backend.Weight, RootService: trafficSplit.Spec.Service})
+		}
+	}
+	return services
+}
+
+// ListServiceAccounts lists ServiceAccounts specified in the overlaid TrafficTarget resources
+func (s *candidateMeshSpec) ListServiceAccounts() []service.K8sServiceAccount {
+	var serviceAccounts []service.K8sServiceAccount
+	for _, trafficTarget := range s.trafficTargets {
+		for _, source := range trafficTarget.Spec.Sources {
+			serviceAccounts = append(serviceAccounts, service.K8sServiceAccount{Namespace: source.Namespace, Name: source.Name})
+		}
+		serviceAccounts = append(serviceAccounts, service.K8sServiceAccount{
+			Namespace: trafficTarget.Spec.Destination.Namespace,
+			Name:      trafficTarget.Spec.Destination.Name,
+		})
+	}
+	return serviceAccounts
+}
+
+// ListHTTPTrafficSpecs lists the live SMI HTTPRouteGroup resources overlaid with the candidate ones
+func (s *candidateMeshSpec) ListHTTPTrafficSpecs() []*smiSpecs.HTTPRouteGroup {
+	return s.httpRouteGroups
+}
+
+// ListTCPTrafficSpecs lists the live SMI TCPRoute resources overlaid with the candidate ones
+func (s *candidateMeshSpec) ListTCPTrafficSpecs() []*smiSpecs.TCPRoute {
+	return s.tcpRoutes
+}
+
+// GetTCPRoute returns the overlaid SMI TCPRoute resource given its name of the form <namespace>/<name>
+func (s *candidateMeshSpec) GetTCPRoute(namespacedName string) *smiSpecs.TCPRoute {
+	for _, tcpRoute := range s.tcpRoutes {
+		if tcpRoute.Namespace+"/"+tcpRoute.Name == namespacedName {
+			return tcpRoute
+		}
+	}
+	return nil
+}
+
+// ListTrafficTargets lists the live SMI TrafficTarget resources overlaid with the candidate ones
+func (s *candidateMeshSpec) ListTrafficTargets() []*smiAccess.TrafficTarget {
+	return s.trafficTargets
+}
+
+// GetBackpressurePolicy fetches the live Backpressure policy for the MeshService
+func (s *candidateMeshSpec) GetBackpressurePolicy(svc service.MeshService) *osmPolicy.Backpressure {
+	return s.live.GetBackpressurePolicy(svc)
+}
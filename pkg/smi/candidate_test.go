@@ -0,0 +1,59 @@
+package smi
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+var _ = Describe("Candidate SMI policies overlaid on the live SMI policies", func() {
+	live := NewFakeMeshSpecClient()
+
+	It("lists the live policies when there are no candidate policies", func() {
+		candidate := NewCandidateMeshSpec(live, CandidatePolicies{})
+
+		Expect(candidate.ListTrafficSplits()).To(Equal(live.ListTrafficSplits()))
+		Expect(candidate.ListHTTPTrafficSpecs()).To(Equal(live.ListHTTPTrafficSpecs()))
+		Expect(candidate.ListTCPTrafficSpecs()).To(Equal(live.ListTCPTrafficSpecs()))
+		Expect(candidate.ListTrafficTargets()).To(Equal(live.ListTrafficTargets()))
+		Expect(candidate.ListTrafficSplitServices()).To(ConsistOf(tests.BookstoreV1WeightedService, tests.BookstoreV2WeightedService))
+	})
+
+	It("replaces the live policy with the same namespace and name by the candidate policy", func() {
+		trafficSplit := tests.TrafficSplit.DeepCopy()
+		trafficSplit.Spec.Backends = []smiSplit.TrafficSplitBackend{{Service: tests.BookstoreV2ServiceName, Weight: 100}}
+
+		candidate := NewCandidateMeshSpec(live, CandidatePolicies{TrafficSplits: []*smiSplit.TrafficSplit{trafficSplit}})
+
+		Expect(candidate.ListTrafficSplits()).To(Equal([]*smiSplit.TrafficSplit{trafficSplit}))
+		Expect(candidate.ListTrafficSplitServices()).To(Equal([]service.WeightedService{{
+			Service:     service.MeshService{Namespace: tests.Namespace, Name: tests.BookstoreV2ServiceName},
+			Weight:      100,
+			RootService: tests.BookstoreApexServiceName,
+		}}))
+	})
+
+	It("adds the candidate policies not matching a live policy", func() {
+		trafficTarget := tests.TrafficTarget.DeepCopy()
+		trafficTarget.Name = "bookthief-access-bookstore"
+		trafficTarget.Spec.Sources = []smiAccess.IdentityBindingSubject{{Kind: "ServiceAccount", Name: "bookthief", Namespace: "default"}}
+		tcpRoute := &smiSpecs.TCPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp-route"}}
+
+		candidate := NewCandidateMeshSpec(live, CandidatePolicies{
+			TrafficTargets: []*smiAccess.TrafficTarget{trafficTarget},
+			TCPRoutes:      []*smiSpecs.TCPRoute{tcpRoute},
+		})
+
+		Expect(candidate.ListTrafficTargets()).To(ConsistOf(trafficTarget, &tests.TrafficTarget))
+		Expect(candidate.ListServiceAccounts()).To(ContainElement(service.K8sServiceAccount{Namespace: "default", Name: "bookthief"}))
+		Expect(candidate.ListTCPTrafficSpecs()).To(ContainElement(tcpRoute))
+		Expect(candidate.GetTCPRoute("default/tcp-route")).To(Equal(tcpRoute))
+		Expect(candidate.GetTCPRoute("default/missing")).To(BeNil())
+	})
+})
@@ -4,9 +4,16 @@ import (
 	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	spec "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	fakeAccessClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	fakeSpecClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	fakeSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 
 	backpressure "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	fakePolicyClient "github.com/openservicemesh/osm/experimental/pkg/client/clientset/versioned/fake"
 	"github.com/openservicemesh/osm/pkg/announcements"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -38,6 +45,34 @@ func NewFakeMeshSpecClient() MeshSpec {
 	}
 }
 
+// NewFakeMeshSpecClientFromObjects creates a Mesh Spec used for testing, backed by fake SMI clientsets holding the given
+// TrafficSplit, HTTPRouteGroup, TCPRoute and TrafficTarget objects. Objects of other types are ignored.
+func NewFakeMeshSpecClientFromObjects(kubeClient kubernetes.Interface, kubeController k8s.Controller, osmNamespace string, objects []runtime.Object, stop chan struct{}) (MeshSpec, error) {
+	var splitObjects, specObjects, accessObjects []runtime.Object
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *split.TrafficSplit:
+			splitObjects = append(splitObjects, obj)
+		case *spec.HTTPRouteGroup, *spec.TCPRoute:
+			specObjects = append(specObjects, obj)
+		case *access.TrafficTarget:
+			accessObjects = append(accessObjects, obj)
+		}
+	}
+
+	return newSMIClient(
+		kubeClient,
+		fakeSplitClient.NewSimpleClientset(splitObjects...),
+		fakeSpecClient.NewSimpleClientset(specObjects...),
+		fakeAccessClient.NewSimpleClientset(accessObjects...),
+		fakePolicyClient.NewSimpleClientset(),
+		osmNamespace,
+		kubeController,
+		kubernetesClientName,
+		stop,
+	)
+}
+
 // ListTrafficSplits lists TrafficSplit SMI resources for the fake Mesh Spec.
 func (f fakeMeshSpec) ListTrafficSplits() []*split.TrafficSplit {
 	return f.trafficSplits
@@ -1,6 +1,9 @@
 package trafficpolicy
 
 import (
+	"fmt"
+	"time"
+
 	set "github.com/deckarep/golang-set"
 
 	"github.com/openservicemesh/osm/pkg/identity"
@@ -18,6 +21,115 @@ type HTTPRouteMatch struct {
 	PathRegex string            `json:"path_regex:omitempty"`
 	Methods   []string          `json:"methods:omitempty"`
 	Headers   map[string]string `json:"headers:omitempty"`
+
+	// PrefixRewrite, if set, matches the route by the path prefix and replaces the matched prefix with this value
+	PrefixRewrite string `json:"prefix_rewrite,omitempty"`
+
+	// RegexRewrite, if set, rewrites the part of the path matched by its pattern
+	RegexRewrite *RegexRewrite `json:"regex_rewrite,omitempty"`
+
+	// MaxRequestBytes, if set, overrides the mesh wide limit on the size of requests matching the route, 0 meaning unlimited
+	MaxRequestBytes *uint32 `json:"max_request_bytes,omitempty"`
+
+	// MirrorPolicies, if set, each mirror a percentage of the requests matching the route to a shadow service
+	MirrorPolicies []MirrorPolicy `json:"mirror_policies,omitempty"`
+
+	// StickyCanaryCookie, if set, keeps routing a client to the weighted cluster the client was first routed to, as
+	// recorded by the cookie with this name
+	StickyCanaryCookie string `json:"sticky_canary_cookie,omitempty"`
+
+	// DirectResponse, if set, responds to the requests matching its path regex instead of routing them
+	DirectResponse *DirectResponse `json:"direct_response,omitempty"`
+
+	// HashPolicy, if set, routes the requests matching the route to the endpoints of a consistent hashing cluster by
+	// the hash of the given request attribute
+	HashPolicy *HashPolicy `json:"hash_policy,omitempty"`
+
+	// RetryPolicy, if set, retries the requests matching the route on the conditions of the policy
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+
+	// Timeout, if set, is the time waited for the response to the requests matching the route, overriding the Envoy default
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// AllowedServiceAccounts, if set, are the only service accounts allowed to access the inbound route, whereas all the
+	// sources allowed to connect to the destination can access it otherwise
+	AllowedServiceAccounts set.Set `json:"allowed_service_accounts,omitempty"`
+
+	// AllowedSourceIPRanges, if set, are the source IP CIDR ranges allowed to access the inbound route regardless of the
+	// identity of the sources, in addition to AllowedServiceAccounts
+	AllowedSourceIPRanges []string `json:"allowed_source_ip_ranges,omitempty"`
+}
+
+// ClientAddressDetection is a struct to represent how the proxies of a service determine the address of the
+// downstream client of HTTP requests, used for access logs and RBAC
+type ClientAddressDetection struct {
+	// UseRemoteAddress defines whether the address of the downstream connection is used as the client address, and
+	// appended to the X-Forwarded-For header, instead of the address taken from the X-Forwarded-For header
+	UseRemoteAddress bool `json:"use_remote_address"`
+
+	// XFFNumTrustedHops is the number of trusted proxies, such as an L7 ingress, in front of the proxy whose addresses
+	// are skipped from the end of the X-Forwarded-For header to determine the client address
+	XFFNumTrustedHops uint32 `json:"xff_num_trusted_hops"`
+}
+
+// HTTP1ProtocolOptions is a struct to represent the HTTP/1.1 options of the connections of the proxies of a service to
+// the local application
+type HTTP1ProtocolOptions struct {
+	// ProperCaseHeaders defines whether the header keys of the requests are sent in proper case instead of lower case
+	ProperCaseHeaders bool `json:"proper_case_headers"`
+
+	// KeepaliveTime is the time a connection is idle before TCP keepalive probes are sent, or 0 to not send probes
+	KeepaliveTime time.Duration `json:"keepalive_time,omitempty"`
+}
+
+// HashPolicy is a struct to represent the request attribute hashed to pick the endpoint of a consistent hashing
+// cluster. Requests without the header or cookie are hashed by their source IP.
+type HashPolicy struct {
+	// Header is the name of the header whose value is hashed
+	Header string `json:"header,omitempty"`
+
+	// Cookie is the name of the cookie whose value is hashed
+	Cookie string `json:"cookie,omitempty"`
+}
+
+// RetryPolicy is a struct to represent the conditions on which the requests to a service are retried
+type RetryPolicy struct {
+	// RetryOn is the comma separated list of Envoy retry conditions on which requests are retried
+	RetryOn string `json:"retry_on"`
+
+	// PerTryTimeout is the timeout of each attempt of a request, 0 meaning the attempts are bound by the request timeout
+	PerTryTimeout time.Duration `json:"per_try_timeout,omitempty"`
+
+	// RetriableStatusCodes are the HTTP status codes of the responses on which requests are retried
+	RetriableStatusCodes []uint32 `json:"retriable_status_codes,omitempty"`
+}
+
+// DirectResponse is a struct to represent a fixed response sent by the proxies instead of routing requests, e.g. a
+// maintenance page
+type DirectResponse struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode uint32 `json:"status_code"`
+
+	// Body is the inline body of the response
+	Body string `json:"body,omitempty"`
+
+	// PathRegex is the regex matching the paths of the requests responded to
+	PathRegex string `json:"path_regex"`
+}
+
+// MirrorPolicy is a struct to represent the mirroring of requests to a shadow service, whose responses are ignored
+type MirrorPolicy struct {
+	// Service is the shadow service requests are mirrored to
+	Service service.MeshService `json:"service"`
+
+	// Percentage is the percentage of requests mirrored to the shadow service, between 0 and 100
+	Percentage float64 `json:"percentage"`
+}
+
+// RegexRewrite is a struct to represent the rewrite of the part of a path matched by a regex pattern
+type RegexRewrite struct {
+	Pattern      string `json:"pattern"`
+	Substitution string `json:"substitution"`
 }
 
 // TCPRouteMatch is a struct to represent a TCP route matching based on ports
@@ -33,6 +145,92 @@ type TrafficTarget struct {
 	HTTPRouteMatches []HTTPRouteMatch    `json:"http_route_matches:omitempty"`
 }
 
+// OutlierDetection is a struct to represent the overrides of the outlier detection of the endpoints of an upstream service.
+// A nil field leaves the corresponding parameter to Envoy's default.
+type OutlierDetection struct {
+	// Consecutive5xx is the number of consecutive 5xx responses after which an endpoint is ejected
+	Consecutive5xx *uint32 `json:"consecutive_5xx,omitempty"`
+
+	// MaxEjectionPercent is the maximum percentage of the endpoints of the service that can be ejected
+	MaxEjectionPercent *uint32 `json:"max_ejection_percent,omitempty"`
+
+	// EnforcingConsecutive5xx is the percentage of ejections due to consecutive 5xx responses that are enforced,
+	// 0 meaning endpoints are never ejected due to consecutive 5xx responses
+	EnforcingConsecutive5xx *uint32 `json:"enforcing_consecutive_5xx,omitempty"`
+
+	// SplitExternalLocalOriginErrors is true if the local-origin failures to reach an endpoint, such as connection
+	// failures and timeouts, are counted separately from the 5xx responses of the endpoint. The local-origin failures
+	// are counted as 5xx responses otherwise.
+	SplitExternalLocalOriginErrors bool `json:"split_external_local_origin_errors,omitempty"`
+
+	// ConsecutiveLocalOriginFailure is the number of consecutive local-origin failures after which an endpoint is
+	// ejected, applying only when SplitExternalLocalOriginErrors is true
+	ConsecutiveLocalOriginFailure *uint32 `json:"consecutive_local_origin_failure,omitempty"`
+
+	// EnforcingConsecutiveLocalOriginFailure is the percentage of ejections due to consecutive local-origin failures
+	// that are enforced, applying only when SplitExternalLocalOriginErrors is true
+	EnforcingConsecutiveLocalOriginFailure *uint32 `json:"enforcing_consecutive_local_origin_failure,omitempty"`
+}
+
+// HealthCheck is a struct to represent the active health checking of the endpoints of an upstream service. The
+// endpoints of a TCP service are checked by connecting to them, and optionally exchanging the Send and Receive
+// payloads. The endpoints of other services are checked with HTTP requests to Path.
+type HealthCheck struct {
+	// Interval is the interval between two health checks of an endpoint
+	Interval time.Duration `json:"interval"`
+
+	// TCP is true if the endpoints are checked with TCP health checks
+	TCP bool `json:"tcp,omitempty"`
+
+	// Path is the path requested by the HTTP health checks
+	Path string `json:"path,omitempty"`
+
+	// ExpectedStatuses are the ranges of status codes of the responses to the HTTP health checks considered healthy,
+	// none meaning only 200 is considered healthy
+	ExpectedStatuses []StatusCodeRange `json:"expected_statuses,omitempty"`
+
+	// Send is the hex encoded payload sent by the TCP health checks, none meaning connect-only health checks
+	Send string `json:"send,omitempty"`
+
+	// Receive is the hex encoded payload expected in the responses to the TCP health checks
+	Receive string `json:"receive,omitempty"`
+}
+
+// StatusCodeRange is an inclusive range of HTTP status codes
+type StatusCodeRange struct {
+	// Start is the first status code of the range
+	Start uint32 `json:"start"`
+
+	// End is the last status code of the range
+	End uint32 `json:"end"`
+}
+
+// CircuitBreaking is a struct to represent the circuit breaker thresholds of an upstream service per routing priority.
+// A nil priority leaves the thresholds of that priority to Envoy's defaults.
+type CircuitBreaking struct {
+	// Default is the thresholds of the requests routed at the default priority
+	Default *CircuitBreakerThresholds `json:"default,omitempty"`
+
+	// High is the thresholds of the requests routed at the high priority
+	High *CircuitBreakerThresholds `json:"high,omitempty"`
+}
+
+// CircuitBreakerThresholds is a struct to represent the circuit breaker thresholds of a routing priority.
+// A nil field leaves the corresponding threshold to Envoy's default.
+type CircuitBreakerThresholds struct {
+	// MaxConnections is the maximum number of connections to the upstream service
+	MaxConnections *uint32 `json:"max_connections,omitempty"`
+
+	// MaxPendingRequests is the maximum number of requests queued while waiting for a connection to the upstream service
+	MaxPendingRequests *uint32 `json:"max_pending_requests,omitempty"`
+
+	// MaxRequests is the maximum number of parallel requests to the upstream service
+	MaxRequests *uint32 `json:"max_requests,omitempty"`
+
+	// MaxRetries is the maximum number of parallel retries to the upstream service
+	MaxRetries *uint32 `json:"max_retries,omitempty"`
+}
+
 // RouteWeightedClusters is a struct of an HTTPRoute, associated weighted clusters and the domains
 type RouteWeightedClusters struct {
 	HTTPRouteMatch   HTTPRouteMatch `json:"http_route_match:omitempty"`
@@ -67,3 +265,44 @@ type TrafficTargetWithRoutes struct {
 	Sources         []identity.ServiceIdentity `json:"sources:omitempty"`
 	TCPRouteMatches []TCPRouteMatch            `json:"tcp_route_matches:omitempty"`
 }
+
+// MeshTopology is the graph of the communications allowed in the mesh
+type MeshTopology struct {
+	// Permissive is set in permissive traffic policy mode, where any workload is allowed to communicate with any service
+	// and no edges are listed
+	Permissive bool `json:"permissive"`
+
+	// Edges are the communications allowed by the SMI TrafficTarget policies
+	Edges []MeshTopologyEdge `json:"edges"`
+}
+
+// MeshTopologyNodeKind is the kind of a node of the mesh topology
+type MeshTopologyNodeKind string
+
+const (
+	// MeshTopologyServiceNode is the kind of a node of the mesh topology that is a service
+	MeshTopologyServiceNode MeshTopologyNodeKind = "service"
+
+	// MeshTopologyServiceAccountNode is the kind of a node of the mesh topology that is a service account without services
+	MeshTopologyServiceAccountNode MeshTopologyNodeKind = "service_account"
+)
+
+// MeshTopologyNode is a node of the mesh topology: a service, or a source service account without services, such as
+// the service account of a client workload
+type MeshTopologyNode struct {
+	Kind      MeshTopologyNodeKind `json:"kind"`
+	Namespace string               `json:"namespace"`
+	Name      string               `json:"name"`
+}
+
+// String returns the node in the form <namespace>/<name>
+func (n MeshTopologyNode) String() string {
+	return fmt.Sprintf("%s/%s", n.Namespace, n.Name)
+}
+
+// MeshTopologyEdge is a communication from a source node to a destination service allowed by an SMI TrafficTarget
+type MeshTopologyEdge struct {
+	Source        MeshTopologyNode `json:"source"`
+	Destination   MeshTopologyNode `json:"destination"`
+	TrafficTarget string           `json:"traffic_target"`
+}
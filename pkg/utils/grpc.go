@@ -4,19 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 )
 
 const (
-	maxStreams              = 100000
-	streamKeepAliveDuration = 60 * time.Second
+	maxStreams = 100000
 )
 
-// NewGrpc creates a new gRPC server
-func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte) (*grpc.Server, net.Listener, error) {
+// NewGrpc creates a new gRPC server, pinging and closing connections according to the given keepalive parameters
+func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte, keepaliveParams keepalive.ServerParameters) (*grpc.Server, net.Listener, error) {
 	log.Info().Msgf("Setting up %s gRPC server...", serverType)
 	addr := fmt.Sprintf(":%d", port)
 	lis, err := net.Listen("tcp", addr)
@@ -25,13 +23,11 @@ func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte) (
 		return nil, nil, err
 	}
 
-	log.Debug().Msgf("Parameters for %s gRPC server: MaxConcurrentStreams=%d;  KeepAlive=%+v", serverType, maxStreams, streamKeepAliveDuration)
+	log.Debug().Msgf("Parameters for %s gRPC server: MaxConcurrentStreams=%d;  KeepAlive=%+v", serverType, maxStreams, keepaliveParams)
 
 	grpcOptions := []grpc.ServerOption{
 		grpc.MaxConcurrentStreams(maxStreams),
-		grpc.KeepaliveParams(keepalive.ServerParameters{
-			Time: streamKeepAliveDuration,
-		}),
+		grpc.KeepaliveParams(keepaliveParams),
 	}
 
 	mutualTLS, err := setupMutualTLS(false, serverType, certPem, keyPem, rootCertPem)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 )
@@ -35,7 +36,7 @@ func TestNewGrpc(t *testing.T) {
 	}
 
 	for _, gt := range newGrpcTests {
-		resServer, resListener, err := NewGrpc(gt.serverType, gt.port, gt.certPem, keyPem, rootPem)
+		resServer, resListener, err := NewGrpc(gt.serverType, gt.port, gt.certPem, keyPem, rootPem, keepalive.ServerParameters{})
 		if err != nil {
 			assert.Nil(resServer)
 			assert.Nil(resListener)
@@ -57,7 +58,7 @@ func TestGrpcServe(t *testing.T) {
 
 	serverType := "ADS"
 	port := 9999
-	grpcServer, lis, err := NewGrpc(serverType, port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA())
+	grpcServer, lis, err := NewGrpc(serverType, port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA(), keepalive.ServerParameters{Time: time.Minute})
 	assert.Nil(err)
 
 	ctx, cancel := context.WithCancel(context.Background())
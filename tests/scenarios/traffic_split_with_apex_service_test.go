@@ -2,7 +2,9 @@ package scenarios
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/rds"
@@ -42,7 +45,17 @@ var _ = Describe(``+
 
 			// ---[  Get the config from rds.NewResponse()  ]-------
 
-			actual, err := rds.NewResponse(meshCatalog, proxy, nil, nil, nil)
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetTrafficSplitEmptyBackendMode().Return(constants.DefaultTrafficSplitEmptyBackendMode).AnyTimes()
+			mockConfigurator.EXPECT().GetOutboundRequestTimeout().Return(time.Duration(0)).AnyTimes()
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().IsHTTPMethodStatsEnabled().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().IsUpstreamClusterHeaderEnabled().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetMaxRequestBytes().Return(uint32(0)).AnyTimes()
+			mockConfigurator.EXPECT().IsSourceIPRangeMTLSBypassEnabled().Return(false).AnyTimes()
+
+			actual, err := rds.NewResponse(meshCatalog, proxy, nil, mockConfigurator, nil)
 			It("did not return an error", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(actual).ToNot(BeNil())